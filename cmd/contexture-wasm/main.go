@@ -0,0 +1,43 @@
+//go:build js && wasm
+
+// Command contexture-wasm exposes rule rendering to JavaScript for the browser
+// playground. It registers contexturePreview(content, variables), which parses
+// a rule file and renders it with the JSON-encoded variables, and returns an
+// object with the rule's title, description, and rendered content, or an error.
+package main
+
+import (
+	"encoding/json"
+	"syscall/js"
+
+	"github.com/contextureai/contexture/internal/render"
+)
+
+func main() {
+	js.Global().Set("contexturePreview", js.FuncOf(preview))
+	select {}
+}
+
+// preview implements contexturePreview
+func preview(_ js.Value, args []js.Value) any {
+	if len(args) == 0 {
+		return map[string]any{"error": "rule content is required"}
+	}
+
+	var variables map[string]any
+	if len(args) > 1 && args[1].Type() == js.TypeString && args[1].String() != "" {
+		if err := json.Unmarshal([]byte(args[1].String()), &variables); err != nil {
+			return map[string]any{"error": "invalid variables: " + err.Error()}
+		}
+	}
+
+	rule, content, err := render.Preview(args[0].String(), variables)
+	if err != nil {
+		return map[string]any{"error": err.Error()}
+	}
+	return map[string]any{
+		"title":       rule.Title,
+		"description": rule.Description,
+		"content":     content,
+	}
+}
@@ -18,6 +18,7 @@ func TestGlobalConfigCreateAndLoad(t *testing.T) {
 	// Setup: Create temp home directory
 	tmpHome := t.TempDir()
 	t.Setenv("HOME", tmpHome)
+	t.Setenv(project.XDGConfigHomeEnv, "")
 
 	// Create manager with OS filesystem
 	manager := project.NewManager(afero.NewOsFs())
@@ -27,7 +28,7 @@ func TestGlobalConfigCreateAndLoad(t *testing.T) {
 		require.NoError(t, err)
 
 		// Verify file exists
-		globalPath := filepath.Join(tmpHome, ".contexture", ".contexture.yaml")
+		globalPath := filepath.Join(tmpHome, ".config", "contexture", ".contexture.yaml")
 		require.FileExists(t, globalPath)
 
 		// Load it back
@@ -41,11 +41,56 @@ func (a *CommandActions) BuildAction(ctx context.Context, cmd *cli.Command) erro
 	return commands.BuildAction(ctx, cmd, a.deps)
 }
 
+// ValidateAction provides a testable wrapper for the validate command
+func (a *CommandActions) ValidateAction(ctx context.Context, cmd *cli.Command) error {
+	return commands.ValidateAction(ctx, cmd, a.deps)
+}
+
+// TestAction provides a testable wrapper for the test command
+func (a *CommandActions) TestAction(ctx context.Context, cmd *cli.Command) error {
+	return commands.TestAction(ctx, cmd, a.deps)
+}
+
+// VendorAction provides a testable wrapper for the vendor command
+func (a *CommandActions) VendorAction(ctx context.Context, cmd *cli.Command) error {
+	return commands.VendorAction(ctx, cmd, a.deps)
+}
+
+// ApproveAction provides a testable wrapper for the approve command
+func (a *CommandActions) ApproveAction(ctx context.Context, cmd *cli.Command) error {
+	return commands.ApproveAction(ctx, cmd, a.deps)
+}
+
+// DecryptAction provides a testable wrapper for the decrypt command
+func (a *CommandActions) DecryptAction(ctx context.Context, cmd *cli.Command) error {
+	return commands.DecryptAction(ctx, cmd, a.deps)
+}
+
+// MergeConfigAction provides a testable wrapper for the merge-config command
+func (a *CommandActions) MergeConfigAction(ctx context.Context, cmd *cli.Command) error {
+	return commands.MergeConfigAction(ctx, cmd, a.deps)
+}
+
+// SnapshotAction provides a testable wrapper for the snapshot command
+func (a *CommandActions) SnapshotAction(ctx context.Context, cmd *cli.Command) error {
+	return commands.SnapshotAction(ctx, cmd, a.deps)
+}
+
+// ImportAction provides a testable wrapper for the import command
+func (a *CommandActions) ImportAction(ctx context.Context, cmd *cli.Command) error {
+	return commands.ImportAction(ctx, cmd, a.deps)
+}
+
 // ListAction provides a testable wrapper for the list command
 func (a *CommandActions) ListAction(ctx context.Context, cmd *cli.Command) error {
 	return commands.ListAction(ctx, cmd, a.deps)
 }
 
+// CompareAction provides a testable wrapper for the rules compare command
+func (a *CommandActions) CompareAction(ctx context.Context, cmd *cli.Command) error {
+	return commands.CompareAction(ctx, cmd, a.deps)
+}
+
 // UpdateAction provides a testable wrapper for the update command
 func (a *CommandActions) UpdateAction(ctx context.Context, cmd *cli.Command) error {
 	return commands.UpdateAction(ctx, cmd, a.deps)
@@ -56,6 +101,116 @@ func (a *CommandActions) NewAction(ctx context.Context, cmd *cli.Command) error
 	return commands.NewAction(ctx, cmd, a.deps)
 }
 
+// NewGroupAction provides a testable wrapper for the new command group
+func (a *CommandActions) NewGroupAction(ctx context.Context, cmd *cli.Command) error {
+	return commands.NewGroupAction(ctx, cmd, a.deps)
+}
+
+// NewRepoAction provides a testable wrapper for the new repo command
+func (a *CommandActions) NewRepoAction(ctx context.Context, cmd *cli.Command) error {
+	return commands.NewRepoAction(ctx, cmd, a.deps)
+}
+
+// GenerateAction provides a testable wrapper for the generate command
+func (a *CommandActions) GenerateAction(ctx context.Context, cmd *cli.Command) error {
+	return commands.GenerateAction(ctx, cmd, a.deps)
+}
+
+// GenerateEnvRuleAction provides a testable wrapper for the generate env-rule command
+func (a *CommandActions) GenerateEnvRuleAction(ctx context.Context, cmd *cli.Command) error {
+	return commands.GenerateEnvRuleAction(ctx, cmd, a.deps)
+}
+
+// GenerateGitRuleAction provides a testable wrapper for the generate git-rule command
+func (a *CommandActions) GenerateGitRuleAction(ctx context.Context, cmd *cli.Command) error {
+	return commands.GenerateGitRuleAction(ctx, cmd, a.deps)
+}
+
+// IntegrateAction provides a testable wrapper for the integrate command
+func (a *CommandActions) IntegrateAction(ctx context.Context, cmd *cli.Command) error {
+	return commands.IntegrateAction(ctx, cmd, a.deps)
+}
+
+// IntegrateDevcontainerAction provides a testable wrapper for the integrate devcontainer command
+func (a *CommandActions) IntegrateDevcontainerAction(ctx context.Context, cmd *cli.Command) error {
+	return commands.IntegrateDevcontainerAction(ctx, cmd, a.deps)
+}
+
+// RepoAction provides a testable wrapper for the repo command
+func (a *CommandActions) RepoAction(ctx context.Context, cmd *cli.Command) error {
+	return commands.RepoAction(ctx, cmd, a.deps)
+}
+
+// RepoLintAction provides a testable wrapper for the repo lint command
+func (a *CommandActions) RepoLintAction(ctx context.Context, cmd *cli.Command) error {
+	return commands.RepoLintAction(ctx, cmd, a.deps)
+}
+
+// RepoStatsAction provides a testable wrapper for the repo stats command
+func (a *CommandActions) RepoStatsAction(ctx context.Context, cmd *cli.Command) error {
+	return commands.RepoStatsAction(ctx, cmd, a.deps)
+}
+
+// SyncAction provides a testable wrapper for the sync command
+func (a *CommandActions) SyncAction(ctx context.Context, cmd *cli.Command) error {
+	return commands.SyncAction(ctx, cmd, a.deps)
+}
+
+// AuditAction provides a testable wrapper for the audit command
+func (a *CommandActions) AuditAction(ctx context.Context, cmd *cli.Command) error {
+	return commands.AuditAction(ctx, cmd, a.deps)
+}
+
+// ReportAction provides a testable wrapper for the report command
+func (a *CommandActions) ReportAction(ctx context.Context, cmd *cli.Command) error {
+	return commands.ReportAction(ctx, cmd, a.deps)
+}
+
+// ReportPRAction provides a testable wrapper for the report pr command
+func (a *CommandActions) ReportPRAction(ctx context.Context, cmd *cli.Command) error {
+	return commands.ReportPRAction(ctx, cmd, a.deps)
+}
+
+// OutdatedAction provides a testable wrapper for the outdated command
+func (a *CommandActions) OutdatedAction(ctx context.Context, cmd *cli.Command) error {
+	return commands.OutdatedAction(ctx, cmd, a.deps)
+}
+
+// StarAction provides a testable wrapper for the star command
+func (a *CommandActions) StarAction(ctx context.Context, cmd *cli.Command) error {
+	return commands.StarAction(ctx, cmd, a.deps)
+}
+
+// UnstarAction provides a testable wrapper for the unstar command
+func (a *CommandActions) UnstarAction(ctx context.Context, cmd *cli.Command) error {
+	return commands.UnstarAction(ctx, cmd, a.deps)
+}
+
+// ProfileListAction provides a testable wrapper for the profile list command
+func (a *CommandActions) ProfileListAction(ctx context.Context, cmd *cli.Command) error {
+	return commands.ProfileListAction(ctx, cmd, a.deps)
+}
+
+// ProfileUseAction provides a testable wrapper for the profile use command
+func (a *CommandActions) ProfileUseAction(ctx context.Context, cmd *cli.Command) error {
+	return commands.ProfileUseAction(ctx, cmd, a.deps)
+}
+
+// ProfileCreateAction provides a testable wrapper for the profile create command
+func (a *CommandActions) ProfileCreateAction(ctx context.Context, cmd *cli.Command) error {
+	return commands.ProfileCreateAction(ctx, cmd, a.deps)
+}
+
+// ShowAction provides a testable wrapper for the rules show command
+func (a *CommandActions) ShowAction(ctx context.Context, cmd *cli.Command) error {
+	return commands.ShowAction(ctx, cmd, a.deps)
+}
+
+// LintAction provides a testable wrapper for the rules lint command
+func (a *CommandActions) LintAction(ctx context.Context, cmd *cli.Command) error {
+	return commands.LintAction(ctx, cmd, a.deps)
+}
+
 // ConfigAction provides a testable wrapper for the config command
 func (a *CommandActions) ConfigAction(ctx context.Context, cmd *cli.Command) error {
 	return commands.ConfigAction(ctx, cmd, a.deps)
@@ -71,6 +226,42 @@ func (a *CommandActions) ConfigFormatsAction(ctx context.Context, cmd *cli.Comma
 	return commands.ConfigFormatsAction(ctx, cmd, a.deps)
 }
 
+// ConfigGetAction provides a testable wrapper for the config get command
+func (a *CommandActions) ConfigGetAction(
+	ctx context.Context,
+	cmd *cli.Command,
+	deps *dependencies.Dependencies,
+) error {
+	return commands.ConfigGetAction(ctx, cmd, deps)
+}
+
+// ConfigSetAction provides a testable wrapper for the config set command
+func (a *CommandActions) ConfigSetAction(
+	ctx context.Context,
+	cmd *cli.Command,
+	deps *dependencies.Dependencies,
+) error {
+	return commands.ConfigSetAction(ctx, cmd, deps)
+}
+
+// ConfigEffectiveAction provides a testable wrapper for the config effective command
+func (a *CommandActions) ConfigEffectiveAction(
+	ctx context.Context,
+	cmd *cli.Command,
+	deps *dependencies.Dependencies,
+) error {
+	return commands.ConfigEffectiveAction(ctx, cmd, deps)
+}
+
+// ConfigNormalizeAction provides a testable wrapper for the config normalize command
+func (a *CommandActions) ConfigNormalizeAction(
+	ctx context.Context,
+	cmd *cli.Command,
+	deps *dependencies.Dependencies,
+) error {
+	return commands.ConfigNormalizeAction(ctx, cmd, deps)
+}
+
 // ConfigFormatsListAction provides a testable wrapper for the config formats list command
 func (a *CommandActions) ConfigFormatsListAction(
 	ctx context.Context,
@@ -157,7 +348,47 @@ func (a *CommandActions) ProvidersShowAction(
 	return commands.ProvidersShowAction(ctx, cmd, deps)
 }
 
+// CacheAction provides a testable wrapper for the cache command
+func (a *CommandActions) CacheAction(ctx context.Context, cmd *cli.Command) error {
+	return commands.CacheAction(ctx, cmd, a.deps)
+}
+
+// CacheGCAction provides a testable wrapper for the cache gc command
+func (a *CommandActions) CacheGCAction(ctx context.Context, cmd *cli.Command) error {
+	return commands.CacheGCAction(ctx, cmd, a.deps)
+}
+
+// DebugAction provides a testable wrapper for the debug command
+func (a *CommandActions) DebugAction(ctx context.Context, cmd *cli.Command) error {
+	return commands.DebugAction(ctx, cmd, a.deps)
+}
+
+// DebugBundleAction provides a testable wrapper for the debug bundle command
+func (a *CommandActions) DebugBundleAction(ctx context.Context, cmd *cli.Command) error {
+	return commands.DebugBundleAction(ctx, cmd, a.deps)
+}
+
+// BenchAction provides a testable wrapper for the bench command
+func (a *CommandActions) BenchAction(ctx context.Context, cmd *cli.Command) error {
+	return commands.BenchAction(ctx, cmd, a.deps)
+}
+
 // QueryAction provides a testable wrapper for the query command
 func (a *CommandActions) QueryAction(ctx context.Context, cmd *cli.Command) error {
 	return commands.QueryAction(ctx, cmd, a.deps)
 }
+
+// VarsAction provides a testable wrapper for the vars command
+func (a *CommandActions) VarsAction(ctx context.Context, cmd *cli.Command) error {
+	return commands.VarsAction(ctx, cmd, a.deps)
+}
+
+// VarsSetAction provides a testable wrapper for the vars set command
+func (a *CommandActions) VarsSetAction(ctx context.Context, cmd *cli.Command) error {
+	return commands.VarsSetAction(ctx, cmd, a.deps)
+}
+
+// EditAction provides a testable wrapper for the edit command
+func (a *CommandActions) EditAction(ctx context.Context, cmd *cli.Command) error {
+	return commands.EditAction(ctx, cmd, a.deps)
+}
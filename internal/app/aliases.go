@@ -0,0 +1,130 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/urfave/cli/v3"
+)
+
+// aliasCommands builds a top-level command for each alias in the global
+// configuration. An alias runs the command it names with the same flags and
+// arguments, so `b: build` makes `contexture b --force` run `contexture build --force`.
+func (a *Application) aliasCommands(builtin []*cli.Command) []*cli.Command {
+	aliases := a.loadAliases()
+	if len(aliases) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(aliases))
+	for name := range aliases {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var commands []*cli.Command
+	for _, name := range names {
+		path := strings.Fields(aliases[name])
+		switch {
+		case name == "" || strings.HasPrefix(name, "-") || strings.ContainsAny(name, " \t"):
+			log.Warn("Ignoring invalid command alias", "alias", name)
+			continue
+		case findCommand(builtin, []string{name}) != nil:
+			log.Warn("Ignoring command alias that shadows a built-in command", "alias", name)
+			continue
+		}
+
+		// Copy the target from a fresh command tree so the alias does not
+		// share flag state or subcommands with the built-in command
+		target := findCommand(a.buildCommands(), path)
+		if target == nil {
+			log.Warn("Ignoring command alias for unknown command", "alias", name, "command", aliases[name])
+			continue
+		}
+
+		alias := *target
+		alias.Name = name
+		alias.Aliases = nil
+		alias.Usage = fmt.Sprintf("Alias for %q", strings.Join(path, " "))
+		commands = append(commands, &alias)
+	}
+	return commands
+}
+
+// loadAliases reads the command aliases of the global configuration. A
+// missing or unreadable configuration defines no aliases; commands that use
+// the configuration report its errors themselves.
+func (a *Application) loadAliases() map[string]string {
+	result, err := project.NewManagerFromDependencies(a.deps).LoadGlobalConfig()
+	if err != nil {
+		log.Debug("Failed to load global configuration for command aliases", "error", err)
+		return nil
+	}
+	if result == nil || result.Config == nil {
+		return nil
+	}
+	return result.Config.Aliases
+}
+
+// findCommand returns the command at path, matching names and built-in aliases
+func findCommand(commands []*cli.Command, path []string) *cli.Command {
+	if len(path) == 0 {
+		return nil
+	}
+	for _, cmd := range commands {
+		if !slices.Contains(cmd.Names(), path[0]) {
+			continue
+		}
+		if len(path) == 1 {
+			return cmd
+		}
+		return findCommand(cmd.Commands, path[1:])
+	}
+	return nil
+}
+
+// enablePrefixMatching lets cmd and its descendants accept unambiguous
+// abbreviations of their subcommands
+func enablePrefixMatching(cmd *cli.Command) {
+	if len(cmd.Commands) == 0 {
+		return
+	}
+	cmd.SuggestCommandFunc = prefixMatchCommand
+	for _, sub := range cmd.Commands {
+		enablePrefixMatching(sub)
+	}
+}
+
+// prefixMatchCommand resolves provided to the command whose name or alias it
+// abbreviates. Exact matches, ambiguous prefixes, and unknown names are
+// returned unchanged.
+func prefixMatchCommand(commands []*cli.Command, provided string) string {
+	for _, cmd := range commands {
+		if slices.Contains(cmd.Names(), provided) {
+			return provided
+		}
+	}
+
+	match := ""
+	for _, cmd := range commands {
+		if cmd.Hidden {
+			continue
+		}
+		for _, name := range cmd.Names() {
+			if !strings.HasPrefix(name, provided) {
+				continue
+			}
+			if match != "" && match != cmd.Name {
+				return provided
+			}
+			match = cmd.Name
+		}
+	}
+	if match == "" {
+		return provided
+	}
+	return match
+}
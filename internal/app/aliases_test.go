@@ -0,0 +1,113 @@
+package app
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+func TestApplication_AliasCommands(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(project.XDGConfigHomeEnv, filepath.Join(home, ".config"))
+
+	deps := dependencies.NewForTesting(context.Background())
+	config := `version: 1
+formats:
+  - type: claude
+    enabled: true
+rules: []
+aliases:
+  b: build
+  up: rules update
+  rules: build
+  broken: rules nope
+`
+	require.NoError(t, afero.WriteFile(deps.FS, filepath.Join(home, ".config", "contexture", domain.GetConfigFileName()), []byte(config), 0o644))
+
+	app := New(deps)
+	root := app.buildCLIApp()
+
+	build := findCommand(root.Commands, []string{"b"})
+	require.NotNil(t, build)
+	assert.Equal(t, `Alias for "build"`, build.Usage)
+	assert.NotNil(t, build.Action)
+
+	update := findCommand(root.Commands, []string{"up"})
+	require.NotNil(t, update)
+	assert.Contains(t, flagNames(update), "dry-run")
+	assert.NotSame(t, findCommand(root.Commands, []string{"rules", "update"}), update)
+
+	// Aliases cannot shadow built-in commands or name unknown ones
+	assert.NotNil(t, findCommand(root.Commands, []string{"rules", "add"}))
+	assert.Nil(t, findCommand(root.Commands, []string{"broken"}))
+}
+
+func TestApplication_AliasCommands_NoGlobalConfig(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	app := New(dependencies.NewForTesting(context.Background()))
+	commands := app.buildCommands()
+	assert.Empty(t, app.aliasCommands(commands))
+}
+
+func TestPrefixMatchCommand(t *testing.T) {
+	commands := []*cli.Command{
+		{Name: "build"},
+		{Name: "rules"},
+		{Name: "repo"},
+		{Name: "remove", Aliases: []string{"rm"}},
+		{Name: "secret", Hidden: true},
+	}
+
+	tests := []struct {
+		provided string
+		want     string
+	}{
+		{"build", "build"},
+		{"bu", "build"},
+		{"ru", "rules"},
+		{"rep", "repo"},
+		{"re", "re"},     // ambiguous: repo and remove
+		{"rm", "rm"},     // exact alias
+		{"sec", "sec"},   // hidden commands are not abbreviated
+		{"xyz", "xyz"},   // unknown
+		{"rul", "rules"}, // longer prefix
+		{"remo", "remove"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provided, func(t *testing.T) {
+			assert.Equal(t, tt.want, prefixMatchCommand(commands, tt.provided))
+		})
+	}
+}
+
+func TestApplication_PrefixMatching(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	app := New(dependencies.NewForTesting(context.Background()))
+	root := app.buildCLIApp()
+
+	require.NotNil(t, root.SuggestCommandFunc)
+	rules := findCommand(root.Commands, []string{"rules"})
+	require.NotNil(t, rules)
+	assert.NotNil(t, rules.SuggestCommandFunc)
+	assert.Equal(t, "update", rules.SuggestCommandFunc(rules.Commands, "up"))
+}
+
+func flagNames(cmd *cli.Command) []string {
+	var names []string
+	for _, flag := range cmd.Flags {
+		names = append(names, flag.Names()...)
+	}
+	return names
+}
@@ -6,12 +6,23 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime/debug"
+	"syscall"
 
 	"github.com/charmbracelet/log"
+	"github.com/contextureai/contexture/internal/benchmark"
 	helpCLI "github.com/contextureai/contexture/internal/cli"
 	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/devcontainer"
 	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/contextureai/contexture/internal/tui"
+	"github.com/contextureai/contexture/internal/ui"
 	"github.com/contextureai/contexture/internal/version"
+	"github.com/spf13/afero"
 	"github.com/urfave/cli/v3"
 )
 
@@ -19,6 +30,11 @@ import (
 type Application struct {
 	deps    *dependencies.Dependencies
 	actions *CommandActions
+	// cancelDeadline releases the deadline of a command timeout, when set
+	cancelDeadline context.CancelFunc
+	// recording is the session recorded with --record into recordFile
+	recording  *tui.Session
+	recordFile string
 }
 
 // New creates a new Application instance with proper dependency injection
@@ -33,40 +49,90 @@ func New(deps *dependencies.Dependencies) *Application {
 	}
 }
 
-// Run executes the application with proper error handling and returns an exit code
+// Run executes the application with proper error handling and returns an exit code.
+// Panics are recovered and reported with a diagnostics bundle.
 func Run(args []string) int {
-	// Create dependencies
-	ctx := context.Background()
-	deps := dependencies.New(ctx)
-	app := New(deps)
+	captureLogs()
+	deps := dependencies.New(context.Background())
+	return runRecovering(func(r any) int {
+		// The working directory is the project directory once --project-dir is applied
+		return handleCrash(os.Stderr, afero.NewOsFs(), deps.GetWorkingDir(), r, debug.Stack(), args)
+	}, func() int {
+		return run(args, deps)
+	})
+}
 
-	if err := app.Execute(ctx, args); err != nil {
-		// Display the error
-		contextureerrors.Display(err)
+// run executes the application with deps and returns its exit code
+func run(args []string, deps *dependencies.Dependencies) int {
+	// Interrupts cancel the command context, so commands stop and clean up
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	ctx, stop := handleInterrupts(context.Background(), signals, ui.SaveTerminal(), os.Exit)
+
+	deps.Context = ctx
+	app := New(deps)
 
-		// Get exit code
-		var e *contextureerrors.Error
-		if errors.As(err, &e) {
-			return e.ExitCode()
+	err := app.Execute(ctx, args)
+	if stop() {
+		// The cancellation error of an interrupted command says nothing new
+		if err != nil && !errors.Is(err, context.Canceled) {
+			contextureerrors.Display(err)
+		}
+		return int(contextureerrors.ExitInterrupted)
+	}
+	if err != nil {
+		// Display the error, as JSON when the command was asked for JSON output
+		if jsonOutputRequested(args) {
+			_ = contextureerrors.DisplayJSON(os.Stderr, err)
+		} else {
+			contextureerrors.Display(err)
 		}
 
-		// Default exit code
-		return 1
+		// Map the error chain to a documented exit code
+		return contextureerrors.ExitCodeFor(err)
 	}
 
 	return 0
 }
 
+// jsonOutputRequested reports whether args select JSON output via --output, -o, or --json
+func jsonOutputRequested(args []string) bool {
+	for i, arg := range args {
+		switch arg {
+		case "--output=json", "-o=json", "--json":
+			return true
+		case "--output", "-o":
+			if i+1 < len(args) && args[i+1] == "json" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // Execute runs the CLI application with the given context and arguments
 func (a *Application) Execute(ctx context.Context, args []string) error {
-	// Save and restore original help printer to avoid global state mutation
+	// Save and restore original help printers to avoid global state mutation
 	originalHelpPrinter := cli.HelpPrinter
+	originalShowCommandHelp := cli.ShowCommandHelp
 	defer func() {
 		cli.HelpPrinter = originalHelpPrinter
+		cli.ShowCommandHelp = originalShowCommandHelp
+	}()
+
+	defer func() {
+		if a.cancelDeadline != nil {
+			a.cancelDeadline()
+		}
 	}()
 
 	app := a.buildCLIApp()
-	return app.Run(ctx, args)
+	err := app.Run(ctx, args)
+	if sessionErr := a.finishSession(); err == nil {
+		err = sessionErr
+	}
+	return err
 }
 
 // buildCLIApp constructs the CLI application structure
@@ -79,6 +145,11 @@ func (a *Application) buildCLIApp() *cli.Command {
 			_, _ = fmt.Fprintf(w, "Error rendering help: %v\n", err)
 		}
 	}
+	// Let `contexture help <topic>` show topic pages as well as command help
+	cli.ShowCommandHelp = helpCLI.ShowCommandHelpWithTopics(cli.DefaultShowCommandHelp)
+
+	commands := a.buildCommands()
+	commands = append(commands, a.aliasCommands(commands)...)
 
 	app := &cli.Command{
 		Name:    "contexture",
@@ -89,10 +160,16 @@ func (a *Application) buildCLIApp() *cli.Command {
 		},
 		Description:        "Contexture helps you manage AI assistant rules across multiple formats (Claude, Cursor, Windsurf).",
 		CustomHelpTemplate: helpCLI.AppHelpTemplate,
-		Commands:           a.buildCommands(),
+		Commands:           commands,
 		Flags:              a.buildGlobalFlags(),
 		Before:             a.setupGlobalFlags,
+		// Adds the hidden completion command printing shell completion scripts
+		EnableShellCompletion: true,
+		// Return errors to Run instead of letting the CLI framework exit,
+		// so every failure is displayed and mapped to an exit code in one place
+		ExitErrHandler: func(context.Context, *cli.Command, error) {},
 	}
+	enablePrefixMatching(app)
 
 	return app
 }
@@ -101,11 +178,35 @@ func (a *Application) buildCLIApp() *cli.Command {
 func (a *Application) buildCommands() []*cli.Command {
 	return []*cli.Command{
 		a.buildInitCommand(),
+		a.buildImportCommand(),
 		a.buildRulesCommand(),
+		a.buildApproveCommand(),
 		a.buildBuildCommand(),
+		a.buildSyncCommand(),
+		a.buildVendorCommand(),
+		a.buildDecryptCommand(),
+		a.buildOutdatedCommand(),
+		a.buildReportCommand(),
+		a.buildAuditCommand(),
+		a.buildValidateCommand(),
+		a.buildTestCommand(),
+		a.buildSnapshotCommand(),
 		a.buildQueryCommand(),
+		a.buildStarCommand(),
+		a.buildUnstarCommand(),
+		a.buildProfileCommand(),
+		a.buildVarsCommand(),
+		a.buildEditCommand(),
+		a.buildNewCommand(),
+		a.buildGenerateCommand(),
+		a.buildIntegrateCommand(),
+		a.buildRepoCommand(),
 		a.buildConfigCommand(),
+		a.buildMergeConfigCommand(),
 		a.buildProvidersCommand(),
+		a.buildCacheCommand(),
+		a.buildDebugCommand(),
+		a.buildBenchCommand(),
 	}
 }
 
@@ -116,6 +217,44 @@ func (a *Application) buildGlobalFlags() []cli.Flag {
 			Name:  "verbose",
 			Usage: "Enable verbose logging",
 		},
+		&cli.BoolFlag{
+			Name:    "quiet",
+			Aliases: []string{"q"},
+			Usage:   "Suppress progress output; summaries and errors are still shown",
+		},
+		&cli.BoolFlag{
+			Name:  "non-interactive",
+			Usage: "Never prompt: confirmations take their default and required selections fail (also " + tui.NonInteractiveEnv + "=true)",
+		},
+		&cli.StringFlag{
+			Name:      "project-dir",
+			Aliases:   []string{"C"},
+			Usage:     "Run as if contexture was started in `dir`",
+			TakesFile: true,
+		},
+		&cli.StringFlag{
+			Name:      "config",
+			Usage:     "Use the project configuration `file` instead of searching the project directory",
+			TakesFile: true,
+		},
+		&cli.StringFlag{
+			Name:      "record",
+			Usage:     "Record the keys pressed in prompts to the session `file`, to reproduce the run",
+			TakesFile: true,
+		},
+		&cli.StringFlag{
+			Name:      "replay",
+			Usage:     "Answer prompts with the keys recorded in the session `file`",
+			TakesFile: true,
+		},
+		&cli.BoolFlag{
+			Name:  "strict-config",
+			Usage: "Fail on unknown keys in configuration files instead of warning about them",
+		},
+		&cli.BoolFlag{
+			Name:  "read-only",
+			Usage: "Report the files the command would write, change, or remove without touching them",
+		},
 	}
 }
 
@@ -128,7 +267,109 @@ func (a *Application) setupGlobalFlags(
 		// Enable debug logging
 		log.SetLevel(log.DebugLevel)
 	}
-	return ctx, nil
+	ui.SetQuiet(cmd.Bool("quiet"))
+	tui.SetNonInteractive(cmd.Bool("non-interactive") || tui.NonInteractiveInEnv(a.deps.GetEnv()))
+	a.applyReadOnly(cmd.Bool("read-only"))
+	if err := a.applySessionFlags(cmd.String("record"), cmd.String("replay")); err != nil {
+		return ctx, err
+	}
+	if err := a.applyProjectFlags(cmd.String("project-dir"), cmd.String("config")); err != nil {
+		return ctx, err
+	}
+	// After the project flags, so the project directory they select is cleaned
+	if !cmd.Bool("read-only") {
+		a.collectGarbage()
+	}
+	project.SetStrictConfig(cmd.Bool("strict-config"))
+	return a.applyExecutionBudget(ctx), nil
+}
+
+// applyProjectFlags makes commands operate on the directory given with
+// --project-dir and read the configuration file given with --config, without
+// changing the process working directory. Without --project-dir, an explicit
+// configuration file also selects its project directory.
+func (a *Application) applyProjectFlags(projectDir, configFile string) error {
+	workingDir, err := a.deps.GetWorkingDir().Getwd()
+	if err != nil {
+		return contextureerrors.Wrap(err, "get current directory")
+	}
+	if configFile != "" {
+		// Relative paths are relative to the directory contexture was started in
+		if !filepath.IsAbs(configFile) {
+			configFile = filepath.Join(workingDir, configFile)
+		}
+		configFile = filepath.Clean(configFile)
+		if projectDir == "" {
+			projectDir = project.ConfigFileProjectDir(configFile)
+		}
+	}
+	a.deps.ConfigFile = configFile
+
+	if projectDir == "" {
+		return nil
+	}
+	if !filepath.IsAbs(projectDir) {
+		projectDir = filepath.Join(workingDir, projectDir)
+	}
+	projectDir = filepath.Clean(projectDir)
+	if info, err := a.deps.FS.Stat(projectDir); err != nil || !info.IsDir() {
+		return contextureerrors.Validation("project-dir", projectDir+" is not a directory").
+			WithSuggestions(contextureerrors.Hint("pass an existing directory to --project-dir"))
+	}
+	a.deps.WorkingDir = dependencies.StaticWorkingDir(projectDir)
+	return nil
+}
+
+// selectionFlags returns the flags that make the selection of an interactive
+// prompt without it, naming the options as what, and report it as JSON
+// instead of applying it
+func selectionFlags(what string) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:  "select",
+			Usage: "Select the `" + what + "` in a comma-separated list instead of prompting",
+		},
+		&cli.StringFlag{
+			Name:  "all-matching",
+			Usage: "Select every " + what + " matching a glob `pattern` or substring instead of prompting",
+		},
+		&cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "Print the selection as JSON without applying it",
+		},
+	}
+}
+
+// profilingFlags returns the flags that capture CPU and memory profiles for a command
+func profilingFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:      "cpuprofile",
+			Usage:     "Write a CPU profile to `file`",
+			TakesFile: true,
+		},
+		&cli.StringFlag{
+			Name:      "memprofile",
+			Usage:     "Write a memory profile to `file` when the command finishes",
+			TakesFile: true,
+		},
+	}
+}
+
+// startProfiling starts the profiles requested by profilingFlags before the command runs
+func (a *Application) startProfiling(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+	if a.deps.Profiler == nil {
+		return ctx, nil
+	}
+	return ctx, a.deps.Profiler.Start(cmd.String("cpuprofile"), cmd.String("memprofile"))
+}
+
+// stopProfiling writes the requested profiles once the command has finished
+func (a *Application) stopProfiling(_ context.Context, _ *cli.Command) error {
+	if a.deps.Profiler == nil {
+		return nil
+	}
+	return a.deps.Profiler.Stop()
 }
 
 // Command builders - extracted for better testability and organization
@@ -138,9 +379,16 @@ func (a *Application) buildInitCommand() *cli.Command {
 		Name:  "init",
 		Usage: "Initialize a new project configuration",
 		Description: `Initialize a new Contexture project in the current directory.
-This will create a configuration file and set up output formats.`,
+This will create a configuration file and set up output formats.
+
+Existing CLAUDE.md, .cursor/rules, and .github/copilot-instructions.md files
+are detected and either imported into local rules or kept as they are.
+Without --adopt you are asked which; non-interactive runs keep them.
+
+Formats chosen with --select or --all-matching skip the prompts, and
+--dry-run prints the formats that would be configured as JSON.`,
 		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
-		Flags: []cli.Flag{
+		Flags: append([]cli.Flag{
 			&cli.BoolFlag{
 				Name:    "force",
 				Aliases: []string{"f"},
@@ -150,11 +398,52 @@ This will create a configuration file and set up output formats.`,
 				Name:  "no-interactive",
 				Usage: "Skip interactive prompts (for CI/CD usage)",
 			},
-		},
+			&cli.StringFlag{
+				Name:  "adopt",
+				Usage: "How to adopt existing assistant files: import or keep",
+			},
+		}, selectionFlags("formats")...),
 		Action: a.actions.InitAction,
 	}
 }
 
+func (a *Application) buildImportCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "import",
+		Usage:     "Import existing assistant files into local rules",
+		ArgsUsage: "<claude|cursor|copilot> [path]",
+		Description: `Split a hand-written assistant context file into local rules with
+generated frontmatter, then rebuild so the file is replaced by generated output.
+
+Sources and the files read when no path is given:
+  claude   CLAUDE.md, split into one rule per section
+  cursor   .cursor/rules/*.mdc, one rule per file (a .cursorrules file may be given)
+  copilot  .github/copilot-instructions.md, split into one rule per section
+
+Rules are written to rules/<source>/ and the matching format is enabled.
+Copilot instructions are imported but left in place.`,
+		Metadata: helpCLI.ExamplesMetadata(
+			helpCLI.Example{Command: "contexture import claude"},
+			helpCLI.Example{Command: "contexture import claude ./docs/CLAUDE.md"},
+			helpCLI.Example{Command: "contexture import cursor"},
+			helpCLI.Example{Command: "contexture import cursor --no-build"},
+		),
+		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:    "force",
+				Aliases: []string{"f"},
+				Usage:   "Overwrite existing rule files",
+			},
+			&cli.BoolFlag{
+				Name:  "no-build",
+				Usage: "Write the rules without rebuilding outputs",
+			},
+		},
+		Action: a.actions.ImportAction,
+	}
+}
+
 func (a *Application) buildRulesCommand() *cli.Command {
 	return &cli.Command{
 		Name:  "rules",
@@ -171,6 +460,9 @@ Use subcommands to manage specific aspects of your rules.`,
 			a.buildRulesListCommand(),
 			a.buildRulesUpdateCommand(),
 			a.buildRulesNewCommand(),
+			a.buildRulesCompareCommand(),
+			a.buildRulesShowCommand(),
+			a.buildRulesLintCommand(),
 		},
 	}
 }
@@ -190,17 +482,23 @@ Rule IDs can be specified in multiple ways:
 • Direct URL: [contexture(https://github.com/user/repo.git):path/to/rule]
 • Git URL: https://github.com/user/repo.git#path/to/rule
 
-Examples:
-  contexture rules add @contexture/languages/go/testing
-  contexture rules add @mycompany/security/auth
-  contexture rules add languages/go/testing
-  contexture rules add @contexture/go/testing --ref v1.2.0`,
+With --global, the changes to user-level files such as ~/.claude/CLAUDE.md
+are shown for confirmation before they are written. --yes skips the
+confirmation and --no-user-write only updates the global configuration.`,
+		Metadata: helpCLI.ExamplesMetadata(
+			helpCLI.Example{Command: "contexture rules add @contexture/languages/go/testing"},
+			helpCLI.Example{Command: "contexture rules add @mycompany/security/auth"},
+			helpCLI.Example{Command: "contexture rules add languages/go/testing"},
+			helpCLI.Example{Command: "contexture rules add @contexture/go/testing --ref v1.2.0"},
+			helpCLI.Example{Command: "contexture rules add --favorites"},
+			helpCLI.Example{Command: "contexture rules add -g @contexture/go/testing --no-user-write"},
+		),
 		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
-		Flags: []cli.Flag{
+		Flags: append([]cli.Flag{
 			&cli.BoolFlag{
 				Name:    "global",
 				Aliases: []string{"g"},
-				Usage:   "Add rule to global configuration (~/.contexture)",
+				Usage:   "Add rule to global configuration (~/.config/contexture)",
 			},
 			&cli.StringFlag{
 				Name:  "data",
@@ -217,7 +515,1002 @@ Examples:
 			},
 			&cli.StringFlag{
 				Name:  "ref",
-				Usage: "Git branch or tag reference",
+				Usage: "Git branch or tag reference",
+			},
+			&cli.BoolFlag{
+				Name:  "favorites",
+				Usage: "Also add the rules starred with 'contexture star', choosing among them in a terminal",
+			},
+			&cli.BoolFlag{
+				Name:    "yes",
+				Aliases: []string{"y"},
+				Usage:   "With --global, write the user-level files without showing their changes first",
+			},
+			&cli.BoolFlag{
+				Name:  "no-user-write",
+				Usage: "With --global, update the global configuration without writing user-level files such as ~/.claude/CLAUDE.md",
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Value:   "default",
+				Usage:   "Output format (default, json)",
+			},
+		}, profilingFlags()...),
+		Before:        a.startProfiling,
+		After:         a.stopProfiling,
+		Action:        a.actions.AddAction,
+		ShellComplete: a.completeRecentRules,
+	}
+}
+
+func (a *Application) buildRulesRemoveCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "remove",
+		Aliases:   []string{"rm"},
+		Usage:     "Remove rules from the project",
+		ArgsUsage: "[rule-id...]",
+		Description: `Remove one or more rules from the current project.
+This will update the configuration and clean generated files.
+Rule IDs are required as arguments.
+
+With --global, the changes to user-level files are shown for confirmation
+before they are written; --yes skips it and --no-user-write leaves them as
+they are.`,
+		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:    "global",
+				Aliases: []string{"g"},
+				Usage:   "Remove rule from global configuration",
+			},
+			&cli.BoolFlag{
+				Name:    "yes",
+				Aliases: []string{"y"},
+				Usage:   "With --global, write the user-level files without showing their changes first",
+			},
+			&cli.BoolFlag{
+				Name:  "no-user-write",
+				Usage: "With --global, update the global configuration without writing user-level files",
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Value:   "default",
+				Usage:   "Output format (default, json)",
+			},
+		},
+		Action: a.actions.RemoveAction,
+	}
+}
+
+func (a *Application) buildBuildCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "build",
+		Usage: "Build output files for all configured formats",
+		Description: `Build output files based on the configured rules and formats.
+This will fetch all rules, process templates, and write format-specific files.
+
+With --global, only the user-level outputs such as ~/.claude/CLAUDE.md are
+regenerated from the global configuration, and no project is needed.
+
+Rules that join an experiment variant are only built while the variant is
+active: selected with --experiment or CONTEXTURE_EXPERIMENT, else the
+experiment's default. The active variants are recorded in
+.contexture/build-manifest.json.
+
+With --config-url, the configuration is downloaded over https instead of
+read from the project, for CI jobs and containers that have no committed
+configuration yet. The download is cached, and pinned with --config-sha256.
+
+Third-party rules are linted for injected instructions, such as requests to
+ignore earlier instructions or send secrets elsewhere. Findings are warnings
+unless generation.safety raises them to errors; with --quarantine, flagged
+rules are left out of the outputs until listed in generation.safety.reviewed.`,
+		Metadata: helpCLI.ExamplesMetadata(
+			helpCLI.Example{Command: "contexture build"},
+			helpCLI.Example{Command: "contexture build --formats claude"},
+			helpCLI.Example{Command: "contexture build --global"},
+			helpCLI.Example{Command: "contexture build --experiment retrieval-style-b", Description: "Build the rules of an experiment variant"},
+			helpCLI.Example{Command: "contexture build --config-url https://example.com/contexture.yaml", Description: "Build from a configuration that is not committed"},
+			helpCLI.Example{Command: "contexture build --quarantine", Description: "Leave out rules with suspicious instructions"},
+		),
+		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+		Flags: append([]cli.Flag{
+			&cli.BoolFlag{
+				Name:    "global",
+				Aliases: []string{"g"},
+				Usage:   "Build only user-level outputs from the global configuration",
+			},
+			&cli.BoolFlag{
+				Name:    "verbose",
+				Aliases: []string{"v"},
+				Usage:   "Show detailed progress information",
+			},
+			&cli.StringSliceFlag{
+				Name:  "formats",
+				Usage: "Build for specific formats only (claude, cursor, windsurf, copilot, gemini)",
+			},
+			&cli.BoolFlag{
+				Name:    "force",
+				Aliases: []string{"f"},
+				Usage:   "Skip confirmation prompt when deleting files",
+			},
+			&cli.StringFlag{
+				Name:  "model",
+				Usage: "Report output size against a model profile (claude-sonnet, gpt-4o, gemini)",
+			},
+			&cli.StringFlag{
+				Name:  "config-url",
+				Usage: "Build from the configuration at an https `url` instead of the project's own",
+			},
+			&cli.StringFlag{
+				Name:  "config-sha256",
+				Usage: "Require the --config-url configuration to have this SHA-256 `checksum`",
+			},
+			&cli.StringSliceFlag{
+				Name:  "experiment",
+				Usage: "Build an experiment variant instead of the experiment's default (also CONTEXTURE_EXPERIMENT)",
+			},
+			&cli.BoolFlag{
+				Name:  "quarantine",
+				Usage: "Leave out third-party rules with suspicious instructions until they are reviewed",
+			},
+		}, profilingFlags()...),
+		Before: a.startProfiling,
+		After:  a.stopProfiling,
+		Action: a.actions.BuildAction,
+	}
+}
+
+func (a *Application) buildValidateCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "validate",
+		Usage: "Validate configuration and rules without generating output",
+		Description: `Check the project configuration, pinned rule commits, local rule files
+and provider reachability without writing any files.
+
+Exits with a non-zero status when any issue is found, making it suitable for CI.`,
+		Metadata: helpCLI.ExamplesMetadata(
+			helpCLI.Example{Command: "contexture validate"},
+			helpCLI.Example{Command: "contexture validate --offline"},
+			helpCLI.Example{Command: "contexture validate --offline --output json"},
+		),
+		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "offline",
+				Usage: "Skip network checks and only validate provider URLs",
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Value:   "default",
+				Usage:   "Output format (default, json, github)",
+			},
+		},
+		Action: a.actions.ValidateAction,
+	}
+}
+
+func (a *Application) buildStarCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "star",
+		Usage:     "Star rules to add them to any project later",
+		ArgsUsage: "[rule-id...]",
+		Description: `Star rules so they can be added to any project with
+'contexture rules add --favorites'. Starred rules are kept in the global
+configuration (~/.config/contexture), so they follow you across projects.
+
+Without rule IDs, lists the starred rules.`,
+		Metadata: helpCLI.ExamplesMetadata(
+			helpCLI.Example{Command: "contexture star @contexture/languages/go/testing"},
+			helpCLI.Example{Command: "contexture star", Description: "List starred rules"},
+			helpCLI.Example{Command: "contexture rules add --favorites", Description: "Add starred rules to a project"},
+		),
+		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+		Action:             a.actions.StarAction,
+	}
+}
+
+func (a *Application) buildUnstarCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "unstar",
+		Usage:     "Remove rules from the starred rules",
+		ArgsUsage: "<rule-id...>",
+		Description: `Remove rules from the starred rules in the global configuration.
+Projects the rules were added to keep them.`,
+		Metadata: helpCLI.ExamplesMetadata(
+			helpCLI.Example{Command: "contexture unstar @contexture/languages/go/testing"},
+		),
+		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+		Action:             a.actions.UnstarAction,
+	}
+}
+
+func (a *Application) buildProfileCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "profile",
+		Usage: "Switch between named global configurations",
+		Description: `Keep several global configurations, such as work and personal, and switch
+the global rules, providers, and formats between them.
+
+The default profile is the global configuration file; the others are stored
+in profiles/<name>.yaml in the global directory (~/.config/contexture). The
+profile chosen with 'contexture profile use' applies to this machine, and the
+CONTEXTURE_PROFILE environment variable overrides it for a shell. Local rules
+in the global rules directory are shared by all profiles.
+
+Without a subcommand, lists the profiles.`,
+		Metadata: helpCLI.ExamplesMetadata(
+			helpCLI.Example{Command: "contexture profile create work --use"},
+			helpCLI.Example{Command: "contexture profile use personal"},
+			helpCLI.Example{Command: "CONTEXTURE_PROFILE=work contexture build --global", Description: "Use a profile for one command"},
+		),
+		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+		Action:             a.actions.ProfileListAction,
+		Commands: []*cli.Command{
+			{
+				Name:               "list",
+				Aliases:            []string{"ls"},
+				Usage:              "List the profiles and mark the active one",
+				Description:        `List the default profile and the named profiles, marking the one in use.`,
+				CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+				Action:             a.actions.ProfileListAction,
+			},
+			{
+				Name:      "use",
+				Usage:     "Switch the global configuration to a profile",
+				ArgsUsage: "<name>",
+				Description: `Make a profile the global configuration of this machine. 'default' switches
+back to the global configuration file. Run 'contexture build --global'
+afterwards to rewrite user-level files such as ~/.claude/CLAUDE.md.`,
+				Metadata: helpCLI.ExamplesMetadata(
+					helpCLI.Example{Command: "contexture profile use work"},
+					helpCLI.Example{Command: "contexture profile use default"},
+				),
+				CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+				Action:             a.actions.ProfileUseAction,
+			},
+			{
+				Name:      "create",
+				Usage:     "Create a profile from the active one",
+				ArgsUsage: "<name>",
+				Description: `Create a named profile as a copy of the active global configuration, or
+with --empty from the default one with no rules.`,
+				Metadata: helpCLI.ExamplesMetadata(
+					helpCLI.Example{Command: "contexture profile create personal --empty"},
+					helpCLI.Example{Command: "contexture profile create work --use"},
+				),
+				CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "empty",
+						Usage: "Start from the default configuration instead of copying the active profile",
+					},
+					&cli.BoolFlag{
+						Name:  "use",
+						Usage: "Switch to the profile once created",
+					},
+				},
+				Action: a.actions.ProfileCreateAction,
+			},
+		},
+	}
+}
+
+func (a *Application) buildQueryCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "query",
+		Usage:     "Search for rules across all providers",
+		ArgsUsage: "<search-text>",
+		Description: `Search for rules across all configured providers.
+
+By default, fuzzy-matches text against rule titles, paths, and metadata,
+tolerating partial words and typos, and ranks the results.
+Use --expr for advanced expression-based queries.
+
+Expression syntax documentation: https://expr-lang.org/docs/language-definition
+
+Available fields for --expr mode:
+  Direct fields:
+    ID            Rule identifier (string)
+    Title         Rule title (string)
+    Description   Rule description (string)
+    Tags          Rule tags ([]string)
+    Languages     Supported languages ([]string)
+    Frameworks    Supported frameworks ([]string)
+    Content       Rule content/body (string)
+    Variables     Rule variables (map)
+    Source        Source provider (string)
+    FilePath      File path in repository (string)
+
+  Computed fields:
+    Tag           Space-joined tags (string)
+    Language      Space-joined languages (string)
+    Framework     Space-joined frameworks (string)
+    Provider      Provider name (string)
+    Path          Extracted path from ID (string)
+    HasVars       Has variables (bool)
+    VarCount      Number of variables (int)
+    TriggerType   Trigger type (string)`,
+		Metadata: helpCLI.ExamplesMetadata(
+			helpCLI.Example{Command: "contexture query testing", Description: "Simple text search"},
+			helpCLI.Example{Command: `contexture query "go test"`},
+			helpCLI.Example{Command: `contexture query --expr 'Tag contains "testing"'`, Description: "Advanced expression search"},
+			helpCLI.Example{Command: `contexture query --expr 'Language == "go" and Provider != "local"'`},
+			helpCLI.Example{Command: `contexture query --expr 'any(Tags, # in ["security", "auth"])'`},
+			helpCLI.Example{Command: "contexture query --expr 'HasVars == true and VarCount > 2'"},
+			helpCLI.Example{Command: `contexture query --expr 'TriggerType == "file_change"'`},
+		),
+		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "expr",
+				Usage: "Use advanced expression syntax (see https://expr-lang.org)",
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Value:   "default",
+				Usage:   "Output format (default, json)",
+			},
+			&cli.StringSliceFlag{
+				Name:  "provider",
+				Usage: "Search specific providers only",
+			},
+			&cli.IntFlag{
+				Name:    "limit",
+				Aliases: []string{"n"},
+				Value:   10,
+				Usage:   "Maximum number of results",
+			},
+		},
+		Action: a.actions.QueryAction,
+	}
+}
+
+func (a *Application) buildVarsCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "vars",
+		Usage:     "Edit the variables of a configured rule",
+		ArgsUsage: "<rule-id>",
+		Description: `Edit the variables of a rule in the project configuration.
+
+Opens a form listing each variable the rule declares, with its current value
+and default. Changes are saved to the rule's entry in .contexture.yaml and the
+rule files are regenerated. Values equal to the rule's defaults are not stored.
+
+Use --var to set values without the form.`,
+		Metadata: helpCLI.ExamplesMetadata(
+			helpCLI.Example{Command: "contexture vars languages/go/testing"},
+			helpCLI.Example{Command: "contexture vars languages/go/testing --var max_line_length=120"},
+			helpCLI.Example{Command: "contexture vars @mycompany/go/style --global"},
+		),
+		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:    "global",
+				Aliases: []string{"g"},
+				Usage:   "Edit a rule in the global configuration",
+			},
+			&cli.StringSliceFlag{
+				Name:  "var",
+				Usage: "Set a variable without prompting (can be used multiple times): --var key=value",
+			},
+		},
+		Action: a.actions.VarsAction,
+		Commands: []*cli.Command{
+			a.buildVarsSetCommand(),
+		},
+	}
+}
+
+func (a *Application) buildVarsSetCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "set",
+		Usage:     "Set variables on every configured rule matching a pattern",
+		ArgsUsage: "<key=value...>",
+		Description: `Set variables on all configured rules whose path matches a --rules glob
+pattern, in one operation. Rules from providers also match without their
+@provider prefix.
+
+The rules affected are listed with the current and new value of each variable
+before anything changes. Values equal to a rule's defaults are not stored, and
+the rule files are regenerated. Local rules are skipped; their variables live
+in their frontmatter.`,
+		Metadata: helpCLI.ExamplesMetadata(
+			helpCLI.Example{Command: `contexture vars set max_line_length=120 --rules "languages/go/*"`},
+			helpCLI.Example{Command: `contexture vars set strict=true --rules "security/**" --dry-run`},
+			helpCLI.Example{Command: `contexture vars set style=google --rules "*/style" --global --yes`},
+		),
+		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:  "rules",
+				Usage: "Glob `pattern` selecting the rules to change (can be used multiple times)",
+			},
+			&cli.BoolFlag{
+				Name:    "global",
+				Aliases: []string{"g"},
+				Usage:   "Change rules in the global configuration",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "List the rules that would change without changing them",
+			},
+			&cli.BoolFlag{
+				Name:    "yes",
+				Aliases: []string{"y"},
+				Usage:   "Apply the changes without prompting",
+			},
+		},
+		Action: a.actions.VarsSetAction,
+	}
+}
+
+func (a *Application) buildEditCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "edit",
+		Usage:     "Edit a local rule in your editor",
+		ArgsUsage: "<path>",
+		Description: `Open a local rule in $VISUAL or $EDITOR (falling back to vi).
+
+When the editor exits, the rule's frontmatter and template are validated. If the
+rule is invalid you can reopen the editor to fix it. A valid rule is shown as a
+rendered preview and the project's rule files are rebuilt.`,
+		Metadata: helpCLI.ExamplesMetadata(
+			helpCLI.Example{Command: "contexture edit my-rule"},
+			helpCLI.Example{Command: "contexture edit security/auth-check --no-build"},
+			helpCLI.Example{Command: "contexture edit team/style --global"},
+		),
+		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:    "global",
+				Aliases: []string{"g"},
+				Usage:   "Edit a rule in the global rules directory (~/.config/contexture/rules)",
+			},
+			&cli.BoolFlag{
+				Name:  "no-build",
+				Usage: "Skip rebuilding rule files after editing",
+			},
+		},
+		Action: a.actions.EditAction,
+	}
+}
+
+func (a *Application) buildTestCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "test",
+		Usage:     "Run rule tests",
+		ArgsUsage: "[rule-path...]",
+		Description: `Run the tests declared for local rules.
+
+Tests are declared in a rule's frontmatter under "tests:" or in YAML files in
+.contexture/tests/. Each test renders the rule with a set of variables and
+checks that the output contains, or does not contain, given text.
+
+Inside a project the rules in the local rules directory are tested. Outside a
+project, such as in a rule repository, the rules in the current directory are
+tested. The command exits with an error if any test fails.`,
+		Metadata: helpCLI.ExamplesMetadata(
+			helpCLI.Example{Command: "contexture test"},
+			helpCLI.Example{Command: "contexture test team/style"},
+			helpCLI.Example{Command: "contexture test --output json"},
+		),
+		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Value:   "default",
+				Usage:   "Output format (default, json, github)",
+			},
+		},
+		Action: a.actions.TestAction,
+	}
+}
+
+func (a *Application) buildVendorCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "vendor",
+		Usage: "Copy the project's remote rules into the project for offline builds",
+		Description: `Copy the content of the project's remote rules, the indexes of their
+repositories, and the files their relative links point to into
+.contexture/vendor/, with a lock.json recording the SHA-256 checksum of each
+file.
+
+While the vendor directory exists, 'contexture build' resolves vendored rules
+from it instead of their repositories, after checking them against the
+lockfile, so builds are hermetic and work without network access, such as in
+Nix or Homebrew package builds. Rules that are not vendored, such as rules
+added since, are fetched as usual. Run the command again after adding,
+updating, or removing rules; it replaces the vendor directory.`,
+		Metadata: helpCLI.ExamplesMetadata(
+			helpCLI.Example{Command: "contexture vendor"},
+			helpCLI.Example{Command: "contexture vendor && git add .contexture/vendor", Description: "Commit the vendored rules"},
+		),
+		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+		Action:             a.actions.VendorAction,
+	}
+}
+
+func (a *Application) buildApproveCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "approve",
+		Usage:     "Approve rules pending approval so builds include them",
+		ArgsUsage: "<rule-id...>",
+		Description: `Approve rules that are pending approval, recording the approver and the
+time in the configuration so builds include them.
+
+When approval.required is set, rules added from providers outside
+approval.trustedProviders are recorded as pending, and builds skip them until
+they are approved. The approver is --by, else the git user, else $USER.
+Committing the configuration change leaves the approval in the history for
+change-control review.`,
+		Metadata: helpCLI.ExamplesMetadata(
+			helpCLI.Example{Command: "contexture approve @community/go/errors"},
+			helpCLI.Example{Command: "contexture approve @community/go/errors --by security@acme.com", Description: "Record the approver explicitly"},
+		),
+		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "by",
+				Usage: "Record the approval under this `name` instead of the git user",
+			},
+		},
+		Action: a.actions.ApproveAction,
+	}
+}
+
+func (a *Application) buildDecryptCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "decrypt",
+		Usage: "Restore the plaintext outputs from the encrypted bundle",
+		Description: `Restore the outputs of the project from the encrypted bundle that builds
+write when sensitive.encryptCommand is set, .contexture/outputs.enc by
+default, so outputs generated from rules with sensitive internal details can be
+distributed without committing them in plaintext.
+
+The bundle is decrypted with sensitive.decryptCommand, which reads it on stdin
+and writes the plaintext to stdout, such as 'age -d -i key.txt' or a script
+calling a KMS. The restored files replace the local outputs together. With
+sensitive.requireIgnored, nothing is restored unless git ignores every file.`,
+		Metadata: helpCLI.ExamplesMetadata(
+			helpCLI.Example{Command: "contexture decrypt"},
+			helpCLI.Example{Command: "git pull && contexture decrypt", Description: "Refresh the outputs after pulling a new bundle"},
+		),
+		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+		Action:             a.actions.DecryptAction,
+	}
+}
+
+func (a *Application) buildMergeConfigCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "merge-config",
+		Usage:     "Merge two versions of a configuration file, as a git merge driver",
+		ArgsUsage: "<base> <ours> <theirs>",
+		Description: `Merge the changes <ours> and <theirs> made to <base>, their common ancestor,
+and write the result to <ours>, as git merge drivers do.
+
+Rules, formats, providers, and saved filters are merged as sets keyed by
+ID, type, or name, and lists of strings such as presets by value, so rules
+added or removed on both sides never conflict. Other settings, and the
+settings of an entry both sides changed, are merged field by field. Settings
+both sides changed differently are listed, keep our value, and fail the
+command, so git reports the file as conflicted.
+
+Register the command as a merge driver in the git configuration and
+.gitattributes; see the command reference for the setup. Comments in the
+configuration are not kept.`,
+		Metadata: helpCLI.ExamplesMetadata(
+			helpCLI.Example{Command: "git config merge.contexture.driver 'contexture merge-config %O %A %B'", Description: "Register the merge driver"},
+			helpCLI.Example{Command: "contexture merge-config base.yaml ours.yaml theirs.yaml --output -", Description: "Print the merge of three files"},
+		),
+		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "Write the merged configuration to this file instead of <ours> (- for stdout)",
+			},
+		},
+		Action: a.actions.MergeConfigAction,
+	}
+}
+
+func (a *Application) buildSnapshotCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "snapshot",
+		Usage: "Compare generated outputs against recorded snapshots",
+		Description: `Build the project and compare the generated files against the snapshots
+recorded in .contexture/snapshots/.
+
+Each mismatched file is shown with a diff and the command exits with an error.
+Use --update to record the current outputs as the new snapshots. Generation
+timestamps are ignored when comparing.`,
+		Metadata: helpCLI.ExamplesMetadata(
+			helpCLI.Example{Command: "contexture snapshot --update"},
+			helpCLI.Example{Command: "contexture snapshot"},
+			helpCLI.Example{Command: "contexture snapshot --no-build"},
+		),
+		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:    "update",
+				Aliases: []string{"u"},
+				Usage:   "Record the current outputs as the snapshots",
+			},
+			&cli.BoolFlag{
+				Name:  "no-build",
+				Usage: "Use the files on disk instead of building first",
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Value:   "default",
+				Usage:   "Output format (default, json, github)",
+			},
+		},
+		Action: a.actions.SnapshotAction,
+	}
+}
+
+func (a *Application) buildNewCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "new",
+		Usage: "Scaffold new Contexture resources",
+		Description: `Scaffold new Contexture resources.
+
+Use 'contexture rules new' to create a single rule file.`,
+		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+		Action:             a.actions.NewGroupAction,
+		Commands: []*cli.Command{
+			a.buildNewRepoCommand(),
+		},
+	}
+}
+
+func (a *Application) buildNewRepoCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "repo",
+		Usage:     "Scaffold a rule repository",
+		ArgsUsage: "<dir>",
+		Description: `Create a rule repository in a new or empty directory.
+
+The repository contains an index.yaml manifest, example rules with tests,
+a GitHub Actions workflow that runs 'contexture repo lint' and
+'contexture test', a README, and a
+LICENSE placeholder. Push it to a Git host and add it as a provider to
+share the rules with your team.`,
+		Metadata: helpCLI.ExamplesMetadata(
+			helpCLI.Example{Command: "contexture new repo team-rules"},
+			helpCLI.Example{Command: "contexture new repo ./rules --name acme"},
+		),
+		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "name",
+				Aliases: []string{"n"},
+				Usage:   "Repository name (defaults to the directory name)",
+			},
+		},
+		Action: a.actions.NewRepoAction,
+	}
+}
+
+func (a *Application) buildGenerateCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "generate",
+		Usage: "Generate local rules from the project",
+		Description: `Generate local rules from what the project contains.
+
+Use subcommands to choose the rule to generate.`,
+		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+		Action:             a.actions.GenerateAction,
+		Commands: []*cli.Command{
+			{
+				Name:  "env-rule",
+				Usage: "Write a rule describing the project's commands, layout, and tooling",
+				Description: `Write the project facts rule, project-facts.md in the local rules directory.
+It lists the languages, the build and test commands from the Makefile,
+package.json scripts, and go.mod, the top-level directories, and the tools
+the project is configured for, so assistants know how to work in it.
+
+The rule is machine-maintained: running the command again rewrites it, and
+with generation.refreshEnvRule set, every 'contexture build' refreshes it.
+A rule edited to remove its generated marker is only replaced with --force.`,
+				Metadata: helpCLI.ExamplesMetadata(
+					helpCLI.Example{Command: "contexture generate env-rule"},
+					helpCLI.Example{Command: "contexture generate env-rule --dry-run", Description: "Print the rule without writing it"},
+				),
+				CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "Print the rule instead of writing it",
+					},
+					&cli.BoolFlag{
+						Name:  "force",
+						Usage: "Replace a project-facts.md that was not generated",
+					},
+				},
+				Action: a.actions.GenerateEnvRuleAction,
+			},
+			{
+				Name:  "git-rule",
+				Usage: "Write a rule describing the project's commit and branch conventions",
+				Description: `Write the git conventions rule, git-conventions.md in the local rules
+directory. It describes the commit message convention, taken from the
+commitlint configuration or from the last 100 commits, such as Conventional
+Commits with the types and scopes in use, and the branch naming prefixes of
+the project's branches, so assistants follow the project's conventions.
+
+The rule is machine-maintained: running the command again rewrites it, and
+with generation.refreshGitRule set, every 'contexture build' refreshes it.
+A rule edited to remove its generated marker is only replaced with --force.`,
+				Metadata: helpCLI.ExamplesMetadata(
+					helpCLI.Example{Command: "contexture generate git-rule"},
+					helpCLI.Example{Command: "contexture generate git-rule --dry-run", Description: "Print the rule without writing it"},
+				),
+				CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "Print the rule instead of writing it",
+					},
+					&cli.BoolFlag{
+						Name:  "force",
+						Usage: "Replace a git-conventions.md that was not generated",
+					},
+				},
+				Action: a.actions.GenerateGitRuleAction,
+			},
+		},
+	}
+}
+
+func (a *Application) buildIntegrateCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "integrate",
+		Usage: "Set up other tools to build the project's rules",
+		Description: `Configure tools that create development environments to run contexture.
+
+Use subcommands to choose the tool to integrate with.`,
+		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+		Action:             a.actions.IntegrateAction,
+		Commands: []*cli.Command{
+			{
+				Name:  "devcontainer",
+				Usage: "Build the rules when a devcontainer or Codespace is created",
+				Description: `Add a managed block to .devcontainer/devcontainer.json, or to
+.devcontainer.json when the project uses it, creating the file when the
+project has neither. The block sets postCreateCommand to run
+'contexture build --non-interactive' when the container is created, and
+mounts a volume at the contexture cache directory so cloned rule
+repositories outlive rebuilt containers.
+
+The block is delimited by // contexture:begin and // contexture:end
+comments: running the command again replaces it, and the rest of the file,
+comments included, is left as it is. Settings the block needs that the file
+already sets, such as a postCreateCommand of its own, are reported instead of
+overwritten. The container image must provide the contexture binary.`,
+				Metadata: helpCLI.ExamplesMetadata(
+					helpCLI.Example{Command: "contexture integrate devcontainer"},
+					helpCLI.Example{Command: "contexture integrate devcontainer --dry-run", Description: "Show the changes without writing them"},
+					helpCLI.Example{Command: "contexture integrate devcontainer --command 'make rules'", Description: "Run another command when the container is created"},
+					helpCLI.Example{Command: "contexture integrate devcontainer --remove", Description: "Remove the managed block"},
+				),
+				CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "Show the changes instead of writing them",
+					},
+					&cli.StringFlag{
+						Name:  "command",
+						Usage: "Command run when the container is created",
+						Value: devcontainer.DefaultCommand,
+					},
+					&cli.BoolFlag{
+						Name:  "no-cache-mount",
+						Usage: "Do not mount a volume at the cache directory",
+					},
+					&cli.BoolFlag{
+						Name:  "remove",
+						Usage: "Remove the managed block",
+					},
+				},
+				Action: a.actions.IntegrateDevcontainerAction,
+			},
+		},
+	}
+}
+
+func (a *Application) buildRepoCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "repo",
+		Usage: "Tools for rule repository maintainers",
+		Description: `Tools for maintaining a rule repository that is used as a provider.
+
+Use subcommands to check a repository before publishing it.`,
+		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+		Action:             a.actions.RepoAction,
+		Commands: []*cli.Command{
+			a.buildRepoLintCommand(),
+			a.buildRepoStatsCommand(),
+		},
+	}
+}
+
+func (a *Application) buildRepoLintCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "lint",
+		Usage:     "Validate a rule repository",
+		ArgsUsage: "[path]",
+		Description: `Validate the layout of a rule repository. Defaults to the current directory.
+
+Checks that:
+• Rule IDs are unique, ignoring case
+• Every rule has valid frontmatter
+• index.yaml, when present, lists exactly the rules in the repository
+• Every {{template "name"}} include refers to a partial defined in the rule
+• Every {{define "name"}} partial is included
+• Relative links resolve to files within the repository
+• HTTP links respond, unless --offline is set
+
+Links that responded are cached for a day, so repeated runs only request new
+or failing links.
+
+The command exits with an error if any issue is found, so it can gate pull
+requests into the repository.`,
+		Metadata: helpCLI.ExamplesMetadata(
+			helpCLI.Example{Command: "contexture repo lint"},
+			helpCLI.Example{Command: "contexture repo lint ./team-rules --output json"},
+			helpCLI.Example{Command: "contexture repo lint --offline"},
+		),
+		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Value:   "default",
+				Usage:   "Output format (default, json, github)",
+			},
+			&cli.BoolFlag{
+				Name:  "offline",
+				Usage: "Skip checking that HTTP links respond",
+			},
+		},
+		Action: a.actions.RepoLintAction,
+	}
+}
+
+func (a *Application) buildRepoStatsCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "stats",
+		Usage:     "Show statistics for a rule repository",
+		ArgsUsage: "[path|url]",
+		Description: `Show statistics for a rule repository at a local path or Git URL.
+Defaults to the current directory.
+
+Reports rule counts by top-level folder, tag, and language, the average body
+length, how long ago rules were last modified, and rules missing a
+description or trigger. Inside a Git repository, rules are dated by their
+last commit.`,
+		Metadata: helpCLI.ExamplesMetadata(
+			helpCLI.Example{Command: "contexture repo stats"},
+			helpCLI.Example{Command: "contexture repo stats ./team-rules"},
+			helpCLI.Example{Command: "contexture repo stats https://github.com/contextureai/rules.git --output json"},
+		),
+		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "ref",
+				Value: "main",
+				Usage: "Branch or tag to inspect when reading from a Git URL",
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Value:   "default",
+				Usage:   "Output format (default, json)",
+			},
+		},
+		Action: a.actions.RepoStatsAction,
+	}
+}
+
+func (a *Application) buildSyncCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "sync",
+		Usage: "Align project rules with an organization baseline",
+		Description: `Compare the project's rules with an organization baseline and propose
+changes to align them.
+
+The baseline is a configuration file, either a path relative to the project
+configuration or @provider/path/to/config.yaml in a provider's repository. It
+is remembered under "sync:" in .contexture.yaml after the first run.
+
+For each difference you can apply the change, keep the difference as an
+intentional deviation, or skip it for now. Kept deviations are recorded in
+.contexture.yaml with an optional reason and are not proposed again.`,
+		Metadata: helpCLI.ExamplesMetadata(
+			helpCLI.Example{Command: "contexture sync --baseline @acme/configs/baseline.yaml"},
+			helpCLI.Example{Command: "contexture sync"},
+			helpCLI.Example{Command: "contexture sync --dry-run"},
+			helpCLI.Example{Command: "contexture sync --yes"},
+		),
+		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "baseline",
+				Aliases: []string{"b"},
+				Usage:   "Baseline configuration to compare against",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "List differences without changing the configuration",
+			},
+			&cli.BoolFlag{
+				Name:    "yes",
+				Aliases: []string{"y"},
+				Usage:   "Apply all changes without prompting",
+			},
+		},
+		Action: a.actions.SyncAction,
+	}
+}
+
+func (a *Application) buildAuditCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "audit",
+		Usage: "Audit the configurations of many repositories",
+		Description: `Read the contexture configuration of every repository in a list and report,
+across all of them:
+
+• How many repositories use each remote rule
+• How many rules each repository has outdated
+• Baseline rules each repository omits or changes without a recorded deviation
+
+The list has one local path or Git URL per line, optionally followed by the
+branch to read. Paths are relative to the list file. Git URLs are cloned
+into the rule cache. Blank lines and lines starting with # are ignored.
+
+With --baseline, every repository is compared with the same baseline.
+Otherwise each is compared with the baseline recorded by 'contexture sync',
+if any. Repositories are never changed.
+
+Nothing is exported unless --export-adoption is given. It writes anonymized
+adoption counts, the number of repositories using each rule of each source
+without naming the repositories, to a file or POSTs them to a webhook, so
+rule maintainers learn which rules are used.`,
+		Metadata: helpCLI.ExamplesMetadata(
+			helpCLI.Example{Command: "contexture audit --repos repos.txt"},
+			helpCLI.Example{Command: "contexture audit --repos repos.txt --baseline @acme/configs/baseline.yaml"},
+			helpCLI.Example{Command: "contexture audit --repos repos.txt --output json"},
+			helpCLI.Example{Command: "contexture audit --repos repos.txt --export-adoption https://rules.acme.dev/adoption"},
+		),
+		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "repos",
+				Usage: "File listing the repositories to audit, one path or Git URL per line",
+			},
+			&cli.StringFlag{
+				Name:    "baseline",
+				Aliases: []string{"b"},
+				Usage:   "Baseline configuration every repository is compared with",
+			},
+			&cli.StringFlag{
+				Name:  "ref",
+				Value: "main",
+				Usage: "Branch to read from Git URLs that do not name one",
+			},
+			&cli.StringFlag{
+				Name:  "export-adoption",
+				Usage: "Export anonymized rule adoption counts to a file or http(s) webhook",
 			},
 			&cli.StringFlag{
 				Name:    "output",
@@ -226,115 +1519,87 @@ Examples:
 				Usage:   "Output format (default, json)",
 			},
 		},
-		Action: a.actions.AddAction,
+		Action: a.actions.AuditAction,
 	}
 }
 
-func (a *Application) buildRulesRemoveCommand() *cli.Command {
+func (a *Application) buildReportCommand() *cli.Command {
 	return &cli.Command{
-		Name:      "remove",
-		Aliases:   []string{"rm"},
-		Usage:     "Remove rules from the project",
-		ArgsUsage: "[rule-id...]",
-		Description: `Remove one or more rules from the current project.
-This will update the configuration and clean generated files.
-Rule IDs are required as arguments.`,
+		Name:  "report",
+		Usage: "Summarize rule changes for reviews",
+		Description: `Produce reports about the project's rules for code review.
+
+Use subcommands to choose the report.`,
 		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
-		Flags: []cli.Flag{
-			&cli.BoolFlag{
-				Name:    "global",
-				Aliases: []string{"g"},
-				Usage:   "Remove rule from global configuration",
-			},
-			&cli.StringFlag{
-				Name:    "output",
-				Aliases: []string{"o"},
-				Value:   "default",
-				Usage:   "Output format (default, json)",
-			},
+		Action:             a.actions.ReportAction,
+		Commands: []*cli.Command{
+			a.buildReportPRCommand(),
 		},
-		Action: a.actions.RemoveAction,
 	}
 }
 
-func (a *Application) buildBuildCommand() *cli.Command {
+func (a *Application) buildReportPRCommand() *cli.Command {
 	return &cli.Command{
-		Name:  "build",
-		Usage: "Build output files for all configured formats",
-		Description: `Build output files based on the configured rules and formats.
-This will fetch all rules, process templates, and write format-specific files.`,
+		Name:  "pr",
+		Usage: "Summarize rule changes as a pull request comment",
+		Description: `Compare the project's rules with the configuration at a base git ref and
+print a markdown summary of the rules added, removed, and updated.
+
+Each rule links to its file in the source repository when it is hosted on
+GitHub or GitLab. Updated rules list how their reference changed, and their
+content diff is shown in a collapsed block. Local rules are not listed,
+since their files appear in the pull request itself.
+
+The summary is printed to standard output, so CI can post it as a comment.`,
+		Metadata: helpCLI.ExamplesMetadata(
+			helpCLI.Example{Command: "contexture report pr --base origin/main"},
+			helpCLI.Example{Command: "contexture report pr --base origin/main > rule-changes.md"},
+			helpCLI.Example{Command: "contexture report pr --base HEAD~1 --offline"},
+		),
 		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
 		Flags: []cli.Flag{
-			&cli.BoolFlag{
-				Name:    "verbose",
-				Aliases: []string{"v"},
-				Usage:   "Show detailed progress information",
-			},
-			&cli.StringSliceFlag{
-				Name:  "formats",
-				Usage: "Build for specific formats only (claude, cursor, windsurf)",
+			&cli.StringFlag{
+				Name:    "base",
+				Aliases: []string{"b"},
+				Usage:   "Git ref to compare against, such as the pull request's target branch",
 			},
 			&cli.BoolFlag{
-				Name:    "force",
-				Aliases: []string{"f"},
-				Usage:   "Skip confirmation prompt when deleting files",
+				Name:  "offline",
+				Usage: "List changes without fetching rule content",
 			},
 		},
-		Action: a.actions.BuildAction,
+		Action: a.actions.ReportPRAction,
 	}
 }
 
-func (a *Application) buildQueryCommand() *cli.Command {
+func (a *Application) buildOutdatedCommand() *cli.Command {
 	return &cli.Command{
-		Name:      "query",
-		Usage:     "Search for rules across all providers",
-		ArgsUsage: "<search-text>",
-		Description: `Search for rules across all configured providers.
-
-By default, searches for text in rule titles and paths.
-Use --expr for advanced expression-based queries.
-
-Expression syntax documentation: https://expr-lang.org/docs/language-definition
-
-Available fields for --expr mode:
-  Direct fields:
-    ID            Rule identifier (string)
-    Title         Rule title (string)
-    Description   Rule description (string)
-    Tags          Rule tags ([]string)
-    Languages     Supported languages ([]string)
-    Frameworks    Supported frameworks ([]string)
-    Content       Rule content/body (string)
-    Variables     Rule variables (map)
-    Source        Source provider (string)
-    FilePath      File path in repository (string)
-
-  Computed fields:
-    Tag           Space-joined tags (string)
-    Language      Space-joined languages (string)
-    Framework     Space-joined frameworks (string)
-    Provider      Provider name (string)
-    Path          Extracted path from ID (string)
-    HasVars       Has variables (bool)
-    VarCount      Number of variables (int)
-    TriggerType   Trigger type (string)
-
-Examples:
-  # Simple text search
-  contexture query testing
-  contexture query "go test"
-
-  # Advanced expression search
-  contexture query --expr 'Tag contains "testing"'
-  contexture query --expr 'Language == "go" and Provider != "local"'
-  contexture query --expr 'any(Tags, # in ["security", "auth"])'
-  contexture query --expr 'HasVars == true and VarCount > 2'
-  contexture query --expr 'TriggerType == "file_change"'`,
+		Name:  "outdated",
+		Usage: "Report rules with updates available",
+		Description: `Report the project's remote rules that have updates available, without
+prompting or changing the configuration.
+
+Each outdated rule shows how many commits touched it since its current commit
+and how many days separate the two commits. Pinned rules are included and
+marked as pinned. Local rules are not checked.
+
+The JSON report is designed for dashboards that track rule freshness across
+many repositories.`,
+		Metadata: helpCLI.ExamplesMetadata(
+			helpCLI.Example{Command: "contexture outdated"},
+			helpCLI.Example{Command: "contexture outdated --json"},
+			helpCLI.Example{Command: "contexture outdated -g --json"},
+		),
 		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
 		Flags: []cli.Flag{
 			&cli.BoolFlag{
-				Name:  "expr",
-				Usage: "Use advanced expression syntax (see https://expr-lang.org)",
+				Name:    "global",
+				Aliases: []string{"g"},
+				Usage:   "Check the global rule configuration",
+			},
+			&cli.BoolFlag{
+				Name:  "json",
+				Usage: "Output the report as JSON (same as --output json)",
 			},
 			&cli.StringFlag{
 				Name:    "output",
@@ -342,18 +1607,8 @@ Examples:
 				Value:   "default",
 				Usage:   "Output format (default, json)",
 			},
-			&cli.StringSliceFlag{
-				Name:  "provider",
-				Usage: "Search specific providers only",
-			},
-			&cli.IntFlag{
-				Name:    "limit",
-				Aliases: []string{"n"},
-				Value:   10,
-				Usage:   "Maximum number of results",
-			},
 		},
-		Action: a.actions.QueryAction,
+		Action: a.actions.OutdatedAction,
 	}
 }
 
@@ -363,7 +1618,10 @@ func (a *Application) buildRulesListCommand() *cli.Command {
 		Aliases: []string{"ls"},
 		Usage:   "List rules",
 		Description: `List rules configured in the current project.
-To add rules, use 'contexture rules add' with rule IDs.`,
+To add rules, use 'contexture rules add' with rule IDs.
+
+With --verbose, each rule shows its last change: the pinned commit of remote
+rules from the cached repository, or the last commit of local rule files.`,
 		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
 		Flags: []cli.Flag{
 			&cli.StringFlag{
@@ -371,17 +1629,116 @@ To add rules, use 'contexture rules add' with rule IDs.`,
 				Aliases: []string{"p"},
 				Usage:   "Filter rules by regex pattern (matches ID, title, description, tags, etc.)",
 			},
+			&cli.StringSliceFlag{
+				Name:  "tag",
+				Usage: "Show only rules with this tag (can be used multiple times)",
+			},
+			&cli.StringSliceFlag{
+				Name:  "language",
+				Usage: "Show only rules for this language (can be used multiple times)",
+			},
+			&cli.StringSliceFlag{
+				Name:  "framework",
+				Usage: "Show only rules for this framework (can be used multiple times)",
+			},
+			&cli.StringFlag{
+				Name:  "filter-name",
+				Usage: "Apply a saved filter from the project or global configuration",
+			},
 			&cli.StringFlag{
 				Name:    "output",
 				Aliases: []string{"o"},
 				Usage:   "Output format (default, json)",
 				Value:   "default",
 			},
+			&cli.BoolFlag{
+				Name:    "verbose",
+				Aliases: []string{"v"},
+				Usage:   "Show the owner and the date, author, and commit of the last change of each rule",
+			},
 		},
 		Action: a.actions.ListAction,
 	}
 }
 
+func (a *Application) buildRulesCompareCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "compare",
+		Usage:     "Compare two rules side by side",
+		ArgsUsage: "<rule-a> <rule-b>",
+		Description: `Compare the metadata and content of two rules.
+Metadata is shown side by side and content differences are shown as a line diff,
+which helps choose between similar rules from different providers.`,
+		Metadata: helpCLI.ExamplesMetadata(
+			helpCLI.Example{Command: "contexture rules compare languages/go/testing @mycompany/go/testing"},
+			helpCLI.Example{Command: "contexture rules compare languages/go/testing languages/go/errors -o json"},
+		),
+		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "Output format (default, json)",
+				Value:   "default",
+			},
+		},
+		Action: a.actions.CompareAction,
+	}
+}
+
+func (a *Application) buildRulesShowCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "show",
+		Usage:     "Show a rule's full content",
+		ArgsUsage: "<rule-id>",
+		Description: `Show a rule's metadata and its full content rendered with the variables
+configured in the project. Content is shown as written, even when
+generation.summarize condenses the rule in generated output.
+
+Each variable is listed with where its value comes from: the rule's
+frontmatter, a preset, the provider's index, the project, or a --var flag,
+from the lowest precedence.`,
+		Metadata: helpCLI.ExamplesMetadata(
+			helpCLI.Example{Command: "contexture rules show languages/go/testing"},
+			helpCLI.Example{Command: "contexture rules show languages/go/testing --summary"},
+			helpCLI.Example{Command: "contexture rules show languages/go/testing --var framework=testify"},
+		),
+		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "summary",
+				Usage: "Show the condensed content used in generated output",
+			},
+			&cli.StringSliceFlag{
+				Name:  "var",
+				Usage: "Render with a variable set (can be used multiple times): --var key=value",
+			},
+		},
+		Action: a.actions.ShowAction,
+	}
+}
+
+func (a *Application) buildRulesLintCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "lint",
+		Usage: "Check third-party rules for injected instructions",
+		Description: `Check the project's third-party rules for instructions that try to take over
+the agent reading them: requests to ignore earlier instructions, to send
+secrets or credentials elsewhere, directives hidden in HTML comments, and
+invisible unicode characters. Local rules are not checked.
+
+Each check reports at the severity set in generation.safety.checks, warning
+by default, and the command fails when a finding is an error. Rules listed
+in generation.safety.reviewed are trusted and not checked. Builds run the
+same checks, and 'contexture build --quarantine' leaves flagged rules out.`,
+		Metadata: helpCLI.ExamplesMetadata(
+			helpCLI.Example{Command: "contexture rules lint"},
+		),
+		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+		Action:             a.actions.LintAction,
+	}
+}
+
 func (a *Application) buildRulesUpdateCommand() *cli.Command {
 	return &cli.Command{
 		Name:  "update",
@@ -389,7 +1746,7 @@ func (a *Application) buildRulesUpdateCommand() *cli.Command {
 		Description: `Update configured rules to their latest versions.
 This will check for updates and optionally apply them.`,
 		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
-		Flags: []cli.Flag{
+		Flags: append([]cli.Flag{
 			&cli.BoolFlag{
 				Name:    "global",
 				Aliases: []string{"g"},
@@ -410,7 +1767,9 @@ This will check for updates and optionally apply them.`,
 				Value:   "default",
 				Usage:   "Output format (default, json)",
 			},
-		},
+		}, profilingFlags()...),
+		Before: a.startProfiling,
+		After:  a.stopProfiling,
 		Action: a.actions.UpdateAction,
 	}
 }
@@ -424,18 +1783,18 @@ func (a *Application) buildRulesNewCommand() *cli.Command {
 
 Behavior modes:
 • Inside a Contexture project: Creates rule in the local 'rules/' directory
-• Outside a Contexture project: Creates rule at the literal path specified
-
-Examples:
-  contexture rules new my-rule
-  contexture rules new security/auth-check --name "Auth Check" --tags "security,auth"
-  contexture rules new path/to/custom-rule --description "Custom rule description"`,
+• Outside a Contexture project: Creates rule at the literal path specified`,
+		Metadata: helpCLI.ExamplesMetadata(
+			helpCLI.Example{Command: "contexture rules new my-rule"},
+			helpCLI.Example{Command: `contexture rules new security/auth-check --name "Auth Check" --tags "security,auth"`},
+			helpCLI.Example{Command: `contexture rules new path/to/custom-rule --description "Custom rule description"`},
+		),
 		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
 		Flags: []cli.Flag{
 			&cli.BoolFlag{
 				Name:    "global",
 				Aliases: []string{"g"},
-				Usage:   "Create rule in global rules directory (~/.contexture/rules)",
+				Usage:   "Create rule in global rules directory (~/.config/contexture/rules)",
 			},
 			&cli.StringFlag{
 				Name:    "name",
@@ -469,12 +1828,16 @@ Use subcommands to manage specific aspects of your configuration.`,
 			&cli.BoolFlag{
 				Name:    "global",
 				Aliases: []string{"g"},
-				Usage:   "View or modify global configuration (~/.contexture)",
+				Usage:   "View or modify global configuration (~/.config/contexture)",
 			},
 		},
 		Action: a.actions.ConfigAction,
 		Commands: []*cli.Command{
 			a.buildConfigShowCommand(),
+			a.buildConfigGetCommand(),
+			a.buildConfigSetCommand(),
+			a.buildConfigEffectiveCommand(),
+			a.buildConfigNormalizeCommand(),
 			a.buildConfigFormatsCommand(),
 		},
 	}
@@ -524,11 +1887,13 @@ func (a *Application) buildConfigFormatsAddCommand() *cli.Command {
 		ArgsUsage: "[format-type...] (if no args provided, shows interactive selection)",
 		Description: `Add output formats to your project configuration.
 
-Available formats: claude, cursor, windsurf
+Available formats: claude, cursor, windsurf, copilot, gemini
 
-When run without arguments, shows an interactive selection menu.`,
+When run without arguments, shows an interactive selection menu.
+--select and --all-matching choose formats without the menu, and --dry-run
+prints the formats that would be changed as JSON.`,
 		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
-		Flags:              []cli.Flag{},
+		Flags:              selectionFlags("formats"),
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			return a.actions.ConfigFormatsAddAction(ctx, cmd, a.deps)
 		},
@@ -543,9 +1908,11 @@ func (a *Application) buildConfigFormatsRemoveCommand() *cli.Command {
 		ArgsUsage: "[format-type...] (if no args provided, shows interactive selection)",
 		Description: `Remove output formats from your project configuration.
 
-When run without arguments, shows an interactive selection menu of configured formats.`,
+When run without arguments, shows an interactive selection menu of configured formats.
+--select and --all-matching choose formats without the menu, and --dry-run
+prints the formats that would be changed as JSON.`,
 		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
-		Flags:              []cli.Flag{},
+		Flags:              selectionFlags("formats"),
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			return a.actions.ConfigFormatsRemoveAction(ctx, cmd, a.deps)
 		},
@@ -561,9 +1928,11 @@ func (a *Application) buildConfigFormatsEnableCommand() *cli.Command {
 
 The format must already be added to the project configuration.
 
-When run without arguments, shows an interactive selection menu.`,
+When run without arguments, shows an interactive selection menu.
+--select and --all-matching choose formats without the menu, and --dry-run
+prints the formats that would be changed as JSON.`,
 		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
-		Flags:              []cli.Flag{},
+		Flags:              selectionFlags("formats"),
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			return a.actions.ConfigFormatsEnableAction(ctx, cmd, a.deps)
 		},
@@ -579,9 +1948,11 @@ func (a *Application) buildConfigFormatsDisableCommand() *cli.Command {
 
 At least one format must remain enabled in the project.
 
-When run without arguments, shows an interactive selection menu.`,
+When run without arguments, shows an interactive selection menu.
+--select and --all-matching choose formats without the menu, and --dry-run
+prints the formats that would be changed as JSON.`,
 		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
-		Flags:              []cli.Flag{},
+		Flags:              selectionFlags("formats"),
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			return a.actions.ConfigFormatsDisableAction(ctx, cmd, a.deps)
 		},
@@ -602,6 +1973,136 @@ This is the default action when running 'contexture config' without subcommands.
 	}
 }
 
+func (a *Application) buildConfigGetCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "get",
+		Usage:     "Print a configuration value",
+		ArgsUsage: "<path>",
+		Description: `Print the value at a path in the configuration, such as
+generation.parallelFetches or formats[type=cursor].enabled.
+
+Paths use the keys of the configuration file. List items are selected by
+index, formats[0], or by a field, formats[type=claude]; keys containing dots
+are quoted, mcpServers["my.server"]. Single values print as text, sections
+as YAML, and anything as JSON with --output json.`,
+		Metadata: helpCLI.ExamplesMetadata(
+			helpCLI.Example{Command: "contexture config get generation.parallelFetches"},
+			helpCLI.Example{Command: "contexture config get formats[type=cursor]"},
+			helpCLI.Example{Command: `contexture config get 'rules[id="@contexture/languages/go/style"].variables' -o json`},
+		),
+		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:    "global",
+				Aliases: []string{"g"},
+				Usage:   "Read the global configuration",
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Value:   "default",
+				Usage:   "Output format (default, json)",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return a.actions.ConfigGetAction(ctx, cmd, a.deps)
+		},
+	}
+}
+
+func (a *Application) buildConfigSetCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "set",
+		Usage:     "Change a configuration value",
+		ArgsUsage: "<path> <value>",
+		Description: `Store a value at a path in the configuration, using the paths of
+'contexture config get'. Missing sections are created.
+
+The value is read as YAML and must fit the type the path holds, so lists are
+written as [a, b]. The configuration is validated before it is saved. Changing
+a format or rule merged from an extended configuration overrides it locally.`,
+		Metadata: helpCLI.ExamplesMetadata(
+			helpCLI.Example{Command: "contexture config set generation.parallelFetches 4"},
+			helpCLI.Example{Command: "contexture config set formats[type=cursor].enabled false"},
+			helpCLI.Example{Command: `contexture config set formats[type=claude].include '["languages/go/**"]'`},
+		),
+		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:    "global",
+				Aliases: []string{"g"},
+				Usage:   "Change the global configuration",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return a.actions.ConfigSetAction(ctx, cmd, a.deps)
+		},
+	}
+}
+
+func (a *Application) buildConfigEffectiveCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "effective",
+		Usage: "Show the merged configuration and where each part comes from",
+		Description: `Print the configuration builds use: the global configuration, the project
+configuration with the base configurations it extends, the local rules
+directory, and the personal overrides in .contexture.local.yaml, merged.
+Each format, provider, and rule is shown with the layer it comes from.
+
+With --diff, only what the other layers add to, change in, or remove from the
+project configuration as written is listed.`,
+		Metadata: helpCLI.ExamplesMetadata(
+			helpCLI.Example{Command: "contexture config effective"},
+			helpCLI.Example{Command: "contexture config effective --diff"},
+		),
+		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "diff",
+				Usage: "Only list what other layers change compared to the project configuration",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return a.actions.ConfigEffectiveAction(ctx, cmd, a.deps)
+		},
+	}
+}
+
+func (a *Application) buildConfigNormalizeCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "normalize",
+		Usage: "Rewrite rule IDs in one form and merge duplicate rules",
+		Description: `Rewrite the rule IDs of the configuration in the form 'contexture rules add'
+stores them: simple paths become [contexture:path], and surrounding spaces
+and stray slashes are removed.
+
+Rules written more than once under different spellings, such as
+languages/go/testing and [contexture:Languages/go/testing/], are merged into
+the first entry. Variables set only on a removed entry are kept. Such
+duplicates are reported as warnings whenever the configuration is loaded.`,
+		Metadata: helpCLI.ExamplesMetadata(
+			helpCLI.Example{Command: "contexture config normalize --dry-run"},
+			helpCLI.Example{Command: "contexture config normalize"},
+			helpCLI.Example{Command: "contexture config normalize --global"},
+		),
+		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:    "global",
+				Aliases: []string{"g"},
+				Usage:   "Normalize the global configuration",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "List the changes without saving them",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return a.actions.ConfigNormalizeAction(ctx, cmd, a.deps)
+		},
+	}
+}
+
 func (a *Application) buildProvidersCommand() *cli.Command {
 	return &cli.Command{
 		Name:  "providers",
@@ -646,9 +2147,13 @@ func (a *Application) buildProvidersAddCommand() *cli.Command {
 
 The provider name will be available for use with the @provider/path syntax.
 
-Examples:
-  contexture providers add mycompany https://github.com/mycompany/rules.git
-  contexture providers add team-security git@github.com:team/security-rules.git`,
+Mirrors are tried in order when the provider URL cannot be reached or refuses
+the credentials.`,
+		Metadata: helpCLI.ExamplesMetadata(
+			helpCLI.Example{Command: "contexture providers add mycompany https://github.com/mycompany/rules.git"},
+			helpCLI.Example{Command: "contexture providers add team-security git@github.com:team/security-rules.git"},
+			helpCLI.Example{Command: "contexture providers add mycompany https://github.com/mycompany/rules.git --mirror https://gitlab.com/mycompany/rules.git"},
+		),
 		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
 		Flags: []cli.Flag{
 			&cli.BoolFlag{
@@ -656,6 +2161,10 @@ Examples:
 				Aliases: []string{"g"},
 				Usage:   "Add provider to global configuration",
 			},
+			&cli.StringSliceFlag{
+				Name:  "mirror",
+				Usage: "Mirror URL tried when the provider URL is unavailable (repeatable)",
+			},
 		},
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			return a.actions.ProvidersAddAction(ctx, cmd, a.deps)
@@ -671,10 +2180,10 @@ func (a *Application) buildProvidersRemoveCommand() *cli.Command {
 		ArgsUsage: "<name>",
 		Description: `Remove a custom provider from your project configuration.
 
-Note: You cannot remove the default @contexture provider.
-
-Examples:
-  contexture providers remove mycompany`,
+Note: You cannot remove the default @contexture provider.`,
+		Metadata: helpCLI.ExamplesMetadata(
+			helpCLI.Example{Command: "contexture providers remove mycompany"},
+		),
 		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
 		Flags: []cli.Flag{
 			&cli.BoolFlag{
@@ -691,17 +2200,128 @@ Examples:
 
 func (a *Application) buildProvidersShowCommand() *cli.Command {
 	return &cli.Command{
-		Name:      "show",
-		Usage:     "Show details for a provider",
-		ArgsUsage: "<name>",
-		Description: `Display detailed information about a specific provider.
-
-Examples:
-  contexture providers show contexture
-  contexture providers show @mycompany`,
+		Name:        "show",
+		Usage:       "Show details for a provider",
+		ArgsUsage:   "<name>",
+		Description: `Display detailed information about a specific provider.`,
+		Metadata: helpCLI.ExamplesMetadata(
+			helpCLI.Example{Command: "contexture providers show contexture"},
+			helpCLI.Example{Command: "contexture providers show @mycompany"},
+		),
 		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			return a.actions.ProvidersShowAction(ctx, cmd, a.deps)
 		},
 	}
 }
+
+func (a *Application) buildCacheCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "cache",
+		Usage: "Manage the rule cache",
+		Description: `Manage the cache of rule repositories and rendered rules.
+
+Use subcommands to clean up the cache.`,
+		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+		Action:             a.actions.CacheAction,
+		Commands: []*cli.Command{
+			a.buildCacheGCCommand(),
+		},
+	}
+}
+
+func (a *Application) buildCacheGCCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "gc",
+		Usage: "Remove leftovers of interrupted commands",
+		Description: `Remove what interrupted commands left behind and report the space reclaimed:
+temporary clone and staging directories, cached clones that did not complete,
+temporary files of configuration saves and cache writes, and git lock files in
+cached repositories.
+
+Leftovers younger than an hour are kept, as they may belong to a command still
+running. Commands run the same cleanup at startup at most once a day; set
+CONTEXTURE_NO_GC to turn it off.`,
+		Metadata: helpCLI.ExamplesMetadata(
+			helpCLI.Example{Command: "contexture cache gc"},
+			helpCLI.Example{Command: "contexture cache gc --dry-run", Description: "List the leftovers without removing them"},
+		),
+		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "List the leftovers without removing them",
+			},
+		},
+		Action: a.actions.CacheGCAction,
+	}
+}
+
+func (a *Application) buildDebugCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "debug",
+		Usage: "Troubleshooting tools",
+		Description: `Tools for troubleshooting contexture and reporting issues.
+
+Use subcommands to collect diagnostic information.`,
+		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+		Action:             a.actions.DebugAction,
+		Commands: []*cli.Command{
+			a.buildDebugBundleCommand(),
+		},
+	}
+}
+
+func (a *Application) buildDebugBundleCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "bundle",
+		Usage: "Write a diagnostics bundle for bug reports",
+		Description: `Write a diagnostics bundle containing version information, sanitized
+project configuration, relevant environment variables, and recent log lines.
+
+Credentials in configuration values and URLs are redacted. Attach the bundle
+when filing an issue.`,
+		Metadata: helpCLI.ExamplesMetadata(
+			helpCLI.Example{Command: "contexture debug bundle"},
+			helpCLI.Example{Command: "contexture debug bundle --dir ./diagnostics"},
+		),
+		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "dir",
+				Usage: "Directory to write the bundle to (defaults to the system temp directory)",
+			},
+		},
+		Action: a.actions.DebugBundleAction,
+	}
+}
+
+func (a *Application) buildBenchCommand() *cli.Command {
+	return &cli.Command{
+		Name:   "bench",
+		Usage:  "Measure generation pipeline performance",
+		Hidden: true,
+		Description: `Time config merging, rule parsing, template rendering, and format generation
+against a synthetic project, without network or filesystem access.
+
+Use this to compare performance locally before and after a change.`,
+		Metadata: helpCLI.ExamplesMetadata(
+			helpCLI.Example{Command: "contexture bench"},
+			helpCLI.Example{Command: "contexture bench --rules 2000 --iterations 10"},
+		),
+		CustomHelpTemplate: helpCLI.CommandHelpTemplate,
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:  "rules",
+				Value: benchmark.DefaultRuleCount,
+				Usage: "Number of rules in the synthetic project",
+			},
+			&cli.IntFlag{
+				Name:  "iterations",
+				Value: 5,
+				Usage: "Number of times each stage is run",
+			},
+		},
+		Action: a.actions.BenchAction,
+	}
+}
@@ -2,12 +2,20 @@ package app
 
 import (
 	"context"
+	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/permissions"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/contextureai/contexture/internal/tui"
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/urfave/cli/v3"
 )
 
@@ -51,6 +59,30 @@ func TestRun(t *testing.T) {
 	})
 }
 
+func TestJSONOutputRequested(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		args     []string
+		expected bool
+	}{
+		{name: "no output flag", args: []string{"contexture", "build"}, expected: false},
+		{name: "long flag", args: []string{"contexture", "validate", "--output", "json"}, expected: true},
+		{name: "short flag", args: []string{"contexture", "rules", "list", "-o", "json"}, expected: true},
+		{name: "equals form", args: []string{"contexture", "validate", "--output=json"}, expected: true},
+		{name: "json flag", args: []string{"contexture", "outdated", "--json"}, expected: true},
+		{name: "default output", args: []string{"contexture", "validate", "--output", "default"}, expected: false},
+		{name: "dangling flag", args: []string{"contexture", "validate", "-o"}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, jsonOutputRequested(tt.args))
+		})
+	}
+}
+
 func TestApplication_Execute(t *testing.T) {
 	t.Run("executes_help_successfully", func(t *testing.T) {
 		deps := dependencies.NewForTesting(context.Background())
@@ -70,6 +102,16 @@ func TestApplication_Execute(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("shows_help_topics_and_restores_help_hooks", func(t *testing.T) {
+		deps := dependencies.NewForTesting(context.Background())
+		app := New(deps)
+		original := reflect.ValueOf(cli.ShowCommandHelp).Pointer()
+
+		err := app.Execute(context.Background(), []string{"contexture", "help", "rule-ids"})
+		require.NoError(t, err)
+		assert.Equal(t, original, reflect.ValueOf(cli.ShowCommandHelp).Pointer())
+	})
+
 	t.Run("respects_context_cancellation", func(_ *testing.T) {
 		deps := dependencies.NewForTesting(context.Background())
 		app := New(deps)
@@ -139,7 +181,7 @@ func TestApplication_buildCommands(t *testing.T) {
 	commands := app.buildCommands()
 
 	t.Run("returns_expected_number_of_commands", func(t *testing.T) {
-		assert.Len(t, commands, 6) // init, rules, build, query, config, providers
+		assert.Len(t, commands, 30) // init, import, rules, approve, build, sync, vendor, decrypt, outdated, report, audit, validate, test, snapshot, query, star, unstar, profile, vars, edit, new, generate, integrate, repo, config, merge-config, providers, cache, debug, bench
 	})
 
 	t.Run("all_commands_have_required_fields", func(t *testing.T) {
@@ -160,9 +202,166 @@ func TestApplication_buildGlobalFlags(t *testing.T) {
 	flags := app.buildGlobalFlags()
 
 	t.Run("has_verbose_flag", func(t *testing.T) {
-		assert.Len(t, flags, 1)
+		assert.Len(t, flags, 9)
 		assert.Equal(t, "verbose", flags[0].Names()[0])
 	})
+
+	t.Run("has_quiet_flag", func(t *testing.T) {
+		assert.Equal(t, []string{"quiet", "q"}, flags[1].Names())
+	})
+
+	t.Run("has_non_interactive_flag", func(t *testing.T) {
+		assert.Equal(t, "non-interactive", flags[2].Names()[0])
+	})
+
+	t.Run("has_project_flags", func(t *testing.T) {
+		assert.Equal(t, []string{"project-dir", "C"}, flags[3].Names())
+		assert.Equal(t, "config", flags[4].Names()[0])
+	})
+
+	t.Run("has_session_flags", func(t *testing.T) {
+		assert.Equal(t, "record", flags[5].Names()[0])
+		assert.Equal(t, "replay", flags[6].Names()[0])
+	})
+
+	t.Run("has_strict_config_flag", func(t *testing.T) {
+		assert.Equal(t, "strict-config", flags[7].Names()[0])
+	})
+
+	t.Run("has_read_only_flag", func(t *testing.T) {
+		assert.Equal(t, "read-only", flags[8].Names()[0])
+	})
+}
+
+func TestApplication_applyReadOnly(t *testing.T) {
+	t.Parallel()
+	deps := dependencies.NewForTesting(context.Background())
+	fs := deps.FS
+	app := New(deps)
+
+	app.applyReadOnly(false)
+	assert.Same(t, fs, deps.FS)
+
+	app.applyReadOnly(true)
+	require.IsType(t, &permissions.ReadOnlyFs{}, deps.FS)
+	require.NoError(t, fs.MkdirAll("/project", 0o755))
+	require.NoError(t, afero.WriteFile(deps.FS, "/project/CLAUDE.md", []byte("rules"), 0o644))
+	exists, err := afero.Exists(fs, "/project/CLAUDE.md")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestReadOnlyPath(t *testing.T) {
+	t.Parallel()
+	workingDir := dependencies.StaticWorkingDir("/project")
+
+	assert.Equal(t, "CLAUDE.md", readOnlyPath(workingDir, "/project/CLAUDE.md"))
+	assert.Equal(t, "/other/CLAUDE.md", readOnlyPath(workingDir, "/other/CLAUDE.md"))
+	assert.Equal(t, "CLAUDE.md", readOnlyPath(workingDir, "CLAUDE.md"))
+}
+
+func TestApplication_applySessionFlags(t *testing.T) {
+	deps := dependencies.NewForTesting(context.Background())
+	app := New(deps)
+	t.Cleanup(tui.StopSession)
+
+	err := app.applySessionFlags("session.json", "session.json")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be used together")
+
+	require.NoError(t, app.applySessionFlags("session.json", ""))
+	require.NoError(t, app.finishSession())
+	recordFile, err := filepath.Abs("session.json")
+	require.NoError(t, err)
+	session, err := tui.LoadSession(deps.FS, recordFile)
+	require.NoError(t, err)
+	assert.Empty(t, session.Forms)
+
+	err = app.applySessionFlags("", "missing.json")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read missing.json")
+}
+
+func TestApplication_applyProjectFlags(t *testing.T) {
+	t.Parallel()
+	root := "/work"
+	sub := filepath.Join(root, "sub")
+
+	newApp := func(t *testing.T) (*Application, *dependencies.Dependencies) {
+		t.Helper()
+		deps := dependencies.NewForTesting(context.Background()).WithWorkingDir(dependencies.StaticWorkingDir(root))
+		require.NoError(t, deps.FS.MkdirAll(filepath.Join(sub, ".contexture"), 0o755))
+		return New(deps), deps
+	}
+	workingDir := func(t *testing.T, deps *dependencies.Dependencies) string {
+		t.Helper()
+		dir, err := deps.GetWorkingDir().Getwd()
+		require.NoError(t, err)
+		return dir
+	}
+
+	t.Run("changes_to_project_dir", func(t *testing.T) {
+		t.Parallel()
+		app, deps := newApp(t)
+		require.NoError(t, app.applyProjectFlags("sub", ""))
+		assert.Equal(t, sub, workingDir(t, deps))
+		assert.Empty(t, deps.ConfigFile)
+	})
+
+	t.Run("config_selects_its_project", func(t *testing.T) {
+		t.Parallel()
+		app, deps := newApp(t)
+		require.NoError(t, app.applyProjectFlags("", filepath.Join("sub", ".contexture", ".contexture.yaml")))
+		assert.Equal(t, sub, workingDir(t, deps))
+		assert.Equal(t, filepath.Join(sub, ".contexture", ".contexture.yaml"), deps.ConfigFile)
+	})
+
+	t.Run("project_dir_takes_precedence", func(t *testing.T) {
+		t.Parallel()
+		app, deps := newApp(t)
+		require.NoError(t, app.applyProjectFlags(root, filepath.Join("sub", "ci.yaml")))
+		assert.Equal(t, root, workingDir(t, deps))
+		assert.Equal(t, filepath.Join(sub, "ci.yaml"), deps.ConfigFile)
+	})
+
+	t.Run("rejects_missing_directory", func(t *testing.T) {
+		t.Parallel()
+		app, _ := newApp(t)
+		err := app.applyProjectFlags(filepath.Join(root, "missing"), "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "is not a directory")
+	})
+
+	t.Run("keeps_process_directory", func(t *testing.T) {
+		t.Parallel()
+		before, err := os.Getwd()
+		require.NoError(t, err)
+		app, _ := newApp(t)
+		require.NoError(t, app.applyProjectFlags("sub", ""))
+		after, err := os.Getwd()
+		require.NoError(t, err)
+		assert.Equal(t, before, after)
+	})
+}
+
+func TestApplication_CollectsGarbageInProjectDir(t *testing.T) {
+	deps := dependencies.NewForTesting(context.Background()).
+		WithWorkingDir(dependencies.StaticWorkingDir("/work")).
+		WithEnv(dependencies.MapEnvironment{"HOME": "/home/user", project.HomeEnv: "/state"})
+	app := New(deps)
+
+	// A configuration save interrupted two hours ago in the project directory
+	leftover := "/work/sub/.contexture.yaml.tmp"
+	require.NoError(t, afero.WriteFile(deps.FS, leftover, []byte("version: 1"), 0o644))
+	old := time.Now().Add(-2 * time.Hour)
+	require.NoError(t, deps.FS.Chtimes(leftover, old, old))
+
+	// The project has no configuration, so the command fails after startup
+	_ = app.Execute(context.Background(), []string{"contexture", "--project-dir", "sub", "validate"})
+
+	exists, err := afero.Exists(deps.FS, leftover)
+	require.NoError(t, err)
+	assert.False(t, exists)
 }
 
 func TestApplication_setupGlobalFlags(t *testing.T) {
@@ -179,6 +378,41 @@ func TestApplication_setupGlobalFlags(t *testing.T) {
 	})
 }
 
+func TestApplication_ProfilingFlags(t *testing.T) {
+	deps := dependencies.NewForTesting(context.Background())
+	app := New(deps)
+
+	t.Run("heavy_commands_have_profiling_flags", func(t *testing.T) {
+		for _, cmd := range []*cli.Command{
+			app.buildBuildCommand(),
+			app.buildRulesAddCommand(),
+			app.buildRulesUpdateCommand(),
+		} {
+			var names []string
+			for _, flag := range cmd.Flags {
+				names = append(names, flag.Names()[0])
+			}
+			assert.Contains(t, names, "cpuprofile", "command %s", cmd.Name)
+			assert.Contains(t, names, "memprofile", "command %s", cmd.Name)
+			assert.NotNil(t, cmd.Before, "command %s", cmd.Name)
+			assert.NotNil(t, cmd.After, "command %s", cmd.Name)
+		}
+	})
+
+	t.Run("writes_profiles_even_when_command_fails", func(t *testing.T) {
+		// The in-memory filesystem has no project configuration, so build fails
+		err := app.Execute(context.Background(),
+			[]string{"contexture", "build", "--cpuprofile", "/cpu.prof", "--memprofile", "/mem.prof"})
+		require.Error(t, err)
+
+		for _, path := range []string{"/cpu.prof", "/mem.prof"} {
+			data, readErr := afero.ReadFile(deps.FS, path)
+			require.NoError(t, readErr, path)
+			assert.NotEmpty(t, data, path)
+		}
+	})
+}
+
 // Test individual command builders
 func TestApplication_CommandBuilders(t *testing.T) {
 	t.Parallel()
@@ -194,6 +428,7 @@ func TestApplication_CommandBuilders(t *testing.T) {
 		{"rules", func() *cli.Command { return app.buildRulesCommand() }, "rules"},
 		{"build", func() *cli.Command { return app.buildBuildCommand() }, "build"},
 		{"config", func() *cli.Command { return app.buildConfigCommand() }, "config"},
+		{"debug", func() *cli.Command { return app.buildDebugCommand() }, "debug"},
 	}
 
 	for _, tt := range tests {
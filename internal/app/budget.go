@@ -0,0 +1,35 @@
+package app
+
+import (
+	"context"
+
+	"github.com/charmbracelet/log"
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/contextureai/contexture/internal/git"
+	"github.com/contextureai/contexture/internal/project"
+)
+
+// applyExecutionBudget applies the timeouts of the project's generation
+// settings, and their environment overrides, to git operations and to the
+// command as a whole. An invalid budget is reported and the defaults are
+// used, so commands that fix the configuration still run.
+func (a *Application) applyExecutionBudget(ctx context.Context) context.Context {
+	var generation *domain.GenerationConfig
+	if dir, err := a.deps.GetWorkingDir().Getwd(); err == nil {
+		if result, err := project.NewManagerFromDependencies(a.deps).LoadConfig(dir); err == nil && result.Config != nil {
+			generation = result.Config.Generation
+		}
+	}
+
+	budget, err := generation.Budget(a.deps.GetEnv().Getenv)
+	if err != nil {
+		log.Warn("Ignoring invalid execution budget", "error", err)
+		return ctx
+	}
+
+	git.SetDefaultTimeouts(budget.CloneTimeout, budget.PullTimeout)
+	if budget.CommandTimeout > 0 {
+		ctx, a.cancelDeadline = context.WithTimeout(ctx, budget.CommandTimeout)
+	}
+	return ctx
+}
@@ -0,0 +1,27 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/contextureai/contexture/internal/commands"
+	"github.com/urfave/cli/v3"
+)
+
+// maxRecentCompletions is the number of recently used rules completed
+const maxRecentCompletions = 20
+
+// completeRecentRules completes rule IDs with the rules the user added or
+// viewed recently, most recent first, and flags as usual
+func (a *Application) completeRecentRules(ctx context.Context, cmd *cli.Command) {
+	// The word being completed precedes the completion flag the shell appends
+	if args := os.Args; len(args) > 1 && strings.HasPrefix(args[len(args)-2], "-") {
+		cli.DefaultCompleteWithFlags(ctx, cmd)
+		return
+	}
+	for _, id := range commands.RecentRules(a.deps, maxRecentCompletions) {
+		_, _ = fmt.Fprintln(cmd.Root().Writer, id)
+	}
+}
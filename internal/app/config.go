@@ -2,40 +2,42 @@
 package app
 
 import (
-	"os"
-
+	"github.com/contextureai/contexture/internal/dependencies"
 	"github.com/contextureai/contexture/internal/domain"
 	"github.com/spf13/afero"
 )
 
 // ConfigDisplayer provides configuration information display
 type ConfigDisplayer struct {
-	fs afero.Fs
+	fs  afero.Fs
+	env dependencies.Environment
 }
 
-// NewConfigManager creates a new configuration displayer
-func NewConfigManager(fs afero.Fs) *ConfigDisplayer {
+// NewConfigManager creates a new configuration displayer reading env
+func NewConfigManager(fs afero.Fs, env dependencies.Environment) *ConfigDisplayer {
 	return &ConfigDisplayer{
-		fs: fs,
+		fs:  fs,
+		env: env,
 	}
 }
 
 // Load shows current configuration values from environment and defaults
 func (c *ConfigDisplayer) Load() (*ConfigInfo, error) {
 	return &ConfigInfo{
-		LogLevel:    getEnvWithDefault("CONTEXTURE_LOG_LEVEL", "info"),
-		LogFormat:   getEnvWithDefault("CONTEXTURE_LOG_FORMAT", "console"),
-		Verbose:     getBoolEnvWithDefault("CONTEXTURE_VERBOSE", false),
-		EnableDebug: getBoolEnvWithDefault("CONTEXTURE_DEBUG", false),
+		LogLevel:    getEnvWithDefault(c.env, "CONTEXTURE_LOG_LEVEL", "info"),
+		LogFormat:   getEnvWithDefault(c.env, "CONTEXTURE_LOG_FORMAT", "console"),
+		Verbose:     getBoolEnvWithDefault(c.env, "CONTEXTURE_VERBOSE", false),
+		EnableDebug: getBoolEnvWithDefault(c.env, "CONTEXTURE_DEBUG", false),
 		DefaultRepository: getEnvWithDefault(
+			c.env,
 			"CONTEXTURE_DEFAULT_REPOSITORY",
 			domain.DefaultRepository,
 		),
-		DefaultBranch: getEnvWithDefault("CONTEXTURE_DEFAULT_BRANCH", domain.DefaultBranch),
+		DefaultBranch: getEnvWithDefault(c.env, "CONTEXTURE_DEFAULT_BRANCH", domain.DefaultBranch),
 		DefaultFormats: []string{
 			"claude",
 		}, // Simplified - actual formats come from project config
-		CacheEnabled: getBoolEnvWithDefault("CONTEXTURE_CACHE_ENABLED", true),
+		CacheEnabled: getBoolEnvWithDefault(c.env, "CONTEXTURE_CACHE_ENABLED", true),
 	}, nil
 }
 
@@ -68,6 +70,12 @@ REPOSITORY:
 CACHE:
   CONTEXTURE_CACHE_ENABLED       Enable caching (true, false) [default: true]
 
+EXECUTION BUDGET (override the generation settings of .contexture.yaml):
+  CONTEXTURE_PARALLEL_FETCHES    Rules fetched concurrently, 1 to 20 [default: 5]
+  CONTEXTURE_CLONE_TIMEOUT       Timeout of each git clone, such as 5m [default: 5m]
+  CONTEXTURE_PULL_TIMEOUT        Timeout of each git pull, such as 2m [default: 2m]
+  CONTEXTURE_COMMAND_TIMEOUT     Deadline of a whole command, such as 10m [default: none]
+
 CONFIGURATION FILES:
   Project configuration files (.contexture.yaml):
   1. .contexture.yaml (current directory)
@@ -81,15 +89,15 @@ EXAMPLES:
 }
 
 // Helper functions
-func getEnvWithDefault(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
+func getEnvWithDefault(env dependencies.Environment, key, defaultValue string) string {
+	if value := env.Getenv(key); value != "" {
 		return value
 	}
 	return defaultValue
 }
 
-func getBoolEnvWithDefault(key string, defaultValue bool) bool {
-	value := os.Getenv(key)
+func getBoolEnvWithDefault(env dependencies.Environment, key string, defaultValue bool) bool {
+	value := env.Getenv(key)
 	if value == "" {
 		return defaultValue
 	}
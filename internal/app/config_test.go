@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/contextureai/contexture/internal/dependencies"
 	"github.com/contextureai/contexture/internal/domain"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
@@ -13,15 +14,15 @@ import (
 func TestNewConfigManager(t *testing.T) {
 	t.Parallel()
 	fs := afero.NewMemMapFs()
-	manager := NewConfigManager(fs)
+	manager := NewConfigManager(fs, dependencies.MapEnvironment{})
 
 	assert.NotNil(t, manager)
 	assert.Equal(t, fs, manager.fs)
 }
 
 func TestConfigDisplayer_Load(t *testing.T) {
+	t.Parallel()
 	fs := afero.NewMemMapFs()
-	manager := NewConfigManager(fs)
 
 	tests := []struct {
 		name     string
@@ -86,10 +87,8 @@ func TestConfigDisplayer_Load(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Set environment variables
-			for key, value := range tt.envVars {
-				t.Setenv(key, value)
-			}
+			t.Parallel()
+			manager := NewConfigManager(fs, dependencies.MapEnvironment(tt.envVars))
 
 			config, err := manager.Load()
 			require.NoError(t, err)
@@ -101,7 +100,7 @@ func TestConfigDisplayer_Load(t *testing.T) {
 func TestConfigDisplayer_GetEnvironmentDocumentation(t *testing.T) {
 	t.Parallel()
 	fs := afero.NewMemMapFs()
-	manager := NewConfigManager(fs)
+	manager := NewConfigManager(fs, dependencies.MapEnvironment{})
 
 	doc := manager.GetEnvironmentDocumentation()
 
@@ -132,6 +131,7 @@ func TestConfigDisplayer_GetEnvironmentDocumentation(t *testing.T) {
 }
 
 func TestGetEnvWithDefault(t *testing.T) {
+	t.Parallel()
 	tests := []struct {
 		name         string
 		key          string
@@ -164,17 +164,20 @@ func TestGetEnvWithDefault(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			env := dependencies.MapEnvironment{}
 			if tt.envValue != "" {
-				t.Setenv(tt.key, tt.envValue)
+				env[tt.key] = tt.envValue
 			}
 
-			result := getEnvWithDefault(tt.key, tt.defaultValue)
+			result := getEnvWithDefault(env, tt.key, tt.defaultValue)
 			assert.Equal(t, tt.expected, result)
 		})
 	}
 }
 
 func TestGetBoolEnvWithDefault(t *testing.T) {
+	t.Parallel()
 	tests := []struct {
 		name         string
 		key          string
@@ -241,11 +244,13 @@ func TestGetBoolEnvWithDefault(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			env := dependencies.MapEnvironment{}
 			if tt.envValue != "" {
-				t.Setenv(tt.key, tt.envValue)
+				env[tt.key] = tt.envValue
 			}
 
-			result := getBoolEnvWithDefault(tt.key, tt.defaultValue)
+			result := getBoolEnvWithDefault(env, tt.key, tt.defaultValue)
 			assert.Equal(t, tt.expected, result)
 		})
 	}
@@ -0,0 +1,68 @@
+// Package app provides the main application structure for contexture
+package app
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/diagnostics"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/spf13/afero"
+)
+
+// captureLogs copies application log output into the diagnostics log buffer
+// while keeping the terminal color profile of stderr
+func captureLogs() {
+	log.SetOutput(io.MultiWriter(os.Stderr, diagnostics.DefaultLogBuffer()))
+	log.SetColorProfile(lipgloss.NewRenderer(os.Stderr).ColorProfile())
+}
+
+// runRecovering returns the exit code of fn, or of crash when fn panics,
+// called with the recovered value
+func runRecovering(crash func(recovered any) int, fn func() int) int {
+	code := 0
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				code = crash(r)
+			}
+		}()
+		code = fn()
+	}()
+	return code
+}
+
+// handleCrash writes a diagnostics bundle for a recovered panic in
+// workingDir, tells the user how to report it, and returns the exit code
+func handleCrash(
+	w io.Writer,
+	fs afero.Fs,
+	workingDir dependencies.WorkingDir,
+	recovered any,
+	stack []byte,
+	args []string,
+) int {
+	workDir, _ := workingDir.Getwd()
+
+	bundle := diagnostics.Collect(fs, workDir, args)
+	bundle.Panic = fmt.Sprint(recovered)
+	bundle.Stack = string(stack)
+
+	_, _ = fmt.Fprintf(w, "contexture crashed unexpectedly: %v\n\n", recovered)
+
+	path, err := bundle.Write(fs, os.TempDir())
+	if err != nil {
+		_, _ = fmt.Fprintf(w, "Could not write a diagnostics bundle: %v\n\n", err)
+		_, _ = fmt.Fprintf(w, "Please report this crash at %s and include the output above.\n", diagnostics.IssueURL)
+	} else {
+		_, _ = fmt.Fprintf(w, "A diagnostics bundle was written to:\n  %s\n\n", path)
+		_, _ = fmt.Fprintf(w, "Please report this crash at %s and attach the bundle.\n", diagnostics.IssueURL)
+		_, _ = fmt.Fprintf(w, "Secrets are redacted, but review the bundle before sharing it.\n")
+	}
+
+	return int(contextureerrors.ExitError)
+}
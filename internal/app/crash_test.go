@@ -0,0 +1,67 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/diagnostics"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleCrash(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+	var out bytes.Buffer
+
+	exitCode := handleCrash(&out, fs, dependencies.StaticWorkingDir("/project"), "boom", []byte("goroutine 1 [running]:"), []string{"contexture", "build"})
+
+	assert.Equal(t, int(contextureerrors.ExitError), exitCode)
+	assert.Contains(t, out.String(), "contexture crashed unexpectedly: boom")
+	assert.Contains(t, out.String(), diagnostics.IssueURL)
+
+	match := regexp.MustCompile(`(?m)^  (\S+contexture-diagnostics-\S+\.json)$`).FindStringSubmatch(out.String())
+	require.Len(t, match, 2, "output should include the bundle path")
+
+	data, err := afero.ReadFile(fs, match[1])
+	require.NoError(t, err)
+
+	var bundle diagnostics.Bundle
+	require.NoError(t, json.Unmarshal(data, &bundle))
+	assert.Equal(t, "boom", bundle.Panic)
+	assert.Equal(t, "goroutine 1 [running]:", bundle.Stack)
+	assert.Equal(t, []string{"contexture", "build"}, bundle.Args)
+	assert.Equal(t, "/project", bundle.WorkDir)
+}
+
+func TestHandleCrash_WriteFailure(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewReadOnlyFs(afero.NewMemMapFs())
+	var out bytes.Buffer
+
+	exitCode := handleCrash(&out, fs, dependencies.StaticWorkingDir("/project"), "boom", nil, nil)
+
+	assert.Equal(t, int(contextureerrors.ExitError), exitCode)
+	assert.Contains(t, out.String(), "Could not write a diagnostics bundle")
+	assert.Contains(t, out.String(), diagnostics.IssueURL)
+	assert.NotContains(t, out.String(), os.TempDir()+string(os.PathSeparator)+"contexture-diagnostics-")
+}
+
+func TestRunRecovering(t *testing.T) {
+	t.Parallel()
+	crash := func(recovered any) int {
+		assert.Equal(t, "boom", recovered)
+		return int(contextureerrors.ExitError)
+	}
+
+	assert.Equal(t, 3, runRecovering(crash, func() int { return 3 }))
+	assert.Equal(t, int(contextureerrors.ExitError), runRecovering(crash, func() int { panic("boom") }))
+}
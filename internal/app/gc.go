@@ -0,0 +1,38 @@
+package app
+
+import (
+	"github.com/charmbracelet/log"
+	"github.com/contextureai/contexture/internal/cache"
+	"github.com/contextureai/contexture/internal/gc"
+	"github.com/contextureai/contexture/internal/project"
+)
+
+// NoGCEnv disables the housekeeping at startup when set to a non-empty value
+const NoGCEnv = "CONTEXTURE_NO_GC"
+
+// collectGarbage moves the cache of earlier versions to the cache directory,
+// then removes what interrupted commands left behind, at most once per
+// gc.Interval. Failures are only logged, so they never fail a command.
+func (a *Application) collectGarbage() {
+	if err := cache.Migrate(a.deps.FS, a.deps.GetEnv()); err != nil {
+		log.Debug("Cache migration failed", "error", err)
+	}
+	if a.deps.GetEnv().Getenv(NoGCEnv) != "" {
+		return
+	}
+	var configDirs []string
+	if dir, err := a.deps.GetWorkingDir().Getwd(); err == nil {
+		configDirs = append(configDirs, dir)
+	}
+	if dir, err := project.NewManagerFromDependencies(a.deps).GlobalConfigDir(); err == nil {
+		configDirs = append(configDirs, dir)
+	}
+
+	report, err := gc.New(a.deps.FS, a.deps.GetEnv(), configDirs...).CollectIfDue()
+	if err != nil {
+		log.Debug("Garbage collection failed", "error", err)
+	}
+	if report != nil && len(report.Items) > 0 {
+		log.Debug("Removed leftovers of interrupted commands", "items", len(report.Items), "reclaimed", gc.FormatBytes(report.Reclaimed))
+	}
+}
@@ -0,0 +1,43 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/contextureai/contexture/internal/cache"
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/permissions"
+	"github.com/contextureai/contexture/internal/ui"
+)
+
+// applyReadOnly makes commands skip their writes with --read-only, reporting
+// each on stderr instead. The rule cache, which is not part of the checkout,
+// is still written so rules can be fetched.
+func (a *Application) applyReadOnly(readOnly bool) {
+	if !readOnly {
+		return
+	}
+	mutedStyle := lipgloss.NewStyle().Foreground(ui.DefaultTheme().Muted)
+	writable := []string{cache.BaseDir(a.deps.GetEnv())}
+	a.deps.FS = permissions.NewReadOnlyFs(a.deps.FS, writable, func(write permissions.Write) {
+		// The working directory is read on each write, after --project-dir has set it
+		path := readOnlyPath(a.deps.GetWorkingDir(), write.Path)
+		fmt.Fprintln(os.Stderr, mutedStyle.Render("read-only: skipped "+write.Op+" "+path))
+	})
+}
+
+// readOnlyPath returns path relative to the working directory when it is
+// inside it
+func readOnlyPath(workingDir dependencies.WorkingDir, path string) string {
+	dir, err := workingDir.Getwd()
+	if err != nil || !filepath.IsAbs(path) {
+		return path
+	}
+	if rel, err := filepath.Rel(dir, path); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return rel
+	}
+	return path
+}
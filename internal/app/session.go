@@ -0,0 +1,45 @@
+package app
+
+import (
+	"path/filepath"
+
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/tui"
+)
+
+// applySessionFlags starts recording the prompts of the run into the file
+// given with --record, or replaying those of the file given with --replay.
+// Paths are resolved before --project-dir changes directory.
+func (a *Application) applySessionFlags(recordFile, replayFile string) error {
+	switch {
+	case recordFile != "" && replayFile != "":
+		return contextureerrors.Validation("record", "--record and --replay cannot be used together").
+			WithSuggestions(contextureerrors.Hint("record a session first, then replay it in another run"))
+	case recordFile != "":
+		abs, err := filepath.Abs(recordFile)
+		if err != nil {
+			return contextureerrors.Wrap(err, "resolve --record")
+		}
+		a.recordFile = abs
+		a.recording = tui.RecordSession()
+	case replayFile != "":
+		session, err := tui.LoadSession(a.deps.FS, replayFile)
+		if err != nil {
+			return err
+		}
+		tui.ReplaySession(session)
+	}
+	return nil
+}
+
+// finishSession stops the session of the run and writes a recorded one to
+// its file, so failed runs can be replayed as well
+func (a *Application) finishSession() error {
+	tui.StopSession()
+	if a.recording == nil {
+		return nil
+	}
+	recording := a.recording
+	a.recording = nil
+	return recording.Save(a.deps.FS, a.recordFile)
+}
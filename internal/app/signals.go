@@ -0,0 +1,83 @@
+package app
+
+import (
+	"context"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/contextureai/contexture/internal/cleanup"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+)
+
+// interruptGracePeriod is how long an interrupted command has to stop before
+// the process exits without it
+const interruptGracePeriod = 10 * time.Second
+
+// handleInterrupts returns a context canceled by the first signal on signals,
+// so in-flight git operations and file writes stop and remove their partial
+// output. When the command has not returned within interruptGracePeriod, or a
+// second signal arrives, the tracked partial files are removed, the terminal
+// is restored, and exit is called with ExitInterrupted.
+//
+// The returned stop function releases the handler and reports whether the
+// command was interrupted.
+func handleInterrupts(
+	parent context.Context,
+	signals <-chan os.Signal,
+	restoreTerminal func(),
+	exit func(int),
+) (context.Context, func() bool) {
+	ctx, cancel := context.WithCancel(parent)
+	stopped := make(chan struct{})
+	var interrupted atomic.Bool
+
+	go func() {
+		select {
+		case sig := <-signals:
+			interrupted.Store(true)
+			log.Warn("Interrupted, stopping; interrupt again to exit immediately", "signal", sig.String())
+			cancel()
+		case <-stopped:
+			return
+		}
+
+		timer := time.NewTimer(interruptGracePeriod)
+		defer timer.Stop()
+		select {
+		case <-signals:
+		case <-timer.C:
+			log.Warn("Command did not stop in time, exiting")
+		case <-stopped:
+			return
+		}
+		removePartialFiles()
+		restoreTerminal()
+		exit(int(contextureerrors.ExitInterrupted))
+	}()
+
+	var once sync.Once
+	return ctx, func() bool {
+		once.Do(func() {
+			close(stopped)
+			cancel()
+		})
+		if !interrupted.Load() {
+			return false
+		}
+		// Operations remove their own partial output when canceled; remove
+		// whatever is left
+		removePartialFiles()
+		restoreTerminal()
+		return true
+	}
+}
+
+// removePartialFiles removes the partial files and directories still tracked
+func removePartialFiles() {
+	if err := cleanup.RemoveAll(); err != nil {
+		log.Warn("Failed to remove partial files", "error", err)
+	}
+}
@@ -0,0 +1,73 @@
+package app
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/contextureai/contexture/internal/cleanup"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleInterrupts_NotInterrupted(t *testing.T) {
+	t.Parallel()
+	signals := make(chan os.Signal, 1)
+	restored := false
+	ctx, stop := handleInterrupts(context.Background(), signals, func() { restored = true }, func(int) {
+		t.Error("exit should not be called")
+	})
+
+	require.NoError(t, ctx.Err())
+	assert.False(t, stop())
+	assert.False(t, restored)
+	assert.Error(t, ctx.Err())
+}
+
+// TestHandleInterrupts_Interrupted removes tracked partial files, so it does not run in parallel
+func TestHandleInterrupts_Interrupted(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, fs.MkdirAll("/cache/partial", 0o755))
+	release := cleanup.Track(fs, "/cache/partial")
+	defer release()
+
+	signals := make(chan os.Signal, 1)
+	restored := false
+	ctx, stop := handleInterrupts(context.Background(), signals, func() { restored = true }, func(int) {
+		t.Error("exit should not be called")
+	})
+
+	signals <- os.Interrupt
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("context was not canceled by the interrupt")
+	}
+
+	assert.True(t, stop())
+	assert.True(t, restored)
+	exists, _ := afero.Exists(fs, "/cache/partial")
+	assert.False(t, exists)
+}
+
+func TestHandleInterrupts_SecondSignalExits(t *testing.T) {
+	t.Parallel()
+	signals := make(chan os.Signal)
+	exitCodes := make(chan int, 1)
+	_, stop := handleInterrupts(context.Background(), signals, func() {}, func(code int) {
+		exitCodes <- code
+	})
+	defer stop()
+
+	signals <- os.Interrupt
+	signals <- os.Interrupt
+	select {
+	case code := <-exitCodes:
+		assert.Equal(t, int(contextureerrors.ExitInterrupted), code)
+	case <-time.After(time.Second):
+		t.Fatal("second interrupt did not exit")
+	}
+}
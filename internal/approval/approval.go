@@ -0,0 +1,79 @@
+// Package approval holds rules from providers a project does not trust in a
+// pending state until someone approves them, for projects whose changes go
+// through change control
+package approval
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/contextureai/contexture/internal/domain"
+)
+
+// Provider returns the provider a rule comes from: a provider name such as
+// @contexture or @mycompany, or the repository URL of a rule added from one
+func Provider(ref domain.RuleRef) string {
+	if name, ok := strings.CutPrefix(ref.ID, "@"); ok {
+		name, _, _ = strings.Cut(name, "/")
+		return "@" + name
+	}
+	source := ref.Source
+	if matches := domain.RuleIDParsePatternRegex.FindStringSubmatch(ref.ID); matches != nil && matches[1] != "" {
+		source = matches[1]
+	}
+	if source == "" || source == domain.DefaultSource || source == domain.DefaultRepository {
+		return "@" + domain.DefaultProviderName
+	}
+	return source
+}
+
+// Trusted reports whether config trusts the provider of ref. Provider names
+// match with or without their @ prefix.
+func Trusted(config *domain.ApprovalConfig, ref domain.RuleRef) bool {
+	if config == nil {
+		return false
+	}
+	provider := Provider(ref)
+	return slices.ContainsFunc(config.TrustedProviders, func(trusted string) bool {
+		trusted = strings.TrimSpace(trusted)
+		return trusted == provider || "@"+trusted == provider
+	})
+}
+
+// Required reports whether ref needs an approval before it is built: config
+// requires approvals and ref is a remote rule from an untrusted provider
+func Required(config *domain.ApprovalConfig, ref domain.RuleRef) bool {
+	if config == nil || !config.Required || ref.Source == "local" {
+		return false
+	}
+	return !Trusted(config, ref)
+}
+
+// Pending reports whether ref is held until approved: it was added pending,
+// or config requires an approval for it and it has none. Rules that reach the
+// configuration without rules add, such as hand edits, extended configs, or
+// imports, are held as well.
+func Pending(config *domain.ApprovalConfig, ref domain.RuleRef) bool {
+	return ref.Pending || (Required(config, ref) && ref.Approval == nil)
+}
+
+// Approve clears the pending state of ref and records who approved it and when
+func Approve(ref *domain.RuleRef, by string, at time.Time) {
+	ref.Pending = false
+	ref.Approval = &domain.RuleApproval{By: by, At: at.UTC().Truncate(time.Second)}
+}
+
+// Filter splits rules into those that can be built and those pending
+// approval under config
+func Filter(config *domain.ApprovalConfig, rules []domain.RuleRef) ([]domain.RuleRef, []domain.RuleRef) {
+	var approved, pending []domain.RuleRef
+	for _, ref := range rules {
+		if Pending(config, ref) {
+			pending = append(pending, ref)
+		} else {
+			approved = append(approved, ref)
+		}
+	}
+	return approved, pending
+}
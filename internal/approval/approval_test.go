@@ -0,0 +1,76 @@
+package approval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvider(t *testing.T) {
+	t.Parallel()
+	tests := map[string]domain.RuleRef{
+		"@contexture":                         {ID: "[contexture:languages/go/testing]"},
+		"@mycompany":                          {ID: "@mycompany/security/auth"},
+		"@community":                          {ID: "[contexture(@community):go/errors]"},
+		"https://github.com/acme/rules.git":   {ID: "[contexture(https://github.com/acme/rules.git):go/errors]"},
+		"https://github.com/acme/private.git": {ID: "[contexture:go/errors]", Source: "https://github.com/acme/private.git"},
+	}
+	for want, ref := range tests {
+		assert.Equal(t, want, Provider(ref), ref.ID)
+	}
+}
+
+func TestRequired(t *testing.T) {
+	t.Parallel()
+	config := &domain.ApprovalConfig{
+		Required:         true,
+		TrustedProviders: []string{"contexture", "@mycompany", "https://github.com/acme/rules.git"},
+	}
+	assert.False(t, Required(config, domain.RuleRef{ID: "languages/go/testing"}))
+	assert.False(t, Required(config, domain.RuleRef{ID: "@mycompany/security/auth"}))
+	assert.False(t, Required(config, domain.RuleRef{ID: "[contexture(https://github.com/acme/rules.git):go/errors]"}))
+	assert.False(t, Required(config, domain.RuleRef{ID: "[contexture(local):notes]", Source: "local"}))
+	assert.True(t, Required(config, domain.RuleRef{ID: "@community/go/errors"}))
+
+	// Approvals are optional
+	assert.False(t, Required(nil, domain.RuleRef{ID: "@community/go/errors"}))
+	assert.False(t, Required(&domain.ApprovalConfig{}, domain.RuleRef{ID: "@community/go/errors"}))
+}
+
+func TestApproveAndFilter(t *testing.T) {
+	t.Parallel()
+	rules := []domain.RuleRef{
+		{ID: "@community/go/errors", Pending: true},
+		{ID: "languages/go/testing"},
+	}
+	approved, pending := Filter(nil, rules)
+	assert.Equal(t, []domain.RuleRef{{ID: "languages/go/testing"}}, approved)
+	require.Len(t, pending, 1)
+
+	at := time.Date(2026, 3, 2, 9, 30, 15, 500, time.FixedZone("CET", 3600))
+	Approve(&rules[0], "Ada <ada@acme.com>", at)
+	assert.False(t, rules[0].Pending)
+	assert.Equal(t, &domain.RuleApproval{By: "Ada <ada@acme.com>", At: time.Date(2026, 3, 2, 8, 30, 15, 0, time.UTC)}, rules[0].Approval)
+
+	approved, pending = Filter(nil, rules)
+	assert.Len(t, approved, 2)
+	assert.Empty(t, pending)
+}
+
+func TestPending(t *testing.T) {
+	t.Parallel()
+	config := &domain.ApprovalConfig{Required: true, TrustedProviders: []string{"contexture"}}
+
+	// Rules written into the configuration without rules add are held too
+	assert.True(t, Pending(config, domain.RuleRef{ID: "@community/go/errors"}))
+	assert.True(t, Pending(nil, domain.RuleRef{ID: "@community/go/errors", Pending: true}))
+	assert.False(t, Pending(config, domain.RuleRef{ID: "[contexture:languages/go/testing]"}))
+	assert.False(t, Pending(config, domain.RuleRef{
+		ID:       "@community/go/errors",
+		Approval: &domain.RuleApproval{By: "Ada", At: time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC)},
+	}))
+	assert.False(t, Pending(nil, domain.RuleRef{ID: "@community/go/errors"}))
+}
@@ -0,0 +1,142 @@
+package benchmark
+
+import (
+	"context"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFixture(t *testing.T) {
+	t.Parallel()
+
+	fixture, err := NewFixture(10)
+	require.NoError(t, err)
+
+	assert.Len(t, fixture.Sources, 10)
+	assert.Len(t, fixture.Rules, 10)
+	assert.Len(t, fixture.Processed, 10)
+	assert.Len(t, fixture.Project.Config.Rules, 10)
+	assert.Len(t, fixture.Global.Config.Rules, 3)
+	assert.Len(t, fixture.Project.Config.Formats, 3)
+}
+
+func TestNewFixture_InvalidCount(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewFixture(0)
+	require.Error(t, err)
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	fixture, err := NewFixture(20)
+	require.NoError(t, err)
+
+	results, err := Run(context.Background(), fixture, 2)
+	require.NoError(t, err)
+
+	require.Len(t, results, 6)
+	assert.Equal(t, StageConfigMerge, results[0].Stage)
+	assert.Equal(t, StageRuleParsing, results[1].Stage)
+	assert.Equal(t, StageTemplateRendering, results[2].Stage)
+	assert.Equal(t, "format generation (claude)", results[3].Stage)
+	for _, result := range results {
+		assert.Equal(t, 20, result.Rules)
+		assert.Equal(t, 2, result.Iterations)
+		assert.Positive(t, result.Total)
+		assert.Equal(t, result.Total/2, result.PerOp())
+	}
+}
+
+func TestRun_InvalidIterations(t *testing.T) {
+	t.Parallel()
+
+	fixture, err := NewFixture(1)
+	require.NoError(t, err)
+
+	_, err = Run(context.Background(), fixture, 0)
+	require.Error(t, err)
+}
+
+func TestRun_Canceled(t *testing.T) {
+	t.Parallel()
+
+	fixture, err := NewFixture(1)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = Run(ctx, fixture, 1)
+	require.Error(t, err)
+}
+
+func TestResult_ZeroValues(t *testing.T) {
+	t.Parallel()
+
+	var result Result
+	assert.Zero(t, result.PerOp())
+	assert.Zero(t, result.PerRule())
+}
+
+// newBenchmarkFixture creates the default-size fixture for benchmarks
+func newBenchmarkFixture(b *testing.B) *Fixture {
+	b.Helper()
+
+	fixture, err := NewFixture(DefaultRuleCount)
+	if err != nil {
+		b.Fatal(err)
+	}
+	return fixture
+}
+
+func BenchmarkConfigMerge(b *testing.B) {
+	fixture := newBenchmarkFixture(b)
+
+	b.ResetTimer()
+	for range b.N {
+		if err := fixture.MergeConfigs(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRuleParsing(b *testing.B) {
+	fixture := newBenchmarkFixture(b)
+
+	b.ResetTimer()
+	for range b.N {
+		if err := fixture.ParseRules(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkTemplateRendering(b *testing.B) {
+	fixture := newBenchmarkFixture(b)
+
+	b.ResetTimer()
+	for range b.N {
+		if err := fixture.RenderTemplates(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkFormatGeneration(b *testing.B) {
+	fixture := newBenchmarkFixture(b)
+
+	for _, formatType := range []domain.FormatType{domain.FormatClaude, domain.FormatCursor, domain.FormatWindsurf} {
+		b.Run(string(formatType), func(b *testing.B) {
+			for range b.N {
+				if err := fixture.GenerateFormat(formatType); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+// Package benchmark measures the rule generation pipeline against synthetic fixtures
+package benchmark
+
+import (
+	"fmt"
+
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/rule"
+)
+
+// DefaultRuleCount is the number of rules in the default fixture
+const DefaultRuleCount = 500
+
+// fixtureTopics are cycled through to give rules varied IDs, tags, and languages
+var fixtureTopics = []string{"go", "typescript", "python", "rust", "java", "security", "testing", "docs"}
+
+// RuleSource is the raw markdown and metadata of a fixture rule
+type RuleSource struct {
+	Content  string
+	Metadata rule.Metadata
+}
+
+// Fixture holds a representative project for benchmarking the generation pipeline
+type Fixture struct {
+	// Global and Project are the configurations merged by the config stage.
+	// Every fourth project rule is also present globally to exercise deduplication.
+	Global  *domain.ConfigResult
+	Project *domain.ConfigResult
+	// Sources are the raw rule files parsed by the parsing stage
+	Sources []RuleSource
+	// Rules are the parsed rules
+	Rules []*domain.Rule
+	// Processed are the rules after variable resolution, ready for rendering and formatting
+	Processed []*domain.ProcessedRule
+}
+
+// NewFixture creates a fixture with ruleCount rules
+func NewFixture(ruleCount int) (*Fixture, error) {
+	if ruleCount <= 0 {
+		return nil, contextureerrors.ValidationErrorf("rules", "rule count must be positive, got %d", ruleCount)
+	}
+
+	fixture := &Fixture{
+		Global: &domain.ConfigResult{
+			Config:   &domain.Project{Version: 1},
+			Location: domain.ConfigLocationGlobal,
+			Path:     "/home/bench/.contexture/.contexture.yaml",
+		},
+		Project: &domain.ConfigResult{
+			Config: &domain.Project{
+				Version: 1,
+				Formats: []domain.FormatConfig{
+					{Type: domain.FormatClaude, Enabled: true},
+					{Type: domain.FormatCursor, Enabled: true},
+					{Type: domain.FormatWindsurf, Enabled: true},
+				},
+			},
+			Location: domain.ConfigLocationRoot,
+			Path:     "/bench/.contexture.yaml",
+		},
+	}
+
+	parser := rule.NewParser()
+	processor := rule.NewProcessor()
+
+	for i := range ruleCount {
+		topic := fixtureTopics[i%len(fixtureTopics)]
+		path := fmt.Sprintf("%s/rule-%04d", topic, i)
+		ruleID := fmt.Sprintf("[contexture:%s]", path)
+
+		ref := domain.RuleRef{ID: ruleID}
+		fixture.Project.Config.Rules = append(fixture.Project.Config.Rules, ref)
+		if i%4 == 0 {
+			fixture.Global.Config.Rules = append(fixture.Global.Config.Rules, ref)
+		}
+
+		source := RuleSource{
+			Content: fixtureRuleContent(i, topic),
+			Metadata: rule.Metadata{
+				ID:       ruleID,
+				FilePath: path + domain.MarkdownExt,
+				Source:   domain.DefaultRepository,
+			},
+		}
+		fixture.Sources = append(fixture.Sources, source)
+
+		parsed, err := parser.ParseRule(source.Content, source.Metadata)
+		if err != nil {
+			return nil, contextureerrors.Wrap(err, "parse fixture rule")
+		}
+		fixture.Rules = append(fixture.Rules, parsed)
+
+		processed, err := processor.ProcessRule(parsed, &domain.RuleContext{})
+		if err != nil {
+			return nil, contextureerrors.Wrap(err, "process fixture rule")
+		}
+		fixture.Processed = append(fixture.Processed, processed)
+	}
+
+	return fixture, nil
+}
+
+// fixtureRuleContent returns a rule file of realistic size that uses template variables
+func fixtureRuleContent(index int, topic string) string {
+	return fmt.Sprintf(`---
+title: %[2]s rule %[1]d
+description: Guidance number %[1]d for %[2]s projects
+tags: [%[2]s, generated, benchmark]
+languages: [%[2]s]
+trigger:
+  type: glob
+  globs: ["**/*.%[2]s"]
+variables:
+  strictness: high
+  examples: [first, second, third]
+---
+# {{ .strictness | titlecase }} %[2]s guidance
+
+Follow these conventions when working on %[2]s code.
+
+{{ range .examples }}
+- Example {{ . }}: keep functions small and focused.
+{{ end }}
+
+## Details
+
+Prefer explicit error handling, document exported identifiers, and keep
+dependencies minimal. Write tests for every behavior change and run them
+before committing. Avoid global state and prefer dependency injection.
+`, index, topic)
+}
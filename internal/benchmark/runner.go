@@ -0,0 +1,149 @@
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/format"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/contextureai/contexture/internal/rule"
+	"github.com/contextureai/contexture/internal/template"
+	"github.com/spf13/afero"
+)
+
+// Stage names reported by Run
+const (
+	StageConfigMerge       = "config merge"
+	StageRuleParsing       = "rule parsing"
+	StageTemplateRendering = "template rendering"
+	StageFormatGeneration  = "format generation"
+)
+
+// Result is the timing of a single pipeline stage
+type Result struct {
+	Stage      string
+	Rules      int
+	Iterations int
+	Total      time.Duration
+}
+
+// PerOp returns the average duration of one iteration
+func (r Result) PerOp() time.Duration {
+	if r.Iterations == 0 {
+		return 0
+	}
+	return r.Total / time.Duration(r.Iterations)
+}
+
+// PerRule returns the average duration spent on one rule in one iteration
+func (r Result) PerRule() time.Duration {
+	if r.Rules == 0 {
+		return 0
+	}
+	return r.PerOp() / time.Duration(r.Rules)
+}
+
+// Run times each pipeline stage over the fixture for the given number of iterations
+func Run(ctx context.Context, fixture *Fixture, iterations int) ([]Result, error) {
+	if iterations <= 0 {
+		return nil, contextureerrors.ValidationErrorf("iterations", "iterations must be positive, got %d", iterations)
+	}
+
+	stages := []struct {
+		name string
+		run  func() error
+	}{
+		{StageConfigMerge, fixture.MergeConfigs},
+		{StageRuleParsing, fixture.ParseRules},
+		{StageTemplateRendering, fixture.RenderTemplates},
+	}
+	for _, formatConfig := range fixture.Project.Config.Formats {
+		formatType := formatConfig.Type
+		stages = append(stages, struct {
+			name string
+			run  func() error
+		}{
+			name: fmt.Sprintf("%s (%s)", StageFormatGeneration, formatType),
+			run:  func() error { return fixture.GenerateFormat(formatType) },
+		})
+	}
+
+	results := make([]Result, 0, len(stages))
+	for _, stage := range stages {
+		result := Result{Stage: stage.name, Rules: len(fixture.Sources), Iterations: iterations}
+		for range iterations {
+			if err := ctx.Err(); err != nil {
+				return nil, contextureerrors.Wrap(err, "run benchmark")
+			}
+
+			start := time.Now()
+			if err := stage.run(); err != nil {
+				return nil, contextureerrors.Wrap(err, stage.name)
+			}
+			result.Total += time.Since(start)
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// MergeConfigs merges the fixture's global and project configurations
+func (f *Fixture) MergeConfigs() error {
+	manager := project.NewManager(afero.NewMemMapFs())
+	merged := manager.MergeConfigs(f.Global, f.Project)
+	if len(merged.MergedRules) != len(f.Project.Config.Rules) {
+		return fmt.Errorf("merged %d rules, expected %d", len(merged.MergedRules), len(f.Project.Config.Rules))
+	}
+	return nil
+}
+
+// ParseRules parses every fixture rule file
+func (f *Fixture) ParseRules() error {
+	parser := rule.NewParser()
+	for _, source := range f.Sources {
+		if _, err := parser.ParseRule(source.Content, source.Metadata); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RenderTemplates renders every processed rule's content with its variables
+func (f *Fixture) RenderTemplates() error {
+	engine := template.NewEngine()
+	for _, processed := range f.Processed {
+		if _, err := engine.Render(processed.Content, processed.Variables); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GenerateFormat transforms every processed rule and writes the output for formatType
+// to an in-memory filesystem
+func (f *Fixture) GenerateFormat(formatType domain.FormatType) error {
+	fs := afero.NewMemMapFs()
+	formatImpl, err := format.GetDefaultRegistry(fs).CreateFormat(formatType, fs, nil)
+	if err != nil {
+		return err
+	}
+
+	transformed := make([]*domain.TransformedRule, 0, len(f.Processed))
+	for _, processed := range f.Processed {
+		rule, err := formatImpl.Transform(processed)
+		if err != nil {
+			return err
+		}
+		transformed = append(transformed, rule)
+	}
+
+	return formatImpl.Write(transformed, &domain.FormatConfig{
+		Type:    formatType,
+		Enabled: true,
+		BaseDir: "/bench",
+	})
+}
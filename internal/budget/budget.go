@@ -0,0 +1,82 @@
+// Package budget estimates how much of a model's context window generated output uses
+package budget
+
+import (
+	"strings"
+
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+)
+
+// DefaultProfile is the model profile used when none is selected
+const DefaultProfile = "claude-sonnet"
+
+// WarnFraction is the share of a context window above which generated
+// context is considered to crowd out working space
+const WarnFraction = 0.10
+
+// charsPerToken approximates tokenization for English prose and code
+const charsPerToken = 4
+
+// Profile describes the context window of an agent model
+type Profile struct {
+	Name          string
+	ContextWindow int
+}
+
+// profiles are the known model profiles
+var profiles = []Profile{
+	{Name: "claude-sonnet", ContextWindow: 200_000},
+	{Name: "gpt-4o", ContextWindow: 128_000},
+	{Name: "gemini", ContextWindow: 1_000_000},
+}
+
+// Profiles returns the known model profiles
+func Profiles() []Profile {
+	return append([]Profile(nil), profiles...)
+}
+
+// Lookup returns the profile with the given name, or DefaultProfile when name is empty
+func Lookup(name string) (Profile, error) {
+	if name == "" {
+		name = DefaultProfile
+	}
+
+	names := make([]string, 0, len(profiles))
+	for _, profile := range profiles {
+		if strings.EqualFold(profile.Name, name) {
+			return profile, nil
+		}
+		names = append(names, profile.Name)
+	}
+	return Profile{}, contextureerrors.ValidationErrorf("model",
+		"unknown model profile %q (available: %s)", name, strings.Join(names, ", "))
+}
+
+// EstimateTokens approximates the number of tokens in content
+func EstimateTokens(content string) int {
+	return (len(content) + charsPerToken - 1) / charsPerToken
+}
+
+// Usage is the estimated context use of one output
+type Usage struct {
+	Name     string
+	Bytes    int
+	Tokens   int
+	Fraction float64
+}
+
+// OverBudget reports whether the output uses more than WarnFraction of the context window
+func (u Usage) OverBudget() bool {
+	return u.Fraction > WarnFraction
+}
+
+// Measure estimates the share of the profile's context window that content uses
+func (p Profile) Measure(name, content string) Usage {
+	tokens := EstimateTokens(content)
+	return Usage{
+		Name:     name,
+		Bytes:    len(content),
+		Tokens:   tokens,
+		Fraction: float64(tokens) / float64(p.ContextWindow),
+	}
+}
@@ -0,0 +1,46 @@
+package budget
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+
+	profile, err := Lookup("")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultProfile, profile.Name)
+
+	profile, err = Lookup("GPT-4o")
+	require.NoError(t, err)
+	assert.Equal(t, 128_000, profile.ContextWindow)
+
+	_, err = Lookup("unknown")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "gemini")
+}
+
+func TestEstimateTokens(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 0, EstimateTokens(""))
+	assert.Equal(t, 1, EstimateTokens("abc"))
+	assert.Equal(t, 2, EstimateTokens("abcde"))
+}
+
+func TestProfile_Measure(t *testing.T) {
+	t.Parallel()
+	profile := Profile{Name: "small", ContextWindow: 1_000}
+
+	usage := profile.Measure("CLAUDE.md", strings.Repeat("a", 400))
+	assert.Equal(t, 100, usage.Tokens)
+	assert.InDelta(t, 0.1, usage.Fraction, 1e-9)
+	assert.False(t, usage.OverBudget())
+
+	usage = profile.Measure("CLAUDE.md", strings.Repeat("a", 404))
+	assert.True(t, usage.OverBudget())
+}
@@ -0,0 +1,142 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/spf13/afero"
+)
+
+const (
+	// BlobCacheDirName is the directory under the cache directory holding rule file contents
+	BlobCacheDirName = "blobs"
+
+	// DefaultBlobCacheEntries is the number of files the blob cache keeps
+	// before evicting the least recently used
+	DefaultBlobCacheEntries = 1000
+)
+
+// commitHashPattern matches full commit hashes, the only refs whose files never change
+var commitHashPattern = regexp.MustCompile(`^(?:[0-9a-f]{40}|[0-9a-f]{64})$`)
+
+// BlobCache is a read-through disk cache for the contents of files in rule
+// repositories, keyed by repository, commit, and path. Files at a commit
+// never change, so entries are only removed to keep the cache within its
+// size, least recently used first.
+type BlobCache struct {
+	fs         afero.Fs
+	dir        string
+	maxEntries int
+	mu         sync.Mutex
+}
+
+// NewBlobCache creates a blob cache in the cache directory of env holding up
+// to maxEntries files, or DefaultBlobCacheEntries when maxEntries is not
+// positive
+func NewBlobCache(fs afero.Fs, env dependencies.Environment, maxEntries int) *BlobCache {
+	if maxEntries <= 0 {
+		maxEntries = DefaultBlobCacheEntries
+	}
+	return &BlobCache{
+		fs:         fs,
+		dir:        filepath.Join(BaseDir(env), BlobCacheDirName),
+		maxEntries: maxEntries,
+	}
+}
+
+// Dir returns the directory holding the entries
+func (c *BlobCache) Dir() string {
+	return c.dir
+}
+
+// GetOrFetch returns the contents of path in the repository source at
+// commit, or calls fetch and caches its result. Only full commit hashes are
+// cached; other refs and cache failures fall back to fetching.
+func (c *BlobCache) GetOrFetch(source, commit, path string, fetch func() ([]byte, error)) ([]byte, error) {
+	if !commitHashPattern.MatchString(commit) {
+		return fetch()
+	}
+
+	entry := filepath.Join(c.dir, blobKey(source, commit, path))
+	if data, err := afero.ReadFile(c.fs, entry); err == nil {
+		// The modification time records the last use, for eviction
+		now := time.Now()
+		_ = c.fs.Chtimes(entry, now, now)
+		return data, nil
+	}
+
+	data, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.write(entry, data); err != nil {
+		log.Debug("Failed to cache file contents", "path", entry, "error", err)
+		return data, nil
+	}
+	c.evict()
+	return data, nil
+}
+
+// blobKey returns the entry name of path in the repository source at commit
+func blobKey(source, commit, path string) string {
+	sum := sha256.Sum256([]byte(source + "\x00" + commit + "\x00" + filepath.ToSlash(path)))
+	return hex.EncodeToString(sum[:])
+}
+
+// write stores data at path through a temporary file, so concurrent
+// readers never see a partial entry
+func (c *BlobCache) write(path string, data []byte) error {
+	if err := c.fs.MkdirAll(c.dir, 0o755); err != nil {
+		return err
+	}
+	tmp, err := afero.TempFile(c.fs, c.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = c.fs.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = c.fs.Remove(tmp.Name())
+		return err
+	}
+	return c.fs.Rename(tmp.Name(), path)
+}
+
+// evict removes the least recently used entries beyond the maximum
+func (c *BlobCache) evict() {
+	entries, err := afero.ReadDir(c.fs, c.dir)
+	if err != nil {
+		return
+	}
+	// Temporary files of concurrent writes are not entries
+	entries = slices.DeleteFunc(entries, func(entry os.FileInfo) bool {
+		return entry.IsDir() || strings.HasPrefix(entry.Name(), ".tmp-")
+	})
+	if len(entries) <= c.maxEntries {
+		return
+	}
+	slices.SortFunc(entries, func(a, b os.FileInfo) int {
+		return a.ModTime().Compare(b.ModTime())
+	})
+	for _, entry := range entries[:len(entries)-c.maxEntries] {
+		path := filepath.Join(c.dir, entry.Name())
+		if err := c.fs.Remove(path); err != nil {
+			log.Debug("Failed to evict cached file contents", "path", path, "error", err)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package cache
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testRepo   = "https://github.com/contextureai/rules.git"
+	testCommit = "3f2a9c1e8b7d6a5f4e3d2c1b0a9f8e7d6c5b4a39"
+)
+
+func TestBlobCache_GetOrFetch(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	blobs := NewBlobCache(fs, testEnv, 0)
+	assert.Equal(t, filepath.Join(BaseDir(testEnv), BlobCacheDirName), blobs.Dir())
+
+	fetches := 0
+	fetch := func() ([]byte, error) {
+		fetches++
+		return []byte("# Testing"), nil
+	}
+
+	for range 2 {
+		data, err := blobs.GetOrFetch(testRepo, testCommit, "go/testing.md", fetch)
+		require.NoError(t, err)
+		assert.Equal(t, "# Testing", string(data))
+	}
+	assert.Equal(t, 1, fetches)
+
+	// Entries are keyed by repository, commit, and path
+	_, err := blobs.GetOrFetch(testRepo, testCommit, "go/errors.md", fetch)
+	require.NoError(t, err)
+	_, err = blobs.GetOrFetch("https://github.com/acme/rules.git", testCommit, "go/testing.md", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, 3, fetches)
+
+	// Branches and short hashes can move, so they are not cached
+	for range 2 {
+		_, err = blobs.GetOrFetch(testRepo, "main", "go/testing.md", fetch)
+		require.NoError(t, err)
+		_, err = blobs.GetOrFetch(testRepo, testCommit[:7], "go/testing.md", fetch)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 7, fetches)
+
+	// Failed fetches are not cached
+	_, err = blobs.GetOrFetch(testRepo, testCommit, "missing.md", func() ([]byte, error) {
+		return nil, errors.New("file not found")
+	})
+	require.Error(t, err)
+	entries, err := afero.ReadDir(fs, blobs.Dir())
+	require.NoError(t, err)
+	assert.Len(t, entries, 3)
+}
+
+func TestBlobCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	blobs := NewBlobCache(fs, testEnv, 2)
+	fetch := func(content string) func() ([]byte, error) {
+		return func() ([]byte, error) { return []byte(content), nil }
+	}
+
+	_, err := blobs.GetOrFetch(testRepo, testCommit, "a.md", fetch("a"))
+	require.NoError(t, err)
+	_, err = blobs.GetOrFetch(testRepo, testCommit, "b.md", fetch("b"))
+	require.NoError(t, err)
+	// b was last used an hour ago, a is used now
+	old := time.Now().Add(-time.Hour)
+	require.NoError(t, fs.Chtimes(filepath.Join(blobs.Dir(), blobKey(testRepo, testCommit, "b.md")), old, old))
+	_, err = blobs.GetOrFetch(testRepo, testCommit, "a.md", fetch("refetched"))
+	require.NoError(t, err)
+
+	_, err = blobs.GetOrFetch(testRepo, testCommit, "c.md", fetch("c"))
+	require.NoError(t, err)
+
+	entries, err := afero.ReadDir(fs, blobs.Dir())
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+	data, err := blobs.GetOrFetch(testRepo, testCommit, "a.md", fetch("refetched"))
+	require.NoError(t, err)
+	assert.Equal(t, "a", string(data))
+	data, err = blobs.GetOrFetch(testRepo, testCommit, "b.md", fetch("refetched"))
+	require.NoError(t, err)
+	assert.Equal(t, "refetched", string(data))
+}
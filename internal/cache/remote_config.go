@@ -0,0 +1,145 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/staging"
+	"github.com/contextureai/contexture/internal/version"
+	"github.com/spf13/afero"
+)
+
+const (
+	// RemoteConfigDirName is the directory under the cache directory holding
+	// configuration files fetched with --config-url
+	RemoteConfigDirName = "configs"
+
+	// remoteConfigTimeout bounds the download of a configuration file
+	remoteConfigTimeout = 30 * time.Second
+	// remoteConfigMaxSize is the largest configuration file accepted
+	remoteConfigMaxSize = 1 << 20
+)
+
+// RemoteConfig downloads project configuration files over HTTPS and keeps a
+// copy of each in the cache directory, so ephemeral environments can build
+// from a configuration that is not committed to the project
+type RemoteConfig struct {
+	fs     afero.Fs
+	dir    string
+	client *http.Client
+}
+
+// NewRemoteConfig creates a cache of remote configuration files in the cache
+// directory of env
+func NewRemoteConfig(fs afero.Fs, env dependencies.Environment) *RemoteConfig {
+	return &RemoteConfig{
+		fs:     fs,
+		dir:    filepath.Join(BaseDir(env), RemoteConfigDirName),
+		client: http.DefaultClient,
+	}
+}
+
+// Fetch returns the path of the cached copy of the configuration at rawURL
+// and the content it verified, which callers read instead of the file so a
+// copy changed after the check is never used. With a SHA-256 checksum, a cached copy with that checksum is used without a
+// request and a download with another checksum is rejected. Without one, the
+// configuration is downloaded on every call, and the cached copy is used with
+// a warning when the server cannot be reached.
+func (c *RemoteConfig) Fetch(ctx context.Context, rawURL, checksum string) (string, []byte, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+		return "", nil, contextureerrors.Validation("config-url", "configuration URLs must use https: "+rawURL).
+			WithSuggestions(contextureerrors.Hint("pass an https:// URL to --config-url"))
+	}
+	checksum = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(checksum), "sha256:"))
+	if checksum != "" {
+		if decoded, err := hex.DecodeString(checksum); err != nil || len(decoded) != sha256.Size {
+			return "", nil, contextureerrors.ValidationErrorf("config-sha256", "expected a hex SHA-256 checksum, got %q", checksum)
+		}
+	}
+
+	key := sha256.Sum256([]byte(rawURL))
+	path := filepath.Join(c.dir, hex.EncodeToString(key[:8])+".yaml")
+	if checksum != "" {
+		if cached, err := afero.ReadFile(c.fs, path); err == nil && domain.SHA256Hex(cached) == checksum {
+			log.Debug("Using cached remote configuration", "url", rawURL, "path", path)
+			return path, cached, nil
+		}
+	}
+
+	data, err := c.download(ctx, rawURL)
+	if err != nil {
+		if checksum == "" {
+			if cached, readErr := afero.ReadFile(c.fs, path); readErr == nil {
+				log.Warn("Using the cached configuration; the remote configuration could not be downloaded",
+					"url", redactURL(parsed), "error", err)
+				return path, cached, nil
+			}
+		}
+		return "", nil, err
+	}
+	if checksum != "" && domain.SHA256Hex(data) != checksum {
+		return "", nil, contextureerrors.Validation("config-sha256",
+			fmt.Sprintf("checksum of %s is %s, expected %s", redactURL(parsed), domain.SHA256Hex(data), checksum)).
+			WithSuggestions(contextureerrors.Hint("update --config-sha256 after reviewing the changed configuration"))
+	}
+
+	if err := c.fs.MkdirAll(c.dir, 0o755); err != nil {
+		return "", nil, contextureerrors.Wrap(err, "create remote configuration cache")
+	}
+	if err := staging.WriteFile(c.fs, path, data, 0o644); err != nil {
+		return "", nil, contextureerrors.Wrap(err, "cache remote configuration")
+	}
+	return path, data, nil
+}
+
+// download reads the configuration at rawURL
+func (c *RemoteConfig) download(ctx context.Context, rawURL string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, remoteConfigTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "create remote configuration request")
+	}
+	req.Header.Set("User-Agent", "contexture/"+version.GetShort())
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "download remote configuration")
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, contextureerrors.WithOpf("download remote configuration", "%s responded with %s", redactURL(req.URL), resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, remoteConfigMaxSize+1))
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "read remote configuration")
+	}
+	if len(data) > remoteConfigMaxSize {
+		return nil, contextureerrors.WithOpf("download remote configuration", "configuration is larger than %d bytes", remoteConfigMaxSize)
+	}
+	return data, nil
+}
+
+// redactURL returns u without credentials or query, which may carry tokens
+func redactURL(u *url.URL) string {
+	redacted := *u
+	redacted.User = nil
+	redacted.RawQuery = ""
+	return redacted.String()
+}
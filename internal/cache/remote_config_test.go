@@ -0,0 +1,121 @@
+package cache
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testRemoteConfig = "version: 1\nformats:\n  - type: claude\n    enabled: true\nrules: []\n"
+
+// newRemoteConfigServer serves the content of config and counts the requests
+func newRemoteConfigServer(t *testing.T, config *atomic.Value, requests *atomic.Int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path != "/contexture.yaml" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(config.Load().(string)))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRemoteConfig_Fetch(t *testing.T) {
+	t.Parallel()
+	checksum := domain.SHA256Hex([]byte(testRemoteConfig))
+
+	setup := func(t *testing.T) (*RemoteConfig, string, *atomic.Value, *atomic.Int32, *httptest.Server) {
+		t.Helper()
+		var config atomic.Value
+		config.Store(testRemoteConfig)
+		var requests atomic.Int32
+		server := newRemoteConfigServer(t, &config, &requests)
+		fs := afero.NewMemMapFs()
+		remote := &RemoteConfig{fs: fs, dir: "/cache/configs", client: server.Client()}
+		return remote, server.URL + "/contexture.yaml", &config, &requests, server
+	}
+
+	t.Run("downloads and caches the configuration", func(t *testing.T) {
+		t.Parallel()
+		remote, url, _, requests, _ := setup(t)
+
+		path, data, err := remote.Fetch(context.Background(), url, "")
+		require.NoError(t, err)
+		assert.Equal(t, testRemoteConfig, string(data))
+		content, err := afero.ReadFile(remote.fs, path)
+		require.NoError(t, err)
+		assert.Equal(t, testRemoteConfig, string(content))
+		assert.Equal(t, int32(1), requests.Load())
+
+		// Without a checksum, every fetch downloads the latest configuration
+		_, _, err = remote.Fetch(context.Background(), url, "")
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("pinned configurations are served from the cache", func(t *testing.T) {
+		t.Parallel()
+		remote, url, _, requests, _ := setup(t)
+
+		_, _, err := remote.Fetch(context.Background(), url, "sha256:"+checksum)
+		require.NoError(t, err)
+		_, data, err := remote.Fetch(context.Background(), url, checksum)
+		require.NoError(t, err)
+		assert.Equal(t, testRemoteConfig, string(data))
+		assert.Equal(t, int32(1), requests.Load())
+	})
+
+	t.Run("rejects a configuration with another checksum", func(t *testing.T) {
+		t.Parallel()
+		remote, url, config, _, _ := setup(t)
+		config.Store(testRemoteConfig + "# changed\n")
+
+		_, _, err := remote.Fetch(context.Background(), url, checksum)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "checksum")
+	})
+
+	t.Run("falls back to the cached copy when the server is unavailable", func(t *testing.T) {
+		t.Parallel()
+		remote, url, _, _, server := setup(t)
+
+		cached, _, err := remote.Fetch(context.Background(), url, "")
+		require.NoError(t, err)
+		server.Close()
+
+		path, data, err := remote.Fetch(context.Background(), url, "")
+		require.NoError(t, err)
+		assert.Equal(t, cached, path)
+		assert.Equal(t, testRemoteConfig, string(data))
+	})
+
+	t.Run("reports missing configurations", func(t *testing.T) {
+		t.Parallel()
+		remote, url, _, _, _ := setup(t)
+
+		_, _, err := remote.Fetch(context.Background(), url+".missing", "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "404")
+	})
+
+	t.Run("rejects insecure URLs and malformed checksums", func(t *testing.T) {
+		t.Parallel()
+		remote, url, _, requests, _ := setup(t)
+
+		_, _, err := remote.Fetch(context.Background(), "http://example.com/contexture.yaml", "")
+		require.Error(t, err)
+		_, _, err = remote.Fetch(context.Background(), url, "abc")
+		require.Error(t, err)
+		assert.Equal(t, int32(0), requests.Load())
+	})
+}
@@ -0,0 +1,104 @@
+package cache
+
+import (
+	"path/filepath"
+	"regexp"
+	"sync"
+
+	"github.com/charmbracelet/log"
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/spf13/afero"
+)
+
+const (
+	// RenderCacheDirName is the directory under the cache directory holding rendered rule content
+	RenderCacheDirName = "rendered"
+)
+
+// unsafeBuildChars matches characters not allowed in a build directory name
+var unsafeBuildChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// RenderCache is a read-through disk cache for rendered rule content.
+// Entries are stored in a directory per CLI build, so upgrading the CLI
+// discards everything rendered by the previous version.
+type RenderCache struct {
+	fs        afero.Fs
+	baseDir   string
+	dir       string
+	pruneOnce sync.Once
+}
+
+// NewRenderCache creates a rendered content cache in the cache directory of
+// env for the given CLI build, such as "v1.2.0-3f2a9c1"
+func NewRenderCache(fs afero.Fs, env dependencies.Environment, build string) *RenderCache {
+	baseDir := filepath.Join(BaseDir(env), RenderCacheDirName)
+	return &RenderCache{
+		fs:      fs,
+		baseDir: baseDir,
+		dir:     filepath.Join(baseDir, unsafeBuildChars.ReplaceAllString(build, "_")),
+	}
+}
+
+// Dir returns the directory holding the entries of the current CLI build
+func (c *RenderCache) Dir() string {
+	return c.dir
+}
+
+// GetOrRender returns the content cached under key, or calls render and
+// caches its result. Cache read and write failures fall back to rendering.
+func (c *RenderCache) GetOrRender(key string, render func() (string, error)) (string, error) {
+	path := filepath.Join(c.dir, key)
+	if data, err := afero.ReadFile(c.fs, path); err == nil {
+		return string(data), nil
+	}
+
+	content, err := render()
+	if err != nil {
+		return "", err
+	}
+
+	c.pruneOnce.Do(c.prune)
+	if err := c.write(path, content); err != nil {
+		log.Debug("Failed to cache rendered content", "path", path, "error", err)
+	}
+	return content, nil
+}
+
+// write stores content at path through a temporary file, so concurrent
+// readers never see a partial entry
+func (c *RenderCache) write(path, content string) error {
+	if err := c.fs.MkdirAll(c.dir, 0o755); err != nil {
+		return err
+	}
+	tmp, err := afero.TempFile(c.fs, c.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.WriteString(content); err != nil {
+		_ = tmp.Close()
+		_ = c.fs.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = c.fs.Remove(tmp.Name())
+		return err
+	}
+	return c.fs.Rename(tmp.Name(), path)
+}
+
+// prune removes the entries rendered by other CLI builds
+func (c *RenderCache) prune() {
+	entries, err := afero.ReadDir(c.fs, c.baseDir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		dir := filepath.Join(c.baseDir, entry.Name())
+		if dir == c.dir {
+			continue
+		}
+		if err := c.fs.RemoveAll(dir); err != nil {
+			log.Debug("Failed to remove stale rendered content", "path", dir, "error", err)
+		}
+	}
+}
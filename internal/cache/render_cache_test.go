@@ -0,0 +1,64 @@
+package cache
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderCache_GetOrRender(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	cache := NewRenderCache(fs, testEnv, "v1.2.0-abc1234")
+	assert.Equal(t, filepath.Join(BaseDir(testEnv), RenderCacheDirName, "v1.2.0-abc1234"), cache.Dir())
+
+	renders := 0
+	render := func() (string, error) {
+		renders++
+		return "rendered", nil
+	}
+
+	content, err := cache.GetOrRender("key", render)
+	require.NoError(t, err)
+	assert.Equal(t, "rendered", content)
+
+	content, err = cache.GetOrRender("key", render)
+	require.NoError(t, err)
+	assert.Equal(t, "rendered", content)
+	assert.Equal(t, 1, renders)
+
+	// Failed renders are not cached
+	_, err = cache.GetOrRender("failing", func() (string, error) { return "", errors.New("bad template") })
+	require.Error(t, err)
+	exists, err := afero.Exists(fs, filepath.Join(cache.Dir(), "failing"))
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestRenderCache_DiscardsOtherBuilds(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+
+	previous := NewRenderCache(fs, testEnv, "v1.1.0-old")
+	_, err := previous.GetOrRender("key", func() (string, error) { return "old", nil })
+	require.NoError(t, err)
+
+	current := NewRenderCache(fs, testEnv, "v1.2.0-new")
+	content, err := current.GetOrRender("key", func() (string, error) { return "new", nil })
+	require.NoError(t, err)
+	assert.Equal(t, "new", content)
+
+	exists, err := afero.DirExists(fs, previous.Dir())
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestNewRenderCache_SanitizesBuild(t *testing.T) {
+	t.Parallel()
+	cache := NewRenderCache(afero.NewMemMapFs(), testEnv, "dev/unknown build")
+	assert.Equal(t, "dev_unknown_build", filepath.Base(cache.Dir()))
+}
@@ -11,33 +11,127 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/log"
+	"github.com/contextureai/contexture/internal/cleanup"
+	"github.com/contextureai/contexture/internal/dependencies"
 	contextureerrors "github.com/contextureai/contexture/internal/errors"
 	"github.com/contextureai/contexture/internal/git"
+	"github.com/contextureai/contexture/internal/staging"
 	"github.com/spf13/afero"
 )
 
 const (
 	// DefaultCacheDirName is the default directory name for contexture cache
 	DefaultCacheDirName = "contexture"
+
+	// XDGCacheHomeEnv is the XDG Base Directory variable for user caches
+	XDGCacheHomeEnv = "XDG_CACHE_HOME"
+	// XDGStateHomeEnv is the XDG Base Directory variable for user state
+	XDGStateHomeEnv = "XDG_STATE_HOME"
+
+	// cloneMarkerSuffix ends the name of the marker of a clone in progress
+	cloneMarkerSuffix = ".cloning"
 )
 
+// ReservedDirs are the directories of the cache directory that are not
+// repository clones
+var ReservedDirs = []string{RenderCacheDirName, BlobCacheDirName, RemoteConfigDirName}
+
+// BaseDir returns the cache directory: $XDG_CACHE_HOME/contexture when
+// XDG_CACHE_HOME is set in env to an absolute path, otherwise
+// ~/.cache/contexture. Without a home directory it is the contexture
+// directory in the system temp directory that earlier versions used.
+func BaseDir(env dependencies.Environment) string {
+	return xdgDir(env, XDGCacheHomeEnv, ".cache")
+}
+
+// StateDir returns the directory of data kept across runs that is neither
+// configuration nor cache, such as the rule history: $XDG_STATE_HOME/contexture
+// when XDG_STATE_HOME is set in env to an absolute path, otherwise
+// ~/.local/state/contexture, with the same fallback as BaseDir
+func StateDir(env dependencies.Environment) string {
+	return xdgDir(env, XDGStateHomeEnv, filepath.Join(".local", "state"))
+}
+
+// xdgDir returns the contexture directory in the XDG base directory named by
+// variable, or in its default under the home directory
+func xdgDir(env dependencies.Environment, variable, defaultDir string) string {
+	// The specification requires absolute paths and ignores relative ones
+	if base := env.Getenv(variable); filepath.IsAbs(base) {
+		return filepath.Join(base, DefaultCacheDirName)
+	}
+	if homeDir, err := dependencies.HomeDir(env); err == nil && filepath.IsAbs(homeDir) {
+		return filepath.Join(homeDir, defaultDir, DefaultCacheDirName)
+	}
+	return legacyBaseDir()
+}
+
+// legacyBaseDir returns the cache directory of earlier versions
+func legacyBaseDir() string {
+	return filepath.Join(os.TempDir(), DefaultCacheDirName)
+}
+
+// Migrate moves the cache that earlier versions kept in the system temp
+// directory to the cache directory of env, the first time it is found.
+// Everything in the cache can be fetched again, so a failed move leaves the
+// new directory empty rather than failing.
+func Migrate(fs afero.Fs, env dependencies.Environment) error {
+	legacy, dir := legacyBaseDir(), BaseDir(env)
+	if dir == legacy {
+		return nil
+	}
+	if info, err := fs.Stat(legacy); err != nil || !info.IsDir() {
+		return nil
+	}
+	if _, err := fs.Stat(dir); err == nil {
+		return nil
+	}
+	if err := fs.MkdirAll(filepath.Dir(dir), 0o755); err != nil {
+		return contextureerrors.Wrap(err, "create cache directory")
+	}
+	// The temp directory is often another filesystem, which rename cannot
+	// move across
+	if err := fs.Rename(legacy, dir); err != nil {
+		if err := staging.CopyDir(fs, legacy, dir); err != nil {
+			_ = fs.RemoveAll(dir)
+			return contextureerrors.WithOpf("migrate cache", "failed to copy %s to %s: %w", legacy, dir, err)
+		}
+		if err := fs.RemoveAll(legacy); err != nil {
+			log.Debug("Failed to remove the previous cache directory", "path", legacy, "error", err)
+		}
+	}
+	log.Debug("Moved cache directory", "from", legacy, "to", dir)
+	return nil
+}
+
+// MirrorResolver returns the mirror URLs of a repository, in the order they
+// are tried when the repository itself is unavailable
+type MirrorResolver interface {
+	Mirrors(repoURL string) []string
+}
+
 // SimpleCache provides cross-session repository caching with human-readable names
 type SimpleCache struct {
 	fs         afero.Fs
 	repository git.Repository
 	baseDir    string
+	mirrors    MirrorResolver
 }
 
-// NewSimpleCache creates a new simple cache
-func NewSimpleCache(fs afero.Fs, repository git.Repository) *SimpleCache {
-	baseDir := filepath.Join(os.TempDir(), DefaultCacheDirName)
+// NewSimpleCache creates a new simple cache in the cache directory of env
+func NewSimpleCache(fs afero.Fs, env dependencies.Environment, repository git.Repository) *SimpleCache {
 	return &SimpleCache{
 		fs:         fs,
 		repository: repository,
-		baseDir:    baseDir,
+		baseDir:    BaseDir(env),
 	}
 }
 
+// SetMirrors makes clones fail over to the mirrors resolver returns when a
+// repository is unavailable
+func (c *SimpleCache) SetMirrors(resolver MirrorResolver) {
+	c.mirrors = resolver
+}
+
 // GetRepository retrieves a repository from the cache or clones it if not present.
 // It returns the local path to the cached repository without pulling updates.
 // Use GetRepositoryWithUpdate if you need to ensure the latest changes are pulled.
@@ -70,6 +164,16 @@ func (c *SimpleCache) GetRepositoryWithUpdate(
 	return c.getRepository(ctx, repoURL, gitRef, true)
 }
 
+// CachedRepository returns the local path of a repository that is already
+// cached, without cloning or pulling it.
+func (c *SimpleCache) CachedRepository(repoURL, gitRef string) (string, bool) {
+	cachePath := filepath.Join(c.baseDir, c.generateCacheKey(repoURL, gitRef))
+	if !c.isValidRepository(cachePath) {
+		return "", false
+	}
+	return cachePath, true
+}
+
 // getRepository is the shared implementation for both cache access patterns
 func (c *SimpleCache) getRepository(
 	ctx context.Context,
@@ -113,15 +217,59 @@ func (c *SimpleCache) cloneRepository(
 		return "", contextureerrors.Wrap(err, "create cache base directory")
 	}
 
+	// The clone is partial until it completes or is removed, so an interrupted
+	// process can remove it. A marker beside it tells garbage collection the
+	// clone of a stopped process from directories it does not own.
+	marker := CloneMarker(cachePath)
+	if err := c.fs.MkdirAll(filepath.Dir(marker), 0o755); err != nil {
+		return "", contextureerrors.Wrap(err, "create cache directory")
+	}
+	if err := afero.WriteFile(c.fs, marker, nil, 0o644); err != nil {
+		return "", contextureerrors.Wrap(err, "mark partial clone")
+	}
+	defer func() { _ = c.fs.Remove(marker) }()
+	release := cleanup.Track(c.fs, cachePath)
+	defer release()
+
 	// Clone repository to cache
 	log.Debug("Cloning repository to cache", "url", repoURL, "ref", gitRef, "path", cachePath)
-	if err := c.repository.Clone(ctx, repoURL, cachePath, git.WithBranch(gitRef)); err != nil {
-		// Clean up failed clone
-		_ = c.fs.RemoveAll(cachePath)
-		return "", contextureerrors.Wrap(err, "clone repository")
+	err := c.repository.Clone(ctx, repoURL, cachePath, git.WithBranch(gitRef))
+	if err == nil {
+		return cachePath, nil
+	}
+	// Clean up failed clone
+	_ = c.fs.RemoveAll(cachePath)
+
+	// Mirrors are tried in order when the repository cannot be reached; the
+	// clone is cached under the repository URL either way
+	if c.mirrors != nil && git.IsUnavailable(err) {
+		for _, mirror := range c.mirrors.Mirrors(repoURL) {
+			log.Warn("Repository unavailable, trying mirror", "url", repoURL, "mirror", mirror, "error", err)
+			mirrorErr := c.repository.Clone(ctx, mirror, cachePath, git.WithBranch(gitRef))
+			if mirrorErr == nil {
+				log.Info("Fetched repository from mirror", "url", repoURL, "mirror", mirror)
+				return cachePath, nil
+			}
+			_ = c.fs.RemoveAll(cachePath)
+			log.Debug("Mirror clone failed", "mirror", mirror, "error", mirrorErr)
+			if !git.IsUnavailable(mirrorErr) {
+				return "", contextureerrors.Wrap(mirrorErr, "clone repository from mirror")
+			}
+		}
 	}
 
-	return cachePath, nil
+	return "", contextureerrors.Wrap(err, "clone repository")
+}
+
+// CloneMarker returns the file that exists beside the repository clone at
+// path while the clone is in progress
+func CloneMarker(path string) string {
+	return filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+cloneMarkerSuffix)
+}
+
+// IsCloneMarker reports whether name is the name of a clone marker
+func IsCloneMarker(name string) bool {
+	return strings.HasPrefix(name, ".") && strings.HasSuffix(name, cloneMarkerSuffix)
 }
 
 // generateCacheKey creates human-readable cache directory name
@@ -3,8 +3,10 @@ package cache
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"testing"
 
+	"github.com/contextureai/contexture/internal/dependencies"
 	"github.com/contextureai/contexture/internal/git"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
@@ -16,6 +18,49 @@ const (
 	testMainBranch = "main"
 )
 
+// testEnv places the cache directory in /cache
+var testEnv = dependencies.MapEnvironment{XDGCacheHomeEnv: "/cache"}
+
+func TestBaseDir(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, filepath.Join("/cache", DefaultCacheDirName), BaseDir(testEnv))
+	home := dependencies.MapEnvironment{"HOME": "/home/user"}
+	assert.Equal(t, "/home/user/.cache/contexture", BaseDir(home))
+	// Relative paths are ignored, as the XDG Base Directory specification requires
+	assert.Equal(t, BaseDir(home), BaseDir(dependencies.MapEnvironment{"HOME": "/home/user", XDGCacheHomeEnv: "cache"}))
+	assert.Equal(t, legacyBaseDir(), BaseDir(dependencies.MapEnvironment{}))
+}
+
+func TestStateDir(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "/state/contexture", StateDir(dependencies.MapEnvironment{XDGStateHomeEnv: "/state"}))
+	assert.Equal(t, "/home/user/.local/state/contexture", StateDir(dependencies.MapEnvironment{"HOME": "/home/user"}))
+	assert.Equal(t, legacyBaseDir(), StateDir(dependencies.MapEnvironment{}))
+}
+
+func TestMigrate(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	legacy := filepath.Join(legacyBaseDir(), "github.com_test_repo-main", ".git", "HEAD")
+	require.NoError(t, fs.MkdirAll(filepath.Dir(legacy), 0o755))
+	require.NoError(t, afero.WriteFile(fs, legacy, []byte("ref: refs/heads/main"), 0o644))
+
+	require.NoError(t, Migrate(fs, testEnv))
+	content, err := afero.ReadFile(fs, "/cache/contexture/github.com_test_repo-main/.git/HEAD")
+	require.NoError(t, err)
+	assert.Equal(t, "ref: refs/heads/main", string(content))
+	exists, err := afero.DirExists(fs, legacyBaseDir())
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	// An existing cache directory is kept
+	require.NoError(t, afero.WriteFile(fs, legacy, []byte("stale"), 0o644))
+	require.NoError(t, Migrate(fs, testEnv))
+	content, err = afero.ReadFile(fs, "/cache/contexture/github.com_test_repo-main/.git/HEAD")
+	require.NoError(t, err)
+	assert.Equal(t, "ref: refs/heads/main", string(content))
+}
+
 func TestSimpleCache_generateCacheKey(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -127,12 +172,12 @@ func TestSimpleCache_GetRepository(t *testing.T) {
 	t.Parallel()
 	fs := afero.NewMemMapFs()
 	mockRepo := git.NewMockRepository(t)
-	cache := NewSimpleCache(fs, mockRepo)
+	cache := NewSimpleCache(fs, testEnv, mockRepo)
 
 	t.Run("clone repository when not cached", func(t *testing.T) {
 		repoURL := "https://github.com/test/repo.git"
 		gitRef := testMainBranch
-		expectedPath := "/tmp/contexture/github.com_test_repo-main"
+		expectedPath := "/cache/contexture/github.com_test_repo-main"
 
 		// Mock successful clone
 		mockRepo.On("Clone", mock.Anything, repoURL, expectedPath, mock.Anything).Return(nil)
@@ -147,7 +192,7 @@ func TestSimpleCache_GetRepository(t *testing.T) {
 	t.Run("use cached repository when available", func(t *testing.T) {
 		repoURL := "https://github.com/test/cached.git"
 		gitRef := testMainBranch
-		cachedPath := "/tmp/contexture/github.com_test_cached-main"
+		cachedPath := "/cache/contexture/github.com_test_cached-main"
 
 		// Set up cached repository
 		_ = fs.MkdirAll(cachedPath+"/.git", 0o755)
@@ -164,12 +209,12 @@ func TestSimpleCache_GetRepositoryWithUpdate(t *testing.T) {
 	t.Parallel()
 	fs := afero.NewMemMapFs()
 	mockRepo := git.NewMockRepository(t)
-	cache := NewSimpleCache(fs, mockRepo)
+	cache := NewSimpleCache(fs, testEnv, mockRepo)
 
 	t.Run("clone repository when not cached", func(t *testing.T) {
 		repoURL := "https://github.com/test/update-repo.git"
 		gitRef := "develop"
-		expectedPath := "/tmp/contexture/github.com_test_update-repo-develop"
+		expectedPath := "/cache/contexture/github.com_test_update-repo-develop"
 
 		// Mock successful clone
 		mockRepo.On("Clone", mock.Anything, repoURL, expectedPath, mock.Anything).Return(nil)
@@ -184,7 +229,7 @@ func TestSimpleCache_GetRepositoryWithUpdate(t *testing.T) {
 	t.Run("pull updates when repository is cached", func(t *testing.T) {
 		repoURL := "https://github.com/test/update-cached.git"
 		gitRef := testMainBranch
-		cachedPath := "/tmp/contexture/github.com_test_update-cached-main"
+		cachedPath := "/cache/contexture/github.com_test_update-cached-main"
 
 		// Set up cached repository
 		_ = fs.MkdirAll(cachedPath+"/.git", 0o755)
@@ -202,7 +247,7 @@ func TestSimpleCache_GetRepositoryWithUpdate(t *testing.T) {
 	t.Run("continue with cached version when pull fails", func(t *testing.T) {
 		repoURL := "https://github.com/test/pull-fail.git"
 		gitRef := testMainBranch
-		cachedPath := "/tmp/contexture/github.com_test_pull-fail-main"
+		cachedPath := "/cache/contexture/github.com_test_pull-fail-main"
 
 		// Set up cached repository
 		_ = fs.MkdirAll(cachedPath+"/.git", 0o755)
@@ -219,3 +264,61 @@ func TestSimpleCache_GetRepositoryWithUpdate(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 }
+
+func TestSimpleCache_CachedRepository(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	cache := NewSimpleCache(fs, testEnv, git.NewMockRepository(t))
+	repoURL := "https://github.com/test/repo.git"
+
+	_, ok := cache.CachedRepository(repoURL, testMainBranch)
+	assert.False(t, ok)
+
+	expectedPath := filepath.Join(BaseDir(testEnv), "github.com_test_repo-main")
+	require.NoError(t, fs.MkdirAll(filepath.Join(expectedPath, ".git"), 0o755))
+
+	path, ok := cache.CachedRepository(repoURL, testMainBranch)
+	assert.True(t, ok)
+	assert.Equal(t, expectedPath, path)
+}
+
+// staticMirrors resolves the same mirrors for every repository
+type staticMirrors []string
+
+func (m staticMirrors) Mirrors(string) []string { return m }
+
+func TestSimpleCache_MirrorFailover(t *testing.T) {
+	t.Parallel()
+
+	t.Run("clones from the first available mirror", func(t *testing.T) {
+		t.Parallel()
+		mockRepo := git.NewMockRepository(t)
+		cache := NewSimpleCache(afero.NewMemMapFs(), testEnv, mockRepo)
+		cache.SetMirrors(staticMirrors{"https://mirror-a.example.com/rules.git", "https://mirror-b.example.com/rules.git"})
+
+		repoURL := "https://github.com/test/mirrored.git"
+		expectedPath := filepath.Join(BaseDir(testEnv), "github.com_test_mirrored-main")
+		mockRepo.On("Clone", mock.Anything, repoURL, expectedPath, mock.Anything).Return(fmt.Errorf("clone: %w", git.ErrAuthFailed))
+		mockRepo.On("Clone", mock.Anything, "https://mirror-a.example.com/rules.git", expectedPath, mock.Anything).
+			Return(fmt.Errorf("clone: %w", context.DeadlineExceeded))
+		mockRepo.On("Clone", mock.Anything, "https://mirror-b.example.com/rules.git", expectedPath, mock.Anything).Return(nil)
+
+		path, err := cache.GetRepository(context.Background(), repoURL, testMainBranch)
+		require.NoError(t, err)
+		assert.Equal(t, expectedPath, path)
+	})
+
+	t.Run("does not fail over on other errors", func(t *testing.T) {
+		t.Parallel()
+		mockRepo := git.NewMockRepository(t)
+		cache := NewSimpleCache(afero.NewMemMapFs(), testEnv, mockRepo)
+		cache.SetMirrors(staticMirrors{"https://mirror-a.example.com/rules.git"})
+
+		repoURL := "https://github.com/test/missing.git"
+		mockRepo.On("Clone", mock.Anything, repoURL, mock.Anything, mock.Anything).Return(fmt.Errorf("repository not found"))
+
+		_, err := cache.GetRepository(context.Background(), repoURL, testMainBranch)
+		require.Error(t, err)
+		mockRepo.AssertNumberOfCalls(t, "Clone", 1)
+	})
+}
@@ -0,0 +1,69 @@
+// Package cleanup tracks the partial files and directories of operations in
+// progress, so they can be removed when the process is interrupted
+package cleanup
+
+import (
+	"errors"
+	"sync"
+
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/spf13/afero"
+)
+
+// entry is a tracked path and the filesystem it is on
+type entry struct {
+	fs   afero.Fs
+	path string
+}
+
+var (
+	mu      sync.Mutex
+	nextID  int
+	tracked = map[int]entry{}
+)
+
+// Track registers path as partial until the returned function is called. The
+// operation creating path calls it once path is complete or removed.
+func Track(fs afero.Fs, path string) func() {
+	mu.Lock()
+	defer mu.Unlock()
+	id := nextID
+	nextID++
+	tracked[id] = entry{fs: fs, path: path}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			mu.Lock()
+			defer mu.Unlock()
+			delete(tracked, id)
+		})
+	}
+}
+
+// Pending returns the paths still tracked
+func Pending() []string {
+	mu.Lock()
+	defer mu.Unlock()
+	paths := make([]string, 0, len(tracked))
+	for _, e := range tracked {
+		paths = append(paths, e.path)
+	}
+	return paths
+}
+
+// RemoveAll removes the paths still tracked and stops tracking them
+func RemoveAll() error {
+	mu.Lock()
+	entries := tracked
+	tracked = map[int]entry{}
+	mu.Unlock()
+
+	var errs []error
+	for _, e := range entries {
+		if err := e.fs.RemoveAll(e.path); err != nil {
+			errs = append(errs, contextureerrors.WithOpf("remove partial files", "failed to delete %s: %w", e.path, err))
+		}
+	}
+	return errors.Join(errs...)
+}
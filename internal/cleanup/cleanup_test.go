@@ -0,0 +1,39 @@
+package cleanup
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The tests share the tracked paths of the package, so they do not run in parallel
+
+func TestRemoveAll(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, fs.MkdirAll("/cache/partial/.git", 0o755))
+	require.NoError(t, afero.WriteFile(fs, "/cache/done.txt", []byte("done"), 0o644))
+
+	Track(fs, "/cache/partial")
+	release := Track(fs, "/cache/done.txt")
+	release()
+	release()
+
+	assert.Equal(t, []string{"/cache/partial"}, Pending())
+	require.NoError(t, RemoveAll())
+
+	exists, _ := afero.Exists(fs, "/cache/partial")
+	assert.False(t, exists)
+	exists, _ = afero.Exists(fs, "/cache/done.txt")
+	assert.True(t, exists)
+	assert.Empty(t, Pending())
+}
+
+func TestRemoveAll_Missing(t *testing.T) {
+	release := Track(afero.NewMemMapFs(), "/never/created")
+	defer release()
+
+	require.NoError(t, RemoveAll())
+	assert.Empty(t, Pending())
+}
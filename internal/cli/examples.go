@@ -0,0 +1,29 @@
+package cli
+
+import "github.com/urfave/cli/v3"
+
+// examplesMetadataKey is the Command.Metadata key that holds command examples
+const examplesMetadataKey = "examples"
+
+// Example is a sample invocation shown in the Examples section of command help
+type Example struct {
+	// Command is the full command line, including the program name
+	Command string
+	// Description optionally explains the example and those following it
+	Description string
+}
+
+// ExamplesMetadata returns command metadata holding the given examples, for
+// use as the Metadata field of a cli.Command
+func ExamplesMetadata(examples ...Example) map[string]any {
+	return map[string]any{examplesMetadataKey: examples}
+}
+
+// CommandExamples returns the examples attached to cmd with ExamplesMetadata
+func CommandExamples(cmd *cli.Command) []Example {
+	if cmd == nil || cmd.Metadata == nil {
+		return nil
+	}
+	examples, _ := cmd.Metadata[examplesMetadataKey].([]Example)
+	return examples
+}
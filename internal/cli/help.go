@@ -17,8 +17,9 @@ const (
 	commandTemplatePattern = "{{.HelpName}} - {{.Usage}}"
 
 	// Layout constants
-	commandPadding  = 3
-	defaultMaxWidth = 80
+	commandPadding = 3
+	// Narrowest column a description is wrapped to before wrapping is skipped
+	minDescriptionWidth = 20
 
 	// Footer messages
 	commandHelpFooter = "Use \"contexture [command] --help\" for more information about a command."
@@ -115,6 +116,8 @@ func (p *helpPrinter) render(templ string, data any) (string, error) {
 // renderer implements the Renderer interface
 type renderer struct {
 	styles StyleProvider
+	// width is the column to wrap at; zero uses the terminal width
+	width int
 }
 
 // NewRenderer creates a new renderer with the given style provider
@@ -144,6 +147,15 @@ func (r *renderer) RenderApp(cmd *cli.Command) (string, error) {
 		r.writeCommands(&help, cmd.Commands)
 	}
 
+	// Help topics are only listed on the root command
+	isRoot := cmd.Root() == cmd
+	if isRoot {
+		r.writeTopics(&help)
+	}
+
+	// Examples
+	r.writeExamples(&help, CommandExamples(cmd))
+
 	// Global options
 	if len(cmd.Flags) > 0 {
 		if err := r.writeGlobalOptions(&help, cmd.Flags); err != nil {
@@ -154,6 +166,10 @@ func (r *renderer) RenderApp(cmd *cli.Command) (string, error) {
 	// Footer
 	help.WriteString(r.styles.DescriptionStyle().Render(commandHelpFooter))
 	help.WriteString("\n")
+	if isRoot {
+		help.WriteString(r.styles.DescriptionStyle().Render(topicHelpFooter))
+		help.WriteString("\n")
+	}
 
 	return help.String(), nil
 }
@@ -183,6 +199,9 @@ func (r *renderer) RenderCommand(cmd *cli.Command) (string, error) {
 		}
 	}
 
+	// Examples
+	r.writeExamples(&help, CommandExamples(cmd))
+
 	return help.String(), nil
 }
 
@@ -246,10 +265,23 @@ func (r *renderer) writeTitle(w *strings.Builder, cmd *cli.Command) {
 	w.WriteString("\n\n")
 }
 
+// lineWidth returns the column help output is wrapped at
+func (r *renderer) lineWidth() int {
+	if r.width > 0 {
+		return r.width
+	}
+	return helpWidth()
+}
+
 func (r *renderer) writeDescription(w *strings.Builder, description string) {
 	// White for long description
 	whiteStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
-	w.WriteString(whiteStyle.Render(description))
+	for i, line := range wrapText(description, r.lineWidth()) {
+		if i > 0 {
+			w.WriteString("\n")
+		}
+		w.WriteString(whiteStyle.Render(line))
+	}
 	w.WriteString("\n\n")
 }
 
@@ -259,7 +291,6 @@ func (r *renderer) writeCommands(w *strings.Builder, commands []*cli.Command) {
 
 	// Calculate alignment
 	maxWidth := r.calculateMaxCommandWidth(commands)
-	darkerGrayStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#808080"))
 
 	// Render visible commands
 	for _, cmd := range commands {
@@ -268,12 +299,54 @@ func (r *renderer) writeCommands(w *strings.Builder, commands []*cli.Command) {
 		}
 
 		names := r.getCommandNames(cmd)
-		nameStr := strings.Join(names, ", ")
+		r.writeRow(w, strings.Join(names, ", "), cmd.Usage, maxWidth)
+	}
+
+	w.WriteString("\n")
+}
+
+func (r *renderer) writeTopics(w *strings.Builder) {
+	topics := Topics()
+	if len(topics) == 0 {
+		return
+	}
+
+	w.WriteString(r.styles.HeaderStyle().Render("Help Topics:"))
+	w.WriteString("\n")
+
+	maxWidth := 0
+	for _, topic := range topics {
+		maxWidth = max(maxWidth, len(topic.Name))
+	}
+	for _, topic := range topics {
+		r.writeRow(w, topic.Name, topic.Summary, maxWidth)
+	}
+
+	w.WriteString("\n")
+}
+
+func (r *renderer) writeExamples(w *strings.Builder, examples []Example) {
+	if len(examples) == 0 {
+		return
+	}
+
+	w.WriteString(r.styles.HeaderStyle().Render("Examples:"))
+	w.WriteString("\n")
 
+	// Example commands are never wrapped so they can be copied as-is
+	for i, example := range examples {
+		if example.Description != "" {
+			if i > 0 {
+				w.WriteString("\n")
+			}
+			for _, line := range wrapText("# "+example.Description, r.lineWidth()-4) {
+				w.WriteString("    ")
+				w.WriteString(r.styles.DescriptionStyle().Render(line))
+				w.WriteString("\n")
+			}
+		}
 		w.WriteString("    ")
-		w.WriteString(r.styles.CommandStyle().Render(nameStr))
-		w.WriteString(strings.Repeat(" ", maxWidth-len(nameStr)+commandPadding))
-		w.WriteString(darkerGrayStyle.Render(cmd.Usage))
+		w.WriteString(r.styles.CommandStyle().Render(example.Command))
 		w.WriteString("\n")
 	}
 
@@ -295,22 +368,40 @@ func (r *renderer) writeOptions(w *strings.Builder, flags []cli.Flag) error {
 func (r *renderer) writeFlags(w *strings.Builder, flags []cli.Flag) error {
 	// Calculate max width for alignment
 	maxWidth := r.calculateMaxFlagWidth(flags)
-	darkerGrayStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#808080"))
 
 	for _, flag := range flags {
 		if flagStr := flag.String(); flagStr != "" {
 			// Parse flag string to separate flag name from description
 			flagName, flagDesc := r.parseFlagString(flagStr)
+			r.writeRow(w, flagName, flagDesc, maxWidth)
+		}
+	}
+	w.WriteString("\n")
+	return nil
+}
 
-			w.WriteString("    ")
-			w.WriteString(r.styles.CommandStyle().Render(flagName))
-			w.WriteString(strings.Repeat(" ", maxWidth-len(flagName)+commandPadding))
-			w.WriteString(darkerGrayStyle.Render(flagDesc))
+// writeRow writes an aligned name and description, wrapping the description
+// into its own column when it does not fit on the line
+func (r *renderer) writeRow(w *strings.Builder, name, description string, nameWidth int) {
+	darkerGrayStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#808080"))
+	indent := 4 + nameWidth + commandPadding
+
+	w.WriteString("    ")
+	w.WriteString(r.styles.CommandStyle().Render(name))
+	w.WriteString(strings.Repeat(" ", nameWidth-len(name)+commandPadding))
+
+	lines := []string{description}
+	if available := r.lineWidth() - indent; available >= minDescriptionWidth {
+		lines = wrapText(description, available)
+	}
+	for i, line := range lines {
+		if i > 0 {
 			w.WriteString("\n")
+			w.WriteString(strings.Repeat(" ", indent))
 		}
+		w.WriteString(darkerGrayStyle.Render(line))
 	}
 	w.WriteString("\n")
-	return nil
 }
 
 func (r *renderer) writeUsage(w *strings.Builder, cmd *cli.Command) {
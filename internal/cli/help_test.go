@@ -394,3 +394,103 @@ func TestTemplateDetection(t *testing.T) {
 		})
 	}
 }
+
+func TestRenderCommandExamples(t *testing.T) {
+	t.Parallel()
+	cmd := &cli.Command{
+		Name:  "query",
+		Usage: "Search rules",
+		Metadata: ExamplesMetadata(
+			Example{Command: "contexture query testing", Description: "Simple text search"},
+			Example{Command: `contexture query "go test"`},
+			Example{Command: "contexture query --expr 'Tag contains \"testing\"'", Description: "Advanced expression search"},
+		),
+	}
+
+	output, err := NewRenderer(NewStyleProvider()).RenderCommand(cmd)
+	require.NoError(t, err)
+
+	assert.Contains(t, output, "Examples:\n"+
+		"    # Simple text search\n"+
+		"    contexture query testing\n"+
+		"    contexture query \"go test\"\n"+
+		"\n"+
+		"    # Advanced expression search\n"+
+		"    contexture query --expr 'Tag contains \"testing\"'\n")
+	assert.Len(t, CommandExamples(cmd), 3)
+	assert.Empty(t, CommandExamples(&cli.Command{Name: "plain"}))
+}
+
+func TestRenderWrapsToWidth(t *testing.T) {
+	t.Parallel()
+	cmd := &cli.Command{
+		Name:        "test",
+		Usage:       "test command",
+		Description: "This description is long enough that it has to be wrapped onto more than one line.\n  • A bullet item that is also long enough to need wrapping",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "output",
+				Usage: "Write the generated output to this file instead of standard output",
+			},
+		},
+	}
+
+	r := &renderer{styles: NewStyleProvider(), width: 40}
+	output, err := r.RenderCommand(cmd)
+	require.NoError(t, err)
+
+	assert.Contains(t, output, "This description is long enough that it\nhas to be wrapped onto more than one\nline.")
+	assert.Contains(t, output, "  • A bullet item that is also long\n    enough to need wrapping")
+	for line := range strings.SplitSeq(output, "\n") {
+		if strings.Contains(line, "--output") {
+			continue
+		}
+		assert.LessOrEqual(t, len(line), 40, "line %q exceeds the width", line)
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		text  string
+		width int
+		want  []string
+	}{
+		{
+			name:  "short line is unchanged",
+			text:  "short line",
+			width: 20,
+			want:  []string{"short line"},
+		},
+		{
+			name:  "keeps line breaks and indentation",
+			text:  "first\n  indented words that wrap",
+			width: 14,
+			want:  []string{"first", "  indented", "  words that", "  wrap"},
+		},
+		{
+			name:  "hanging indent for bullets",
+			text:  "- item with several words",
+			width: 12,
+			want:  []string{"- item with", "  several", "  words"},
+		},
+		{
+			name:  "long word is not split",
+			text:  "https://github.com/contextureai/rules.git",
+			width: 10,
+			want:  []string{"https://github.com/contextureai/rules.git"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, wrapText(tt.text, tt.width))
+		})
+	}
+
+	assert.Equal(t, minHelpWidth, clampWidth(10))
+	assert.Equal(t, maxHelpWidth, clampWidth(300))
+	assert.Equal(t, 72, clampWidth(72))
+}
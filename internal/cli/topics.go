@@ -0,0 +1,241 @@
+package cli
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+)
+
+// Footer messages linking the root help and the topic pages
+const (
+	topicHelpFooter = "Use \"contexture help [topic]\" for more information about a topic."
+	topicListFooter = "Use \"contexture help\" to list all commands and help topics."
+)
+
+// Topic is a help page about a concept rather than a command, shown by
+// `contexture help <topic>`
+type Topic struct {
+	Name    string
+	Summary string
+	// Content is lightweight markdown: "## " headings, "- " bullet items,
+	// lines indented by four spaces shown verbatim, and paragraphs that are
+	// wrapped to the terminal width
+	Content string
+}
+
+// topics lists the built-in help topics in the order they are shown
+var topics = []Topic{
+	{
+		Name:    "rule-ids",
+		Summary: "How to reference rules from providers, URLs, and local files",
+		Content: `A rule ID tells contexture where to find a rule. Most commands that take
+rules, such as rules add, rules remove, and vars, accept any of these forms.
+
+## Provider syntax
+
+The recommended form names a provider and the rule path within its repository,
+without the .md extension:
+
+    @contexture/languages/go/testing
+    @mycompany/security/auth
+
+A path without a provider uses the default @contexture provider:
+
+    languages/go/testing
+
+## Bracketed syntax
+
+The bracketed form can pin a Git ref and pass variables inline. The source in
+parentheses is a provider name or a repository URL, and defaults to contexture:
+
+    [contexture:path/to/rule]
+    [contexture(@mycompany):path/to/rule,v1.2.0]
+    [contexture(https://github.com/user/repo.git):path/to/rule] {"key": "value"}
+
+A Git URL followed by # and the rule path is also accepted:
+
+    https://github.com/user/repo.git#path/to/rule
+
+## Versions and variables
+
+- Use --ref to add a rule from a branch, tag, or commit instead of the provider's default branch.
+- Use --var key=value to set rule variables, or pass a JSON5 object after the rule ID.
+
+    contexture rules add @contexture/go/testing --ref v1.2.0
+    contexture rules add @contexture/testing/coverage --var threshold=90
+
+## Local rules
+
+Rules in the project's rules/ directory are referenced by their path and
+are read directly from disk.`,
+	},
+	{
+		Name:    "providers",
+		Summary: "Named rule repositories used by @provider rule IDs",
+		Content: `A provider is a named Git repository of rules. The provider name is the part
+after @ in a rule ID, so @mycompany/security/auth reads security/auth.md from the
+repository registered as mycompany.
+
+## The default provider
+
+The @contexture provider is always available and points to the community
+rules repository:
+
+    https://github.com/contextureai/rules.git
+
+## Custom providers
+
+Providers are stored in .contexture.yaml, or in the global configuration
+with --global, and can be used by any rule ID once added:
+
+    contexture providers add mycompany https://github.com/mycompany/rules.git
+    contexture rules add @mycompany/security/auth
+
+- contexture providers list shows the configured providers.
+- contexture providers show <name> prints a provider's URL and settings.
+- contexture providers remove <name> deletes a provider.
+
+Run "contexture providers --help" for the options of each command.`,
+	},
+	{
+		Name:    "variables",
+		Summary: "Customizing rule content with template variables",
+		Content: `Rules are Go text/template documents, so variables can customize their
+content for each project.
+
+    Maximum line length: {{.max_line_length}} characters.
+    Test framework: {{default_if_empty .framework "jest"}}
+
+## Where values come from
+
+Variables are resolved in this order of precedence:
+
+- Variables set on the rule reference in .contexture.yaml, with --var or inline JSON5.
+- Default values in the rule's frontmatter.
+- Global variables provided by contexture, such as .date, .time, and .contexture.version.
+
+## Functions
+
+Besides the standard template actions, rules can use upper, lower, titlecase,
+trim, join, and default_if_empty.
+
+## Related commands
+
+    contexture vars languages/go/testing
+    contexture rules add languages/go/testing --var max_line_length=120`,
+	},
+	{
+		Name:    "formats",
+		Summary: "The assistant output formats contexture generates",
+		Content: `Each enabled format turns the project's rules into the files an AI assistant
+reads. Formats are enabled in .contexture.yaml and built with contexture build.
+
+## Built-in formats
+
+- claude writes every rule to a single CLAUDE.md file, optionally through a custom template.
+- cursor writes one file per rule to .cursor/rules/.
+- windsurf writes one file per rule to .windsurf/rules/, split to stay under 12,000 characters per file.
+
+## Managing formats
+
+    contexture config formats add cursor
+    contexture config formats disable windsurf
+    contexture build
+
+Run "contexture config formats --help" for all format commands.`,
+	},
+}
+
+// Topics returns the built-in help topics
+func Topics() []Topic {
+	return topics
+}
+
+// LookupTopic returns the help topic with the given name
+func LookupTopic(name string) (Topic, bool) {
+	for _, topic := range topics {
+		if topic.Name == name {
+			return topic, true
+		}
+	}
+	return Topic{}, false
+}
+
+// RenderTopic renders a help topic styled and wrapped for the terminal
+func RenderTopic(topic Topic) string {
+	return renderTopic(topic, NewStyleProvider(), helpWidth())
+}
+
+// ShowCommandHelpWithTopics wraps a cli.ShowCommandHelp implementation so
+// that `contexture help <topic>` prints the topic page. Topic names take
+// precedence over command names of the root command.
+func ShowCommandHelpWithTopics(
+	next func(context.Context, *cli.Command, string) error,
+) func(context.Context, *cli.Command, string) error {
+	return func(ctx context.Context, cmd *cli.Command, name string) error {
+		topic, ok := LookupTopic(name)
+		if !ok || cmd.Root() != cmd {
+			return next(ctx, cmd, name)
+		}
+		_, err := io.WriteString(cmd.Root().Writer, RenderTopic(topic))
+		return err
+	}
+}
+
+func renderTopic(topic Topic, styles StyleProvider, width int) string {
+	var out strings.Builder
+	out.WriteString(styles.TitleStyle().Render(topic.Name))
+	out.WriteString(" ")
+	out.WriteString(styles.DescriptionStyle().Render(topic.Summary))
+	out.WriteString("\n\n")
+
+	var paragraph []string
+	flush := func() {
+		if len(paragraph) == 0 {
+			return
+		}
+		for _, line := range wrapLine(strings.Join(paragraph, " "), width) {
+			out.WriteString(line)
+			out.WriteString("\n")
+		}
+		paragraph = nil
+	}
+
+	// Headings sit directly above their section, like the headers of command help
+	afterHeading := false
+	for line := range strings.SplitSeq(topic.Content, "\n") {
+		blankAfterHeading := afterHeading && strings.TrimSpace(line) == ""
+		afterHeading = strings.HasPrefix(line, "## ")
+		switch {
+		case blankAfterHeading:
+			continue
+		case strings.TrimSpace(line) == "":
+			flush()
+			out.WriteString("\n")
+		case afterHeading:
+			flush()
+			out.WriteString(styles.HeaderStyle().Render(strings.TrimPrefix(line, "## ") + ":"))
+			out.WriteString("\n")
+		case strings.HasPrefix(line, "    "):
+			flush()
+			out.WriteString(styles.CommandStyle().Render(line))
+			out.WriteString("\n")
+		case strings.HasPrefix(line, "- "):
+			flush()
+			for _, wrapped := range wrapLine("  • "+strings.TrimPrefix(line, "- "), width) {
+				out.WriteString(wrapped)
+				out.WriteString("\n")
+			}
+		default:
+			paragraph = append(paragraph, line)
+		}
+	}
+	flush()
+	out.WriteString("\n")
+	out.WriteString(styles.DescriptionStyle().Render(topicListFooter))
+	out.WriteString("\n")
+
+	return out.String()
+}
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+func TestLookupTopic(t *testing.T) {
+	t.Parallel()
+	for _, name := range []string{"rule-ids", "providers", "variables", "formats"} {
+		topic, ok := LookupTopic(name)
+		require.True(t, ok, name)
+		assert.Equal(t, name, topic.Name)
+		assert.NotEmpty(t, topic.Summary)
+		assert.NotEmpty(t, topic.Content)
+	}
+
+	_, ok := LookupTopic("unknown")
+	assert.False(t, ok)
+}
+
+func TestRenderTopic(t *testing.T) {
+	t.Parallel()
+	topic := Topic{
+		Name:    "sample",
+		Summary: "A sample topic",
+		Content: `An introduction that is long enough to be wrapped
+across lines by the renderer.
+
+## Section
+
+    contexture sample --flag value that stays on one line
+
+- A bullet that wraps onto a second line`,
+	}
+
+	output := renderTopic(topic, NewStyleProvider(), 40)
+
+	assert.Contains(t, output, "sample A sample topic\n\n")
+	assert.Contains(t, output, "An introduction that is long enough to\nbe wrapped across lines by the renderer.\n")
+	assert.Contains(t, output, "\nSection:\n    contexture sample --flag value that stays on one line\n")
+	assert.Contains(t, output, "  • A bullet that wraps onto a second\n    line\n")
+	assert.Contains(t, output, topicListFooter)
+}
+
+func TestShowCommandHelpWithTopics(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	root := &cli.Command{Name: "contexture", Writer: &buf}
+	sub := &cli.Command{Name: "rules"}
+	root.Commands = []*cli.Command{sub}
+
+	var delegated []string
+	show := ShowCommandHelpWithTopics(func(_ context.Context, _ *cli.Command, name string) error {
+		delegated = append(delegated, name)
+		return nil
+	})
+
+	require.NoError(t, show(context.Background(), root, "variables"))
+	assert.True(t, strings.HasPrefix(buf.String(), "variables "))
+	assert.Empty(t, delegated)
+
+	require.NoError(t, show(context.Background(), root, "rules"))
+	assert.Equal(t, []string{"rules"}, delegated)
+}
+
+func TestRenderAppListsTopics(t *testing.T) {
+	t.Parallel()
+	root := &cli.Command{
+		Name:     "contexture",
+		Usage:    "AI assistant rule management",
+		Commands: []*cli.Command{{Name: "rules", Usage: "Manage project rules"}},
+	}
+
+	output, err := NewRenderer(NewStyleProvider()).RenderApp(root)
+	require.NoError(t, err)
+
+	assert.Contains(t, output, "Help Topics:")
+	for _, topic := range Topics() {
+		assert.Contains(t, output, topic.Name)
+	}
+	assert.Contains(t, output, topicHelpFooter)
+}
@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/contextureai/contexture/internal/ui"
+)
+
+// Width limits for wrapped help output
+const (
+	maxHelpWidth = 100
+	minHelpWidth = 40
+)
+
+// helpWidth returns the width help output is wrapped to: the terminal width,
+// capped so long lines stay readable on wide terminals
+func helpWidth() int {
+	return clampWidth(ui.TerminalWidth())
+}
+
+func clampWidth(width int) int {
+	return min(max(width, minHelpWidth), maxHelpWidth)
+}
+
+// wrapText wraps each line of text to width columns. Lines keep their
+// indentation, and continuation lines of a bullet item align with its text.
+func wrapText(text string, width int) []string {
+	var lines []string
+	for line := range strings.SplitSeq(text, "\n") {
+		lines = append(lines, wrapLine(line, width)...)
+	}
+	return lines
+}
+
+// wrapLine wraps a single line at word boundaries
+func wrapLine(line string, width int) []string {
+	if utf8.RuneCountInString(line) <= width {
+		return []string{line}
+	}
+
+	trimmed := strings.TrimLeft(line, " ")
+	indent := line[:len(line)-len(trimmed)]
+	hanging := indent
+	for _, bullet := range []string{"• ", "- ", "* "} {
+		if strings.HasPrefix(trimmed, bullet) {
+			hanging += strings.Repeat(" ", utf8.RuneCountInString(bullet))
+			break
+		}
+	}
+
+	words := strings.Fields(trimmed)
+	if len(words) == 0 {
+		return []string{line}
+	}
+
+	var lines []string
+	current := indent + words[0]
+	for _, word := range words[1:] {
+		if utf8.RuneCountInString(current)+1+utf8.RuneCountInString(word) > width {
+			lines = append(lines, current)
+			current = hanging + word
+			continue
+		}
+		current += " " + word
+	}
+	return append(lines, current)
+}
@@ -5,12 +5,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"os"
-	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/log"
+	"github.com/contextureai/contexture/internal/approval"
+	"github.com/contextureai/contexture/internal/cleanup"
 	"github.com/contextureai/contexture/internal/dependencies"
 	"github.com/contextureai/contexture/internal/domain"
 	contextureerrors "github.com/contextureai/contexture/internal/errors"
@@ -31,6 +32,8 @@ type AddCommand struct {
 	ruleValidator  rule.Validator
 	ruleGenerator  *RuleGenerator
 	registry       *format.Registry
+	workingDir     dependencies.WorkingDir
+	env            dependencies.Environment
 }
 
 // NewAddCommand creates a new add command
@@ -40,11 +43,11 @@ func NewAddCommand(deps *dependencies.Dependencies) *AddCommand {
 	// Create provider registry
 	providerRegistry := deps.ProviderRegistry
 
-	ruleFetcher := rule.NewFetcher(deps.FS, newOpenRepository(deps.FS), rule.FetcherConfig{}, providerRegistry)
+	ruleFetcher := rule.NewFetcher(deps.FS, newOpenRepository(deps.FS), fetcherConfig(deps), providerRegistry)
 	ruleValidator := rule.NewValidator()
 
 	return &AddCommand{
-		projectManager: project.NewManager(deps.FS),
+		projectManager: project.NewManagerFromDependencies(deps),
 		ruleFetcher:    ruleFetcher,
 		ruleValidator:  ruleValidator,
 		ruleGenerator: NewRuleGenerator(
@@ -53,8 +56,10 @@ func NewAddCommand(deps *dependencies.Dependencies) *AddCommand {
 			rule.NewProcessor(),
 			registry,
 			deps.FS,
-		),
-		registry: registry,
+		).withEnvironment(deps.GetWorkingDir(), deps.GetEnv()),
+		registry:   registry,
+		workingDir: deps.GetWorkingDir(),
+		env:        deps.GetEnv(),
 	}
 }
 
@@ -108,14 +113,14 @@ func (c *AddCommand) ExecuteWithDeps(ctx context.Context, cmd *cli.Command, rule
 	isGlobal := cmd.Bool("global")
 
 	// Load configuration
-	config, configPath, err := loadConfigByScope(c.projectManager, isGlobal)
+	config, configPath, err := loadConfigByScope(c.projectManager, c.workingDir, isGlobal)
 	if err != nil {
 		return err
 	}
 
 	var currentDir string
 	if !isGlobal {
-		currentDir, err = os.Getwd()
+		currentDir, err = c.workingDir.Getwd()
 		if err != nil {
 			return contextureerrors.Wrap(err, "get current directory")
 		}
@@ -125,7 +130,7 @@ func (c *AddCommand) ExecuteWithDeps(ctx context.Context, cmd *cli.Command, rule
 	// Only check if we're in a project context (not just adding to global config)
 	if isGlobal && !isJSONMode && currentDir == "" {
 		// Try to load project config to check for Cursor
-		cwd, cwdErr := os.Getwd()
+		cwd, cwdErr := c.workingDir.Getwd()
 		if cwdErr == nil {
 			if projectConfigResult, loadErr := c.projectManager.LoadConfig(cwd); loadErr == nil {
 				for _, formatConfig := range projectConfigResult.Config.Formats {
@@ -136,7 +141,7 @@ func (c *AddCommand) ExecuteWithDeps(ctx context.Context, cmd *cli.Command, rule
 							mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
 							fmt.Printf("%s %s\n\n",
 								mutedStyle.Render("⚠"),
-								mutedStyle.Render("Cursor does not support native global rules. Your global rules will be merged into project files, which may cause conflicts in team environments. Consider setting Cursor's userRulesMode to 'disabled' in .contexture.yaml"))
+								mutedStyle.Render("Cursor does not support native global rules. Your global rules will be merged into project files, which may cause conflicts in team environments. Consider setting Cursor's userRulesMode to 'file' or 'disabled' in .contexture.yaml"))
 							break
 						}
 					}
@@ -227,6 +232,15 @@ func (c *AddCommand) ExecuteWithDeps(ctx context.Context, cmd *cli.Command, rule
 				fullRuleID = fmt.Sprintf("[contexture:%s]", processedRuleID)
 			}
 
+			// A rule configured in another spelling is the same rule, so it
+			// is updated in place rather than added a second time
+			if !c.projectManager.HasRule(config, fullRuleID) && !c.projectManager.HasRule(config, ruleID) {
+				if existing := c.projectManager.FindEquivalentRule(config, fullRuleID); existing != nil {
+					log.Warn("Rule already configured in another spelling", "rule", ruleID, "configured", existing.ID)
+					fullRuleID = existing.ID
+				}
+			}
+
 			// Check if rule already exists (check both formats)
 			if c.projectManager.HasRule(config, fullRuleID) ||
 				c.projectManager.HasRule(config, ruleID) {
@@ -307,6 +321,9 @@ func (c *AddCommand) ExecuteWithDeps(ctx context.Context, cmd *cli.Command, rule
 				ruleRef.Ref = parsedID.Ref
 			}
 
+			// Rules from providers the project does not trust wait for contexture approve
+			ruleRef.Pending = approval.Required(config.Approval, ruleRef)
+
 			validRuleRefs = append(validRuleRefs, ruleRefWithOriginal{
 				ruleRef:     ruleRef,
 				originalID:  ruleID,
@@ -358,23 +375,23 @@ func (c *AddCommand) ExecuteWithDeps(ctx context.Context, cmd *cli.Command, rule
 	if !isJSONMode {
 		if isGlobal {
 			// For global rules, rebuild both global locations and project if in project context
-			if err := c.rebuildAfterGlobalAdd(ctx); err != nil {
-				log.Warn("Failed to auto-generate rules", "error", err)
-				fmt.Println("Rules added but generation failed. Run 'contexture build' manually.")
-				return nil // Exit early - rules were added but generation failed
+			if err := c.rebuildAfterGlobalAdd(ctx, userRulesWriteFromFlags(cmd)); err != nil {
+				// Rules were added but generation failed
+				return contextureerrors.Partial("generate rules", err).
+					WithSuggestions(contextureerrors.RunCommand("contexture build", "generate the rule files"))
 			}
 		} else {
 			// For project rules, use merged config (global + project)
 			if err := c.generateRulesWithMergedConfig(ctx, currentDir); err != nil {
-				log.Warn("Failed to auto-generate rules", "error", err)
-				fmt.Println("Rules added but generation failed. Run 'contexture build' manually.")
-				return nil // Exit early - rules were added but generation failed
+				// Rules were added but generation failed
+				return contextureerrors.Partial("generate rules", err).
+					WithSuggestions(contextureerrors.RunCommand("contexture build", "generate the rule files"))
 			}
 		}
 	}
 
 	// Handle output format
-	outputManager, err := output.NewManager(outputFormat)
+	outputManager, err := output.NewManager(outputFormat, c.workingDir, c.env)
 	if err != nil {
 		return contextureerrors.Wrap(err, "create output manager")
 	}
@@ -396,6 +413,7 @@ func (c *AddCommand) ExecuteWithDeps(ctx context.Context, cmd *cli.Command, rule
 	if err != nil {
 		return contextureerrors.Wrap(err, "write output")
 	}
+	recordRuleUse(deps, addedRuleIDs...)
 
 	// For default format, also display the detailed information
 	if outputFormat == output.FormatDefault {
@@ -418,6 +436,10 @@ func (c *AddCommand) ExecuteWithDeps(ctx context.Context, cmd *cli.Command, rule
 			}
 
 			fmt.Printf("  %s\n", displayRuleID)
+			if ruleRefWithOrig.ruleRef.Pending {
+				mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+				fmt.Printf("    %s\n", mutedStyle.Render("Pending approval, not built until: contexture approve "+displayRuleID))
+			}
 
 			// Show source information for custom source rules (but not provider syntax)
 			// Provider syntax rules start with @ and shouldn't show the underlying git URL
@@ -456,9 +478,10 @@ func (c *AddCommand) Execute(ctx context.Context, cmd *cli.Command, ruleIDs []st
 }
 
 // rebuildAfterGlobalAdd rebuilds outputs after adding a global rule
-// 1. Generates to native user rules locations (e.g., ~/.claude/CLAUDE.md)
+// 1. Generates to native user rules locations (e.g., ~/.claude/CLAUDE.md),
+// showing the changes first and honoring --yes and --no-user-write
 // 2. If in a project, also rebuilds project files based on UserRulesMode
-func (c *AddCommand) rebuildAfterGlobalAdd(ctx context.Context) error {
+func (c *AddCommand) rebuildAfterGlobalAdd(ctx context.Context, write userRulesWrite) error {
 	// Load global config with local rules to get all global rules
 	globalConfigResult, err := c.projectManager.LoadGlobalConfigWithLocalRules()
 	if err != nil {
@@ -479,14 +502,10 @@ func (c *AddCommand) rebuildAfterGlobalAdd(ctx context.Context) error {
 			continue
 		}
 
-		// Only include formats that support native user rules
-		if caps.SupportsUserRules && caps.UserRulesPath != "" {
+		// Only include formats with a native user rules location or user rules file
+		if userRulesPath(formatConfig, caps) != "" {
 			// Create format config for user rules generation
-			userFormatConfig := formatConfig
-			userDir := filepath.Dir(caps.UserRulesPath)
-			userFormatConfig.BaseDir = userDir
-			userFormatConfig.IsUserRules = true
-			userFormats = append(userFormats, userFormatConfig)
+			userFormats = append(userFormats, userRulesFormatConfig(formatConfig, caps, globalConfig))
 		}
 	}
 	log.Debug("Found formats with native user rules support", "count", len(userFormats))
@@ -499,15 +518,15 @@ func (c *AddCommand) rebuildAfterGlobalAdd(ctx context.Context) error {
 		userConfig.Rules = globalConfig.Rules
 
 		log.Debug("Auto-generating global rules to user locations", "formats", len(userFormats), "rules", len(userConfig.Rules))
-		if err := c.ruleGenerator.GenerateRulesWithScope(ctx, userConfig, userFormats, "global"); err != nil {
+		if err := c.ruleGenerator.writeUserRules(ctx, userConfig, userFormats, write); err != nil {
 			log.Error("Failed to generate global rules to user locations", "error", err)
 			return contextureerrors.Wrap(err, "generate global rules to user locations")
 		}
-		log.Debug("Successfully generated global rules to user locations")
+		log.Debug("Generated global rules to user locations")
 	}
 
 	// Check if we're in a project context
-	currentDir, err := os.Getwd()
+	currentDir, err := c.workingDir.Getwd()
 	if err != nil {
 		// Can't determine current directory, skip project rebuild
 		log.Debug("Cannot determine current directory, skipping project rebuild", "error", err)
@@ -542,14 +561,14 @@ func (c *AddCommand) rebuildAfterGlobalAdd(ctx context.Context) error {
 	}
 
 	// Group formats by which rules they need based on UserRulesMode
-	var nativeOnlyFormats []domain.FormatConfig // UserRulesNative - project rules only
+	var nativeOnlyFormats []domain.FormatConfig // UserRulesNative/File - project rules only
 	var mergedFormats []domain.FormatConfig     // UserRulesProject - project + user rules
 	var disabledFormats []domain.FormatConfig   // UserRulesDisabled - project rules only
 
 	for _, formatConfig := range targetFormats {
 		mode := formatConfig.GetEffectiveUserRulesMode()
 		switch mode {
-		case domain.UserRulesNative:
+		case domain.UserRulesNative, domain.UserRulesFile:
 			nativeOnlyFormats = append(nativeOnlyFormats, formatConfig)
 		case domain.UserRulesProject:
 			mergedFormats = append(mergedFormats, formatConfig)
@@ -616,13 +635,13 @@ func (c *AddCommand) generateRulesWithMergedConfig(ctx context.Context, currentD
 	}
 
 	// Group formats by which rules they need based on UserRulesMode
-	var nativeOnlyFormats []domain.FormatConfig // UserRulesNative/Disabled - project rules only
+	var nativeOnlyFormats []domain.FormatConfig // UserRulesNative/File/Disabled - project rules only
 	var mergedFormats []domain.FormatConfig     // UserRulesProject - project + user rules
 
 	for _, formatConfig := range targetFormats {
 		mode := formatConfig.GetEffectiveUserRulesMode()
 		switch mode {
-		case domain.UserRulesNative, domain.UserRulesDisabled:
+		case domain.UserRulesNative, domain.UserRulesFile, domain.UserRulesDisabled:
 			nativeOnlyFormats = append(nativeOnlyFormats, formatConfig)
 		case domain.UserRulesProject:
 			mergedFormats = append(mergedFormats, formatConfig)
@@ -665,11 +684,11 @@ func (c *AddCommand) fetchLatestCommitHash(
 	parsedID *domain.ParsedRuleID,
 ) (string, error) {
 	// Clone the repository to a temporary directory
-	tempDir, cleanup, err := c.cloneRepositoryToTemp(ctx, parsedID.Source, parsedID.Ref)
+	tempDir, removeTempDir, err := c.cloneRepositoryToTemp(ctx, parsedID.Source, parsedID.Ref)
 	if err != nil {
 		return "", contextureerrors.Wrap(err, "clone repository")
 	}
-	defer cleanup()
+	defer removeTempDir()
 
 	// Get the rule file path within the repository
 	ruleFilePath := parsedID.RulePath + ".md"
@@ -697,8 +716,10 @@ func (c *AddCommand) cloneRepositoryToTemp(
 		return "", nil, contextureerrors.Wrap(err, "create temp directory")
 	}
 
-	// Cleanup function
-	cleanup := func() {
+	// Cleanup function; the directory is removed on interrupt until then
+	release := cleanup.Track(afero.NewOsFs(), tempDir)
+	cleanupTempDir := func() {
+		defer release()
 		if err := afero.NewOsFs().RemoveAll(tempDir); err != nil {
 			log.Warn("Failed to cleanup temporary directory", "path", tempDir, "error", err)
 		}
@@ -710,11 +731,11 @@ func (c *AddCommand) cloneRepositoryToTemp(
 	// Clone repository with the specified branch
 	err = gitRepo.Clone(ctx, repoURL, tempDir, git.WithBranch(branch))
 	if err != nil {
-		cleanup() // Clean up on error
+		cleanupTempDir() // Clean up on error
 		return "", nil, contextureerrors.Wrap(err, "clone repository")
 	}
 
-	return tempDir, cleanup, nil
+	return tempDir, cleanupTempDir, nil
 }
 
 // AddAction is the CLI action handler for the add command
@@ -722,9 +743,36 @@ func AddAction(ctx context.Context, cmd *cli.Command, deps *dependencies.Depende
 	ruleIDs := cmd.Args().Slice()
 	addCmd := NewAddCommand(deps)
 
+	isJSONMode := output.Format(cmd.String("output")) == output.FormatJSON
+
+	// Starred rules are added alongside the rules given
+	if cmd.Bool("favorites") {
+		favorites, err := favoriteRules(deps.FS, !isJSONMode)
+		if err != nil {
+			return err
+		}
+		for _, id := range favorites {
+			if !slices.Contains(ruleIDs, id) {
+				ruleIDs = append(ruleIDs, id)
+			}
+		}
+	}
+
+	// Without rule IDs, offer the rules used recently
+	if len(ruleIDs) == 0 && !cmd.Bool("favorites") && !isJSONMode {
+		selected, err := selectRecentRules(deps)
+		if err != nil {
+			return err
+		}
+		ruleIDs = selected
+	}
+
 	// If no rule IDs provided, show helpful error message
 	if len(ruleIDs) == 0 {
-		return contextureerrors.ValidationErrorf("rule-id", "no rule IDs provided")
+		return contextureerrors.Validation("rule-id", "no rule IDs provided").WithSuggestions(
+			contextureerrors.RunCommand("contexture query <term>", "find rule IDs to add"),
+			contextureerrors.RunCommand("contexture rules add --help", "see more options"),
+		)
 	}
 
 	return addCmd.ExecuteWithDeps(ctx, cmd, ruleIDs, deps)
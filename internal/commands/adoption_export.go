@@ -0,0 +1,125 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/version"
+	"github.com/spf13/afero"
+)
+
+// adoptionExportTimeout bounds a webhook delivery of adoption counts
+const adoptionExportTimeout = 30 * time.Second
+
+// adoptionExport holds anonymized rule adoption counts for rule maintainers.
+// It names no repositories, only how many use each rule of each source.
+type adoptionExport struct {
+	GeneratedAt  time.Time        `json:"generatedAt"`
+	Contexture   string           `json:"contexture"`   // version that produced the export
+	Repositories int              `json:"repositories"` // repositories audited successfully
+	Sources      []sourceAdoption `json:"sources"`
+}
+
+// sourceAdoption counts the adoption of the rules of one source repository
+type sourceAdoption struct {
+	Source string             `json:"source"` // repository URL without credentials
+	Rules  []rulePathAdoption `json:"rules"`
+}
+
+// rulePathAdoption counts the repositories using a rule. ID is the rule path
+// within its source, as listed in the source's index.yaml.
+type rulePathAdoption struct {
+	ID           string `json:"id"`
+	Repositories int    `json:"repositories"`
+}
+
+// adoptionCounts accumulates the repositories using each rule by source and path
+type adoptionCounts map[string]map[string]int
+
+// add counts one repository using the rule at path in source
+func (a adoptionCounts) add(source, path string) {
+	source = stripCredentials(source)
+	if a[source] == nil {
+		a[source] = map[string]int{}
+	}
+	a[source][path]++
+}
+
+// export returns the counts as an export, sorted by source and by adoption
+func (a adoptionCounts) export(repositories int, now time.Time) adoptionExport {
+	export := adoptionExport{
+		GeneratedAt:  now.UTC(),
+		Contexture:   version.GetShort(),
+		Repositories: repositories,
+		Sources:      []sourceAdoption{},
+	}
+	for source, rules := range a {
+		adoption := sourceAdoption{Source: source}
+		for path, count := range rules {
+			adoption.Rules = append(adoption.Rules, rulePathAdoption{ID: path, Repositories: count})
+		}
+		sort.Slice(adoption.Rules, func(i, j int) bool {
+			if adoption.Rules[i].Repositories != adoption.Rules[j].Repositories {
+				return adoption.Rules[i].Repositories > adoption.Rules[j].Repositories
+			}
+			return adoption.Rules[i].ID < adoption.Rules[j].ID
+		})
+		export.Sources = append(export.Sources, adoption)
+	}
+	sort.Slice(export.Sources, func(i, j int) bool { return export.Sources[i].Source < export.Sources[j].Source })
+	return export
+}
+
+// stripCredentials removes user information, such as tokens, from a repository URL
+func stripCredentials(source string) string {
+	u, err := url.Parse(source)
+	if err != nil || u.User == nil || u.Host == "" {
+		return source
+	}
+	u.User = nil
+	return u.String()
+}
+
+// writeAdoptionExport writes export as JSON to target: POSTed to an http(s)
+// URL, or written to a file otherwise
+func writeAdoptionExport(ctx context.Context, fs afero.Fs, target string, export adoptionExport) error {
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return contextureerrors.Wrap(err, "marshal adoption export")
+	}
+
+	if !strings.HasPrefix(target, "https://") && !strings.HasPrefix(target, "http://") {
+		if err := afero.WriteFile(fs, target, append(data, '\n'), 0o644); err != nil {
+			return contextureerrors.Wrap(err, "write adoption export")
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, adoptionExportTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(data))
+	if err != nil {
+		return contextureerrors.Wrap(err, "create adoption export request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "contexture/"+version.GetShort())
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return contextureerrors.Wrap(err, "send adoption export")
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return contextureerrors.WithOpf("send adoption export", "%s responded with %s", stripCredentials(target), resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/contextureai/contexture/internal/approval"
+	"github.com/contextureai/contexture/internal/dependencies"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/git"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/contextureai/contexture/internal/ui"
+	"github.com/urfave/cli/v3"
+)
+
+// ApproveCommand implements the approve command
+type ApproveCommand struct {
+	projectManager *project.Manager
+	workingDir     dependencies.WorkingDir
+	env            dependencies.Environment
+	// identity returns the git user of a directory, the default approver
+	identity func(dir string) string
+	now      func() time.Time
+}
+
+// NewApproveCommand creates a new approve command
+func NewApproveCommand(deps *dependencies.Dependencies) *ApproveCommand {
+	return &ApproveCommand{
+		projectManager: project.NewManagerFromDependencies(deps),
+		workingDir:     deps.GetWorkingDir(),
+		env:            deps.GetEnv(),
+		identity:       git.UserIdentity,
+		now:            time.Now,
+	}
+}
+
+// Execute approves pending rules of the project, recording the approver and
+// the time in the configuration, so builds include them
+func (c *ApproveCommand) Execute(_ context.Context, cmd *cli.Command) error {
+	ruleIDs := cmd.Args().Slice()
+	if len(ruleIDs) == 0 {
+		return contextureerrors.ValidationErrorf("rule-id", "no rule IDs provided")
+	}
+
+	configLoad, err := LoadProjectConfig(c.projectManager, c.workingDir)
+	if err != nil {
+		return err
+	}
+	approver := c.approver(cmd.String("by"), configLoad.CurrentDir)
+	if approver == "" {
+		return contextureerrors.Validation("by", "no approver identity found").
+			WithSuggestions(
+				contextureerrors.Hint("set user.email in git config"),
+				contextureerrors.RunCommand("contexture approve <rule-id> --by <name>", "name the approver"))
+	}
+
+	mutedStyle := lipgloss.NewStyle().Foreground(ui.DefaultTheme().Muted)
+	approved := 0
+	for _, id := range ruleIDs {
+		ref := c.projectManager.FindRule(configLoad.Config, id)
+		if ref == nil || ref.Source == "local" {
+			return contextureerrors.Validation("rule-id", "rule not found in configuration: "+id).
+				WithSuggestions(contextureerrors.RunCommand("contexture rules list", "list the configured rules"))
+		}
+		if !approval.Pending(configLoad.Config.Approval, *ref) {
+			fmt.Printf("  %s\n", mutedStyle.Render("Not pending approval: "+id))
+			continue
+		}
+		approval.Approve(ref, approver, c.now())
+		// Approving a rule from an extended config overrides it locally
+		ref.Inherited = false
+		approved++
+		fmt.Printf("  ✓ %s\n", id)
+	}
+	if approved == 0 {
+		return nil
+	}
+
+	if err := configLoad.SaveConfig(c.projectManager); err != nil {
+		return contextureerrors.Wrap(err, "save config")
+	}
+	successStyle := lipgloss.NewStyle().Foreground(ui.DefaultTheme().Success)
+	fmt.Println(successStyle.Render(fmt.Sprintf("✓ Approved %d rule(s) as %s", approved, approver)))
+	fmt.Println(mutedStyle.Render("Run 'contexture build' to include them in the outputs"))
+	return nil
+}
+
+// approver returns the --by flag, else the git user of dir, else the user
+// name of the environment
+func (c *ApproveCommand) approver(by, dir string) string {
+	if by = strings.TrimSpace(by); by != "" {
+		return by
+	}
+	if identity := c.identity(dir); identity != "" {
+		return identity
+	}
+	return c.env.Getenv("USER")
+}
+
+// ApproveAction is the CLI action handler for the approve command
+func ApproveAction(ctx context.Context, cmd *cli.Command, deps *dependencies.Dependencies) error {
+	approveCmd := NewApproveCommand(deps)
+	return approveCmd.Execute(ctx, cmd)
+}
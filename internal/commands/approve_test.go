@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+const approveProject = `version: 1
+formats:
+  - type: claude
+    enabled: true
+approval:
+  required: true
+  trustedProviders:
+    - contexture
+rules:
+  - id: "@community/go/errors"
+    pending: true
+  - id: "[contexture:languages/go/testing]"
+`
+
+// runApprove runs the approve command with the given CLI arguments
+func runApprove(t *testing.T, approveCmd *ApproveCommand, args ...string) error {
+	t.Helper()
+
+	var execErr error
+	app := &cli.Command{
+		Name:  "approve",
+		Flags: []cli.Flag{&cli.StringFlag{Name: "by"}},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			execErr = approveCmd.Execute(ctx, cmd)
+			return nil
+		},
+	}
+	require.NoError(t, app.Run(context.Background(), append([]string{"approve"}, args...)))
+	return execErr
+}
+
+func TestApproveCommand_Execute(t *testing.T) {
+	deps := createTestDependencies().WithWorkingDir(dependencies.StaticWorkingDir("/project"))
+	require.NoError(t, afero.WriteFile(deps.FS, "/project/.contexture.yaml", []byte(approveProject), 0o644))
+
+	approveCmd := NewApproveCommand(deps)
+	approveCmd.identity = func(string) string { return "Ada <ada@acme.com>" }
+	approveCmd.now = func() time.Time { return time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC) }
+
+	require.Error(t, runApprove(t, approveCmd))
+	require.Error(t, runApprove(t, approveCmd, "go/missing"))
+
+	require.NoError(t, runApprove(t, approveCmd, "@community/go/errors"))
+	result, err := project.NewManager(deps.FS).LoadConfig("/project")
+	require.NoError(t, err)
+	require.Len(t, result.Config.Rules, 2)
+	assert.False(t, result.Config.Rules[0].Pending)
+	assert.Equal(t, &domain.RuleApproval{By: "Ada <ada@acme.com>", At: time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC)},
+		result.Config.Rules[0].Approval)
+	assert.True(t, result.Config.Approval.Required)
+
+	// Approving again keeps the first approval
+	require.NoError(t, runApprove(t, approveCmd, "@community/go/errors", "--by", "someone-else"))
+	result, err = project.NewManager(deps.FS).LoadConfig("/project")
+	require.NoError(t, err)
+	assert.Equal(t, "Ada <ada@acme.com>", result.Config.Rules[0].Approval.By)
+}
+
+func TestRuleGenerator_ApplicableRules_Pending(t *testing.T) {
+	t.Parallel()
+	generator := &RuleGenerator{}
+	applicable, skipped, err := generator.applicableRules(nil, []domain.RuleRef{
+		{ID: "@community/go/errors", Pending: true},
+		{ID: "[contexture:languages/go/testing]"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.RuleRef{{ID: "[contexture:languages/go/testing]"}}, applicable)
+	assert.Equal(t, []domain.RuleRef{{ID: "@community/go/errors", Pending: true}}, skipped)
+}
+
+func TestRuleGenerator_ApplicableRules_UnapprovedByHand(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	// Written by hand: the untrusted rule was never marked pending
+	require.NoError(t, afero.WriteFile(fs, "/project/.contexture.yaml", []byte(`version: 1
+formats:
+  - type: claude
+    enabled: true
+approval:
+  required: true
+  trustedProviders:
+    - contexture
+rules:
+  - id: "@community/go/errors"
+  - id: "[contexture:languages/go/testing]"
+`), 0o644))
+	result, err := project.NewManager(fs).LoadConfig("/project")
+	require.NoError(t, err)
+
+	generator := &RuleGenerator{}
+	applicable, skipped, err := generator.applicableRules(result.Config.Approval, result.Config.Rules)
+	require.NoError(t, err)
+	require.Len(t, applicable, 1)
+	assert.Equal(t, "[contexture:languages/go/testing]", applicable[0].ID)
+	require.Len(t, skipped, 1)
+	assert.Equal(t, "@community/go/errors", skipped[0].ID)
+	assert.True(t, skipped[0].Pending)
+}
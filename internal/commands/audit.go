@@ -0,0 +1,271 @@
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/contextureai/contexture/internal/cache"
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/output"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/contextureai/contexture/internal/provider"
+	"github.com/contextureai/contexture/internal/rule"
+	"github.com/spf13/afero"
+	"github.com/urfave/cli/v3"
+)
+
+// auditTarget is a repository listed for auditing
+type auditTarget struct {
+	// repository is the local path or Git URL as listed
+	repository string
+	// ref is the branch read from a Git URL
+	ref string
+}
+
+// AuditCommand implements the audit command
+type AuditCommand struct {
+	fs               afero.Fs
+	projectManager   *project.Manager
+	cache            *cache.SimpleCache
+	outdated         *OutdatedCommand
+	ruleFetcher      rule.Fetcher
+	providerRegistry *provider.Registry
+	workingDir       dependencies.WorkingDir
+	env              dependencies.Environment
+}
+
+// NewAuditCommand creates a new audit command
+func NewAuditCommand(deps *dependencies.Dependencies) *AuditCommand {
+	outdated := NewOutdatedCommand(deps)
+	return &AuditCommand{
+		fs:               deps.FS,
+		projectManager:   project.NewManagerFromDependencies(deps),
+		cache:            newProviderCache(deps.FS, deps.GetEnv(), newOpenRepository(deps.FS), deps.ProviderRegistry),
+		outdated:         outdated,
+		ruleFetcher:      outdated.ruleFetcher,
+		providerRegistry: deps.ProviderRegistry,
+		workingDir:       deps.GetWorkingDir(),
+		env:              deps.GetEnv(),
+	}
+}
+
+// Execute audits the contexture configurations of the repositories in the
+// list file and reports rule adoption, outdated rules, and baseline
+// violations across them. It never changes the repositories.
+func (c *AuditCommand) Execute(ctx context.Context, cmd *cli.Command) error {
+	outputManager, err := output.NewManager(output.Format(cmd.String("output")), c.workingDir, c.env)
+	if err != nil {
+		return contextureerrors.Wrap(err, "create output manager")
+	}
+
+	reposFile := cmd.String("repos")
+	if reposFile == "" {
+		return contextureerrors.Validation("repos", "a repository list is required").
+			WithSuggestions(contextureerrors.RunCommand(
+				"contexture audit --repos repos.txt",
+				"audit the repositories listed one per line"))
+	}
+
+	currentDir, err := c.workingDir.Getwd()
+	if err != nil {
+		return contextureerrors.Wrap(err, "get current directory")
+	}
+	if !filepath.IsAbs(reposFile) {
+		reposFile = filepath.Join(currentDir, reposFile)
+	}
+	targets, err := readAuditTargets(c.fs, reposFile, cmd.String("ref"))
+	if err != nil {
+		return err
+	}
+
+	// Baselines given on the command line are relative to the working directory
+	baseline := cmd.String("baseline")
+	if baseline != "" && !strings.HasPrefix(baseline, "@") && !filepath.IsAbs(baseline) {
+		baseline = filepath.Join(currentDir, baseline)
+	}
+
+	report := output.AuditReport{Baseline: cmd.String("baseline")}
+	adoption := map[string]int{}
+	bySource := adoptionCounts{}
+	for _, target := range targets {
+		result, ruleIDs := c.auditRepository(ctx, target, filepath.Dir(reposFile), baseline)
+		if result.Error != "" {
+			report.Failed++
+		}
+		report.Violations += len(result.Violations)
+		for _, id := range ruleIDs {
+			adoption[id]++
+			// Parse while the repository's providers are registered
+			if parsed, err := c.ruleFetcher.ParseRuleID(id); err == nil {
+				bySource.add(parsed.Source, parsed.RulePath)
+			}
+		}
+		report.Repositories = append(report.Repositories, result)
+	}
+
+	for id, repositories := range adoption {
+		report.Adoption = append(report.Adoption, output.RuleAdoption{ID: id, Repositories: repositories})
+	}
+	sort.Slice(report.Adoption, func(i, j int) bool {
+		if report.Adoption[i].Repositories != report.Adoption[j].Repositories {
+			return report.Adoption[i].Repositories > report.Adoption[j].Repositories
+		}
+		return report.Adoption[i].ID < report.Adoption[j].ID
+	})
+
+	if err := outputManager.WriteAuditReport(report); err != nil {
+		return contextureerrors.Wrap(err, "write audit report")
+	}
+
+	// Adoption counts leave the machine only when asked to
+	if target := cmd.String("export-adoption"); target != "" {
+		if !strings.Contains(target, "://") && !filepath.IsAbs(target) {
+			target = filepath.Join(currentDir, target)
+		}
+		export := bySource.export(len(targets)-report.Failed, time.Now())
+		if err := writeAdoptionExport(ctx, c.fs, target, export); err != nil {
+			return err
+		}
+		log.Info("Exported rule adoption counts", "to", stripCredentials(target))
+	}
+
+	if report.Failed > 0 {
+		return contextureerrors.Partial("audit repositories",
+			fmt.Errorf("%d of %d repositories could not be audited", report.Failed, len(targets)))
+	}
+	return nil
+}
+
+// auditRepository summarizes the configuration of one repository and returns
+// the IDs of its remote rules. Local paths are relative to listDir. Problems
+// are recorded in the result rather than returned, so one repository cannot
+// stop the audit.
+func (c *AuditCommand) auditRepository(
+	ctx context.Context,
+	target auditTarget,
+	listDir, baseline string,
+) (output.AuditRepository, []string) {
+	result := output.AuditRepository{Repository: target.repository}
+
+	repoDir := target.repository
+	switch {
+	case strings.HasPrefix(repoDir, "https://") || strings.HasPrefix(repoDir, "git@"):
+		var err error
+		repoDir, err = c.cache.GetRepositoryWithUpdate(ctx, target.repository, target.ref)
+		if err != nil {
+			result.Error = contextureerrors.Wrap(err, "fetch repository").Error()
+			return result, nil
+		}
+	case !filepath.IsAbs(repoDir):
+		repoDir = filepath.Join(listDir, repoDir)
+	}
+
+	configResult, err := c.projectManager.LoadConfigWithLocalRules(repoDir)
+	if err != nil {
+		result.Error = contextureerrors.Wrap(err, "load configuration").Error()
+		return result, nil
+	}
+	config := configResult.Config
+	if rel, err := filepath.Rel(repoDir, configResult.Path); err == nil {
+		result.ConfigPath = filepath.ToSlash(rel)
+	}
+	if err := c.providerRegistry.LoadFromProject(config); err != nil {
+		result.Error = contextureerrors.Wrap(err, "load providers").Error()
+		return result, nil
+	}
+
+	var ruleIDs []string
+	result.Rules = len(config.Rules)
+	for _, ruleRef := range config.Rules {
+		if ruleRef.Source == "local" {
+			result.LocalRules++
+			continue
+		}
+		ruleIDs = append(ruleIDs, ruleRef.ID)
+	}
+	result.Outdated = c.outdated.collectReport(ctx, config.Rules).Outdated
+
+	// Without a baseline for every repository, each is compared with its own
+	if baseline == "" && config.Sync != nil {
+		baseline = config.Sync.Baseline
+		result.Baseline = baseline
+	}
+	if baseline == "" {
+		return result, ruleIDs
+	}
+
+	baselineConfig, err := c.projectManager.LoadReferencedConfig(baseline, configResult)
+	if err != nil {
+		result.Error = contextureerrors.Wrap(err, "load baseline "+baseline).Error()
+		return result, ruleIDs
+	}
+	var deviations []domain.SyncDeviation
+	if config.Sync != nil {
+		deviations = config.Sync.Deviations
+	}
+	pending, _ := partitionSyncProposals(syncProposals(c.projectManager, config, baselineConfig), deviations)
+	for _, proposal := range pending {
+		// Rules beyond the baseline are allowed
+		if proposal.kind == domain.SyncDeviationExtra {
+			continue
+		}
+		result.Violations = append(result.Violations, output.AuditViolation{
+			Rule:   proposal.rule.ID,
+			Type:   string(proposal.kind),
+			Detail: proposal.detail,
+		})
+	}
+
+	return result, ruleIDs
+}
+
+// readAuditTargets reads a repository list: one local path or Git URL per
+// line, optionally followed by the branch to read, which defaults to ref.
+// Blank lines and lines starting with # are ignored.
+func readAuditTargets(fs afero.Fs, path, ref string) ([]auditTarget, error) {
+	data, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "read repository list")
+	}
+
+	var targets []auditTarget
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) > 2 {
+			return nil, contextureerrors.ValidationErrorf("repos",
+				"%s:%d: expected a repository and an optional branch, got %q", path, lineNumber, line)
+		}
+		target := auditTarget{repository: fields[0], ref: ref}
+		if len(fields) == 2 {
+			target.ref = fields[1]
+		}
+		targets = append(targets, target)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, contextureerrors.Wrap(err, "read repository list")
+	}
+	if len(targets) == 0 {
+		return nil, contextureerrors.ValidationErrorf("repos", "%s lists no repositories", path)
+	}
+	return targets, nil
+}
+
+// AuditAction is the CLI action handler for the audit command
+func AuditAction(ctx context.Context, cmd *cli.Command, deps *dependencies.Dependencies) error {
+	auditCmd := NewAuditCommand(deps)
+	return auditCmd.Execute(ctx, cmd)
+}
@@ -0,0 +1,119 @@
+package commands
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/cache"
+	"github.com/contextureai/contexture/internal/git"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const auditBaseline = `version: 1
+formats:
+  - type: claude
+    enabled: true
+rules:
+  - id: "[contexture:go/errors]"
+  - id: "[contexture:security/secrets]"
+`
+
+const auditAPIConfig = `version: 1
+formats:
+  - type: claude
+    enabled: true
+rules:
+  - id: "[contexture:go/errors]"
+    commitHash: old1
+  - id: "[contexture(local):team/api]"
+sync:
+  baseline: ../baseline.yaml
+`
+
+const auditWebConfig = `version: 1
+formats:
+  - type: claude
+    enabled: true
+rules:
+  - id: "[contexture:go/errors]"
+    commitHash: new1
+  - id: "[contexture:security/secrets]"
+    commitHash: new2
+`
+
+func TestReadAuditTargets(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/org/repos.txt", []byte(`# services
+services/api
+
+https://github.com/acme/web.git develop
+`), 0o644))
+
+	targets, err := readAuditTargets(fs, "/org/repos.txt", "main")
+	require.NoError(t, err)
+	assert.Equal(t, []auditTarget{
+		{repository: "services/api", ref: "main"},
+		{repository: "https://github.com/acme/web.git", ref: "develop"},
+	}, targets)
+
+	require.NoError(t, afero.WriteFile(fs, "/org/bad.txt", []byte("services/api main extra\n"), 0o644))
+	_, err = readAuditTargets(fs, "/org/bad.txt", "main")
+	require.ErrorContains(t, err, "bad.txt:1")
+
+	require.NoError(t, afero.WriteFile(fs, "/org/empty.txt", []byte("# nothing yet\n"), 0o644))
+	_, err = readAuditTargets(fs, "/org/empty.txt", "main")
+	require.Error(t, err)
+}
+
+func TestAuditCommand_AuditRepository(t *testing.T) {
+	deps := createTestDependencies()
+	fs := deps.FS
+	auditCmd := NewAuditCommand(deps)
+	mockRepo := git.NewMockRepository(t)
+	auditCmd.outdated.gitRepo = mockRepo
+	auditCmd.outdated.cache = cache.NewSimpleCache(fs, deps.GetEnv(), mockRepo)
+
+	require.NoError(t, afero.WriteFile(fs, "/org/baseline.yaml", []byte(auditBaseline), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "/org/api/.contexture.yaml", []byte(auditAPIConfig), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "/org/web/.contexture.yaml", []byte(auditWebConfig), 0o644))
+
+	rulesDir := filepath.Join(cache.BaseDir(deps.GetEnv()), "github.com_contextureai_rules-main")
+	require.NoError(t, fs.MkdirAll(filepath.Join(rulesDir, ".git"), 0o755))
+	mockRepo.EXPECT().Pull(mock.Anything, rulesDir, mock.Anything).Return(nil)
+	mockRepo.EXPECT().GetFileCommitInfo(rulesDir, "go/errors.md", "main").
+		Return(&git.CommitInfo{Hash: "new1", Date: "11 Mar 2025"}, nil)
+	mockRepo.EXPECT().GetFileCommitInfo(rulesDir, "security/secrets.md", "main").
+		Return(&git.CommitInfo{Hash: "new2", Date: "11 Mar 2025"}, nil)
+	mockRepo.EXPECT().GetCommitInfoByHash(rulesDir, "old1").
+		Return(&git.CommitInfo{Hash: "old1", Date: "1 Mar 2025"}, nil)
+	mockRepo.EXPECT().CountFileCommitsSince(rulesDir, "go/errors.md", "old1", "main").Return(2, nil)
+
+	// The API repository is compared with the baseline recorded by sync
+	api, ruleIDs := auditCmd.auditRepository(context.Background(), auditTarget{repository: "api"}, "/org", "")
+	assert.Empty(t, api.Error)
+	assert.Equal(t, ".contexture.yaml", api.ConfigPath)
+	assert.Equal(t, "../baseline.yaml", api.Baseline)
+	assert.Equal(t, 2, api.Rules)
+	assert.Equal(t, 1, api.LocalRules)
+	assert.Equal(t, 1, api.Outdated)
+	assert.Equal(t, []string{"[contexture:go/errors]"}, ruleIDs)
+	require.Len(t, api.Violations, 1)
+	assert.Equal(t, "[contexture:security/secrets]", api.Violations[0].Rule)
+	assert.Equal(t, "omitted", api.Violations[0].Type)
+
+	web, ruleIDs := auditCmd.auditRepository(context.Background(), auditTarget{repository: "/org/web"}, "/org", "/org/baseline.yaml")
+	assert.Empty(t, web.Error)
+	assert.Empty(t, web.Baseline, "a baseline given for every repository is reported once")
+	assert.Zero(t, web.Outdated)
+	assert.Empty(t, web.Violations)
+	assert.Len(t, ruleIDs, 2)
+
+	missing, ruleIDs := auditCmd.auditRepository(context.Background(), auditTarget{repository: "gone"}, "/org", "")
+	assert.Contains(t, missing.Error, "load configuration")
+	assert.Empty(t, ruleIDs)
+}
@@ -0,0 +1,80 @@
+// Package commands provides CLI command implementations
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/contextureai/contexture/internal/benchmark"
+	"github.com/contextureai/contexture/internal/dependencies"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/ui"
+	"github.com/urfave/cli/v3"
+)
+
+// BenchCommand implements the hidden bench command
+type BenchCommand struct{}
+
+// NewBenchCommand creates a new bench command
+func NewBenchCommand(_ *dependencies.Dependencies) *BenchCommand {
+	return &BenchCommand{}
+}
+
+// Execute runs the generation pipeline benchmarks and prints stage timings
+func (c *BenchCommand) Execute(ctx context.Context, cmd *cli.Command) error {
+	ruleCount := int(cmd.Int("rules"))
+	iterations := int(cmd.Int("iterations"))
+
+	var fixture *benchmark.Fixture
+	err := ui.WithProgress(fmt.Sprintf("Prepared %d rules", ruleCount), func() error {
+		var fixtureErr error
+		fixture, fixtureErr = benchmark.NewFixture(ruleCount)
+		return fixtureErr
+	})
+	if err != nil {
+		return contextureerrors.Wrap(err, "create benchmark fixture")
+	}
+
+	var results []benchmark.Result
+	err = ui.WithProgress("Benchmarked generation pipeline", func() error {
+		var runErr error
+		results, runErr = benchmark.Run(ctx, fixture, iterations)
+		return runErr
+	})
+	if err != nil {
+		return contextureerrors.Wrap(err, "run benchmarks")
+	}
+
+	c.displayResults(results)
+	return nil
+}
+
+// displayResults prints a table of stage timings
+func (c *BenchCommand) displayResults(results []benchmark.Result) {
+	theme := ui.DefaultTheme()
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(theme.Primary)
+	mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+
+	fmt.Println()
+	fmt.Printf("%s\n", headerStyle.Render(fmt.Sprintf("%-32s %14s %14s", "Stage", "Per run", "Per rule")))
+
+	var total time.Duration
+	for _, result := range results {
+		fmt.Printf("%-32s %14s %14s\n", result.Stage, result.PerOp().Round(time.Microsecond), result.PerRule())
+		total += result.PerOp()
+	}
+
+	fmt.Println()
+	if len(results) > 0 {
+		fmt.Println(mutedStyle.Render(fmt.Sprintf("Total per run: %s (%d rules, %d iterations)",
+			total.Round(time.Microsecond), results[0].Rules, results[0].Iterations)))
+	}
+}
+
+// BenchAction is the CLI action handler for the bench command
+func BenchAction(ctx context.Context, cmd *cli.Command, deps *dependencies.Dependencies) error {
+	benchCmd := NewBenchCommand(deps)
+	return benchCmd.Execute(ctx, cmd)
+}
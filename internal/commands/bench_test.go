@@ -0,0 +1,45 @@
+// Package commands provides CLI command implementations
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+func TestNewBenchCommand(t *testing.T) {
+	cmd := NewBenchCommand(createTestDependencies())
+	assert.NotNil(t, cmd)
+}
+
+// runBench runs the bench command with the given CLI arguments
+func runBench(t *testing.T, args ...string) error {
+	t.Helper()
+
+	benchCmd := NewBenchCommand(createTestDependencies())
+	app := &cli.Command{
+		Name: "bench",
+		Flags: []cli.Flag{
+			&cli.IntFlag{Name: "rules", Value: 500},
+			&cli.IntFlag{Name: "iterations", Value: 5},
+		},
+		Action: benchCmd.Execute,
+		// Prevent the CLI from exiting the test process on error
+		ExitErrHandler: func(context.Context, *cli.Command, error) {},
+	}
+
+	return app.Run(context.Background(), append([]string{"bench"}, args...))
+}
+
+func TestBenchCommand_Execute(t *testing.T) {
+	require.NoError(t, runBench(t, "--rules", "5", "--iterations", "1"))
+}
+
+func TestBenchCommand_Execute_InvalidRules(t *testing.T) {
+	err := runBench(t, "--rules", "0")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rule count must be positive")
+}
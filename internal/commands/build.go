@@ -4,18 +4,27 @@ package commands
 import (
 	"context"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/log"
+	"github.com/contextureai/contexture/internal/approval"
+	"github.com/contextureai/contexture/internal/budget"
+	"github.com/contextureai/contexture/internal/cache"
 	"github.com/contextureai/contexture/internal/dependencies"
 	"github.com/contextureai/contexture/internal/domain"
 	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/experiment"
 	"github.com/contextureai/contexture/internal/format"
+	"github.com/contextureai/contexture/internal/permissions"
 	"github.com/contextureai/contexture/internal/project"
 	"github.com/contextureai/contexture/internal/rule"
+	"github.com/contextureai/contexture/internal/sensitive"
+	"github.com/contextureai/contexture/internal/tui"
 	"github.com/contextureai/contexture/internal/ui"
 	"github.com/spf13/afero"
 	"github.com/urfave/cli/v3"
@@ -27,38 +36,59 @@ type BuildCommand struct {
 	ruleGenerator  *RuleGenerator
 	registry       *format.Registry
 	fs             afero.Fs
+	workingDir     dependencies.WorkingDir
+	env            dependencies.Environment
 }
 
 // NewBuildCommand creates a new build command
 func NewBuildCommand(deps *dependencies.Dependencies) *BuildCommand {
 	registry := format.GetDefaultRegistry(deps.FS)
+	// Rules vendored with 'contexture vendor' are resolved from the project
+	// directory instead of their repositories
+	projectDir, _ := deps.GetWorkingDir().Getwd()
+	fetcher := rule.NewFetcher(deps.FS, newOpenRepository(deps.FS), fetcherConfig(deps), deps.ProviderRegistry)
 	return &BuildCommand{
-		projectManager: project.NewManager(deps.FS),
+		projectManager: project.NewManagerFromDependencies(deps),
 		ruleGenerator: NewRuleGenerator(
-			rule.NewFetcher(deps.FS, newOpenRepository(deps.FS), rule.FetcherConfig{}, deps.ProviderRegistry),
+			rule.NewVendoredFetcher(deps.FS, fetcher, projectDir),
 			rule.NewValidator(),
 			rule.NewProcessor(),
 			registry,
 			deps.FS,
-		),
-		registry: registry,
-		fs:       deps.FS,
+		).withEnvironment(deps.GetWorkingDir(), deps.GetEnv()),
+		registry:   registry,
+		fs:         deps.FS,
+		workingDir: deps.GetWorkingDir(),
+		env:        deps.GetEnv(),
 	}
 }
 
 // Execute runs the build command
 func (c *BuildCommand) Execute(ctx context.Context, cmd *cli.Command) error {
+	c.ruleGenerator.quarantine = cmd.Bool("quarantine")
+	if cmd.Bool("global") {
+		return c.buildGlobal(ctx, cmd)
+	}
+	if err := c.useRemoteConfig(ctx, cmd.String("config-url"), cmd.String("config-sha256")); err != nil {
+		return err
+	}
+
 	// Get current directory
-	currentDir, err := os.Getwd()
+	currentDir, err := c.workingDir.Getwd()
 	if err != nil {
 		return contextureerrors.Wrap(err, "get current directory")
 	}
 
+	// The project facts and git conventions rules are local rules, so they
+	// are refreshed before local rules are discovered
+	refreshEnvRule(c.fs, c.projectManager, currentDir)
+	refreshGitRule(c.fs, c.projectManager, currentDir)
+
 	// Load merged configuration (global + project + local rules)
 	merged, err := c.projectManager.LoadConfigMergedWithLocalRules(currentDir)
 	if err != nil {
 		return contextureerrors.Wrap(err, "load configuration").
-			WithSuggestions("Run 'contexture init' to create a project configuration")
+			WithSuggestions(contextureerrors.RunCommand("contexture init", "create a project configuration"))
 	}
 
 	// Separate user rules from project rules
@@ -71,6 +101,35 @@ func (c *BuildCommand) Execute(ctx context.Context, cmd *cli.Command) error {
 		}
 	}
 
+	// Drop rules whose when expression does not hold for this build
+	var approvalConfig *domain.ApprovalConfig
+	if merged.Project != nil {
+		approvalConfig = merged.Project.Approval
+	}
+	projectRules, skippedProject, err := c.ruleGenerator.applicableRules(approvalConfig, projectRules)
+	if err != nil {
+		return err
+	}
+	userRules, skippedUser, err := c.ruleGenerator.applicableRules(nil, userRules)
+	if err != nil {
+		return err
+	}
+	skippedRules := append(skippedProject, skippedUser...)
+	printPendingRules(skippedRules)
+
+	// Leave out the rules of experiment variants not selected for this build
+	experiments := merged.Project.Experiments
+	activeVariants, err := experiment.Resolve(experiments, append(
+		experiment.ParseEnv(c.ruleGenerator.getenv(experiment.Env)),
+		cmd.StringSlice("experiment")...,
+	))
+	if err != nil {
+		return err
+	}
+	projectRules, inactiveProject := experiment.Filter(projectRules, activeVariants)
+	userRules, inactiveUser := experiment.Filter(userRules, activeVariants)
+	inactiveRules := append(inactiveProject, inactiveUser...)
+
 	// Create project config for generation
 	config := &domain.Project{}
 	*config = *merged.Project
@@ -125,7 +184,11 @@ func (c *BuildCommand) Execute(ctx context.Context, cmd *cli.Command) error {
 			}
 			fmt.Println()
 
-			// Skip prompt if --force flag is set or if running in non-interactive mode
+			// Skip prompt if --force flag is set; non-interactive runs take its default, which is to abort
+			if !cmd.Bool("force") && tui.IsNonInteractive() {
+				fmt.Println("Aborted. No files were deleted. Use --force to delete them without a prompt.")
+				return nil
+			}
 			if !cmd.Bool("force") {
 				fmt.Print("Do you want to continue? (y/N): ")
 				var response string
@@ -163,6 +226,7 @@ func (c *BuildCommand) Execute(ctx context.Context, cmd *cli.Command) error {
 		Bold(true).
 		Foreground(lipgloss.AdaptiveColor{Light: "#F793FF", Dark: "#AD58B4"})
 	fmt.Printf("%s\n\n", headerStyle.Render("Build Rules"))
+	printActiveVariants(experiments, activeVariants)
 
 	// Get target formats (either user-specified or all enabled)
 	targetFormats := c.getTargetFormats(config, cmd.StringSlice("formats"))
@@ -170,6 +234,16 @@ func (c *BuildCommand) Execute(ctx context.Context, cmd *cli.Command) error {
 		return contextureerrors.ValidationErrorf("formats", "no target formats available")
 	}
 
+	// Resolve the model profile used for context size reports
+	model := cmd.String("model")
+	if model == "" {
+		model = config.GetGeneration().Model
+	}
+	profile, err := budget.Lookup(model)
+	if err != nil {
+		return err
+	}
+
 	log.Debug("Starting build",
 		"project_rules", len(projectRules),
 		"user_rules", len(userRules),
@@ -187,22 +261,272 @@ func (c *BuildCommand) Execute(ctx context.Context, cmd *cli.Command) error {
 			}
 		}
 		fmt.Printf("%s\n", strings.Join(formatNames, ", "))
+
+		mutedStyle := lipgloss.NewStyle().Foreground(ui.DefaultTheme().Muted)
+		for _, ref := range skippedRules {
+			if !ref.Pending {
+				fmt.Println(mutedStyle.Render(fmt.Sprintf("Skipped %s (when: %s)", ref.ID, ref.When)))
+			}
+		}
+		for _, ref := range inactiveRules {
+			fmt.Println(mutedStyle.Render(fmt.Sprintf("Skipped %s (experiment: %s)", ref.ID, ref.Experiment)))
+		}
+	}
+
+	// Fail before writing anything when an output cannot be written
+	if err := permissions.Check(c.fs, c.writeTargets(targetFormats, merged.GlobalConfig, len(userRules) > 0)); err != nil {
+		return err
+	}
+
+	// Outputs with sensitive rules must stay out of version control. The
+	// bundle holds the outputs of every enabled format, whichever are built.
+	var sensitiveOutputs []string
+	if config.Sensitive != nil {
+		sensitiveOutputs = c.projectOutputs(c.getTargetFormats(config, nil), currentDir)
+		if config.Sensitive.RequireIgnored {
+			if err := sensitive.CheckIgnored(c.fs, currentDir, sensitiveOutputs); err != nil {
+				return err
+			}
+		}
 	}
 
 	// Clean up orphaned rules before generation
 	c.cleanupOrphanedRules(ctx, targetFormats, projectRules, userRules)
 
 	// Generate rules per format based on user rules mode
-	err = c.generateWithUserRulesHandling(ctx, config, targetFormats, projectRules, userRules)
+	err = c.generateWithUserRulesHandling(ctx, config, merged.GlobalConfig, targetFormats, projectRules, userRules)
 	if err != nil {
 		return contextureerrors.Wrap(err, "generate rules")
 	}
 
+	c.reportContextSize(targetFormats, profile, model != "" || cmd.Bool("verbose"))
+
+	if config.Sensitive != nil && config.Sensitive.EncryptCommand != "" {
+		bundler := sensitive.NewBundler(c.fs, currentDir, *config.Sensitive)
+		files, err := bundler.Write(ctx, sensitiveOutputs)
+		if err != nil {
+			return err
+		}
+		successStyle := lipgloss.NewStyle().Foreground(ui.DefaultTheme().Success)
+		bundle, _ := filepath.Rel(currentDir, bundler.Path())
+		fmt.Println(successStyle.Render(fmt.Sprintf("✓ Encrypted %d output files into %s", files, bundle)))
+	}
+
+	if len(experiments) > 0 {
+		manifest := experiment.NewManifest(experiments, activeVariants, slices.Concat(projectRules, userRules))
+		if path, err := experiment.WriteManifest(c.fs, currentDir, manifest); err != nil {
+			log.Warn("Failed to write the build manifest", "path", path, "error", err)
+		}
+	}
+
 	log.Debug("Build completed successfully")
 
 	return nil
 }
 
+// useRemoteConfig makes the build read the configuration downloaded from
+// configURL instead of searching the project directory for one. Without a
+// checksum, a configuration setting commands contexture runs is rejected.
+func (c *BuildCommand) useRemoteConfig(ctx context.Context, configURL, checksum string) error {
+	if configURL == "" {
+		if checksum != "" {
+			return contextureerrors.ValidationErrorf("config-sha256", "--config-sha256 requires --config-url")
+		}
+		return nil
+	}
+	if c.projectManager.ConfigFile() != "" {
+		return contextureerrors.ValidationErrorf("config-url", "--config-url cannot be combined with --config")
+	}
+
+	path, data, err := cache.NewRemoteConfig(c.fs, c.env).Fetch(ctx, configURL, checksum)
+	if err != nil {
+		return err
+	}
+	log.Debug("Using remote configuration", "url", configURL, "path", path)
+	// The build loads the content that was verified rather than the cached
+	// file, which could have changed since
+	c.projectManager.SetConfigData(path, data)
+	if checksum != "" {
+		return nil
+	}
+
+	// An unpinned configuration can change between builds without review, so
+	// it may not make the build run programs
+	result, err := c.projectManager.LoadConfig(filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+	if settings := result.Config.CommandSettings(); len(settings) > 0 {
+		c.projectManager.SetConfigFile("")
+		return contextureerrors.Validation("config-sha256",
+			"the remote configuration sets "+strings.Join(settings, ", ")+", which run commands, so it must be pinned").
+			WithSuggestions(contextureerrors.Hint("review the configuration and pass its SHA-256 checksum with --config-sha256"))
+	}
+	return nil
+}
+
+// printActiveVariants prints the variant each experiment is built with
+func printActiveVariants(experiments map[string]domain.Experiment, active map[string]string) {
+	if len(experiments) == 0 {
+		return
+	}
+	mutedStyle := lipgloss.NewStyle().Foreground(ui.DefaultTheme().Muted)
+	for _, name := range slices.Sorted(maps.Keys(experiments)) {
+		variant := active[name]
+		if variant == "" {
+			variant = "none"
+		}
+		fmt.Println(mutedStyle.Render(fmt.Sprintf("Experiment %s: %s", name, variant)))
+	}
+	fmt.Println()
+}
+
+// printPendingRules warns about the skipped rules that are pending approval
+func printPendingRules(skipped []domain.RuleRef) {
+	_, pending := approval.Filter(nil, skipped)
+	if len(pending) == 0 {
+		return
+	}
+	ids := make([]string, len(pending))
+	for i, ref := range pending {
+		ids[i] = ref.ID
+	}
+	theme := ui.DefaultTheme()
+	warningStyle := lipgloss.NewStyle().Foreground(theme.Warning)
+	mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+	fmt.Fprintln(os.Stderr, warningStyle.Render(fmt.Sprintf("⚠ Skipped %d rule(s) pending approval: %s", len(pending), strings.Join(ids, ", "))))
+	fmt.Fprintln(os.Stderr, mutedStyle.Render("  Approve them with 'contexture approve <rule-id>'"))
+}
+
+// buildGlobal regenerates the native user-level outputs, such as
+// ~/.claude/CLAUDE.md, from the global configuration alone. It does not need a
+// project and never writes project outputs.
+func (c *BuildCommand) buildGlobal(ctx context.Context, cmd *cli.Command) error {
+	globalResult, err := c.projectManager.LoadGlobalConfigWithLocalRules()
+	if err != nil {
+		return contextureerrors.Wrap(err, "load global configuration")
+	}
+	if globalResult.Config == nil || len(globalResult.Config.Rules) == 0 {
+		fmt.Fprintln(os.Stderr, "No global rules configured")
+		return nil
+	}
+
+	userRules, skippedRules, err := c.ruleGenerator.applicableRules(globalResult.Config.Approval, globalResult.Config.Rules)
+	if err != nil {
+		return err
+	}
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.AdaptiveColor{Light: "#F793FF", Dark: "#AD58B4"})
+	fmt.Printf("%s\n\n", headerStyle.Render("Build Global Rules"))
+
+	// Build the global configuration's formats, or Claude when it defines none
+	config := &domain.Project{}
+	*config = *globalResult.Config
+	if len(config.Formats) == 0 {
+		config.Formats = []domain.FormatConfig{{Type: domain.FormatClaude, Enabled: true}}
+	}
+	targetFormats := c.getTargetFormats(config, cmd.StringSlice("formats"))
+
+	theme := ui.DefaultTheme()
+	mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+	var userFormats []domain.FormatConfig
+	for _, formatConfig := range targetFormats {
+		caps, _ := c.registry.GetCapabilities(formatConfig.Type)
+		if userRulesPath(formatConfig, caps) == "" {
+			displayName := string(formatConfig.Type)
+			if handler, exists := c.registry.GetHandler(formatConfig.Type); exists {
+				displayName = handler.GetDisplayName()
+			}
+			fmt.Printf("  %s %s does not support global rules\n", mutedStyle.Render("⚠"), displayName)
+			continue
+		}
+
+		userFormats = append(userFormats, userRulesFormatConfig(formatConfig, caps, globalResult.Config))
+	}
+	if len(userFormats) == 0 {
+		return contextureerrors.Validation("formats", "no target formats support global rules").
+			WithSuggestions(contextureerrors.Hint("global rules can be built for claude and windsurf, and for cursor with userRulesMode: file"))
+	}
+
+	if cmd.Bool("verbose") {
+		for _, ref := range skippedRules {
+			fmt.Println(mutedStyle.Render(fmt.Sprintf("Skipped %s (when: %s)", ref.ID, ref.When)))
+		}
+	}
+
+	var targets []permissions.Target
+	for _, formatConfig := range userFormats {
+		targets = append(targets, permissions.Target{Path: formatConfig.UserRulesFile, Description: string(formatConfig.Type) + " user rules"})
+	}
+	if err := permissions.Check(c.fs, targets); err != nil {
+		return err
+	}
+
+	log.Debug("Starting global build", "user_rules", len(userRules), "formats", len(userFormats))
+
+	config.Rules = userRules
+	if err := c.ruleGenerator.GenerateRulesWithScope(ctx, config, userFormats, "global"); err != nil {
+		return contextureerrors.Wrap(err, "generate global rules")
+	}
+
+	log.Debug("Global build completed successfully")
+	return nil
+}
+
+// writeTargets returns the outputs a build of targetFormats writes, with
+// the user rules files of the formats that write user rules outside the
+// project when there are user rules
+func (c *BuildCommand) writeTargets(
+	targetFormats []domain.FormatConfig,
+	globalConfig *domain.Project,
+	hasUserRules bool,
+) []permissions.Target {
+	var targets []permissions.Target
+	for _, formatConfig := range targetFormats {
+		name := string(formatConfig.Type)
+		if format, err := c.registry.CreateFormat(formatConfig.Type, c.fs, nil); err == nil {
+			targets = append(targets, permissions.Target{Path: format.GetOutputPath(&formatConfig), Description: name + " output"})
+		}
+		if !hasUserRules {
+			continue
+		}
+		switch formatConfig.GetEffectiveUserRulesMode() {
+		case domain.UserRulesNative, domain.UserRulesFile:
+			caps, _ := c.registry.GetCapabilities(formatConfig.Type)
+			if userRulesPath(formatConfig, caps) != "" {
+				userFormat := userRulesFormatConfig(formatConfig, caps, globalConfig)
+				targets = append(targets, permissions.Target{Path: userFormat.UserRulesFile, Description: name + " user rules"})
+			}
+		case domain.UserRulesProject, domain.UserRulesDisabled:
+		}
+	}
+	return targets
+}
+
+// projectOutputs returns the outputs of formats inside projectDir, relative
+// to it
+func (c *BuildCommand) projectOutputs(formats []domain.FormatConfig, projectDir string) []string {
+	var outputs []string
+	for _, formatConfig := range formats {
+		format, err := c.registry.CreateFormat(formatConfig.Type, c.fs, nil)
+		if err != nil {
+			continue
+		}
+		output := format.GetOutputPath(&formatConfig)
+		if filepath.IsAbs(output) {
+			rel, err := filepath.Rel(projectDir, output)
+			if err != nil || !filepath.IsLocal(rel) {
+				continue
+			}
+			output = rel
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs
+}
+
 // getTargetFormats determines which formats to generate based on user input and configuration
 func (c *BuildCommand) getTargetFormats(
 	config *domain.Project,
@@ -225,6 +549,10 @@ func (c *BuildCommand) getTargetFormats(
 			requestedTypes = append(requestedTypes, domain.FormatCursor)
 		case "windsurf":
 			requestedTypes = append(requestedTypes, domain.FormatWindsurf)
+		case "copilot":
+			requestedTypes = append(requestedTypes, domain.FormatCopilot)
+		case "gemini":
+			requestedTypes = append(requestedTypes, domain.FormatGemini)
 		default:
 			log.Warn("Unknown format requested", "format", formatStr)
 		}
@@ -247,7 +575,7 @@ func (c *BuildCommand) getTargetFormats(
 // generateWithUserRulesHandling generates rules for each format based on user rules mode
 func (c *BuildCommand) generateWithUserRulesHandling(
 	ctx context.Context,
-	baseConfig *domain.Project,
+	baseConfig, globalConfig *domain.Project,
 	targetFormats []domain.FormatConfig,
 	projectRules, userRules []domain.RuleRef,
 ) error {
@@ -263,21 +591,17 @@ func (c *BuildCommand) generateWithUserRulesHandling(
 
 		// Determine which rules to use based on mode
 		switch mode {
-		case domain.UserRulesNative:
-			// For native mode, generate project rules to project location
+		case domain.UserRulesNative, domain.UserRulesFile:
+			// For native and file modes, generate project rules to project location
 			if len(projectRules) > 0 {
 				projectFormats = append(projectFormats, formatConfig)
 			}
 
-			// Generate user rules to native location if supported
+			// Generate user rules to the native location or user rules file if supported
 			if len(userRules) > 0 {
-				if caps.SupportsUserRules {
+				if userRulesPath(formatConfig, caps) != "" {
 					// Create format config for user rules
-					userFormatConfig := formatConfig
-					userDir := filepath.Dir(caps.UserRulesPath)
-					userFormatConfig.BaseDir = userDir
-					userFormatConfig.IsUserRules = true
-					userFormats = append(userFormats, userFormatConfig)
+					userFormats = append(userFormats, userRulesFormatConfig(formatConfig, caps, globalConfig))
 				} else {
 					// Warn that this format doesn't support global rules
 					handler, _ := c.registry.GetHandler(formatConfig.Type)
@@ -334,6 +658,65 @@ func (c *BuildCommand) generateWithUserRulesHandling(
 	return nil
 }
 
+// reportContextSize shows each format's output size as a share of the
+// profile's context window. Unless all is set, only outputs over budget are shown.
+func (c *BuildCommand) reportContextSize(targetFormats []domain.FormatConfig, profile budget.Profile, all bool) {
+	theme := ui.DefaultTheme()
+	mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+	warningStyle := lipgloss.NewStyle().Foreground(theme.Warning)
+
+	headerShown := false
+	for _, formatConfig := range targetFormats {
+		usage, ok := c.measureOutput(formatConfig, profile)
+		if !ok || (!all && !usage.OverBudget()) {
+			continue
+		}
+
+		if !headerShown {
+			fmt.Printf("\n%s\n", mutedStyle.Render(fmt.Sprintf("Context size (%s, %d tokens)", profile.Name, profile.ContextWindow)))
+			headerShown = true
+		}
+		line := fmt.Sprintf("  %s ~%d tokens, %.1f%% of window", usage.Name, usage.Tokens, usage.Fraction*100)
+		if usage.OverBudget() {
+			fmt.Println(warningStyle.Render(fmt.Sprintf("%s ⚠ over %.0f%%, leaving less room for working context",
+				line, budget.WarnFraction*100)))
+		} else {
+			fmt.Println(mutedStyle.Render(line))
+		}
+	}
+}
+
+// measureOutput estimates the context use of everything a format generated
+func (c *BuildCommand) measureOutput(formatConfig domain.FormatConfig, profile budget.Profile) (budget.Usage, bool) {
+	formatImpl, err := c.registry.CreateFormat(formatConfig.Type, c.fs, nil)
+	if err != nil {
+		return budget.Usage{}, false
+	}
+	outputPath := formatImpl.GetOutputPath(&formatConfig)
+	if outputPath == "" {
+		return budget.Usage{}, false
+	}
+
+	var content strings.Builder
+	err = afero.Walk(c.fs, outputPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		data, err := afero.ReadFile(c.fs, path)
+		if err != nil {
+			return err
+		}
+		content.Write(data)
+		return nil
+	})
+	if err != nil {
+		log.Debug("Failed to measure output", "format", formatConfig.Type, "error", err)
+		return budget.Usage{}, false
+	}
+
+	return profile.Measure(outputPath, content.String()), true
+}
+
 // cleanupOrphanedRules removes rule files that exist in outputs but not in config
 func (c *BuildCommand) cleanupOrphanedRules(
 	_ context.Context,
@@ -378,7 +761,7 @@ func (c *BuildCommand) cleanupOrphanedRules(
 
 		// Also handle UserRulesMode changes for formats that merge global rules
 		mode := formatConfig.GetEffectiveUserRulesMode()
-		if mode == domain.UserRulesDisabled {
+		if mode == domain.UserRulesDisabled || mode == domain.UserRulesFile {
 			// Remove any global rules that might be in project files
 			for _, userRule := range userRules {
 				// Try to remove - ignore errors as rule might not exist
@@ -3,9 +3,20 @@ package commands
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/contextureai/contexture/internal/budget"
+	"github.com/contextureai/contexture/internal/cache"
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/contextureai/contexture/internal/permissions"
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/urfave/cli/v3"
 )
 
@@ -47,3 +58,184 @@ func TestBuildCommand_SpecificBehavior(t *testing.T) {
 	cmd := NewBuildCommand(deps)
 	assert.NotNil(t, cmd.ruleGenerator, "BuildCommand should have ruleGenerator")
 }
+
+func TestBuildCommand_MeasureOutput(t *testing.T) {
+	deps := createTestDependencies()
+	cmd := NewBuildCommand(deps)
+	formatConfig := domain.FormatConfig{Type: domain.FormatClaude, Enabled: true}
+
+	_, ok := cmd.measureOutput(formatConfig, budget.Profile{Name: "small", ContextWindow: 100})
+	assert.False(t, ok, "nothing generated yet")
+
+	require.NoError(t, afero.WriteFile(deps.FS, domain.ClaudeOutputFile, []byte(strings.Repeat("a", 80)), 0o644))
+	usage, ok := cmd.measureOutput(formatConfig, budget.Profile{Name: "small", ContextWindow: 100})
+	require.True(t, ok)
+	assert.Equal(t, 20, usage.Tokens)
+	assert.True(t, usage.OverBudget())
+}
+
+// runBuildCommand runs the build command with the given arguments and returns its error
+func runBuildCommand(t *testing.T, buildCmd *BuildCommand, args ...string) error {
+	t.Helper()
+
+	var execErr error
+	app := &cli.Command{
+		Name: "build",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "global"},
+			&cli.BoolFlag{Name: "verbose"},
+			&cli.StringSliceFlag{Name: "formats"},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			execErr = buildCmd.Execute(ctx, cmd)
+			return nil
+		},
+	}
+
+	require.NoError(t, app.Run(context.Background(), append([]string{"build"}, args...)))
+	return execErr
+}
+
+func TestBuildCommand_Global(t *testing.T) {
+	t.Run("without global rules", func(t *testing.T) {
+		deps := createTestDependencies()
+		t.Setenv("HOME", t.TempDir())
+
+		require.NoError(t, runBuildCommand(t, NewBuildCommand(deps), "--global"))
+	})
+
+	t.Run("writes user-level output", func(t *testing.T) {
+		deps := createTestDependencies()
+		rulePath := setupGlobalRule(t, deps.FS, validEditedRule)
+		globalDir := filepath.Dir(filepath.Dir(filepath.Dir(rulePath)))
+		home := filepath.Dir(filepath.Dir(globalDir))
+		require.NoError(t, afero.WriteFile(deps.FS,
+			filepath.Join(globalDir, domain.GetConfigFileName()),
+			[]byte("version: 1\nformats:\n  - type: claude\n    enabled: true\n"), 0o644))
+
+		require.NoError(t, runBuildCommand(t, NewBuildCommand(deps), "--global"))
+
+		content, err := afero.ReadFile(deps.FS, filepath.Join(home, ".claude", "CLAUDE.md"))
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "Use tabs for indentation.")
+		exists, err := afero.Exists(deps.FS, domain.ClaudeOutputFile)
+		require.NoError(t, err)
+		assert.False(t, exists, "project output should not be written")
+	})
+
+	t.Run("writes to overridden user rules path", func(t *testing.T) {
+		deps := createTestDependencies()
+		rulePath := setupGlobalRule(t, deps.FS, validEditedRule)
+		globalDir := filepath.Dir(filepath.Dir(filepath.Dir(rulePath)))
+		home := filepath.Dir(filepath.Dir(globalDir))
+		require.NoError(t, afero.WriteFile(deps.FS,
+			filepath.Join(globalDir, domain.GetConfigFileName()),
+			[]byte("version: 1\nuserRulesPaths:\n  claude: ~/dotfiles/CLAUDE.md\n"), 0o644))
+
+		require.NoError(t, runBuildCommand(t, NewBuildCommand(deps), "--global"))
+
+		content, err := afero.ReadFile(deps.FS, filepath.Join(home, "dotfiles", "CLAUDE.md"))
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "Use tabs for indentation.")
+		exists, err := afero.Exists(deps.FS, filepath.Join(home, ".claude", "CLAUDE.md"))
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("checks permissions before writing", func(t *testing.T) {
+		deps := createTestDependencies()
+		rulePath := setupGlobalRule(t, deps.FS, validEditedRule)
+		globalDir := filepath.Dir(filepath.Dir(filepath.Dir(rulePath)))
+		require.NoError(t, afero.WriteFile(deps.FS,
+			filepath.Join(globalDir, domain.GetConfigFileName()),
+			[]byte("version: 1\nformats:\n  - type: claude\n    enabled: true\n"), 0o644))
+
+		err := runBuildCommand(t, NewBuildCommand(deps.WithFS(afero.NewReadOnlyFs(deps.FS))), "--global")
+		require.Error(t, err)
+		require.ErrorIs(t, err, os.ErrPermission)
+		assert.Contains(t, err.Error(), "claude user rules")
+	})
+
+	t.Run("read-only mode writes nothing", func(t *testing.T) {
+		deps := createTestDependencies()
+		rulePath := setupGlobalRule(t, deps.FS, validEditedRule)
+		globalDir := filepath.Dir(filepath.Dir(filepath.Dir(rulePath)))
+		home := filepath.Dir(filepath.Dir(globalDir))
+		require.NoError(t, afero.WriteFile(deps.FS,
+			filepath.Join(globalDir, domain.GetConfigFileName()),
+			[]byte("version: 1\nformats:\n  - type: claude\n    enabled: true\n"), 0o644))
+
+		var skipped []permissions.Write
+		readOnly := permissions.NewReadOnlyFs(deps.FS, nil, func(write permissions.Write) { skipped = append(skipped, write) })
+		require.NoError(t, runBuildCommand(t, NewBuildCommand(deps.WithFS(readOnly)), "--global"))
+
+		userRules := filepath.Join(home, ".claude", "CLAUDE.md")
+		exists, err := afero.Exists(deps.FS, userRules)
+		require.NoError(t, err)
+		assert.False(t, exists)
+		assert.Contains(t, skipped, permissions.Write{Op: "write", Path: userRules})
+	})
+
+	t.Run("formats without user rules support", func(t *testing.T) {
+		deps := createTestDependencies()
+		rulePath := setupGlobalRule(t, deps.FS, validEditedRule)
+		globalDir := filepath.Dir(filepath.Dir(filepath.Dir(rulePath)))
+		require.NoError(t, afero.WriteFile(deps.FS,
+			filepath.Join(globalDir, domain.GetConfigFileName()),
+			[]byte("version: 1\nformats:\n  - type: cursor\n    enabled: true\n"), 0o644))
+
+		err := runBuildCommand(t, NewBuildCommand(deps), "--global")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no target formats support global rules")
+	})
+}
+
+func TestBuildCommand_UseRemoteConfig(t *testing.T) {
+	cmd := NewBuildCommand(createTestDependencies())
+
+	require.NoError(t, cmd.useRemoteConfig(context.Background(), "", ""))
+
+	err := cmd.useRemoteConfig(context.Background(), "", "sha256:abc")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "requires --config-url")
+
+	err = cmd.useRemoteConfig(context.Background(), "http://example.com/contexture.yaml", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "https")
+	assert.Empty(t, cmd.projectManager.ConfigFile())
+}
+
+func TestBuildCommand_UseRemoteConfig_UnpinnedCommands(t *testing.T) {
+	t.Parallel()
+	deps := createTestDependencies()
+	cmd := NewBuildCommand(deps)
+
+	// The server cannot be reached, so the build falls back to the cached copy
+	configURL := "https://127.0.0.1:1/contexture.yaml"
+	key := sha256.Sum256([]byte(configURL))
+	cached := filepath.Join(cache.BaseDir(deps.GetEnv()), cache.RemoteConfigDirName, hex.EncodeToString(key[:8])+".yaml")
+	writeCached := func(content string) {
+		require.NoError(t, afero.WriteFile(deps.FS, cached, []byte(content), 0o644))
+	}
+
+	writeCached("version: 1\nformats:\n  - type: claude\n    enabled: true\n")
+	require.NoError(t, cmd.useRemoteConfig(context.Background(), configURL, ""))
+	assert.Equal(t, cached, cmd.projectManager.ConfigFile())
+
+	// The build reads the content that was checked, not the file as changed since
+	writeCached("version: 1\nformats:\n  - type: cursor\n    enabled: true\n")
+	result, err := cmd.projectManager.LoadConfig("/project")
+	require.NoError(t, err)
+	require.Len(t, result.Config.Formats, 1)
+	assert.Equal(t, domain.FormatClaude, result.Config.Formats[0].Type)
+
+	cmd.projectManager.SetConfigFile("")
+	writeCached("version: 1\nformats:\n  - type: claude\n    enabled: true\n" +
+		"generation:\n  summarize:\n    strategy: command\n    command: ./summarize\n" +
+		"sensitive:\n  encryptCommand: age -r age1\n")
+	err = cmd.useRemoteConfig(context.Background(), configURL, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "generation.summarize.command, sensitive.encryptCommand")
+	assert.Contains(t, err.Error(), "must be pinned")
+	assert.Empty(t, cmd.projectManager.ConfigFile())
+}
@@ -0,0 +1,81 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/contextureai/contexture/internal/dependencies"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/gc"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/contextureai/contexture/internal/ui"
+	"github.com/spf13/afero"
+	"github.com/urfave/cli/v3"
+)
+
+// CacheCommand implements the cache command
+type CacheCommand struct {
+	projectManager *project.Manager
+	fs             afero.Fs
+	workingDir     dependencies.WorkingDir
+	env            dependencies.Environment
+}
+
+// NewCacheCommand creates a new cache command
+func NewCacheCommand(deps *dependencies.Dependencies) *CacheCommand {
+	return &CacheCommand{
+		projectManager: project.NewManagerFromDependencies(deps),
+		fs:             deps.FS,
+		workingDir:     deps.GetWorkingDir(),
+		env:            deps.GetEnv(),
+	}
+}
+
+// GC removes what interrupted commands left behind and reports the space
+// reclaimed, or with --dry-run only lists it
+func (c *CacheCommand) GC(_ context.Context, cmd *cli.Command) error {
+	currentDir, err := c.workingDir.Getwd()
+	if err != nil {
+		return contextureerrors.Wrap(err, "get current directory")
+	}
+	configDirs := []string{currentDir}
+	if globalDir, err := c.projectManager.GlobalConfigDir(); err == nil {
+		configDirs = append(configDirs, globalDir)
+	}
+
+	dryRun := cmd.Bool("dry-run")
+	report, collectErr := gc.New(c.fs, c.env, configDirs...).Collect(dryRun)
+
+	theme := ui.DefaultTheme()
+	successStyle := lipgloss.NewStyle().Foreground(theme.Success)
+	mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+	verb := "Removed"
+	if dryRun {
+		verb = "Would remove"
+	}
+	for _, item := range report.Items {
+		fmt.Printf("  %s %s %s\n", mutedStyle.Render(fmt.Sprintf("%-13s", item.Kind)), item.Path,
+			mutedStyle.Render(gc.FormatBytes(item.Size)))
+	}
+	switch {
+	case len(report.Items) == 0 && collectErr == nil:
+		fmt.Println(successStyle.Render("✓ Nothing to clean up"))
+	case dryRun:
+		fmt.Println(mutedStyle.Render(fmt.Sprintf("%s %d item(s), reclaiming %s", verb, len(report.Items), gc.FormatBytes(report.Reclaimed))))
+	case len(report.Items) > 0:
+		fmt.Println(successStyle.Render(fmt.Sprintf("✓ %s %d item(s), reclaimed %s", verb, len(report.Items), gc.FormatBytes(report.Reclaimed))))
+	}
+	return collectErr
+}
+
+// CacheAction is the default action when running 'contexture cache'
+func CacheAction(_ context.Context, cmd *cli.Command, _ *dependencies.Dependencies) error {
+	return cli.ShowSubcommandHelp(cmd)
+}
+
+// CacheGCAction is the CLI action handler for the cache gc command
+func CacheGCAction(ctx context.Context, cmd *cli.Command, deps *dependencies.Dependencies) error {
+	cacheCmd := NewCacheCommand(deps)
+	return cacheCmd.GC(ctx, cmd)
+}
@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+func TestCacheCommand_GC(t *testing.T) {
+	deps := createTestDependencies().WithWorkingDir(dependencies.StaticWorkingDir("/project"))
+	require.NoError(t, afero.WriteFile(deps.FS, "/project/.contexture.yaml", []byte("version: 1\n"), 0o644))
+	require.NoError(t, afero.WriteFile(deps.FS, "/project/.contexture.yaml.tmp", []byte("version: 1\n"), 0o644))
+	old := time.Now().Add(-2 * time.Hour)
+	require.NoError(t, deps.FS.Chtimes("/project/.contexture.yaml.tmp", old, old))
+
+	run := func(args ...string) {
+		t.Helper()
+		app := &cli.Command{
+			Name:  "gc",
+			Flags: []cli.Flag{&cli.BoolFlag{Name: "dry-run"}},
+			Action: func(ctx context.Context, cmd *cli.Command) error {
+				return NewCacheCommand(deps).GC(ctx, cmd)
+			},
+		}
+		require.NoError(t, app.Run(context.Background(), append([]string{"gc"}, args...)))
+	}
+
+	run("--dry-run")
+	exists, err := afero.Exists(deps.FS, "/project/.contexture.yaml.tmp")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	run()
+	exists, err = afero.Exists(deps.FS, "/project/.contexture.yaml.tmp")
+	require.NoError(t, err)
+	assert.False(t, exists)
+	exists, err = afero.Exists(deps.FS, "/project/.contexture.yaml")
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
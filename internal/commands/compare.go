@@ -0,0 +1,76 @@
+// Package commands provides CLI command implementations
+package commands
+
+import (
+	"context"
+
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/diff"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/output"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/contextureai/contexture/internal/provider"
+	"github.com/contextureai/contexture/internal/rule"
+	"github.com/urfave/cli/v3"
+)
+
+// CompareCommand implements the rules compare command
+type CompareCommand struct {
+	projectManager   *project.Manager
+	ruleFetcher      rule.Fetcher
+	providerRegistry *provider.Registry
+	workingDir       dependencies.WorkingDir
+	env              dependencies.Environment
+}
+
+// NewCompareCommand creates a new compare command
+func NewCompareCommand(deps *dependencies.Dependencies) *CompareCommand {
+	return &CompareCommand{
+		projectManager:   project.NewManagerFromDependencies(deps),
+		ruleFetcher:      rule.NewFetcher(deps.FS, newOpenRepository(deps.FS), fetcherConfig(deps), deps.ProviderRegistry),
+		providerRegistry: deps.ProviderRegistry,
+		workingDir:       deps.GetWorkingDir(),
+		env:              deps.GetEnv(),
+	}
+}
+
+// Execute runs the compare command
+func (c *CompareCommand) Execute(ctx context.Context, cmd *cli.Command) error {
+	if cmd.Args().Len() != 2 {
+		return contextureerrors.ValidationErrorf("rules", "exactly two rule IDs are required, got %d", cmd.Args().Len())
+	}
+	leftID, rightID := cmd.Args().Get(0), cmd.Args().Get(1)
+
+	// Load custom providers from the project config if there is one
+	currentDir, err := c.workingDir.Getwd()
+	if err != nil {
+		return contextureerrors.Wrap(err, "get current directory")
+	}
+	if configResult, err := c.projectManager.LoadConfig(currentDir); err == nil {
+		if err := c.providerRegistry.LoadFromProject(configResult.Config); err != nil {
+			return contextureerrors.Wrap(err, "load providers")
+		}
+	}
+
+	left, err := c.ruleFetcher.FetchRule(ctx, leftID)
+	if err != nil {
+		return contextureerrors.Wrap(err, "fetch rule "+leftID)
+	}
+	right, err := c.ruleFetcher.FetchRule(ctx, rightID)
+	if err != nil {
+		return contextureerrors.Wrap(err, "fetch rule "+rightID)
+	}
+
+	outputMgr, err := output.NewManager(output.Format(cmd.String("output")), c.workingDir, c.env)
+	if err != nil {
+		return err
+	}
+
+	return outputMgr.WriteRuleComparison(left, right, diff.Lines(left.Content, right.Content))
+}
+
+// CompareAction is the CLI action handler for the rules compare command
+func CompareAction(ctx context.Context, cmd *cli.Command, deps *dependencies.Dependencies) error {
+	compareCmd := NewCompareCommand(deps)
+	return compareCmd.Execute(ctx, cmd)
+}
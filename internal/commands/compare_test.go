@@ -0,0 +1,43 @@
+// Package commands provides CLI command implementations
+package commands
+
+import (
+	"context"
+	"testing"
+
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+func TestNewCompareCommand(t *testing.T) {
+	deps := createTestDependencies()
+
+	cmd := NewCompareCommand(deps)
+
+	assert.NotNil(t, cmd)
+	assert.NotNil(t, cmd.projectManager)
+	assert.NotNil(t, cmd.ruleFetcher)
+	assert.NotNil(t, cmd.providerRegistry)
+}
+
+func TestCompareCommand_RequiresTwoRules(t *testing.T) {
+	deps := createTestDependencies()
+	compareCmd := NewCompareCommand(deps)
+
+	for _, args := range [][]string{{}, {"languages/go/testing"}, {"a", "b", "c"}} {
+		var execErr error
+		app := &cli.Command{
+			Name: "compare",
+			Action: func(ctx context.Context, cmd *cli.Command) error {
+				execErr = compareCmd.Execute(ctx, cmd)
+				return nil
+			},
+		}
+
+		require.NoError(t, app.Run(context.Background(), append([]string{"compare"}, args...)))
+		require.Error(t, execErr, "args %v", args)
+		assert.Equal(t, int(contextureerrors.ExitValidation), contextureerrors.ExitCodeFor(execErr), "args %v", args)
+	}
+}
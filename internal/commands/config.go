@@ -4,12 +4,12 @@ package commands
 import (
 	"context"
 	"fmt"
-	"os"
-	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/log"
+	"github.com/contextureai/contexture/internal/cache"
 	"github.com/contextureai/contexture/internal/dependencies"
 	"github.com/contextureai/contexture/internal/domain"
 	contextureerrors "github.com/contextureai/contexture/internal/errors"
@@ -31,14 +31,18 @@ type MainConfigCommand struct {
 	projectManager *project.Manager
 	registry       *format.Registry
 	fs             afero.Fs
+	workingDir     dependencies.WorkingDir
+	env            dependencies.Environment
 }
 
 // NewMainConfigCommand creates a new main config command
 func NewMainConfigCommand(deps *dependencies.Dependencies) *MainConfigCommand {
 	return &MainConfigCommand{
-		projectManager: project.NewManager(deps.FS),
+		projectManager: project.NewManagerFromDependencies(deps),
 		registry:       format.GetDefaultRegistry(deps.FS),
 		fs:             deps.FS,
+		workingDir:     deps.GetWorkingDir(),
+		env:            deps.GetEnv(),
 	}
 }
 
@@ -50,9 +54,8 @@ func (c *MainConfigCommand) Execute(_ context.Context, cmd *cli.Command) error {
 		// Load and display global configuration
 		globalResult, err := c.projectManager.LoadGlobalConfig()
 		if err != nil {
-			fmt.Println("No global configuration found")
-			fmt.Println("Create global rules with: contexture rules add -g <rule-id>")
-			return err
+			return contextureerrors.Wrap(err, "load global configuration").
+				WithSuggestions(contextureerrors.RunCommand("contexture rules add -g <rule-id>", "create global rules"))
 		}
 
 		c.displayGlobalConfiguration(globalResult.Config, globalResult.Path)
@@ -60,16 +63,15 @@ func (c *MainConfigCommand) Execute(_ context.Context, cmd *cli.Command) error {
 	}
 
 	// Get current directory and load configuration
-	currentDir, err := os.Getwd()
+	currentDir, err := c.workingDir.Getwd()
 	if err != nil {
 		return contextureerrors.Wrap(err, "get current directory")
 	}
 
 	configResult, err := c.projectManager.LoadConfigWithLocalRules(currentDir)
 	if err != nil {
-		fmt.Println("No project configuration found")
-		fmt.Println("Initialize a project with: contexture init")
-		return err
+		return contextureerrors.Wrap(err, "load project configuration").
+			WithSuggestions(contextureerrors.RunCommand("contexture init", "initialize a new project"))
 	}
 
 	config := configResult.Config
@@ -162,7 +164,7 @@ func (c *MainConfigCommand) Execute(_ context.Context, cmd *cli.Command) error {
 
 	// Display cache configuration
 	fmt.Println(sectionStyle.Render("Cache Configuration"))
-	cacheDir := filepath.Join(os.TempDir(), "contexture")
+	cacheDir := cache.BaseDir(c.env)
 
 	fmt.Printf("  %s %s\n",
 		darkMutedStyle.Render("directory:"),
@@ -175,7 +177,7 @@ func (c *MainConfigCommand) Execute(_ context.Context, cmd *cli.Command) error {
 		if err == nil {
 			repoCount := 0
 			for _, file := range files {
-				if file.IsDir() {
+				if file.IsDir() && !slices.Contains(cache.ReservedDirs, file.Name()) {
 					repoCount++
 				}
 			}
@@ -25,15 +25,21 @@ func ConfigFormatsAddAction(
 	deps *dependencies.Dependencies,
 ) error {
 	fm := NewFormatManager(deps)
-	args := cmd.Args().Slice()
+	formats, selected, err := fm.selectedFormats(cmd, formatAdd)
+	if err != nil {
+		return err
+	}
 
-	if len(args) == 0 {
+	if !selected {
 		// Interactive mode
 		return fm.interactiveAddFormat(ctx, cmd)
 	}
+	if cmd.Bool("dry-run") {
+		return writeDryRunSelection("config formats add", formats)
+	}
 
 	// Add specific formats
-	for _, formatType := range args {
+	for _, formatType := range formats {
 		if err := fm.AddFormat(ctx, cmd, formatType); err != nil {
 			return err
 		}
@@ -48,15 +54,21 @@ func ConfigFormatsRemoveAction(
 	deps *dependencies.Dependencies,
 ) error {
 	fm := NewFormatManager(deps)
-	args := cmd.Args().Slice()
+	formats, selected, err := fm.selectedFormats(cmd, formatRemove)
+	if err != nil {
+		return err
+	}
 
-	if len(args) == 0 {
+	if !selected {
 		// Interactive mode
 		return fm.interactiveRemoveFormat(ctx, cmd)
 	}
+	if cmd.Bool("dry-run") {
+		return writeDryRunSelection("config formats remove", formats)
+	}
 
 	// Remove specific formats
-	for _, formatType := range args {
+	for _, formatType := range formats {
 		if err := fm.RemoveFormat(ctx, cmd, formatType); err != nil {
 			return err
 		}
@@ -71,15 +83,26 @@ func ConfigFormatsEnableAction(
 	deps *dependencies.Dependencies,
 ) error {
 	fm := NewFormatManager(deps)
-	args := cmd.Args().Slice()
+	formats, selected, err := fm.selectedFormats(cmd, formatEnable)
+	if err != nil {
+		return err
+	}
 
-	if len(args) == 0 {
+	if !selected {
 		// Interactive mode
 		return fm.interactiveEnableFormat(ctx, cmd)
 	}
+	if cmd.Bool("dry-run") {
+		return writeDryRunSelection("config formats enable", formats)
+	}
 
-	// Enable specific format
-	return fm.EnableFormat(ctx, cmd, args[0])
+	// Enable specific formats
+	for _, formatType := range formats {
+		if err := fm.EnableFormat(ctx, cmd, formatType); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // ConfigFormatsDisableAction handles the config formats disable command
@@ -89,15 +112,26 @@ func ConfigFormatsDisableAction(
 	deps *dependencies.Dependencies,
 ) error {
 	fm := NewFormatManager(deps)
-	args := cmd.Args().Slice()
+	formats, selected, err := fm.selectedFormats(cmd, formatDisable)
+	if err != nil {
+		return err
+	}
 
-	if len(args) == 0 {
+	if !selected {
 		// Interactive mode
 		return fm.interactiveDisableFormat(ctx, cmd)
 	}
+	if cmd.Bool("dry-run") {
+		return writeDryRunSelection("config formats disable", formats)
+	}
 
-	// Disable specific format
-	return fm.DisableFormat(ctx, cmd, args[0])
+	// Disable specific formats
+	for _, formatType := range formats {
+		if err := fm.DisableFormat(ctx, cmd, formatType); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // ConfigFormatsAction handles the base config formats command (defaults to list)
@@ -6,8 +6,11 @@ import (
 	"testing"
 
 	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/contextureai/contexture/internal/project"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/urfave/cli/v3"
 )
 
@@ -69,3 +72,91 @@ func TestConfigActionsErrorHandling(t *testing.T) {
 		}, "Should not panic with read-only filesystem")
 	})
 }
+
+func TestConfigFormatsActions_SelectionFlags(t *testing.T) {
+	t.Parallel()
+
+	setup := func(t *testing.T) (*dependencies.Dependencies, func() *domain.Project) {
+		t.Helper()
+		fs := afero.NewMemMapFs()
+		require.NoError(t, fs.MkdirAll("/project", 0o755))
+		require.NoError(t, afero.WriteFile(fs, "/project/"+domain.ConfigFile, []byte(`version: 1
+formats:
+  - type: claude
+    enabled: true
+rules: []
+`), 0o644))
+		deps := &dependencies.Dependencies{
+			FS:         fs,
+			Context:    context.Background(),
+			WorkingDir: dependencies.StaticWorkingDir("/project"),
+		}
+		load := func() *domain.Project {
+			result, err := project.NewManager(fs).LoadConfig("/project")
+			require.NoError(t, err)
+			return result.Config
+		}
+		return deps, load
+	}
+	run := func(deps *dependencies.Dependencies, action func(context.Context, *cli.Command, *dependencies.Dependencies) error, args ...string) error {
+		var execErr error
+		app := &cli.Command{
+			Name: "formats",
+			Flags: []cli.Flag{
+				&cli.StringSliceFlag{Name: "select"},
+				&cli.StringFlag{Name: "all-matching"},
+				&cli.BoolFlag{Name: "dry-run"},
+			},
+			// The error is returned from the test helper, not the app, which
+			// would exit for errors carrying an exit code
+			Action: func(ctx context.Context, cmd *cli.Command) error {
+				execErr = action(ctx, cmd, deps)
+				return nil
+			},
+		}
+		if err := app.Run(context.Background(), append([]string{"formats"}, args...)); err != nil {
+			return err
+		}
+		return execErr
+	}
+	formatTypes := func(config *domain.Project) []domain.FormatType {
+		var types []domain.FormatType
+		for _, f := range config.Formats {
+			types = append(types, f.Type)
+		}
+		return types
+	}
+
+	t.Run("add with select", func(t *testing.T) {
+		t.Parallel()
+		deps, load := setup(t)
+		require.NoError(t, run(deps, ConfigFormatsAddAction, "--select", "cursor,windsurf"))
+		assert.Equal(t, []domain.FormatType{domain.FormatClaude, domain.FormatCursor, domain.FormatWindsurf}, formatTypes(load()))
+	})
+
+	t.Run("add dry run leaves the configuration", func(t *testing.T) {
+		t.Parallel()
+		deps, load := setup(t)
+		require.NoError(t, run(deps, ConfigFormatsAddAction, "--all-matching", "*", "--dry-run"))
+		assert.Equal(t, []domain.FormatType{domain.FormatClaude}, formatTypes(load()))
+	})
+
+	t.Run("add rejects configured formats", func(t *testing.T) {
+		t.Parallel()
+		deps, _ := setup(t)
+		err := run(deps, ConfigFormatsAddAction, "--select", "claude")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `no option "claude"`)
+	})
+
+	t.Run("disable with all matching", func(t *testing.T) {
+		t.Parallel()
+		deps, load := setup(t)
+		require.NoError(t, run(deps, ConfigFormatsAddAction, "--select", "cursor"))
+		require.NoError(t, run(deps, ConfigFormatsDisableAction, "--all-matching", "cursor"))
+		config := load()
+		require.Len(t, config.Formats, 2)
+		assert.True(t, config.Formats[0].Enabled)
+		assert.False(t, config.Formats[1].Enabled)
+	})
+}
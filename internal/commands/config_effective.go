@@ -0,0 +1,196 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/contextureai/contexture/internal/ui"
+	"github.com/urfave/cli/v3"
+)
+
+// layerOrder is the order sources are listed in, from the lowest precedence
+var layerOrder = []project.Layer{
+	project.LayerGlobal,
+	project.LayerExtends,
+	project.LayerProject,
+	project.LayerLocalRules,
+	project.LayerLocalOverrides,
+}
+
+// ConfigEffectiveAction prints the configuration used for generation with the
+// layer each format, provider, and rule comes from. With --diff it only lists
+// what the other layers add to, change in, or remove from the project
+// configuration.
+func ConfigEffectiveAction(_ context.Context, cmd *cli.Command, deps *dependencies.Dependencies) error {
+	currentDir, err := deps.GetWorkingDir().Getwd()
+	if err != nil {
+		return contextureerrors.Wrap(err, "get current directory")
+	}
+	effective, err := project.NewManagerFromDependencies(deps).LoadEffectiveConfig(currentDir)
+	if err != nil {
+		return contextureerrors.Wrap(err, "load configuration").
+			WithSuggestions(contextureerrors.RunCommand("contexture init", "create a project configuration"))
+	}
+
+	if cmd.Bool("diff") {
+		printEffectiveDiff(effective)
+		return nil
+	}
+	printEffectiveConfig(effective)
+	return nil
+}
+
+// printEffectiveConfig prints the sources, formats, providers, and rules of
+// the effective configuration, each with its layer
+func printEffectiveConfig(effective *project.EffectiveConfig) {
+	theme := ui.DefaultTheme()
+	sectionStyle := lipgloss.NewStyle().Bold(true).Foreground(theme.Primary).MarginTop(1)
+	mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+
+	fmt.Println(ui.CommandHeader("effective configuration"))
+
+	fmt.Println(sectionStyle.Render("Sources"))
+	var sources [][2]string
+	for _, layer := range layerOrder {
+		if file, ok := effective.Files[layer]; ok {
+			sources = append(sources, [2]string{string(layer), file})
+		}
+	}
+	printColumns(sources, mutedStyle)
+
+	fmt.Println(sectionStyle.Render("Output Formats"))
+	var formats [][2]string
+	for _, format := range effective.Formats {
+		status := statusDisabled
+		if format.Format.Enabled {
+			status = statusEnabled
+		}
+		formats = append(formats, [2]string{fmt.Sprintf("%s %s", format.Format.Type, status), string(format.Layer)})
+	}
+	printColumns(formats, mutedStyle)
+
+	if len(effective.Providers) > 0 {
+		fmt.Println(sectionStyle.Render("Providers"))
+		var providers [][2]string
+		for _, p := range effective.Providers {
+			providers = append(providers, [2]string{"@" + p.Provider.Name, string(p.Layer)})
+		}
+		printColumns(providers, mutedStyle)
+	}
+
+	fmt.Println(sectionStyle.Render("Rules"))
+	if len(effective.Rules) == 0 {
+		fmt.Println("  No rules configured")
+	}
+	var rules [][2]string
+	for _, rule := range effective.Rules {
+		origin := string(rule.Layer)
+		if rule.Overrides != "" {
+			origin += ", overrides " + string(rule.Overrides)
+		}
+		if len(rule.LocalVariables) > 0 {
+			origin += fmt.Sprintf(", %s from %s", strings.Join(rule.LocalVariables, ", "), project.LayerLocalOverrides)
+		}
+		rules = append(rules, [2]string{domain.ExtractRulePath(rule.Rule.ID), origin})
+	}
+	printColumns(rules, mutedStyle)
+
+	if len(effective.Disabled) > 0 {
+		fmt.Println(sectionStyle.Render("Disabled by Local Overrides"))
+		var disabled [][2]string
+		for _, rule := range effective.Disabled {
+			disabled = append(disabled, [2]string{domain.ExtractRulePath(rule.Rule.ID), string(rule.Layer)})
+		}
+		printColumns(disabled, mutedStyle)
+	}
+}
+
+// printEffectiveDiff lists what the global configuration, base
+// configurations, local rules, and local overrides change compared to the
+// project configuration as written: + added, ~ changed, - removed
+func printEffectiveDiff(effective *project.EffectiveConfig) {
+	theme := ui.DefaultTheme()
+	addedStyle := lipgloss.NewStyle().Foreground(theme.Success)
+	changedStyle := lipgloss.NewStyle().Foreground(theme.Warning)
+	removedStyle := lipgloss.NewStyle().Foreground(theme.Error)
+	mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+
+	type change struct {
+		style  lipgloss.Style
+		marker string
+		item   string
+		note   string
+	}
+	var changes []change
+	for _, format := range effective.Formats {
+		if format.Layer != project.LayerProject {
+			changes = append(changes, change{addedStyle, "+", "format " + string(format.Format.Type), "from " + string(format.Layer)})
+		}
+	}
+	for _, p := range effective.Providers {
+		if p.Layer != project.LayerProject {
+			changes = append(changes, change{addedStyle, "+", "provider @" + p.Provider.Name, "from " + string(p.Layer)})
+		}
+	}
+	for _, rule := range effective.Rules {
+		item := "rule " + domain.ExtractRulePath(rule.Rule.ID)
+		if rule.Layer != project.LayerProject {
+			changes = append(changes, change{addedStyle, "+", item, "from " + string(rule.Layer)})
+		}
+		if rule.Overrides != "" {
+			changes = append(changes, change{changedStyle, "~", item, "replaces the " + string(rule.Overrides) + " rule"})
+		}
+		if len(rule.LocalVariables) > 0 {
+			changes = append(changes, change{changedStyle, "~", item,
+				strings.Join(rule.LocalVariables, ", ") + " set by " + string(project.LayerLocalOverrides)})
+		}
+	}
+	for _, rule := range effective.Disabled {
+		changes = append(changes, change{removedStyle, "-", "rule " + domain.ExtractRulePath(rule.Rule.ID),
+			"disabled by " + string(project.LayerLocalOverrides)})
+	}
+
+	fmt.Println(ui.CommandHeader("effective configuration"))
+	if len(changes) == 0 {
+		fmt.Println("The project configuration is used as written; no other layer changes it.")
+		return
+	}
+	// Additions first, then changes, then removals, each by item
+	rank := map[string]int{"+": 0, "~": 1, "-": 2}
+	sort.SliceStable(changes, func(i, j int) bool {
+		if rank[changes[i].marker] != rank[changes[j].marker] {
+			return rank[changes[i].marker] < rank[changes[j].marker]
+		}
+		return changes[i].item < changes[j].item
+	})
+
+	width := 0
+	for _, c := range changes {
+		width = max(width, len(c.item))
+	}
+	for _, c := range changes {
+		fmt.Printf("%s %s%s  %s\n",
+			c.style.Render(c.marker),
+			c.item,
+			strings.Repeat(" ", width-len(c.item)),
+			mutedStyle.Render(c.note))
+	}
+}
+
+// printColumns prints rows of two columns, the first padded to align the second
+func printColumns(rows [][2]string, secondStyle lipgloss.Style) {
+	width := 0
+	for _, row := range rows {
+		width = max(width, len(row[0]))
+	}
+	for _, row := range rows {
+		fmt.Printf("  %s%s  %s\n", row[0], strings.Repeat(" ", width-len(row[0])), secondStyle.Render(row[1]))
+	}
+}
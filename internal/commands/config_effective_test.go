@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigEffectiveAction(t *testing.T) {
+	t.Setenv(project.HomeEnv, "/state")
+	deps := createTestDependencies().WithWorkingDir(dependencies.StaticWorkingDir("/project"))
+
+	err := runConfigCommand(t, deps, ConfigEffectiveAction)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "load configuration")
+
+	require.NoError(t, afero.WriteFile(deps.FS, "/state/.contexture.yaml", []byte(`version: 1
+rules:
+  - id: "[contexture:languages/go/testing]"
+  - id: "[contexture:personal/terse]"
+`), 0o644))
+	require.NoError(t, afero.WriteFile(deps.FS, "/project/.contexture.yaml", []byte(`version: 1
+formats:
+  - type: claude
+    enabled: true
+rules:
+  - id: "[contexture:languages/go/testing]"
+`), 0o644))
+	require.NoError(t, afero.WriteFile(deps.FS, "/project/.contexture.local.yaml", []byte(`disable:
+  - "[contexture:languages/go/testing]"
+`), 0o644))
+
+	require.NoError(t, runConfigCommand(t, deps, ConfigEffectiveAction))
+	require.NoError(t, runConfigCommand(t, deps, ConfigEffectiveAction, "--diff"))
+}
@@ -4,7 +4,7 @@ package commands
 import (
 	"context"
 	"fmt"
-	"os"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -24,31 +24,34 @@ import (
 type FormatManager struct {
 	projectManager *project.Manager
 	registry       *format.Registry
+	detector       *format.Detector
 	fs             afero.Fs
+	workingDir     dependencies.WorkingDir
 }
 
 // NewFormatManager creates a new format manager
 func NewFormatManager(deps *dependencies.Dependencies) *FormatManager {
 	return &FormatManager{
-		projectManager: project.NewManager(deps.FS),
+		projectManager: project.NewManagerFromDependencies(deps),
 		registry:       format.GetDefaultRegistry(deps.FS),
+		detector:       format.NewDetector(deps.FS, deps.GetEnv()),
 		fs:             deps.FS,
+		workingDir:     deps.GetWorkingDir(),
 	}
 }
 
 // ListFormats displays all configured formats
 func (fm *FormatManager) ListFormats(_ context.Context, _ *cli.Command) error {
 	// Handle project configuration
-	currentDir, err := os.Getwd()
+	currentDir, err := fm.workingDir.Getwd()
 	if err != nil {
 		return contextureerrors.Wrap(err, "get directory")
 	}
 
 	configResult, err := fm.projectManager.LoadConfig(currentDir)
 	if err != nil {
-		fmt.Println("No project configuration found")
-		fmt.Println("Initialize a project with: contexture init")
-		return err
+		return contextureerrors.Wrap(err, "load project configuration").
+			WithSuggestions(contextureerrors.RunCommand("contexture init", "initialize a new project"))
 	}
 
 	return fm.displayProjectFormats(configResult.Config)
@@ -61,7 +64,7 @@ func (fm *FormatManager) AddFormat(ctx context.Context, cmd *cli.Command, format
 	}
 
 	// Handle project configuration
-	currentDir, err := os.Getwd()
+	currentDir, err := fm.workingDir.Getwd()
 	if err != nil {
 		return contextureerrors.Wrap(err, "get directory")
 	}
@@ -81,7 +84,7 @@ func (fm *FormatManager) EnableFormat(
 	formatType string,
 ) error {
 	// Handle project configuration
-	currentDir, err := os.Getwd()
+	currentDir, err := fm.workingDir.Getwd()
 	if err != nil {
 		return contextureerrors.Wrap(err, "get directory")
 	}
@@ -101,7 +104,7 @@ func (fm *FormatManager) DisableFormat(
 	formatType string,
 ) error {
 	// Handle project configuration
-	currentDir, err := os.Getwd()
+	currentDir, err := fm.workingDir.Getwd()
 	if err != nil {
 		return contextureerrors.Wrap(err, "get directory")
 	}
@@ -121,7 +124,7 @@ func (fm *FormatManager) RemoveFormat(
 	formatType string,
 ) error {
 	// Handle project configuration
-	currentDir, err := os.Getwd()
+	currentDir, err := fm.workingDir.Getwd()
 	if err != nil {
 		return contextureerrors.Wrap(err, "get directory")
 	}
@@ -135,9 +138,9 @@ func (fm *FormatManager) RemoveFormat(
 }
 
 // interactiveAddFormat provides an interactive interface to add formats
-func (fm *FormatManager) interactiveAddFormat(_ context.Context, _ *cli.Command) error {
+func (fm *FormatManager) interactiveAddFormat(_ context.Context, cmd *cli.Command) error {
 	// Get current directory and load configuration
-	currentDir, err := os.Getwd()
+	currentDir, err := fm.workingDir.Getwd()
 	if err != nil {
 		return contextureerrors.Wrap(err, "get directory")
 	}
@@ -245,15 +248,31 @@ func (fm *FormatManager) interactiveAddFormat(_ context.Context, _ *cli.Command)
 		return nil
 	}
 
+	// Preselect available formats of assistants found on this machine
+	description := "Choose one or more formats to add to your project\nPress 'q' or 'esc' to exit"
+	var detected []format.Detection
+	for _, detection := range fm.detector.Detect(currentDir) {
+		if !existingTypes[detection.Format] {
+			detected = append(detected, detection)
+		}
+	}
+	if summary := detectedFormatsDescription(fm.registry, detected); summary != "" {
+		description = summary + "\n" + description
+	}
+
 	// Interactive selection
 	selectedFormats, err := tui.MultiSelect(tui.MultiSelectOptions{
 		Title:       "Select formats to add",
-		Description: "Choose one or more formats to add to your project\nPress 'q' or 'esc' to exit",
+		Description: description,
 		Options:     availableFormats,
+		Default:     format.DetectedFormats(detected),
 	})
 	if err != nil {
 		return contextureerrors.Wrap(err, "select format")
 	}
+	if cmd.Bool("dry-run") {
+		return writeDryRunSelection("config formats add", selectedFormats)
+	}
 
 	if len(selectedFormats) == 0 {
 		log.Info("No formats selected")
@@ -303,9 +322,9 @@ func (fm *FormatManager) interactiveAddFormat(_ context.Context, _ *cli.Command)
 }
 
 // interactiveRemoveFormat provides an interactive interface to remove formats
-func (fm *FormatManager) interactiveRemoveFormat(_ context.Context, _ *cli.Command) error {
+func (fm *FormatManager) interactiveRemoveFormat(_ context.Context, cmd *cli.Command) error {
 	// Get current directory and load configuration
-	currentDir, err := os.Getwd()
+	currentDir, err := fm.workingDir.Getwd()
 	if err != nil {
 		return contextureerrors.Wrap(err, "get directory")
 	}
@@ -405,6 +424,9 @@ func (fm *FormatManager) interactiveRemoveFormat(_ context.Context, _ *cli.Comma
 	if err != nil {
 		return contextureerrors.Wrap(err, "select format")
 	}
+	if cmd.Bool("dry-run") {
+		return writeDryRunSelection("config formats remove", selectedFormats)
+	}
 
 	if len(selectedFormats) == 0 {
 		log.Info("No formats selected for removal")
@@ -471,9 +493,9 @@ func (fm *FormatManager) interactiveRemoveFormat(_ context.Context, _ *cli.Comma
 }
 
 // interactiveEnableFormat provides an interactive interface to enable formats
-func (fm *FormatManager) interactiveEnableFormat(_ context.Context, _ *cli.Command) error {
+func (fm *FormatManager) interactiveEnableFormat(_ context.Context, cmd *cli.Command) error {
 	// Get current directory and load configuration
-	currentDir, err := os.Getwd()
+	currentDir, err := fm.workingDir.Getwd()
 	if err != nil {
 		return contextureerrors.Wrap(err, "get directory")
 	}
@@ -580,6 +602,9 @@ func (fm *FormatManager) interactiveEnableFormat(_ context.Context, _ *cli.Comma
 	if err != nil {
 		return contextureerrors.Wrap(err, "select format")
 	}
+	if cmd.Bool("dry-run") {
+		return writeDryRunSelection("config formats enable", []string{selectedFormat})
+	}
 
 	if selectedFormat == "" {
 		log.Info("No format selected")
@@ -591,9 +616,9 @@ func (fm *FormatManager) interactiveEnableFormat(_ context.Context, _ *cli.Comma
 }
 
 // interactiveDisableFormat provides an interactive interface to disable formats
-func (fm *FormatManager) interactiveDisableFormat(_ context.Context, _ *cli.Command) error {
+func (fm *FormatManager) interactiveDisableFormat(_ context.Context, cmd *cli.Command) error {
 	// Get current directory and load configuration
-	currentDir, err := os.Getwd()
+	currentDir, err := fm.workingDir.Getwd()
 	if err != nil {
 		return contextureerrors.Wrap(err, "get directory")
 	}
@@ -713,6 +738,9 @@ func (fm *FormatManager) interactiveDisableFormat(_ context.Context, _ *cli.Comm
 	if err != nil {
 		return contextureerrors.Wrap(err, "select format")
 	}
+	if cmd.Bool("dry-run") {
+		return writeDryRunSelection("config formats disable", []string{selectedFormat})
+	}
 
 	if selectedFormat == "" {
 		log.Info("No format selected")
@@ -725,6 +753,79 @@ func (fm *FormatManager) interactiveDisableFormat(_ context.Context, _ *cli.Comm
 
 // Helper methods
 
+// formatOperation is a change to the formats of the project configuration
+type formatOperation int
+
+const (
+	formatAdd formatOperation = iota
+	formatRemove
+	formatEnable
+	formatDisable
+)
+
+// selectedFormats returns the formats named by the arguments and chosen by
+// the --select and --all-matching flags among those operation applies to. It
+// reports false when neither names a format, so the interactive selection runs.
+func (fm *FormatManager) selectedFormats(cmd *cli.Command, operation formatOperation) ([]string, bool, error) {
+	formats := cmd.Args().Slice()
+	selection := selectionFromFlags(cmd)
+	if !selection.IsSet() {
+		return formats, len(formats) > 0, nil
+	}
+
+	options, err := fm.formatOptions(operation)
+	if err != nil {
+		return nil, false, err
+	}
+	selected, err := selection.Resolve(options)
+	if err != nil {
+		return nil, false, err
+	}
+	for _, formatType := range selected {
+		if !slices.Contains(formats, formatType) {
+			formats = append(formats, formatType)
+		}
+	}
+	return formats, true, nil
+}
+
+// formatOptions returns the formats operation applies to: the supported
+// formats not configured yet for add, and the configured formats otherwise,
+// limited to the disabled ones for enable and the enabled ones for disable
+func (fm *FormatManager) formatOptions(operation formatOperation) ([]tui.SelectOption, error) {
+	currentDir, err := fm.workingDir.Getwd()
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "get directory")
+	}
+	configResult, err := fm.projectManager.LoadConfig(currentDir)
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "load config")
+	}
+
+	var options []tui.SelectOption
+	add := func(formatType domain.FormatType) {
+		if handler, exists := fm.registry.GetHandler(formatType); exists {
+			options = append(options, tui.SelectOption{Label: handler.GetDisplayName(), Value: string(formatType)})
+		}
+	}
+
+	if operation == formatAdd {
+		for _, formatType := range fm.registry.GetAvailableFormats() {
+			if !slices.ContainsFunc(configResult.Config.Formats, func(f domain.FormatConfig) bool { return f.Type == formatType }) {
+				add(formatType)
+			}
+		}
+		return options, nil
+	}
+	for _, formatConfig := range configResult.Config.Formats {
+		if operation == formatEnable && formatConfig.Enabled || operation == formatDisable && !formatConfig.Enabled {
+			continue
+		}
+		add(formatConfig.Type)
+	}
+	return options, nil
+}
+
 // displayProjectFormats displays project format configuration
 func (fm *FormatManager) displayProjectFormats(config *domain.Project) error {
 	theme := ui.DefaultTheme()
@@ -1048,3 +1149,22 @@ func (fm *FormatManager) removeFormatFromProjectConfig(configResult *domain.Conf
 	fmt.Println(successStyle.Render("Format removed: " + displayName))
 	return nil
 }
+
+// detectedFormatsDescription names the detected assistants for a format
+// prompt, or returns an empty string when none were detected
+func detectedFormatsDescription(registry *format.Registry, detections []format.Detection) string {
+	if len(detections) == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(detections))
+	for _, detection := range detections {
+		log.Debug("Detected assistant", "format", detection.Format, "signals", detection.Signals)
+		name := string(detection.Format)
+		if handler, exists := registry.GetHandler(detection.Format); exists {
+			name = handler.GetDisplayName()
+		}
+		names = append(names, name)
+	}
+	return "Detected on this machine: " + strings.Join(names, ", ")
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/contextureai/contexture/internal/dependencies"
 	"github.com/contextureai/contexture/internal/domain"
+	"github.com/contextureai/contexture/internal/format"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 )
@@ -83,3 +84,17 @@ func TestFormatManager_HelperMethods(t *testing.T) {
 		}
 	})
 }
+
+func TestDetectedFormatsDescription(t *testing.T) {
+	t.Parallel()
+	registry := format.GetDefaultRegistry(afero.NewMemMapFs())
+
+	assert.Empty(t, detectedFormatsDescription(registry, nil))
+	assert.Equal(t,
+		"Detected on this machine: Claude (CLAUDE.md), Windsurf (.windsurf/rules/)",
+		detectedFormatsDescription(registry, []format.Detection{
+			{Format: domain.FormatClaude, Signals: []string{"claude on PATH"}},
+			{Format: domain.FormatWindsurf, Signals: []string{"~/.windsurf"}},
+		}),
+	)
+}
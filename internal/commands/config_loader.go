@@ -2,8 +2,7 @@
 package commands
 
 import (
-	"os"
-
+	"github.com/contextureai/contexture/internal/dependencies"
 	"github.com/contextureai/contexture/internal/domain"
 	contextureerrors "github.com/contextureai/contexture/internal/errors"
 	"github.com/contextureai/contexture/internal/project"
@@ -19,9 +18,9 @@ type ConfigLoadResult struct {
 
 // LoadProjectConfig loads project configuration
 // This function encapsulates the common pattern used across multiple commands
-func LoadProjectConfig(projectManager *project.Manager) (*ConfigLoadResult, error) {
+func LoadProjectConfig(projectManager *project.Manager, workingDir dependencies.WorkingDir) (*ConfigLoadResult, error) {
 	// Get current directory
-	currentDir, err := os.Getwd()
+	currentDir, err := workingDir.Getwd()
 	if err != nil {
 		return nil, contextureerrors.Wrap(err, "get current directory")
 	}
@@ -30,7 +29,7 @@ func LoadProjectConfig(projectManager *project.Manager) (*ConfigLoadResult, erro
 	configResult, err := projectManager.LoadConfigWithLocalRules(currentDir)
 	if err != nil {
 		return nil, contextureerrors.Wrap(err, "load project configuration").
-			WithSuggestions("Run 'contexture init' to create a project configuration")
+			WithSuggestions(contextureerrors.RunCommand("contexture init", "create a project configuration"))
 	}
 
 	result := &ConfigLoadResult{
@@ -45,9 +44,9 @@ func LoadProjectConfig(projectManager *project.Manager) (*ConfigLoadResult, erro
 
 // LoadProjectConfigOptional loads project configuration but doesn't fail if not found
 // This is useful for commands that can work without existing configuration
-func LoadProjectConfigOptional(projectManager *project.Manager) (*ConfigLoadResult, error) {
+func LoadProjectConfigOptional(projectManager *project.Manager, workingDir dependencies.WorkingDir) (*ConfigLoadResult, error) {
 	// Get current directory
-	currentDir, err := os.Getwd()
+	currentDir, err := workingDir.Getwd()
 	if err != nil {
 		return nil, contextureerrors.Wrap(err, "get current directory")
 	}
@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/contextureai/contexture/internal/ui"
+	"github.com/urfave/cli/v3"
+)
+
+// ConfigNormalizeAction rewrites the rule IDs of the configuration in the form
+// rules add stores them and merges rules configured more than once under
+// different spellings
+func ConfigNormalizeAction(_ context.Context, cmd *cli.Command, deps *dependencies.Dependencies) error {
+	projectManager := project.NewManagerFromDependencies(deps)
+	isGlobal := cmd.Bool("global")
+
+	var config *domain.Project
+	var configLoad *ConfigLoadResult
+	if isGlobal {
+		var err error
+		config, _, err = loadConfigByScope(projectManager, deps.GetWorkingDir(), true)
+		if err != nil {
+			return err
+		}
+	} else {
+		var err error
+		configLoad, err = LoadProjectConfig(projectManager, deps.GetWorkingDir())
+		if err != nil {
+			return err
+		}
+		config = configLoad.Config
+	}
+
+	changes := project.NormalizeRules(config)
+	if len(changes) == 0 {
+		fmt.Println("Rule IDs are already normalized")
+		return nil
+	}
+
+	theme := ui.DefaultTheme()
+	changedStyle := lipgloss.NewStyle().Foreground(theme.Warning)
+	removedStyle := lipgloss.NewStyle().Foreground(theme.Error)
+	mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+	for _, change := range changes {
+		if change.Removed {
+			fmt.Printf("%s %s %s\n", removedStyle.Render("-"), change.From, mutedStyle.Render("duplicate of "+change.To))
+		} else {
+			fmt.Printf("%s %s %s %s\n", changedStyle.Render("~"), change.From, mutedStyle.Render("→"), change.To)
+		}
+	}
+
+	if cmd.Bool("dry-run") {
+		return nil
+	}
+	if isGlobal {
+		if err := projectManager.SaveGlobalConfig(config); err != nil {
+			return contextureerrors.Wrap(err, "save global config")
+		}
+	} else if err := configLoad.SaveConfig(projectManager); err != nil {
+		return contextureerrors.Wrap(err, "save config")
+	}
+
+	successStyle := lipgloss.NewStyle().Foreground(theme.Success)
+	fmt.Println(successStyle.Render(fmt.Sprintf("✓ Normalized %d rule entries", len(changes))))
+	return nil
+}
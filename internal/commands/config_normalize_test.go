@@ -0,0 +1,40 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigNormalizeAction(t *testing.T) {
+	deps := createTestDependencies().WithWorkingDir(dependencies.StaticWorkingDir("/project"))
+	configPath := "/project/.contexture.yaml"
+	original := `version: 1
+formats:
+  - type: claude
+    enabled: true
+rules:
+  - id: languages/go/testing
+  - id: "[contexture:Languages/go/testing/]"
+    variables:
+      strict: true
+`
+	require.NoError(t, afero.WriteFile(deps.FS, configPath, []byte(original), 0o644))
+
+	require.NoError(t, runConfigCommand(t, deps, ConfigNormalizeAction, "--dry-run"))
+	data, err := afero.ReadFile(deps.FS, configPath)
+	require.NoError(t, err)
+	assert.Equal(t, original, string(data), "a dry run saves nothing")
+
+	require.NoError(t, runConfigCommand(t, deps, ConfigNormalizeAction))
+	data, err = afero.ReadFile(deps.FS, configPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `id: '[contexture:languages/go/testing]'`)
+	assert.NotContains(t, string(data), "Languages")
+	assert.Contains(t, string(data), "strict: true")
+
+	require.NoError(t, runConfigCommand(t, deps, ConfigNormalizeAction), "normalizing again changes nothing")
+}
@@ -0,0 +1,121 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/output"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/contextureai/contexture/internal/ui"
+	"github.com/urfave/cli/v3"
+	"gopkg.in/yaml.v3"
+)
+
+// pathSuggestion points to the syntax of configuration paths
+var pathSuggestion = contextureerrors.Hint(
+	`paths use the keys of the configuration file, such as generation.parallelFetches or formats[type=cursor].enabled`)
+
+// ConfigGetAction prints the value at a configuration path: scalars as
+// plain text, sections as YAML, or anything as JSON with --output json
+func ConfigGetAction(_ context.Context, cmd *cli.Command, deps *dependencies.Dependencies) error {
+	if cmd.Args().Len() != 1 {
+		return contextureerrors.Validation("path", "expected one configuration path").
+			WithSuggestions(pathSuggestion)
+	}
+	projectManager := project.NewManagerFromDependencies(deps)
+	config, _, err := loadConfigByScope(projectManager, deps.GetWorkingDir(), cmd.Bool("global"))
+	if err != nil {
+		return err
+	}
+
+	value, err := project.GetPath(config, cmd.Args().First())
+	if err != nil {
+		return contextureerrors.Wrap(err, "get config value").WithSuggestions(pathSuggestion)
+	}
+
+	if output.Format(cmd.String("output")) == output.FormatJSON {
+		data, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			return contextureerrors.Wrap(err, "marshal config value")
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+	if isScalar(value) {
+		fmt.Println(value)
+		return nil
+	}
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return contextureerrors.Wrap(err, "marshal config value")
+	}
+	fmt.Print(string(data))
+	return nil
+}
+
+// ConfigSetAction stores a value at a configuration path. The value is
+// parsed as YAML into the type the path holds, and the configuration is
+// validated before it is saved.
+func ConfigSetAction(_ context.Context, cmd *cli.Command, deps *dependencies.Dependencies) error {
+	if cmd.Args().Len() != 2 {
+		return contextureerrors.Validation("path", "expected a configuration path and a value").
+			WithSuggestions(contextureerrors.RunCommand("contexture config set generation.parallelFetches 4", "set a value"))
+	}
+	path, raw := cmd.Args().Get(0), cmd.Args().Get(1)
+	projectManager := project.NewManagerFromDependencies(deps)
+
+	isGlobal := cmd.Bool("global")
+	var config *domain.Project
+	var configLoad *ConfigLoadResult
+	if isGlobal {
+		var err error
+		config, _, err = loadConfigByScope(projectManager, deps.GetWorkingDir(), true)
+		if err != nil {
+			return err
+		}
+	} else {
+		var err error
+		configLoad, err = LoadProjectConfig(projectManager, deps.GetWorkingDir())
+		if err != nil {
+			return err
+		}
+		config = configLoad.Config
+	}
+
+	if err := project.SetPath(config, path, raw); err != nil {
+		return contextureerrors.Wrap(err, "set config value").WithSuggestions(pathSuggestion)
+	}
+
+	if isGlobal {
+		err := projectManager.SaveGlobalConfig(config)
+		if err != nil {
+			return contextureerrors.Wrap(err, "save global config")
+		}
+	} else if err := configLoad.SaveConfig(projectManager); err != nil {
+		return contextureerrors.Wrap(err, "save config")
+	}
+
+	theme := ui.DefaultTheme()
+	successStyle := lipgloss.NewStyle().Foreground(theme.Success)
+	fmt.Println(successStyle.Render("✓ Set " + path))
+	return nil
+}
+
+// isScalar reports whether value prints as a single line of text
+func isScalar(value any) bool {
+	if value == nil {
+		return true
+	}
+	switch reflect.TypeOf(value).Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct, reflect.Pointer:
+		return false
+	default:
+		return true
+	}
+}
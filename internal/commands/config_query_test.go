@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+// runConfigCommand runs action as a command with the flags of the config subcommands
+func runConfigCommand(
+	t *testing.T,
+	deps *dependencies.Dependencies,
+	action func(context.Context, *cli.Command, *dependencies.Dependencies) error,
+	args ...string,
+) error {
+	t.Helper()
+
+	var execErr error
+	app := &cli.Command{
+		Name: "config",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "global"},
+			&cli.StringFlag{Name: "output", Value: "default"},
+			&cli.BoolFlag{Name: "diff"},
+			&cli.BoolFlag{Name: "dry-run"},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			execErr = action(ctx, cmd, deps)
+			return nil
+		},
+	}
+	require.NoError(t, app.Run(context.Background(), append([]string{"config"}, args...)))
+	return execErr
+}
+
+func TestConfigGetAndSet(t *testing.T) {
+	deps := createTestDependencies().WithWorkingDir(dependencies.StaticWorkingDir("/project"))
+	require.NoError(t, afero.WriteFile(deps.FS, "/project/.contexture.yaml", []byte(`version: 1
+formats:
+  - type: claude
+    enabled: true
+    wrap: 100
+  - type: cursor
+    enabled: true
+`), 0o644))
+
+	require.NoError(t, runConfigCommand(t, deps, ConfigGetAction, "formats[type=claude].wrap"))
+	require.NoError(t, runConfigCommand(t, deps, ConfigGetAction, "formats[1]", "--output", "json"))
+
+	require.NoError(t, runConfigCommand(t, deps, ConfigSetAction, "formats[type=cursor].enabled", "false"))
+	require.NoError(t, runConfigCommand(t, deps, ConfigSetAction, "generation.parallelFetches", "4"))
+
+	loaded, err := project.NewManager(deps.FS).LoadConfig("/project")
+	require.NoError(t, err)
+	config := loaded.Config
+	assert.False(t, config.Formats[1].Enabled)
+	require.NotNil(t, config.Generation)
+	assert.Equal(t, 4, config.Generation.ParallelFetches)
+	assert.Equal(t, 100, config.Formats[0].Wrap, "format options are kept when saving")
+
+	err = runConfigCommand(t, deps, ConfigSetAction, "generation.parallelFetches", "four")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expected an integer")
+
+	err = runConfigCommand(t, deps, ConfigSetAction, "formats[0].enabled", "false")
+	require.Error(t, err, "the configuration is validated before saving")
+	assert.Contains(t, err.Error(), "at least one format must be enabled")
+
+	err = runConfigCommand(t, deps, ConfigGetAction, "formats[type=windsurf]")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no item with type=windsurf")
+
+	err = runConfigCommand(t, deps, ConfigSetAction, "generation.parallelFetches")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expected a configuration path and a value")
+}
+
+func TestConfigSetGlobal(t *testing.T) {
+	t.Setenv(project.HomeEnv, "/state")
+	deps := createTestDependencies()
+
+	require.NoError(t, runConfigCommand(t, deps, ConfigSetAction, "--global", "aliases.up", "rules update"))
+
+	loaded, err := project.NewManager(deps.FS).LoadGlobalConfig()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"up": "rules update"}, loaded.Config.Aliases)
+}
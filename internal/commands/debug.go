@@ -0,0 +1,74 @@
+// Package commands provides CLI command implementations
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/diagnostics"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/ui"
+	"github.com/spf13/afero"
+	"github.com/urfave/cli/v3"
+)
+
+// DebugCommand implements the debug command
+type DebugCommand struct {
+	fs         afero.Fs
+	workingDir dependencies.WorkingDir
+}
+
+// NewDebugCommand creates a new debug command
+func NewDebugCommand(deps *dependencies.Dependencies) *DebugCommand {
+	return &DebugCommand{
+		fs:         deps.FS,
+		workingDir: deps.GetWorkingDir(),
+	}
+}
+
+// Bundle writes a diagnostics bundle for the current project
+func (c *DebugCommand) Bundle(_ context.Context, cmd *cli.Command) error {
+	currentDir, err := c.workingDir.Getwd()
+	if err != nil {
+		return contextureerrors.Wrap(err, "get current directory")
+	}
+
+	return c.writeBundle(cmd, currentDir)
+}
+
+// writeBundle collects diagnostics for workDir and writes them to the requested directory
+func (c *DebugCommand) writeBundle(cmd *cli.Command, workDir string) error {
+	dir := cmd.String("dir")
+	if dir == "" {
+		dir = os.TempDir()
+	}
+
+	bundle := diagnostics.Collect(c.fs, workDir, os.Args)
+	path, err := bundle.Write(c.fs, dir)
+	if err != nil {
+		return contextureerrors.Wrap(err, "create diagnostics bundle")
+	}
+
+	theme := ui.DefaultTheme()
+	successStyle := lipgloss.NewStyle().Foreground(theme.Success)
+	mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+
+	fmt.Printf("%s %s\n", successStyle.Render("✓ Diagnostics bundle written to"), path)
+	fmt.Println(mutedStyle.Render("Review the bundle, then attach it when filing an issue at " + diagnostics.IssueURL))
+
+	return nil
+}
+
+// DebugAction is the default action when running 'contexture debug'
+func DebugAction(_ context.Context, cmd *cli.Command, _ *dependencies.Dependencies) error {
+	return cli.ShowSubcommandHelp(cmd)
+}
+
+// DebugBundleAction is the CLI action handler for the debug bundle command
+func DebugBundleAction(ctx context.Context, cmd *cli.Command, deps *dependencies.Dependencies) error {
+	debugCmd := NewDebugCommand(deps)
+	return debugCmd.Bundle(ctx, cmd)
+}
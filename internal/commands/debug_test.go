@@ -0,0 +1,53 @@
+// Package commands provides CLI command implementations
+package commands
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+func TestNewDebugCommand(t *testing.T) {
+	deps := createTestDependencies()
+
+	cmd := NewDebugCommand(deps)
+
+	assert.NotNil(t, cmd)
+	assert.NotNil(t, cmd.fs)
+}
+
+func TestDebugCommand_WriteBundle(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, filepath.Join("/project", domain.ConfigFile),
+		[]byte("version: 1\nformats:\n  - type: claude\n    enabled: true\n"), 0o644))
+
+	deps := createTestDependencies()
+	deps.FS = fs
+	debugCmd := NewDebugCommand(deps)
+
+	app := &cli.Command{
+		Name: "bundle",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "dir"},
+		},
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			return debugCmd.writeBundle(cmd, "/project")
+		},
+	}
+
+	require.NoError(t, app.Run(context.Background(), []string{"bundle", "--dir", "/out"}))
+
+	files, err := afero.ReadDir(fs, "/out")
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	data, err := afero.ReadFile(fs, filepath.Join("/out", files[0].Name()))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "type: claude")
+}
@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/contextureai/contexture/internal/dependencies"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/contextureai/contexture/internal/sensitive"
+	"github.com/contextureai/contexture/internal/ui"
+	"github.com/urfave/cli/v3"
+)
+
+// DecryptAction restores the plaintext outputs of the project from the
+// bundle builds encrypt with sensitive.encryptCommand, using
+// sensitive.decryptCommand
+func DecryptAction(ctx context.Context, _ *cli.Command, deps *dependencies.Dependencies) error {
+	currentDir, err := deps.GetWorkingDir().Getwd()
+	if err != nil {
+		return contextureerrors.Wrap(err, "get current directory")
+	}
+	configResult, err := project.NewManagerFromDependencies(deps).LoadConfig(currentDir)
+	if err != nil {
+		return contextureerrors.Wrap(err, "load configuration").
+			WithSuggestions(contextureerrors.RunCommand("contexture init", "create a project configuration"))
+	}
+	config := configResult.Config.Sensitive
+	if config == nil || config.DecryptCommand == "" {
+		return contextureerrors.Validation("sensitive.decryptCommand", "no decrypt command configured").
+			WithSuggestions(contextureerrors.Hint("set sensitive.decryptCommand in the configuration, such as 'age -d -i key.txt'"))
+	}
+
+	bundler := sensitive.NewBundler(deps.FS, currentDir, *config)
+	restored, err := bundler.Restore(ctx)
+	if err != nil {
+		return err
+	}
+
+	successStyle := lipgloss.NewStyle().Foreground(ui.DefaultTheme().Success)
+	bundle, _ := filepath.Rel(currentDir, bundler.Path())
+	fmt.Println(successStyle.Render(fmt.Sprintf("✓ Restored %d output files from %s", len(restored), bundle)))
+	return nil
+}
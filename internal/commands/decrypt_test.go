@@ -0,0 +1,30 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecryptAction(t *testing.T) {
+	deps := createTestDependencies().WithWorkingDir(dependencies.StaticWorkingDir("/project"))
+
+	// Without a configuration
+	require.Error(t, DecryptAction(context.Background(), nil, deps))
+
+	require.NoError(t, afero.WriteFile(deps.FS, "/project/.contexture.yaml",
+		[]byte("version: 1\nformats:\n  - type: claude\n    enabled: true\nsensitive:\n  encryptCommand: age -r age1example\n"), 0o644))
+	err := DecryptAction(context.Background(), nil, deps)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no decrypt command configured")
+
+	require.NoError(t, afero.WriteFile(deps.FS, "/project/.contexture.yaml",
+		[]byte("version: 1\nformats:\n  - type: claude\n    enabled: true\nsensitive:\n  decryptCommand: age -d -i key.txt\n"), 0o644))
+	err = DecryptAction(context.Background(), nil, deps)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "read encrypted bundle")
+}
@@ -0,0 +1,227 @@
+// Package commands provides CLI command implementations
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/contextureai/contexture/internal/rule"
+	"github.com/contextureai/contexture/internal/tui"
+	"github.com/contextureai/contexture/internal/ui"
+	rulesui "github.com/contextureai/contexture/internal/ui/rules"
+	"github.com/spf13/afero"
+	"github.com/urfave/cli/v3"
+)
+
+// defaultEditor is used when neither $VISUAL nor $EDITOR is set
+const defaultEditor = "vi"
+
+// EditCommand implements the edit command
+type EditCommand struct {
+	deps           *dependencies.Dependencies
+	fs             afero.Fs
+	projectManager *project.Manager
+	parser         rule.Parser
+	processor      rule.Processor
+	// openEditor opens a file for editing and waits for the editor to exit; replaced in tests
+	openEditor func(ctx context.Context, path string) error
+	// confirmReopen asks whether to reopen a rule that failed validation; replaced in tests
+	confirmReopen func() (bool, error)
+}
+
+// NewEditCommand creates a new edit command
+func NewEditCommand(deps *dependencies.Dependencies) *EditCommand {
+	return &EditCommand{
+		deps:           deps,
+		fs:             deps.FS,
+		projectManager: project.NewManagerFromDependencies(deps),
+		parser:         rule.NewParser(),
+		processor:      rule.NewProcessor(),
+		openEditor: func(ctx context.Context, path string) error {
+			return runEditor(ctx, editorCommand(deps.GetEnv()), path)
+		},
+		confirmReopen: promptReopen,
+	}
+}
+
+// Execute runs the edit command
+func (c *EditCommand) Execute(ctx context.Context, cmd *cli.Command) error {
+	ruleArg := cmd.Args().First()
+	if ruleArg == "" {
+		return contextureerrors.ValidationErrorf("rule", "rule path is required")
+	}
+	rulePath := strings.TrimSuffix(domain.ExtractRulePath(ruleArg), domain.MarkdownExt)
+
+	isGlobal := cmd.Bool("global")
+	rulesDir, err := c.localRulesDir(isGlobal)
+	if err != nil {
+		return err
+	}
+
+	filePath := rulePath + domain.MarkdownExt
+	if !filepath.IsAbs(filePath) {
+		filePath = filepath.Join(rulesDir, filePath)
+	}
+
+	exists, err := afero.Exists(c.fs, filePath)
+	if err != nil {
+		return contextureerrors.Wrap(err, "check rule file")
+	}
+	if !exists {
+		return contextureerrors.Validation("rule", "local rule not found: "+filePath).
+			WithSuggestions(contextureerrors.RunCommand("contexture rules new "+rulePath, "create the rule"))
+	}
+
+	// The editor waits for a person, so it cannot run non-interactively
+	if err := tui.RequireInteractive("open editor",
+		contextureerrors.Hint("edit "+filePath+" directly, then run 'contexture build'")); err != nil {
+		return err
+	}
+
+	// Keep editing until the rule validates or the user gives up
+	var edited *domain.Rule
+	var content string
+	for {
+		if err := c.openEditor(ctx, filePath); err != nil {
+			return contextureerrors.Wrap(err, "run editor")
+		}
+
+		edited, content, err = c.checkRule(filePath, rulePath)
+		if err == nil {
+			break
+		}
+
+		fmt.Fprintf(os.Stderr, "%s %v\n", lipgloss.NewStyle().Foreground(ui.DefaultTheme().Error).Render("✗"), err)
+		reopen, promptErr := c.confirmReopen()
+		if promptErr != nil {
+			if errors.Is(promptErr, tui.ErrUserCancelled) {
+				reopen = false
+			} else {
+				return promptErr
+			}
+		}
+		if !reopen {
+			return contextureerrors.Validation("rule", fmt.Sprintf("%s is invalid: %v", filePath, err)).
+				WithSuggestions(contextureerrors.RunCommand("contexture edit "+rulePath, "fix the rule"))
+		}
+	}
+
+	if err := rulesui.DisplayRulePreview(edited, content); err != nil {
+		return err
+	}
+
+	if cmd.Bool("no-build") {
+		return nil
+	}
+
+	// Global rules are built into whichever project is being built, so only
+	// rebuild when the current directory holds a project
+	configLoad, err := LoadProjectConfigOptional(c.projectManager, c.deps.GetWorkingDir())
+	if err != nil {
+		return err
+	}
+	if configLoad.Config == nil {
+		log.Debug("No project configuration found, skipping build")
+		return nil
+	}
+
+	fmt.Println()
+	if err := NewBuildCommand(c.deps).Execute(ctx, &cli.Command{}); err != nil {
+		return contextureerrors.Partial("generate rules", err).
+			WithSuggestions(contextureerrors.RunCommand("contexture build", "generate the rule files"))
+	}
+
+	return nil
+}
+
+// localRulesDir returns the directory holding the local rules being edited
+func (c *EditCommand) localRulesDir(isGlobal bool) (string, error) {
+	if isGlobal {
+		globalDir, err := c.projectManager.GlobalConfigDir()
+		if err != nil {
+			return "", contextureerrors.Wrap(err, "get global config directory")
+		}
+		return filepath.Join(globalDir, domain.LocalRulesDir), nil
+	}
+
+	configLoad, err := LoadProjectConfig(c.projectManager, c.deps.GetWorkingDir())
+	if err != nil {
+		return "", err
+	}
+	return c.projectManager.LocalRulesDir(configLoad.ConfigResult)
+}
+
+// checkRule parses the rule file and renders its template with default variables,
+// returning the rule and rendered content
+func (c *EditCommand) checkRule(filePath, rulePath string) (*domain.Rule, string, error) {
+	data, err := afero.ReadFile(c.fs, filePath)
+	if err != nil {
+		return nil, "", contextureerrors.Wrap(err, "read rule file")
+	}
+
+	parsed, err := c.parser.ParseRule(string(data), rule.Metadata{
+		ID:       fmt.Sprintf("[contexture(local):%s]", rulePath),
+		FilePath: rulePath,
+		Source:   "local",
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	processed, err := c.processor.ProcessRule(parsed, &domain.RuleContext{})
+	if err != nil {
+		return nil, "", err
+	}
+	content, err := c.processor.ProcessTemplate(parsed.Content, processed.Variables)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return parsed, content, nil
+}
+
+// editorCommand returns the user's editor command from env split into its arguments
+func editorCommand(env dependencies.Environment) []string {
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(env.Getenv(name)); len(fields) > 0 {
+			return fields
+		}
+	}
+	return []string{defaultEditor}
+}
+
+// runEditor opens path in the editor command attached to the terminal
+func runEditor(ctx context.Context, editorArgs []string, path string) error {
+	args := append(slices.Clone(editorArgs), path)
+	editor := exec.CommandContext(ctx, args[0], args[1:]...)
+	editor.Stdin = os.Stdin
+	editor.Stdout = os.Stdout
+	editor.Stderr = os.Stderr
+	return editor.Run()
+}
+
+// promptReopen asks whether to reopen the editor after a validation failure
+func promptReopen() (bool, error) {
+	return tui.Confirm(tui.ConfirmOptions{
+		Title:   "Reopen the editor to fix the rule?",
+		Default: true,
+	})
+}
+
+// EditAction is the CLI action handler for the edit command
+func EditAction(ctx context.Context, cmd *cli.Command, deps *dependencies.Dependencies) error {
+	editCmd := NewEditCommand(deps)
+	return editCmd.Execute(ctx, cmd)
+}
@@ -0,0 +1,167 @@
+// Package commands provides CLI command implementations
+package commands
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/dependencies"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+const validEditedRule = `---
+title: Team Style
+description: House style for the team
+tags: [style]
+trigger: manual
+variables:
+  indent: tabs
+---
+
+# Team Style
+
+Use {{ .indent }} for indentation.
+`
+
+// runEditCommand runs the edit command with the given arguments and returns its error
+func runEditCommand(t *testing.T, editCmd *EditCommand, args ...string) error {
+	t.Helper()
+
+	var execErr error
+	app := &cli.Command{
+		Name: "edit",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "global"},
+			&cli.BoolFlag{Name: "no-build"},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			execErr = editCmd.Execute(ctx, cmd)
+			return nil
+		},
+	}
+
+	require.NoError(t, app.Run(context.Background(), append([]string{"edit"}, args...)))
+	return execErr
+}
+
+// setupGlobalRule writes a global local rule under a temporary home directory
+func setupGlobalRule(t *testing.T, fs afero.Fs, content string) string {
+	t.Helper()
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(project.XDGConfigHomeEnv, "")
+
+	rulePath := filepath.Join(home, ".config", "contexture", "rules", "team", "style.md")
+	require.NoError(t, fs.MkdirAll(filepath.Dir(rulePath), 0o755))
+	require.NoError(t, afero.WriteFile(fs, rulePath, []byte(content), 0o644))
+	return rulePath
+}
+
+func TestNewEditCommand(t *testing.T) {
+	deps := createTestDependencies()
+
+	cmd := NewEditCommand(deps)
+
+	assert.NotNil(t, cmd)
+	assert.NotNil(t, cmd.projectManager)
+	assert.NotNil(t, cmd.parser)
+	assert.NotNil(t, cmd.processor)
+	assert.NotNil(t, cmd.openEditor)
+	assert.NotNil(t, cmd.confirmReopen)
+}
+
+func TestEditCommand_RequiresRule(t *testing.T) {
+	editCmd := NewEditCommand(createTestDependencies())
+
+	err := runEditCommand(t, editCmd)
+
+	require.Error(t, err)
+	assert.Equal(t, int(contextureerrors.ExitValidation), contextureerrors.ExitCodeFor(err))
+}
+
+func TestEditCommand_RuleNotFound(t *testing.T) {
+	deps := createTestDependencies()
+	setupGlobalRule(t, deps.FS, validEditedRule)
+	editCmd := NewEditCommand(deps)
+	editCmd.openEditor = func(context.Context, string) error {
+		t.Fatal("editor should not open for a missing rule")
+		return nil
+	}
+
+	err := runEditCommand(t, editCmd, "--global", "--no-build", "team/missing")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "local rule not found")
+}
+
+func TestEditCommand_ValidEdit(t *testing.T) {
+	deps := createTestDependencies()
+	rulePath := setupGlobalRule(t, deps.FS, "---\ntitle: Draft\n---\n")
+	editCmd := NewEditCommand(deps)
+
+	var opened []string
+	editCmd.openEditor = func(_ context.Context, path string) error {
+		opened = append(opened, path)
+		return afero.WriteFile(deps.FS, path, []byte(validEditedRule), 0o644)
+	}
+
+	err := runEditCommand(t, editCmd, "--global", "--no-build", "team/style.md")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{rulePath}, opened)
+}
+
+func TestEditCommand_InvalidEdit(t *testing.T) {
+	t.Run("reopens until valid", func(t *testing.T) {
+		deps := createTestDependencies()
+		setupGlobalRule(t, deps.FS, validEditedRule)
+		editCmd := NewEditCommand(deps)
+
+		edits := []string{"---\ntitle: [unclosed\n---\n", validEditedRule}
+		editCmd.openEditor = func(_ context.Context, path string) error {
+			content := edits[0]
+			edits = edits[1:]
+			return afero.WriteFile(deps.FS, path, []byte(content), 0o644)
+		}
+		prompts := 0
+		editCmd.confirmReopen = func() (bool, error) {
+			prompts++
+			return true, nil
+		}
+
+		err := runEditCommand(t, editCmd, "--global", "--no-build", "team/style")
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, prompts)
+		assert.Empty(t, edits)
+	})
+
+	t.Run("fails when not reopened", func(t *testing.T) {
+		deps := createTestDependencies()
+		setupGlobalRule(t, deps.FS, validEditedRule)
+		editCmd := NewEditCommand(deps)
+		editCmd.openEditor = func(_ context.Context, path string) error {
+			return afero.WriteFile(deps.FS, path, []byte("---\ntitle: [unclosed\n---\n"), 0o644)
+		}
+		editCmd.confirmReopen = func() (bool, error) { return false, nil }
+
+		err := runEditCommand(t, editCmd, "--global", "--no-build", "team/style")
+
+		require.Error(t, err)
+		assert.Equal(t, int(contextureerrors.ExitValidation), contextureerrors.ExitCodeFor(err))
+	})
+}
+
+func TestEditorCommand(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, []string{defaultEditor}, editorCommand(dependencies.MapEnvironment{}))
+	assert.Equal(t, []string{"code", "--wait"}, editorCommand(dependencies.MapEnvironment{"EDITOR": "code --wait"}))
+	assert.Equal(t, []string{"nvim"}, editorCommand(dependencies.MapEnvironment{"EDITOR": "code --wait", "VISUAL": "nvim"}))
+}
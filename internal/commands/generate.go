@@ -0,0 +1,153 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+	"github.com/contextureai/contexture/internal/dependencies"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/facts"
+	"github.com/contextureai/contexture/internal/gitconventions"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/contextureai/contexture/internal/ui"
+	"github.com/spf13/afero"
+	"github.com/urfave/cli/v3"
+)
+
+// GenerateAction is the CLI action handler for the generate command
+func GenerateAction(_ context.Context, cmd *cli.Command, _ *dependencies.Dependencies) error {
+	return cli.ShowSubcommandHelp(cmd)
+}
+
+// GenerateEnvRuleAction writes the project facts rule, describing the build
+// and test commands, layout, and tooling of the project, to the local rules
+// directory
+func GenerateEnvRuleAction(_ context.Context, cmd *cli.Command, deps *dependencies.Dependencies) error {
+	projectManager := project.NewManagerFromDependencies(deps)
+	configLoad, err := LoadProjectConfig(projectManager, deps.GetWorkingDir())
+	if err != nil {
+		return err
+	}
+
+	if cmd.Bool("dry-run") {
+		detected, err := facts.Detect(deps.FS, configLoad.CurrentDir)
+		if err != nil {
+			return contextureerrors.Wrap(err, "detect project facts")
+		}
+		fmt.Print(facts.Render(detected))
+		return nil
+	}
+
+	rulesDir, err := projectManager.LocalRulesDir(configLoad.ConfigResult)
+	if err != nil {
+		return err
+	}
+	path, changed, err := facts.Write(deps.FS, configLoad.CurrentDir, rulesDir, cmd.Bool("force"))
+	if err != nil {
+		return err
+	}
+
+	theme := ui.DefaultTheme()
+	mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+	if !changed {
+		fmt.Println(mutedStyle.Render(path + " is up to date"))
+		return nil
+	}
+	successStyle := lipgloss.NewStyle().Foreground(theme.Success)
+	fmt.Println(successStyle.Render("✓ Wrote " + path))
+	if !configLoad.Config.GetGeneration().RefreshEnvRule {
+		fmt.Println(mutedStyle.Render("Set generation.refreshEnvRule to keep it up to date on every build"))
+	}
+	fmt.Println(mutedStyle.Render("Run 'contexture build' to add it to the generated files"))
+	return nil
+}
+
+// refreshEnvRule rewrites the project facts rule before a build when the
+// project configuration sets generation.refreshEnvRule. Failures are logged,
+// leaving the rule as it was.
+func refreshEnvRule(fs afero.Fs, projectManager *project.Manager, currentDir string) {
+	configResult, err := projectManager.LoadConfig(currentDir)
+	if err != nil || !configResult.Config.GetGeneration().RefreshEnvRule {
+		return
+	}
+	rulesDir, err := projectManager.LocalRulesDir(configResult)
+	if err != nil {
+		return
+	}
+	path, changed, err := facts.Write(fs, currentDir, rulesDir, false)
+	if err != nil {
+		log.Warn("Failed to refresh the project facts rule", "path", path, "error", err)
+		return
+	}
+	if changed {
+		log.Info("Refreshed the project facts rule", "path", path)
+	}
+}
+
+// GenerateGitRuleAction writes the git conventions rule, describing the
+// commit message and branch naming conventions of the project, to the local
+// rules directory
+func GenerateGitRuleAction(_ context.Context, cmd *cli.Command, deps *dependencies.Dependencies) error {
+	projectManager := project.NewManagerFromDependencies(deps)
+	configLoad, err := LoadProjectConfig(projectManager, deps.GetWorkingDir())
+	if err != nil {
+		return err
+	}
+	gitRepo := newOpenRepository(deps.FS)
+
+	if cmd.Bool("dry-run") {
+		detected, err := gitconventions.Detect(deps.FS, gitRepo, configLoad.CurrentDir)
+		if err != nil {
+			return contextureerrors.Wrap(err, "detect git conventions")
+		}
+		fmt.Print(gitconventions.Render(detected))
+		return nil
+	}
+
+	rulesDir, err := projectManager.LocalRulesDir(configLoad.ConfigResult)
+	if err != nil {
+		return err
+	}
+	path, changed, err := gitconventions.Write(deps.FS, gitRepo, configLoad.CurrentDir, rulesDir, cmd.Bool("force"))
+	if err != nil {
+		return err
+	}
+
+	theme := ui.DefaultTheme()
+	mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+	if !changed {
+		fmt.Println(mutedStyle.Render(path + " is up to date"))
+		return nil
+	}
+	successStyle := lipgloss.NewStyle().Foreground(theme.Success)
+	fmt.Println(successStyle.Render("✓ Wrote " + path))
+	if !configLoad.Config.GetGeneration().RefreshGitRule {
+		fmt.Println(mutedStyle.Render("Set generation.refreshGitRule to keep it up to date on every build"))
+	}
+	fmt.Println(mutedStyle.Render("Run 'contexture build' to add it to the generated files"))
+	return nil
+}
+
+// refreshGitRule rewrites the git conventions rule before a build when the
+// project configuration sets generation.refreshGitRule. Failures are logged,
+// leaving the rule as it was.
+func refreshGitRule(fs afero.Fs, projectManager *project.Manager, currentDir string) {
+	configResult, err := projectManager.LoadConfig(currentDir)
+	if err != nil || !configResult.Config.GetGeneration().RefreshGitRule {
+		return
+	}
+	rulesDir, err := projectManager.LocalRulesDir(configResult)
+	if err != nil {
+		return
+	}
+	path, changed, err := gitconventions.Write(fs, newOpenRepository(fs), currentDir, rulesDir, false)
+	if err != nil {
+		log.Warn("Failed to refresh the git conventions rule", "path", path, "error", err)
+		return
+	}
+	if changed {
+		log.Info("Refreshed the git conventions rule", "path", path)
+	}
+}
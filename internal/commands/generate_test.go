@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/contextureai/contexture/internal/facts"
+	"github.com/contextureai/contexture/internal/gitconventions"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshEnvRule(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	manager := project.NewManager(fs)
+	rulePath := "/work/app/rules/" + facts.RuleFile
+	require.NoError(t, afero.WriteFile(fs, "/work/app/go.mod", []byte("module github.com/acme/app\n"), 0o644))
+
+	require.NoError(t, afero.WriteFile(fs, "/work/app/.contexture.yaml", []byte("version: 1\nformats:\n  - type: claude\n    enabled: true\n"), 0o644))
+	refreshEnvRule(fs, manager, "/work/app")
+	exists, err := afero.Exists(fs, rulePath)
+	require.NoError(t, err)
+	assert.False(t, exists, "builds only write the rule with refreshEnvRule")
+
+	require.NoError(t, afero.WriteFile(fs, "/work/app/.contexture.yaml", []byte("version: 1\nformats:\n  - type: claude\n    enabled: true\ngeneration:\n  refreshEnvRule: true\n"), 0o644))
+	refreshEnvRule(fs, manager, "/work/app")
+	content, err := afero.ReadFile(fs, rulePath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "module `github.com/acme/app`")
+}
+
+func TestRefreshGitRule(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	manager := project.NewManager(fs)
+	rulePath := "/work/app/rules/" + gitconventions.RuleFile
+	require.NoError(t, afero.WriteFile(fs, "/work/app/.commitlintrc.json", []byte(`{"extends": ["@commitlint/config-conventional"]}`), 0o644))
+
+	require.NoError(t, afero.WriteFile(fs, "/work/app/.contexture.yaml", []byte("version: 1\nformats:\n  - type: claude\n    enabled: true\n"), 0o644))
+	refreshGitRule(fs, manager, "/work/app")
+	exists, err := afero.Exists(fs, rulePath)
+	require.NoError(t, err)
+	assert.False(t, exists, "builds only write the rule with refreshGitRule")
+
+	require.NoError(t, afero.WriteFile(fs, "/work/app/.contexture.yaml", []byte("version: 1\nformats:\n  - type: claude\n    enabled: true\ngeneration:\n  refreshGitRule: true\n"), 0o644))
+	refreshGitRule(fs, manager, "/work/app")
+	content, err := afero.ReadFile(fs, rulePath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "Follow Conventional Commits")
+}
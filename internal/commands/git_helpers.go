@@ -1,7 +1,11 @@
 package commands
 
 import (
+	"github.com/contextureai/contexture/internal/cache"
+	"github.com/contextureai/contexture/internal/dependencies"
 	"github.com/contextureai/contexture/internal/git"
+	"github.com/contextureai/contexture/internal/provider"
+	"github.com/contextureai/contexture/internal/rule"
 	"github.com/spf13/afero"
 )
 
@@ -11,3 +15,24 @@ func newOpenRepository(fs afero.Fs) git.Repository {
 	config.AllowedHosts = nil
 	return git.NewClient(fs, config)
 }
+
+// fetcherConfig returns the rule fetcher configuration for the working
+// directory and environment of deps
+func fetcherConfig(deps *dependencies.Dependencies) rule.FetcherConfig {
+	return rule.FetcherConfig{WorkingDir: deps.GetWorkingDir(), Env: deps.GetEnv()}
+}
+
+// newProviderCache returns a repository cache in the cache directory of env
+// whose clones fail over to the mirrors of the providers in registry
+func newProviderCache(
+	fs afero.Fs,
+	env dependencies.Environment,
+	repository git.Repository,
+	registry *provider.Registry,
+) *cache.SimpleCache {
+	repositoryCache := cache.NewSimpleCache(fs, env, repository)
+	if registry != nil {
+		repositoryCache.SetMirrors(registry)
+	}
+	return repositoryCache
+}
@@ -1,8 +1,7 @@
 package commands
 
 import (
-	"os"
-
+	"github.com/contextureai/contexture/internal/dependencies"
 	"github.com/contextureai/contexture/internal/domain"
 	contextureerrors "github.com/contextureai/contexture/internal/errors"
 	"github.com/contextureai/contexture/internal/project"
@@ -10,7 +9,11 @@ import (
 
 // loadConfigByScope loads either global or project configuration based on the isGlobal flag
 // Returns the config, config path, and any error encountered
-func loadConfigByScope(projectManager *project.Manager, isGlobal bool) (*domain.Project, string, error) {
+func loadConfigByScope(
+	projectManager *project.Manager,
+	workingDir dependencies.WorkingDir,
+	isGlobal bool,
+) (*domain.Project, string, error) {
 	if isGlobal {
 		// Initialize global config if needed (only for add/update commands)
 		err := projectManager.InitializeGlobalConfig()
@@ -30,7 +33,7 @@ func loadConfigByScope(projectManager *project.Manager, isGlobal bool) (*domain.
 	}
 
 	// Get current directory and load project configuration
-	currentDir, err := os.Getwd()
+	currentDir, err := workingDir.Getwd()
 	if err != nil {
 		return nil, "", contextureerrors.Wrap(err, "get current directory")
 	}
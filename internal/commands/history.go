@@ -0,0 +1,96 @@
+package commands
+
+import (
+	"path/filepath"
+
+	"github.com/charmbracelet/log"
+	"github.com/contextureai/contexture/internal/cache"
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/history"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/contextureai/contexture/internal/staging"
+	"github.com/contextureai/contexture/internal/tui"
+	"github.com/contextureai/contexture/internal/ui"
+	"github.com/spf13/afero"
+)
+
+// maxRecentSuggestions is the number of recently used rules offered when no
+// rule IDs are given to add
+const maxRecentSuggestions = 10
+
+// historyStore returns the store of the rules the user added or viewed
+// recently, kept in the state directory
+func historyStore(deps *dependencies.Dependencies) *history.Store {
+	path := filepath.Join(cache.StateDir(deps.GetEnv()), history.File)
+	migrateHistory(deps, path)
+	return history.NewStore(deps.FS, path)
+}
+
+// migrateHistory moves the history that earlier versions kept in the global
+// contexture directory to path, when path does not exist yet
+func migrateHistory(deps *dependencies.Dependencies, path string) {
+	if exists, err := afero.Exists(deps.FS, path); err != nil || exists {
+		return
+	}
+	dir, err := project.NewManagerFromDependencies(deps).GlobalConfigDir()
+	if err != nil {
+		return
+	}
+	legacy := filepath.Join(dir, history.File)
+	data, err := afero.ReadFile(deps.FS, legacy)
+	if err != nil {
+		return
+	}
+	if err := deps.FS.MkdirAll(filepath.Dir(path), 0o755); err == nil {
+		err = staging.WriteFile(deps.FS, path, data, 0o644)
+	}
+	if err != nil {
+		log.Debug("Failed to move rule history", "from", legacy, "to", path, "error", err)
+		return
+	}
+	_ = deps.FS.Remove(legacy)
+}
+
+// recordRuleUse adds rules to the user's history. Failures are only logged,
+// as the history only serves suggestions.
+func recordRuleUse(deps *dependencies.Dependencies, ruleIDs ...string) {
+	if err := historyStore(deps).Record(ruleIDs...); err != nil {
+		log.Debug("Failed to record rule history", "error", err)
+	}
+}
+
+// RecentRules returns the IDs of up to limit rules the user added or viewed
+// recently, most recent first. An unreadable history has no rules.
+func RecentRules(deps *dependencies.Dependencies, limit int) []string {
+	entries, err := historyStore(deps).Recent(limit)
+	if err != nil {
+		log.Debug("Failed to read rule history", "error", err)
+		return nil
+	}
+	ids := make([]string, len(entries))
+	for i, entry := range entries {
+		ids[i] = entry.RuleID
+	}
+	return ids
+}
+
+// selectRecentRules asks which of the rules used recently to add. It returns
+// nothing without a history, when prompts are disabled, or without a terminal.
+func selectRecentRules(deps *dependencies.Dependencies) ([]string, error) {
+	if tui.IsNonInteractive() || !ui.StdinIsTerminal() {
+		return nil, nil
+	}
+	recent := RecentRules(deps, maxRecentSuggestions)
+	if len(recent) == 0 {
+		return nil, nil
+	}
+	options := make([]tui.SelectOption, len(recent))
+	for i, id := range recent {
+		options[i] = tui.SelectOption{Label: id, Value: id}
+	}
+	return tui.MultiSelect(tui.MultiSelectOptions{
+		Title:       "Recently used",
+		Description: "Select rules to add",
+		Options:     options,
+	})
+}
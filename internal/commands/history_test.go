@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/cache"
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/contextureai/contexture/internal/tui"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newHistoryDeps returns dependencies keeping state in /state and the global
+// configuration in /config
+func newHistoryDeps() *dependencies.Dependencies {
+	deps := dependencies.NewForTesting(context.Background())
+	deps.Env = dependencies.MapEnvironment{
+		"HOME":                "/home/user",
+		cache.XDGStateHomeEnv: "/state",
+		project.HomeEnv:       "/config",
+	}
+	return deps
+}
+
+func TestRuleHistory(t *testing.T) {
+	deps := newHistoryDeps()
+
+	assert.Empty(t, RecentRules(deps, 0))
+
+	recordRuleUse(deps, "languages/go/testing")
+	recordRuleUse(deps, "@mycompany/security/auth", "languages/go/testing")
+	assert.Equal(t, []string{"@mycompany/security/auth", "languages/go/testing"}, RecentRules(deps, 0))
+	assert.Equal(t, []string{"@mycompany/security/auth"}, RecentRules(deps, 1))
+
+	exists, err := afero.Exists(deps.FS, "/state/contexture/history.json")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	tui.SetNonInteractive(true)
+	t.Cleanup(func() { tui.SetNonInteractive(false) })
+	selected, err := selectRecentRules(deps)
+	require.NoError(t, err)
+	assert.Empty(t, selected, "recent rules are not offered without prompts")
+}
+
+func TestRuleHistory_Migrates(t *testing.T) {
+	t.Parallel()
+	deps := newHistoryDeps()
+	legacy := `[{"ruleId":"languages/go/testing","usedAt":"2026-03-02T12:00:00Z"}]`
+	require.NoError(t, afero.WriteFile(deps.FS, "/config/history.json", []byte(legacy), 0o644))
+
+	assert.Equal(t, []string{"languages/go/testing"}, RecentRules(deps, 0))
+
+	exists, err := afero.Exists(deps.FS, "/config/history.json")
+	require.NoError(t, err)
+	assert.False(t, exists, "the history of earlier versions is moved")
+}
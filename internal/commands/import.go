@@ -0,0 +1,471 @@
+// Package commands provides CLI command implementations
+package commands
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/contextureai/contexture/internal/rule"
+	"github.com/contextureai/contexture/internal/ui"
+	"github.com/spf13/afero"
+	"github.com/urfave/cli/v3"
+	"gopkg.in/yaml.v3"
+)
+
+// Import sources accepted by the import command
+const (
+	importSourceClaude  = "claude"
+	importSourceCursor  = "cursor"
+	importSourceCopilot = "copilot"
+)
+
+// importDefaultPaths are the files read for each source when no path is given
+var importDefaultPaths = map[string]string{
+	importSourceClaude:  domain.ClaudeOutputFile,
+	importSourceCursor:  domain.CursorOutputDir,
+	importSourceCopilot: filepath.FromSlash(domain.CopilotOutputFile),
+}
+
+// importSlugPattern matches runs of characters not allowed in rule file names
+var importSlugPattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+// importedRule is a rule split out of an existing assistant file
+type importedRule struct {
+	name        string
+	title       string
+	description string
+	trigger     *domain.RuleTrigger
+	content     string
+}
+
+// ImportCommand implements the import command
+type ImportCommand struct {
+	fs             afero.Fs
+	projectManager *project.Manager
+	// build regenerates the project's outputs; replaced in tests
+	build      func(ctx context.Context) error
+	workingDir dependencies.WorkingDir
+}
+
+// NewImportCommand creates a new import command
+func NewImportCommand(deps *dependencies.Dependencies) *ImportCommand {
+	return &ImportCommand{
+		fs:             deps.FS,
+		projectManager: project.NewManagerFromDependencies(deps),
+		build: func(ctx context.Context) error {
+			return NewBuildCommand(deps).Execute(ctx, &cli.Command{})
+		},
+		workingDir: deps.GetWorkingDir(),
+	}
+}
+
+// Execute runs the import command
+func (c *ImportCommand) Execute(ctx context.Context, cmd *cli.Command) error {
+	currentDir, err := c.workingDir.Getwd()
+	if err != nil {
+		return contextureerrors.Wrap(err, "get current directory")
+	}
+
+	return c.run(ctx, cmd, currentDir)
+}
+
+// run imports the file named on the command line into the project in basePath
+func (c *ImportCommand) run(ctx context.Context, cmd *cli.Command, basePath string) error {
+	source := strings.ToLower(cmd.Args().First())
+	if _, ok := importDefaultPaths[source]; !ok {
+		return contextureerrors.ValidationErrorf("source", "unsupported import source %q (expected claude, cursor, or copilot)", source)
+	}
+
+	configResult, err := c.projectManager.LoadConfig(basePath)
+	if err != nil {
+		return contextureerrors.Wrap(err, "load project configuration").
+			WithSuggestions(contextureerrors.RunCommand("contexture init", "create a project configuration"))
+	}
+
+	return c.importSource(ctx, configResult, basePath, source, cmd.Args().Get(1), cmd.Bool("force"), !cmd.Bool("no-build"))
+}
+
+// importSource splits the source file at sourcePath, or the source's default
+// path when empty, into local rules of the project in basePath. With build set
+// the outputs are regenerated to replace the file.
+func (c *ImportCommand) importSource(
+	ctx context.Context,
+	configResult *domain.ConfigResult,
+	basePath, source, sourcePath string,
+	force, build bool,
+) error {
+	if sourcePath == "" {
+		sourcePath = importDefaultPaths[source]
+	}
+	if !filepath.IsAbs(sourcePath) {
+		sourcePath = filepath.Join(basePath, sourcePath)
+	}
+
+	rules, sourceFiles, err := c.readSource(source, sourcePath)
+	if err != nil {
+		return err
+	}
+	if len(rules) == 0 {
+		return contextureerrors.ValidationErrorf("source", "no rules found in %s", sourcePath)
+	}
+
+	rulesDir, err := c.projectManager.LocalRulesDir(configResult)
+	if err != nil {
+		return err
+	}
+	written, err := c.writeRules(filepath.Join(rulesDir, source), rules, force)
+	if err != nil {
+		return err
+	}
+
+	theme := ui.DefaultTheme()
+	successStyle := lipgloss.NewStyle().Foreground(theme.Success)
+	mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+	fmt.Println(successStyle.Render(fmt.Sprintf("✓ Imported %d rule(s) from %s", len(written), sourcePath)))
+	for _, path := range written {
+		if relPath, err := filepath.Rel(basePath, path); err == nil {
+			path = relPath
+		}
+		fmt.Println(mutedStyle.Render("  " + path))
+	}
+
+	formatType := domain.FormatType(source)
+	if enableImportFormat(configResult.Config, formatType) {
+		if err := c.projectManager.SaveConfig(configResult.Config, configResult.Location, basePath); err != nil {
+			return contextureerrors.Wrap(err, "save configuration")
+		}
+		fmt.Println(successStyle.Render(fmt.Sprintf("✓ Enabled %s format", formatType)))
+	}
+
+	// Generated Cursor files are named after the rules, so the hand-written
+	// files would be left beside them; Claude's and Copilot's single files are overwritten
+	if source == importSourceCursor {
+		for _, path := range sourceFiles {
+			if err := c.fs.Remove(path); err != nil {
+				return contextureerrors.WithOpf("remove imported file", "%s: %w", path, err)
+			}
+		}
+	}
+
+	if !build {
+		return nil
+	}
+	fmt.Println()
+	if err := c.build(ctx); err != nil {
+		return contextureerrors.Wrap(err, "build rules")
+	}
+	return nil
+}
+
+// readSource splits the file or directory at sourcePath into rules and
+// returns the files they were read from
+func (c *ImportCommand) readSource(source, sourcePath string) ([]importedRule, []string, error) {
+	info, err := c.fs.Stat(sourcePath)
+	if err != nil {
+		return nil, nil, contextureerrors.WithOpf("read import source", "%s: %w", sourcePath, err)
+	}
+
+	var files []string
+	if info.IsDir() {
+		entries, err := afero.ReadDir(c.fs, sourcePath)
+		if err != nil {
+			return nil, nil, contextureerrors.WithOpf("read import source", "%s: %w", sourcePath, err)
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".mdc") {
+				files = append(files, filepath.Join(sourcePath, entry.Name()))
+			}
+		}
+	} else {
+		files = []string{sourcePath}
+	}
+
+	var rules []importedRule
+	var imported []string
+	for _, path := range files {
+		data, err := afero.ReadFile(c.fs, path)
+		if err != nil {
+			return nil, nil, contextureerrors.WithOpf("read import source", "%s: %w", path, err)
+		}
+		content := strings.ReplaceAll(string(data), "\r\n", "\n")
+
+		if isGeneratedContent(content) {
+			if info.IsDir() {
+				// Files from a previous build are regenerated, not imported
+				continue
+			}
+			return nil, nil, contextureerrors.ValidationErrorf("source", "%s was generated by contexture and has nothing to import", path)
+		}
+
+		if source == importSourceCursor && strings.HasSuffix(path, ".mdc") {
+			importedMDC, err := splitMDCFile(path, content)
+			if err != nil {
+				return nil, nil, err
+			}
+			rules = append(rules, importedMDC)
+		} else {
+			rules = append(rules, splitMarkdownSections(content)...)
+		}
+		imported = append(imported, path)
+	}
+
+	return rules, imported, nil
+}
+
+// writeRules writes each rule to dir with generated frontmatter and returns
+// the written paths. Existing rule files are only replaced when force is set.
+func (c *ImportCommand) writeRules(dir string, rules []importedRule, force bool) ([]string, error) {
+	used := make(map[string]int)
+	written := make([]string, 0, len(rules))
+
+	for _, imported := range rules {
+		name := imported.name
+		if name == "" {
+			name = "rule"
+		}
+		// Sections sharing a heading get numbered file names
+		used[name]++
+		if count := used[name]; count > 1 {
+			name = fmt.Sprintf("%s-%d", name, count)
+		}
+
+		path := filepath.Join(dir, name+domain.MarkdownExt)
+		exists, err := afero.Exists(c.fs, path)
+		if err != nil {
+			return nil, contextureerrors.Wrap(err, "check rule file")
+		}
+		if exists && !force {
+			return nil, contextureerrors.Validation("rule", fmt.Sprintf("rule file %s already exists", path)).
+				WithSuggestions(contextureerrors.RunCommand("contexture import --force", "replace the existing rule files"))
+		}
+
+		content, err := importedRuleContent(imported, filepath.Base(dir))
+		if err != nil {
+			return nil, err
+		}
+		if err := c.fs.MkdirAll(dir, 0o755); err != nil {
+			return nil, contextureerrors.Wrap(err, "create rules directory")
+		}
+		if err := afero.WriteFile(c.fs, path, []byte(content), 0o644); err != nil {
+			return nil, contextureerrors.WithOpf("write rule file", "%s: %w", path, err)
+		}
+		written = append(written, path)
+	}
+
+	return written, nil
+}
+
+// importedRuleContent renders an imported rule as a local rule file
+func importedRuleContent(imported importedRule, source string) (string, error) {
+	description := imported.description
+	if description == "" {
+		description = rule.DescriptionFromContent(imported.content)
+	}
+	if description == "" {
+		description = imported.title
+	}
+
+	frontmatter := struct {
+		Title       string              `yaml:"title"`
+		Description string              `yaml:"description"`
+		Tags        []string            `yaml:"tags"`
+		Trigger     *domain.RuleTrigger `yaml:"trigger"`
+	}{
+		Title:       rule.ShortenTitle(imported.title),
+		Description: description,
+		Tags:        []string{"imported", source},
+		Trigger:     imported.trigger,
+	}
+	yamlBytes, err := yaml.Marshal(frontmatter)
+	if err != nil {
+		return "", contextureerrors.Wrap(err, "marshal frontmatter")
+	}
+
+	var sb strings.Builder
+	sb.WriteString("---\n")
+	sb.Write(yamlBytes)
+	sb.WriteString("---\n\n")
+	sb.WriteString(imported.content)
+	sb.WriteString("\n")
+	return sb.String(), nil
+}
+
+// splitMarkdownSections splits a hand-written context file into one rule per
+// section. Level-two headings split the file when it has any, otherwise
+// level-one headings do; text before the first section becomes its own rule
+// under the document title.
+func splitMarkdownSections(content string) []importedRule {
+	lines := strings.Split(content, "\n")
+	headings := markdownHeadingLines(lines)
+
+	level := "# "
+	for _, i := range headings {
+		if strings.HasPrefix(lines[i], "## ") {
+			level = "## "
+			break
+		}
+	}
+
+	documentTitle := "Project Instructions"
+	start := 0
+	if level == "## " && strings.HasPrefix(lines[headings[0]], "# ") {
+		documentTitle = strings.TrimSpace(strings.TrimPrefix(lines[headings[0]], "# "))
+		start = headings[0] + 1
+	}
+
+	var rules []importedRule
+	title := documentTitle
+	var body []string
+	flush := func() {
+		text := strings.TrimSpace(strings.Join(body, "\n"))
+		if text != "" {
+			rules = append(rules, importedRule{
+				name:    importRuleName(title),
+				title:   title,
+				trigger: &domain.RuleTrigger{Type: domain.TriggerAlways},
+				content: text,
+			})
+		}
+		body = nil
+	}
+
+	isHeading := make(map[int]bool, len(headings))
+	for _, i := range headings {
+		isHeading[i] = true
+	}
+	for i := start; i < len(lines); i++ {
+		if isHeading[i] && strings.HasPrefix(lines[i], level) {
+			flush()
+			title = strings.TrimSpace(strings.TrimPrefix(lines[i], level))
+			continue
+		}
+		body = append(body, lines[i])
+	}
+	flush()
+
+	return rules
+}
+
+// markdownHeadingLines returns the indexes of heading lines outside code blocks
+func markdownHeadingLines(lines []string) []int {
+	var headings []int
+	inFence := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if !inFence && (strings.HasPrefix(line, "# ") || strings.HasPrefix(line, "## ")) {
+			headings = append(headings, i)
+		}
+	}
+	return headings
+}
+
+// splitMDCFile converts a Cursor rule file into a rule, mapping its
+// alwaysApply, globs, and description settings to a trigger
+func splitMDCFile(path, content string) (importedRule, error) {
+	var settings struct {
+		Description string `yaml:"description"`
+		Globs       any    `yaml:"globs"`
+		AlwaysApply bool   `yaml:"alwaysApply"`
+	}
+
+	body := content
+	if strings.HasPrefix(content, "---\n") {
+		end := strings.Index(content[4:], "\n---")
+		if end < 0 {
+			return importedRule{}, contextureerrors.ValidationErrorf("frontmatter", "%s: unterminated frontmatter", path)
+		}
+		if err := yaml.Unmarshal([]byte(content[4:4+end]), &settings); err != nil {
+			return importedRule{}, contextureerrors.WithOpf("parse cursor rule", "%s: %w", path, err)
+		}
+		body = content[4+end+len("\n---"):]
+	}
+	body = strings.TrimSpace(body)
+
+	name := strings.TrimSuffix(filepath.Base(path), ".mdc")
+	imported := importedRule{
+		name:        importRuleName(name),
+		title:       name,
+		description: settings.Description,
+		content:     body,
+	}
+	if strings.HasPrefix(body, "# ") {
+		heading, rest, _ := strings.Cut(body, "\n")
+		imported.title = strings.TrimSpace(strings.TrimPrefix(heading, "# "))
+		imported.content = strings.TrimSpace(rest)
+	}
+
+	globs := mdcGlobs(settings.Globs)
+	switch {
+	case settings.AlwaysApply:
+		imported.trigger = &domain.RuleTrigger{Type: domain.TriggerAlways}
+	case len(globs) > 0:
+		imported.trigger = &domain.RuleTrigger{Type: domain.TriggerGlob, Globs: globs}
+	case settings.Description != "":
+		imported.trigger = &domain.RuleTrigger{Type: domain.TriggerModel}
+	default:
+		imported.trigger = &domain.RuleTrigger{Type: domain.TriggerManual}
+	}
+	return imported, nil
+}
+
+// mdcGlobs reads Cursor globs, written either as a comma-separated string or a list
+func mdcGlobs(value any) []string {
+	var raw []string
+	switch v := value.(type) {
+	case string:
+		raw = strings.Split(v, ",")
+	case []any:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				raw = append(raw, s)
+			}
+		}
+	}
+
+	var globs []string
+	for _, glob := range raw {
+		if glob = strings.TrimSpace(glob); glob != "" {
+			globs = append(globs, glob)
+		}
+	}
+	return globs
+}
+
+// importRuleName converts a heading into a rule file name
+func importRuleName(title string) string {
+	return strings.Trim(importSlugPattern.ReplaceAllString(strings.ToLower(title), "-"), "-")
+}
+
+// enableImportFormat makes sure the format replacing the imported file is
+// enabled and reports whether the configuration changed
+func enableImportFormat(config *domain.Project, formatType domain.FormatType) bool {
+	for i := range config.Formats {
+		if config.Formats[i].Type == formatType {
+			if config.Formats[i].Enabled {
+				return false
+			}
+			config.Formats[i].Enabled = true
+			return true
+		}
+	}
+	config.Formats = append(config.Formats, domain.FormatConfig{Type: formatType, Enabled: true})
+	return true
+}
+
+// ImportAction is the CLI action handler for the import command
+func ImportAction(ctx context.Context, cmd *cli.Command, deps *dependencies.Dependencies) error {
+	importCmd := NewImportCommand(deps)
+	return importCmd.Execute(ctx, cmd)
+}
@@ -0,0 +1,216 @@
+// Package commands provides CLI command implementations
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+// runImportCommand runs the import command against basePath and returns its error
+func runImportCommand(t *testing.T, importCmd *ImportCommand, basePath string, args ...string) error {
+	t.Helper()
+
+	var execErr error
+	app := &cli.Command{
+		Name: "import",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "force"},
+			&cli.BoolFlag{Name: "no-build"},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			execErr = importCmd.run(ctx, cmd, basePath)
+			return nil
+		},
+	}
+
+	require.NoError(t, app.Run(context.Background(), append([]string{"import"}, args...)))
+	return execErr
+}
+
+// newTestImportCommand creates an import command for a project with the given
+// formats and counts the builds it runs
+func newTestImportCommand(t *testing.T, formats ...domain.FormatType) (*ImportCommand, afero.Fs, *int) {
+	t.Helper()
+
+	deps := createTestDependencies()
+	importCmd := NewImportCommand(deps)
+	builds := 0
+	importCmd.build = func(context.Context) error {
+		builds++
+		return nil
+	}
+
+	_, err := importCmd.projectManager.InitConfig("/project", formats, domain.ConfigLocationRoot)
+	require.NoError(t, err)
+	return importCmd, deps.FS, &builds
+}
+
+func TestNewImportCommand(t *testing.T) {
+	cmd := NewImportCommand(createTestDependencies())
+
+	assert.NotNil(t, cmd)
+	assert.NotNil(t, cmd.projectManager)
+	assert.NotNil(t, cmd.build)
+}
+
+func TestImportCommand_Claude(t *testing.T) {
+	importCmd, fs, builds := newTestImportCommand(t, domain.FormatClaude)
+	require.NoError(t, afero.WriteFile(fs, "/project/CLAUDE.md", []byte(`# Acme API
+
+Go service for the Acme API.
+
+## Testing
+
+Run go test ./... before pushing.
+
+`+"```sh\n## not a heading\n```"+`
+
+## Code Style
+
+- Use gofmt
+`), 0o644))
+
+	require.NoError(t, runImportCommand(t, importCmd, "/project", "claude"))
+	assert.Equal(t, 1, *builds)
+
+	overview, err := afero.ReadFile(fs, "/project/rules/claude/acme-api.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(overview), "title: Acme API")
+	assert.Contains(t, string(overview), "description: Go service for the Acme API.")
+	assert.Contains(t, string(overview), "- imported\n    - claude")
+	assert.Contains(t, string(overview), "type: always")
+
+	testingRule, err := afero.ReadFile(fs, "/project/rules/claude/testing.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(testingRule), "## not a heading")
+
+	style, err := afero.ReadFile(fs, "/project/rules/claude/code-style.md")
+	require.NoError(t, err)
+	// Sections without prose are described by their title
+	assert.Contains(t, string(style), "description: Code Style")
+
+	t.Run("existing rules need force", func(t *testing.T) {
+		err := runImportCommand(t, importCmd, "/project", "claude", "--no-build")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "already exists")
+
+		require.NoError(t, runImportCommand(t, importCmd, "/project", "claude", "--force", "--no-build"))
+		assert.Equal(t, 1, *builds)
+	})
+
+	t.Run("generated file", func(t *testing.T) {
+		require.NoError(t, afero.WriteFile(fs, "/project/CLAUDE.md",
+			[]byte("# claude.md\n\n<!-- Generated by Contexture CLI at 2025-01-02 15:04:05 -->"), 0o644))
+		err := runImportCommand(t, importCmd, "/project", "claude", "--force")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "generated by contexture")
+	})
+}
+
+func TestImportCommand_Cursor(t *testing.T) {
+	importCmd, fs, builds := newTestImportCommand(t, domain.FormatClaude)
+	require.NoError(t, fs.MkdirAll("/project/.cursor/rules", 0o755))
+	require.NoError(t, afero.WriteFile(fs, "/project/.cursor/rules/go.mdc", []byte(`---
+description: Go conventions
+globs: "*.go, cmd/**"
+alwaysApply: false
+---
+
+# Go Conventions
+
+Wrap errors with context.
+`), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "/project/.cursor/rules/general.mdc",
+		[]byte("---\nalwaysApply: true\n---\nKeep changes small.\n"), 0o644))
+
+	require.NoError(t, runImportCommand(t, importCmd, "/project", "cursor"))
+	assert.Equal(t, 1, *builds)
+
+	goRule, err := afero.ReadFile(fs, "/project/rules/cursor/go.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(goRule), "title: Go Conventions")
+	assert.Contains(t, string(goRule), "description: Go conventions")
+	assert.Contains(t, string(goRule), "type: glob")
+	assert.Contains(t, string(goRule), "- '*.go'\n        - cmd/**")
+	assert.NotContains(t, string(goRule), "# Go Conventions")
+
+	general, err := afero.ReadFile(fs, "/project/rules/cursor/general.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(general), "title: general")
+	assert.Contains(t, string(general), "type: always")
+
+	// The hand-written files are replaced by generated ones
+	exists, err := afero.Exists(fs, "/project/.cursor/rules/go.mdc")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	// The cursor format is enabled in the saved config
+	configResult, err := importCmd.projectManager.LoadConfig("/project")
+	require.NoError(t, err)
+	cursor := configResult.Config.GetFormatByType(domain.FormatCursor)
+	require.NotNil(t, cursor)
+	assert.True(t, cursor.Enabled)
+}
+
+func TestImportCommand_Copilot(t *testing.T) {
+	importCmd, fs, builds := newTestImportCommand(t, domain.FormatClaude)
+	require.NoError(t, afero.WriteFile(fs, "/project/.github/copilot-instructions.md",
+		[]byte("Prefer table-driven tests.\n"), 0o644))
+
+	require.NoError(t, runImportCommand(t, importCmd, "/project", "copilot"))
+	assert.Equal(t, 1, *builds)
+
+	imported, err := afero.ReadFile(fs, "/project/rules/copilot/project-instructions.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(imported), "Prefer table-driven tests.")
+
+	// The copilot format is enabled so the build replaces the file
+	configResult, err := importCmd.projectManager.LoadConfig("/project")
+	require.NoError(t, err)
+	copilot := configResult.Config.GetFormatByType(domain.FormatCopilot)
+	require.NotNil(t, copilot)
+	assert.True(t, copilot.Enabled)
+}
+
+func TestImportCommand_Errors(t *testing.T) {
+	importCmd, fs, _ := newTestImportCommand(t, domain.FormatClaude)
+
+	err := runImportCommand(t, importCmd, "/project", "windsurf")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported import source")
+
+	err = runImportCommand(t, importCmd, "/project", "claude")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "read import source")
+
+	require.NoError(t, afero.WriteFile(fs, "/project/CLAUDE.md", []byte("\n\n"), 0o644))
+	err = runImportCommand(t, importCmd, "/project", "claude")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no rules found")
+}
+
+func TestSplitMarkdownSections(t *testing.T) {
+	t.Parallel()
+
+	t.Run("level one headings", func(t *testing.T) {
+		t.Parallel()
+		rules := splitMarkdownSections("# Testing\n\nRun tests.\n\n# Style\n\nUse gofmt.\n")
+		require.Len(t, rules, 2)
+		assert.Equal(t, "Testing", rules[0].title)
+		assert.Equal(t, "Run tests.", rules[0].content)
+		assert.Equal(t, "style", rules[1].name)
+	})
+
+	t.Run("no headings", func(t *testing.T) {
+		t.Parallel()
+		rules := splitMarkdownSections("Be concise.")
+		require.Len(t, rules, 1)
+		assert.Equal(t, "Project Instructions", rules[0].title)
+	})
+}
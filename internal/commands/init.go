@@ -5,7 +5,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/lipgloss"
@@ -14,40 +15,112 @@ import (
 	"github.com/contextureai/contexture/internal/domain"
 	contextureerrors "github.com/contextureai/contexture/internal/errors"
 	"github.com/contextureai/contexture/internal/format"
+	"github.com/contextureai/contexture/internal/format/base"
 	"github.com/contextureai/contexture/internal/project"
 	"github.com/contextureai/contexture/internal/tui"
 	"github.com/contextureai/contexture/internal/ui"
+	"github.com/spf13/afero"
 	"github.com/urfave/cli/v3"
 )
 
 const defaultConfigFileName = ".contexture.yaml"
 
+// Ways to adopt assistant files found during init
+const (
+	// adoptImport splits the file into local rules
+	adoptImport = "import"
+	// adoptKeep preserves the file's content across builds
+	adoptKeep = "keep"
+)
+
+// unmanagedFile is an assistant file found in a project that contexture did not generate
+type unmanagedFile struct {
+	source string
+	path   string
+}
+
 // InitCommand implements the init command
 type InitCommand struct {
+	fs             afero.Fs
 	projectManager *project.Manager
 	registry       *format.Registry
+	detector       *format.Detector
+	importer       *ImportCommand
+	workingDir     dependencies.WorkingDir
 }
 
 // NewInitCommand creates a new init command
 func NewInitCommand(deps *dependencies.Dependencies) *InitCommand {
 	return &InitCommand{
-		projectManager: project.NewManager(deps.FS),
+		fs:             deps.FS,
+		projectManager: project.NewManagerFromDependencies(deps),
 		registry:       format.GetDefaultRegistry(deps.FS),
+		detector:       format.NewDetector(deps.FS, deps.GetEnv()),
+		importer:       NewImportCommand(deps),
+		workingDir:     deps.GetWorkingDir(),
 	}
 }
 
 // Execute runs the init command
-func (c *InitCommand) Execute(_ context.Context, cmd *cli.Command) error {
-	noInteractive := cmd.Bool("no-interactive")
+func (c *InitCommand) Execute(ctx context.Context, cmd *cli.Command) error {
+	noInteractive := cmd.Bool("no-interactive") || tui.IsNonInteractive()
 	force := cmd.Bool("force")
+	adopt := cmd.String("adopt")
+	if adopt != "" && adopt != adoptImport && adopt != adoptKeep {
+		return contextureerrors.ValidationErrorf("adopt", "must be %q or %q", adoptImport, adoptKeep)
+	}
 
-	return c.initProjectConfig(force, noInteractive)
+	// Formats chosen with flags replace the format prompt
+	var formats []string
+	if selection := selectionFromFlags(cmd); selection.IsSet() {
+		var err error
+		if formats, err = selection.Resolve(c.formatOptions()); err != nil {
+			return err
+		}
+		noInteractive = true
+	}
+
+	return c.initProjectConfig(ctx, initOptions{
+		force:         force,
+		noInteractive: noInteractive,
+		dryRun:        cmd.Bool("dry-run"),
+		adopt:         adopt,
+		formats:       formats,
+	})
+}
+
+// initOptions are the choices of an init run made with flags
+type initOptions struct {
+	force         bool
+	noInteractive bool
+	// dryRun reports the selected formats as JSON instead of writing the configuration
+	dryRun bool
+	adopt  string
+	// formats are the formats selected with flags, or none to prompt or use the default
+	formats []string
+}
+
+// formatOptions returns the formats init can select
+func (c *InitCommand) formatOptions() []tui.SelectOption {
+	var options []tui.SelectOption
+	for _, formatType := range c.registry.GetAvailableFormats() {
+		if handler, exists := c.registry.GetHandler(formatType); exists {
+			options = append(options, tui.SelectOption{Label: handler.GetDisplayName(), Value: string(formatType)})
+		}
+	}
+	return options
 }
 
 // initProjectConfig initializes project-specific configuration
-func (c *InitCommand) initProjectConfig(force, noInteractive bool) error {
+func (c *InitCommand) initProjectConfig(ctx context.Context, opts initOptions) error {
+	force, noInteractive, adopt := opts.force, opts.noInteractive, opts.adopt
+	formats := opts.formats
+	if noInteractive && len(formats) == 0 {
+		formats = []string{string(domain.FormatClaude)} // Default to Claude format
+	}
+
 	// Check if configuration already exists
-	currentDir, err := os.Getwd()
+	currentDir, err := c.workingDir.Getwd()
 	if err != nil {
 		return contextureerrors.Wrap(err, "get current directory")
 	}
@@ -60,6 +133,11 @@ func (c *InitCommand) initProjectConfig(force, noInteractive bool) error {
 		return contextureerrors.ValidationErrorf("configuration", "configuration already exists")
 	}
 
+	// Without prompts the selection is known, and a dry run only reports it
+	if noInteractive && opts.dryRun {
+		return writeDryRunSelection("init", formats)
+	}
+
 	// Show command header
 	fmt.Printf("%s\n\n", ui.CommandHeader("init"))
 
@@ -75,7 +153,25 @@ func (c *InitCommand) initProjectConfig(force, noInteractive bool) error {
 
 	// Handle non-interactive mode
 	if noInteractive {
-		return c.initProjectNonInteractive(currentDir)
+		if err := c.initProjectNonInteractive(currentDir, formats); err != nil {
+			return err
+		}
+		if adopt == "" {
+			adopt = adoptKeep
+		}
+		return c.adoptUnmanagedFiles(ctx, currentDir, c.findUnmanagedFiles(currentDir), adopt)
+	}
+
+	// Preselect the formats of assistants found on this machine, or Claude
+	// when none are found
+	detections := c.detector.Detect(currentDir)
+	preselected := format.DetectedFormats(detections)
+	if len(preselected) == 0 {
+		preselected = []string{string(domain.FormatClaude)}
+	}
+	formatsDescription := "Choose which formats you want to generate"
+	if detected := detectedFormatsDescription(c.registry, detections); detected != "" {
+		formatsDescription += "\n" + detected
 	}
 
 	// Interactive form for configuration
@@ -86,8 +182,8 @@ func (c *InitCommand) initProjectConfig(force, noInteractive bool) error {
 		huh.NewGroup(
 			huh.NewMultiSelect[string]().
 				Title("Select output formats").
-				Description("Choose which formats you want to generate").
-				Options(c.registry.GetUIOptions([]string{"claude"})...).
+				Description(formatsDescription).
+				Options(c.registry.GetUIOptions(preselected)...).
 				Value(&selectedFormats).
 				Validate(func(val []string) error {
 					if len(val) == 0 {
@@ -106,7 +202,7 @@ func (c *InitCommand) initProjectConfig(force, noInteractive bool) error {
 		),
 	))
 
-	err = tui.HandleFormError(form.Run())
+	err = tui.RunForm(form)
 	if err != nil {
 		// Handle user cancellation gracefully
 		if errors.Is(err, tui.ErrUserCancelled) {
@@ -115,6 +211,9 @@ func (c *InitCommand) initProjectConfig(force, noInteractive bool) error {
 		}
 		return err
 	}
+	if opts.dryRun {
+		return writeDryRunSelection("init", selectedFormats)
+	}
 
 	// Convert selected formats to FormatTypes
 	var formatTypes []domain.FormatType
@@ -162,14 +261,30 @@ func (c *InitCommand) initProjectConfig(force, noInteractive bool) error {
 		"location", location,
 		"path", domain.GetConfigPath(currentDir, location))
 
-	return nil
+	unmanaged := c.findUnmanagedFiles(currentDir)
+	if len(unmanaged) == 0 {
+		return nil
+	}
+	if adopt == "" {
+		adopt, err = promptAdoption(unmanaged)
+		if err != nil {
+			if errors.Is(err, tui.ErrUserCancelled) {
+				return nil
+			}
+			return err
+		}
+	}
+	return c.adoptUnmanagedFiles(ctx, currentDir, unmanaged, adopt)
 }
 
 // initProjectNonInteractive initializes project config without interactive prompts
-func (c *InitCommand) initProjectNonInteractive(currentDir string) error {
+func (c *InitCommand) initProjectNonInteractive(currentDir string, formats []string) error {
 	// Use default settings for non-interactive mode
-	formatTypes := []domain.FormatType{domain.FormatClaude} // Default to Claude format
-	location := domain.ConfigLocationRoot                   // Default to project root
+	formatTypes := make([]domain.FormatType, len(formats))
+	for i, selected := range formats {
+		formatTypes[i] = domain.FormatType(selected)
+	}
+	location := domain.ConfigLocationRoot // Default to project root
 
 	// Create the configuration
 	config, err := c.projectManager.InitConfig(currentDir, formatTypes, location)
@@ -207,6 +322,111 @@ func (c *InitCommand) initProjectNonInteractive(currentDir string) error {
 	return nil
 }
 
+// findUnmanagedFiles returns the assistant files in basePath that were written
+// by hand rather than generated
+func (c *InitCommand) findUnmanagedFiles(basePath string) []unmanagedFile {
+	var found []unmanagedFile
+
+	for _, source := range []string{importSourceClaude, importSourceCopilot} {
+		path := filepath.Join(basePath, importDefaultPaths[source])
+		data, err := afero.ReadFile(c.fs, path)
+		if err != nil || strings.TrimSpace(string(data)) == "" || isGeneratedContent(string(data)) {
+			continue
+		}
+		found = append(found, unmanagedFile{source: source, path: path})
+	}
+
+	cursorDir := filepath.Join(basePath, importDefaultPaths[importSourceCursor])
+	entries, _ := afero.ReadDir(c.fs, cursorDir)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".mdc") {
+			continue
+		}
+		data, err := afero.ReadFile(c.fs, filepath.Join(cursorDir, entry.Name()))
+		if err == nil && !isGeneratedContent(string(data)) {
+			found = append(found, unmanagedFile{source: importSourceCursor, path: cursorDir})
+			break
+		}
+	}
+
+	return found
+}
+
+// promptAdoption asks how to adopt the assistant files found during init
+func promptAdoption(unmanaged []unmanagedFile) (string, error) {
+	paths := make([]string, 0, len(unmanaged))
+	for _, file := range unmanaged {
+		paths = append(paths, filepath.Base(file.path))
+	}
+
+	adopt := adoptImport
+	form := ui.ConfigureHuhForm(huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title("Existing assistant files found").
+				Description(strings.Join(paths, ", ")).
+				Options(
+					huh.NewOption("Import them into local rules", adoptImport),
+					huh.NewOption("Keep them as they are", adoptKeep),
+				).
+				Value(&adopt),
+		),
+	))
+	return adopt, tui.RunForm(form)
+}
+
+// adoptUnmanagedFiles imports the files found during init into local rules,
+// or keeps them so the first build does not replace them
+func (c *InitCommand) adoptUnmanagedFiles(ctx context.Context, basePath string, unmanaged []unmanagedFile, adopt string) error {
+	if len(unmanaged) == 0 {
+		return nil
+	}
+
+	theme := ui.DefaultTheme()
+	mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+	fmt.Println()
+
+	if adopt == adoptKeep {
+		for _, file := range unmanaged {
+			// Cursor files are never replaced by a build; CLAUDE.md and the
+			// Copilot instructions are, so their content moves into a keep block
+			if file.source != importSourceCursor {
+				data, err := afero.ReadFile(c.fs, file.path)
+				if err != nil {
+					return contextureerrors.WithOpf("keep existing file", "%s: %w", file.path, err)
+				}
+				if len(base.ExtractKeepBlocks(string(data))) == 0 {
+					if err := afero.WriteFile(c.fs, file.path, []byte(base.WrapKeepBlock(string(data))+"\n"), 0o644); err != nil {
+						return contextureerrors.WithOpf("keep existing file", "%s: %w", file.path, err)
+					}
+				}
+			}
+			fmt.Println(mutedStyle.Render(fmt.Sprintf("Kept %s", file.path)))
+		}
+		return nil
+	}
+
+	configResult, err := c.projectManager.LoadConfig(basePath)
+	if err != nil {
+		return contextureerrors.Wrap(err, "load project configuration")
+	}
+	for _, file := range unmanaged {
+		if err := c.importer.importSource(ctx, configResult, basePath, file.source, file.path, false, false); err != nil {
+			return err
+		}
+	}
+	fmt.Println()
+	if err := c.importer.build(ctx); err != nil {
+		return contextureerrors.Wrap(err, "build rules")
+	}
+	return nil
+}
+
+// isGeneratedContent reports whether an assistant file was written by contexture
+func isGeneratedContent(content string) bool {
+	return strings.Contains(content, "Generated by Contexture CLI") || strings.Contains(content, domain.RuleIDCommentPrefix+"[contexture")
+}
+
 // InitAction is the CLI action handler for the init command
 func InitAction(ctx context.Context, cmd *cli.Command, deps *dependencies.Dependencies) error {
 	initCmd := NewInitCommand(deps)
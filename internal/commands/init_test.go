@@ -8,6 +8,7 @@ import (
 
 	"github.com/contextureai/contexture/internal/dependencies"
 	"github.com/contextureai/contexture/internal/domain"
+	"github.com/contextureai/contexture/internal/project"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -238,3 +239,151 @@ func TestInitAction(t *testing.T) {
 	assert.NotNil(t, initCmd.projectManager)
 	assert.NotNil(t, initCmd.registry)
 }
+
+func TestInitCommand_FindUnmanagedFiles(t *testing.T) {
+	t.Parallel()
+	deps := createTestDependencies()
+	fs := deps.FS
+	cmd := NewInitCommand(deps)
+
+	assert.Empty(t, cmd.findUnmanagedFiles("/project"))
+
+	require.NoError(t, afero.WriteFile(fs, "/project/CLAUDE.md", []byte("# Notes\n\nUse tabs.\n"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "/project/.github/copilot-instructions.md", []byte("Be brief.\n"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "/project/.cursor/rules/generated.mdc",
+		[]byte("Rule\n\n<!-- id: [contexture:go/style] -->\n"), 0o644))
+
+	found := cmd.findUnmanagedFiles("/project")
+	assert.Equal(t, []unmanagedFile{
+		{source: importSourceClaude, path: "/project/CLAUDE.md"},
+		{source: importSourceCopilot, path: "/project/.github/copilot-instructions.md"},
+	}, found)
+
+	// Hand-written Cursor rules are found; generated output is not
+	require.NoError(t, afero.WriteFile(fs, "/project/.cursor/rules/team.mdc", []byte("Use tabs.\n"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "/project/CLAUDE.md",
+		[]byte("# claude.md\n\n<!-- Generated by Contexture CLI at 2025-01-02 15:04:05 -->"), 0o644))
+	found = cmd.findUnmanagedFiles("/project")
+	assert.Equal(t, []unmanagedFile{
+		{source: importSourceCopilot, path: "/project/.github/copilot-instructions.md"},
+		{source: importSourceCursor, path: "/project/.cursor/rules"},
+	}, found)
+}
+
+func TestInitCommand_AdoptUnmanagedFiles(t *testing.T) {
+	t.Parallel()
+
+	setup := func(t *testing.T) (*InitCommand, afero.Fs, *int) {
+		t.Helper()
+		deps := createTestDependencies()
+		cmd := NewInitCommand(deps)
+		builds := 0
+		cmd.importer.build = func(context.Context) error {
+			builds++
+			return nil
+		}
+		_, err := cmd.projectManager.InitConfig("/project", []domain.FormatType{domain.FormatClaude}, domain.ConfigLocationRoot)
+		require.NoError(t, err)
+		require.NoError(t, afero.WriteFile(deps.FS, "/project/CLAUDE.md", []byte("## Testing\n\nRun go test.\n"), 0o644))
+		return cmd, deps.FS, &builds
+	}
+
+	t.Run("keep", func(t *testing.T) {
+		t.Parallel()
+		cmd, fs, builds := setup(t)
+		require.NoError(t, afero.WriteFile(fs, "/project/.github/copilot-instructions.md", []byte("Be brief.\n"), 0o644))
+
+		require.NoError(t, cmd.adoptUnmanagedFiles(context.Background(), "/project", cmd.findUnmanagedFiles("/project"), adoptKeep))
+		assert.Equal(t, 0, *builds)
+
+		content, err := afero.ReadFile(fs, "/project/CLAUDE.md")
+		require.NoError(t, err)
+		assert.Equal(t, "<!-- contexture:keep -->\n## Testing\n\nRun go test.\n<!-- /contexture:keep -->\n", string(content))
+
+		copilot, err := afero.ReadFile(fs, "/project/.github/copilot-instructions.md")
+		require.NoError(t, err)
+		assert.Equal(t, "<!-- contexture:keep -->\nBe brief.\n<!-- /contexture:keep -->\n", string(copilot))
+
+		// Keeping again does not nest blocks
+		require.NoError(t, cmd.adoptUnmanagedFiles(context.Background(), "/project", cmd.findUnmanagedFiles("/project"), adoptKeep))
+		again, err := afero.ReadFile(fs, "/project/CLAUDE.md")
+		require.NoError(t, err)
+		assert.Equal(t, string(content), string(again))
+	})
+
+	t.Run("import", func(t *testing.T) {
+		t.Parallel()
+		cmd, fs, builds := setup(t)
+
+		require.NoError(t, cmd.adoptUnmanagedFiles(context.Background(), "/project", cmd.findUnmanagedFiles("/project"), adoptImport))
+		assert.Equal(t, 1, *builds)
+
+		exists, err := afero.Exists(fs, "/project/rules/claude/testing.md")
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+}
+
+func TestInitCommand_SelectionFlags(t *testing.T) {
+	t.Parallel()
+
+	run := func(fs afero.Fs, args ...string) error {
+		initCmd := NewInitCommand(&dependencies.Dependencies{
+			FS:         fs,
+			Context:    context.Background(),
+			WorkingDir: dependencies.StaticWorkingDir("/project"),
+		})
+		var execErr error
+		app := &cli.Command{
+			Name: "init",
+			Flags: []cli.Flag{
+				&cli.BoolFlag{Name: "no-interactive"},
+				&cli.StringFlag{Name: "adopt"},
+				&cli.StringSliceFlag{Name: "select"},
+				&cli.StringFlag{Name: "all-matching"},
+				&cli.BoolFlag{Name: "dry-run"},
+			},
+			Action: func(ctx context.Context, cmd *cli.Command) error {
+				execErr = initCmd.Execute(ctx, cmd)
+				return nil
+			},
+		}
+		if err := app.Run(context.Background(), append([]string{"init"}, args...)); err != nil {
+			return err
+		}
+		return execErr
+	}
+
+	t.Run("selected formats are configured without prompting", func(t *testing.T) {
+		t.Parallel()
+		fs := afero.NewMemMapFs()
+		require.NoError(t, fs.MkdirAll("/project", 0o755))
+
+		require.NoError(t, run(fs, "--select", "cursor", "--all-matching", "wind*"))
+
+		result, err := project.NewManager(fs).LoadConfig("/project")
+		require.NoError(t, err)
+		require.Len(t, result.Config.Formats, 2)
+		assert.Equal(t, domain.FormatCursor, result.Config.Formats[0].Type)
+		assert.Equal(t, domain.FormatWindsurf, result.Config.Formats[1].Type)
+	})
+
+	t.Run("dry run writes no configuration", func(t *testing.T) {
+		t.Parallel()
+		fs := afero.NewMemMapFs()
+		require.NoError(t, fs.MkdirAll("/project", 0o755))
+
+		require.NoError(t, run(fs, "--select", "claude", "--dry-run"))
+
+		exists, err := afero.Exists(fs, filepath.Join("/project", domain.ConfigFile))
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		t.Parallel()
+		err := run(afero.NewMemMapFs(), "--select", "emacs")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `no option "emacs"`)
+	})
+}
@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/devcontainer"
+	"github.com/contextureai/contexture/internal/diff"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/contextureai/contexture/internal/ui"
+	"github.com/spf13/afero"
+	"github.com/urfave/cli/v3"
+)
+
+// IntegrateAction is the CLI action handler for the integrate command
+func IntegrateAction(_ context.Context, cmd *cli.Command, _ *dependencies.Dependencies) error {
+	return cli.ShowSubcommandHelp(cmd)
+}
+
+// IntegrateDevcontainerAction adds a managed block to the project's
+// devcontainer.json that builds the outputs when the container is created,
+// or removes it with --remove
+func IntegrateDevcontainerAction(_ context.Context, cmd *cli.Command, deps *dependencies.Dependencies) error {
+	remove := cmd.Bool("remove")
+	var currentDir string
+	if remove {
+		dir, err := deps.GetWorkingDir().Getwd()
+		if err != nil {
+			return contextureerrors.Wrap(err, "get current directory")
+		}
+		currentDir = dir
+	} else {
+		// The container builds from the project configuration, so it must exist
+		configLoad, err := LoadProjectConfig(project.NewManagerFromDependencies(deps), deps.GetWorkingDir())
+		if err != nil {
+			return err
+		}
+		currentDir = configLoad.CurrentDir
+	}
+
+	path, exists, err := devcontainer.Find(deps.FS, currentDir)
+	if err != nil {
+		return err
+	}
+	theme := ui.DefaultTheme()
+	mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+	relPath, err := filepath.Rel(currentDir, path)
+	if err != nil {
+		relPath = path
+	}
+	if remove && !exists {
+		fmt.Println(mutedStyle.Render("No devcontainer.json found"))
+		return nil
+	}
+
+	content, err := devcontainer.Read(deps.FS, path)
+	if err != nil {
+		return err
+	}
+	var updated []byte
+	if remove {
+		updated, err = devcontainer.Remove(content)
+	} else {
+		updated, err = devcontainer.Apply(content, filepath.Base(currentDir), devcontainer.Options{
+			Command:    cmd.String("command"),
+			CacheMount: !cmd.Bool("no-cache-mount"),
+		})
+	}
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(content, updated) {
+		fmt.Println(mutedStyle.Render(relPath + " is up to date"))
+		return nil
+	}
+
+	if cmd.Bool("dry-run") {
+		pathStyle := lipgloss.NewStyle().Bold(true)
+		fmt.Println(pathStyle.Render(relPath))
+		fmt.Println(ui.RenderDiff(diff.Lines(string(content), string(updated))))
+		return nil
+	}
+
+	if err := deps.FS.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return contextureerrors.Wrap(err, "create devcontainer directory")
+	}
+	if err := afero.WriteFile(deps.FS, path, updated, 0o644); err != nil {
+		return contextureerrors.Wrap(err, "write "+relPath)
+	}
+
+	successStyle := lipgloss.NewStyle().Foreground(theme.Success)
+	if remove {
+		fmt.Println(successStyle.Render("✓ Removed the contexture block from " + relPath))
+		return nil
+	}
+	fmt.Println(successStyle.Render("✓ Updated " + relPath))
+	fmt.Println(mutedStyle.Render("The container image must provide contexture; rebuild the container to apply"))
+	return nil
+}
@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/devcontainer"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+// runIntegrateDevcontainer runs IntegrateDevcontainerAction with args
+func runIntegrateDevcontainer(t *testing.T, deps *dependencies.Dependencies, args ...string) error {
+	t.Helper()
+	var execErr error
+	app := &cli.Command{
+		Name: "devcontainer",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "dry-run"},
+			&cli.BoolFlag{Name: "remove"},
+			&cli.BoolFlag{Name: "no-cache-mount"},
+			&cli.StringFlag{Name: "command"},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			execErr = IntegrateDevcontainerAction(ctx, cmd, deps)
+			return nil
+		},
+	}
+	require.NoError(t, app.Run(context.Background(), append([]string{"devcontainer"}, args...)))
+	return execErr
+}
+
+func TestIntegrateDevcontainerAction(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	deps := createTestDependencies().WithFS(fs).WithWorkingDir(dependencies.StaticWorkingDir("/work/app"))
+	path := "/work/app/.devcontainer/devcontainer.json"
+
+	err := runIntegrateDevcontainer(t, deps)
+	require.Error(t, err, "the container builds from the project configuration")
+
+	require.NoError(t, afero.WriteFile(fs, "/work/app/.contexture.yaml", []byte("version: 1\nformats:\n  - type: claude\n    enabled: true\n"), 0o644))
+	require.NoError(t, runIntegrateDevcontainer(t, deps, "--dry-run"))
+	exists, err := afero.Exists(fs, path)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	require.NoError(t, runIntegrateDevcontainer(t, deps))
+	content, err := afero.ReadFile(fs, path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), devcontainer.BeginMarker)
+	assert.Contains(t, string(content), devcontainer.DefaultCommand)
+
+	require.NoError(t, runIntegrateDevcontainer(t, deps, "--remove"))
+	content, err = afero.ReadFile(fs, path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), devcontainer.BeginMarker)
+	assert.Contains(t, string(content), `"name": "app"`)
+}
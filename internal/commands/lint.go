@@ -0,0 +1,154 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/contextureai/contexture/internal/provider"
+	"github.com/contextureai/contexture/internal/rule"
+	"github.com/contextureai/contexture/internal/safety"
+	"github.com/contextureai/contexture/internal/ui"
+	"github.com/spf13/afero"
+	"github.com/urfave/cli/v3"
+)
+
+// LintCommand implements the rules lint command
+type LintCommand struct {
+	projectManager   *project.Manager
+	ruleFetcher      rule.Fetcher
+	ruleProcessor    rule.Processor
+	providerRegistry *provider.Registry
+	fs               afero.Fs
+	workingDir       dependencies.WorkingDir
+	env              dependencies.Environment
+}
+
+// NewLintCommand creates a new lint command
+func NewLintCommand(deps *dependencies.Dependencies) *LintCommand {
+	// Rules are linted as builds fetch them, vendored copies included
+	projectDir, _ := deps.GetWorkingDir().Getwd()
+	fetcher := rule.NewFetcher(deps.FS, newOpenRepository(deps.FS), fetcherConfig(deps), deps.ProviderRegistry)
+	return &LintCommand{
+		projectManager:   project.NewManagerFromDependencies(deps),
+		ruleFetcher:      rule.NewVendoredFetcher(deps.FS, fetcher, projectDir),
+		ruleProcessor:    rule.NewProcessor(),
+		providerRegistry: deps.ProviderRegistry,
+		fs:               deps.FS,
+		workingDir:       deps.GetWorkingDir(),
+		env:              deps.GetEnv(),
+	}
+}
+
+// Execute lints the project's third-party rules for injected instructions
+// and fails when a finding has error severity. It never changes the project.
+func (c *LintCommand) Execute(ctx context.Context, _ *cli.Command) error {
+	currentDir, err := c.workingDir.Getwd()
+	if err != nil {
+		return contextureerrors.Wrap(err, "get current directory")
+	}
+
+	merged, err := c.projectManager.LoadConfigMergedWithLocalRules(currentDir)
+	if err != nil {
+		return contextureerrors.Wrap(err, "load configuration").
+			WithSuggestions(contextureerrors.RunCommand("contexture init", "create a project configuration"))
+	}
+	if merged.GlobalConfig != nil {
+		if err := c.providerRegistry.LoadFromProject(merged.GlobalConfig); err != nil {
+			return contextureerrors.Wrap(err, "load global providers")
+		}
+	}
+	if err := c.providerRegistry.LoadFromProject(merged.Project); err != nil {
+		return contextureerrors.Wrap(err, "load project providers")
+	}
+	linter, err := safety.New(merged.Project.GetGeneration().Safety)
+	if err != nil {
+		return err
+	}
+
+	var refs []domain.RuleRef
+	for _, rws := range merged.MergedRules {
+		if rws.RuleRef.Source != "local" {
+			refs = append(refs, rws.RuleRef)
+		}
+	}
+
+	theme := ui.DefaultTheme()
+	successStyle := lipgloss.NewStyle().Foreground(theme.Success)
+	if len(refs) == 0 {
+		fmt.Println(successStyle.Render("✓ No third-party rules to lint"))
+		return nil
+	}
+
+	budget, err := merged.Project.GetGeneration().Budget(c.env.Getenv)
+	if err != nil {
+		return err
+	}
+	preset, err := c.projectManager.ResolvePresets(merged.Project)
+	if err != nil {
+		return contextureerrors.Wrap(err, "resolve presets")
+	}
+	var rules []*domain.Rule
+	err = ui.WithProgress("Fetched rules", func() error {
+		var fetchErr error
+		rules, fetchErr = rule.FetchRulesWithPreset(ctx, c.ruleFetcher, refs, budget.ParallelFetches, preset)
+		return fetchErr
+	})
+	if err != nil {
+		return contextureerrors.Wrap(err, "fetch rules")
+	}
+	rules = rule.SortRulesDeterministically(rules, rule.NewRuleIDParser("", nil))
+
+	titleStyle := lipgloss.NewStyle().Bold(true)
+	warningStyle := lipgloss.NewStyle().Foreground(theme.Warning)
+	errorStyle := lipgloss.NewStyle().Foreground(theme.Error)
+	flagged, errorCount := 0, 0
+	for _, fetched := range rules {
+		processed, err := c.ruleProcessor.ProcessRule(fetched, &domain.RuleContext{})
+		if err != nil {
+			return contextureerrors.Wrap(err, "process rule "+fetched.ID)
+		}
+		findings, err := lintRule(c.fs, linter, processed)
+		if err != nil {
+			return contextureerrors.Wrap(err, "render rule "+fetched.ID)
+		}
+		if len(findings) == 0 {
+			continue
+		}
+		flagged++
+		fmt.Println(titleStyle.Render(fetched.ID))
+		for _, finding := range findings {
+			style := warningStyle
+			if finding.Severity == domain.SafetyError {
+				style = errorStyle
+				errorCount++
+			}
+			fmt.Printf("  %s %s\n", style.Render(fmt.Sprintf("%-7s", finding.Severity)), finding)
+		}
+	}
+
+	if flagged == 0 {
+		fmt.Println(successStyle.Render(fmt.Sprintf("✓ No suspicious instructions in %d third-party rule(s)", len(rules))))
+		return nil
+	}
+	fmt.Println()
+	if errorCount > 0 {
+		return contextureerrors.Validation("rules",
+			fmt.Sprintf("%d rule(s) with suspicious instructions", flagged)).
+			WithSuggestions(
+				contextureerrors.Hint("review the rules, then list them in generation.safety.reviewed"),
+				contextureerrors.RunCommand("contexture build --quarantine", "build without the flagged rules"))
+	}
+	fmt.Println(warningStyle.Render(fmt.Sprintf("⚠ %d rule(s) with suspicious instructions", flagged)))
+	return nil
+}
+
+// LintAction is the CLI action handler for the rules lint command
+func LintAction(ctx context.Context, cmd *cli.Command, deps *dependencies.Dependencies) error {
+	lintCmd := NewLintCommand(deps)
+	return lintCmd.Execute(ctx, cmd)
+}
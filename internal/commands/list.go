@@ -4,41 +4,57 @@ package commands
 import (
 	"context"
 	"fmt"
-	"os"
+	"path/filepath"
 
+	"github.com/contextureai/contexture/internal/cache"
 	"github.com/contextureai/contexture/internal/dependencies"
 	"github.com/contextureai/contexture/internal/domain"
 	contextureerrors "github.com/contextureai/contexture/internal/errors"
 	"github.com/contextureai/contexture/internal/format"
+	"github.com/contextureai/contexture/internal/git"
 	"github.com/contextureai/contexture/internal/output"
 	"github.com/contextureai/contexture/internal/project"
 	"github.com/contextureai/contexture/internal/provider"
+	"github.com/contextureai/contexture/internal/query"
 	"github.com/contextureai/contexture/internal/rule"
+	"github.com/spf13/afero"
 	"github.com/urfave/cli/v3"
 )
 
 // ListCommand implements the list command
 type ListCommand struct {
+	fs               afero.Fs
 	projectManager   *project.Manager
 	ruleFetcher      rule.Fetcher
 	registry         *format.Registry
 	providerRegistry *provider.Registry
+	gitRepo          git.Repository
+	cache            *cache.SimpleCache
+	workingDir       dependencies.WorkingDir
+	env              dependencies.Environment
 }
 
 // RuleWithSourceInfo combines a Rule with its source information
 type RuleWithSourceInfo struct {
 	Rule            *domain.Rule
+	RuleRef         domain.RuleRef
 	Source          domain.RuleSource
 	OverridesGlobal bool
 }
 
 // NewListCommand creates a new list command
 func NewListCommand(deps *dependencies.Dependencies) *ListCommand {
+	gitRepo := newOpenRepository(deps.FS)
 	return &ListCommand{
-		projectManager:   project.NewManager(deps.FS),
-		ruleFetcher:      rule.NewFetcher(deps.FS, newOpenRepository(deps.FS), rule.FetcherConfig{}, deps.ProviderRegistry),
+		fs:               deps.FS,
+		projectManager:   project.NewManagerFromDependencies(deps),
+		ruleFetcher:      rule.NewFetcher(deps.FS, gitRepo, fetcherConfig(deps), deps.ProviderRegistry),
 		registry:         format.GetDefaultRegistry(deps.FS),
 		providerRegistry: deps.ProviderRegistry,
+		gitRepo:          gitRepo,
+		cache:            newProviderCache(deps.FS, deps.GetEnv(), gitRepo, deps.ProviderRegistry),
+		workingDir:       deps.GetWorkingDir(),
+		env:              deps.GetEnv(),
 	}
 }
 
@@ -50,7 +66,7 @@ func (c *ListCommand) Execute(ctx context.Context, cmd *cli.Command) error {
 // listInstalledRules lists rules configured in the current project
 func (c *ListCommand) listInstalledRules(ctx context.Context, cmd *cli.Command) error {
 	// Get current directory and load configuration
-	currentDir, err := os.Getwd()
+	currentDir, err := c.workingDir.Getwd()
 	if err != nil {
 		return contextureerrors.Wrap(err, "get current directory")
 	}
@@ -59,7 +75,7 @@ func (c *ListCommand) listInstalledRules(ctx context.Context, cmd *cli.Command)
 	mergedConfig, err := c.projectManager.LoadConfigMergedWithLocalRules(currentDir)
 	if err != nil {
 		return contextureerrors.Wrap(err, "load project configuration").
-			WithSuggestions("Run 'contexture init' to initialize a new project")
+			WithSuggestions(contextureerrors.RunCommand("contexture init", "initialize a new project"))
 	}
 
 	// Load providers from both global and project configs into registry
@@ -72,14 +88,143 @@ func (c *ListCommand) listInstalledRules(ctx context.Context, cmd *cli.Command)
 		return contextureerrors.Wrap(err, "load project providers")
 	}
 
+	// Resolve filters before fetching so an unknown saved filter fails fast
+	filter, err := resolveListFilter(cmd, mergedConfig)
+	if err != nil {
+		return err
+	}
+
 	// Fetch the actual rules from the merged rule references
 	rules, err := c.fetchRulesFromReferencesWithSource(ctx, mergedConfig.MergedRules)
 	if err != nil {
 		return contextureerrors.Wrap(err, "fetch rules")
 	}
 
+	// Attribute each rule to its last commit when asked
+	var lastChanges map[string]domain.RuleChange
+	if cmd.Bool("verbose") {
+		lastChanges = c.lastChanges(rules, currentDir)
+	}
+
 	// Use simple rule list display
-	return c.showRuleListWithSource(rules, cmd)
+	return c.showRuleListWithSource(rules, cmd, filter, lastChanges)
+}
+
+// lastChanges looks up the last commit of each rule. Remote rules use the
+// pinned commit, or the latest commit touching the rule file, from the cached
+// repository; nothing is fetched. Local rules use the project's git history.
+// Rules without available history are left out.
+func (c *ListCommand) lastChanges(rules []RuleWithSourceInfo, basePath string) map[string]domain.RuleChange {
+	changes := make(map[string]domain.RuleChange)
+
+	var localRulesDir string
+	if configResult, err := c.projectManager.LoadConfig(basePath); err == nil {
+		localRulesDir, _ = c.projectManager.LocalRulesDir(configResult)
+	}
+
+	for _, rws := range rules {
+		var info *git.CommitInfo
+		var err error
+
+		if rws.RuleRef.Source == "local" {
+			rulePath := rws.RuleRef.ID + domain.MarkdownExt
+			if !filepath.IsAbs(rulePath) {
+				if localRulesDir == "" {
+					continue
+				}
+				rulePath = filepath.Join(localRulesDir, rulePath)
+			}
+			repoRoot, ok := c.findRepositoryRoot(filepath.Dir(rulePath))
+			if !ok {
+				continue
+			}
+			relPath, relErr := filepath.Rel(repoRoot, rulePath)
+			if relErr != nil {
+				continue
+			}
+			info, err = c.gitRepo.GetFileCommitInfo(repoRoot, filepath.ToSlash(relPath), "")
+		} else {
+			parsed, parseErr := c.ruleFetcher.ParseRuleID(rws.RuleRef.ID)
+			if parseErr != nil {
+				continue
+			}
+			repoDir, ok := c.cache.CachedRepository(parsed.Source, parsed.Ref)
+			if !ok {
+				continue
+			}
+			if rws.RuleRef.CommitHash != "" {
+				info, err = c.gitRepo.GetCommitInfoByHash(repoDir, rws.RuleRef.CommitHash)
+			} else {
+				info, err = c.gitRepo.GetFileCommitInfo(repoDir, parsed.RulePath+domain.MarkdownExt, parsed.Ref)
+			}
+		}
+
+		if err != nil || info == nil {
+			continue
+		}
+		changes[rws.Rule.ID] = domain.RuleChange{Hash: info.Hash, Date: info.Date, Author: info.Author}
+	}
+
+	return changes
+}
+
+// findRepositoryRoot returns the nearest directory at or above dir that
+// contains a .git directory
+func (c *ListCommand) findRepositoryRoot(dir string) (string, bool) {
+	for {
+		if exists, _ := afero.DirExists(c.fs, filepath.Join(dir, ".git")); exists {
+			return dir, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// listFilter combines the pattern, facet, and source filters for the list command
+type listFilter struct {
+	pattern string
+	facets  query.Facets
+	source  domain.RuleSource
+}
+
+// resolveListFilter builds the list filter from a saved filter named by
+// --filter-name, if any, overridden by explicitly set flags. Project filters
+// take precedence over user-level filters with the same name.
+func resolveListFilter(cmd *cli.Command, mergedConfig *domain.MergedConfig) (listFilter, error) {
+	var filter listFilter
+
+	if name := cmd.String("filter-name"); name != "" {
+		saved, found := mergedConfig.Project.FindFilter(name)
+		if !found && mergedConfig.GlobalConfig != nil {
+			saved, found = mergedConfig.GlobalConfig.FindFilter(name)
+		}
+		if !found {
+			return filter, contextureerrors.Validation("filter-name", fmt.Sprintf("no saved filter named %q", name)).
+				WithSuggestions(contextureerrors.CheckConfig("filters", "define the filter under filters in .contexture.yaml"))
+		}
+
+		filter.pattern = saved.Pattern
+		filter.facets = query.Facets{Tags: saved.Tags, Languages: saved.Languages, Frameworks: saved.Frameworks}
+		filter.source = saved.Source
+	}
+
+	if cmd.IsSet("pattern") {
+		filter.pattern = cmd.String("pattern")
+	}
+	if cmd.IsSet("tag") {
+		filter.facets.Tags = cmd.StringSlice("tag")
+	}
+	if cmd.IsSet("language") {
+		filter.facets.Languages = cmd.StringSlice("language")
+	}
+	if cmd.IsSet("framework") {
+		filter.facets.Frameworks = cmd.StringSlice("framework")
+	}
+
+	return filter, nil
 }
 
 // fetchRulesFromReferencesWithSource fetches the actual rule content from rule references with source info
@@ -118,6 +263,7 @@ func (c *ListCommand) fetchRulesFromReferencesWithSource(
 
 		rules = append(rules, RuleWithSourceInfo{
 			Rule:            fetchedRule,
+			RuleRef:         rws.RuleRef,
 			Source:          rws.Source,
 			OverridesGlobal: rws.OverridesGlobal,
 		})
@@ -180,36 +326,54 @@ func (c *ListCommand) fetchRulesFromReferences(
 }
 
 // showRuleListWithSource displays rules with source information using the configured output format
-func (c *ListCommand) showRuleListWithSource(rulesWithSource []RuleWithSourceInfo, cmd *cli.Command) error {
+func (c *ListCommand) showRuleListWithSource(
+	rulesWithSource []RuleWithSourceInfo,
+	cmd *cli.Command,
+	filter listFilter,
+	lastChanges map[string]domain.RuleChange,
+) error {
 	// Determine output format
 	outputFormat := output.Format(cmd.String("output"))
 
 	// Create output manager
-	outputMgr, err := output.NewManager(outputFormat)
+	outputMgr, err := output.NewManager(outputFormat, c.workingDir, c.env)
 	if err != nil {
 		return err
 	}
 
-	// Extract just the rules for the output manager
-	rules := make([]*domain.Rule, len(rulesWithSource))
-	for i, rws := range rulesWithSource {
-		rules[i] = rws.Rule
+	// Extract just the rules for the output manager, skipping rules from other sources
+	rules := make([]*domain.Rule, 0, len(rulesWithSource))
+	for _, rws := range rulesWithSource {
+		if filter.source != "" && rws.Source != filter.source {
+			continue
+		}
+
+		rule := rws.Rule
 		// Annotate the rule ID with source information for display
 		if rws.OverridesGlobal {
-			rules[i].Source = string(domain.RuleSourceProject) + " (overrides global)"
+			rule.Source = string(domain.RuleSourceProject) + " (overrides global)"
 		} else {
-			rules[i].Source = string(rws.Source)
+			rule.Source = string(rws.Source)
 		}
+		rules = append(rules, rule)
 	}
 
-	totalRules := len(rules)
-	pattern := cmd.String("pattern")
+	totalRules := len(rulesWithSource)
+
+	// Narrow to the selected tags, languages, and frameworks
+	rules = filter.facets.Filter(rules)
 
 	// Prepare metadata
 	metadata := output.ListMetadata{
-		Pattern:       pattern,
+		Pattern:       filter.pattern,
+		FilterName:    cmd.String("filter-name"),
 		TotalRules:    totalRules,
-		FilteredRules: totalRules, // This will be corrected by the writers
+		FilteredRules: len(rules), // Pattern filtering is applied by the writers
+		LastChanges:   lastChanges,
+		Verbose:       cmd.Bool("verbose"),
+	}
+	if !filter.facets.IsEmpty() {
+		metadata.Facets = &filter.facets
 	}
 
 	// Write output in requested format
@@ -224,7 +388,7 @@ func (c *ListCommand) showRuleList(ruleList []*domain.Rule, cmd *cli.Command) er
 	outputFormat := output.Format(cmd.String("output"))
 
 	// Create output manager
-	outputMgr, err := output.NewManager(outputFormat)
+	outputMgr, err := output.NewManager(outputFormat, c.workingDir, c.env)
 	if err != nil {
 		return err
 	}
@@ -3,9 +3,15 @@ package commands
 
 import (
 	"context"
+	"path/filepath"
 	"testing"
 
+	"github.com/contextureai/contexture/internal/cache"
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/contextureai/contexture/internal/git"
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/urfave/cli/v3"
 )
 
@@ -50,3 +56,122 @@ func TestListCommand_SpecificBehavior(t *testing.T) {
 // 1. Unit tests in internal/ui/rules/display_test.go for output formatting
 // 2. Existing command structure tests in this file
 // 3. E2E tests in the e2e/ directory for full command workflows
+
+// runResolveListFilter resolves the list filter for the given arguments
+func runResolveListFilter(t *testing.T, mergedConfig *domain.MergedConfig, args ...string) (listFilter, error) {
+	t.Helper()
+
+	var filter listFilter
+	var resolveErr error
+	app := &cli.Command{
+		Name: "list",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "pattern"},
+			&cli.StringSliceFlag{Name: "tag"},
+			&cli.StringSliceFlag{Name: "language"},
+			&cli.StringSliceFlag{Name: "framework"},
+			&cli.StringFlag{Name: "filter-name"},
+		},
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			filter, resolveErr = resolveListFilter(cmd, mergedConfig)
+			return nil
+		},
+		ExitErrHandler: func(_ context.Context, _ *cli.Command, _ error) {},
+	}
+
+	require.NoError(t, app.Run(context.Background(), append([]string{"list"}, args...)))
+	return filter, resolveErr
+}
+
+func TestResolveListFilter(t *testing.T) {
+	mergedConfig := &domain.MergedConfig{
+		Project: &domain.Project{
+			Filters: []domain.SavedFilter{
+				{Name: "security-review", Pattern: "auth", Tags: []string{"security"}, Languages: []string{"python"}},
+			},
+		},
+		GlobalConfig: &domain.Project{
+			Filters: []domain.SavedFilter{
+				{Name: "security-review", Tags: []string{"ignored"}},
+				{Name: "mine", Source: domain.RuleSourceUser},
+			},
+		},
+	}
+
+	t.Run("flags only", func(t *testing.T) {
+		filter, err := runResolveListFilter(t, mergedConfig, "--tag", "security", "--tag", "auth", "--language", "python")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"security", "auth"}, filter.facets.Tags)
+		assert.Equal(t, []string{"python"}, filter.facets.Languages)
+		assert.Empty(t, filter.facets.Frameworks)
+	})
+
+	t.Run("project filter takes precedence", func(t *testing.T) {
+		filter, err := runResolveListFilter(t, mergedConfig, "--filter-name", "security-review")
+		require.NoError(t, err)
+		assert.Equal(t, "auth", filter.pattern)
+		assert.Equal(t, []string{"security"}, filter.facets.Tags)
+	})
+
+	t.Run("global filter", func(t *testing.T) {
+		filter, err := runResolveListFilter(t, mergedConfig, "--filter-name", "mine")
+		require.NoError(t, err)
+		assert.Equal(t, domain.RuleSourceUser, filter.source)
+	})
+
+	t.Run("flags override saved filter", func(t *testing.T) {
+		filter, err := runResolveListFilter(t, mergedConfig, "--filter-name", "security-review", "--language", "go")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"security"}, filter.facets.Tags)
+		assert.Equal(t, []string{"go"}, filter.facets.Languages)
+	})
+
+	t.Run("unknown filter", func(t *testing.T) {
+		_, err := runResolveListFilter(t, mergedConfig, "--filter-name", "missing")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "missing")
+	})
+}
+
+func TestListCommand_LastChanges(t *testing.T) {
+	deps := createTestDependencies()
+	fs := deps.FS
+	cmd := NewListCommand(deps)
+	mockRepo := git.NewMockRepository(t)
+	cmd.gitRepo = mockRepo
+
+	_, err := cmd.projectManager.InitConfig("/project", []domain.FormatType{domain.FormatClaude}, domain.ConfigLocationRoot)
+	require.NoError(t, err)
+	require.NoError(t, fs.MkdirAll("/project/.git", 0o755))
+	require.NoError(t, afero.WriteFile(fs, "/project/rules/team/style.md", []byte("Use tabs."), 0o644))
+
+	// Only the default repository is cached, so the rule on v2 has no history
+	cachedDir := filepath.Join(cache.BaseDir(deps.GetEnv()), "github.com_contextureai_rules-main")
+	require.NoError(t, fs.MkdirAll(filepath.Join(cachedDir, ".git"), 0o755))
+
+	mockRepo.EXPECT().GetCommitInfoByHash(cachedDir, "abc1234def").
+		Return(&git.CommitInfo{Hash: "abc1234def", Date: "2 Jan 2025", Author: "Jane Doe"}, nil)
+	mockRepo.EXPECT().GetFileCommitInfo("/project", "rules/team/style.md", "").
+		Return(&git.CommitInfo{Hash: "fed9876", Date: "3 Feb 2025", Author: "Sam Roe"}, nil)
+
+	rules := []RuleWithSourceInfo{
+		{
+			Rule:    &domain.Rule{ID: "[contexture:go/testing]"},
+			RuleRef: domain.RuleRef{ID: "[contexture:go/testing]", CommitHash: "abc1234def"},
+		},
+		{
+			Rule:    &domain.Rule{ID: "[contexture(local):team/style]"},
+			RuleRef: domain.RuleRef{ID: "team/style", Source: "local"},
+		},
+		{
+			Rule:    &domain.Rule{ID: "[contexture:go/style,v2]"},
+			RuleRef: domain.RuleRef{ID: "[contexture:go/style,v2]"},
+		},
+	}
+
+	changes := cmd.lastChanges(rules, "/project")
+	assert.Equal(t, map[string]domain.RuleChange{
+		"[contexture:go/testing]":        {Hash: "abc1234def", Date: "2 Jan 2025", Author: "Jane Doe"},
+		"[contexture(local):team/style]": {Hash: "fed9876", Date: "3 Feb 2025", Author: "Sam Roe"},
+	}, changes)
+}
@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/contextureai/contexture/internal/ui"
+	"github.com/spf13/afero"
+	"github.com/urfave/cli/v3"
+)
+
+// MergeConfigAction merges the changes two versions of a configuration file
+// made to their common ancestor, as a git merge driver. The result replaces
+// <ours>, as git expects, unless --output names another file. Settings both
+// sides changed differently keep the value of ours and fail the command, so
+// git reports the file as conflicted.
+func MergeConfigAction(_ context.Context, cmd *cli.Command, deps *dependencies.Dependencies) error {
+	if cmd.Args().Len() != 3 {
+		return contextureerrors.ValidationErrorf("arguments", "expected <base> <ours> <theirs>, got %d arguments", cmd.Args().Len())
+	}
+	paths := cmd.Args().Slice()
+	manager := project.NewManagerFromDependencies(deps)
+	configs := make([]*domain.Project, len(paths))
+	for i, path := range paths {
+		config, err := manager.LoadConfigFile(path)
+		if err != nil {
+			return err
+		}
+		configs[i] = config
+	}
+
+	merged, conflicts := project.MergeThreeWay(configs[0], configs[1], configs[2])
+	data, err := manager.MarshalConfig(merged)
+	if err != nil {
+		return err
+	}
+	switch output := cmd.String("output"); output {
+	case "-":
+		if _, err := os.Stdout.Write(data); err != nil {
+			return contextureerrors.Wrap(err, "write merged configuration")
+		}
+	case "":
+		output = paths[1]
+		fallthrough
+	default:
+		if err := afero.WriteFile(deps.FS, output, data, 0o644); err != nil {
+			return contextureerrors.Wrap(err, "write merged configuration")
+		}
+	}
+
+	if len(conflicts) == 0 {
+		return nil
+	}
+	theme := ui.DefaultTheme()
+	pathStyle := lipgloss.NewStyle().Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+	for _, conflict := range conflicts {
+		fmt.Fprintln(os.Stderr, pathStyle.Render(conflict.Path))
+		fmt.Fprintln(os.Stderr, mutedStyle.Render("  ours:   ")+formatMergeValue(conflict.Ours))
+		fmt.Fprintln(os.Stderr, mutedStyle.Render("  theirs: ")+formatMergeValue(conflict.Theirs))
+	}
+	return contextureerrors.Validation("merge-config",
+		fmt.Sprintf("%d settings were changed differently on both sides", len(conflicts))).
+		WithSuggestions(contextureerrors.Hint("the merged file keeps our value of each; edit it to resolve the conflicts"))
+}
+
+// formatMergeValue returns a conflicting value on one line
+func formatMergeValue(value any) string {
+	if value == nil {
+		return "(removed)"
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
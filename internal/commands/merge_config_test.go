@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+// runMergeConfig runs MergeConfigAction with args
+func runMergeConfig(t *testing.T, deps *dependencies.Dependencies, args ...string) error {
+	t.Helper()
+	var execErr error
+	app := &cli.Command{
+		Name:  "merge-config",
+		Flags: []cli.Flag{&cli.StringFlag{Name: "output"}},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			execErr = MergeConfigAction(ctx, cmd, deps)
+			return nil
+		},
+	}
+	require.NoError(t, app.Run(context.Background(), append([]string{"merge-config"}, args...)))
+	return execErr
+}
+
+func TestMergeConfigAction(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	deps := createTestDependencies().WithFS(fs)
+	formats := "formats:\n  - type: claude\n    enabled: true\n"
+	require.NoError(t, afero.WriteFile(fs, "/tmp/base", []byte(formats+"rules:\n  - id: \"[contexture:go/style]\"\n"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "/tmp/ours", []byte(formats+"rules:\n  - id: \"[contexture:go/style]\"\n  - id: \"[contexture:go/testing]\"\n"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "/tmp/theirs", []byte(formats+"rules:\n  - id: \"[contexture:go/style]\"\n  - id: \"[contexture:go/errors]\"\n"), 0o644))
+
+	require.NoError(t, runMergeConfig(t, deps, "/tmp/base", "/tmp/ours", "/tmp/theirs"))
+	merged, err := afero.ReadFile(fs, "/tmp/ours")
+	require.NoError(t, err)
+	assert.Contains(t, string(merged), "[contexture:go/testing]")
+	assert.Contains(t, string(merged), "[contexture:go/errors]")
+
+	require.NoError(t, afero.WriteFile(fs, "/tmp/theirs", []byte("formats:\n  - type: claude\n    enabled: false\nrules: []\n"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "/tmp/ours", []byte("formats:\n  - type: claude\n    enabled: true\n    template: custom.tmpl\nrules: []\n"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "/tmp/base", []byte(""), 0o644))
+	err = runMergeConfig(t, deps, "/tmp/base", "/tmp/ours", "/tmp/theirs", "--output", "/tmp/merged")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "changed differently on both sides")
+	merged, err = afero.ReadFile(fs, "/tmp/merged")
+	require.NoError(t, err)
+	assert.Contains(t, string(merged), "custom.tmpl")
+
+	require.Error(t, runMergeConfig(t, deps, "/tmp/base", "/tmp/ours"))
+}
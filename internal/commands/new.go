@@ -3,7 +3,6 @@ package commands
 import (
 	"context"
 	"fmt"
-	"os"
 	"path/filepath"
 	"strings"
 
@@ -26,7 +25,7 @@ type NewCommand struct {
 // NewNewCommand creates a new NewCommand instance
 func NewNewCommand(deps *dependencies.Dependencies) *NewCommand {
 	return &NewCommand{
-		projectManager: project.NewManager(deps.FS),
+		projectManager: project.NewManagerFromDependencies(deps),
 		fs:             deps.FS,
 	}
 }
@@ -103,7 +102,7 @@ func (c *NewCommand) determineTargetPath(workingDir, rulePath string, isGlobal b
 
 	// If global flag is set, use global rules directory
 	if isGlobal {
-		globalDir, err := domain.GetGlobalConfigDir()
+		globalDir, err := c.projectManager.GlobalConfigDir()
 		if err == nil {
 			rulesDir := filepath.Join(globalDir, domain.LocalRulesDir)
 			return filepath.Join(rulesDir, rulePath+".md")
@@ -212,7 +211,7 @@ func NewAction(ctx context.Context, cmd *cli.Command, deps *dependencies.Depende
 	}
 
 	// Get current working directory
-	workingDir, err := os.Getwd()
+	workingDir, err := deps.GetWorkingDir().Getwd()
 	if err != nil {
 		return contextureerrors.Wrap(err, "get current directory")
 	}
@@ -0,0 +1,243 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/ui"
+	"github.com/spf13/afero"
+	"github.com/urfave/cli/v3"
+	"gopkg.in/yaml.v3"
+)
+
+// scaffoldRule is an example rule written into a new rule repository
+type scaffoldRule struct {
+	id      string
+	title   string
+	tags    []string
+	content string
+}
+
+// scaffoldRules are the example rules of a new rule repository
+var scaffoldRules = []scaffoldRule{
+	{
+		id:    "examples/code-review",
+		title: "Code Review Checklist",
+		tags:  []string{"example", "review"},
+		content: `---
+title: Code Review Checklist
+description: Points to check before approving a change
+tags: [example, review]
+trigger: manual
+variables:
+  max_function_lines: 50
+tests:
+  - name: default limit
+    contains: ["longer than 50 lines"]
+  - name: custom limit
+    variables:
+      max_function_lines: 80
+    contains: ["longer than 80 lines"]
+    not_contains: ["50 lines"]
+---
+
+# Code Review Checklist
+
+- Flag functions longer than {{ .max_function_lines }} lines.
+- Check that new behavior is covered by tests.
+- Check that errors are handled or returned, never ignored.
+`,
+	},
+	{
+		id:    "examples/commit-messages",
+		title: "Commit Messages",
+		tags:  []string{"example", "git"},
+		content: `---
+title: Commit Messages
+description: How to write commit messages for this team
+tags: [example, git]
+trigger: always
+tests:
+  - name: imperative mood
+    contains: ["imperative mood"]
+---
+
+# Commit Messages
+
+- Write the subject line in the imperative mood, e.g. "Add retry to uploads".
+- Keep the subject line under 72 characters.
+- Explain why the change is needed in the body.
+`,
+	},
+}
+
+// scaffoldWorkflow validates the rules of a new rule repository in CI
+const scaffoldWorkflow = `name: Validate rules
+
+on:
+  push:
+    branches: [main]
+  pull_request:
+
+jobs:
+  validate:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: actions/setup-go@v5
+        with:
+          go-version: stable
+      - name: Install Contexture
+        run: go install github.com/contextureai/contexture/cmd/contexture@latest
+      - name: Lint rules
+        run: contexture repo lint
+      - name: Run rule tests
+        run: contexture test
+`
+
+// scaffoldLicense is a placeholder for the license of a new rule repository
+const scaffoldLicense = `TODO: Choose a license for these rules and replace this file with its text.
+
+Rules shared only inside your organization can state that here instead.
+`
+
+// RepoScaffoldCommand implements the new repo command
+type RepoScaffoldCommand struct {
+	fs afero.Fs
+}
+
+// NewRepoScaffoldCommand creates a new RepoScaffoldCommand instance
+func NewRepoScaffoldCommand(deps *dependencies.Dependencies) *RepoScaffoldCommand {
+	return &RepoScaffoldCommand{
+		fs: deps.FS,
+	}
+}
+
+// Execute scaffolds a rule repository in dir
+func (c *RepoScaffoldCommand) Execute(_ context.Context, cmd *cli.Command, dir string) error {
+	name := cmd.String("name")
+	if name == "" {
+		name = filepath.Base(filepath.Clean(dir))
+	}
+
+	exists, err := afero.DirExists(c.fs, dir)
+	if err != nil {
+		return contextureerrors.Wrap(err, "check directory")
+	}
+	if exists {
+		empty, err := afero.IsEmpty(c.fs, dir)
+		if err != nil {
+			return contextureerrors.Wrap(err, "check directory")
+		}
+		if !empty {
+			return contextureerrors.ValidationErrorf("dir", "directory is not empty: %s", dir)
+		}
+	}
+
+	files, err := scaffoldFiles(name)
+	if err != nil {
+		return err
+	}
+
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		target := filepath.Join(dir, filepath.FromSlash(path))
+		if err := c.fs.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return contextureerrors.Wrap(err, "create directory")
+		}
+		if err := afero.WriteFile(c.fs, target, []byte(files[path]), 0o644); err != nil {
+			return contextureerrors.Wrap(err, "write "+path)
+		}
+	}
+
+	theme := ui.DefaultTheme()
+	successStyle := lipgloss.NewStyle().Bold(true).Foreground(theme.Success)
+	mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+
+	fmt.Printf("\n%s\n", successStyle.Render("Rule repository created!"))
+	fmt.Printf("  Location: %s\n", dir)
+	for _, path := range paths {
+		fmt.Printf("  %s\n", mutedStyle.Render(path))
+	}
+	fmt.Println()
+	fmt.Println("Next steps:")
+	fmt.Printf("  cd %s && contexture test\n", dir)
+	fmt.Printf("  contexture providers add %s <git-url>\n", name)
+	fmt.Println()
+
+	return nil
+}
+
+// scaffoldFiles returns the files of a new rule repository keyed by slash-separated path
+func scaffoldFiles(name string) (map[string]string, error) {
+	index := domain.RepositoryIndex{
+		Name:        name,
+		Description: "Rules for " + name,
+	}
+	files := map[string]string{
+		"LICENSE":                        scaffoldLicense,
+		".github/workflows/validate.yml": scaffoldWorkflow,
+		"README.md":                      scaffoldReadme(name),
+	}
+	for _, rule := range scaffoldRules {
+		index.Rules = append(index.Rules, domain.RepositoryIndexEntry{
+			ID:    rule.id,
+			Title: rule.title,
+			Tags:  rule.tags,
+		})
+		files[rule.id+domain.MarkdownExt] = rule.content
+	}
+
+	indexData, err := yaml.Marshal(index)
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "marshal index")
+	}
+	files[domain.RepositoryIndexFile] = string(indexData)
+
+	return files, nil
+}
+
+// scaffoldReadme returns the README of a new rule repository
+func scaffoldReadme(name string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s\n\n", name)
+	sb.WriteString("Rules for [Contexture](https://github.com/contextureai/contexture).\n\n")
+	sb.WriteString("## Using These Rules\n\n")
+	sb.WriteString("```bash\n")
+	fmt.Fprintf(&sb, "contexture providers add %s <git-url>\n", name)
+	fmt.Fprintf(&sb, "contexture rules add @%s/examples/code-review\n", name)
+	sb.WriteString("```\n\n")
+	sb.WriteString("## Adding a Rule\n\n")
+	sb.WriteString("1. Create a Markdown file with frontmatter, e.g. `team/style.md`.\n")
+	fmt.Fprintf(&sb, "2. List it in `%s`.\n", domain.RepositoryIndexFile)
+	sb.WriteString("3. Declare tests under `tests:` and run `contexture test`.\n")
+	return sb.String()
+}
+
+// NewGroupAction is the CLI action handler for the new command group
+func NewGroupAction(_ context.Context, cmd *cli.Command, _ *dependencies.Dependencies) error {
+	return cli.ShowSubcommandHelp(cmd)
+}
+
+// NewRepoAction is the CLI action handler for the new repo command
+func NewRepoAction(ctx context.Context, cmd *cli.Command, deps *dependencies.Dependencies) error {
+	args := cmd.Args().Slice()
+	if len(args) == 0 {
+		return contextureerrors.ValidationErrorf("dir", "no directory provided")
+	}
+
+	newRepoCmd := NewRepoScaffoldCommand(deps)
+	return newRepoCmd.Execute(ctx, cmd, args[0])
+}
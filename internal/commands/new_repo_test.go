@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+	"gopkg.in/yaml.v3"
+)
+
+// runNewRepoCommand runs the new repo command for dir and returns its error
+func runNewRepoCommand(t *testing.T, newRepoCmd *RepoScaffoldCommand, dir string, args ...string) error {
+	t.Helper()
+
+	var execErr error
+	app := &cli.Command{
+		Name: "repo",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "name"},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			execErr = newRepoCmd.Execute(ctx, cmd, dir)
+			return nil
+		},
+	}
+
+	require.NoError(t, app.Run(context.Background(), append([]string{"repo"}, args...)))
+	return execErr
+}
+
+func TestRepoScaffoldCommand(t *testing.T) {
+	deps := createTestDependencies()
+	fs := deps.FS
+	newRepoCmd := NewRepoScaffoldCommand(deps)
+
+	require.NoError(t, runNewRepoCommand(t, newRepoCmd, "/work/team-rules"))
+
+	for _, path := range []string{
+		"/work/team-rules/README.md",
+		"/work/team-rules/LICENSE",
+		"/work/team-rules/.github/workflows/validate.yml",
+		"/work/team-rules/examples/code-review.md",
+		"/work/team-rules/examples/commit-messages.md",
+	} {
+		exists, err := afero.Exists(fs, path)
+		require.NoError(t, err)
+		assert.True(t, exists, path)
+	}
+
+	data, err := afero.ReadFile(fs, "/work/team-rules/"+domain.RepositoryIndexFile)
+	require.NoError(t, err)
+	var index domain.RepositoryIndex
+	require.NoError(t, yaml.Unmarshal(data, &index))
+	assert.Equal(t, "team-rules", index.Name)
+	require.Len(t, index.Rules, 2)
+	assert.Equal(t, "examples/code-review", index.Rules[0].ID)
+}
+
+func TestRepoScaffoldCommand_Name(t *testing.T) {
+	deps := createTestDependencies()
+	newRepoCmd := NewRepoScaffoldCommand(deps)
+
+	require.NoError(t, runNewRepoCommand(t, newRepoCmd, "/work/rules", "--name", "acme"))
+
+	readme, err := afero.ReadFile(deps.FS, "/work/rules/README.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(readme), "contexture rules add @acme/examples/code-review")
+}
+
+func TestRepoScaffoldCommand_NonEmptyDirectory(t *testing.T) {
+	deps := createTestDependencies()
+	require.NoError(t, afero.WriteFile(deps.FS, "/work/existing/notes.txt", []byte("keep"), 0o644))
+	newRepoCmd := NewRepoScaffoldCommand(deps)
+
+	err := runNewRepoCommand(t, newRepoCmd, "/work/existing")
+	require.Error(t, err)
+	assert.Equal(t, int(contextureerrors.ExitValidation), contextureerrors.ExitCodeFor(err))
+	assert.Contains(t, err.Error(), "not empty")
+}
+
+func TestRepoScaffoldCommand_ExampleRulesPassTests(t *testing.T) {
+	deps := createTestDependencies()
+	require.NoError(t, runNewRepoCommand(t, NewRepoScaffoldCommand(deps), "/work/team-rules"))
+
+	testCmd := NewTestCommand(deps)
+	testCmd.fs = deps.FS
+	require.NoError(t, runTestCommand(t, testCmd, "/work/team-rules"))
+}
@@ -0,0 +1,209 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/contextureai/contexture/internal/cache"
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/git"
+	"github.com/contextureai/contexture/internal/output"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/contextureai/contexture/internal/provider"
+	"github.com/contextureai/contexture/internal/rule"
+	"github.com/urfave/cli/v3"
+)
+
+// OutdatedCommand implements the outdated command
+type OutdatedCommand struct {
+	projectManager   *project.Manager
+	ruleFetcher      rule.Fetcher
+	gitRepo          git.Repository
+	cache            *cache.SimpleCache
+	providerRegistry *provider.Registry
+	workingDir       dependencies.WorkingDir
+	env              dependencies.Environment
+}
+
+// NewOutdatedCommand creates a new outdated command
+func NewOutdatedCommand(deps *dependencies.Dependencies) *OutdatedCommand {
+	gitRepo := newOpenRepository(deps.FS)
+	return &OutdatedCommand{
+		projectManager:   project.NewManagerFromDependencies(deps),
+		ruleFetcher:      rule.NewFetcher(deps.FS, gitRepo, fetcherConfig(deps), deps.ProviderRegistry),
+		gitRepo:          gitRepo,
+		cache:            newProviderCache(deps.FS, deps.GetEnv(), gitRepo, deps.ProviderRegistry),
+		providerRegistry: deps.ProviderRegistry,
+		workingDir:       deps.GetWorkingDir(),
+		env:              deps.GetEnv(),
+	}
+}
+
+// Execute reports the project's remote rules that have updates available.
+// It never prompts and never changes the configuration.
+func (c *OutdatedCommand) Execute(ctx context.Context, cmd *cli.Command) error {
+	outputFormat := output.Format(cmd.String("output"))
+	if cmd.Bool("json") {
+		outputFormat = output.FormatJSON
+	}
+	outputManager, err := output.NewManager(outputFormat, c.workingDir, c.env)
+	if err != nil {
+		return contextureerrors.Wrap(err, "create output manager")
+	}
+
+	var config *domain.Project
+	var configPath string
+	if cmd.Bool("global") {
+		globalResult, err := c.projectManager.LoadGlobalConfig()
+		if err != nil {
+			return contextureerrors.Wrap(err, "load global configuration")
+		}
+		if globalResult == nil || globalResult.Config == nil {
+			return contextureerrors.ValidationErrorf("global config", "global configuration not found - initialize with: contexture rules add -g <rule-id>")
+		}
+		config = globalResult.Config
+		configPath = globalResult.Path
+	} else {
+		configLoadResult, err := LoadProjectConfig(c.projectManager, c.workingDir)
+		if err != nil {
+			return err
+		}
+		config = configLoadResult.Config
+		configPath = configLoadResult.ConfigPath
+	}
+
+	if err := c.providerRegistry.LoadFromProject(config); err != nil {
+		return contextureerrors.Wrap(err, "load providers")
+	}
+
+	report := c.collectReport(ctx, config.Rules)
+	report.ConfigPath = configPath
+
+	if err := outputManager.WriteOutdatedReport(report); err != nil {
+		return contextureerrors.Wrap(err, "write outdated report")
+	}
+
+	if report.Failed > 0 {
+		return contextureerrors.Partial("check for outdated rules",
+			fmt.Errorf("%d of %d rule(s) could not be checked", report.Failed, report.RulesChecked))
+	}
+	return nil
+}
+
+// collectReport checks every remote rule against the latest commit touching
+// it in its source repository. Pinned rules are checked too, so dashboards can
+// see how far a pin has drifted.
+func (c *OutdatedCommand) collectReport(ctx context.Context, refs []domain.RuleRef) output.OutdatedReport {
+	report := output.OutdatedReport{}
+
+	for _, ruleRef := range refs {
+		if ruleRef.Source == "local" {
+			continue
+		}
+		report.RulesChecked++
+
+		result := c.checkRule(ctx, ruleRef)
+		switch {
+		case result.Error != "":
+			report.Failed++
+		case result.CurrentCommit == result.LatestCommit:
+			continue
+		default:
+			report.Outdated++
+			if result.Pinned {
+				report.Pinned++
+			}
+		}
+		report.Rules = append(report.Rules, result)
+	}
+
+	return report
+}
+
+// checkRule compares a rule's current commit with the latest commit touching
+// the rule file in its cached source repository
+func (c *OutdatedCommand) checkRule(ctx context.Context, ruleRef domain.RuleRef) output.OutdatedRule {
+	result := output.OutdatedRule{
+		ID:            ruleRef.ID,
+		Pinned:        ruleRef.Pinned,
+		CurrentCommit: ruleRef.CommitHash,
+	}
+
+	parsed, err := c.ruleFetcher.ParseRuleID(ruleRef.ID)
+	if err != nil {
+		result.Error = contextureerrors.Wrap(err, "parse rule ID").Error()
+		return result
+	}
+	result.Source = parsed.Source
+	result.Ref = parsed.Ref
+
+	repoDir, err := c.cache.GetRepositoryWithUpdate(ctx, parsed.Source, parsed.Ref)
+	if err != nil {
+		result.Error = contextureerrors.Wrap(err, "get repository").Error()
+		return result
+	}
+
+	ruleFilePath := parsed.RulePath + domain.MarkdownExt
+	latest, err := c.gitRepo.GetFileCommitInfo(repoDir, ruleFilePath, parsed.Ref)
+	if err != nil {
+		result.Error = contextureerrors.Wrap(err, "get file commit info").Error()
+		return result
+	}
+	result.LatestCommit = latest.Hash
+	result.LatestDate = isoCommitDate(latest.Date)
+
+	// Rules added before commits were recorded have no known position
+	if result.CurrentCommit == "" || result.CurrentCommit == result.LatestCommit {
+		return result
+	}
+
+	if current, err := c.gitRepo.GetCommitInfoByHash(repoDir, result.CurrentCommit); err == nil {
+		result.CurrentCommit = current.Hash
+		result.CurrentDate = isoCommitDate(current.Date)
+		result.DaysBehind = daysBetween(current.Date, latest.Date)
+	}
+
+	behind, err := c.gitRepo.CountFileCommitsSince(repoDir, ruleFilePath, result.CurrentCommit, parsed.Ref)
+	if err != nil {
+		result.Error = contextureerrors.Wrap(err, "count commits behind").Error()
+		return result
+	}
+	result.CommitsBehind = behind
+
+	return result
+}
+
+// commitDateLayout is the date format of git.CommitInfo
+const commitDateLayout = "2 Jan 2006"
+
+// isoCommitDate converts a commit date to YYYY-MM-DD, or returns "" when it cannot be parsed
+func isoCommitDate(date string) string {
+	parsed, err := time.Parse(commitDateLayout, date)
+	if err != nil {
+		return ""
+	}
+	return parsed.Format(time.DateOnly)
+}
+
+// daysBetween returns the whole days from one commit date to another, or 0
+// when either cannot be parsed
+func daysBetween(from, to string) int {
+	fromDate, err := time.Parse(commitDateLayout, from)
+	if err != nil {
+		return 0
+	}
+	toDate, err := time.Parse(commitDateLayout, to)
+	if err != nil || toDate.Before(fromDate) {
+		return 0
+	}
+	return int(toDate.Sub(fromDate).Hours() / 24)
+}
+
+// OutdatedAction is the CLI action handler for the outdated command
+func OutdatedAction(ctx context.Context, cmd *cli.Command, deps *dependencies.Dependencies) error {
+	outdatedCmd := NewOutdatedCommand(deps)
+	return outdatedCmd.Execute(ctx, cmd)
+}
@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/cache"
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/contextureai/contexture/internal/git"
+	"github.com/contextureai/contexture/internal/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutdatedCommand_CollectReport(t *testing.T) {
+	deps := createTestDependencies()
+	cmd := NewOutdatedCommand(deps)
+	mockRepo := git.NewMockRepository(t)
+	cmd.gitRepo = mockRepo
+	cmd.cache = cache.NewSimpleCache(deps.FS, deps.GetEnv(), mockRepo)
+
+	repoDir := filepath.Join(cache.BaseDir(deps.GetEnv()), "github.com_contextureai_rules-main")
+	require.NoError(t, deps.FS.MkdirAll(filepath.Join(repoDir, ".git"), 0o755))
+
+	mockRepo.EXPECT().Pull(mock.Anything, repoDir, mock.Anything).Return(nil)
+	mockRepo.EXPECT().GetFileCommitInfo(repoDir, "go/testing.md", "main").
+		Return(&git.CommitInfo{Hash: "new1", Date: "11 Mar 2025"}, nil)
+	mockRepo.EXPECT().GetFileCommitInfo(repoDir, "go/style.md", "main").
+		Return(&git.CommitInfo{Hash: "cur2", Date: "1 Mar 2025"}, nil)
+	mockRepo.EXPECT().GetFileCommitInfo(repoDir, "go/errors.md", "main").
+		Return(&git.CommitInfo{Hash: "new3", Date: "5 Mar 2025"}, nil)
+	mockRepo.EXPECT().GetCommitInfoByHash(repoDir, "cur1").
+		Return(&git.CommitInfo{Hash: "cur1", Date: "1 Mar 2025"}, nil)
+	mockRepo.EXPECT().GetCommitInfoByHash(repoDir, "cur3").
+		Return(&git.CommitInfo{Hash: "cur3", Date: "4 Mar 2025"}, nil)
+	mockRepo.EXPECT().CountFileCommitsSince(repoDir, "go/testing.md", "cur1", "main").Return(3, nil)
+	mockRepo.EXPECT().CountFileCommitsSince(repoDir, "go/errors.md", "cur3", "main").Return(1, nil)
+
+	report := cmd.collectReport(context.Background(), []domain.RuleRef{
+		{ID: "[contexture:go/testing]", CommitHash: "cur1"},
+		{ID: "[contexture:go/style]", CommitHash: "cur2"},
+		{ID: "[contexture:go/errors]", CommitHash: "cur3", Pinned: true},
+		{ID: "team/local", Source: "local"},
+	})
+
+	assert.Equal(t, 3, report.RulesChecked)
+	assert.Equal(t, 2, report.Outdated)
+	assert.Equal(t, 1, report.Pinned)
+	assert.Equal(t, 0, report.Failed)
+	require.Len(t, report.Rules, 2)
+	assert.Equal(t, output.OutdatedRule{
+		ID:            "[contexture:go/testing]",
+		Source:        "https://github.com/contextureai/rules.git",
+		Ref:           "main",
+		CurrentCommit: "cur1",
+		CurrentDate:   "2025-03-01",
+		LatestCommit:  "new1",
+		LatestDate:    "2025-03-11",
+		CommitsBehind: 3,
+		DaysBehind:    10,
+	}, report.Rules[0])
+	assert.Equal(t, "[contexture:go/errors]", report.Rules[1].ID)
+	assert.True(t, report.Rules[1].Pinned)
+	assert.Equal(t, 1, report.Rules[1].CommitsBehind)
+}
+
+func TestDaysBetween(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, 31, daysBetween("1 Jan 2025", "1 Feb 2025"))
+	assert.Equal(t, 0, daysBetween("1 Feb 2025", "1 Jan 2025"))
+	assert.Equal(t, 0, daysBetween("unknown", "1 Jan 2025"))
+}
@@ -0,0 +1,142 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/contextureai/contexture/internal/dependencies"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/contextureai/contexture/internal/ui"
+	"github.com/urfave/cli/v3"
+)
+
+// ProfileCommand implements the profile commands, which switch between named
+// global configurations such as work and personal
+type ProfileCommand struct {
+	projectManager *project.Manager
+	env            dependencies.Environment
+}
+
+// NewProfileCommand creates a new profile command
+func NewProfileCommand(deps *dependencies.Dependencies) *ProfileCommand {
+	return &ProfileCommand{
+		projectManager: project.NewManagerFromDependencies(deps),
+		env:            deps.GetEnv(),
+	}
+}
+
+// List prints the global profiles, marking the active one
+func (c *ProfileCommand) List() error {
+	profiles, err := c.projectManager.ListProfiles()
+	if err != nil {
+		return err
+	}
+	active, err := c.projectManager.ActiveProfile()
+	if err != nil {
+		return err
+	}
+
+	theme := ui.DefaultTheme()
+	activeStyle := lipgloss.NewStyle().Bold(true).Foreground(theme.Success)
+	mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+	fmt.Println(ui.CommandHeader("profiles"))
+	for _, name := range profiles {
+		if name == active {
+			fmt.Printf("%s %s\n", activeStyle.Render("* "+name), mutedStyle.Render("(active)"))
+		} else {
+			fmt.Printf("  %s\n", name)
+		}
+	}
+	if c.env.Getenv(project.ProfileEnv) != "" {
+		fmt.Println(mutedStyle.Render(fmt.Sprintf("\nSelected by %s", project.ProfileEnv)))
+	}
+	return nil
+}
+
+// Use makes name the global profile of this machine
+func (c *ProfileCommand) Use(name string) error {
+	if name == "" {
+		return contextureerrors.ValidationErrorf("profile", "no profile name provided")
+	}
+	if err := c.projectManager.UseProfile(name); err != nil {
+		return err
+	}
+
+	theme := ui.DefaultTheme()
+	successStyle := lipgloss.NewStyle().Foreground(theme.Success)
+	mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+	fmt.Println(successStyle.Render("✓ Using profile " + name))
+	if env := c.env.Getenv(project.ProfileEnv); env != "" && env != name {
+		fmt.Println(mutedStyle.Render(fmt.Sprintf("%s=%s still selects %s in this shell", project.ProfileEnv, env, env)))
+	}
+	fmt.Println(mutedStyle.Render("Run 'contexture build --global' to rewrite user-level files such as ~/.claude/CLAUDE.md"))
+	return nil
+}
+
+// Create writes a new profile, copying the active one unless empty is set
+func (c *ProfileCommand) Create(name string, empty bool) error {
+	if name == "" {
+		return contextureerrors.ValidationErrorf("profile", "no profile name provided")
+	}
+
+	config := project.DefaultGlobalConfig()
+	if !empty {
+		result, err := c.projectManager.LoadGlobalConfig()
+		if err != nil {
+			return contextureerrors.Wrap(err, "load global config")
+		}
+		if result.Config != nil {
+			config = result.Config
+		}
+	}
+	if err := c.projectManager.CreateProfile(name, config); err != nil {
+		return err
+	}
+
+	path, err := c.projectManager.ProfilePath(name)
+	if err != nil {
+		return err
+	}
+	successStyle := lipgloss.NewStyle().Foreground(ui.DefaultTheme().Success)
+	fmt.Println(successStyle.Render(fmt.Sprintf("✓ Created profile %s with %d rule(s): %s", name, len(config.Rules), path)))
+	return nil
+}
+
+// profileArg returns the single profile name argument of a profile command
+func profileArg(cmd *cli.Command) (string, error) {
+	if cmd.Args().Len() > 1 {
+		return "", contextureerrors.ValidationErrorf("profile", "expected one profile name, got %d", cmd.Args().Len())
+	}
+	return cmd.Args().First(), nil
+}
+
+// ProfileListAction is the CLI action handler for the profile list command
+func ProfileListAction(_ context.Context, _ *cli.Command, deps *dependencies.Dependencies) error {
+	return NewProfileCommand(deps).List()
+}
+
+// ProfileUseAction is the CLI action handler for the profile use command
+func ProfileUseAction(_ context.Context, cmd *cli.Command, deps *dependencies.Dependencies) error {
+	name, err := profileArg(cmd)
+	if err != nil {
+		return err
+	}
+	return NewProfileCommand(deps).Use(name)
+}
+
+// ProfileCreateAction is the CLI action handler for the profile create command
+func ProfileCreateAction(_ context.Context, cmd *cli.Command, deps *dependencies.Dependencies) error {
+	name, err := profileArg(cmd)
+	if err != nil {
+		return err
+	}
+	if err := NewProfileCommand(deps).Create(name, cmd.Bool("empty")); err != nil {
+		return err
+	}
+	if cmd.Bool("use") {
+		return NewProfileCommand(deps).Use(name)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfileCommand(t *testing.T) {
+	t.Parallel()
+	env := dependencies.MapEnvironment{"HOME": "/home/user", project.HomeEnv: "/state"}
+	deps := dependencies.NewForTesting(context.Background()).WithEnv(env)
+	manager := project.NewManagerFromDependencies(deps)
+	profiles := NewProfileCommand(deps)
+
+	require.NoError(t, manager.SaveGlobalConfig(&domain.Project{
+		Version: 1,
+		Formats: []domain.FormatConfig{{Type: domain.FormatClaude, Enabled: true}},
+		Rules:   []domain.RuleRef{{ID: "[contexture:go/style]"}},
+	}))
+
+	require.NoError(t, profiles.Create("work", false))
+	require.NoError(t, profiles.Create("personal", true))
+	require.NoError(t, profiles.List())
+
+	require.NoError(t, profiles.Use("work"))
+	result, err := manager.LoadGlobalConfig()
+	require.NoError(t, err)
+	assert.Len(t, result.Config.Rules, 1, "work copies the active profile")
+
+	require.NoError(t, profiles.Use("personal"))
+	result, err = manager.LoadGlobalConfig()
+	require.NoError(t, err)
+	assert.Empty(t, result.Config.Rules, "personal starts empty")
+
+	err = profiles.Use("")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no profile name provided")
+
+	// CONTEXTURE_PROFILE in the environment of the dependencies wins
+	env[project.ProfileEnv] = "work"
+	active, err := NewProfileCommand(deps).projectManager.ActiveProfile()
+	require.NoError(t, err)
+	assert.Equal(t, "work", active)
+	result, err = project.NewManagerFromDependencies(deps).LoadGlobalConfig()
+	require.NoError(t, err)
+	assert.Len(t, result.Config.Rules, 1)
+}
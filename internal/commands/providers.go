@@ -4,7 +4,6 @@ package commands
 import (
 	"context"
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -19,12 +18,14 @@ import (
 // ProvidersCommand implements the providers command
 type ProvidersCommand struct {
 	projectManager *project.Manager
+	workingDir     dependencies.WorkingDir
 }
 
 // NewProvidersCommand creates a new providers command
 func NewProvidersCommand(deps *dependencies.Dependencies) *ProvidersCommand {
 	return &ProvidersCommand{
-		projectManager: project.NewManager(deps.FS),
+		projectManager: project.NewManagerFromDependencies(deps),
+		workingDir:     deps.GetWorkingDir(),
 	}
 }
 
@@ -50,7 +51,7 @@ func (c *ProvidersCommand) ListAction(_ context.Context, _ *cli.Command, deps *d
 	fmt.Printf("%s\n\n", headerStyle.Render("Providers"))
 
 	// Get current directory
-	currentDir, err := os.Getwd()
+	currentDir, err := c.workingDir.Getwd()
 	if err != nil {
 		return contextureerrors.Wrap(err, "get current directory")
 	}
@@ -167,7 +168,7 @@ func (c *ProvidersCommand) AddAction(_ context.Context, cmd *cli.Command, _ *dep
 		config = globalResult.Config
 	} else {
 		// Get current directory and load project configuration
-		currentDir, err := os.Getwd()
+		currentDir, err := c.workingDir.Getwd()
 		if err != nil {
 			return contextureerrors.Wrap(err, "get current directory")
 		}
@@ -186,8 +187,9 @@ func (c *ProvidersCommand) AddAction(_ context.Context, cmd *cli.Command, _ *dep
 
 	// Create new provider
 	newProvider := domain.Provider{
-		Name: name,
-		URL:  url,
+		Name:    name,
+		URL:     url,
+		Mirrors: cmd.StringSlice("mirror"),
 	}
 
 	// Add to config
@@ -199,7 +201,7 @@ func (c *ProvidersCommand) AddAction(_ context.Context, cmd *cli.Command, _ *dep
 			return contextureerrors.Wrap(err, "save global config")
 		}
 	} else {
-		currentDir, err := os.Getwd()
+		currentDir, err := c.workingDir.Getwd()
 		if err != nil {
 			return contextureerrors.Wrap(err, "get current directory")
 		}
@@ -213,6 +215,9 @@ func (c *ProvidersCommand) AddAction(_ context.Context, cmd *cli.Command, _ *dep
 	successStyle := lipgloss.NewStyle().Bold(true).Foreground(theme.Success)
 	fmt.Println(successStyle.Render("Provider added successfully!"))
 	fmt.Printf("  @%s → %s\n", name, url)
+	for _, mirror := range newProvider.Mirrors {
+		fmt.Printf("    mirror → %s\n", mirror)
+	}
 
 	return nil
 }
@@ -252,7 +257,7 @@ func (c *ProvidersCommand) RemoveAction(_ context.Context, cmd *cli.Command, _ *
 		config = globalResult.Config
 	} else {
 		// Get current directory and load project configuration
-		currentDir, err := os.Getwd()
+		currentDir, err := c.workingDir.Getwd()
 		if err != nil {
 			return contextureerrors.Wrap(err, "get current directory")
 		}
@@ -287,7 +292,7 @@ func (c *ProvidersCommand) RemoveAction(_ context.Context, cmd *cli.Command, _ *
 			return contextureerrors.Wrap(err, "save global config")
 		}
 	} else {
-		currentDir, saveErr := os.Getwd()
+		currentDir, saveErr := c.workingDir.Getwd()
 		if saveErr != nil {
 			return contextureerrors.Wrap(saveErr, "get current directory")
 		}
@@ -322,7 +327,7 @@ func (c *ProvidersCommand) ShowAction(_ context.Context, _ *cli.Command, deps *d
 	name = strings.TrimPrefix(name, "@")
 
 	// Get current directory
-	currentDir, err := os.Getwd()
+	currentDir, err := c.workingDir.Getwd()
 	if err != nil {
 		return contextureerrors.Wrap(err, "get current directory")
 	}
@@ -378,6 +383,9 @@ func (c *ProvidersCommand) ShowAction(_ context.Context, _ *cli.Command, deps *d
 	if provider.DefaultBranch != "" {
 		fmt.Printf("  %s %s\n", labelStyle.Render("Branch:"), provider.DefaultBranch)
 	}
+	if len(provider.Mirrors) > 0 {
+		fmt.Printf("  %s %s\n", labelStyle.Render("Mirrors:"), strings.Join(provider.Mirrors, ", "))
+	}
 	if provider.Auth != nil {
 		fmt.Printf("  %s %s\n", labelStyle.Render("Auth:"), provider.Auth.Type)
 	}
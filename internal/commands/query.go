@@ -4,7 +4,7 @@ package commands
 import (
 	"context"
 	"fmt"
-	"os"
+	"sort"
 
 	"github.com/contextureai/contexture/internal/dependencies"
 	"github.com/contextureai/contexture/internal/domain"
@@ -29,15 +29,19 @@ type QueryCommand struct {
 	ruleFetcher      rule.Fetcher
 	providerRegistry *provider.Registry
 	evaluator        query.Evaluator
+	workingDir       dependencies.WorkingDir
+	env              dependencies.Environment
 }
 
 // NewQueryCommand creates a new query command
 func NewQueryCommand(deps *dependencies.Dependencies) *QueryCommand {
 	return &QueryCommand{
-		projectManager:   project.NewManager(deps.FS),
-		ruleFetcher:      rule.NewFetcher(deps.FS, newOpenRepository(deps.FS), rule.FetcherConfig{}, deps.ProviderRegistry),
+		projectManager:   project.NewManagerFromDependencies(deps),
+		ruleFetcher:      rule.NewFetcher(deps.FS, newOpenRepository(deps.FS), fetcherConfig(deps), deps.ProviderRegistry),
 		providerRegistry: deps.ProviderRegistry,
 		evaluator:        query.NewEvaluator(),
+		workingDir:       deps.GetWorkingDir(),
+		env:              deps.GetEnv(),
 	}
 }
 
@@ -53,7 +57,7 @@ func (c *QueryCommand) Execute(ctx context.Context, cmd *cli.Command) error {
 	useExpr := cmd.Bool("expr")
 
 	// Get current directory and load configuration (for providers)
-	currentDir, err := os.Getwd()
+	currentDir, err := c.workingDir.Getwd()
 	if err != nil {
 		return contextureerrors.Wrap(err, "get current directory")
 	}
@@ -81,7 +85,9 @@ func (c *QueryCommand) Execute(ctx context.Context, cmd *cli.Command) error {
 	return c.outputResults(filtered, queryStr, useExpr, cmd)
 }
 
-// fetchAndFilterRules fetches rules with streaming and early exit when limit is reached
+// fetchAndFilterRules fetches rules with streaming and filters them. Expression
+// queries exit early when limit is reached; text queries score every rule so the
+// best fuzzy matches are returned first.
 func (c *QueryCommand) fetchAndFilterRules(ctx context.Context, cmd *cli.Command, queryStr string, useExpr bool, limit int) ([]*domain.Rule, error) {
 	providerFilter := cmd.StringSlice("provider")
 
@@ -106,8 +112,10 @@ func (c *QueryCommand) fetchAndFilterRules(ctx context.Context, cmd *cli.Command
 		return nil, contextureerrors.ValidationErrorf("provider", "no providers found")
 	}
 
-	// Collect matching rules with early exit
+	// Collect matching rules, exiting early only for expression queries
 	matchedRules := make([]*domain.Rule, 0, limit)
+	scores := make(map[*domain.Rule]int)
+	earlyExit := useExpr && limit > 0
 
 	// Iterate through providers
 	for _, provider := range providers {
@@ -117,7 +125,7 @@ func (c *QueryCommand) fetchAndFilterRules(ctx context.Context, cmd *cli.Command
 		}
 
 		// Check if we've reached the limit
-		if limit > 0 && len(matchedRules) >= limit {
+		if earlyExit && len(matchedRules) >= limit {
 			break
 		}
 
@@ -136,7 +144,7 @@ func (c *QueryCommand) fetchAndFilterRules(ctx context.Context, cmd *cli.Command
 			}
 
 			// Check if we've reached the limit
-			if limit > 0 && len(matchedRules) >= limit {
+			if earlyExit && len(matchedRules) >= limit {
 				break
 			}
 
@@ -155,7 +163,7 @@ func (c *QueryCommand) fetchAndFilterRules(ctx context.Context, cmd *cli.Command
 				}
 
 				// Early exit if we've hit the limit
-				if limit > 0 && len(matchedRules) >= limit {
+				if earlyExit && len(matchedRules) >= limit {
 					break
 				}
 
@@ -176,7 +184,9 @@ func (c *QueryCommand) fetchAndFilterRules(ctx context.Context, cmd *cli.Command
 						return nil, err
 					}
 				} else {
-					matches = c.evaluator.MatchesText(fetchedRule, queryStr)
+					match := c.evaluator.ScoreText(fetchedRule, queryStr)
+					matches = match.Matched
+					scores[fetchedRule] = match.Score
 				}
 
 				// Add to results if it matches
@@ -187,6 +197,16 @@ func (c *QueryCommand) fetchAndFilterRules(ctx context.Context, cmd *cli.Command
 		}
 	}
 
+	if !useExpr {
+		// Rank text matches by score, keeping discovery order for ties
+		sort.SliceStable(matchedRules, func(i, j int) bool {
+			return scores[matchedRules[i]] > scores[matchedRules[j]]
+		})
+		if limit > 0 && len(matchedRules) > limit {
+			matchedRules = matchedRules[:limit]
+		}
+	}
+
 	return matchedRules, nil
 }
 
@@ -196,7 +216,7 @@ func (c *QueryCommand) outputResults(rules []*domain.Rule, queryStr string, useE
 	outputFormat := output.Format(cmd.String("output"))
 
 	// Create output manager
-	outputMgr, err := output.NewManager(outputFormat)
+	outputMgr, err := output.NewManager(outputFormat, c.workingDir, c.env)
 	if err != nil {
 		return err
 	}
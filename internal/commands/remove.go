@@ -5,8 +5,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"os"
-	"path/filepath"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -31,15 +29,18 @@ type RemoveCommand struct {
 	registry       *format.Registry
 	ruleFetcher    rule.Fetcher
 	ruleGenerator  *RuleGenerator
+	fs             afero.Fs
+	workingDir     dependencies.WorkingDir
+	env            dependencies.Environment
 }
 
 // NewRemoveCommand creates a new remove command
 func NewRemoveCommand(deps *dependencies.Dependencies) *RemoveCommand {
-	ruleFetcher := rule.NewFetcher(deps.FS, newOpenRepository(deps.FS), rule.FetcherConfig{}, deps.ProviderRegistry)
+	ruleFetcher := rule.NewFetcher(deps.FS, newOpenRepository(deps.FS), fetcherConfig(deps), deps.ProviderRegistry)
 	registry := format.GetDefaultRegistry(deps.FS)
 
 	return &RemoveCommand{
-		projectManager: project.NewManager(deps.FS),
+		projectManager: project.NewManagerFromDependencies(deps),
 		registry:       registry,
 		ruleFetcher:    ruleFetcher,
 		ruleGenerator: NewRuleGenerator(
@@ -48,7 +49,10 @@ func NewRemoveCommand(deps *dependencies.Dependencies) *RemoveCommand {
 			rule.NewProcessor(),
 			registry,
 			deps.FS,
-		),
+		).withEnvironment(deps.GetWorkingDir(), deps.GetEnv()),
+		fs:         deps.FS,
+		workingDir: deps.GetWorkingDir(),
+		env:        deps.GetEnv(),
 	}
 }
 
@@ -70,18 +74,18 @@ func (c *RemoveCommand) Execute(ctx context.Context, cmd *cli.Command, ruleIDs [
 	isGlobal := cmd.Bool("global")
 
 	// Load configuration
-	config, _, err := loadConfigByScope(c.projectManager, isGlobal)
+	config, _, err := loadConfigByScope(c.projectManager, c.workingDir, isGlobal)
 	if err != nil {
 		if !isGlobal {
 			return contextureerrors.Wrap(err, "load project configuration").
-				WithSuggestions("Run 'contexture init' to initialize a new project")
+				WithSuggestions(contextureerrors.RunCommand("contexture init", "initialize a new project"))
 		}
 		return err
 	}
 
 	var currentDir string
 	if !isGlobal {
-		currentDir, err = os.Getwd()
+		currentDir, err = c.workingDir.Getwd()
 		if err != nil {
 			return contextureerrors.Wrap(err, "get current directory")
 		}
@@ -112,7 +116,7 @@ func (c *RemoveCommand) Execute(ctx context.Context, cmd *cli.Command, ruleIDs [
 	if len(rulesToRemove) == 0 {
 		// Handle output format when no rules to remove
 		outputFormat := output.Format(cmd.String("output"))
-		outputManager, err := output.NewManager(outputFormat)
+		outputManager, err := output.NewManager(outputFormat, c.workingDir, c.env)
 		if err != nil {
 			return contextureerrors.Wrap(err, "create output manager")
 		}
@@ -173,7 +177,7 @@ func (c *RemoveCommand) Execute(ctx context.Context, cmd *cli.Command, ruleIDs [
 		// Clean up empty directories after removing rules, similar to build command
 		targetFormats := config.GetEnabledFormats()
 		for _, formatConfig := range targetFormats {
-			format, err := c.registry.CreateFormat(formatConfig.Type, afero.NewOsFs(), nil)
+			format, err := c.registry.CreateFormat(formatConfig.Type, c.fs, nil)
 			if err != nil {
 				log.Warn("Failed to create format for cleanup", "format", formatConfig.Type, "error", err)
 				continue
@@ -197,14 +201,14 @@ func (c *RemoveCommand) Execute(ctx context.Context, cmd *cli.Command, ruleIDs [
 
 	// Auto-rebuild after removing global rules (similar to add command)
 	if isGlobal && !isJSONMode {
-		if err := c.rebuildAfterGlobalRemove(ctx); err != nil {
+		if err := c.rebuildAfterGlobalRemove(ctx, userRulesWriteFromFlags(cmd)); err != nil {
 			log.Warn("Failed to auto-rebuild after removing global rules", "error", err)
 			// Don't fail the remove - rules were removed successfully
 		}
 	}
 
 	// Handle output format
-	outputManager, err := output.NewManager(outputFormat)
+	outputManager, err := output.NewManager(outputFormat, c.workingDir, c.env)
 	if err != nil {
 		return contextureerrors.Wrap(err, "create output manager")
 	}
@@ -305,7 +309,7 @@ func (c *RemoveCommand) removeFromOutputs(
 	var errors []string
 
 	for _, formatConfig := range config.GetEnabledFormats() {
-		format, err := c.registry.CreateFormat(formatConfig.Type, afero.NewOsFs(), nil)
+		format, err := c.registry.CreateFormat(formatConfig.Type, c.fs, nil)
 		if err != nil {
 			errors = append(errors, contextureerrors.Wrap(err, "create format").Error())
 			continue
@@ -328,7 +332,7 @@ func (c *RemoveCommand) removeFromOutputs(
 
 // rebuildAfterGlobalRemove rebuilds outputs after removing global rules
 // This is similar to rebuildAfterGlobalAdd in add.go
-func (c *RemoveCommand) rebuildAfterGlobalRemove(ctx context.Context) error {
+func (c *RemoveCommand) rebuildAfterGlobalRemove(ctx context.Context, write userRulesWrite) error {
 	// Load global config to get the remaining global rules
 	globalConfigResult, err := c.projectManager.LoadGlobalConfig()
 	if err != nil {
@@ -349,14 +353,10 @@ func (c *RemoveCommand) rebuildAfterGlobalRemove(ctx context.Context) error {
 			continue
 		}
 
-		// Only include formats that support native user rules
-		if caps.SupportsUserRules && caps.UserRulesPath != "" {
+		// Only include formats with a native user rules location or user rules file
+		if userRulesPath(formatConfig, caps) != "" {
 			// Create format config for user rules generation
-			userFormatConfig := formatConfig
-			userDir := filepath.Dir(caps.UserRulesPath)
-			userFormatConfig.BaseDir = userDir
-			userFormatConfig.IsUserRules = true
-			userFormats = append(userFormats, userFormatConfig)
+			userFormats = append(userFormats, userRulesFormatConfig(formatConfig, caps, globalConfig))
 		}
 	}
 
@@ -367,13 +367,13 @@ func (c *RemoveCommand) rebuildAfterGlobalRemove(ctx context.Context) error {
 		userConfig.Rules = globalConfig.Rules
 
 		log.Debug("Regenerating global rules to user locations after removal", "formats", len(userFormats))
-		if err := c.ruleGenerator.GenerateRulesWithScope(ctx, userConfig, userFormats, "global"); err != nil {
+		if err := c.ruleGenerator.writeUserRules(ctx, userConfig, userFormats, write); err != nil {
 			log.Warn("Failed to regenerate global rules to user locations", "error", err)
 		}
 	}
 
 	// Check if we're in a project context
-	currentDir, err := os.Getwd()
+	currentDir, err := c.workingDir.Getwd()
 	if err != nil {
 		// Can't determine current directory, skip project rebuild
 		log.Debug("Cannot determine current directory, skipping project rebuild", "error", err)
@@ -408,14 +408,14 @@ func (c *RemoveCommand) rebuildAfterGlobalRemove(ctx context.Context) error {
 	}
 
 	// Group formats by which rules they need based on UserRulesMode
-	var nativeOnlyFormats []domain.FormatConfig // UserRulesNative - project rules only
+	var nativeOnlyFormats []domain.FormatConfig // UserRulesNative/File - project rules only
 	var mergedFormats []domain.FormatConfig     // UserRulesProject - project + user rules
 	var disabledFormats []domain.FormatConfig   // UserRulesDisabled - project rules only
 
 	for _, formatConfig := range targetFormats {
 		mode := formatConfig.GetEffectiveUserRulesMode()
 		switch mode {
-		case domain.UserRulesNative:
+		case domain.UserRulesNative, domain.UserRulesFile:
 			nativeOnlyFormats = append(nativeOnlyFormats, formatConfig)
 		case domain.UserRulesProject:
 			mergedFormats = append(mergedFormats, formatConfig)
@@ -459,8 +459,11 @@ func RemoveAction(ctx context.Context, cmd *cli.Command, deps *dependencies.Depe
 
 	// If no rule IDs provided, show helpful error message
 	if len(ruleIDs) == 0 {
-		return contextureerrors.ValidationErrorf("rule-ids",
-			"no rule IDs provided\n\nUsage:\n  contexture rules remove [rule-id...]\n\nExamples:\n  # Remove specific rules (simple format)\n  contexture rules remove languages/go/code-organization testing/unit-tests\n  \n  # Remove rules (full format)\n  contexture rules remove \"[contexture:languages/go/advanced-patterns]\" \"[contexture:security/input-validation]\"\n  \n  # Remove from custom source\n  contexture rules remove my/custom-rule\n\nTo see installed rules:\n  Use 'contexture rules list' to see currently installed rules\n  \nRun 'contexture rules remove --help' for more options")
+		return contextureerrors.Validation("rule-ids", "no rule IDs provided").WithSuggestions(
+			contextureerrors.RunCommand("contexture rules list", "see currently installed rules"),
+			contextureerrors.RunCommand("contexture rules remove languages/go/code-organization", "remove a specific rule"),
+			contextureerrors.RunCommand("contexture rules remove --help", "see more options"),
+		)
 	}
 
 	return removeCmd.Execute(ctx, cmd, ruleIDs)
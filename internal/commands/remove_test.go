@@ -3,7 +3,6 @@ package commands
 
 import (
 	"context"
-	"os"
 	"path/filepath"
 	"testing"
 
@@ -49,8 +48,9 @@ func TestRemoveCommand_Execute_NoConfig(t *testing.T) {
 	_ = fs.MkdirAll(tempDir, 0o755)
 
 	deps := &dependencies.Dependencies{
-		FS:      fs,
-		Context: context.Background(),
+		FS:         fs,
+		Context:    context.Background(),
+		WorkingDir: dependencies.StaticWorkingDir(tempDir),
 	}
 
 	cmd := NewRemoveCommand(deps)
@@ -71,8 +71,9 @@ func TestRemoveCommand_CustomSourceRules(t *testing.T) {
 	// Use real filesystem for this test since we need actual directory operations
 	realFS := afero.NewOsFs()
 	deps := &dependencies.Dependencies{
-		FS:      realFS,
-		Context: context.Background(),
+		FS:         realFS,
+		Context:    context.Background(),
+		WorkingDir: dependencies.StaticWorkingDir(tempDir),
 	}
 
 	// Create a test configuration with both standard and custom source rules
@@ -94,20 +95,11 @@ rules:
 	err := afero.WriteFile(realFS, configPath, []byte(configContent), 0o644)
 	require.NoError(t, err)
 
-	// Save current directory and restore after test
-	originalWd, err := os.Getwd()
-	require.NoError(t, err)
-	//nolint:usetesting // Need to restore original dir at end of test
-	defer func() { _ = os.Chdir(originalWd) }()
-
 	cmd := NewRemoveCommand(deps)
 	cliCmd := &cli.Command{}
 
 	// Test removing a custom source rule with SSH URL
 	t.Run("removes custom SSH source rule", func(t *testing.T) {
-		// Change to temp directory for this test
-		t.Chdir(tempDir)
-
 		err = cmd.Execute(context.Background(), cliCmd, []string{"[contexture(git@github.com:user/custom-repo.git):test/custom-rule]"})
 		require.NoError(t, err)
 
@@ -125,8 +117,6 @@ rules:
 
 	// Test removing a custom source rule with HTTPS URL and branch
 	t.Run("removes custom HTTPS source rule with branch", func(t *testing.T) {
-		t.Chdir(tempDir)
-
 		err = cmd.Execute(context.Background(), cliCmd, []string{"[contexture(https://github.com/org/rules.git):security/auth,v1.0]"})
 		require.NoError(t, err)
 
@@ -144,8 +134,6 @@ rules:
 
 	// Test removing non-existent custom source rule
 	t.Run("handles non-existent custom source rule", func(t *testing.T) {
-		t.Chdir(tempDir)
-
 		err = cmd.Execute(context.Background(), cliCmd, []string{"[contexture(git@github.com:nonexistent/repo.git):missing/rule]"})
 		require.NoError(t, err) // Should not error, just log warning
 
@@ -164,8 +152,9 @@ func TestRemoveCommand_RuleIDMatching(t *testing.T) {
 	// Use real filesystem for this test since we need actual directory operations
 	realFS := afero.NewOsFs()
 	deps := &dependencies.Dependencies{
-		FS:      realFS,
-		Context: context.Background(),
+		FS:         realFS,
+		Context:    context.Background(),
+		WorkingDir: dependencies.StaticWorkingDir(tempDir),
 	}
 
 	// Create configuration with various rule formats
@@ -186,12 +175,6 @@ rules:
 	err := afero.WriteFile(realFS, configPath, []byte(configContent), 0o644)
 	require.NoError(t, err)
 
-	// Save current directory and restore after test
-	originalWd, err := os.Getwd()
-	require.NoError(t, err)
-	//nolint:usetesting // Need to restore original dir at end of test
-	defer func() { _ = os.Chdir(originalWd) }()
-
 	removeCmd := NewRemoveCommand(deps)
 	cliCmd := &cli.Command{}
 
@@ -227,9 +210,6 @@ rules:
 			err := afero.WriteFile(realFS, configPath, []byte(configContent), 0o644)
 			require.NoError(t, err)
 
-			// Change to temp directory for this test
-			t.Chdir(tempDir)
-
 			err = removeCmd.Execute(context.Background(), cliCmd, []string{tt.ruleIDToRemove})
 			require.NoError(t, err)
 
@@ -0,0 +1,615 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/contextureai/contexture/internal/cache"
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/git"
+	"github.com/contextureai/contexture/internal/links"
+	"github.com/contextureai/contexture/internal/output"
+	"github.com/contextureai/contexture/internal/rule"
+	"github.com/contextureai/contexture/internal/template"
+	"github.com/spf13/afero"
+	"github.com/urfave/cli/v3"
+	"gopkg.in/yaml.v3"
+)
+
+// Lint check categories reported by the repo lint command
+const (
+	lintCheckID          = "id"
+	lintCheckFrontmatter = "frontmatter"
+	lintCheckTemplate    = "template"
+	lintCheckInclude     = "include"
+	lintCheckPartial     = "partial"
+	lintCheckIndex       = "index"
+	lintCheckLink        = "link"
+)
+
+// ruleAgeBuckets are the last-modified ranges reported by repo stats, oldest last
+var ruleAgeBuckets = []struct {
+	label  string
+	maxAge time.Duration
+}{
+	{label: "within 30 days", maxAge: 30 * 24 * time.Hour},
+	{label: "30-90 days ago", maxAge: 90 * 24 * time.Hour},
+	{label: "90-365 days ago", maxAge: 365 * 24 * time.Hour},
+	{label: "over a year ago"},
+}
+
+// RepoCommand implements the repo commands for rule repository maintainers
+type RepoCommand struct {
+	fs         afero.Fs
+	parser     rule.Parser
+	repository git.Repository
+	// linkChecker checks the HTTP links of rules unless lint runs offline
+	linkChecker *links.Checker
+	// now returns the current time; replaced in tests
+	now        func() time.Time
+	workingDir dependencies.WorkingDir
+	env        dependencies.Environment
+}
+
+// repoRule is a rule file found in a rule repository
+type repoRule struct {
+	id   string // path relative to the repository root without the .md extension
+	path string
+}
+
+// NewRepoCommand creates a new repo command
+func NewRepoCommand(deps *dependencies.Dependencies) *RepoCommand {
+	return &RepoCommand{
+		fs:          deps.FS,
+		parser:      rule.NewParser(),
+		repository:  newOpenRepository(deps.FS),
+		linkChecker: links.NewChecker(deps.FS, filepath.Join(cache.BaseDir(deps.GetEnv()), links.CacheFile)),
+		now:         time.Now,
+		workingDir:  deps.GetWorkingDir(),
+		env:         deps.GetEnv(),
+	}
+}
+
+// Lint validates the layout of the rule repository in the given directory,
+// or the current directory when none is given
+func (c *RepoCommand) Lint(ctx context.Context, cmd *cli.Command) error {
+	repoDir := cmd.Args().First()
+	if repoDir == "" {
+		currentDir, err := c.workingDir.Getwd()
+		if err != nil {
+			return contextureerrors.Wrap(err, "get current directory")
+		}
+		repoDir = currentDir
+	}
+
+	return c.lint(ctx, cmd, repoDir)
+}
+
+// lint checks every rule in repoDir and reports the issues found. HTTP links
+// are checked unless the offline flag is set.
+func (c *RepoCommand) lint(ctx context.Context, cmd *cli.Command, repoDir string) error {
+	outputManager, err := output.NewManager(output.Format(cmd.String("output")), c.workingDir, c.env)
+	if err != nil {
+		return contextureerrors.Wrap(err, "create output manager")
+	}
+
+	issues, metadata, err := c.lintRepository(repoDir)
+	if err != nil {
+		return err
+	}
+	if !cmd.Bool("offline") {
+		linkIssues, err := c.lintHTTPLinks(ctx, repoDir)
+		if err != nil {
+			return err
+		}
+		issues = append(issues, linkIssues...)
+		metadata.Valid = len(issues) == 0
+	}
+	if err := outputManager.WriteLintResults(issues, metadata); err != nil {
+		return contextureerrors.Wrap(err, "write lint results")
+	}
+
+	if !metadata.Valid {
+		return contextureerrors.ValidationErrorf("repository", "lint failed with %d issue(s)", len(issues))
+	}
+	return nil
+}
+
+// lintRepository runs every lint check against the rule repository in repoDir
+func (c *RepoCommand) lintRepository(repoDir string) ([]output.ValidationIssue, output.LintMetadata, error) {
+	rules, err := c.findRules(repoDir)
+	if err != nil {
+		return nil, output.LintMetadata{}, err
+	}
+
+	issues := lintRuleIDs(rules)
+	titles := make(map[string]string, len(rules))
+	for _, r := range rules {
+		ruleIssues, title := c.lintRule(r)
+		issues = append(issues, ruleIssues...)
+		titles[r.id] = title
+
+		linkIssues, err := c.lintRelativeLinks(repoDir, r)
+		if err != nil {
+			return nil, output.LintMetadata{}, err
+		}
+		issues = append(issues, linkIssues...)
+	}
+
+	indexIssues, indexed, err := c.lintIndex(repoDir, rules, titles)
+	if err != nil {
+		return nil, output.LintMetadata{}, err
+	}
+	issues = append(issues, indexIssues...)
+
+	return issues, output.LintMetadata{
+		Path:         repoDir,
+		RulesChecked: len(rules),
+		Indexed:      indexed,
+		Valid:        len(issues) == 0,
+	}, nil
+}
+
+// lintRelativeLinks checks that the relative links in a rule point to files
+// within the repository
+func (c *RepoCommand) lintRelativeLinks(repoDir string, r repoRule) ([]output.ValidationIssue, error) {
+	content, err := afero.ReadFile(c.fs, r.path)
+	if err != nil {
+		return nil, nil //nolint:nilerr // Unreadable rules are reported by the frontmatter check
+	}
+	relPath, err := filepath.Rel(repoDir, r.path)
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "resolve rule path")
+	}
+
+	var issues []output.ValidationIssue
+	for _, link := range links.Extract(string(content)) {
+		if !link.IsRelative() {
+			continue
+		}
+		resolved, ok := links.ResolveRelative(filepath.ToSlash(relPath), link.Target)
+		if !ok {
+			issues = append(issues, output.ValidationIssue{
+				Check:   lintCheckLink,
+				Path:    r.path,
+				Message: fmt.Sprintf("line %d: link %q points outside the repository", link.Line, link.Target),
+			})
+			continue
+		}
+		exists, err := afero.Exists(c.fs, filepath.Join(repoDir, filepath.FromSlash(resolved)))
+		if err != nil {
+			return nil, contextureerrors.Wrap(err, "check link target")
+		}
+		if !exists {
+			issues = append(issues, output.ValidationIssue{
+				Check:   lintCheckLink,
+				Path:    r.path,
+				Message: fmt.Sprintf("line %d: link %q does not resolve to a file in the repository", link.Line, link.Target),
+			})
+		}
+	}
+	return issues, nil
+}
+
+// lintHTTPLinks checks that the HTTP links in the rules of repoDir respond.
+// Each URL is requested once however many rules link to it, and URLs that
+// responded recently are not requested again.
+func (c *RepoCommand) lintHTTPLinks(ctx context.Context, repoDir string) ([]output.ValidationIssue, error) {
+	rules, err := c.findRules(repoDir)
+	if err != nil {
+		return nil, err
+	}
+
+	type occurrence struct {
+		path string
+		line int
+	}
+	var urls []string
+	occurrences := map[string][]occurrence{}
+	for _, r := range rules {
+		content, err := afero.ReadFile(c.fs, r.path)
+		if err != nil {
+			continue
+		}
+		for _, link := range links.Extract(string(content)) {
+			if !link.IsHTTP() {
+				continue
+			}
+			if _, seen := occurrences[link.Target]; !seen {
+				urls = append(urls, link.Target)
+			}
+			occurrences[link.Target] = append(occurrences[link.Target], occurrence{path: r.path, line: link.Line})
+		}
+	}
+	if len(urls) == 0 {
+		return nil, nil
+	}
+
+	failures, err := c.linkChecker.CheckAll(ctx, urls)
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "check links")
+	}
+	var issues []output.ValidationIssue
+	for _, target := range urls {
+		failure, failed := failures[target]
+		if !failed {
+			continue
+		}
+		for _, occ := range occurrences[target] {
+			issues = append(issues, output.ValidationIssue{
+				Check:   lintCheckLink,
+				Path:    occ.path,
+				Message: fmt.Sprintf("line %d: link %s is broken: %v", occ.line, target, failure),
+			})
+		}
+	}
+	return issues, nil
+}
+
+// findRules returns the rule files in repoDir sorted by ID, skipping hidden
+// directories and READMEs the same way rule fetching does
+func (c *RepoCommand) findRules(repoDir string) ([]repoRule, error) {
+	exists, err := afero.DirExists(c.fs, repoDir)
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "check repository directory")
+	}
+	if !exists {
+		return nil, contextureerrors.ValidationErrorf("path", "repository directory not found: %s", repoDir)
+	}
+
+	var rules []repoRule
+	err = afero.Walk(c.fs, repoDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path != repoDir && strings.HasPrefix(info.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(info.Name(), domain.MarkdownExt) || strings.EqualFold(info.Name(), "readme.md") {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(repoDir, path)
+		if err != nil {
+			return contextureerrors.Wrap(err, "get relative path")
+		}
+		rules = append(rules, repoRule{
+			id:   filepath.ToSlash(strings.TrimSuffix(relPath, domain.MarkdownExt)),
+			path: path,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "walk repository")
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].id < rules[j].id
+	})
+	return rules, nil
+}
+
+// lintRuleIDs reports rule IDs that differ only in case, since they collide
+// on case-insensitive filesystems and in provider lookups
+func lintRuleIDs(rules []repoRule) []output.ValidationIssue {
+	var issues []output.ValidationIssue
+	seen := make(map[string]string, len(rules))
+	for _, r := range rules {
+		key := strings.ToLower(r.id)
+		if other, ok := seen[key]; ok {
+			issues = append(issues, output.ValidationIssue{
+				Check:   lintCheckID,
+				Path:    r.path,
+				Message: fmt.Sprintf("rule ID %q conflicts with %q", r.id, other),
+			})
+			continue
+		}
+		seen[key] = r.id
+	}
+	return issues
+}
+
+// lintRule checks a rule's frontmatter and partials and returns its title
+func (c *RepoCommand) lintRule(r repoRule) ([]output.ValidationIssue, string) {
+	data, err := afero.ReadFile(c.fs, r.path)
+	if err != nil {
+		return []output.ValidationIssue{{Check: lintCheckFrontmatter, Path: r.path, Message: err.Error()}}, ""
+	}
+
+	// Plain markdown is accepted for local rules but not in a shared repository
+	if !strings.HasPrefix(strings.TrimSpace(string(data)), "---") {
+		return []output.ValidationIssue{{
+			Check:   lintCheckFrontmatter,
+			Path:    r.path,
+			Message: "missing frontmatter: add title, description, and tags",
+		}}, ""
+	}
+
+	parsed, err := c.parser.ParseRule(string(data), rule.Metadata{
+		ID:       fmt.Sprintf("[contexture:%s]", r.id),
+		FilePath: r.path,
+		Source:   domain.DefaultSource,
+	})
+	if err != nil {
+		return []output.ValidationIssue{{Check: lintCheckFrontmatter, Path: r.path, Message: err.Error()}}, ""
+	}
+
+	defined, included, err := template.Partials(parsed.Content)
+	if err != nil {
+		return []output.ValidationIssue{{Check: lintCheckTemplate, Path: r.path, Message: err.Error()}}, parsed.Title
+	}
+
+	var issues []output.ValidationIssue
+	for _, name := range included {
+		if !slices.Contains(defined, name) {
+			issues = append(issues, output.ValidationIssue{
+				Check:   lintCheckInclude,
+				Path:    r.path,
+				Message: fmt.Sprintf("includes undefined partial %q", name),
+			})
+		}
+	}
+	for _, name := range defined {
+		if !slices.Contains(included, name) {
+			issues = append(issues, output.ValidationIssue{
+				Check:   lintCheckPartial,
+				Path:    r.path,
+				Message: fmt.Sprintf("partial %q is never included", name),
+			})
+		}
+	}
+
+	return issues, parsed.Title
+}
+
+// lintIndex checks that the repository index, when present, lists exactly the
+// rules in the repository with matching titles. It reports whether an index exists.
+func (c *RepoCommand) lintIndex(
+	repoDir string,
+	rules []repoRule,
+	titles map[string]string,
+) ([]output.ValidationIssue, bool, error) {
+	indexPath := filepath.Join(repoDir, domain.RepositoryIndexFile)
+	data, err := afero.ReadFile(c.fs, indexPath)
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, contextureerrors.Wrap(err, "read repository index")
+	}
+
+	var index domain.RepositoryIndex
+	if err := yaml.Unmarshal(data, &index); err != nil {
+		return []output.ValidationIssue{{Check: lintCheckIndex, Path: indexPath, Message: err.Error()}}, true, nil
+	}
+
+	var issues []output.ValidationIssue
+	if index.Name == "" {
+		issues = append(issues, output.ValidationIssue{Check: lintCheckIndex, Path: indexPath, Message: "name is required"})
+	}
+
+	listed := make(map[string]bool, len(index.Rules))
+	for _, entry := range index.Rules {
+		id := strings.TrimSuffix(entry.ID, domain.MarkdownExt)
+		switch {
+		case id == "":
+			issues = append(issues, output.ValidationIssue{Check: lintCheckIndex, Path: indexPath, Message: "rule entry has no id"})
+			continue
+		case listed[id]:
+			issues = append(issues, output.ValidationIssue{
+				Check: lintCheckIndex, Path: indexPath, Message: fmt.Sprintf("rule %q is listed more than once", id),
+			})
+			continue
+		}
+		listed[id] = true
+
+		title, ok := titles[id]
+		switch {
+		case !ok:
+			issues = append(issues, output.ValidationIssue{
+				Check: lintCheckIndex, Path: indexPath, Message: fmt.Sprintf("rule %q is listed but has no file", id),
+			})
+		case entry.Title != "" && title != "" && entry.Title != title:
+			issues = append(issues, output.ValidationIssue{
+				Check:   lintCheckIndex,
+				Path:    indexPath,
+				Message: fmt.Sprintf("rule %q is listed with title %q but its frontmatter says %q", id, entry.Title, title),
+			})
+		}
+	}
+
+	for _, r := range rules {
+		if !listed[r.id] {
+			issues = append(issues, output.ValidationIssue{
+				Check: lintCheckIndex, Path: r.path, Message: fmt.Sprintf("rule is not listed in %s", domain.RepositoryIndexFile),
+			})
+		}
+	}
+
+	return issues, true, nil
+}
+
+// Stats reports statistics for the rule repository at a local path or Git URL,
+// or the current directory when none is given
+func (c *RepoCommand) Stats(ctx context.Context, cmd *cli.Command) error {
+	source := cmd.Args().First()
+	repoDir := source
+	// Local repositories are dated from their checked-out HEAD
+	ref := ""
+	switch {
+	case source == "":
+		currentDir, err := c.workingDir.Getwd()
+		if err != nil {
+			return contextureerrors.Wrap(err, "get current directory")
+		}
+		source, repoDir = currentDir, currentDir
+	case strings.HasPrefix(source, "https://") || strings.HasPrefix(source, "git@"):
+		var err error
+		ref = cmd.String("ref")
+		repoDir, err = cache.NewSimpleCache(c.fs, c.env, c.repository).GetRepositoryWithUpdate(ctx, source, ref)
+		if err != nil {
+			return contextureerrors.Wrap(err, "fetch repository")
+		}
+	}
+
+	return c.stats(cmd, source, repoDir, ref)
+}
+
+// stats collects and reports the statistics of the rule repository in repoDir
+func (c *RepoCommand) stats(cmd *cli.Command, source, repoDir, ref string) error {
+	outputManager, err := output.NewManager(output.Format(cmd.String("output")), c.workingDir, c.env)
+	if err != nil {
+		return contextureerrors.Wrap(err, "create output manager")
+	}
+
+	stats, err := c.collectStats(source, repoDir, ref)
+	if err != nil {
+		return err
+	}
+
+	if err := outputManager.WriteRepoStats(stats); err != nil {
+		return contextureerrors.Wrap(err, "write repo stats")
+	}
+	return nil
+}
+
+// collectStats reads every rule in repoDir. Rules are dated by their last
+// commit on ref when repoDir is a Git repository, and by file time otherwise.
+func (c *RepoCommand) collectStats(source, repoDir, ref string) (output.RepoStats, error) {
+	rules, err := c.findRules(repoDir)
+	if err != nil {
+		return output.RepoStats{}, err
+	}
+
+	stats := output.RepoStats{
+		Source:     source,
+		TotalRules: len(rules),
+		ByFolder:   make(map[string]int),
+		ByTag:      make(map[string]int),
+		ByLanguage: make(map[string]int),
+	}
+	ages := make([]int, len(ruleAgeBuckets))
+	isGit := c.repository.IsValidRepository(repoDir)
+	now := c.now()
+	totalBodyLength := 0
+
+	for _, r := range rules {
+		folder := "."
+		if i := strings.Index(r.id, "/"); i >= 0 {
+			folder = r.id[:i]
+		}
+		stats.ByFolder[folder]++
+
+		modified, err := c.lastModified(r, repoDir, ref, isGit)
+		if err != nil {
+			return output.RepoStats{}, err
+		}
+		ages[ageBucket(now.Sub(modified))]++
+
+		data, err := afero.ReadFile(c.fs, r.path)
+		if err != nil {
+			return output.RepoStats{}, contextureerrors.Wrap(err, "read rule")
+		}
+		frontmatter, body, err := c.parser.ParseContent(string(data))
+		if err != nil {
+			stats.Unreadable = append(stats.Unreadable, r.id)
+			continue
+		}
+		totalBodyLength += len(strings.TrimSpace(body))
+
+		if description, _ := frontmatter["description"].(string); strings.TrimSpace(description) == "" {
+			stats.MissingDescription = append(stats.MissingDescription, r.id)
+		}
+		if frontmatter["trigger"] == nil {
+			stats.MissingTrigger = append(stats.MissingTrigger, r.id)
+		}
+		for _, tag := range frontmatterStrings(frontmatter["tags"]) {
+			stats.ByTag[tag]++
+		}
+		for _, language := range frontmatterStrings(frontmatter["languages"]) {
+			stats.ByLanguage[language]++
+		}
+	}
+
+	if parsed := len(rules) - len(stats.Unreadable); parsed > 0 {
+		stats.AverageBodyLength = totalBodyLength / parsed
+	}
+	for i, bucket := range ruleAgeBuckets {
+		stats.LastModified = append(stats.LastModified, output.AgeBucket{Label: bucket.label, Rules: ages[i]})
+	}
+
+	return stats, nil
+}
+
+// lastModified returns when a rule was last changed
+func (c *RepoCommand) lastModified(r repoRule, repoDir, ref string, isGit bool) (time.Time, error) {
+	if isGit {
+		info, err := c.repository.GetFileCommitInfo(repoDir, r.id+domain.MarkdownExt, ref)
+		if err == nil {
+			if date, err := time.Parse("2 Jan 2006", info.Date); err == nil {
+				return date, nil
+			}
+		}
+	}
+
+	fileInfo, err := c.fs.Stat(r.path)
+	if err != nil {
+		return time.Time{}, contextureerrors.Wrap(err, "stat rule")
+	}
+	return fileInfo.ModTime(), nil
+}
+
+// ageBucket returns the index of the age bucket for a rule of the given age
+func ageBucket(age time.Duration) int {
+	for i, bucket := range ruleAgeBuckets {
+		if bucket.maxAge == 0 || age < bucket.maxAge {
+			return i
+		}
+	}
+	return len(ruleAgeBuckets) - 1
+}
+
+// frontmatterStrings reads a frontmatter field that holds a string or a list of strings
+func frontmatterStrings(value any) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// RepoAction is the CLI action handler for the repo command group
+func RepoAction(_ context.Context, cmd *cli.Command, _ *dependencies.Dependencies) error {
+	return cli.ShowSubcommandHelp(cmd)
+}
+
+// RepoLintAction is the CLI action handler for the repo lint command
+func RepoLintAction(ctx context.Context, cmd *cli.Command, deps *dependencies.Dependencies) error {
+	repoCmd := NewRepoCommand(deps)
+	return repoCmd.Lint(ctx, cmd)
+}
+
+// RepoStatsAction is the CLI action handler for the repo stats command
+func RepoStatsAction(ctx context.Context, cmd *cli.Command, deps *dependencies.Dependencies) error {
+	repoCmd := NewRepoCommand(deps)
+	return repoCmd.Stats(ctx, cmd)
+}
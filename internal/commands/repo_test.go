@@ -0,0 +1,199 @@
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/output"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+// runRepoLint runs the repo lint command against repoDir and returns its error
+func runRepoLint(t *testing.T, repoCmd *RepoCommand, repoDir string) error {
+	t.Helper()
+
+	var execErr error
+	app := &cli.Command{
+		Name: "lint",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "output", Value: "json"},
+			&cli.BoolFlag{Name: "offline", Value: true},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			execErr = repoCmd.lint(ctx, cmd, repoDir)
+			return nil
+		},
+	}
+
+	require.NoError(t, app.Run(context.Background(), []string{"lint"}))
+	return execErr
+}
+
+func TestNewRepoCommand(t *testing.T) {
+	cmd := NewRepoCommand(createTestDependencies())
+
+	assert.NotNil(t, cmd)
+	assert.NotNil(t, cmd.fs)
+	assert.NotNil(t, cmd.parser)
+}
+
+func TestRepoCommand_LintScaffoldedRepository(t *testing.T) {
+	deps := createTestDependencies()
+	require.NoError(t, runNewRepoCommand(t, NewRepoScaffoldCommand(deps), "/repo"))
+
+	require.NoError(t, runRepoLint(t, NewRepoCommand(deps), "/repo"))
+}
+
+func TestRepoCommand_LintPlainMarkdown(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	writeTestFile(t, fs, "/repo/go/notes.md", "# Notes\n\nKeep it simple.\n")
+
+	repoCmd := NewRepoCommand(createTestDependencies())
+	repoCmd.fs = fs
+
+	issues, _, err := repoCmd.lintRepository("/repo")
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, "frontmatter", issues[0].Check)
+	assert.Equal(t, "missing frontmatter: add title, description, and tags", issues[0].Message)
+}
+
+func TestRepoCommand_LintIssues(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	writeTestFile(t, fs, "/repo/index.yaml", `name: team
+rules:
+  - id: go/style
+    title: Go Style
+  - id: go/style
+  - id: go/removed
+`)
+	writeTestFile(t, fs, "/repo/go/style.md",
+		"---\ntitle: Go Style Guide\ndescription: Go style\ntags: [go]\n---\n\n{{define \"footer\"}}x{{end}}{{template \"header\"}}\n")
+	writeTestFile(t, fs, "/repo/Go/Style.md", "---\ntitle: Other\ndescription: Other\ntags: [go]\n---\n\nbody\n")
+	writeTestFile(t, fs, "/repo/broken.md", "---\ntitle: [\n---\n")
+	writeTestFile(t, fs, "/repo/README.md", "# Rules\n")
+	writeTestFile(t, fs, "/repo/.github/notes.md", "not a rule")
+
+	repoCmd := NewRepoCommand(createTestDependencies())
+	repoCmd.fs = fs
+
+	issues, metadata, err := repoCmd.lintRepository("/repo")
+	require.NoError(t, err)
+	assert.Equal(t, 3, metadata.RulesChecked)
+	assert.True(t, metadata.Indexed)
+	assert.False(t, metadata.Valid)
+
+	messages := make(map[string]string, len(issues))
+	for _, issue := range issues {
+		assert.NotContains(t, issue.Path, "README")
+		assert.NotContains(t, issue.Path, ".github")
+		messages[issue.Message] = issue.Check
+	}
+	assert.Equal(t, "id", messages[`rule ID "go/style" conflicts with "Go/Style"`])
+	assert.Equal(t, "include", messages[`includes undefined partial "header"`])
+	assert.Equal(t, "partial", messages[`partial "footer" is never included`])
+	assert.Equal(t, "index", messages[`rule "go/style" is listed more than once`])
+	assert.Equal(t, "index", messages[`rule "go/removed" is listed but has no file`])
+	assert.Equal(t, "index", messages[`rule "go/style" is listed with title "Go Style" but its frontmatter says "Go Style Guide"`])
+	assert.Equal(t, "index", messages["rule is not listed in index.yaml"])
+
+	var frontmatterIssues int
+	for _, issue := range issues {
+		if issue.Check == "frontmatter" {
+			frontmatterIssues++
+			assert.Equal(t, "/repo/broken.md", issue.Path)
+		}
+	}
+	assert.Equal(t, 1, frontmatterIssues)
+
+	err = runRepoLint(t, repoCmd, "/repo")
+	require.Error(t, err)
+	assert.Equal(t, int(contextureerrors.ExitValidation), contextureerrors.ExitCodeFor(err))
+}
+
+func TestRepoCommand_LintWithoutIndex(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	writeTestFile(t, fs, "/repo/go/style.md", "---\ntitle: Go Style\ndescription: Go style\ntags: [go]\n---\n\nbody\n")
+	repoCmd := NewRepoCommand(createTestDependencies())
+	repoCmd.fs = fs
+
+	require.NoError(t, runRepoLint(t, repoCmd, "/repo"))
+
+	err := runRepoLint(t, repoCmd, "/missing")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestRepoCommand_Stats(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	writeTestFile(t, fs, "/repo/go/style.md",
+		"---\ntitle: Go Style\ndescription: Go style\ntags: [go, style]\nlanguages: [go]\ntrigger: always\n---\n\n1234567890\n")
+	writeTestFile(t, fs, "/repo/go/errors.md", "---\ntitle: Errors\ntags: go\n---\n\n12345\n")
+	writeTestFile(t, fs, "/repo/general.md", "No frontmatter at all\n")
+	writeTestFile(t, fs, "/repo/broken.md", "---\ntitle: [\n---\n")
+	writeTestFile(t, fs, "/repo/README.md", "# Rules\n")
+
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, fs.Chtimes("/repo/go/style.md", now, now.Add(-24*time.Hour)))
+	require.NoError(t, fs.Chtimes("/repo/go/errors.md", now, now.Add(-60*24*time.Hour)))
+	require.NoError(t, fs.Chtimes("/repo/general.md", now, now.Add(-400*24*time.Hour)))
+	require.NoError(t, fs.Chtimes("/repo/broken.md", now, now.Add(-400*24*time.Hour)))
+
+	repoCmd := NewRepoCommand(createTestDependencies())
+	repoCmd.fs = fs
+	repoCmd.now = func() time.Time { return now }
+
+	stats, err := repoCmd.collectStats("/repo", "/repo", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, stats.TotalRules)
+	assert.Equal(t, map[string]int{".": 2, "go": 2}, stats.ByFolder)
+	assert.Equal(t, map[string]int{"go": 2, "style": 1}, stats.ByTag)
+	assert.Equal(t, map[string]int{"go": 1}, stats.ByLanguage)
+	assert.Equal(t, (10+5+len("No frontmatter at all"))/3, stats.AverageBodyLength)
+	assert.Equal(t, []output.AgeBucket{
+		{Label: "within 30 days", Rules: 1},
+		{Label: "30-90 days ago", Rules: 1},
+		{Label: "90-365 days ago", Rules: 0},
+		{Label: "over a year ago", Rules: 2},
+	}, stats.LastModified)
+	assert.Equal(t, []string{"general", "go/errors"}, stats.MissingDescription)
+	assert.Equal(t, []string{"general", "go/errors"}, stats.MissingTrigger)
+	assert.Equal(t, []string{"broken"}, stats.Unreadable)
+
+	var execErr error
+	app := &cli.Command{
+		Name:  "stats",
+		Flags: []cli.Flag{&cli.StringFlag{Name: "output", Value: "json"}},
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			execErr = repoCmd.stats(cmd, "/repo", "/repo", "")
+			return nil
+		},
+	}
+	require.NoError(t, app.Run(context.Background(), []string{"stats"}))
+	require.NoError(t, execErr)
+}
+
+func TestRepoCommand_LintRelativeLinks(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	writeTestFile(t, fs, "/repo/go/errors.md", "---\ntitle: Errors\ndescription: Errors\ntags: [go]\n---\n\n"+
+		"See [style](style.md), [testing](../go/testing.md#setup), and [outside](../../secrets.md).\n"+
+		"Read https://go.dev/blog/errors.\n")
+	writeTestFile(t, fs, "/repo/go/style.md", "---\ntitle: Style\ndescription: Style\ntags: [go]\n---\n\nbody\n")
+
+	repoCmd := NewRepoCommand(createTestDependencies())
+	repoCmd.fs = fs
+
+	issues, metadata, err := repoCmd.lintRepository("/repo")
+	require.NoError(t, err)
+	assert.False(t, metadata.Valid)
+	require.Len(t, issues, 2)
+	assert.Equal(t, "link", issues[0].Check)
+	assert.Equal(t, `line 7: link "../go/testing.md#setup" does not resolve to a file in the repository`, issues[0].Message)
+	assert.Equal(t, `line 7: link "../../secrets.md" points outside the repository`, issues[1].Message)
+}
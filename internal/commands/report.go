@@ -0,0 +1,427 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/diff"
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/git"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/contextureai/contexture/internal/provider"
+	"github.com/contextureai/contexture/internal/rule"
+	"github.com/spf13/afero"
+	"github.com/urfave/cli/v3"
+	"gopkg.in/yaml.v3"
+)
+
+// reportDiffContext is the number of unchanged lines shown around changes in report diffs
+const reportDiffContext = 3
+
+// ruleChangeKind is how a rule changed between two configurations
+type ruleChangeKind string
+
+const (
+	ruleAdded   ruleChangeKind = "added"
+	ruleRemoved ruleChangeKind = "removed"
+	ruleUpdated ruleChangeKind = "updated"
+)
+
+// ruleChange is a rule that differs between the base and current configurations
+type ruleChange struct {
+	kind ruleChangeKind
+	// before is the rule in the base configuration, nil for added rules
+	before *domain.RuleRef
+	// after is the rule in the current configuration, nil for removed rules
+	after *domain.RuleRef
+	// details describe how an updated rule's reference changed
+	details []string
+	// title is the title of the rule's content, when it could be fetched
+	title string
+	// link is the URL of the rule file in its source repository, when known
+	link string
+	// diff is the content diff of an updated rule
+	diff []diff.Line
+	// problem explains why the rule's content is missing from the report
+	problem string
+}
+
+// id returns the ID of the changed rule
+func (c ruleChange) id() string {
+	if c.after != nil {
+		return c.after.ID
+	}
+	return c.before.ID
+}
+
+// ReportCommand implements the report commands
+type ReportCommand struct {
+	fs               afero.Fs
+	projectManager   *project.Manager
+	ruleFetcher      rule.Fetcher
+	gitRepo          git.Repository
+	providerRegistry *provider.Registry
+	workingDir       dependencies.WorkingDir
+}
+
+// NewReportCommand creates a new report command
+func NewReportCommand(deps *dependencies.Dependencies) *ReportCommand {
+	gitRepo := newOpenRepository(deps.FS)
+	return &ReportCommand{
+		fs:               deps.FS,
+		projectManager:   project.NewManagerFromDependencies(deps),
+		ruleFetcher:      rule.NewFetcher(deps.FS, gitRepo, fetcherConfig(deps), deps.ProviderRegistry),
+		gitRepo:          gitRepo,
+		providerRegistry: deps.ProviderRegistry,
+		workingDir:       deps.GetWorkingDir(),
+	}
+}
+
+// PR prints a markdown summary of the rules added, removed, and updated since
+// the configuration at the base git ref, for posting as a pull request comment
+func (c *ReportCommand) PR(ctx context.Context, cmd *cli.Command) error {
+	base := cmd.String("base")
+	if base == "" {
+		return contextureerrors.Validation("base", "a base git ref is required").
+			WithSuggestions(contextureerrors.RunCommand(
+				"contexture report pr --base origin/main",
+				"compare against the branch the pull request targets"))
+	}
+
+	configLoadResult, err := LoadProjectConfig(c.projectManager, c.workingDir)
+	if err != nil {
+		return err
+	}
+
+	baseConfig, err := c.loadBaseConfig(configLoadResult.ConfigPath, base)
+	if err != nil {
+		return err
+	}
+
+	// Providers removed by the change are still needed to describe removed rules
+	for _, config := range []*domain.Project{baseConfig, configLoadResult.Config} {
+		if err := c.providerRegistry.LoadFromProject(config); err != nil {
+			return contextureerrors.Wrap(err, "load providers")
+		}
+	}
+
+	changes := c.ruleChanges(baseConfig, configLoadResult.Config)
+	for i := range changes {
+		c.describe(ctx, &changes[i], cmd.Bool("offline"))
+	}
+
+	fmt.Print(renderPRReport(base, changes))
+	return nil
+}
+
+// loadBaseConfig reads the configuration at configPath as it was at the base
+// git ref. A configuration that did not exist yet is empty.
+func (c *ReportCommand) loadBaseConfig(configPath, base string) (*domain.Project, error) {
+	repoRoot := findRepositoryRoot(c.fs, filepath.Dir(configPath))
+	if repoRoot == "" {
+		return nil, contextureerrors.ValidationErrorf("base", "%s is not in a git repository", filepath.Dir(configPath))
+	}
+	relPath, err := filepath.Rel(repoRoot, configPath)
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "resolve configuration path")
+	}
+
+	data, err := c.gitRepo.GetFileAtCommit(repoRoot, filepath.ToSlash(relPath), base)
+	if errors.Is(err, git.ErrFileNotFound) {
+		return &domain.Project{}, nil
+	}
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "read configuration at "+base)
+	}
+
+	var config domain.Project
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, contextureerrors.Wrap(err, "parse configuration at "+base)
+	}
+	return &config, nil
+}
+
+// ruleChanges lists the rules added, removed, and updated from before to
+// after. Local rules are left out, since their files show in the pull request.
+func (c *ReportCommand) ruleChanges(before, after *domain.Project) []ruleChange {
+	var changes []ruleChange
+
+	for i := range after.Rules {
+		current := &after.Rules[i]
+		if current.Source == "local" || current.Inherited {
+			continue
+		}
+		previous := c.projectManager.FindRule(before, current.ID)
+		if previous == nil {
+			changes = append(changes, ruleChange{kind: ruleAdded, after: current})
+			continue
+		}
+		if details := refChanges(previous, current); len(details) > 0 {
+			changes = append(changes, ruleChange{kind: ruleUpdated, before: previous, after: current, details: details})
+		}
+	}
+
+	for i := range before.Rules {
+		previous := &before.Rules[i]
+		if previous.Source == "local" {
+			continue
+		}
+		if c.projectManager.FindRule(after, previous.ID) == nil {
+			changes = append(changes, ruleChange{kind: ruleRemoved, before: previous})
+		}
+	}
+
+	return changes
+}
+
+// refChanges describes how a rule reference changed
+func refChanges(before, after *domain.RuleRef) []string {
+	var details []string
+	if before.GetRef() != after.GetRef() {
+		details = append(details, fmt.Sprintf("ref %s → %s", before.GetRef(), after.GetRef()))
+	}
+	if before.CommitHash != after.CommitHash {
+		details = append(details, fmt.Sprintf("commit %s → %s", shortCommit(before.CommitHash), shortCommit(after.CommitHash)))
+	}
+	if before.Pinned != after.Pinned {
+		if after.Pinned {
+			details = append(details, "pinned")
+		} else {
+			details = append(details, "unpinned")
+		}
+	}
+	if !sameVariables(before.Variables, after.Variables) {
+		details = append(details, "variables changed")
+	}
+	if before.When != after.When {
+		details = append(details, "when expression changed")
+	}
+	if !reflect.DeepEqual(before.Priority, after.Priority) {
+		details = append(details, "priority changed")
+	}
+	if before.Section != after.Section {
+		details = append(details, "section changed")
+	}
+	return details
+}
+
+// shortCommit abbreviates a commit hash for display
+func shortCommit(hash string) string {
+	if hash == "" {
+		return "none"
+	}
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
+
+// describe adds the source link, title, and content diff of a change. Unless
+// offline, the rule content is fetched on both sides of the change.
+func (c *ReportCommand) describe(ctx context.Context, change *ruleChange, offline bool) {
+	ref := change.after
+	if ref == nil {
+		ref = change.before
+	}
+	change.link = c.sourceLink(*ref)
+	if offline {
+		return
+	}
+
+	var before, after *domain.Rule
+	var err error
+	if change.before != nil {
+		if before, err = c.fetch(ctx, *change.before); err != nil {
+			change.problem = err.Error()
+			return
+		}
+		change.title = before.Title
+	}
+	if change.after != nil {
+		if after, err = c.fetch(ctx, *change.after); err != nil {
+			change.problem = err.Error()
+			return
+		}
+		change.title = after.Title
+	}
+	if before != nil && after != nil {
+		change.diff = diff.Lines(before.Content, after.Content)
+	}
+}
+
+// fetch fetches a rule as the build command would, at its recorded commit
+func (c *ReportCommand) fetch(ctx context.Context, ref domain.RuleRef) (*domain.Rule, error) {
+	rules, err := rule.FetchRulesParallel(ctx, c.ruleFetcher, []domain.RuleRef{ref}, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) == 0 {
+		return nil, contextureerrors.ValidationErrorf("rule", "rule not found: %s", ref.ID)
+	}
+	return rules[0], nil
+}
+
+// sourceLink returns the web URL of a rule file for repositories on GitHub
+// and GitLab, at its recorded commit or its ref, or "" for other hosts
+func (c *ReportCommand) sourceLink(ref domain.RuleRef) string {
+	parsed, err := c.ruleFetcher.ParseRuleID(ref.ID)
+	if err != nil {
+		return ""
+	}
+
+	repoURL := strings.TrimSuffix(parsed.Source, ".git")
+	var blob string
+	switch {
+	case strings.HasPrefix(repoURL, "https://github.com/"):
+		blob = "/blob/"
+	case strings.HasPrefix(repoURL, "https://gitlab.com/"):
+		blob = "/-/blob/"
+	default:
+		return ""
+	}
+
+	revision := ref.CommitHash
+	if revision == "" {
+		revision = parsed.Ref
+	}
+	if revision == "" {
+		revision = domain.DefaultBranch
+	}
+	return repoURL + blob + revision + "/" + parsed.RulePath + domain.MarkdownExt
+}
+
+// renderPRReport renders rule changes as a markdown pull request comment
+func renderPRReport(base string, changes []ruleChange) string {
+	var b strings.Builder
+	b.WriteString("## Contexture rule changes\n\n")
+	if len(changes) == 0 {
+		fmt.Fprintf(&b, "No rule changes compared with `%s`.\n", base)
+		return b.String()
+	}
+
+	counts := map[ruleChangeKind]int{}
+	for _, change := range changes {
+		counts[change.kind]++
+	}
+	fmt.Fprintf(&b, "Compared with `%s`: %d added, %d removed, %d updated.\n",
+		base, counts[ruleAdded], counts[ruleRemoved], counts[ruleUpdated])
+
+	sections := []struct {
+		kind    ruleChangeKind
+		heading string
+	}{
+		{ruleAdded, "Added"},
+		{ruleRemoved, "Removed"},
+		{ruleUpdated, "Updated"},
+	}
+	for _, section := range sections {
+		if counts[section.kind] == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n### %s\n\n", section.heading)
+		for _, change := range changes {
+			if change.kind == section.kind {
+				writeRuleChange(&b, change)
+			}
+		}
+	}
+	return b.String()
+}
+
+// writeRuleChange writes a rule change as a list item, followed by its
+// content diff in a collapsed block
+func writeRuleChange(b *strings.Builder, change ruleChange) {
+	name := "`" + change.id() + "`"
+	if change.link != "" {
+		name = "[" + name + "](" + change.link + ")"
+	}
+	b.WriteString("- " + name)
+	if change.title != "" {
+		b.WriteString(" — " + change.title)
+	}
+	if len(change.details) > 0 {
+		b.WriteString(" (" + strings.Join(change.details, ", ") + ")")
+	}
+	b.WriteString("\n")
+
+	switch {
+	case change.problem != "":
+		fmt.Fprintf(b, "  - Content unavailable: %s\n", change.problem)
+	case change.diff != nil && !diff.HasChanges(change.diff):
+		b.WriteString("  - Content unchanged\n")
+	case change.diff != nil:
+		b.WriteString("\n  <details><summary>Content diff</summary>\n\n  ```diff\n")
+		for _, line := range compactDiff(change.diff, reportDiffContext) {
+			b.WriteString("  " + line + "\n")
+		}
+		b.WriteString("  ```\n\n  </details>\n\n")
+	}
+}
+
+// compactDiff formats a diff in unified style, keeping only the changed lines
+// and up to context unchanged lines around them
+func compactDiff(lines []diff.Line, context int) []string {
+	keep := make([]bool, len(lines))
+	for i, line := range lines {
+		if line.Op == diff.OpEqual {
+			continue
+		}
+		for j := max(0, i-context); j <= min(len(lines)-1, i+context); j++ {
+			keep[j] = true
+		}
+	}
+
+	var out []string
+	skipped := false
+	for i, line := range lines {
+		if !keep[i] {
+			skipped = true
+			continue
+		}
+		if skipped && len(out) > 0 {
+			out = append(out, "@@")
+		}
+		skipped = false
+		switch line.Op {
+		case diff.OpInsert:
+			out = append(out, "+"+line.Text)
+		case diff.OpDelete:
+			out = append(out, "-"+line.Text)
+		default:
+			out = append(out, " "+line.Text)
+		}
+	}
+	return out
+}
+
+// findRepositoryRoot returns the closest directory from dir upwards that
+// contains .git, or "" when dir is not in a git repository
+func findRepositoryRoot(fs afero.Fs, dir string) string {
+	for {
+		if exists, _ := afero.Exists(fs, filepath.Join(dir, ".git")); exists {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// ReportAction is the CLI action handler for the report command group
+func ReportAction(_ context.Context, cmd *cli.Command, _ *dependencies.Dependencies) error {
+	return cli.ShowSubcommandHelp(cmd)
+}
+
+// ReportPRAction is the CLI action handler for the report pr command
+func ReportPRAction(ctx context.Context, cmd *cli.Command, deps *dependencies.Dependencies) error {
+	reportCmd := NewReportCommand(deps)
+	return reportCmd.PR(ctx, cmd)
+}
@@ -0,0 +1,176 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/diff"
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/git"
+	"github.com/contextureai/contexture/internal/rule"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const reportBaseConfig = `version: 1
+rules:
+  - id: "[contexture:go/errors]"
+    commitHash: 1111111aaaa
+  - id: "[contexture:team/legacy]"
+  - id: "[contexture:go/style]"
+`
+
+func TestReportCommand_RuleChanges(t *testing.T) {
+	t.Parallel()
+	reportCmd := NewReportCommand(createTestDependencies())
+
+	before := &domain.Project{Rules: []domain.RuleRef{
+		{ID: "[contexture:go/errors]", CommitHash: "1111111aaaa"},
+		{ID: "[contexture:team/legacy]"},
+		{ID: "[contexture:go/style]"},
+		{ID: "[contexture(local):notes]", Source: "local"},
+	}}
+	after := &domain.Project{Rules: []domain.RuleRef{
+		{ID: "[contexture:go/errors]", CommitHash: "2222222bbbb", Variables: map[string]any{"strict": true}},
+		{ID: "[contexture:go/style]"},
+		{ID: "[contexture:security/secrets]"},
+		{ID: "[contexture(local):team/api]", Source: "local"},
+		{ID: "[contexture:org/inherited]", Inherited: true},
+	}}
+
+	changes := reportCmd.ruleChanges(before, after)
+	require.Len(t, changes, 3)
+	assert.Equal(t, ruleUpdated, changes[0].kind)
+	assert.Equal(t, "[contexture:go/errors]", changes[0].id())
+	assert.Equal(t, []string{"commit 1111111 → 2222222", "variables changed"}, changes[0].details)
+	assert.Equal(t, ruleAdded, changes[1].kind)
+	assert.Equal(t, "[contexture:security/secrets]", changes[1].id())
+	assert.Equal(t, ruleRemoved, changes[2].kind)
+	assert.Equal(t, "[contexture:team/legacy]", changes[2].id())
+}
+
+func TestReportCommand_LoadBaseConfig(t *testing.T) {
+	t.Parallel()
+	deps := createTestDependencies()
+	reportCmd := NewReportCommand(deps)
+	mockRepo := git.NewMockRepository(t)
+	reportCmd.gitRepo = mockRepo
+
+	repoRoot := "/work/repo"
+	configPath := filepath.Join(repoRoot, "service", domain.ConfigFile)
+	require.NoError(t, deps.FS.MkdirAll(filepath.Join(repoRoot, ".git"), 0o755))
+
+	mockRepo.EXPECT().GetFileAtCommit(repoRoot, "service/"+domain.ConfigFile, "origin/main").
+		Return([]byte(reportBaseConfig), nil).Once()
+	config, err := reportCmd.loadBaseConfig(configPath, "origin/main")
+	require.NoError(t, err)
+	require.Len(t, config.Rules, 3)
+	assert.Equal(t, "1111111aaaa", config.Rules[0].CommitHash)
+
+	// A configuration added by the change has no rules at the base
+	mockRepo.EXPECT().GetFileAtCommit(repoRoot, "service/"+domain.ConfigFile, "HEAD~1").
+		Return(nil, contextureerrors.Wrap(git.ErrFileNotFound, "get_file")).Once()
+	config, err = reportCmd.loadBaseConfig(configPath, "HEAD~1")
+	require.NoError(t, err)
+	assert.Empty(t, config.Rules)
+
+	mockRepo.EXPECT().GetFileAtCommit(repoRoot, "service/"+domain.ConfigFile, "nope").
+		Return(nil, errors.New("reference not found")).Once()
+	_, err = reportCmd.loadBaseConfig(configPath, "nope")
+	require.Error(t, err)
+
+	_, err = reportCmd.loadBaseConfig("/elsewhere/"+domain.ConfigFile, "origin/main")
+	require.Error(t, err)
+}
+
+func TestReportCommand_Describe(t *testing.T) {
+	t.Parallel()
+	reportCmd := NewReportCommand(createTestDependencies())
+	fetcher := rule.NewMockFetcher(t)
+	reportCmd.ruleFetcher = fetcher
+
+	fetcher.EXPECT().ParseRuleID("[contexture:go/errors]").Return(&domain.ParsedRuleID{
+		Source:   "https://github.com/contextureai/rules.git",
+		RulePath: "go/errors",
+		Ref:      "main",
+	}, nil)
+	fetcher.EXPECT().FetchRule(mock.Anything, "[contexture:go/errors]").
+		Return(&domain.Rule{Title: "Go Errors", Content: "Wrap errors.\nReturn early."}, nil).Once()
+	fetcher.EXPECT().FetchRule(mock.Anything, "[contexture:go/errors]").
+		Return(&domain.Rule{Title: "Go Errors", Content: "Wrap errors with context.\nReturn early."}, nil).Once()
+
+	change := ruleChange{
+		kind:   ruleUpdated,
+		before: &domain.RuleRef{ID: "[contexture:go/errors]"},
+		after:  &domain.RuleRef{ID: "[contexture:go/errors]", Ref: "v2"},
+	}
+	reportCmd.describe(context.Background(), &change, false)
+
+	assert.Equal(t, "https://github.com/contextureai/rules/blob/main/go/errors.md", change.link)
+	assert.Equal(t, "Go Errors", change.title)
+	assert.Empty(t, change.problem)
+	assert.True(t, diff.HasChanges(change.diff))
+}
+
+func TestReportCommand_SourceLink(t *testing.T) {
+	t.Parallel()
+	reportCmd := NewReportCommand(createTestDependencies())
+	fetcher := rule.NewMockFetcher(t)
+	reportCmd.ruleFetcher = fetcher
+
+	fetcher.EXPECT().ParseRuleID("[contexture(https://gitlab.com/acme/rules):go/errors]").Return(&domain.ParsedRuleID{
+		Source:   "https://gitlab.com/acme/rules",
+		RulePath: "go/errors",
+	}, nil)
+	fetcher.EXPECT().ParseRuleID("[contexture(https://git.acme.dev/rules):go/errors]").Return(&domain.ParsedRuleID{
+		Source:   "https://git.acme.dev/rules",
+		RulePath: "go/errors",
+	}, nil)
+
+	assert.Equal(t, "https://gitlab.com/acme/rules/-/blob/abc123/go/errors.md", reportCmd.sourceLink(domain.RuleRef{
+		ID:         "[contexture(https://gitlab.com/acme/rules):go/errors]",
+		CommitHash: "abc123",
+	}))
+	assert.Empty(t, reportCmd.sourceLink(domain.RuleRef{ID: "[contexture(https://git.acme.dev/rules):go/errors]"}))
+}
+
+func TestRenderPRReport(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "## Contexture rule changes\n\nNo rule changes compared with `origin/main`.\n",
+		renderPRReport("origin/main", nil))
+
+	report := renderPRReport("origin/main", []ruleChange{
+		{
+			kind:    ruleUpdated,
+			before:  &domain.RuleRef{ID: "[contexture:go/errors]"},
+			after:   &domain.RuleRef{ID: "[contexture:go/errors]"},
+			details: []string{"ref main → v2"},
+			title:   "Go Errors",
+			link:    "https://github.com/contextureai/rules/blob/v2/go/errors.md",
+			diff:    diff.Lines("Wrap errors.\n", "Wrap errors with context.\n"),
+		},
+		{kind: ruleAdded, after: &domain.RuleRef{ID: "[contexture:security/secrets]"}, title: "Secrets"},
+		{kind: ruleRemoved, before: &domain.RuleRef{ID: "[contexture:team/legacy]"}, problem: "rule not found"},
+	})
+
+	assert.Contains(t, report, "Compared with `origin/main`: 1 added, 1 removed, 1 updated.")
+	assert.Contains(t, report, "### Added\n\n- `[contexture:security/secrets]` — Secrets\n")
+	assert.Contains(t, report, "### Removed\n\n- `[contexture:team/legacy]`\n  - Content unavailable: rule not found\n")
+	assert.Contains(t, report,
+		"- [`[contexture:go/errors]`](https://github.com/contextureai/rules/blob/v2/go/errors.md) — Go Errors (ref main → v2)\n")
+	assert.Contains(t, report, "  -Wrap errors.\n  +Wrap errors with context.\n")
+	assert.Less(t, strings.Index(report, "### Added"), strings.Index(report, "### Removed"))
+	assert.Less(t, strings.Index(report, "### Removed"), strings.Index(report, "### Updated"))
+}
+
+func TestCompactDiff(t *testing.T) {
+	t.Parallel()
+	lines := diff.Lines("a\nb\nc\nd\ne\nf\ng\nh\n", "a\nB\nc\nd\ne\nf\ng\nH\n")
+	assert.Equal(t, []string{" a", "-b", "+B", " c", "@@", " g", "-h", "+H"}, compactDiff(lines, 1))
+}
@@ -0,0 +1,293 @@
+// Package commands provides CLI command implementations
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/output"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/contextureai/contexture/internal/rule"
+	"github.com/spf13/afero"
+	"github.com/urfave/cli/v3"
+	"gopkg.in/yaml.v3"
+)
+
+// TestCommand implements the test command
+type TestCommand struct {
+	fs             afero.Fs
+	projectManager *project.Manager
+	parser         rule.Parser
+	processor      rule.Processor
+	workingDir     dependencies.WorkingDir
+	env            dependencies.Environment
+}
+
+// ruleTestCase is a test together with the file that declared it
+type ruleTestCase struct {
+	test domain.RuleTest
+	path string
+}
+
+// ruleTestSuite holds a rule and every test declared for it
+type ruleTestSuite struct {
+	rule     *domain.Rule
+	parseErr error
+	tests    []ruleTestCase
+}
+
+// NewTestCommand creates a new test command
+func NewTestCommand(deps *dependencies.Dependencies) *TestCommand {
+	return &TestCommand{
+		fs:             deps.FS,
+		projectManager: project.NewManagerFromDependencies(deps),
+		parser:         rule.NewParser(),
+		processor:      rule.NewProcessor(),
+		workingDir:     deps.GetWorkingDir(),
+		env:            deps.GetEnv(),
+	}
+}
+
+// Execute runs the test command
+func (c *TestCommand) Execute(_ context.Context, cmd *cli.Command) error {
+	currentDir, err := c.workingDir.Getwd()
+	if err != nil {
+		return contextureerrors.Wrap(err, "get current directory")
+	}
+
+	return c.run(cmd, currentDir)
+}
+
+// run collects and runs the rule tests for the project or rule repository in basePath
+func (c *TestCommand) run(cmd *cli.Command, basePath string) error {
+	outputManager, err := output.NewManager(output.Format(cmd.String("output")), c.workingDir, c.env)
+	if err != nil {
+		return contextureerrors.Wrap(err, "create output manager")
+	}
+
+	rulesDir, err := c.rulesDir(basePath)
+	if err != nil {
+		return err
+	}
+
+	suites, err := c.collectSuites(basePath, rulesDir)
+	if err != nil {
+		return err
+	}
+
+	// Restrict to the rules named on the command line
+	if cmd.Args().Len() > 0 {
+		selected := make(map[string]*ruleTestSuite)
+		for _, arg := range cmd.Args().Slice() {
+			rulePath := strings.TrimSuffix(domain.ExtractRulePath(arg), domain.MarkdownExt)
+			suite, ok := suites[rulePath]
+			if !ok {
+				return contextureerrors.ValidationErrorf("rule", "no tests found for rule %s", rulePath)
+			}
+			selected[rulePath] = suite
+		}
+		suites = selected
+	}
+
+	rulePaths := make([]string, 0, len(suites))
+	for rulePath := range suites {
+		rulePaths = append(rulePaths, rulePath)
+	}
+	sort.Strings(rulePaths)
+
+	var results []output.TestResult
+	metadata := output.TestMetadata{RulesTested: len(rulePaths)}
+	for _, rulePath := range rulePaths {
+		suite := suites[rulePath]
+		for i, testCase := range suite.tests {
+			result := c.runTest(rulePath, suite, testCase, i)
+			if !result.Passed {
+				metadata.TestsFailed++
+			}
+			results = append(results, result)
+		}
+	}
+	metadata.TestsRun = len(results)
+	metadata.Passed = metadata.TestsFailed == 0
+
+	if err := outputManager.WriteTestResults(results, metadata); err != nil {
+		return contextureerrors.Wrap(err, "write test results")
+	}
+
+	if !metadata.Passed {
+		return contextureerrors.ValidationErrorf("tests", "%d of %d rule test(s) failed", metadata.TestsFailed, metadata.TestsRun)
+	}
+	return nil
+}
+
+// runTest runs a single test case and converts the outcome to an output result
+func (c *TestCommand) runTest(rulePath string, suite *ruleTestSuite, testCase ruleTestCase, index int) output.TestResult {
+	result := output.TestResult{
+		Rule: rulePath,
+		Test: testCase.test.Name,
+		Path: testCase.path,
+	}
+	if result.Test == "" {
+		result.Test = fmt.Sprintf("test %d", index+1)
+	}
+
+	switch {
+	case suite.parseErr != nil:
+		result.Failures = []string{suite.parseErr.Error()}
+	case suite.rule == nil:
+		result.Failures = []string{"rule not found: " + rulePath}
+	default:
+		failures, err := rule.RunTest(c.processor, suite.rule, testCase.test)
+		if err != nil {
+			failures = []string{err.Error()}
+		}
+		result.Failures = failures
+	}
+
+	result.Passed = len(result.Failures) == 0
+	return result
+}
+
+// rulesDir returns the project's local rules directory, or basePath itself
+// when it is a rule repository without a project configuration
+func (c *TestCommand) rulesDir(basePath string) (string, error) {
+	configResult, err := c.projectManager.LoadConfig(basePath)
+	if err != nil {
+		log.Debug("No project configuration found, testing rules in current directory", "path", basePath)
+		return basePath, nil
+	}
+	return c.projectManager.LocalRulesDir(configResult)
+}
+
+// collectSuites gathers tests declared in rule frontmatter and in test files,
+// keyed by rule path. Rules without tests are left out.
+func (c *TestCommand) collectSuites(basePath, rulesDir string) (map[string]*ruleTestSuite, error) {
+	suites := make(map[string]*ruleTestSuite)
+
+	// Load every rule so test files can refer to them
+	if exists, _ := afero.DirExists(c.fs, rulesDir); exists {
+		err := afero.Walk(c.fs, rulesDir, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				if path != rulesDir && strings.HasPrefix(info.Name(), ".") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !strings.HasSuffix(info.Name(), domain.MarkdownExt) {
+				return nil
+			}
+
+			relPath, err := filepath.Rel(rulesDir, path)
+			if err != nil {
+				return contextureerrors.Wrap(err, "get relative path")
+			}
+			rulePath := filepath.ToSlash(strings.TrimSuffix(relPath, domain.MarkdownExt))
+			suites[rulePath] = c.loadRule(path, rulePath)
+			return nil
+		})
+		if err != nil {
+			return nil, contextureerrors.Wrap(err, "walk rules directory")
+		}
+	}
+
+	// Add tests from .contexture/tests/
+	testsDir := filepath.Join(basePath, domain.ContextureDir, domain.RuleTestsDir)
+	entries, err := afero.ReadDir(c.fs, testsDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, contextureerrors.Wrap(err, "read tests directory")
+	}
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != domain.YAMLExt && ext != domain.YMLExt) {
+			continue
+		}
+
+		testPath := filepath.Join(testsDir, entry.Name())
+		testFile, err := c.readTestFile(testPath)
+		if err != nil {
+			return nil, err
+		}
+
+		rulePath := strings.TrimSuffix(domain.ExtractRulePath(testFile.Rule), domain.MarkdownExt)
+		suite, ok := suites[rulePath]
+		if !ok {
+			// A suite without a rule reports every test as a missing rule
+			suite = &ruleTestSuite{}
+			suites[rulePath] = suite
+		}
+		for _, test := range testFile.Tests {
+			suite.tests = append(suite.tests, ruleTestCase{test: test, path: testPath})
+		}
+	}
+
+	for rulePath, suite := range suites {
+		if len(suite.tests) == 0 {
+			delete(suites, rulePath)
+		}
+	}
+	return suites, nil
+}
+
+// loadRule parses a rule file into a suite holding its frontmatter tests. A rule
+// that fails to parse is kept with its error so any tests for it fail.
+func (c *TestCommand) loadRule(path, rulePath string) *ruleTestSuite {
+	data, err := afero.ReadFile(c.fs, path)
+	if err != nil {
+		return &ruleTestSuite{parseErr: err}
+	}
+
+	parsed, err := c.parser.ParseRule(string(data), rule.Metadata{
+		ID:       fmt.Sprintf("[contexture(local):%s]", rulePath),
+		FilePath: rulePath,
+		Source:   "local",
+	})
+	if err != nil {
+		suite := &ruleTestSuite{parseErr: err}
+		// Surface the error when the rule meant to declare tests of its own
+		if frontmatter, _, fmErr := c.parser.ParseContent(string(data)); fmErr == nil && frontmatter["tests"] != nil {
+			suite.tests = []ruleTestCase{{test: domain.RuleTest{Name: "parse"}, path: path}}
+		}
+		return suite
+	}
+
+	suite := &ruleTestSuite{rule: parsed}
+	for _, test := range parsed.Tests {
+		suite.tests = append(suite.tests, ruleTestCase{test: test, path: path})
+	}
+	return suite
+}
+
+// readTestFile reads and decodes a test file
+func (c *TestCommand) readTestFile(path string) (*domain.RuleTestFile, error) {
+	data, err := afero.ReadFile(c.fs, path)
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "read test file")
+	}
+
+	var testFile domain.RuleTestFile
+	if err := yaml.Unmarshal(data, &testFile); err != nil {
+		return nil, contextureerrors.ValidationErrorf("tests", "invalid test file %s: %v", path, err)
+	}
+	if testFile.Rule == "" {
+		return nil, contextureerrors.ValidationErrorf("tests", "test file %s does not name a rule", path)
+	}
+
+	return &testFile, nil
+}
+
+// TestAction is the CLI action handler for the test command
+func TestAction(ctx context.Context, cmd *cli.Command, deps *dependencies.Dependencies) error {
+	testCmd := NewTestCommand(deps)
+	return testCmd.Execute(ctx, cmd)
+}
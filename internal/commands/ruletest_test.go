@@ -0,0 +1,137 @@
+// Package commands provides CLI command implementations
+package commands
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+const testedRule = `---
+title: Team Style
+description: House style for the team
+tags: [style]
+variables:
+  indent: tabs
+tests:
+  - name: defaults
+    contains: ["Indent with tabs."]
+---
+
+Indent with {{ .indent }}.
+`
+
+// runTestCommand runs the test command against basePath and returns its error
+func runTestCommand(t *testing.T, testCmd *TestCommand, basePath string, args ...string) error {
+	t.Helper()
+
+	var execErr error
+	app := &cli.Command{
+		Name: "test",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "output", Value: "json"},
+		},
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			execErr = testCmd.run(cmd, basePath)
+			return nil
+		},
+	}
+
+	require.NoError(t, app.Run(context.Background(), append([]string{"test"}, args...)))
+	return execErr
+}
+
+func writeTestFile(t *testing.T, fs afero.Fs, path, content string) {
+	t.Helper()
+	require.NoError(t, fs.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, afero.WriteFile(fs, path, []byte(content), 0o644))
+}
+
+func TestNewTestCommand(t *testing.T) {
+	cmd := NewTestCommand(createTestDependencies())
+
+	assert.NotNil(t, cmd)
+	assert.NotNil(t, cmd.fs)
+	assert.NotNil(t, cmd.projectManager)
+	assert.NotNil(t, cmd.parser)
+	assert.NotNil(t, cmd.processor)
+}
+
+func TestTestCommand_RuleRepository(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	writeTestFile(t, fs, "/repo/team/style.md", testedRule)
+	writeTestFile(t, fs, "/repo/README.md", "# Rules\n")
+	testCmd := NewTestCommand(createTestDependencies())
+	testCmd.fs = fs
+
+	t.Run("passing frontmatter tests", func(t *testing.T) {
+		require.NoError(t, runTestCommand(t, testCmd, "/repo"))
+	})
+
+	t.Run("failing test file", func(t *testing.T) {
+		writeTestFile(t, fs, "/repo/.contexture/tests/style.yaml", `rule: team/style
+tests:
+  - name: spaces
+    variables:
+      indent: spaces
+    contains: ["Indent with spaces."]
+    not_contains: ["tabs"]
+  - name: still tabs
+    variables:
+      indent: spaces
+    contains: ["tabs"]
+`)
+		t.Cleanup(func() { _ = fs.Remove("/repo/.contexture/tests/style.yaml") })
+
+		err := runTestCommand(t, testCmd, "/repo")
+		require.Error(t, err)
+		assert.Equal(t, int(contextureerrors.ExitValidation), contextureerrors.ExitCodeFor(err))
+		assert.Contains(t, err.Error(), "1 of 3")
+	})
+
+	t.Run("unknown rule in test file", func(t *testing.T) {
+		writeTestFile(t, fs, "/repo/.contexture/tests/missing.yml", "rule: team/missing\ntests:\n  - contains: [x]\n")
+		t.Cleanup(func() { _ = fs.Remove("/repo/.contexture/tests/missing.yml") })
+
+		err := runTestCommand(t, testCmd, "/repo")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "1 of 2")
+	})
+
+	t.Run("selected rule without tests", func(t *testing.T) {
+		err := runTestCommand(t, testCmd, "/repo", "README")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no tests found")
+	})
+}
+
+func TestTestCommand_ProjectRules(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	writeTestFile(t, fs, "/project/.contexture.yaml", "version: 1\nformats:\n  - type: claude\n    enabled: true\n")
+	writeTestFile(t, fs, "/project/rules/team/style.md", testedRule)
+	// Markdown outside the rules directory is not tested
+	writeTestFile(t, fs, "/project/docs/broken.md", "---\ntests: [\n---\n")
+	testCmd := NewTestCommand(createTestDependencies())
+	testCmd.fs = fs
+	testCmd.projectManager = project.NewManager(fs)
+
+	require.NoError(t, runTestCommand(t, testCmd, "/project", "team/style"))
+}
+
+func TestTestCommand_InvalidRuleWithTests(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	writeTestFile(t, fs, "/repo/broken.md", "---\ntitle: Broken\ntests:\n  - contains: [x]\n---\n\nbody\n")
+	testCmd := NewTestCommand(createTestDependencies())
+	testCmd.fs = fs
+
+	err := runTestCommand(t, testCmd, "/repo")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 of 1")
+}
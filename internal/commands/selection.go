@@ -0,0 +1,25 @@
+package commands
+
+import (
+	"github.com/contextureai/contexture/internal/output"
+	"github.com/contextureai/contexture/internal/tui"
+	"github.com/urfave/cli/v3"
+)
+
+// selectionFromFlags returns the selection made with the --select and
+// --all-matching flags, the headless equivalents of interactive selections
+func selectionFromFlags(cmd *cli.Command) tui.Selection {
+	return tui.Selection{
+		Values: cmd.StringSlice("select"),
+		Filter: cmd.String("all-matching"),
+	}
+}
+
+// writeDryRunSelection reports what command would have selected as JSON,
+// instead of applying the selection
+func writeDryRunSelection(command string, selected []string) error {
+	return output.NewJSONWriter().WriteSelection(output.SelectionMetadata{
+		Command:  command,
+		Selected: selected,
+	})
+}
@@ -4,18 +4,61 @@ package commands
 import (
 	"context"
 	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/log"
+	"github.com/contextureai/contexture/internal/approval"
+	"github.com/contextureai/contexture/internal/condition"
+	"github.com/contextureai/contexture/internal/dependencies"
 	"github.com/contextureai/contexture/internal/domain"
 	contextureerrors "github.com/contextureai/contexture/internal/errors"
 	"github.com/contextureai/contexture/internal/format"
+	"github.com/contextureai/contexture/internal/format/base"
+	"github.com/contextureai/contexture/internal/permissions"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/contextureai/contexture/internal/redact"
 	"github.com/contextureai/contexture/internal/rule"
+	"github.com/contextureai/contexture/internal/safety"
+	"github.com/contextureai/contexture/internal/staging"
+	"github.com/contextureai/contexture/internal/summarize"
+	"github.com/contextureai/contexture/internal/template"
 	"github.com/contextureai/contexture/internal/ui"
 	"github.com/spf13/afero"
 )
 
+// userRulesFormatConfig returns the configuration that writes formatConfig's
+// user rules to the assistant's native location, or to the file the global
+// configuration overrides it with
+func userRulesFormatConfig(
+	formatConfig domain.FormatConfig,
+	caps domain.FormatCapabilities,
+	global *domain.Project,
+) domain.FormatConfig {
+	defaultPath := userRulesPath(formatConfig, caps)
+	userFormatConfig := formatConfig
+	userFormatConfig.BaseDir = filepath.Dir(defaultPath)
+	userFormatConfig.UserRulesFile = global.ResolveUserRulesPath(formatConfig.Type, defaultPath)
+	userFormatConfig.IsUserRules = true
+	return userFormatConfig
+}
+
+// userRulesPath returns the file formatConfig's user rules are written to
+// outside projects: the dedicated file of a format in file mode, or the
+// assistant's native location. It returns "" when the format has neither.
+func userRulesPath(formatConfig domain.FormatConfig, caps domain.FormatCapabilities) string {
+	if formatConfig.GetEffectiveUserRulesMode() == domain.UserRulesFile && caps.UserRulesFilePath != "" {
+		return caps.UserRulesFilePath
+	}
+	if caps.SupportsUserRules {
+		return caps.UserRulesPath
+	}
+	return ""
+}
+
 // RuleGenerator provides shared rule generation functionality
 type RuleGenerator struct {
 	ruleFetcher   rule.Fetcher
@@ -23,6 +66,14 @@ type RuleGenerator struct {
 	ruleProcessor rule.Processor
 	registry      *format.Registry
 	fs            afero.Fs
+	// conditionContext returns the context that when expressions are evaluated against
+	conditionContext func() *condition.Context
+	// getenv returns the environment overrides of the execution budget
+	getenv func(string) string
+	// preview generates without reporting the formats as written
+	preview bool
+	// quarantine leaves out third-party rules with safety findings instead of building them
+	quarantine bool
 }
 
 // NewRuleGenerator creates a new rule generator
@@ -36,13 +87,61 @@ func NewRuleGenerator(
 	if fs == nil {
 		fs = afero.NewOsFs()
 	}
-	return &RuleGenerator{
+	return (&RuleGenerator{
 		ruleFetcher:   fetcher,
 		ruleValidator: validator,
 		ruleProcessor: processor,
 		registry:      registry,
 		fs:            fs,
+	}).withEnvironment(dependencies.OSWorkingDir{}, dependencies.OSEnvironment{})
+}
+
+// withEnvironment makes the generator evaluate when expressions against the
+// given working directory and environment
+func (g *RuleGenerator) withEnvironment(workingDir dependencies.WorkingDir, env dependencies.Environment) *RuleGenerator {
+	g.conditionContext = func() *condition.Context {
+		dir, _ := workingDir.Getwd()
+		return condition.Detect(g.fs, dir, env.Environ())
+	}
+	g.getenv = env.Getenv
+	return g
+}
+
+// applicableRules splits rules into those whose when expression holds and
+// those it excludes, along with rules pending approval under approvalConfig.
+// Skipped pending rules are returned with Pending set.
+func (g *RuleGenerator) applicableRules(
+	approvalConfig *domain.ApprovalConfig,
+	rules []domain.RuleRef,
+) ([]domain.RuleRef, []domain.RuleRef, error) {
+	var conditionCtx *condition.Context
+	var applicable, skipped []domain.RuleRef
+	for _, ref := range rules {
+		if approval.Pending(approvalConfig, ref) {
+			log.Debug("Skipping rule pending approval", "rule", ref.ID)
+			ref.Pending = true
+			skipped = append(skipped, ref)
+			continue
+		}
+		if ref.When == "" {
+			applicable = append(applicable, ref)
+			continue
+		}
+		if conditionCtx == nil {
+			conditionCtx = g.conditionContext()
+		}
+		matched, err := condition.Evaluate(ref.When, conditionCtx)
+		if err != nil {
+			return nil, nil, contextureerrors.WithOp("evaluate when of "+ref.ID, err)
+		}
+		if matched {
+			applicable = append(applicable, ref)
+		} else {
+			log.Debug("Skipping rule", "rule", ref.ID, "when", ref.When)
+			skipped = append(skipped, ref)
+		}
 	}
+	return applicable, skipped, nil
 }
 
 // GenerateRules handles the complete rule generation process with consistent UI
@@ -87,9 +186,14 @@ func (g *RuleGenerator) generateRulesWithScopeInternal(
 		return contextureerrors.ValidationErrorf("formats", "no target formats available")
 	}
 
+	ruleRefs, _, err := g.applicableRules(config.Approval, config.Rules)
+	if err != nil {
+		return err
+	}
+
 	// If no rules, we still need to generate (which will trigger cleanup/deletion in format handlers)
 	var processedRules []*domain.ProcessedRule
-	if len(config.Rules) > 0 {
+	if len(ruleRefs) > 0 {
 		// Fetch all rules in parallel with progress indicator and timing
 		var rules []*domain.Rule
 		scopeLabel := ""
@@ -99,13 +203,23 @@ func (g *RuleGenerator) generateRulesWithScopeInternal(
 			scopeLabel = " " + mutedStyle.Render(fmt.Sprintf("[%s]", scope))
 		}
 
-		err := ui.WithProgress("Fetched rules"+scopeLabel, func() error {
+		budget, err := config.GetGeneration().Budget(g.getenv)
+		if err != nil {
+			return err
+		}
+		preset, err := project.NewManager(g.fs).ResolvePresets(config)
+		if err != nil {
+			return contextureerrors.Wrap(err, "resolve presets")
+		}
+
+		err = ui.WithProgress("Fetched rules"+scopeLabel, func() error {
 			var fetchErr error
-			rules, fetchErr = rule.FetchRulesParallel(
+			rules, fetchErr = rule.FetchRulesWithPreset(
 				ctx,
 				g.ruleFetcher,
-				config.Rules,
-				config.GetGeneration().ParallelFetches,
+				ruleRefs,
+				budget.ParallelFetches,
+				preset,
 			)
 			return fetchErr
 		})
@@ -120,7 +234,7 @@ func (g *RuleGenerator) generateRulesWithScopeInternal(
 		// Process rules (templates, validation) with progress indicator and timing
 		err = ui.WithProgress("Generated rules"+scopeLabel, func() error {
 			var processErr error
-			processedRules, processErr = g.processRules(ctx, rules)
+			processedRules, processErr = g.processRules(ctx, rules, config.GetGeneration())
 			return processErr
 		})
 		if err != nil {
@@ -132,13 +246,13 @@ func (g *RuleGenerator) generateRulesWithScopeInternal(
 
 	// Generate output for each format (even with 0 rules to trigger cleanup)
 	for _, formatConfig := range targetFormats {
-		if err := g.generateFormat(ctx, processedRules, formatConfig); err != nil {
+		if err := g.generateFormat(ctx, processedRules, formatConfig, config.MCPServers); err != nil {
 			log.Warn("Failed to generate format", "format", formatConfig.Type, "error", err)
 			continue
 		}
 
 		// Show format completion with scope tag (only if we had rules to process)
-		if len(processedRules) > 0 {
+		if len(processedRules) > 0 && !g.preview {
 			if handler, exists := g.registry.GetHandler(formatConfig.Type); exists {
 				theme := ui.DefaultTheme()
 				successStyle := lipgloss.NewStyle().Foreground(theme.Success)
@@ -154,7 +268,7 @@ func (g *RuleGenerator) generateRulesWithScopeInternal(
 				if hasGlobalRules && formatConfig.Type == domain.FormatCursor && scope == "project" {
 					fmt.Printf("     %s %s\n",
 						mutedStyle.Render("⚠"),
-						mutedStyle.Render("Cursor does not support native global rules. Your global rules will be merged into project files, which may cause conflicts in team environments. Consider setting Cursor's userRulesMode to 'disabled' in .contexture.yaml"))
+						mutedStyle.Render("Cursor does not support native global rules. Your global rules will be merged into project files, which may cause conflicts in team environments. Consider setting Cursor's userRulesMode to 'file' or 'disabled' in .contexture.yaml"))
 				}
 			}
 		}
@@ -167,14 +281,35 @@ func (g *RuleGenerator) generateRulesWithScopeInternal(
 	return nil
 }
 
-// processRules validates and processes rules through templates
+// processRules validates and processes rules through templates, linting
+// third-party rules for injected instructions, redacting secrets when
+// generation.redact is set and condensing oversized rules when
+// generation.summarize is set
 func (g *RuleGenerator) processRules(
-	_ context.Context,
+	ctx context.Context,
 	rules []*domain.Rule,
+	generation *domain.GenerationConfig,
 ) ([]*domain.ProcessedRule, error) {
 	var processedRules []*domain.ProcessedRule
 	var errors []string
 
+	linter, err := safety.New(generation.Safety)
+	if err != nil {
+		return nil, err
+	}
+	var redactor *redact.Redactor
+	if generation.Redact != nil {
+		if redactor, err = redact.New(*generation.Redact); err != nil {
+			return nil, err
+		}
+	}
+	var summarizer *summarize.Summarizer
+	if generation.Summarize != nil {
+		if summarizer, err = summarize.New(*generation.Summarize); err != nil {
+			return nil, err
+		}
+	}
+
 	for _, rule := range rules {
 		// Validate rule
 		validationResult := g.ruleValidator.ValidateRule(rule)
@@ -195,6 +330,40 @@ func (g *RuleGenerator) processRules(
 			continue
 		}
 
+		findings, err := lintRule(g.fs, linter, processedRule)
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("rule %s processing failed: %v", rule.ID, err))
+			continue
+		}
+		if len(findings) > 0 {
+			found := describeFindings(findings)
+			switch {
+			case g.quarantine:
+				log.Warn("Quarantined rule pending review", "rule", rule.ID, "findings", found)
+				continue
+			case safety.HasErrors(findings):
+				errors = append(errors, fmt.Sprintf("rule %s has suspicious instructions: %s", rule.ID, found))
+				continue
+			default:
+				log.Warn("Suspicious instructions in rule", "rule", rule.ID, "findings", found)
+			}
+		}
+
+		// Secrets are redacted first, so they never reach a summarizer command
+		if redactor != nil {
+			if err := g.redactRule(redactor, processedRule); err != nil {
+				errors = append(errors, fmt.Sprintf("rule %s %v", rule.ID, err))
+				continue
+			}
+		}
+
+		if summarizer != nil {
+			if err := g.summarizeRule(ctx, summarizer, processedRule); err != nil {
+				errors = append(errors, fmt.Sprintf("rule %s summarization failed: %v", rule.ID, err))
+				continue
+			}
+		}
+
 		processedRules = append(processedRules, processedRule)
 	}
 
@@ -205,14 +374,193 @@ func (g *RuleGenerator) processRules(
 	return processedRules, nil
 }
 
-// generateFormat generates output for a single format
+// lintRule returns the safety findings in the rendered content of a
+// third-party rule. Local rules and rules listed as reviewed are not linted.
+func lintRule(fs afero.Fs, linter *safety.Linter, processed *domain.ProcessedRule) ([]safety.Finding, error) {
+	if processed.Rule.Source == "local" || ruleMatchesAny(processed.Rule.ID, linter.Reviewed()) {
+		return nil, nil
+	}
+	rendered, err := renderRuleContent(fs, processed)
+	if err != nil {
+		return nil, err
+	}
+	return linter.Lint(rendered), nil
+}
+
+// describeFindings lists findings for messages
+func describeFindings(findings []safety.Finding) string {
+	described := make([]string, len(findings))
+	for i, finding := range findings {
+		described[i] = finding.String()
+	}
+	return strings.Join(described, ", ")
+}
+
+// redactRule masks the secrets in the rendered content of processed and in
+// the fields formats render around it, such as its title, description, and
+// variables, or fails with the patterns matched when the redactor fails on
+// matches
+func (g *RuleGenerator) redactRule(redactor *redact.Redactor, processed *domain.ProcessedRule) error {
+	rendered, err := renderRuleContent(g.fs, processed)
+	if err != nil {
+		return err
+	}
+
+	redacted, matches := redactor.Redact(rendered)
+	found := make([]string, 0, len(matches))
+	for _, match := range matches {
+		found = append(found, match.String())
+	}
+	fields := ruleFieldRedactor{redactor: redactor}
+	redactedRule := *processed.Rule
+	redactedRule.Title = fields.redact("title", redactedRule.Title)
+	redactedRule.Description = fields.redact("description", redactedRule.Description)
+	redactedRule.Tags = fields.redactAll("tags", redactedRule.Tags)
+	redactedRule.Languages = fields.redactAll("languages", redactedRule.Languages)
+	redactedRule.Frameworks = fields.redactAll("frameworks", redactedRule.Frameworks)
+	if redactedRule.Trigger != nil {
+		trigger := *redactedRule.Trigger
+		trigger.Globs = fields.redactAll("trigger globs", trigger.Globs)
+		redactedRule.Trigger = &trigger
+	}
+	redactedRule.Variables = fields.redactVariables(redactedRule.Variables)
+	variables := fields.redactVariables(processed.Variables)
+	found = append(found, fields.found...)
+
+	if len(found) == 0 {
+		return nil
+	}
+	if redactor.Action() == domain.RedactFail {
+		return fmt.Errorf("contains secrets: %s", strings.Join(found, ", "))
+	}
+	log.Warn("Redacted secrets", "rule", processed.Rule.ID, "matches", strings.Join(found, ", "))
+
+	// Formats render rule content as a template, so the redacted content is escaped to pass through unchanged
+	redactedRule.Content = template.Escape(redacted)
+	processed.Rule = &redactedRule
+	processed.Content = redactedRule.Content
+	processed.Variables = variables
+	return nil
+}
+
+// ruleFieldRedactor redacts the fields of a rule, collecting the matches
+// with the field they were found in
+type ruleFieldRedactor struct {
+	redactor *redact.Redactor
+	found    []string
+}
+
+// redact returns value with its secrets masked
+func (r *ruleFieldRedactor) redact(field, value string) string {
+	redacted, matches := r.redactor.Redact(value)
+	for _, match := range matches {
+		r.found = append(r.found, match.Pattern+" in "+field)
+	}
+	return redacted
+}
+
+// redactAll returns a copy of values with their secrets masked
+func (r *ruleFieldRedactor) redactAll(field string, values []string) []string {
+	if values == nil {
+		return nil
+	}
+	redacted := make([]string, len(values))
+	for i, value := range values {
+		redacted[i] = r.redact(field, value)
+	}
+	return redacted
+}
+
+// redactVariables returns a copy of variables with the secrets in their
+// string values masked, including values nested in lists and maps
+func (r *ruleFieldRedactor) redactVariables(variables map[string]any) map[string]any {
+	if variables == nil {
+		return nil
+	}
+	redacted := make(map[string]any, len(variables))
+	for _, name := range slices.Sorted(maps.Keys(variables)) {
+		redacted[name] = r.redactValue("variable "+name, variables[name])
+	}
+	return redacted
+}
+
+// redactValue returns value with the secrets in its strings masked
+func (r *ruleFieldRedactor) redactValue(field string, value any) any {
+	switch v := value.(type) {
+	case string:
+		return r.redact(field, v)
+	case []string:
+		return r.redactAll(field, v)
+	case []any:
+		redacted := make([]any, len(v))
+		for i, item := range v {
+			redacted[i] = r.redactValue(field, item)
+		}
+		return redacted
+	case map[string]any:
+		redacted := make(map[string]any, len(v))
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			redacted[key] = r.redactValue(field, v[key])
+		}
+		return redacted
+	default:
+		return value
+	}
+}
+
+// summarizeRule replaces the content of processed with a summary when its
+// rendered content is longer than the summarizer's threshold
+func (g *RuleGenerator) summarizeRule(
+	ctx context.Context,
+	summarizer *summarize.Summarizer,
+	processed *domain.ProcessedRule,
+) error {
+	rendered, err := renderRuleContent(g.fs, processed)
+	if err != nil {
+		return err
+	}
+
+	summary, condensed, err := summarizer.Summarize(ctx, rendered)
+	if err != nil || !condensed {
+		return err
+	}
+	log.Debug("Summarized rule", "rule", processed.Rule.ID, "from", len(rendered), "to", len(summary))
+
+	// Formats render rule content as a template, so the summary is escaped to pass through unchanged
+	condensedRule := *processed.Rule
+	condensedRule.Content = template.Escape(summary)
+	processed.Rule = &condensedRule
+	processed.Content = condensedRule.Content
+	return nil
+}
+
+// renderRuleContent renders the content of a processed rule with its variables, as formats do
+func renderRuleContent(fs afero.Fs, processed *domain.ProcessedRule) (string, error) {
+	return base.NewBaseFormat(fs, "").RenderRuleContent(processed.Rule, processed.Variables)
+}
+
+// generateFormat generates output for a single format, including the MCP
+// servers for formats whose assistant reads them from the project
 func (g *RuleGenerator) generateFormat(
 	_ context.Context,
 	rules []*domain.ProcessedRule,
 	formatConfig domain.FormatConfig,
+	mcpServers map[string]domain.MCPServer,
 ) error {
+	// Outputs are staged and moved into place once the whole format is
+	// written, so a failed build leaves the previous outputs intact, and a
+	// commit that fails midway restores the files it already moved. Read-only
+	// mode already keeps writes in memory.
+	formatFS := g.fs
+	var stage *staging.Fs
+	if _, readOnly := g.fs.(*permissions.ReadOnlyFs); !readOnly {
+		stage = staging.New(g.fs)
+		defer stage.Discard()
+		formatFS = stage
+	}
+
 	// Create format instance
-	format, err := g.registry.CreateFormat(formatConfig.Type, g.fs, nil)
+	format, err := g.registry.CreateFormat(formatConfig.Type, formatFS, nil)
 	if err != nil {
 		return contextureerrors.Wrap(err, "create format")
 	}
@@ -220,6 +568,14 @@ func (g *RuleGenerator) generateFormat(
 	// Transform rules for this format
 	var transformedRules []*domain.TransformedRule
 	for _, processedRule := range rules {
+		if !formatConfig.IncludesRule(processedRule.Rule.ID) {
+			log.Debug("Rule excluded from format", "rule", processedRule.Rule.ID, "format", formatConfig.Type)
+			continue
+		}
+		if processedRule.Rule.IsCommand() && !format.GetMetadata().SupportsCommands {
+			log.Debug("Format does not support command rules", "rule", processedRule.Rule.ID, "format", formatConfig.Type)
+			continue
+		}
 		transformed, err := format.Transform(processedRule)
 		if err != nil {
 			return contextureerrors.Wrap(err, "transform rule")
@@ -233,11 +589,25 @@ func (g *RuleGenerator) generateFormat(
 		return contextureerrors.Wrap(err, "write format output")
 	}
 
+	if writer, ok := format.(domain.MCPConfigWriter); ok {
+		if err := writer.WriteMCPServers(mcpServers, &formatConfig); err != nil {
+			return contextureerrors.Wrap(err, "write mcp servers")
+		}
+	} else if len(mcpServers) > 0 {
+		log.Debug("Format does not support MCP servers", "format", formatConfig.Type)
+	}
+
 	// Clean up empty directories if no rules were written
 	if len(transformedRules) == 0 {
 		g.cleanupEmptyFormatDirectory(format, &formatConfig)
 	}
 
+	if stage != nil {
+		if err := stage.Commit(); err != nil {
+			return contextureerrors.Wrap(err, "move format output into place")
+		}
+	}
+
 	log.Debug("Format generated", "type", formatConfig.Type, "rules", len(transformedRules))
 	return nil
 }
@@ -0,0 +1,197 @@
+// Package commands provides CLI command implementations
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/globs"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/contextureai/contexture/internal/provider"
+	"github.com/contextureai/contexture/internal/rule"
+	"github.com/contextureai/contexture/internal/summarize"
+	"github.com/contextureai/contexture/internal/ui"
+	"github.com/spf13/afero"
+	"github.com/urfave/cli/v3"
+)
+
+// ShowCommand implements the rules show command
+type ShowCommand struct {
+	projectManager   *project.Manager
+	ruleFetcher      rule.Fetcher
+	ruleProcessor    rule.Processor
+	providerRegistry *provider.Registry
+	fs               afero.Fs
+	workingDir       dependencies.WorkingDir
+	// deps records the rules shown in the user's history
+	deps *dependencies.Dependencies
+}
+
+// NewShowCommand creates a new show command
+func NewShowCommand(deps *dependencies.Dependencies) *ShowCommand {
+	return &ShowCommand{
+		projectManager:   project.NewManagerFromDependencies(deps),
+		ruleFetcher:      rule.NewFetcher(deps.FS, newOpenRepository(deps.FS), fetcherConfig(deps), deps.ProviderRegistry),
+		ruleProcessor:    rule.NewProcessor(),
+		providerRegistry: deps.ProviderRegistry,
+		fs:               deps.FS,
+		workingDir:       deps.GetWorkingDir(),
+		deps:             deps,
+	}
+}
+
+// Execute runs the show command in the current directory
+func (c *ShowCommand) Execute(ctx context.Context, cmd *cli.Command) error {
+	currentDir, err := c.workingDir.Getwd()
+	if err != nil {
+		return contextureerrors.Wrap(err, "get current directory")
+	}
+
+	return c.show(ctx, cmd, currentDir)
+}
+
+// show prints a rule rendered with the variables configured in the project at basePath
+func (c *ShowCommand) show(ctx context.Context, cmd *cli.Command, basePath string) error {
+	ruleID := cmd.Args().First()
+	if ruleID == "" {
+		return contextureerrors.ValidationErrorf("rule-id", "rule ID is required")
+	}
+
+	// Rules outside a project are shown with their default variables
+	ref := domain.RuleRef{ID: ruleID}
+	var summarizeConfig *domain.SummarizeConfig
+	var preset map[string]any
+	inProject := false
+	if merged, err := c.projectManager.LoadConfigMergedWithLocalRules(basePath); err == nil {
+		if merged.GlobalConfig != nil {
+			if err := c.providerRegistry.LoadFromProject(merged.GlobalConfig); err != nil {
+				return contextureerrors.Wrap(err, "load global providers")
+			}
+		}
+		if err := c.providerRegistry.LoadFromProject(merged.Project); err != nil {
+			return contextureerrors.Wrap(err, "load project providers")
+		}
+		if configured := c.projectManager.FindRule(merged.Project, ruleID); configured != nil {
+			ref = *configured
+		}
+		summarizeConfig = merged.Project.GetGeneration().Summarize
+		if preset, err = c.projectManager.ResolvePresets(merged.Project); err != nil {
+			return contextureerrors.Wrap(err, "resolve presets")
+		}
+		inProject = true
+	}
+
+	rules, err := rule.FetchRulesWithPreset(ctx, c.ruleFetcher, []domain.RuleRef{ref}, 1, preset)
+	if err != nil {
+		return contextureerrors.Wrap(err, "fetch rule")
+	}
+	for _, varFlag := range cmd.StringSlice("var") {
+		key, value, err := parseVarFlag(varFlag)
+		if err != nil {
+			return contextureerrors.Wrap(err, "parse var")
+		}
+		rule.SetVariable(rules[0], key, value, domain.VariableOriginFlag)
+	}
+	processed, err := c.ruleProcessor.ProcessRule(rules[0], &domain.RuleContext{})
+	if err != nil {
+		return contextureerrors.Wrap(err, "process rule")
+	}
+	content, err := renderRuleContent(c.fs, processed)
+	if err != nil {
+		return contextureerrors.Wrap(err, "render rule")
+	}
+
+	theme := ui.DefaultTheme()
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(theme.Primary)
+	mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+
+	fetched := processed.Rule
+	fmt.Println(titleStyle.Render(fetched.Title))
+	fmt.Println(mutedStyle.Render(fetched.ID))
+	if fetched.Description != "" {
+		fmt.Println(fetched.Description)
+	}
+	if len(fetched.Tags) > 0 {
+		fmt.Printf("%s %s\n", mutedStyle.Render("Tags:"), strings.Join(fetched.Tags, ", "))
+	}
+	if fetched.Owner != "" {
+		fmt.Printf("%s %s\n", mutedStyle.Render("Owner:"), fetched.Owner)
+	}
+	if len(fetched.Variables) > 0 {
+		fmt.Println(mutedStyle.Render("Variables:"))
+		printColumns(showVariables(fetched), mutedStyle)
+	}
+
+	// Glob coverage is only meaningful against a project's files
+	if trigger := fetched.GetDefaultTrigger(); trigger.Type == domain.TriggerGlob && len(trigger.Globs) > 0 && inProject {
+		files, err := globs.ProjectFiles(c.fs, basePath)
+		if err != nil {
+			return err
+		}
+		warningStyle := lipgloss.NewStyle().Foreground(theme.Warning)
+		fmt.Println(mutedStyle.Render("Globs:"))
+		for _, pattern := range trigger.Globs {
+			count := globs.Count(pattern, files)
+			if count == 0 {
+				fmt.Printf("  %s %s\n", pattern, warningStyle.Render("matches no files"))
+				continue
+			}
+			fmt.Printf("  %s %s\n", pattern, mutedStyle.Render(fmt.Sprintf("(%d file(s))", count)))
+		}
+	}
+
+	if summarizeConfig != nil {
+		summarizer, err := summarize.New(*summarizeConfig)
+		if err != nil {
+			return err
+		}
+		if len(content) > summarizer.Threshold() {
+			if cmd.Bool("summary") {
+				summary, _, err := summarizer.Summarize(ctx, content)
+				if err != nil {
+					return contextureerrors.Wrap(err, "summarize rule")
+				}
+				fmt.Println(mutedStyle.Render(fmt.Sprintf(
+					"Condensed from %d to %d characters for generated output", len(content), len(summary))))
+				content = summary
+			} else {
+				fmt.Println(mutedStyle.Render(fmt.Sprintf(
+					"%d characters; generated output condenses rules over %d (see --summary)",
+					len(content), summarizer.Threshold())))
+			}
+		}
+	}
+
+	fmt.Println()
+	fmt.Print(strings.TrimRight(content, "\n") + "\n")
+	recordRuleUse(c.deps, ruleID)
+	return nil
+}
+
+// showVariables lists the variables of a rule as sorted key=value pairs,
+// each with where its value comes from
+func showVariables(fetched *domain.Rule) [][2]string {
+	keys := make([]string, 0, len(fetched.Variables))
+	for key := range fetched.Variables {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	rows := make([][2]string, 0, len(keys))
+	for _, key := range keys {
+		rows = append(rows, [2]string{fmt.Sprintf("%s=%v", key, fetched.Variables[key]), string(fetched.VariableOrigin(key))})
+	}
+	return rows
+}
+
+// ShowAction is the CLI action handler for the rules show command
+func ShowAction(ctx context.Context, cmd *cli.Command, deps *dependencies.Dependencies) error {
+	showCmd := NewShowCommand(deps)
+	return showCmd.Execute(ctx, cmd)
+}
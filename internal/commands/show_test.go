@@ -0,0 +1,127 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/rule"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+const showProject = `version: 1
+formats:
+  - type: claude
+    enabled: true
+generation:
+  summarize:
+    threshold: 10
+rules:
+  - id: "[contexture:go/style]"
+    variables:
+      subject: functions
+`
+
+// runShow runs the show command against basePath with the given CLI arguments
+func runShow(t *testing.T, showCmd *ShowCommand, basePath string, args ...string) error {
+	t.Helper()
+
+	var execErr error
+	app := &cli.Command{
+		Name:  "show",
+		Flags: []cli.Flag{&cli.BoolFlag{Name: "summary"}, &cli.StringSliceFlag{Name: "var"}},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			execErr = showCmd.show(ctx, cmd, basePath)
+			return nil
+		},
+	}
+
+	require.NoError(t, app.Run(context.Background(), append([]string{"show"}, args...)))
+	return execErr
+}
+
+func TestShowCommand_Show(t *testing.T) {
+	deps := createTestDependencies()
+	require.NoError(t, afero.WriteFile(deps.FS, "/work/app/.contexture.yaml", []byte(showProject), 0o644))
+
+	fetcher := rule.NewMockFetcher(t)
+	var fetched *domain.Rule
+	fetcher.EXPECT().FetchRule(mock.Anything, "[contexture:go/style]").RunAndReturn(
+		func(context.Context, string) (*domain.Rule, error) {
+			fetched = &domain.Rule{
+				ID:      "[contexture:go/style]",
+				Title:   "Style",
+				Content: "# Style\n\nKeep {{.subject}} small.\n\n- Name {{.subject}} clearly\n",
+			}
+			return fetched, nil
+		})
+
+	showCmd := NewShowCommand(deps)
+	showCmd.ruleFetcher = fetcher
+
+	require.NoError(t, runShow(t, showCmd, "/work/app", "go/style"))
+	assert.Equal(t, "functions", fetched.Variables["subject"])
+
+	require.NoError(t, runShow(t, showCmd, "/work/app", "go/style", "--summary"))
+}
+
+func TestShowCommand_Show_VariableOrigins(t *testing.T) {
+	deps := createTestDependencies()
+	require.NoError(t, afero.WriteFile(deps.FS, "/work/app/.contexture.yaml", []byte(showProject), 0o644))
+
+	fetcher := rule.NewMockFetcher(t)
+	var fetched *domain.Rule
+	fetcher.EXPECT().FetchRule(mock.Anything, "[contexture:go/style]").RunAndReturn(
+		func(context.Context, string) (*domain.Rule, error) {
+			fetched = &domain.Rule{
+				ID:               "[contexture:go/style]",
+				Title:            "Style",
+				Content:          "Keep {{.subject}} {{.size}}.",
+				Variables:        map[string]any{"subject": "code", "size": "small", "tone": "plain"},
+				DefaultVariables: map[string]any{"subject": "code", "size": "small", "tone": "plain"},
+			}
+			return fetched, nil
+		})
+
+	showCmd := NewShowCommand(deps)
+	showCmd.ruleFetcher = fetcher
+
+	require.NoError(t, runShow(t, showCmd, "/work/app", "go/style", "--var", "size=tiny"))
+	assert.Equal(t, [][2]string{
+		{"size=tiny", "flag"},
+		{"subject=functions", "project"},
+		{"tone=plain", "rule"},
+	}, showVariables(fetched))
+}
+
+func TestShowCommand_Show_TriggerGlobs(t *testing.T) {
+	deps := createTestDependencies()
+	require.NoError(t, afero.WriteFile(deps.FS, "/work/app/.contexture.yaml", []byte(showProject), 0o644))
+	require.NoError(t, afero.WriteFile(deps.FS, "/work/app/main.go", []byte("package main"), 0o644))
+
+	fetcher := rule.NewMockFetcher(t)
+	fetcher.EXPECT().FetchRule(mock.Anything, "[contexture:go/style]").Return(&domain.Rule{
+		ID:      "[contexture:go/style]",
+		Title:   "Style",
+		Trigger: &domain.RuleTrigger{Type: domain.TriggerGlob, Globs: []string{"**/*.go", "**/*.rs"}},
+		Content: "Keep functions small.",
+	}, nil)
+
+	showCmd := NewShowCommand(deps)
+	showCmd.ruleFetcher = fetcher
+
+	require.NoError(t, runShow(t, showCmd, "/work/app", "go/style"))
+}
+
+func TestShowCommand_MissingRuleID(t *testing.T) {
+	showCmd := NewShowCommand(createTestDependencies())
+
+	err := runShow(t, showCmd, "/work/app")
+	require.Error(t, err)
+	assert.Equal(t, int(contextureerrors.ExitValidation), contextureerrors.ExitCodeFor(err))
+}
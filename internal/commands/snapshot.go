@@ -0,0 +1,180 @@
+// Package commands provides CLI command implementations
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/format"
+	"github.com/contextureai/contexture/internal/output"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/contextureai/contexture/internal/snapshot"
+	"github.com/contextureai/contexture/internal/ui"
+	"github.com/spf13/afero"
+	"github.com/urfave/cli/v3"
+)
+
+// snapshotsDir is the directory under .contexture/ holding recorded outputs
+const snapshotsDir = "snapshots"
+
+// SnapshotCommand implements the snapshot command
+type SnapshotCommand struct {
+	deps           *dependencies.Dependencies
+	fs             afero.Fs
+	projectManager *project.Manager
+	registry       *format.Registry
+	// build regenerates the project's outputs; replaced in tests
+	build      func(ctx context.Context) error
+	workingDir dependencies.WorkingDir
+	env        dependencies.Environment
+}
+
+// NewSnapshotCommand creates a new snapshot command
+func NewSnapshotCommand(deps *dependencies.Dependencies) *SnapshotCommand {
+	return &SnapshotCommand{
+		deps:           deps,
+		fs:             deps.FS,
+		projectManager: project.NewManagerFromDependencies(deps),
+		registry:       format.GetDefaultRegistry(deps.FS),
+		build: func(ctx context.Context) error {
+			return NewBuildCommand(deps).Execute(ctx, &cli.Command{})
+		},
+		workingDir: deps.GetWorkingDir(),
+		env:        deps.GetEnv(),
+	}
+}
+
+// Execute runs the snapshot command
+func (c *SnapshotCommand) Execute(ctx context.Context, cmd *cli.Command) error {
+	configLoad, err := LoadProjectConfig(c.projectManager, c.workingDir)
+	if err != nil {
+		return err
+	}
+
+	return c.run(ctx, cmd, configLoad.Config, configLoad.CurrentDir)
+}
+
+// run builds the project in basePath and records or checks its snapshots
+func (c *SnapshotCommand) run(ctx context.Context, cmd *cli.Command, config *domain.Project, basePath string) error {
+	outputManager, err := output.NewManager(output.Format(cmd.String("output")), c.workingDir, c.env)
+	if err != nil {
+		return contextureerrors.Wrap(err, "create output manager")
+	}
+
+	if !cmd.Bool("no-build") {
+		if err := c.build(ctx); err != nil {
+			return contextureerrors.Wrap(err, "build rules")
+		}
+		fmt.Println()
+	}
+
+	outputs, err := c.collectOutputs(config, basePath)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(basePath, domain.ContextureDir, snapshotsDir)
+	theme := ui.DefaultTheme()
+	successStyle := lipgloss.NewStyle().Foreground(theme.Success)
+
+	if cmd.Bool("update") {
+		if err := snapshot.Write(c.fs, dir, outputs); err != nil {
+			return err
+		}
+		fmt.Println(successStyle.Render(fmt.Sprintf("✓ Recorded %d snapshot(s) in %s", len(outputs), dir)))
+		return nil
+	}
+
+	snapshots, err := snapshot.Read(c.fs, dir)
+	if err != nil {
+		return err
+	}
+	if len(snapshots) == 0 {
+		return contextureerrors.Validation("snapshot", "no snapshots recorded").
+			WithSuggestions(contextureerrors.RunCommand("contexture snapshot --update", "record the current outputs"))
+	}
+
+	mismatches := snapshot.Compare(outputs, snapshots)
+	results := make([]output.SnapshotMismatch, 0, len(mismatches))
+	for _, mismatch := range mismatches {
+		results = append(results, output.SnapshotMismatch{
+			Path:   mismatch.Path,
+			Status: string(mismatch.Status),
+			Diff:   mismatch.Diff,
+		})
+	}
+	metadata := output.SnapshotMetadata{
+		BaseDir:      basePath,
+		FilesChecked: len(outputs),
+		Matched:      len(mismatches) == 0,
+	}
+	if err := outputManager.WriteSnapshotResults(results, metadata); err != nil {
+		return contextureerrors.Wrap(err, "write snapshot results")
+	}
+	if metadata.Matched {
+		return nil
+	}
+
+	return contextureerrors.Validation("snapshot",
+		fmt.Sprintf("%d generated file(s) differ from their snapshots", len(mismatches))).
+		WithSuggestions(contextureerrors.RunCommand("contexture snapshot --update", "accept the new outputs"))
+}
+
+// collectOutputs reads the generated files of every enabled format, keyed by
+// path relative to basePath, with timestamps normalized
+func (c *SnapshotCommand) collectOutputs(config *domain.Project, basePath string) (map[string]string, error) {
+	outputs := make(map[string]string)
+
+	for _, formatConfig := range config.GetEnabledFormats() {
+		formatImpl, err := c.registry.CreateFormat(formatConfig.Type, c.fs, nil)
+		if err != nil {
+			return nil, contextureerrors.Wrap(err, "create format")
+		}
+
+		formatConfig.BaseDir = basePath
+		outputPath := formatImpl.GetOutputPath(&formatConfig)
+		if !filepath.IsAbs(outputPath) {
+			outputPath = filepath.Join(basePath, outputPath)
+		}
+
+		err = afero.Walk(c.fs, outputPath, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			if info.IsDir() {
+				return nil
+			}
+
+			relPath, err := filepath.Rel(basePath, path)
+			if err != nil {
+				return err
+			}
+			data, err := afero.ReadFile(c.fs, path)
+			if err != nil {
+				return err
+			}
+			outputs[filepath.ToSlash(relPath)] = snapshot.Normalize(string(data))
+			return nil
+		})
+		if err != nil {
+			return nil, contextureerrors.Wrap(err, "read generated files")
+		}
+	}
+
+	return outputs, nil
+}
+
+// SnapshotAction is the CLI action handler for the snapshot command
+func SnapshotAction(ctx context.Context, cmd *cli.Command, deps *dependencies.Dependencies) error {
+	snapshotCmd := NewSnapshotCommand(deps)
+	return snapshotCmd.Execute(ctx, cmd)
+}
@@ -0,0 +1,100 @@
+// Package commands provides CLI command implementations
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+// runSnapshotCommand runs the snapshot command against basePath and returns its error
+func runSnapshotCommand(t *testing.T, snapshotCmd *SnapshotCommand, config *domain.Project, basePath string, args ...string) error {
+	t.Helper()
+
+	var execErr error
+	app := &cli.Command{
+		Name: "snapshot",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "update"},
+			&cli.BoolFlag{Name: "no-build"},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			execErr = snapshotCmd.run(ctx, cmd, config, basePath)
+			return nil
+		},
+	}
+
+	require.NoError(t, app.Run(context.Background(), append([]string{"snapshot"}, args...)))
+	return execErr
+}
+
+func TestNewSnapshotCommand(t *testing.T) {
+	cmd := NewSnapshotCommand(createTestDependencies())
+
+	assert.NotNil(t, cmd)
+	assert.NotNil(t, cmd.projectManager)
+	assert.NotNil(t, cmd.registry)
+	assert.NotNil(t, cmd.build)
+}
+
+func TestSnapshotCommand(t *testing.T) {
+	deps := createTestDependencies()
+	fs := deps.FS
+	snapshotCmd := NewSnapshotCommand(deps)
+
+	builds := 0
+	snapshotCmd.build = func(context.Context) error {
+		builds++
+		return nil
+	}
+
+	config := &domain.Project{Formats: []domain.FormatConfig{
+		{Type: domain.FormatClaude, Enabled: true},
+		{Type: domain.FormatCursor, Enabled: true},
+	}}
+	require.NoError(t, afero.WriteFile(fs, "/project/CLAUDE.md",
+		[]byte("# claude.md\n\nUse tabs.\n<!-- Generated by Contexture CLI at 2025-01-02 15:04:05 -->"), 0o644))
+	require.NoError(t, fs.MkdirAll("/project/.cursor/rules", 0o755))
+	require.NoError(t, afero.WriteFile(fs, "/project/.cursor/rules/style.mdc", []byte("Use tabs."), 0o644))
+
+	t.Run("no snapshots recorded", func(t *testing.T) {
+		err := runSnapshotCommand(t, snapshotCmd, config, "/project")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no snapshots recorded")
+	})
+
+	t.Run("update records outputs", func(t *testing.T) {
+		require.NoError(t, runSnapshotCommand(t, snapshotCmd, config, "/project", "--update"))
+
+		recorded, err := afero.ReadFile(fs, "/project/.contexture/snapshots/CLAUDE.md")
+		require.NoError(t, err)
+		assert.Contains(t, string(recorded), "Generated by Contexture CLI at <timestamp>")
+		exists, err := afero.Exists(fs, "/project/.contexture/snapshots/.cursor/rules/style.mdc")
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("matching outputs with a new timestamp", func(t *testing.T) {
+		require.NoError(t, afero.WriteFile(fs, "/project/CLAUDE.md",
+			[]byte("# claude.md\n\nUse tabs.\n<!-- Generated by Contexture CLI at 2025-03-04 09:10:11 -->"), 0o644))
+
+		require.NoError(t, runSnapshotCommand(t, snapshotCmd, config, "/project"))
+	})
+
+	t.Run("changed output", func(t *testing.T) {
+		require.NoError(t, afero.WriteFile(fs, "/project/.cursor/rules/style.mdc", []byte("Use spaces."), 0o644))
+
+		err := runSnapshotCommand(t, snapshotCmd, config, "/project", "--no-build")
+		require.Error(t, err)
+		assert.Equal(t, int(contextureerrors.ExitValidation), contextureerrors.ExitCodeFor(err))
+		assert.Contains(t, err.Error(), "1 generated file(s) differ")
+	})
+
+	assert.Equal(t, 3, builds)
+}
@@ -0,0 +1,139 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/contextureai/contexture/internal/dependencies"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/contextureai/contexture/internal/tui"
+	"github.com/contextureai/contexture/internal/ui"
+	"github.com/spf13/afero"
+	"github.com/urfave/cli/v3"
+)
+
+// StarCommand implements the star and unstar commands, which keep the
+// favorite rules of the user in the global configuration
+type StarCommand struct {
+	projectManager *project.Manager
+	workingDir     dependencies.WorkingDir
+}
+
+// NewStarCommand creates a new star command
+func NewStarCommand(deps *dependencies.Dependencies) *StarCommand {
+	return &StarCommand{
+		projectManager: project.NewManagerFromDependencies(deps),
+		workingDir:     deps.GetWorkingDir(),
+	}
+}
+
+// Star adds rules to the favorites, or lists the favorites when no rules are given
+func (c *StarCommand) Star(ruleIDs []string) error {
+	config, _, err := loadConfigByScope(c.projectManager, c.workingDir, true)
+	if err != nil {
+		return err
+	}
+	if len(ruleIDs) == 0 {
+		printFavorites(config.Favorites)
+		return nil
+	}
+
+	mutedStyle := lipgloss.NewStyle().Foreground(ui.DefaultTheme().Muted)
+	for _, id := range ruleIDs {
+		if slices.Contains(config.Favorites, id) {
+			fmt.Printf("  %s\n", mutedStyle.Render("Already starred: "+id))
+			continue
+		}
+		config.Favorites = append(config.Favorites, id)
+		fmt.Printf("  ★ %s\n", id)
+	}
+	if err := c.projectManager.SaveGlobalConfig(config); err != nil {
+		return contextureerrors.Wrap(err, "save global config")
+	}
+	return nil
+}
+
+// Unstar removes rules from the favorites
+func (c *StarCommand) Unstar(ruleIDs []string) error {
+	if len(ruleIDs) == 0 {
+		return contextureerrors.ValidationErrorf("rule-id", "no rule IDs provided")
+	}
+	config, _, err := loadConfigByScope(c.projectManager, c.workingDir, true)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ruleIDs {
+		index := slices.Index(config.Favorites, id)
+		if index == -1 {
+			return contextureerrors.Validation("rule-id", "rule is not starred: "+id).
+				WithSuggestions(contextureerrors.RunCommand("contexture star", "list the starred rules"))
+		}
+		config.Favorites = slices.Delete(config.Favorites, index, index+1)
+		fmt.Printf("  ☆ %s\n", id)
+	}
+	if err := c.projectManager.SaveGlobalConfig(config); err != nil {
+		return contextureerrors.Wrap(err, "save global config")
+	}
+	return nil
+}
+
+// printFavorites lists the starred rules
+func printFavorites(favorites []string) {
+	if len(favorites) == 0 {
+		fmt.Println("No starred rules")
+		return
+	}
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.AdaptiveColor{Light: "#F793FF", Dark: "#AD58B4"})
+	fmt.Printf("%s\n\n", headerStyle.Render("Starred Rules"))
+	for _, id := range favorites {
+		fmt.Printf("  ★ %s\n", id)
+	}
+}
+
+// favoriteRules returns the starred rules to add with --favorites. In a
+// terminal the user picks among them, all selected by default; otherwise all
+// of them are added.
+func favoriteRules(fs afero.Fs, prompt bool) ([]string, error) {
+	result, err := project.NewManager(fs).LoadGlobalConfig()
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "load global config")
+	}
+	var favorites []string
+	if result != nil && result.Config != nil {
+		favorites = result.Config.Favorites
+	}
+	if len(favorites) == 0 {
+		return nil, contextureerrors.Validation("favorites", "no starred rules").
+			WithSuggestions(contextureerrors.RunCommand("contexture star <rule-id>", "star a rule"))
+	}
+	if !prompt || tui.IsNonInteractive() || !ui.StdinIsTerminal() {
+		return favorites, nil
+	}
+
+	options := make([]tui.SelectOption, len(favorites))
+	for i, id := range favorites {
+		options[i] = tui.SelectOption{Label: id, Value: id}
+	}
+	return tui.MultiSelect(tui.MultiSelectOptions{
+		Title:       "Favorites",
+		Description: "Select starred rules to add",
+		Options:     options,
+		Default:     favorites,
+	})
+}
+
+// StarAction is the CLI action handler for the star command
+func StarAction(_ context.Context, cmd *cli.Command, deps *dependencies.Dependencies) error {
+	return NewStarCommand(deps).Star(cmd.Args().Slice())
+}
+
+// UnstarAction is the CLI action handler for the unstar command
+func UnstarAction(_ context.Context, cmd *cli.Command, deps *dependencies.Dependencies) error {
+	return NewStarCommand(deps).Unstar(cmd.Args().Slice())
+}
@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStarCommand(t *testing.T) {
+	t.Setenv(project.HomeEnv, "/state")
+	deps := dependencies.NewForTesting(context.Background())
+	star := NewStarCommand(deps)
+
+	_, err := favoriteRules(deps.FS, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no starred rules")
+
+	require.NoError(t, star.Star([]string{"@contexture/languages/go/testing", "security/auth"}))
+	require.NoError(t, star.Star([]string{"security/auth"}), "starring twice is not an error")
+	require.NoError(t, star.Star(nil), "listing")
+
+	favorites, err := favoriteRules(deps.FS, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"@contexture/languages/go/testing", "security/auth"}, favorites)
+
+	require.NoError(t, star.Unstar([]string{"@contexture/languages/go/testing"}))
+	favorites, err = favoriteRules(deps.FS, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"security/auth"}, favorites)
+
+	err = star.Unstar([]string{"languages/python"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rule is not starred")
+}
@@ -0,0 +1,324 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/contextureai/contexture/internal/tui"
+	"github.com/contextureai/contexture/internal/ui"
+	"github.com/urfave/cli/v3"
+)
+
+// syncProposal is a change that would align the project with the baseline
+type syncProposal struct {
+	// kind is the deviation recorded when the change is declined
+	kind domain.SyncDeviationType
+	// rule is the baseline rule for omitted and modified rules, or the project rule for extra rules
+	rule domain.RuleRef
+	// detail describes how a modified rule differs from the baseline
+	detail string
+}
+
+// action describes the change a proposal makes
+func (p syncProposal) action() string {
+	switch p.kind {
+	case domain.SyncDeviationOmitted:
+		return "add"
+	case domain.SyncDeviationExtra:
+		return "remove"
+	default:
+		return "update"
+	}
+}
+
+// syncDecision is what to do with a sync proposal
+type syncDecision string
+
+const (
+	syncApply syncDecision = "apply"
+	syncKeep  syncDecision = "keep"
+	syncSkip  syncDecision = "skip"
+)
+
+// SyncCommand implements the sync command
+type SyncCommand struct {
+	projectManager *project.Manager
+	// decide asks whether to apply a proposal and, when the difference is kept, why
+	decide     func(proposal syncProposal) (syncDecision, string, error)
+	workingDir dependencies.WorkingDir
+}
+
+// NewSyncCommand creates a new SyncCommand instance
+func NewSyncCommand(deps *dependencies.Dependencies) *SyncCommand {
+	return &SyncCommand{
+		projectManager: project.NewManagerFromDependencies(deps),
+		decide:         promptSyncDecision,
+		workingDir:     deps.GetWorkingDir(),
+	}
+}
+
+// Execute runs the sync command in the current directory
+func (c *SyncCommand) Execute(ctx context.Context, cmd *cli.Command) error {
+	currentDir, err := c.workingDir.Getwd()
+	if err != nil {
+		return contextureerrors.Wrap(err, "get current directory")
+	}
+
+	return c.sync(ctx, cmd, currentDir)
+}
+
+// sync compares the project in basePath with its baseline and applies the accepted changes
+func (c *SyncCommand) sync(_ context.Context, cmd *cli.Command, basePath string) error {
+	configResult, err := c.projectManager.LoadConfigWithLocalRules(basePath)
+	if err != nil {
+		return contextureerrors.Wrap(err, "load project configuration").
+			WithSuggestions(contextureerrors.RunCommand("contexture init", "create a project configuration"))
+	}
+	config := configResult.Config
+
+	baseline := cmd.String("baseline")
+	if baseline == "" && config.Sync != nil {
+		baseline = config.Sync.Baseline
+	}
+	if baseline == "" {
+		return contextureerrors.Validation("baseline", "no baseline configured").
+			WithSuggestions(contextureerrors.RunCommand(
+				"contexture sync --baseline @provider/path/to/config.yaml",
+				"compare against an organization baseline and remember it"))
+	}
+
+	baselineConfig, err := c.projectManager.LoadReferencedConfig(baseline, configResult)
+	if err != nil {
+		return contextureerrors.Wrap(err, "load baseline "+baseline)
+	}
+
+	var deviations []domain.SyncDeviation
+	if config.Sync != nil {
+		deviations = config.Sync.Deviations
+	}
+	proposals := syncProposals(c.projectManager, config, baselineConfig)
+	pending, kept := partitionSyncProposals(proposals, deviations)
+
+	theme := ui.DefaultTheme()
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(theme.Primary)
+	mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+	successStyle := lipgloss.NewStyle().Foreground(theme.Success)
+
+	fmt.Printf("%s %s\n\n", headerStyle.Render("Sync with baseline"), mutedStyle.Render(baseline))
+
+	if len(pending) == 0 {
+		fmt.Println(successStyle.Render("Project is aligned with the baseline"))
+	}
+	if len(kept) > 0 {
+		fmt.Println(mutedStyle.Render(fmt.Sprintf("%d recorded deviation(s)", len(kept))))
+	}
+
+	if cmd.Bool("dry-run") {
+		for _, proposal := range pending {
+			fmt.Printf("  %s %s%s\n", proposal.action(), proposal.rule.ID, formatSyncDetail(proposal, mutedStyle))
+		}
+		return nil
+	}
+
+	// Non-interactive runs take each prompt's default, which is to apply
+	skipConfirmation := cmd.Bool("yes") || tui.IsNonInteractive()
+	applied := 0
+	for _, proposal := range pending {
+		decision := syncApply
+		reason := ""
+		if !skipConfirmation {
+			decision, reason, err = c.decide(proposal)
+			if err != nil {
+				if errors.Is(err, tui.ErrUserCancelled) {
+					log.Info("Sync cancelled")
+					return nil
+				}
+				return err
+			}
+		}
+
+		switch decision {
+		case syncApply:
+			if err := c.apply(config, proposal); err != nil {
+				return err
+			}
+			applied++
+			fmt.Printf("  %s %s %s\n", successStyle.Render("✓"), proposal.action(), proposal.rule.ID)
+		case syncKeep:
+			kept = append(kept, domain.SyncDeviation{Rule: proposal.rule.ID, Type: proposal.kind, Reason: reason})
+			fmt.Printf("  %s %s\n", mutedStyle.Render("kept"), proposal.rule.ID)
+		case syncSkip:
+		}
+	}
+
+	config.Sync = &domain.SyncConfig{Baseline: baseline, Deviations: kept}
+	if err := c.projectManager.SaveConfig(config, configResult.Location, basePath); err != nil {
+		return contextureerrors.Wrap(err, "save configuration")
+	}
+
+	if applied > 0 {
+		fmt.Println()
+		fmt.Println(mutedStyle.Render("Run 'contexture build' to regenerate output files"))
+	}
+	return nil
+}
+
+// syncProposals lists the changes that would make config match baseline
+func syncProposals(projectManager *project.Manager, config, baseline *domain.Project) []syncProposal {
+	var proposals []syncProposal
+
+	for _, want := range baseline.Rules {
+		have := projectManager.FindRule(config, want.ID)
+		if have == nil {
+			proposals = append(proposals, syncProposal{kind: domain.SyncDeviationOmitted, rule: want})
+			continue
+		}
+
+		var detail string
+		switch {
+		case have.GetRef() != want.GetRef():
+			detail = fmt.Sprintf("ref %s, baseline %s", have.GetRef(), want.GetRef())
+		case !sameVariables(have.Variables, want.Variables):
+			detail = "variables differ from baseline"
+		}
+		if detail != "" {
+			proposals = append(proposals, syncProposal{kind: domain.SyncDeviationModified, rule: want, detail: detail})
+		}
+	}
+
+	for _, have := range config.Rules {
+		// Local and inherited rules are not part of the configuration being synced
+		if have.Source == "local" || have.Inherited {
+			continue
+		}
+		if projectManager.FindRule(baseline, have.ID) == nil {
+			proposals = append(proposals, syncProposal{kind: domain.SyncDeviationExtra, rule: have})
+		}
+	}
+
+	return proposals
+}
+
+// apply changes config as proposal describes
+func (c *SyncCommand) apply(config *domain.Project, proposal syncProposal) error {
+	switch proposal.kind {
+	case domain.SyncDeviationOmitted:
+		return c.projectManager.AddRule(config, proposal.rule)
+	case domain.SyncDeviationExtra:
+		return c.projectManager.RemoveRule(config, proposal.rule.ID)
+	case domain.SyncDeviationModified:
+		ref := c.projectManager.FindRule(config, proposal.rule.ID)
+		if ref == nil {
+			return contextureerrors.ValidationErrorf("rule", "rule not found: %s", proposal.rule.ID)
+		}
+		ref.Ref = proposal.rule.Ref
+		ref.Variables = proposal.rule.Variables
+		ref.CommitHash = ""
+		ref.Inherited = false
+	}
+	return nil
+}
+
+// partitionSyncProposals separates proposals already recorded as deviations.
+// Deviations that no longer match a difference are dropped.
+func partitionSyncProposals(
+	proposals []syncProposal,
+	deviations []domain.SyncDeviation,
+) ([]syncProposal, []domain.SyncDeviation) {
+	var pending []syncProposal
+	var kept []domain.SyncDeviation
+	for _, proposal := range proposals {
+		recorded := false
+		for _, deviation := range deviations {
+			if deviation.Type == proposal.kind && deviation.Rule == proposal.rule.ID {
+				kept = append(kept, deviation)
+				recorded = true
+				break
+			}
+		}
+		if !recorded {
+			pending = append(pending, proposal)
+		}
+	}
+	return pending, kept
+}
+
+// sameVariables compares variables, treating nil and empty maps as equal
+func sameVariables(a, b map[string]any) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// formatSyncDetail renders the detail of a proposal for display
+func formatSyncDetail(proposal syncProposal, style lipgloss.Style) string {
+	if proposal.detail == "" {
+		return ""
+	}
+	return " " + style.Render("("+proposal.detail+")")
+}
+
+// promptSyncDecision asks the user what to do with a proposal
+func promptSyncDecision(proposal syncProposal) (syncDecision, string, error) {
+	title := fmt.Sprintf("%s %s?", proposal.action(), proposal.rule.ID)
+	var description string
+	switch proposal.kind {
+	case domain.SyncDeviationOmitted:
+		description = "The baseline includes this rule, but the project does not."
+	case domain.SyncDeviationExtra:
+		description = "The project includes this rule, but the baseline does not."
+	case domain.SyncDeviationModified:
+		description = "The rule differs from the baseline: " + proposal.detail
+	}
+
+	decision := syncApply
+	form := ui.ConfigureHuhForm(huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[syncDecision]().
+				Title(title).
+				Description(description).
+				Options(
+					huh.NewOption("Apply", syncApply),
+					huh.NewOption("Keep as a deviation", syncKeep),
+					huh.NewOption("Skip for now", syncSkip),
+				).
+				Value(&decision),
+		),
+	))
+	if err := tui.RunForm(form); err != nil {
+		return "", "", err
+	}
+	if decision != syncKeep {
+		return decision, "", nil
+	}
+
+	var reason string
+	reasonForm := ui.ConfigureHuhForm(huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Title("Why does this project deviate?").
+				Description("Optional; recorded in .contexture.yaml").
+				Value(&reason),
+		),
+	))
+	if err := tui.RunForm(reasonForm); err != nil {
+		return "", "", err
+	}
+	return decision, reason, nil
+}
+
+// SyncAction is the CLI action handler for the sync command
+func SyncAction(ctx context.Context, cmd *cli.Command, deps *dependencies.Dependencies) error {
+	syncCmd := NewSyncCommand(deps)
+	return syncCmd.Execute(ctx, cmd)
+}
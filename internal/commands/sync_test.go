@@ -0,0 +1,154 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+const syncBaseline = `version: 1
+formats:
+  - type: claude
+    enabled: true
+rules:
+  - id: "[contexture:security/secrets]"
+  - id: "[contexture:go/errors]"
+    variables:
+      strict: true
+`
+
+const syncProject = `version: 1
+formats:
+  - type: claude
+    enabled: true
+rules:
+  - id: "[contexture:go/errors]"
+  - id: "[contexture:team/legacy]"
+`
+
+// runSync runs the sync command against basePath with the given CLI arguments
+func runSync(t *testing.T, syncCmd *SyncCommand, basePath string, args ...string) error {
+	t.Helper()
+
+	var execErr error
+	app := &cli.Command{
+		Name: "sync",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "baseline"},
+			&cli.BoolFlag{Name: "dry-run"},
+			&cli.BoolFlag{Name: "yes"},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			execErr = syncCmd.sync(ctx, cmd, basePath)
+			return nil
+		},
+	}
+
+	require.NoError(t, app.Run(context.Background(), append([]string{"sync"}, args...)))
+	return execErr
+}
+
+func setupSyncProject(t *testing.T) (*SyncCommand, afero.Fs) {
+	t.Helper()
+
+	deps := createTestDependencies()
+	require.NoError(t, afero.WriteFile(deps.FS, "/org/baseline.yaml", []byte(syncBaseline), 0o644))
+	require.NoError(t, afero.WriteFile(deps.FS, "/work/app/.contexture.yaml", []byte(syncProject), 0o644))
+	return NewSyncCommand(deps), deps.FS
+}
+
+func loadSyncedConfig(t *testing.T, fs afero.Fs) *domain.Project {
+	t.Helper()
+
+	result, err := project.NewManager(fs).LoadConfig("/work/app")
+	require.NoError(t, err)
+	return result.Config
+}
+
+func TestSyncCommand_Proposals(t *testing.T) {
+	syncCmd, fs := setupSyncProject(t)
+	manager := project.NewManager(fs)
+	config, err := manager.LoadConfig("/work/app")
+	require.NoError(t, err)
+	baseline, err := manager.LoadReferencedConfig("../../org/baseline.yaml", config)
+	require.NoError(t, err)
+
+	proposals := syncProposals(syncCmd.projectManager, config.Config, baseline)
+	require.Len(t, proposals, 3)
+	assert.Equal(t, domain.SyncDeviationOmitted, proposals[0].kind)
+	assert.Equal(t, "[contexture:security/secrets]", proposals[0].rule.ID)
+	assert.Equal(t, domain.SyncDeviationModified, proposals[1].kind)
+	assert.Equal(t, "[contexture:go/errors]", proposals[1].rule.ID)
+	assert.Equal(t, domain.SyncDeviationExtra, proposals[2].kind)
+	assert.Equal(t, "[contexture:team/legacy]", proposals[2].rule.ID)
+}
+
+func TestSyncCommand_Yes(t *testing.T) {
+	syncCmd, fs := setupSyncProject(t)
+
+	require.NoError(t, runSync(t, syncCmd, "/work/app", "--baseline", "../../org/baseline.yaml", "--yes"))
+
+	config := loadSyncedConfig(t, fs)
+	require.Len(t, config.Rules, 2)
+	assert.Equal(t, "[contexture:go/errors]", config.Rules[0].ID)
+	assert.Equal(t, true, config.Rules[0].Variables["strict"])
+	assert.Equal(t, "[contexture:security/secrets]", config.Rules[1].ID)
+	require.NotNil(t, config.Sync)
+	assert.Equal(t, "../../org/baseline.yaml", config.Sync.Baseline)
+	assert.Empty(t, config.Sync.Deviations)
+}
+
+func TestSyncCommand_RecordsDeviations(t *testing.T) {
+	syncCmd, fs := setupSyncProject(t)
+	var prompted []string
+	syncCmd.decide = func(proposal syncProposal) (syncDecision, string, error) {
+		prompted = append(prompted, proposal.rule.ID)
+		if proposal.kind == domain.SyncDeviationExtra {
+			return syncKeep, "still migrating", nil
+		}
+		return syncSkip, "", nil
+	}
+
+	require.NoError(t, runSync(t, syncCmd, "/work/app", "--baseline", "../../org/baseline.yaml"))
+	assert.Len(t, prompted, 3)
+
+	config := loadSyncedConfig(t, fs)
+	assert.Len(t, config.Rules, 2)
+	require.NotNil(t, config.Sync)
+	assert.Equal(t, []domain.SyncDeviation{{
+		Rule:   "[contexture:team/legacy]",
+		Type:   domain.SyncDeviationExtra,
+		Reason: "still migrating",
+	}}, config.Sync.Deviations)
+
+	// The recorded baseline is reused and kept deviations are not proposed again
+	prompted = nil
+	require.NoError(t, runSync(t, syncCmd, "/work/app"))
+	assert.Equal(t, []string{"[contexture:security/secrets]", "[contexture:go/errors]"}, prompted)
+	assert.Len(t, loadSyncedConfig(t, fs).Sync.Deviations, 1)
+}
+
+func TestSyncCommand_DryRun(t *testing.T) {
+	syncCmd, fs := setupSyncProject(t)
+
+	require.NoError(t, runSync(t, syncCmd, "/work/app", "--baseline", "../../org/baseline.yaml", "--dry-run"))
+
+	config := loadSyncedConfig(t, fs)
+	assert.Nil(t, config.Sync)
+	assert.Len(t, config.Rules, 2)
+}
+
+func TestSyncCommand_NoBaseline(t *testing.T) {
+	syncCmd, _ := setupSyncProject(t)
+
+	err := runSync(t, syncCmd, "/work/app")
+	require.Error(t, err)
+	assert.Equal(t, int(contextureerrors.ExitValidation), contextureerrors.ExitCodeFor(err))
+}
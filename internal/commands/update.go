@@ -15,6 +15,8 @@ import (
 	"github.com/contextureai/contexture/internal/domain"
 	contextureerrors "github.com/contextureai/contexture/internal/errors"
 	"github.com/contextureai/contexture/internal/output"
+	"github.com/contextureai/contexture/internal/ownership"
+	"github.com/contextureai/contexture/internal/permissions"
 	"github.com/contextureai/contexture/internal/project"
 	"github.com/contextureai/contexture/internal/provider"
 	"github.com/contextureai/contexture/internal/rule"
@@ -32,6 +34,8 @@ type UpdateCommand struct {
 	cache            *cache.SimpleCache
 	fs               afero.Fs
 	providerRegistry *provider.Registry
+	workingDir       dependencies.WorkingDir
+	env              dependencies.Environment
 }
 
 // GitCommitInfo represents git commit information for a rule
@@ -77,12 +81,14 @@ const (
 func NewUpdateCommand(deps *dependencies.Dependencies) *UpdateCommand {
 	gitRepo := newOpenRepository(deps.FS)
 	return &UpdateCommand{
-		projectManager:   project.NewManager(deps.FS),
-		ruleFetcher:      rule.NewFetcher(deps.FS, gitRepo, rule.FetcherConfig{}, deps.ProviderRegistry),
+		projectManager:   project.NewManagerFromDependencies(deps),
+		ruleFetcher:      rule.NewFetcher(deps.FS, gitRepo, fetcherConfig(deps), deps.ProviderRegistry),
 		ruleValidator:    rule.NewValidator(),
-		cache:            cache.NewSimpleCache(deps.FS, gitRepo),
+		cache:            newProviderCache(deps.FS, deps.GetEnv(), gitRepo, deps.ProviderRegistry),
 		fs:               deps.FS,
 		providerRegistry: deps.ProviderRegistry,
+		workingDir:       deps.GetWorkingDir(),
+		env:              deps.GetEnv(),
 	}
 }
 
@@ -100,6 +106,8 @@ func NewUpdateCommandWithDependencies(
 		ruleValidator:  ruleValidator,
 		cache:          cache,
 		fs:             fs,
+		workingDir:     dependencies.OSWorkingDir{},
+		env:            dependencies.OSEnvironment{},
 	}
 }
 
@@ -117,7 +125,8 @@ func (c *UpdateCommand) Execute(ctx context.Context, cmd *cli.Command) error {
 		fmt.Printf("%s\n\n", commandHeaderStyle.Render("Update Rules"))
 	}
 	dryRun := cmd.Bool("dry-run")
-	skipConfirmation := cmd.Bool("yes")
+	// Non-interactive runs take the confirmation's default, which is to apply
+	skipConfirmation := cmd.Bool("yes") || tui.IsNonInteractive()
 	isGlobal := cmd.Bool("global")
 
 	// Load configuration based on global flag
@@ -139,7 +148,7 @@ func (c *UpdateCommand) Execute(ctx context.Context, cmd *cli.Command) error {
 		configPath = globalResult.Path
 	} else {
 		// Load project configuration using shared utility
-		configLoadResult, err := LoadProjectConfig(c.projectManager)
+		configLoadResult, err := LoadProjectConfig(c.projectManager, c.workingDir)
 		if err != nil {
 			return err
 		}
@@ -153,6 +162,14 @@ func (c *UpdateCommand) Execute(ctx context.Context, cmd *cli.Command) error {
 		return contextureerrors.Wrap(err, "load providers")
 	}
 
+	// Updates rewrite the configuration, so fail before checking every rule
+	// when it cannot be written
+	if !dryRun {
+		if err := permissions.Check(c.fs, []permissions.Target{{Path: configPath, Description: "configuration"}}); err != nil {
+			return err
+		}
+	}
+
 	const localSource = "local"
 
 	// Filter out local rules - they cannot be updated since they are local files
@@ -166,7 +183,7 @@ func (c *UpdateCommand) Execute(ctx context.Context, cmd *cli.Command) error {
 	if len(updatableRules) == 0 {
 		// Handle output format when no rules to update
 		// outputFormat already declared
-		outputManager, err := output.NewManager(outputFormat)
+		outputManager, err := output.NewManager(outputFormat, c.workingDir, c.env)
 		if err != nil {
 			return contextureerrors.Wrap(err, "create output manager")
 		}
@@ -196,14 +213,14 @@ func (c *UpdateCommand) Execute(ctx context.Context, cmd *cli.Command) error {
 
 	// Check for updates with real-time progress
 	theme := ui.DefaultTheme()
-	if !isJSONMode {
+	if !isJSONMode && ui.CurrentProgressMode() != ui.ProgressQuiet {
 		headerStyle := lipgloss.NewStyle().Bold(true).Foreground(theme.Primary)
 		fmt.Println(headerStyle.Render("Checking for updates..."))
 		fmt.Println()
 	}
 
 	updateResults := c.checkForUpdatesWithProgress(ctx, updatableRules, isJSONMode)
-	if !isJSONMode {
+	if !isJSONMode && ui.CurrentProgressMode() != ui.ProgressQuiet {
 		fmt.Println()
 	}
 
@@ -254,10 +271,29 @@ func (c *UpdateCommand) Execute(ctx context.Context, cmd *cli.Command) error {
 		}
 	}
 
+	// Ask for coordination before updating rules other teams own, when the
+	// project's ownership policy requires it
+	if updatesAvailable > 0 && !isGlobal && !isJSONMode &&
+		config.Ownership != nil && config.Ownership.RequireCoordination {
+		notices, err := c.coordinationNotices(ctx, config, configPath, currentDir, updatableRules, updateResults)
+		if err != nil {
+			return err
+		}
+		if len(notices) > 0 {
+			warningStyle := lipgloss.NewStyle().Foreground(theme.Warning)
+			mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+			fmt.Println()
+			for _, notice := range notices {
+				fmt.Println(warningStyle.Render(fmt.Sprintf("⚠ %s is owned by %s", notice.displayName, notice.owner)))
+			}
+			fmt.Println(mutedStyle.Render("The ownership policy of this project asks to coordinate updates with the owning teams"))
+		}
+	}
+
 	if updatesAvailable == 0 {
 		// Handle output format for no updates available
 		// outputFormat already declared
-		outputManager, err := output.NewManager(outputFormat)
+		outputManager, err := output.NewManager(outputFormat, c.workingDir, c.env)
 		if err != nil {
 			return contextureerrors.Wrap(err, "create output manager")
 		}
@@ -291,7 +327,7 @@ func (c *UpdateCommand) Execute(ctx context.Context, cmd *cli.Command) error {
 	if dryRun {
 		// Handle output format for dry runs
 		// outputFormat already declared
-		outputManager, err := output.NewManager(outputFormat)
+		outputManager, err := output.NewManager(outputFormat, c.workingDir, c.env)
 		if err != nil {
 			return contextureerrors.Wrap(err, "create output manager")
 		}
@@ -353,7 +389,7 @@ func (c *UpdateCommand) Execute(ctx context.Context, cmd *cli.Command) error {
 			),
 		))
 
-		if err := tui.HandleFormError(confirmForm.Run()); err != nil {
+		if err := tui.RunForm(confirmForm); err != nil {
 			return err
 		}
 
@@ -381,7 +417,7 @@ func (c *UpdateCommand) Execute(ctx context.Context, cmd *cli.Command) error {
 
 	// Handle output format
 	// outputFormat already declared
-	outputManager, err := output.NewManager(outputFormat)
+	outputManager, err := output.NewManager(outputFormat, c.workingDir, c.env)
 	if err != nil {
 		return contextureerrors.Wrap(err, "create output manager")
 	}
@@ -427,6 +463,12 @@ func (c *UpdateCommand) Execute(ctx context.Context, cmd *cli.Command) error {
 		}
 	}
 
+	// Some rules updated while others failed: report partial success
+	if len(rulesUpdated) > 0 && len(rulesFailed) > 0 {
+		return contextureerrors.Partial("update rules",
+			fmt.Errorf("%d of %d rule update(s) failed", len(rulesFailed), len(rulesUpdated)+len(rulesFailed)))
+	}
+
 	return nil
 }
 
@@ -439,6 +481,10 @@ func (c *UpdateCommand) checkForUpdatesWithProgress(
 	results := make([]UpdateResult, len(rules))
 	theme := ui.DefaultTheme()
 
+	// Spinners and in-place redraws garble logs, so they are only shown on a terminal
+	showProgress := !isJSONMode
+	animate := showProgress && ui.CurrentProgressMode() == ui.ProgressInteractive
+
 	// Status indicators
 	checkingSpinner := []string{"⢷", "⢹", "⢺", "⢼", "⢾", "⢿", "⠹", "⢸"}
 	spinnerIndex := 0
@@ -496,12 +542,10 @@ func (c *UpdateCommand) checkForUpdatesWithProgress(
 			}
 
 			// Clear line and show pinned status
-			if !isJSONMode {
-				fmt.Printf("\r") // Clear the line first
-				pinnedLine := c.formatRuleDisplay(result,
+			if showProgress {
+				ui.ProgressLine(c.formatRuleDisplay(result,
 					lipgloss.NewStyle().Foreground(theme.Info).Render("~"),
-					lipgloss.NewStyle().Foreground(theme.Muted).Render("pinned"))
-				fmt.Printf("%s\n", pinnedLine)
+					lipgloss.NewStyle().Foreground(theme.Muted).Render("pinned")))
 			}
 			results[i] = result
 			continue
@@ -523,7 +567,7 @@ func (c *UpdateCommand) checkForUpdatesWithProgress(
 			mutedStyle.Render("checking..."),
 		)
 		// Show checking status with simple carriage return
-		if !isJSONMode {
+		if animate {
 			fmt.Printf("\r%s", checkingLine+strings.Repeat(" ", 20)) // Add padding to clear any leftover text
 		}
 
@@ -537,12 +581,10 @@ func (c *UpdateCommand) checkForUpdatesWithProgress(
 			result.Error = contextureerrors.Wrap(err, "check rule for updates")
 			result.Status = StatusError
 			// Clear line and show error with proper formatting
-			if !isJSONMode {
-				fmt.Printf("\r") // Clear the line first
-				errorLine := c.formatRuleDisplay(result,
+			if showProgress {
+				ui.ProgressLine(c.formatRuleDisplay(result,
 					lipgloss.NewStyle().Foreground(theme.Error).Render("✗"),
-					lipgloss.NewStyle().Foreground(theme.Error).Render("error"))
-				fmt.Printf("%s\n", errorLine)
+					lipgloss.NewStyle().Foreground(theme.Error).Render("error")))
 			}
 		} else {
 			// Set current and latest commit info (both now have real dates)
@@ -563,12 +605,10 @@ func (c *UpdateCommand) checkForUpdatesWithProgress(
 				result.LatestVersion = latestCommit.Hash
 
 				// Clear line and show update available with commit info
-				if !isJSONMode {
-					fmt.Printf("\r") // Clear the line first
-					updateLine := c.formatRuleDisplay(result,
+				if showProgress {
+					ui.ProgressLine(c.formatRuleDisplay(result,
 						lipgloss.NewStyle().Foreground(theme.Update).Render("↑"),
-						lipgloss.NewStyle().Foreground(theme.Update).Render("update available"))
-					fmt.Printf("%s\n", updateLine)
+						lipgloss.NewStyle().Foreground(theme.Update).Render("update available")))
 				}
 			} else {
 				result.HasUpdate = false
@@ -577,12 +617,10 @@ func (c *UpdateCommand) checkForUpdatesWithProgress(
 				result.LatestVersion = latestCommit.Hash
 
 				// Clear line and show up to date with commit info
-				if !isJSONMode {
-					fmt.Printf("\r") // Clear the line first
-					upToDateLine := c.formatRuleDisplay(result,
+				if showProgress {
+					ui.ProgressLine(c.formatRuleDisplay(result,
 						lipgloss.NewStyle().Foreground(theme.Success).Render("✓"),
-						lipgloss.NewStyle().Foreground(theme.Muted).Render("up to date"))
-					fmt.Printf("%s\n", upToDateLine)
+						lipgloss.NewStyle().Foreground(theme.Muted).Render("up to date")))
 				}
 			}
 		}
@@ -591,7 +629,9 @@ func (c *UpdateCommand) checkForUpdatesWithProgress(
 		spinnerIndex++
 
 		// Add small delay to show the checking animation
-		time.Sleep(150 * time.Millisecond)
+		if animate {
+			time.Sleep(150 * time.Millisecond)
+		}
 	}
 
 	return results
@@ -638,7 +678,9 @@ func (c *UpdateCommand) checkRuleForUpdate(
 		currentCommitInfo, err := gitRepo.GetCommitInfoByHash(repoDir, currentCommitHash)
 		if err != nil {
 			// Print warning on new line with proper formatting
-			fmt.Printf("\n")
+			if ui.CurrentProgressMode() == ui.ProgressInteractive {
+				fmt.Printf("\n")
+			}
 			log.Warn("Failed to get current commit info", "hash", currentCommitHash, "error", err)
 			currentCommit = &GitCommitInfo{
 				Hash: currentCommitHash,
@@ -668,6 +710,46 @@ func (c *UpdateCommand) checkRuleForUpdate(
 	return currentCommit, latestCommit, hasUpdate, nil
 }
 
+// coordinationNotice is an available update of a rule another team owns
+type coordinationNotice struct {
+	displayName string
+	owner       string
+}
+
+// coordinationNotices returns the rules with available updates that teams
+// other than the project's own. The owner is read from the latest version of
+// each rule; rules that cannot be fetched are skipped.
+func (c *UpdateCommand) coordinationNotices(
+	ctx context.Context,
+	config *domain.Project,
+	configPath, projectDir string,
+	rules []domain.RuleRef,
+	results []UpdateResult,
+) ([]coordinationNotice, error) {
+	team, err := ownership.Team(c.fs, config, projectDir, configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var notices []coordinationNotice
+	for i, result := range results {
+		if result.Status != StatusUpdateAvailable || result.Error != nil {
+			continue
+		}
+		latest := rules[i]
+		latest.CommitHash = ""
+		fetched, err := rule.FetchRuleRef(ctx, c.ruleFetcher, latest)
+		if err != nil {
+			log.Debug("Skipping the ownership check of a rule", "rule", result.RuleID, "error", err)
+			continue
+		}
+		if ownership.OwnedByOthers(fetched.Owner, team) {
+			notices = append(notices, coordinationNotice{displayName: result.DisplayName, owner: fetched.Owner})
+		}
+	}
+	return notices, nil
+}
+
 // updateRuleCommitHash updates the commit hash for a specific rule in the config
 func (c *UpdateCommand) updateRuleCommitHash(config *domain.Project, ruleID, newCommitHash string) {
 	for i, rule := range config.Rules {
@@ -692,8 +774,11 @@ func (c *UpdateCommand) applyUpdates(
 	errorStyle := lipgloss.NewStyle().Foreground(theme.Error)
 	mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
 
-	fmt.Println(headerStyle.Render("Applying updates..."))
-	fmt.Println()
+	mode := ui.CurrentProgressMode()
+	if mode != ui.ProgressQuiet {
+		fmt.Println(headerStyle.Render("Applying updates..."))
+		fmt.Println()
+	}
 
 	updatedCount := 0
 	var errors []string
@@ -709,22 +794,23 @@ func (c *UpdateCommand) applyUpdates(
 			lipgloss.NewStyle().Foreground(theme.Update).Render("↑"),
 			result.DisplayName,
 			mutedStyle.Render("applying..."))
-		fmt.Printf("\r\033[K%s", applyingLine)
+		if mode == ui.ProgressInteractive {
+			fmt.Printf("\r\033[K%s", applyingLine)
 
-		// Simulate some processing time
-		time.Sleep(200 * time.Millisecond)
+			// Simulate some processing time
+			time.Sleep(200 * time.Millisecond)
+		}
 
 		// Fetch and validate the updated rule
 		fetchedRule, err := c.ruleFetcher.FetchRule(ctx, result.RuleID)
 		if err != nil {
 			// Clear line and show error
-			fmt.Printf("\r") // Clear the line first
-			errorLine := fmt.Sprintf("  %s %s %s",
+			ui.ProgressLine(fmt.Sprintf("  %s %s %s",
 				errorStyle.Render("✗"),
 				result.DisplayName,
-				errorStyle.Render("failed"))
-			fmt.Printf("%s\n", errorLine)
+				errorStyle.Render("failed")))
 			errors = append(errors, fmt.Sprintf("%s: %v", result.DisplayName, err))
+			setResultStatus(results, result.RuleID, StatusError)
 			continue
 		}
 
@@ -737,13 +823,12 @@ func (c *UpdateCommand) applyUpdates(
 			}
 			errorMsg := fmt.Sprintf("validation failed: %s", strings.Join(errorMessages, ", "))
 			// Clear line and show validation error
-			fmt.Printf("\r") // Clear the line first
-			validationErrorLine := fmt.Sprintf("  %s %s %s",
+			ui.ProgressLine(fmt.Sprintf("  %s %s %s",
 				errorStyle.Render("✗"),
 				result.DisplayName,
-				errorStyle.Render("validation failed"))
-			fmt.Printf("%s\n", validationErrorLine)
+				errorStyle.Render("validation failed")))
 			errors = append(errors, fmt.Sprintf("%s: %s", result.DisplayName, errorMsg))
+			setResultStatus(results, result.RuleID, StatusError)
 			continue
 		}
 
@@ -751,20 +836,13 @@ func (c *UpdateCommand) applyUpdates(
 		c.updateRuleCommitHash(config, result.RuleID, result.LatestCommit.Hash)
 
 		// Update status to applied
-		for i := range results {
-			if results[i].RuleID == result.RuleID {
-				results[i].Status = StatusApplied
-				break
-			}
-		}
+		setResultStatus(results, result.RuleID, StatusApplied)
 
 		// Clear line and show success
-		fmt.Printf("\r\033[K") // Clear the line first
-		successLine := fmt.Sprintf("  %s %s %s",
+		ui.ProgressLine(fmt.Sprintf("  %s %s %s",
 			successStyle.Render("✓"),
 			result.DisplayName,
-			successStyle.Render("updated"))
-		fmt.Printf("%s\n", successLine)
+			successStyle.Render("updated")))
 		updatedCount++
 	}
 
@@ -781,7 +859,9 @@ func (c *UpdateCommand) applyUpdates(
 	}
 
 	// Display final results
-	fmt.Println()
+	if mode != ui.ProgressQuiet {
+		fmt.Println()
+	}
 	if updatedCount > 0 {
 		headerStyle := lipgloss.NewStyle().Bold(true).Foreground(theme.Success)
 		message := fmt.Sprintf("✓ Successfully updated %d rule(s)", updatedCount)
@@ -806,8 +886,10 @@ func (c *UpdateCommand) applyUpdates(
 
 		// Create build command and execute it
 		buildCmd := NewBuildCommand(&dependencies.Dependencies{
-			FS:      c.fs,
-			Context: ctx,
+			FS:         c.fs,
+			Context:    ctx,
+			WorkingDir: c.workingDir,
+			Env:        c.env,
 		})
 
 		// Create a minimal CLI command for generation
@@ -820,6 +902,16 @@ func (c *UpdateCommand) applyUpdates(
 	return nil
 }
 
+// setResultStatus updates the status of the result matching ruleID
+func setResultStatus(results []UpdateResult, ruleID string, status UpdateStatus) {
+	for i := range results {
+		if results[i].RuleID == ruleID {
+			results[i].Status = status
+			return
+		}
+	}
+}
+
 // shortHash returns the first 7 characters of a commit hash for display
 func shortHash(hash string) string {
 	if len(hash) >= 7 {
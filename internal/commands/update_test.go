@@ -14,6 +14,7 @@ import (
 	"github.com/contextureai/contexture/internal/rule"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/urfave/cli/v3"
 )
@@ -46,7 +47,7 @@ func TestUpdateCommandWithDependencies(t *testing.T) {
 	mockValidator := rule.NewValidator()
 	mockProjectManager := project.NewManager(fs)
 	mockGitRepo := git.NewMockRepository(t)
-	mockCache := cache.NewSimpleCache(fs, mockGitRepo)
+	mockCache := cache.NewSimpleCache(fs, dependencies.OSEnvironment{}, mockGitRepo)
 
 	// Test that we can create an UpdateCommand with explicit dependencies
 	cmd := NewUpdateCommandWithDependencies(
@@ -121,7 +122,7 @@ func TestUpdateCommand_UpdateRuleCommitHash(t *testing.T) {
 	mockValidator := rule.NewValidator()
 	mockProjectManager := project.NewManager(fs)
 	mockGitRepo := git.NewMockRepository(t)
-	mockCache := cache.NewSimpleCache(fs, mockGitRepo)
+	mockCache := cache.NewSimpleCache(fs, dependencies.OSEnvironment{}, mockGitRepo)
 
 	cmd := NewUpdateCommandWithDependencies(
 		mockProjectManager,
@@ -211,3 +212,33 @@ func TestUpdateResult(t *testing.T) {
 	assert.True(t, result.HasUpdate)
 	require.NoError(t, result.Error)
 }
+
+func TestUpdateCommand_CoordinationNotices(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/project/CODEOWNERS", []byte("/.contexture.yaml @acme/web\n"), 0o644))
+
+	fetcher := rule.NewMockFetcher(t)
+	fetcher.EXPECT().FetchRule(mock.Anything, "[contexture:security/secrets]").
+		Return(&domain.Rule{Owner: "@acme/security"}, nil)
+	fetcher.EXPECT().FetchRule(mock.Anything, "[contexture:web/react]").
+		Return(&domain.Rule{Owner: "@acme/web"}, nil)
+	updateCmd := NewUpdateCommandWithDependencies(project.NewManager(fs), fetcher, rule.NewValidator(), nil, fs)
+
+	rules := []domain.RuleRef{
+		{ID: "[contexture:security/secrets]", CommitHash: "abc123"},
+		{ID: "[contexture:web/react]"},
+		{ID: "[contexture:go/errors]"},
+	}
+	results := []UpdateResult{
+		{RuleID: rules[0].ID, DisplayName: "security/secrets", Status: StatusUpdateAvailable},
+		{RuleID: rules[1].ID, DisplayName: "web/react", Status: StatusUpdateAvailable},
+		{RuleID: rules[2].ID, DisplayName: "go/errors", Status: StatusUpToDate},
+	}
+
+	// The team comes from CODEOWNERS, and owners are read from the latest version
+	notices, err := updateCmd.coordinationNotices(context.Background(), &domain.Project{},
+		"/project/.contexture.yaml", "/project", rules, results)
+	require.NoError(t, err)
+	assert.Equal(t, []coordinationNotice{{displayName: "security/secrets", owner: "@acme/security"}}, notices)
+}
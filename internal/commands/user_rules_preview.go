@@ -0,0 +1,250 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/contextureai/contexture/internal/diff"
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/tui"
+	"github.com/contextureai/contexture/internal/ui"
+	"github.com/spf13/afero"
+	"github.com/urfave/cli/v3"
+)
+
+// userRulesWrite controls how rules add and remove --global write the
+// user-level outputs in the home directory
+type userRulesWrite struct {
+	// skip leaves the user-level outputs untouched (--no-user-write)
+	skip bool
+	// yes writes them without asking (--yes)
+	yes bool
+}
+
+// userRulesWriteFromFlags reads the --no-user-write and --yes flags
+func userRulesWriteFromFlags(cmd *cli.Command) userRulesWrite {
+	return userRulesWrite{skip: cmd.Bool("no-user-write"), yes: cmd.Bool("yes")}
+}
+
+// userRulesChange is a user-level file the generation would write or remove
+type userRulesChange struct {
+	path    string
+	old     []byte
+	content []byte
+	mode    os.FileMode
+	removed bool
+}
+
+// writeUserRules generates the user-level outputs of formats, shows the
+// changes they make to the home directory, and writes them once confirmed.
+// Non-interactive runs take the confirmation's default, which is to write.
+func (g *RuleGenerator) writeUserRules(
+	ctx context.Context,
+	config *domain.Project,
+	formats []domain.FormatConfig,
+	opts userRulesWrite,
+) error {
+	theme := ui.DefaultTheme()
+	mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+	if opts.skip {
+		fmt.Println(mutedStyle.Render("Left user-level outputs untouched; run 'contexture build --global' to write them"))
+		return nil
+	}
+
+	changes, err := g.previewUserRules(ctx, config, formats)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	printUserRulesChanges(changes)
+
+	if !opts.yes {
+		confirmed, err := tui.Confirm(tui.ConfirmOptions{
+			Title:       fmt.Sprintf("Write %d user-level file(s)?", len(changes)),
+			Description: "These files are in your home directory and apply to every project.",
+			Default:     true,
+		})
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println(mutedStyle.Render("Left user-level outputs untouched; run 'contexture build --global' to write them"))
+			return nil
+		}
+	}
+
+	successStyle := lipgloss.NewStyle().Foreground(theme.Success)
+	for _, change := range changes {
+		if err := g.applyUserRulesChange(change); err != nil {
+			return err
+		}
+		action := "Updated"
+		if change.removed {
+			action = "Removed"
+		}
+		fmt.Printf("  %s %s %s\n", successStyle.Render("✓"), action, change.path)
+	}
+	return nil
+}
+
+// previewUserRules generates formats onto a layer over a read-only view of
+// the filesystem and returns the files the generation would change
+func (g *RuleGenerator) previewUserRules(
+	ctx context.Context,
+	config *domain.Project,
+	formats []domain.FormatConfig,
+) ([]userRulesChange, error) {
+	layer := afero.NewMemMapFs()
+	previewFs := &userRulesPreviewFs{
+		Fs:      afero.NewCopyOnWriteFs(afero.NewReadOnlyFs(g.fs), layer),
+		base:    g.fs,
+		removed: map[string]bool{},
+	}
+	preview := *g
+	preview.fs = previewFs
+	preview.preview = true
+	if err := preview.GenerateRulesWithScope(ctx, config, formats, "global"); err != nil {
+		return nil, err
+	}
+
+	// Only files in the user rules directories are outputs; the rest, such
+	// as the render cache, are left out of the preview
+	isOutput := func(path string) bool {
+		return slices.ContainsFunc(formats, func(formatConfig domain.FormatConfig) bool {
+			rel, err := filepath.Rel(formatConfig.BaseDir, path)
+			return formatConfig.BaseDir != "" && err == nil && !strings.HasPrefix(rel, "..")
+		})
+	}
+
+	var changes []userRulesChange
+	err := afero.Walk(layer, string(filepath.Separator), func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || !isOutput(path) {
+			return err
+		}
+		content, err := afero.ReadFile(layer, path)
+		if err != nil {
+			return err
+		}
+		old, err := afero.ReadFile(g.fs, path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		if err == nil && bytes.Equal(old, content) {
+			return nil
+		}
+		delete(previewFs.removed, path)
+		mode := info.Mode().Perm()
+		if existing, statErr := g.fs.Stat(path); statErr == nil {
+			mode = existing.Mode().Perm()
+		}
+		if mode == 0 {
+			mode = 0o644
+		}
+		changes = append(changes, userRulesChange{path: path, old: old, content: content, mode: mode})
+		return nil
+	})
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "read user rules preview")
+	}
+	for path := range previewFs.removed {
+		if exists, _ := afero.Exists(layer, path); exists || !isOutput(path) {
+			continue
+		}
+		old, err := afero.ReadFile(g.fs, path)
+		if err != nil {
+			continue
+		}
+		changes = append(changes, userRulesChange{path: path, old: old, removed: true})
+	}
+	slices.SortFunc(changes, func(a, b userRulesChange) int { return strings.Compare(a.path, b.path) })
+	return changes, nil
+}
+
+// applyUserRulesChange writes or removes a previewed file
+func (g *RuleGenerator) applyUserRulesChange(change userRulesChange) error {
+	if change.removed {
+		if err := g.fs.Remove(change.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return contextureerrors.Wrap(err, "remove "+change.path)
+		}
+		return nil
+	}
+	if err := g.fs.MkdirAll(filepath.Dir(change.path), 0o755); err != nil {
+		return contextureerrors.Wrap(err, "create directory for "+change.path)
+	}
+	if err := afero.WriteFile(g.fs, change.path, change.content, change.mode); err != nil {
+		return contextureerrors.Wrap(err, "write "+change.path)
+	}
+	return nil
+}
+
+// printUserRulesChanges prints each changed user-level file with its diff
+func printUserRulesChanges(changes []userRulesChange) {
+	theme := ui.DefaultTheme()
+	pathStyle := lipgloss.NewStyle().Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+
+	fmt.Println()
+	for _, change := range changes {
+		status := "changed"
+		switch {
+		case change.removed:
+			status = "removed"
+		case change.old == nil:
+			status = "added"
+		}
+		fmt.Printf("%s %s\n", pathStyle.Render(change.path), mutedStyle.Render("("+status+")"))
+		fmt.Println(ui.RenderDiff(diff.Lines(string(change.old), string(change.content))))
+		fmt.Println()
+	}
+}
+
+// userRulesPreviewFs is a copy-on-write filesystem that records removals of
+// files in the read-only base instead of failing, so they can be applied
+// once the preview is confirmed
+type userRulesPreviewFs struct {
+	afero.Fs
+	base    afero.Fs
+	removed map[string]bool
+}
+
+// Remove removes name from the layer and records its removal from the base
+func (fs *userRulesPreviewFs) Remove(name string) error {
+	fs.recordRemoval(name)
+	return ignoreBaseRemoval(fs.Fs.Remove(name))
+}
+
+// RemoveAll removes path from the layer and records the removal of the base
+// files under it
+func (fs *userRulesPreviewFs) RemoveAll(path string) error {
+	_ = afero.Walk(fs.base, path, func(name string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			fs.removed[name] = true
+		}
+		return nil
+	})
+	return ignoreBaseRemoval(fs.Fs.RemoveAll(path))
+}
+
+func (fs *userRulesPreviewFs) recordRemoval(name string) {
+	if info, err := fs.base.Stat(name); err == nil && !info.IsDir() {
+		fs.removed[name] = true
+	}
+}
+
+func ignoreBaseRemoval(err error) error {
+	if errors.Is(err, syscall.EPERM) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/contextureai/contexture/internal/format"
+	"github.com/contextureai/contexture/internal/rule"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const userClaudeFile = "/home/user/.claude/CLAUDE.md"
+
+// newUserRulesGenerator returns a generator whose fetcher serves one rule,
+// and the Claude user rules format config
+func newUserRulesGenerator(t *testing.T, fs afero.Fs, content string) (*RuleGenerator, []domain.FormatConfig) {
+	t.Helper()
+	fetcher := rule.NewMockFetcher(t)
+	fetcher.EXPECT().FetchRule(mock.Anything, "[contexture:go/style]").Return(&domain.Rule{
+		ID:          "[contexture:go/style]",
+		Title:       "Style",
+		Description: "Go style",
+		Tags:        []string{"go"},
+		Content:     content,
+	}, nil).Maybe()
+	generator := NewRuleGenerator(fetcher, rule.NewValidator(), rule.NewProcessor(), format.GetDefaultRegistry(fs), fs)
+
+	caps := domain.FormatCapabilities{SupportsUserRules: true, UserRulesPath: userClaudeFile}
+	formats := []domain.FormatConfig{
+		userRulesFormatConfig(domain.FormatConfig{Type: domain.FormatClaude, Enabled: true}, caps, &domain.Project{}),
+	}
+	return generator, formats
+}
+
+func TestRuleGenerator_WriteUserRules(t *testing.T) {
+	config := &domain.Project{Rules: []domain.RuleRef{{ID: "[contexture:go/style]"}}}
+
+	t.Run("preview leaves the home directory untouched", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		generator, formats := newUserRulesGenerator(t, fs, "Keep functions small.")
+
+		changes, err := generator.previewUserRules(context.Background(), config, formats)
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+		assert.Equal(t, userClaudeFile, changes[0].path)
+		assert.Nil(t, changes[0].old)
+		assert.Contains(t, string(changes[0].content), "Keep functions small.")
+
+		exists, err := afero.Exists(fs, userClaudeFile)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("yes writes the previewed files", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		generator, formats := newUserRulesGenerator(t, fs, "Keep functions small.")
+
+		require.NoError(t, generator.writeUserRules(context.Background(), config, formats, userRulesWrite{yes: true}))
+		content, err := afero.ReadFile(fs, userClaudeFile)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "Keep functions small.")
+
+		// A second run has nothing left to change
+		changes, err := generator.previewUserRules(context.Background(), config, formats)
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("no-user-write skips the home directory", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		generator, formats := newUserRulesGenerator(t, fs, "Keep functions small.")
+
+		require.NoError(t, generator.writeUserRules(context.Background(), config, formats, userRulesWrite{skip: true}))
+		exists, err := afero.Exists(fs, userClaudeFile)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("existing files are diffed against their content", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		generator, formats := newUserRulesGenerator(t, fs, "Keep functions small.")
+		require.NoError(t, generator.writeUserRules(context.Background(), config, formats, userRulesWrite{yes: true}))
+		before, err := afero.ReadFile(fs, userClaudeFile)
+		require.NoError(t, err)
+
+		generator, formats = newUserRulesGenerator(t, fs, "Keep functions short.")
+		changes, err := generator.previewUserRules(context.Background(), config, formats)
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+		assert.Equal(t, before, changes[0].old)
+		assert.Contains(t, string(changes[0].content), "Keep functions short.")
+
+		after, err := afero.ReadFile(fs, userClaudeFile)
+		require.NoError(t, err)
+		assert.Equal(t, before, after)
+	})
+}
@@ -0,0 +1,436 @@
+// Package commands provides CLI command implementations
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/contextureai/contexture/internal/approval"
+	"github.com/contextureai/contexture/internal/budget"
+	"github.com/contextureai/contexture/internal/condition"
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/format"
+	"github.com/contextureai/contexture/internal/git"
+	"github.com/contextureai/contexture/internal/globs"
+	"github.com/contextureai/contexture/internal/output"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/contextureai/contexture/internal/provider"
+	"github.com/contextureai/contexture/internal/redact"
+	"github.com/contextureai/contexture/internal/rule"
+	"github.com/contextureai/contexture/internal/safety"
+	"github.com/contextureai/contexture/internal/summarize"
+	"github.com/spf13/afero"
+	"github.com/urfave/cli/v3"
+)
+
+// Validation check categories reported by the validate command
+const (
+	validateCheckConfig   = "config"
+	validateCheckLock     = "lock"
+	validateCheckRule     = "rule"
+	validateCheckProvider = "provider"
+)
+
+// commitHashRegex matches abbreviated or full git commit hashes
+var commitHashRegex = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+
+// ValidateCommand implements the validate command
+type ValidateCommand struct {
+	fs               afero.Fs
+	projectManager   *project.Manager
+	registry         *format.Registry
+	repository       git.Repository
+	parser           rule.Parser
+	providerRegistry *provider.Registry
+	workingDir       dependencies.WorkingDir
+	env              dependencies.Environment
+}
+
+// NewValidateCommand creates a new validate command
+func NewValidateCommand(deps *dependencies.Dependencies) *ValidateCommand {
+	return &ValidateCommand{
+		fs:               deps.FS,
+		projectManager:   project.NewManagerFromDependencies(deps),
+		registry:         format.GetDefaultRegistry(deps.FS),
+		repository:       newOpenRepository(deps.FS),
+		parser:           rule.NewParser(),
+		providerRegistry: deps.ProviderRegistry,
+		workingDir:       deps.GetWorkingDir(),
+		env:              deps.GetEnv(),
+	}
+}
+
+// Execute runs the validate command
+func (c *ValidateCommand) Execute(ctx context.Context, cmd *cli.Command) error {
+	currentDir, err := c.workingDir.Getwd()
+	if err != nil {
+		return contextureerrors.Wrap(err, "get current directory")
+	}
+
+	return c.validate(ctx, cmd, currentDir)
+}
+
+// validate runs all checks against the project in basePath and reports the results
+func (c *ValidateCommand) validate(ctx context.Context, cmd *cli.Command, basePath string) error {
+	outputFormat := output.Format(cmd.String("output"))
+	outputManager, err := output.NewManager(outputFormat, c.workingDir, c.env)
+	if err != nil {
+		return contextureerrors.Wrap(err, "create output manager")
+	}
+
+	metadata := output.ValidateMetadata{
+		Offline: cmd.Bool("offline"),
+	}
+
+	issues, configResult := c.validateConfig(basePath)
+	if configResult != nil {
+		metadata.ConfigPath = configResult.Path
+
+		issues = append(issues, c.validateLock(configResult)...)
+
+		ruleIssues, rulesChecked := c.validateLocalRules(configResult, basePath)
+		issues = append(issues, ruleIssues...)
+		metadata.RulesChecked = rulesChecked
+
+		providerIssues, providersChecked := c.validateProviders(ctx, configResult.Config, metadata.Offline)
+		issues = append(issues, providerIssues...)
+		metadata.ProvidersChecked = providersChecked
+	}
+
+	errorCount := 0
+	for _, issue := range issues {
+		if !issue.Warning {
+			errorCount++
+		}
+	}
+	metadata.Valid = errorCount == 0
+
+	if err := outputManager.WriteValidationResults(issues, metadata); err != nil {
+		return contextureerrors.Wrap(err, "write validation results")
+	}
+
+	if !metadata.Valid {
+		return contextureerrors.ValidationErrorf("project", "validation failed with %d issue(s)", errorCount)
+	}
+
+	log.Debug("Validation passed",
+		"config", metadata.ConfigPath,
+		"rules", metadata.RulesChecked,
+		"providers", metadata.ProvidersChecked)
+
+	return nil
+}
+
+// validateConfig loads and validates the project configuration file
+func (c *ValidateCommand) validateConfig(basePath string) ([]output.ValidationIssue, *domain.ConfigResult) {
+	configResult, err := c.projectManager.LoadConfig(basePath)
+	if err == nil {
+		var issues []output.ValidationIssue
+		for _, formatConfig := range configResult.Config.Formats {
+			if !c.registry.IsSupported(formatConfig.Type) {
+				issues = append(issues, output.ValidationIssue{
+					Check:   validateCheckConfig,
+					Path:    configResult.Path,
+					Message: fmt.Sprintf("unsupported format type %q", formatConfig.Type),
+				})
+			}
+			for _, err := range []error{
+				formatConfig.ValidateRulePatterns(),
+				formatConfig.ValidateSettings(),
+				formatConfig.ValidateGroupBy(),
+				formatConfig.ValidateFileSizes(),
+				formatConfig.ValidateWrap(),
+				formatConfig.ValidateAssets(),
+			} {
+				if err != nil {
+					issues = append(issues, output.ValidationIssue{
+						Check:   validateCheckConfig,
+						Path:    configResult.Path,
+						Message: err.Error(),
+					})
+				}
+			}
+		}
+		if configResult.Config.Generation != nil && configResult.Config.Generation.Model != "" {
+			if _, err := budget.Lookup(configResult.Config.Generation.Model); err != nil {
+				issues = append(issues, output.ValidationIssue{
+					Check:   validateCheckConfig,
+					Path:    configResult.Path,
+					Message: err.Error(),
+				})
+			}
+		}
+		if _, err := configResult.Config.Generation.Budget(nil); err != nil {
+			issues = append(issues, output.ValidationIssue{
+				Check:   validateCheckConfig,
+				Path:    configResult.Path,
+				Message: err.Error(),
+			})
+		}
+		if configResult.Config.Generation != nil && configResult.Config.Generation.Summarize != nil {
+			if err := summarize.Validate(*configResult.Config.Generation.Summarize); err != nil {
+				issues = append(issues, output.ValidationIssue{
+					Check:   validateCheckConfig,
+					Path:    configResult.Path,
+					Message: err.Error(),
+				})
+			}
+		}
+		if configResult.Config.Generation != nil && configResult.Config.Generation.Redact != nil {
+			if err := redact.Validate(*configResult.Config.Generation.Redact); err != nil {
+				issues = append(issues, output.ValidationIssue{
+					Check:   validateCheckConfig,
+					Path:    configResult.Path,
+					Message: err.Error(),
+				})
+			}
+		}
+		if configResult.Config.Generation != nil && configResult.Config.Generation.Safety != nil {
+			if err := safety.Validate(*configResult.Config.Generation.Safety); err != nil {
+				issues = append(issues, output.ValidationIssue{
+					Check:   validateCheckConfig,
+					Path:    configResult.Path,
+					Message: err.Error(),
+				})
+			}
+		}
+		for _, ref := range configResult.Config.Rules {
+			if approval.Pending(configResult.Config.Approval, ref) {
+				issues = append(issues, output.ValidationIssue{
+					Check:   validateCheckConfig,
+					Path:    ref.ID,
+					Message: "rule is pending approval and is not built",
+					Warning: true,
+				})
+			}
+		}
+		if err := domain.ValidateMCPServers(configResult.Config.MCPServers); err != nil {
+			issues = append(issues, output.ValidationIssue{
+				Check:   validateCheckConfig,
+				Path:    configResult.Path,
+				Message: err.Error(),
+			})
+		}
+		for _, ref := range configResult.Config.Rules {
+			if ref.When == "" {
+				continue
+			}
+			if err := condition.Validate(ref.When); err != nil {
+				issues = append(issues, output.ValidationIssue{
+					Check:   validateCheckConfig,
+					Path:    ref.ID,
+					Message: err.Error(),
+				})
+			}
+		}
+		if _, err := c.projectManager.LoadLocalOverrides(configResult); err != nil {
+			issues = append(issues, output.ValidationIssue{
+				Check:   validateCheckConfig,
+				Path:    c.projectManager.LocalOverridesPath(configResult),
+				Message: err.Error(),
+			})
+		}
+		if configResult.Config.Extends != "" {
+			if _, err := c.projectManager.LoadConfigWithLocalRules(basePath); err != nil {
+				issues = append(issues, output.ValidationIssue{
+					Check:   validateCheckConfig,
+					Path:    configResult.Path,
+					Message: err.Error(),
+				})
+			}
+		}
+		return issues, configResult
+	}
+
+	path := basePath
+	var configErr *project.ConfigError
+	if errors.As(err, &configErr) {
+		path = configErr.Path
+	}
+
+	return []output.ValidationIssue{{
+		Check:   validateCheckConfig,
+		Path:    path,
+		Message: err.Error(),
+	}}, nil
+}
+
+// validateLock checks that pinned rule references carry a well-formed commit hash
+func (c *ValidateCommand) validateLock(configResult *domain.ConfigResult) []output.ValidationIssue {
+	var issues []output.ValidationIssue
+
+	for _, ref := range configResult.Config.Rules {
+		switch {
+		case ref.Pinned && ref.CommitHash == "":
+			issues = append(issues, output.ValidationIssue{
+				Check:   validateCheckLock,
+				Path:    ref.ID,
+				Message: "rule is pinned but has no commit hash",
+			})
+		case ref.CommitHash != "" && !commitHashRegex.MatchString(ref.CommitHash):
+			issues = append(issues, output.ValidationIssue{
+				Check:   validateCheckLock,
+				Path:    ref.ID,
+				Message: fmt.Sprintf("invalid commit hash %q", ref.CommitHash),
+			})
+		}
+	}
+
+	return issues
+}
+
+// validateLocalRules parses and validates every local rule file without processing
+// templates, and warns about trigger globs that match no files in basePath
+func (c *ValidateCommand) validateLocalRules(configResult *domain.ConfigResult, basePath string) ([]output.ValidationIssue, int) {
+	localRules, err := c.projectManager.DiscoverLocalRules(configResult)
+	if err != nil {
+		return []output.ValidationIssue{{
+			Check:   validateCheckRule,
+			Path:    filepath.Dir(configResult.Path),
+			Message: err.Error(),
+		}}, 0
+	}
+
+	rulesDir := filepath.Join(filepath.Dir(configResult.Path), domain.LocalRulesDir)
+
+	var issues []output.ValidationIssue
+	// projectFiles is listed when the first glob trigger is found
+	var projectFiles []string
+	for _, ref := range localRules {
+		rulePath := filepath.Join(rulesDir, ref.ID+domain.MarkdownExt)
+
+		data, err := afero.ReadFile(c.fs, rulePath)
+		if err != nil {
+			issues = append(issues, output.ValidationIssue{
+				Check:   validateCheckRule,
+				Path:    rulePath,
+				Message: err.Error(),
+			})
+			continue
+		}
+
+		metadata := rule.Metadata{
+			ID:       fmt.Sprintf("[contexture(local):%s]", ref.ID),
+			FilePath: ref.ID,
+			Source:   "local",
+		}
+		parsed, err := c.parser.ParseRule(string(data), metadata)
+		if err != nil {
+			issues = append(issues, output.ValidationIssue{
+				Check:   validateCheckRule,
+				Path:    rulePath,
+				Message: err.Error(),
+			})
+			continue
+		}
+
+		trigger := parsed.GetDefaultTrigger()
+		if trigger.Type != domain.TriggerGlob || len(trigger.Globs) == 0 {
+			continue
+		}
+		if projectFiles == nil {
+			if projectFiles, err = globs.ProjectFiles(c.fs, basePath); err != nil {
+				log.Debug("Skipping trigger glob checks", "error", err)
+				projectFiles = []string{}
+				continue
+			}
+		}
+		if len(projectFiles) == 0 {
+			continue
+		}
+		for _, pattern := range trigger.Globs {
+			if globs.Count(pattern, projectFiles) == 0 {
+				issues = append(issues, output.ValidationIssue{
+					Check:   validateCheckRule,
+					Path:    rulePath,
+					Message: fmt.Sprintf("trigger glob %q matches no files in the project", pattern),
+					Warning: true,
+				})
+			}
+		}
+	}
+
+	return issues, len(localRules)
+}
+
+// validateProviders checks that every remote rule source resolves, and unless offline, is reachable
+func (c *ValidateCommand) validateProviders(
+	ctx context.Context,
+	config *domain.Project,
+	offline bool,
+) ([]output.ValidationIssue, int) {
+	if err := c.providerRegistry.LoadFromProject(config); err != nil {
+		return []output.ValidationIssue{{
+			Check:   validateCheckProvider,
+			Message: err.Error(),
+		}}, 0
+	}
+
+	var issues []output.ValidationIssue
+	urls := make(map[string]bool)
+
+	for _, p := range config.Providers {
+		urls[p.URL] = true
+	}
+
+	parser := rule.NewRuleIDParser(domain.DefaultRepository, c.providerRegistry)
+	for _, ref := range config.Rules {
+		if ref.Source == "local" {
+			continue
+		}
+
+		if strings.HasPrefix(ref.Source, "https://") || strings.HasPrefix(ref.Source, "git@") {
+			urls[ref.Source] = true
+			continue
+		}
+
+		parsed, err := parser.ParseRuleID(ref.ID)
+		if err != nil {
+			issues = append(issues, output.ValidationIssue{
+				Check:   validateCheckProvider,
+				Path:    ref.ID,
+				Message: err.Error(),
+			})
+			continue
+		}
+		urls[parsed.Source] = true
+	}
+
+	sortedURLs := make([]string, 0, len(urls))
+	for url := range urls {
+		sortedURLs = append(sortedURLs, url)
+	}
+	sort.Strings(sortedURLs)
+
+	for _, url := range sortedURLs {
+		var err error
+		if offline {
+			err = c.repository.ValidateURL(url)
+		} else {
+			err = c.repository.CheckRemote(ctx, url)
+		}
+		if err != nil {
+			issues = append(issues, output.ValidationIssue{
+				Check:   validateCheckProvider,
+				Path:    url,
+				Message: err.Error(),
+			})
+		}
+	}
+
+	return issues, len(sortedURLs)
+}
+
+// ValidateAction is the CLI action handler for the validate command
+func ValidateAction(ctx context.Context, cmd *cli.Command, deps *dependencies.Dependencies) error {
+	validateCmd := NewValidateCommand(deps)
+	return validateCmd.Execute(ctx, cmd)
+}
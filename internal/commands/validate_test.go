@@ -0,0 +1,282 @@
+// Package commands provides CLI command implementations
+package commands
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+func TestNewValidateCommand(t *testing.T) {
+	deps := createTestDependencies()
+
+	cmd := NewValidateCommand(deps)
+
+	assert.NotNil(t, cmd)
+	assert.NotNil(t, cmd.projectManager)
+	assert.NotNil(t, cmd.repository)
+	assert.NotNil(t, cmd.parser)
+	assert.NotNil(t, cmd.providerRegistry)
+}
+
+// runValidate runs the validate command against basePath with the given CLI arguments
+func runValidate(t *testing.T, fs afero.Fs, basePath string, args ...string) error {
+	t.Helper()
+
+	deps := createTestDependencies()
+	deps.FS = fs
+	validateCmd := NewValidateCommand(deps)
+
+	app := &cli.Command{
+		Name: "validate",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "offline"},
+			&cli.StringFlag{Name: "output", Value: "default"},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return validateCmd.validate(ctx, cmd, basePath)
+		},
+		// Prevent the CLI from exiting the test process on error
+		ExitErrHandler: func(context.Context, *cli.Command, error) {},
+	}
+
+	return app.Run(context.Background(), append([]string{"validate"}, args...))
+}
+
+func writeValidateFixture(t *testing.T, fs afero.Fs, basePath, config string, rules map[string]string) {
+	t.Helper()
+
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(basePath, domain.ConfigFile), []byte(config), 0o644))
+	for name, content := range rules {
+		path := filepath.Join(basePath, domain.LocalRulesDir, name)
+		require.NoError(t, fs.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, afero.WriteFile(fs, path, []byte(content), 0o644))
+	}
+}
+
+func TestValidateCommand_Validate(t *testing.T) {
+	const basePath = "/project"
+	const validConfig = `version: 1
+formats:
+  - type: claude
+    enabled: true
+rules: []
+`
+	const validRule = `---
+title: Local Rule
+---
+Always write tests.
+`
+
+	tests := []struct {
+		name        string
+		config      string
+		rules       map[string]string
+		expectError bool
+	}{
+		{
+			name:   "valid project with local rules",
+			config: validConfig,
+			rules: map[string]string{
+				"testing.md": validRule,
+			},
+		},
+		{
+			name:   "local rule without title",
+			config: validConfig,
+			rules: map[string]string{
+				"broken.md": "---\ndescription: missing title\n---\nBody\n",
+			},
+			expectError: true,
+		},
+		{
+			name: "pinned rule without commit hash",
+			config: `version: 1
+formats:
+  - type: claude
+    enabled: true
+rules:
+  - id: "[contexture:languages/go/testing]"
+    pinned: true
+`,
+			expectError: true,
+		},
+		{
+			name: "malformed commit hash",
+			config: `version: 1
+formats:
+  - type: claude
+    enabled: true
+rules:
+  - id: "[contexture:languages/go/testing]"
+    commitHash: "not-a-hash"
+`,
+			expectError: true,
+		},
+		{
+			name: "invalid when expression",
+			config: `version: 1
+formats:
+  - type: claude
+    enabled: true
+rules:
+  - id: "[contexture:languages/go/testing]"
+    when: "project.language =="
+`,
+			expectError: true,
+		},
+		{
+			name: "unknown model profile",
+			config: `version: 1
+formats:
+  - type: claude
+    enabled: true
+generation:
+  model: gpt-1
+rules: []
+`,
+			expectError: true,
+		},
+		{
+			name: "summarize command strategy without command",
+			config: `version: 1
+formats:
+  - type: claude
+    enabled: true
+generation:
+  summarize:
+    strategy: command
+rules: []
+`,
+			expectError: true,
+		},
+		{
+			name: "invalid format rule pattern",
+			config: `version: 1
+formats:
+  - type: cursor
+    enabled: true
+    exclude: ["architecture/[a"]
+rules: []
+`,
+			expectError: true,
+		},
+		{
+			name: "settings on non-claude format",
+			config: `version: 1
+formats:
+  - type: cursor
+    enabled: true
+    settings:
+      allowedTools: ["Read"]
+rules: []
+`,
+			expectError: true,
+		},
+		{
+			name: "mcp server without command",
+			config: `version: 1
+formats:
+  - type: claude
+    enabled: true
+mcpServers:
+  github:
+    args: ["--stdio"]
+rules: []
+`,
+			expectError: true,
+		},
+		{
+			name: "unknown provider",
+			config: `version: 1
+formats:
+  - type: claude
+    enabled: true
+rules:
+  - id: "@missing/security/auth"
+`,
+			expectError: true,
+		},
+		{
+			name: "invalid format type",
+			config: `version: 1
+formats:
+  - type: unknown
+    enabled: true
+rules: []
+`,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			writeValidateFixture(t, fs, basePath, tt.config, tt.rules)
+
+			err := runValidate(t, fs, basePath, "--offline", "--output", "json")
+			if tt.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "validation failed")
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateCommand_Validate_NoConfig(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, fs.MkdirAll("/empty", 0o755))
+
+	err := runValidate(t, fs, "/empty", "--offline")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "validation failed")
+}
+
+func TestValidateCommand_Validate_UnsupportedOutput(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	err := runValidate(t, fs, "/project", "--output", "xml")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported output format")
+}
+
+func TestValidateCommand_Validate_LocalOverrides(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	writeValidateFixture(t, fs, "/project", "version: 1\nformats:\n  - type: claude\n    enabled: true\n", nil)
+	require.NoError(t, afero.WriteFile(fs, "/project/"+domain.LocalConfigFile, []byte("rules: [not: valid"), 0o644))
+
+	err := runValidate(t, fs, "/project", "--offline", "--output", "json")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "validation failed")
+}
+
+func TestValidateCommand_ValidateLocalRules_TriggerGlobs(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	writeValidateFixture(t, fs, "/project", "version: 1\nformats:\n  - type: claude\n    enabled: true\nrules: []\n", map[string]string{
+		"go.md": "---\ntitle: Go\ntrigger:\n  type: glob\n  globs: [\"**/*.go\", \"**/*.rs\"]\n---\nUse gofmt.\n",
+	})
+	require.NoError(t, afero.WriteFile(fs, "/project/cmd/main.go", []byte("package main"), 0o644))
+
+	deps := createTestDependencies()
+	deps.FS = fs
+	validateCmd := NewValidateCommand(deps)
+	configResult, err := validateCmd.projectManager.LoadConfig("/project")
+	require.NoError(t, err)
+
+	issues, checked := validateCmd.validateLocalRules(configResult, "/project")
+	assert.Equal(t, 1, checked)
+	require.Len(t, issues, 1)
+	assert.True(t, issues[0].Warning)
+	assert.Contains(t, issues[0].Message, `"**/*.rs" matches no files`)
+
+	// Warnings are reported without failing validation
+	require.NoError(t, runValidate(t, fs, "/project", "--offline", "--output", "json"))
+}
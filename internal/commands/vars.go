@@ -0,0 +1,248 @@
+// Package commands provides CLI command implementations
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/contextureai/contexture/internal/provider"
+	"github.com/contextureai/contexture/internal/rule"
+	"github.com/contextureai/contexture/internal/tui"
+	"github.com/contextureai/contexture/internal/ui"
+	"github.com/urfave/cli/v3"
+	"gopkg.in/yaml.v3"
+)
+
+// VarsCommand implements the vars command
+type VarsCommand struct {
+	projectManager   *project.Manager
+	ruleFetcher      rule.Fetcher
+	providerRegistry *provider.Registry
+	// editVariables prompts for new variable values; replaced in tests
+	editVariables func(ruleID string, fields []tui.InputField) (map[string]string, error)
+	// rebuild regenerates the rule files once variables change; replaced in tests
+	rebuild    func(ctx context.Context) error
+	workingDir dependencies.WorkingDir
+}
+
+// NewVarsCommand creates a new vars command
+func NewVarsCommand(deps *dependencies.Dependencies) *VarsCommand {
+	return &VarsCommand{
+		projectManager:   project.NewManagerFromDependencies(deps),
+		ruleFetcher:      rule.NewFetcher(deps.FS, newOpenRepository(deps.FS), fetcherConfig(deps), deps.ProviderRegistry),
+		providerRegistry: deps.ProviderRegistry,
+		editVariables:    promptVariables,
+		rebuild: func(ctx context.Context) error {
+			return NewBuildCommand(deps).Execute(ctx, &cli.Command{})
+		},
+		workingDir: deps.GetWorkingDir(),
+	}
+}
+
+// Execute runs the vars command
+func (c *VarsCommand) Execute(ctx context.Context, cmd *cli.Command) error {
+	ruleID := cmd.Args().First()
+	if ruleID == "" {
+		return contextureerrors.ValidationErrorf("rule-id", "rule ID is required")
+	}
+
+	isGlobal := cmd.Bool("global")
+	config, _, err := loadConfigByScope(c.projectManager, c.workingDir, isGlobal)
+	if err != nil {
+		return err
+	}
+	if err := c.providerRegistry.LoadFromProject(config); err != nil {
+		return contextureerrors.Wrap(err, "load providers")
+	}
+
+	ruleRef := c.projectManager.FindRule(config, ruleID)
+	if ruleRef == nil {
+		return contextureerrors.Wrap(fmt.Errorf("rule %s is not configured: %w", ruleID, contextureerrors.ErrNotFound), "find rule").
+			WithSuggestions(contextureerrors.RunCommand("contexture rules list", "see the configured rules"))
+	}
+	if ruleRef.Source == "local" {
+		return contextureerrors.ValidationErrorf("rule-id",
+			"%s is a local rule; set its variables in the rule file's frontmatter", ruleID)
+	}
+
+	fetchedRule, err := c.ruleFetcher.FetchRule(ctx, ruleRef.ID)
+	if err != nil {
+		return contextureerrors.Wrap(err, "fetch rule")
+	}
+	defaults := fetchedRule.DefaultVariables
+
+	// Start from the configured values layered over the rule's defaults
+	current := make(map[string]any, len(defaults)+len(ruleRef.Variables))
+	for key, value := range defaults {
+		current[key] = value
+	}
+	for key, value := range ruleRef.Variables {
+		current[key] = value
+	}
+
+	if varFlags := cmd.StringSlice("var"); len(varFlags) > 0 {
+		for _, varFlag := range varFlags {
+			key, value, err := parseVarFlag(varFlag)
+			if err != nil {
+				return contextureerrors.Wrap(err, "parse var")
+			}
+			current[key] = value
+		}
+	} else {
+		if len(current) == 0 {
+			fmt.Printf("%s declares no variables\n", domain.ExtractRulePath(ruleRef.ID))
+			return nil
+		}
+
+		edited, err := c.editVariables(ruleRef.ID, variableFields(current, defaults))
+		if err != nil {
+			if errors.Is(err, tui.ErrUserCancelled) {
+				log.Info("Variable editing cancelled")
+				return nil
+			}
+			return err
+		}
+		for key, input := range edited {
+			value, err := parseVariableInput(input, defaults[key])
+			if err != nil {
+				return contextureerrors.ValidationError(key, err)
+			}
+			current[key] = value
+		}
+	}
+
+	// Only values that differ from the rule's defaults are stored
+	ruleRef.Variables = rule.FilterNonDefaultVariables(current, defaults)
+
+	if err := c.saveVariables(config, isGlobal, ruleRef); err != nil {
+		return err
+	}
+
+	theme := ui.DefaultTheme()
+	successStyle := lipgloss.NewStyle().Foreground(theme.Success)
+	fmt.Println(successStyle.Render("✓ Updated variables for " + domain.ExtractRulePath(ruleRef.ID)))
+
+	return c.regenerate(ctx)
+}
+
+// saveVariables saves the variables of the rules, taken from config, to the
+// global or project configuration
+func (c *VarsCommand) saveVariables(config *domain.Project, isGlobal bool, ruleRefs ...*domain.RuleRef) error {
+	if isGlobal {
+		if err := c.projectManager.SaveGlobalConfig(config); err != nil {
+			return contextureerrors.Wrap(err, "save global config")
+		}
+		return nil
+	}
+
+	configLoad, err := LoadProjectConfig(c.projectManager, c.workingDir)
+	if err != nil {
+		return err
+	}
+	for _, ruleRef := range ruleRefs {
+		if loadedRef := c.projectManager.FindRule(configLoad.Config, ruleRef.ID); loadedRef != nil {
+			loadedRef.Variables = ruleRef.Variables
+			// Customizing a rule from an extended config overrides it locally
+			loadedRef.Inherited = false
+		}
+	}
+	if err := configLoad.SaveConfig(c.projectManager); err != nil {
+		return contextureerrors.Wrap(err, "save config")
+	}
+	return nil
+}
+
+// regenerate rebuilds the rule files so new variable values take effect
+func (c *VarsCommand) regenerate(ctx context.Context) error {
+	if err := c.rebuild(ctx); err != nil {
+		return contextureerrors.Partial("generate rules", err).
+			WithSuggestions(contextureerrors.RunCommand("contexture build", "generate the rule files"))
+	}
+	return nil
+}
+
+// variableFields builds one form field per variable, in key order
+func variableFields(current, defaults map[string]any) []tui.InputField {
+	keys := make([]string, 0, len(current))
+	for key := range current {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fields := make([]tui.InputField, 0, len(keys))
+	for _, key := range keys {
+		defaultValue, hasDefault := defaults[key]
+		description := "No default"
+		if hasDefault {
+			description = "Default: " + formatVariableValue(defaultValue)
+		}
+
+		fields = append(fields, tui.InputField{
+			Key:         key,
+			Title:       key,
+			Description: description,
+			Value:       formatVariableValue(current[key]),
+			Validate: func(input string) error {
+				_, err := parseVariableInput(input, defaultValue)
+				return err
+			},
+		})
+	}
+	return fields
+}
+
+// formatVariableValue renders a variable value for editing. Strings are shown
+// as-is and other values as JSON.
+func formatVariableValue(value any) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// parseVariableInput converts edited text back into a variable value. Input for
+// string variables is kept as text; anything else is parsed as YAML, so numbers,
+// booleans, lists, and maps (including JSON) keep the types used by rule defaults.
+func parseVariableInput(input string, defaultValue any) (any, error) {
+	if _, isString := defaultValue.(string); isString {
+		return input, nil
+	}
+	if strings.TrimSpace(input) == "" {
+		return input, nil
+	}
+
+	var value any
+	if err := yaml.Unmarshal([]byte(input), &value); err != nil {
+		return nil, fmt.Errorf("invalid value: %w", err)
+	}
+	return value, nil
+}
+
+// promptVariables shows the variables form for a rule
+func promptVariables(ruleID string, fields []tui.InputField) (map[string]string, error) {
+	return tui.Inputs(tui.InputsOptions{
+		Title:       "Variables for " + domain.ExtractRulePath(ruleID),
+		Description: "Strings are entered as plain text; other values as JSON",
+		Fields:      fields,
+	})
+}
+
+// VarsAction is the CLI action handler for the vars command
+func VarsAction(ctx context.Context, cmd *cli.Command, deps *dependencies.Dependencies) error {
+	varsCmd := NewVarsCommand(deps)
+	return varsCmd.Execute(ctx, cmd)
+}
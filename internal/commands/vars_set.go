@@ -0,0 +1,171 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/globs"
+	"github.com/contextureai/contexture/internal/rule"
+	"github.com/contextureai/contexture/internal/tui"
+	"github.com/contextureai/contexture/internal/ui"
+	"github.com/urfave/cli/v3"
+)
+
+// Set applies variable assignments to every configured rule matching the
+// --rules patterns, after previewing the rules affected
+func (c *VarsCommand) Set(ctx context.Context, cmd *cli.Command) error {
+	if cmd.Args().Len() == 0 {
+		return contextureerrors.Validation("var", "no variables given").
+			WithSuggestions(contextureerrors.Hint("pass assignments such as max_line_length=120"))
+	}
+	// Values are kept as text until the defaults of each rule give their type
+	assignments := make(map[string]string, cmd.Args().Len())
+	for _, arg := range cmd.Args().Slice() {
+		key, _, err := parseVarFlag(arg)
+		if err != nil {
+			return contextureerrors.Wrap(err, "parse var")
+		}
+		_, assignments[key], _ = strings.Cut(arg, "=")
+	}
+	patterns := cmd.StringSlice("rules")
+	if len(patterns) == 0 {
+		return contextureerrors.Validation("rules", "no rules selected").
+			WithSuggestions(contextureerrors.Hint(`select rules with --rules, such as --rules "languages/go/*"`))
+	}
+
+	isGlobal := cmd.Bool("global")
+	config, _, err := loadConfigByScope(c.projectManager, c.workingDir, isGlobal)
+	if err != nil {
+		return err
+	}
+	if err := c.providerRegistry.LoadFromProject(config); err != nil {
+		return contextureerrors.Wrap(err, "load providers")
+	}
+
+	var matched []*domain.RuleRef
+	for i := range config.Rules {
+		ruleRef := &config.Rules[i]
+		if !ruleMatchesAny(ruleRef.ID, patterns) {
+			continue
+		}
+		if ruleRef.Source == "local" {
+			log.Warn("Skipping local rule; set its variables in the rule file's frontmatter", "rule", ruleRef.ID)
+			continue
+		}
+		matched = append(matched, ruleRef)
+	}
+	if len(matched) == 0 {
+		return contextureerrors.Validation("rules", "no configured rules match "+strings.Join(patterns, ", ")).
+			WithSuggestions(contextureerrors.RunCommand("contexture rules list", "see the configured rules"))
+	}
+
+	printVariablePreview(matched, assignments)
+	if cmd.Bool("dry-run") {
+		return nil
+	}
+	if !cmd.Bool("yes") {
+		confirmed, err := tui.Confirm(tui.ConfirmOptions{
+			Title:   fmt.Sprintf("Update %d rule(s)?", len(matched)),
+			Default: true,
+		})
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			log.Info("Variable update cancelled")
+			return nil
+		}
+	}
+
+	// Values equal to a rule's defaults are not stored, so the defaults of
+	// every rule are needed
+	refs := make([]domain.RuleRef, len(matched))
+	for i, ruleRef := range matched {
+		refs[i] = *ruleRef
+	}
+	fetched, err := rule.FetchRulesParallel(ctx, c.ruleFetcher, refs, domain.DefaultParallelFetches)
+	if err != nil {
+		return contextureerrors.Wrap(err, "fetch rules")
+	}
+	for i, ruleRef := range matched {
+		defaults := fetched[i].DefaultVariables
+		current := make(map[string]any, len(ruleRef.Variables)+len(assignments))
+		for key, value := range ruleRef.Variables {
+			current[key] = value
+		}
+		for key, input := range assignments {
+			value, err := parseVariableInput(input, defaults[key])
+			if err != nil {
+				return contextureerrors.ValidationError(key, err)
+			}
+			current[key] = value
+		}
+		ruleRef.Variables = rule.FilterNonDefaultVariables(current, defaults)
+	}
+
+	if err := c.saveVariables(config, isGlobal, matched...); err != nil {
+		return err
+	}
+
+	theme := ui.DefaultTheme()
+	successStyle := lipgloss.NewStyle().Foreground(theme.Success)
+	fmt.Println(successStyle.Render(fmt.Sprintf("✓ Updated variables for %d rule(s)", len(matched))))
+
+	return c.regenerate(ctx)
+}
+
+// ruleMatchesAny reports whether the path of a rule matches one of the glob
+// patterns. Rules from providers also match without their @provider prefix.
+func ruleMatchesAny(ruleID string, patterns []string) bool {
+	rulePath := domain.ExtractRulePath(ruleID)
+	candidates := []string{rulePath}
+	if strings.HasPrefix(rulePath, "@") {
+		if _, withoutProvider, ok := strings.Cut(rulePath, "/"); ok {
+			candidates = append(candidates, withoutProvider)
+		}
+	}
+	for _, pattern := range patterns {
+		for _, candidate := range candidates {
+			if globs.Match(pattern, candidate) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// printVariablePreview lists the rules a bulk update changes, with the
+// current and new value of each variable
+func printVariablePreview(ruleRefs []*domain.RuleRef, assignments map[string]string) {
+	keys := make([]string, 0, len(assignments))
+	for key := range assignments {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	mutedStyle := lipgloss.NewStyle().Foreground(ui.DefaultTheme().Muted)
+	fmt.Printf("Setting variables on %d rule(s):\n", len(ruleRefs))
+	for _, ruleRef := range ruleRefs {
+		fmt.Printf("  %s\n", domain.ExtractRulePath(ruleRef.ID))
+		for _, key := range keys {
+			previous := "default"
+			if value, ok := ruleRef.Variables[key]; ok {
+				previous = formatVariableValue(value)
+			}
+			fmt.Printf("    %s %s\n", key, mutedStyle.Render(previous+" → "+assignments[key]))
+		}
+	}
+	fmt.Println()
+}
+
+// VarsSetAction is the CLI action handler for the vars set command
+func VarsSetAction(ctx context.Context, cmd *cli.Command, deps *dependencies.Dependencies) error {
+	return NewVarsCommand(deps).Set(ctx, cmd)
+}
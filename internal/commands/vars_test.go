@@ -0,0 +1,195 @@
+// Package commands provides CLI command implementations
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/contextureai/contexture/internal/rule"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+func TestNewVarsCommand(t *testing.T) {
+	deps := createTestDependencies()
+
+	cmd := NewVarsCommand(deps)
+
+	assert.NotNil(t, cmd)
+	assert.NotNil(t, cmd.projectManager)
+	assert.NotNil(t, cmd.ruleFetcher)
+	assert.NotNil(t, cmd.providerRegistry)
+	assert.NotNil(t, cmd.editVariables)
+}
+
+func TestVarsCommand_RequiresRuleID(t *testing.T) {
+	deps := createTestDependencies()
+	varsCmd := NewVarsCommand(deps)
+
+	var execErr error
+	app := &cli.Command{
+		Name: "vars",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			execErr = varsCmd.Execute(ctx, cmd)
+			return nil
+		},
+	}
+
+	require.NoError(t, app.Run(context.Background(), []string{"vars"}))
+	require.Error(t, execErr)
+	assert.Equal(t, int(contextureerrors.ExitValidation), contextureerrors.ExitCodeFor(execErr))
+}
+
+func TestVariableFields(t *testing.T) {
+	defaults := map[string]any{"max_line_length": 100, "style": "strict"}
+	current := map[string]any{"max_line_length": 120, "style": "strict", "extra": []any{"a", "b"}}
+
+	fields := variableFields(current, defaults)
+
+	require.Len(t, fields, 3)
+	assert.Equal(t, "extra", fields[0].Key)
+	assert.Equal(t, `["a","b"]`, fields[0].Value)
+	assert.Equal(t, "No default", fields[0].Description)
+
+	assert.Equal(t, "max_line_length", fields[1].Key)
+	assert.Equal(t, "120", fields[1].Value)
+	assert.Equal(t, "Default: 100", fields[1].Description)
+	require.Error(t, fields[1].Validate("[unclosed"))
+
+	assert.Equal(t, "style", fields[2].Key)
+	assert.Equal(t, "strict", fields[2].Value)
+	require.NoError(t, fields[2].Validate("[unclosed"))
+}
+
+func TestParseVariableInput(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		defaultValue any
+		expected     any
+	}{
+		{"string default keeps text", "123", "abc", "123"},
+		{"integer", "120", 100, 120},
+		{"boolean", "false", true, false},
+		{"json list", `["a", "b"]`, nil, []any{"a", "b"}},
+		{"json object", `{"k": 1}`, map[string]any{}, map[string]any{"k": 1}},
+		{"plain text without default", "hello", nil, "hello"},
+		{"empty input", "", 5, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := parseVariableInput(tt.input, tt.defaultValue)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+
+	_, err := parseVariableInput("{unclosed", 1)
+	require.Error(t, err)
+}
+
+func TestRuleMatchesAny(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		ruleID   string
+		patterns []string
+		want     bool
+	}{
+		{"[contexture:languages/go/testing]", []string{"languages/go/*"}, true},
+		{"[contexture:languages/go/testing]", []string{"languages/python/*"}, false},
+		{"[contexture:languages/go/testing]", []string{"languages/python/*", "**/testing"}, true},
+		{"@mycompany/languages/go/style", []string{"languages/go/*"}, true},
+		{"@mycompany/languages/go/style", []string{"@mycompany/**"}, true},
+		{"[contexture(https://github.com/acme/rules.git):go/style,main]", []string{"go/*"}, true},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, ruleMatchesAny(tt.ruleID, tt.patterns), "%s %v", tt.ruleID, tt.patterns)
+	}
+}
+
+const varsSetProject = `version: 1
+formats:
+  - type: claude
+    enabled: true
+rules:
+  - id: "[contexture:languages/go/testing]"
+    variables:
+      max_line_length: 100
+  - id: "[contexture:languages/go/style]"
+  - id: "[contexture:languages/python/style]"
+`
+
+// runVarsSet runs the vars set command with the given CLI arguments
+func runVarsSet(t *testing.T, varsCmd *VarsCommand, args ...string) error {
+	t.Helper()
+
+	var execErr error
+	app := &cli.Command{
+		Name: "set",
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{Name: "rules"},
+			&cli.BoolFlag{Name: "global"},
+			&cli.BoolFlag{Name: "dry-run"},
+			&cli.BoolFlag{Name: "yes"},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			execErr = varsCmd.Set(ctx, cmd)
+			return nil
+		},
+	}
+	require.NoError(t, app.Run(context.Background(), append([]string{"set"}, args...)))
+	return execErr
+}
+
+func TestVarsCommand_Set(t *testing.T) {
+	deps := createTestDependencies().WithWorkingDir(dependencies.StaticWorkingDir("/project"))
+	configPath := "/project/.contexture.yaml"
+	require.NoError(t, afero.WriteFile(deps.FS, configPath, []byte(varsSetProject), 0o644))
+
+	fetcher := rule.NewMockFetcher(t)
+	fetcher.EXPECT().FetchRule(mock.Anything, "[contexture:languages/go/testing]").
+		Return(&domain.Rule{ID: "[contexture:languages/go/testing]", DefaultVariables: map[string]any{"max_line_length": 80}}, nil)
+	fetcher.EXPECT().FetchRule(mock.Anything, "[contexture:languages/go/style]").
+		Return(&domain.Rule{ID: "[contexture:languages/go/style]", DefaultVariables: map[string]any{"max_line_length": 120}}, nil)
+
+	varsCmd := NewVarsCommand(deps)
+	varsCmd.ruleFetcher = fetcher
+	rebuilt := 0
+	varsCmd.rebuild = func(context.Context) error {
+		rebuilt++
+		return nil
+	}
+
+	// A dry run only previews
+	require.NoError(t, runVarsSet(t, varsCmd, "max_line_length=120", "--rules", "languages/go/*", "--dry-run"))
+	data, err := afero.ReadFile(deps.FS, configPath)
+	require.NoError(t, err)
+	assert.Equal(t, varsSetProject, string(data))
+
+	require.NoError(t, runVarsSet(t, varsCmd, "max_line_length=120", "--rules", "languages/go/*", "--yes"))
+	assert.Equal(t, 1, rebuilt)
+
+	config, err := project.NewManager(deps.FS).LoadConfig("/project")
+	require.NoError(t, err)
+	rules := config.Config.Rules
+	require.Len(t, rules, 3)
+	assert.Equal(t, map[string]any{"max_line_length": 120}, rules[0].Variables)
+	assert.Empty(t, rules[1].Variables, "values equal to the defaults are not stored")
+	assert.Empty(t, rules[2].Variables, "rules not matching are left alone")
+
+	err = runVarsSet(t, varsCmd, "max_line_length=120", "--rules", "languages/rust/*", "--yes")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no configured rules match")
+
+	err = runVarsSet(t, varsCmd, "max_line_length=120")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no rules selected")
+}
@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/git"
+	"github.com/contextureai/contexture/internal/links"
+	"github.com/contextureai/contexture/internal/permissions"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/contextureai/contexture/internal/rule"
+	"github.com/contextureai/contexture/internal/ui"
+	"github.com/contextureai/contexture/internal/vendored"
+	"github.com/spf13/afero"
+	"github.com/urfave/cli/v3"
+)
+
+// VendorAction copies the content of the project's remote rules into
+// .contexture/vendor with a lockfile of their checksums. Builds prefer the
+// vendored copies, so they work without the network.
+func VendorAction(ctx context.Context, _ *cli.Command, deps *dependencies.Dependencies) error {
+	currentDir, err := deps.GetWorkingDir().Getwd()
+	if err != nil {
+		return contextureerrors.Wrap(err, "get current directory")
+	}
+	merged, err := project.NewManagerFromDependencies(deps).LoadConfigMergedWithLocalRules(currentDir)
+	if err != nil {
+		return contextureerrors.Wrap(err, "load configuration").
+			WithSuggestions(contextureerrors.RunCommand("contexture init", "create a project configuration"))
+	}
+
+	// User rules come from each developer's global configuration, so only
+	// the remote rules of the project are vendored
+	var refs []domain.RuleRef
+	for _, rws := range merged.MergedRules {
+		if rws.Source != domain.RuleSourceUser && rws.RuleRef.Source != "local" {
+			refs = append(refs, rws.RuleRef)
+		}
+	}
+
+	// Fail before fetching when the vendor directory cannot be written
+	if err := permissions.Check(deps.FS, []permissions.Target{{Path: vendored.Dir(currentDir), Description: "vendored rules"}}); err != nil {
+		return err
+	}
+
+	gitRepo := newOpenRepository(deps.FS)
+	fetcher := rule.NewFetcher(deps.FS, gitRepo, fetcherConfig(deps), deps.ProviderRegistry)
+	snapshot := vendored.NewSnapshot()
+	err = ui.WithProgress("Fetched rules", func() error {
+		for _, ref := range refs {
+			if err := vendorRule(ctx, deps.FS, gitRepo, fetcher, snapshot, ref); err != nil {
+				return contextureerrors.Wrap(err, "rule "+ref.ID)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return contextureerrors.Wrap(err, "vendor rules")
+	}
+	lock, err := snapshot.Write(deps.FS, currentDir)
+	if err != nil {
+		return err
+	}
+
+	theme := ui.DefaultTheme()
+	successStyle := lipgloss.NewStyle().Foreground(theme.Success)
+	mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+	relDir := filepath.Join(domain.ContextureDir, vendored.DirName)
+	fmt.Println(successStyle.Render(fmt.Sprintf("✓ Vendored %d rules to %s", len(lock.Rules), relDir)))
+	fmt.Println(mutedStyle.Render("Builds use the vendored rules; run 'contexture vendor' again after changing them"))
+	return nil
+}
+
+// vendorRule adds the rule ref points to, the index of its repository, and
+// the files its relative links point to, to snapshot
+func vendorRule(
+	ctx context.Context,
+	fs afero.Fs,
+	gitRepo git.Repository,
+	fetcher rule.Fetcher,
+	snapshot *vendored.Snapshot,
+	ref domain.RuleRef,
+) error {
+	fetched, err := rule.FetchRuleRef(ctx, fetcher, ref)
+	if err != nil {
+		return err
+	}
+	parsed, err := fetcher.ParseRuleID(ref.ID)
+	if err != nil {
+		return err
+	}
+
+	// Pinned rules and the repository index are read at the rule's commit,
+	// as builds read them
+	read := func(relPath string) ([]byte, error) {
+		if ref.CommitHash != "" {
+			return gitRepo.GetFileAtCommit(fetched.RepoDir, relPath, ref.CommitHash)
+		}
+		return afero.ReadFile(fs, filepath.Join(fetched.RepoDir, filepath.FromSlash(relPath)))
+	}
+	data, err := read(parsed.RulePath + ".md")
+	if err != nil {
+		return contextureerrors.Wrap(err, "read rule file")
+	}
+	snapshot.AddRule(parsed.Source, parsed.RulePath, parsed.Ref, ref.CommitHash, data)
+
+	repoDir := vendored.RepoDir(parsed.Source, parsed.Ref, ref.CommitHash)
+	if index, err := read(domain.RepositoryIndexFile); err == nil {
+		snapshot.AddFile(repoDir, domain.RepositoryIndexFile, index)
+	}
+
+	// Assets are copied from the checkout, as builds copy them; links to
+	// files that do not exist are reported by the build
+	for _, link := range links.Extract(fetched.Content) {
+		if !link.IsRelative() {
+			continue
+		}
+		resolved, ok := links.ResolveRelative(parsed.RulePath, link.Target)
+		if !ok || resolved == "." || strings.HasSuffix(resolved, ".md") {
+			continue
+		}
+		asset, err := afero.ReadFile(fs, filepath.Join(fetched.RepoDir, filepath.FromSlash(resolved)))
+		if err != nil {
+			continue
+		}
+		snapshot.AddFile(repoDir, resolved, asset)
+	}
+	return nil
+}
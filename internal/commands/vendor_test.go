@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/contextureai/contexture/internal/git"
+	"github.com/contextureai/contexture/internal/provider"
+	"github.com/contextureai/contexture/internal/rule"
+	"github.com/contextureai/contexture/internal/vendored"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVendorRule(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	mockRepo := git.NewMockRepository(t)
+	const source = "https://github.com/contextureai/rules.git"
+	mockRepo.On("Clone", mock.Anything, source, mock.AnythingOfType("string"), mock.AnythingOfType("[]git.CloneOption")).
+		Run(func(args mock.Arguments) {
+			dir := args.Get(2).(string)
+			_ = afero.WriteFile(fs, dir+"/go/testing.md", []byte("---\ntitle: Testing\ndescription: Testing rule\ntags: [go]\n---\n\n"+
+				"See ![the pyramid](pyramid.png) and [style](style.md)."), 0o644)
+			_ = afero.WriteFile(fs, dir+"/go/pyramid.png", []byte("png"), 0o644)
+			_ = afero.WriteFile(fs, dir+"/index.yaml", []byte("name: rules\n"), 0o644)
+		}).
+		Return(nil)
+	fetcher := rule.NewFetcher(fs, mockRepo, rule.FetcherConfig{DefaultURL: source}, provider.NewRegistry())
+
+	snapshot := vendored.NewSnapshot()
+	require.NoError(t, vendorRule(context.Background(), fs, mockRepo, fetcher, snapshot, domain.RuleRef{ID: "[contexture:go/testing]"}))
+	lock, err := snapshot.Write(fs, "/work/app")
+	require.NoError(t, err)
+
+	require.Len(t, lock.Rules, 1)
+	assert.Equal(t, "go/testing", lock.Rules[0].RulePath)
+	var files []string
+	for _, file := range lock.Files {
+		files = append(files, file.Path)
+	}
+	repoDir := lock.Rules[0].Path[:len(lock.Rules[0].Path)-len("/go/testing.md")]
+	assert.Equal(t, []string{repoDir + "/go/pyramid.png", repoDir + "/index.yaml"}, files)
+}
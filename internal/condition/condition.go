@@ -0,0 +1,121 @@
+// Package condition evaluates the when expressions of rule references
+package condition
+
+import (
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
+	"github.com/spf13/afero"
+)
+
+// languageMarkers maps files at the project root to the language they indicate.
+// The first detected language is the primary project language.
+var languageMarkers = []struct {
+	file     string
+	language string
+}{
+	{"go.mod", "go"},
+	{"Cargo.toml", "rust"},
+	{"pyproject.toml", "python"},
+	{"requirements.txt", "python"},
+	{"setup.py", "python"},
+	{"Pipfile", "python"},
+	{"tsconfig.json", "typescript"},
+	{"package.json", "javascript"},
+	{"pom.xml", "java"},
+	{"build.gradle", "java"},
+	{"build.gradle.kts", "kotlin"},
+	{"Gemfile", "ruby"},
+	{"composer.json", "php"},
+	{"Package.swift", "swift"},
+	{"mix.exs", "elixir"},
+}
+
+// Context is the data a when expression is evaluated against
+type Context struct {
+	Env     map[string]string `expr:"env"`
+	OS      string            `expr:"os"`
+	Project Project           `expr:"project"`
+}
+
+// Project describes the project being built
+type Project struct {
+	Name      string   `expr:"name"`
+	Dir       string   `expr:"dir"`
+	Language  string   `expr:"language"`
+	Languages []string `expr:"languages"`
+}
+
+// Detect builds the evaluation context for the project in dir from the
+// marker files it contains and environ, in the form of os.Environ.
+func Detect(fs afero.Fs, dir string, environ []string) *Context {
+	ctx := &Context{
+		Env: make(map[string]string, len(environ)),
+		OS:  runtime.GOOS,
+		Project: Project{
+			Name:      filepath.Base(dir),
+			Dir:       dir,
+			Languages: []string{},
+		},
+	}
+
+	for _, kv := range environ {
+		if key, value, ok := strings.Cut(kv, "="); ok {
+			ctx.Env[key] = value
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, marker := range languageMarkers {
+		if seen[marker.language] {
+			continue
+		}
+		if exists, _ := afero.Exists(fs, filepath.Join(dir, marker.file)); exists {
+			seen[marker.language] = true
+			ctx.Project.Languages = append(ctx.Project.Languages, marker.language)
+		}
+	}
+	if len(ctx.Project.Languages) > 0 {
+		ctx.Project.Language = ctx.Project.Languages[0]
+	}
+
+	return ctx
+}
+
+// Validate reports whether expression compiles to a boolean expression
+func Validate(expression string) error {
+	_, err := compile(expression)
+	return err
+}
+
+// compile type-checks expression against the fields of Context
+func compile(expression string) (*vm.Program, error) {
+	program, err := expr.Compile(expression, expr.Env(Context{}), expr.AsBool())
+	if err != nil {
+		return nil, contextureerrors.ValidationErrorf("when", "invalid expression %q: %v", expression, err)
+	}
+	return program, nil
+}
+
+// Evaluate reports whether expression holds in ctx. An empty expression always holds.
+func Evaluate(expression string, ctx *Context) (bool, error) {
+	if strings.TrimSpace(expression) == "" {
+		return true, nil
+	}
+
+	program, err := compile(expression)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := expr.Run(program, ctx)
+	if err != nil {
+		return false, contextureerrors.Wrap(err, "evaluate when expression")
+	}
+	matched, _ := result.(bool)
+	return matched, nil
+}
@@ -0,0 +1,77 @@
+package condition
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetect(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/work/app/go.mod", []byte("module app"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "/work/app/package.json", []byte("{}"), 0o644))
+
+	ctx := Detect(fs, "/work/app", []string{"CI=true", "EMPTY="})
+
+	assert.Equal(t, "app", ctx.Project.Name)
+	assert.Equal(t, "go", ctx.Project.Language)
+	assert.Equal(t, []string{"go", "javascript"}, ctx.Project.Languages)
+	assert.Equal(t, "true", ctx.Env["CI"])
+	assert.Contains(t, ctx.Env, "EMPTY")
+}
+
+func TestEvaluate(t *testing.T) {
+	t.Parallel()
+	ctx := &Context{
+		Env: map[string]string{"CI": "true"},
+		OS:  "linux",
+		Project: Project{
+			Name:      "app",
+			Language:  "go",
+			Languages: []string{"go", "javascript"},
+		},
+	}
+
+	tests := []struct {
+		expression string
+		expected   bool
+	}{
+		{"", true},
+		{"env.CI == 'true'", true},
+		{"env.DEPLOY == 'prod'", false},
+		{"project.language == 'go'", true},
+		{"project.language == 'python'", false},
+		{"'javascript' in project.languages", true},
+		{"os == 'linux' && project.name == 'app'", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			t.Parallel()
+			matched, err := Evaluate(tt.expression, ctx)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, matched)
+		})
+	}
+}
+
+func TestEvaluate_Invalid(t *testing.T) {
+	t.Parallel()
+	ctx := &Context{Env: map[string]string{}}
+
+	_, err := Evaluate("project.language", ctx)
+	require.Error(t, err)
+
+	_, err = Evaluate("env.CI ==", ctx)
+	require.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+	require.NoError(t, Validate("env.CI == 'true' || project.language == 'go'"))
+	require.Error(t, Validate("project.unknown == 'x'"))
+	require.Error(t, Validate("1 + 1"))
+}
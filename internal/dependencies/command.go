@@ -0,0 +1,29 @@
+package dependencies
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// CommandRunner runs args with input on stdin and returns its stdout
+type CommandRunner func(ctx context.Context, args []string, input []byte) ([]byte, error)
+
+// RunCommand runs args with input on stdin and returns its stdout. The
+// command is killed when ctx is done; its stderr is included in the error.
+func RunCommand(ctx context.Context, args []string, input []byte) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdin = bytes.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return nil, fmt.Errorf("%s: %w: %s", args[0], err, message)
+		}
+		return nil, fmt.Errorf("%s: %w", args[0], err)
+	}
+	return stdout.Bytes(), nil
+}
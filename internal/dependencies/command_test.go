@@ -0,0 +1,21 @@
+package dependencies
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunCommand(t *testing.T) {
+	t.Parallel()
+
+	output, err := RunCommand(context.Background(), []string{"cat"}, []byte("rules\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "rules\n", string(output))
+
+	_, err = RunCommand(context.Background(), []string{"sh", "-c", "echo no key >&2; exit 2"}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "sh: exit status 2: no key")
+}
@@ -18,6 +18,19 @@ type Dependencies struct {
 
 	// ProviderRegistry manages rule providers
 	ProviderRegistry *provider.Registry
+
+	// Profiler captures CPU and memory profiles when requested on the command line
+	Profiler *Profiler
+
+	// WorkingDir provides the directory commands operate on
+	WorkingDir WorkingDir
+
+	// Env provides environment variables
+	Env Environment
+
+	// ConfigFile is the project configuration file selected with --config,
+	// read instead of searching the project directory when set
+	ConfigFile string
 }
 
 // New creates a new Dependencies instance with production defaults.
@@ -26,24 +39,34 @@ func New(ctx context.Context) *Dependencies {
 		ctx = context.Background()
 	}
 
+	fs := afero.NewOsFs()
 	return &Dependencies{
-		FS:               afero.NewOsFs(),
+		FS:               fs,
 		Context:          ctx,
 		ProviderRegistry: provider.NewRegistry(),
+		Profiler:         NewProfiler(fs),
+		WorkingDir:       OSWorkingDir{},
+		Env:              OSEnvironment{},
 	}
 }
 
 // NewForTesting creates a new Dependencies instance optimized for testing.
-// It uses an in-memory filesystem to avoid side effects.
+// It uses an in-memory filesystem to avoid side effects. The working directory
+// and environment are those of the process; use WithWorkingDir and WithEnv to
+// replace them.
 func NewForTesting(ctx context.Context) *Dependencies {
 	if ctx == nil {
 		ctx = context.Background()
 	}
 
+	fs := afero.NewMemMapFs()
 	return &Dependencies{
-		FS:               afero.NewMemMapFs(),
+		FS:               fs,
 		Context:          ctx,
 		ProviderRegistry: provider.NewRegistry(),
+		Profiler:         NewProfiler(fs),
+		WorkingDir:       OSWorkingDir{},
+		Env:              OSEnvironment{},
 	}
 }
 
@@ -54,6 +77,10 @@ func (d *Dependencies) WithContext(ctx context.Context) *Dependencies {
 		FS:               d.FS,
 		Context:          ctx,
 		ProviderRegistry: d.ProviderRegistry,
+		Profiler:         d.Profiler,
+		WorkingDir:       d.WorkingDir,
+		Env:              d.Env,
+		ConfigFile:       d.ConfigFile,
 	}
 }
 
@@ -64,6 +91,10 @@ func (d *Dependencies) WithFS(fs afero.Fs) *Dependencies {
 		FS:               fs,
 		Context:          d.Context,
 		ProviderRegistry: d.ProviderRegistry,
+		Profiler:         d.Profiler,
+		WorkingDir:       d.WorkingDir,
+		Env:              d.Env,
+		ConfigFile:       d.ConfigFile,
 	}
 }
 
@@ -74,5 +105,67 @@ func (d *Dependencies) WithProviderRegistry(registry *provider.Registry) *Depend
 		FS:               d.FS,
 		Context:          d.Context,
 		ProviderRegistry: registry,
+		Profiler:         d.Profiler,
+		WorkingDir:       d.WorkingDir,
+		Env:              d.Env,
+		ConfigFile:       d.ConfigFile,
+	}
+}
+
+// WithWorkingDir returns a new Dependencies instance with the given working directory.
+func (d *Dependencies) WithWorkingDir(workingDir WorkingDir) *Dependencies {
+	return &Dependencies{
+		FS:               d.FS,
+		Context:          d.Context,
+		ProviderRegistry: d.ProviderRegistry,
+		Profiler:         d.Profiler,
+		WorkingDir:       workingDir,
+		Env:              d.Env,
+		ConfigFile:       d.ConfigFile,
+	}
+}
+
+// WithEnv returns a new Dependencies instance with the given environment.
+func (d *Dependencies) WithEnv(env Environment) *Dependencies {
+	return &Dependencies{
+		FS:               d.FS,
+		Context:          d.Context,
+		ProviderRegistry: d.ProviderRegistry,
+		Profiler:         d.Profiler,
+		WorkingDir:       d.WorkingDir,
+		Env:              env,
+		ConfigFile:       d.ConfigFile,
+	}
+}
+
+// WithConfigFile returns a new Dependencies instance that reads the project
+// configuration from path.
+func (d *Dependencies) WithConfigFile(path string) *Dependencies {
+	return &Dependencies{
+		FS:               d.FS,
+		Context:          d.Context,
+		ProviderRegistry: d.ProviderRegistry,
+		Profiler:         d.Profiler,
+		WorkingDir:       d.WorkingDir,
+		Env:              d.Env,
+		ConfigFile:       path,
+	}
+}
+
+// GetWorkingDir returns the working directory provider, falling back to the
+// process working directory when none is set
+func (d *Dependencies) GetWorkingDir() WorkingDir {
+	if d.WorkingDir == nil {
+		return OSWorkingDir{}
+	}
+	return d.WorkingDir
+}
+
+// GetEnv returns the environment, falling back to the process environment
+// when none is set
+func (d *Dependencies) GetEnv() Environment {
+	if d.Env == nil {
+		return OSEnvironment{}
 	}
+	return d.Env
 }
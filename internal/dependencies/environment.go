@@ -0,0 +1,93 @@
+package dependencies
+
+import (
+	"errors"
+	"maps"
+	"os"
+	"slices"
+)
+
+// WorkingDir provides the directory commands operate on
+type WorkingDir interface {
+	// Getwd returns the working directory
+	Getwd() (string, error)
+}
+
+// Environment provides access to environment variables
+type Environment interface {
+	// Getenv returns the value of the variable, or an empty string when it is unset
+	Getenv(key string) string
+	// LookupEnv returns the value of the variable and whether it is set
+	LookupEnv(key string) (string, bool)
+	// Environ returns the variables in the form "key=value"
+	Environ() []string
+}
+
+// OSWorkingDir is the process working directory
+type OSWorkingDir struct{}
+
+// Getwd returns the process working directory
+func (OSWorkingDir) Getwd() (string, error) {
+	return os.Getwd()
+}
+
+// StaticWorkingDir is a fixed working directory, for tests and callers that
+// operate on a directory other than the process working directory
+type StaticWorkingDir string
+
+// Getwd returns the directory
+func (d StaticWorkingDir) Getwd() (string, error) {
+	return string(d), nil
+}
+
+// OSEnvironment is the process environment
+type OSEnvironment struct{}
+
+// Getenv returns the value of a process environment variable
+func (OSEnvironment) Getenv(key string) string {
+	return os.Getenv(key)
+}
+
+// LookupEnv returns the value of a process environment variable and whether it is set
+func (OSEnvironment) LookupEnv(key string) (string, bool) {
+	return os.LookupEnv(key)
+}
+
+// Environ returns the process environment
+func (OSEnvironment) Environ() []string {
+	return os.Environ()
+}
+
+// HomeDir returns the user's home directory from env: HOME, or USERPROFILE
+// when HOME is unset, the variables os.UserHomeDir reads on Unix and Windows
+func HomeDir(env Environment) (string, error) {
+	for _, key := range []string{"HOME", "USERPROFILE"} {
+		if dir := env.Getenv(key); dir != "" {
+			return dir, nil
+		}
+	}
+	return "", errors.New("neither $HOME nor $USERPROFILE is set")
+}
+
+// MapEnvironment is an environment holding only the variables in the map
+type MapEnvironment map[string]string
+
+// Getenv returns the value of the variable
+func (e MapEnvironment) Getenv(key string) string {
+	return e[key]
+}
+
+// LookupEnv returns the value of the variable and whether it is set
+func (e MapEnvironment) LookupEnv(key string) (string, bool) {
+	value, ok := e[key]
+	return value, ok
+}
+
+// Environ returns the variables sorted by name
+func (e MapEnvironment) Environ() []string {
+	environ := make([]string, 0, len(e))
+	for _, key := range slices.Sorted(maps.Keys(e)) {
+		environ = append(environ, key+"="+e[key])
+	}
+	return environ
+}
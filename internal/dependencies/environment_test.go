@@ -0,0 +1,78 @@
+package dependencies
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithWorkingDir(t *testing.T) {
+	t.Parallel()
+	deps := NewForTesting(context.Background())
+
+	newDeps := deps.WithWorkingDir(StaticWorkingDir("/project"))
+	assert.NotSame(t, deps, newDeps)
+	assert.Equal(t, deps.FS, newDeps.FS)
+
+	dir, err := newDeps.GetWorkingDir().Getwd()
+	require.NoError(t, err)
+	assert.Equal(t, "/project", dir)
+
+	// The original keeps the process working directory
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	dir, err = deps.GetWorkingDir().Getwd()
+	require.NoError(t, err)
+	assert.Equal(t, wd, dir)
+}
+
+func TestWithEnv(t *testing.T) {
+	t.Parallel()
+	deps := NewForTesting(context.Background())
+
+	newDeps := deps.WithEnv(MapEnvironment{"EDITOR": "vim", "CI": "true"})
+	assert.NotSame(t, deps, newDeps)
+	assert.Equal(t, "vim", newDeps.GetEnv().Getenv("EDITOR"))
+	assert.Equal(t, []string{"CI=true", "EDITOR=vim"}, newDeps.GetEnv().Environ())
+
+	_, ok := newDeps.GetEnv().LookupEnv("HOME")
+	assert.False(t, ok)
+}
+
+func TestWithConfigFile(t *testing.T) {
+	t.Parallel()
+	deps := NewForTesting(context.Background()).WithWorkingDir(StaticWorkingDir("/project"))
+
+	newDeps := deps.WithConfigFile("/configs/ci.yaml")
+	assert.NotSame(t, deps, newDeps)
+	assert.Equal(t, "/configs/ci.yaml", newDeps.ConfigFile)
+	assert.Empty(t, deps.ConfigFile)
+
+	// The other builders keep the configuration file
+	assert.Equal(t, "/configs/ci.yaml", newDeps.WithEnv(MapEnvironment{}).ConfigFile)
+	assert.Equal(t, newDeps.WorkingDir, newDeps.WithEnv(MapEnvironment{}).WorkingDir)
+}
+
+func TestGetWorkingDirAndEnvDefaults(t *testing.T) {
+	t.Parallel()
+	deps := &Dependencies{}
+	assert.Equal(t, OSWorkingDir{}, deps.GetWorkingDir())
+	assert.Equal(t, OSEnvironment{}, deps.GetEnv())
+}
+
+func TestHomeDir(t *testing.T) {
+	t.Parallel()
+	home, err := HomeDir(MapEnvironment{"HOME": "/home/user", "USERPROFILE": `C:\Users\user`})
+	require.NoError(t, err)
+	assert.Equal(t, "/home/user", home)
+
+	home, err = HomeDir(MapEnvironment{"USERPROFILE": `C:\Users\user`})
+	require.NoError(t, err)
+	assert.Equal(t, `C:\Users\user`, home)
+
+	_, err = HomeDir(MapEnvironment{})
+	require.Error(t, err)
+}
@@ -0,0 +1,90 @@
+package dependencies
+
+import (
+	"runtime"
+	"runtime/pprof"
+	"sync"
+
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/spf13/afero"
+)
+
+// Profiler captures CPU and heap profiles for the lifetime of a command.
+// It is shared by all copies of a Dependencies instance.
+type Profiler struct {
+	fs      afero.Fs
+	mu      sync.Mutex
+	cpuFile afero.File
+	memPath string
+}
+
+// NewProfiler creates a profiler that writes profiles to fs
+func NewProfiler(fs afero.Fs) *Profiler {
+	return &Profiler{fs: fs}
+}
+
+// Start begins CPU profiling to cpuPath and records memPath for a heap profile
+// written by Stop. Empty paths disable the corresponding profile.
+func (p *Profiler) Start(cpuPath, memPath string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.cpuFile != nil {
+		return contextureerrors.ValidationErrorf("cpuprofile", "CPU profiling is already running")
+	}
+
+	if cpuPath != "" {
+		file, err := p.fs.Create(cpuPath)
+		if err != nil {
+			return contextureerrors.Wrap(err, "create CPU profile")
+		}
+		if err := pprof.StartCPUProfile(file); err != nil {
+			_ = file.Close()
+			return contextureerrors.Wrap(err, "start CPU profile")
+		}
+		p.cpuFile = file
+	}
+
+	p.memPath = memPath
+	return nil
+}
+
+// Stop ends CPU profiling and writes the heap profile, if either was requested
+func (p *Profiler) Stop() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.cpuFile != nil {
+		pprof.StopCPUProfile()
+		err := p.cpuFile.Close()
+		p.cpuFile = nil
+		if err != nil {
+			return contextureerrors.Wrap(err, "close CPU profile")
+		}
+	}
+
+	if p.memPath != "" {
+		memPath := p.memPath
+		p.memPath = ""
+		return p.writeHeapProfile(memPath)
+	}
+
+	return nil
+}
+
+// writeHeapProfile writes an up-to-date heap profile to path
+func (p *Profiler) writeHeapProfile(path string) error {
+	file, err := p.fs.Create(path)
+	if err != nil {
+		return contextureerrors.Wrap(err, "create memory profile")
+	}
+	defer func() { _ = file.Close() }()
+
+	// Run a GC so the profile reflects live allocations
+	runtime.GC()
+	if err := pprof.WriteHeapProfile(file); err != nil {
+		return contextureerrors.Wrap(err, "write memory profile")
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package dependencies
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfiler_StartStop(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	profiler := NewProfiler(fs)
+
+	require.NoError(t, profiler.Start("/cpu.prof", "/mem.prof"))
+
+	// Starting again while CPU profiling is running fails
+	err := profiler.Start("/other.prof", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already running")
+
+	require.NoError(t, profiler.Stop())
+
+	cpu, err := afero.ReadFile(fs, "/cpu.prof")
+	require.NoError(t, err)
+	assert.NotEmpty(t, cpu)
+
+	mem, err := afero.ReadFile(fs, "/mem.prof")
+	require.NoError(t, err)
+	assert.NotEmpty(t, mem)
+
+	// Stopping again is a no-op
+	require.NoError(t, profiler.Stop())
+}
+
+func TestProfiler_Disabled(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	profiler := NewProfiler(fs)
+
+	require.NoError(t, profiler.Start("", ""))
+	require.NoError(t, profiler.Stop())
+
+	files, err := afero.ReadDir(fs, "/")
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestProfiler_CreateFailure(t *testing.T) {
+	profiler := NewProfiler(afero.NewReadOnlyFs(afero.NewMemMapFs()))
+
+	err := profiler.Start("/cpu.prof", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "create CPU profile")
+
+	require.NoError(t, profiler.Start("", "/mem.prof"))
+	err = profiler.Stop()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "create memory profile")
+}
+
+func TestDependencies_ShareProfiler(t *testing.T) {
+	t.Parallel()
+
+	deps := NewForTesting(nil)
+	require.NotNil(t, deps.Profiler)
+
+	assert.Same(t, deps.Profiler, deps.WithFS(afero.NewMemMapFs()).Profiler)
+	assert.Same(t, deps.Profiler, deps.WithContext(t.Context()).Profiler)
+	assert.Same(t, deps.Profiler, deps.WithProviderRegistry(nil).Profiler)
+}
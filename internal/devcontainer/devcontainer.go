@@ -0,0 +1,300 @@
+// Package devcontainer keeps a managed block in a devcontainer.json that
+// builds the contexture outputs when the container is created
+package devcontainer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/spf13/afero"
+)
+
+const (
+	// BeginMarker and EndMarker delimit the managed block. devcontainer.json
+	// is JSON with comments, so the block survives edits made by hand.
+	BeginMarker = "// contexture:begin"
+	EndMarker   = "// contexture:end"
+
+	// DefaultCommand builds the outputs when the container is created
+	DefaultCommand = "contexture build --non-interactive"
+	// CacheDir is where the cache volume is mounted. ~/.cache/contexture, the
+	// contexture cache directory in containers that do not set
+	// XDG_CACHE_HOME, links to it.
+	CacheDir = "/tmp/contexture"
+	// CacheVolume is the volume holding the cache, shared by the containers
+	// of every project
+	CacheVolume = "contexture-cache"
+	// DefaultImage is the image of a devcontainer.json created from scratch
+	DefaultImage = "mcr.microsoft.com/devcontainers/base:ubuntu"
+)
+
+// Paths are the devcontainer configuration files of a project, in the order
+// they are looked for
+var Paths = []string{
+	filepath.Join(".devcontainer", "devcontainer.json"),
+	".devcontainer.json",
+}
+
+// Options configures the managed block
+type Options struct {
+	// Command is run when the container is created
+	Command string
+	// CacheMount mounts a volume at the cache directory, so cloned rule
+	// repositories outlive rebuilt containers
+	CacheMount bool
+}
+
+// Find returns the devcontainer configuration of projectDir, or the path
+// of a new one when the project has none
+func Find(fs afero.Fs, projectDir string) (string, bool, error) {
+	for _, path := range Paths {
+		path = filepath.Join(projectDir, path)
+		exists, err := afero.Exists(fs, path)
+		if err != nil {
+			return "", false, contextureerrors.Wrap(err, "check "+path)
+		}
+		if exists {
+			return path, true, nil
+		}
+	}
+	return filepath.Join(projectDir, Paths[0]), false, nil
+}
+
+// Read returns the content of the devcontainer configuration at path, or nil
+// when it does not exist
+func Read(fs afero.Fs, path string) ([]byte, error) {
+	data, err := afero.ReadFile(fs, path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "read "+filepath.Base(path))
+	}
+	return data, nil
+}
+
+// Apply returns content with the managed block set from opts. Empty content
+// is a new configuration for a project called name. Settings the block
+// needs that are already set outside it are errors, since JSON objects
+// cannot repeat a key.
+func Apply(content []byte, name string, opts Options) ([]byte, error) {
+	if len(bytes.TrimSpace(content)) == 0 {
+		content = fmt.Appendf(nil, "{\n\t\"name\": %s,\n\t\"image\": %s\n}\n", jsonString(name), jsonString(DefaultImage))
+	}
+	rest, err := Remove(content)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := topLevelKeys(rest)
+	if err != nil {
+		return nil, err
+	}
+	entries := blockEntries(opts)
+	var conflicts []string
+	for _, entry := range entries {
+		if slices.Contains(members, entry.key) {
+			conflicts = append(conflicts, entry.key)
+		}
+	}
+	if len(conflicts) > 0 {
+		return nil, contextureerrors.Validation("devcontainer",
+			"devcontainer.json already sets "+strings.Join(conflicts, " and ")).
+			WithSuggestions(
+				contextureerrors.Hint("add '"+opts.command()+"' to the existing settings by hand"),
+				contextureerrors.Hint("or remove them and run the command again"),
+			)
+	}
+
+	open := objectStart(rest)
+	if open < 0 {
+		return nil, contextureerrors.ValidationErrorf("devcontainer", "devcontainer.json is not a JSON object")
+	}
+	indent := detectIndent(rest[open+1:])
+	lines := []string{indent + BeginMarker + " (managed by contexture integrate devcontainer)"}
+	for i, entry := range entries {
+		line := indent + fmt.Sprintf("%q: %s", entry.key, entry.value)
+		if i < len(entries)-1 || len(members) > 0 {
+			line += ","
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, indent+EndMarker)
+
+	after := rest[open+1:]
+	block := "\n" + strings.Join(lines, "\n")
+	if !bytes.HasPrefix(after, []byte("\n")) && !bytes.HasPrefix(after, []byte("\r\n")) {
+		block += "\n"
+	}
+	result := slices.Concat(rest[:open+1], []byte(block), after)
+	return result, nil
+}
+
+// Remove returns content without the managed block
+func Remove(content []byte) ([]byte, error) {
+	lines := strings.SplitAfter(string(content), "\n")
+	begin := slices.IndexFunc(lines, func(line string) bool {
+		return strings.HasPrefix(strings.TrimSpace(line), BeginMarker)
+	})
+	if begin < 0 {
+		return content, nil
+	}
+	end := slices.IndexFunc(lines[begin:], func(line string) bool {
+		return strings.TrimSpace(line) == EndMarker
+	})
+	if end < 0 {
+		return nil, contextureerrors.ValidationErrorf("devcontainer", "devcontainer.json has %q without %q", BeginMarker, EndMarker)
+	}
+	kept := slices.Concat(lines[:begin], lines[begin+end+1:])
+	return []byte(strings.Join(kept, "")), nil
+}
+
+// blockEntry is a setting of the managed block, with its JSON value
+type blockEntry struct {
+	key   string
+	value string
+}
+
+func (o Options) command() string {
+	if o.Command == "" {
+		return DefaultCommand
+	}
+	return o.Command
+}
+
+// blockEntries returns the settings of the managed block
+func blockEntries(opts Options) []blockEntry {
+	command := opts.command()
+	var entries []blockEntry
+	if opts.CacheMount {
+		mount := jsonString("source=" + CacheVolume + ",target=" + CacheDir + ",type=volume")
+		entries = append(entries, blockEntry{key: "mounts", value: "[" + mount + "]"})
+		// New volumes belong to root, so the container user takes over the
+		// cache directory before building
+		command = `sudo -n chown "$(id -u)" ` + CacheDir + " 2>/dev/null; " +
+			`mkdir -p "$HOME/.cache" && ln -sfn ` + CacheDir + ` "$HOME/.cache/contexture"; ` + command
+	}
+	return append(entries, blockEntry{key: "postCreateCommand", value: jsonString(command)})
+}
+
+// jsonString returns s as a JSON string, leaving the shell redirections of
+// commands readable
+func jsonString(s string) string {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// topLevelKeys returns the keys of the JSON object in content, which may
+// contain comments and trailing commas
+func topLevelKeys(content []byte) ([]string, error) {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(standardize(content), &object); err != nil {
+		return nil, contextureerrors.WithOpf("parse devcontainer.json", "%v", err)
+	}
+	if object == nil {
+		return nil, contextureerrors.ValidationErrorf("devcontainer", "devcontainer.json is not a JSON object")
+	}
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// standardize blanks the comments of JSON with comments and drops the
+// trailing commas it allows, leaving plain JSON
+func standardize(content []byte) []byte {
+	out := make([]byte, 0, len(content))
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		switch {
+		case c == '"':
+			end := stringEnd(content, i)
+			out = append(out, content[i:end]...)
+			i = end - 1
+		case c == '/' && i+1 < len(content) && content[i+1] == '/':
+			for i < len(content) && content[i] != '\n' {
+				i++
+			}
+			out = append(out, '\n')
+		case c == '/' && i+1 < len(content) && content[i+1] == '*':
+			end := bytes.Index(content[i+2:], []byte("*/"))
+			if end < 0 {
+				i = len(content)
+			} else {
+				i += end + 3
+			}
+			out = append(out, ' ')
+		case c == '}' || c == ']':
+			trimmed := bytes.TrimRight(out, " \t\r\n")
+			if len(trimmed) > 0 && trimmed[len(trimmed)-1] == ',' {
+				out = trimmed[:len(trimmed)-1]
+			}
+			out = append(out, c)
+		default:
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// stringEnd returns the index after the JSON string starting at start
+func stringEnd(content []byte, start int) int {
+	for i := start + 1; i < len(content); i++ {
+		switch content[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+	return len(content)
+}
+
+// objectStart returns the index of the brace opening the top-level object,
+// skipping leading comments, or -1 when content does not start with one
+func objectStart(content []byte) int {
+	for i := 0; i < len(content); i++ {
+		switch {
+		case content[i] == '{':
+			return i
+		case content[i] == '/' && i+1 < len(content) && content[i+1] == '/':
+			for i < len(content) && content[i] != '\n' {
+				i++
+			}
+		case content[i] == '/' && i+1 < len(content) && content[i+1] == '*':
+			end := bytes.Index(content[i+2:], []byte("*/"))
+			if end < 0 {
+				return -1
+			}
+			i += end + 3
+		case content[i] != ' ' && content[i] != '\t' && content[i] != '\r' && content[i] != '\n' && content[i] != 0xEF && content[i] != 0xBB && content[i] != 0xBF:
+			return -1
+		}
+	}
+	return -1
+}
+
+// detectIndent returns the indentation of the first indented line of
+// content, or a tab, the indentation of the devcontainer templates
+func detectIndent(content []byte) string {
+	for line := range strings.SplitSeq(string(content), "\n") {
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed != "" && len(trimmed) < len(line) {
+			return line[:len(line)-len(trimmed)]
+		}
+	}
+	return "\t"
+}
@@ -0,0 +1,111 @@
+package devcontainer
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testConfig = `// Dev container for the app
+{
+  "name": "app",
+  "image": "mcr.microsoft.com/devcontainers/go:1", // pinned by hand
+  /* features are added by the team */
+  "features": {},
+}
+`
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+
+	t.Run("adds the managed block and keeps comments", func(t *testing.T) {
+		t.Parallel()
+		updated, err := Apply([]byte(testConfig), "app", Options{CacheMount: true})
+		require.NoError(t, err)
+		assert.Equal(t, `// Dev container for the app
+{
+  // contexture:begin (managed by contexture integrate devcontainer)
+  "mounts": ["source=contexture-cache,target=/tmp/contexture,type=volume"],
+  "postCreateCommand": "sudo -n chown \"$(id -u)\" /tmp/contexture 2>/dev/null; mkdir -p \"$HOME/.cache\" && ln -sfn /tmp/contexture \"$HOME/.cache/contexture\"; contexture build --non-interactive",
+  // contexture:end
+  "name": "app",
+  "image": "mcr.microsoft.com/devcontainers/go:1", // pinned by hand
+  /* features are added by the team */
+  "features": {},
+}
+`, string(updated))
+
+		again, err := Apply(updated, "app", Options{CacheMount: true})
+		require.NoError(t, err)
+		assert.Equal(t, string(updated), string(again))
+
+		removed, err := Remove(updated)
+		require.NoError(t, err)
+		assert.Equal(t, testConfig, string(removed))
+	})
+
+	t.Run("replaces the managed block", func(t *testing.T) {
+		t.Parallel()
+		updated, err := Apply([]byte(testConfig), "app", Options{CacheMount: true})
+		require.NoError(t, err)
+		updated, err = Apply(updated, "app", Options{Command: "make rules"})
+		require.NoError(t, err)
+		assert.Contains(t, string(updated), `"postCreateCommand": "make rules",`)
+		assert.NotContains(t, string(updated), "mounts")
+	})
+
+	t.Run("creates a configuration", func(t *testing.T) {
+		t.Parallel()
+		updated, err := Apply(nil, "app", Options{})
+		require.NoError(t, err)
+		assert.Equal(t, `{
+	// contexture:begin (managed by contexture integrate devcontainer)
+	"postCreateCommand": "contexture build --non-interactive",
+	// contexture:end
+	"name": "app",
+	"image": "mcr.microsoft.com/devcontainers/base:ubuntu"
+}
+`, string(updated))
+	})
+
+	t.Run("fills an empty object", func(t *testing.T) {
+		t.Parallel()
+		updated, err := Apply([]byte("{}"), "app", Options{})
+		require.NoError(t, err)
+		assert.Equal(t, "{\n\t// contexture:begin (managed by contexture integrate devcontainer)\n"+
+			"\t\"postCreateCommand\": \"contexture build --non-interactive\"\n\t// contexture:end\n}", string(updated))
+	})
+
+	t.Run("reports settings made by hand", func(t *testing.T) {
+		t.Parallel()
+		_, err := Apply([]byte(`{"postCreateCommand": "npm ci"}`), "app", Options{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "already sets postCreateCommand")
+	})
+
+	t.Run("rejects malformed configurations", func(t *testing.T) {
+		t.Parallel()
+		_, err := Apply([]byte(`["not", "an", "object"]`), "app", Options{})
+		require.Error(t, err)
+		_, err = Apply([]byte("{\n  // contexture:begin\n  \"name\": \"app\"\n}\n"), "app", Options{})
+		require.Error(t, err)
+	})
+}
+
+func TestFind(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+
+	path, exists, err := Find(fs, "/work/app")
+	require.NoError(t, err)
+	assert.False(t, exists)
+	assert.Equal(t, "/work/app/.devcontainer/devcontainer.json", path)
+
+	require.NoError(t, afero.WriteFile(fs, "/work/app/.devcontainer.json", []byte("{}"), 0o644))
+	path, exists, err = Find(fs, "/work/app")
+	require.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, "/work/app/.devcontainer.json", path)
+}
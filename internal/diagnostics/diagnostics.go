@@ -0,0 +1,148 @@
+// Package diagnostics collects runtime and configuration details for bug reports
+package diagnostics
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/version"
+	"github.com/spf13/afero"
+)
+
+// IssueURL is where users are asked to report crashes
+const IssueURL = "https://github.com/contextureai/contexture/issues/new"
+
+// redacted replaces sensitive values in bundles
+const redacted = "[REDACTED]"
+
+var (
+	// defaultLogs receives a copy of everything written to the application logger
+	defaultLogs = NewLogBuffer(DefaultLogLines)
+
+	// secretKeyRegex matches configuration and environment keys that hold credentials
+	secretKeyRegex = regexp.MustCompile(`(?i)(token|secret|password|passwd|credential|api[_-]?key|auth)`)
+	// yamlKeyValueRegex matches a single-line YAML key/value pair
+	yamlKeyValueRegex = regexp.MustCompile(`^(\s*(?:-\s+)?)([A-Za-z0-9_.-]+)(\s*:\s*)(\S.*)$`)
+	// urlCredentialsRegex matches user info embedded in URLs
+	urlCredentialsRegex = regexp.MustCompile(`([a-zA-Z][a-zA-Z0-9+.-]*://)[^/@\s]+@`)
+	// ansiRegex matches terminal escape sequences in captured log output
+	ansiRegex = regexp.MustCompile(`\x1b\[[0-9;]*[A-Za-z]`)
+)
+
+// Bundle holds the diagnostic information attached to a bug report
+type Bundle struct {
+	CreatedAt  time.Time         `json:"created_at"`
+	Version    version.Info      `json:"version"`
+	Args       []string          `json:"args"`
+	WorkDir    string            `json:"work_dir"`
+	Env        map[string]string `json:"env"`
+	ConfigPath string            `json:"config_path,omitempty"`
+	Config     string            `json:"config,omitempty"`
+	Panic      string            `json:"panic,omitempty"`
+	Stack      string            `json:"stack,omitempty"`
+	Logs       []string          `json:"logs"`
+}
+
+// DefaultLogBuffer returns the buffer holding the most recent application log lines
+func DefaultLogBuffer() *LogBuffer {
+	return defaultLogs
+}
+
+// Collect gathers diagnostics for the project in workDir. The project configuration,
+// command arguments, and environment are sanitized before being included.
+func Collect(fs afero.Fs, workDir string, args []string) *Bundle {
+	bundle := &Bundle{
+		CreatedAt: time.Now().UTC(),
+		Version:   version.Get(),
+		WorkDir:   workDir,
+		Env:       collectEnv(),
+		Logs:      stripANSI(defaultLogs.Lines()),
+	}
+
+	for _, arg := range args {
+		bundle.Args = append(bundle.Args, SanitizeURL(arg))
+	}
+
+	for _, location := range []domain.ConfigLocation{domain.ConfigLocationRoot, domain.ConfigLocationContexture} {
+		path := domain.GetConfigPath(workDir, location)
+		data, err := afero.ReadFile(fs, path)
+		if err != nil {
+			continue
+		}
+		bundle.ConfigPath = path
+		bundle.Config = SanitizeConfig(string(data))
+		break
+	}
+
+	return bundle
+}
+
+// Write saves the bundle as JSON in dir and returns the file path
+func (b *Bundle) Write(fs afero.Fs, dir string) (string, error) {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return "", contextureerrors.Wrap(err, "marshal diagnostics bundle")
+	}
+
+	if err := fs.MkdirAll(dir, 0o755); err != nil {
+		return "", contextureerrors.Wrap(err, "create diagnostics directory")
+	}
+
+	name := fmt.Sprintf("contexture-diagnostics-%s.json", b.CreatedAt.Format("20060102-150405"))
+	path := filepath.Join(dir, name)
+	if err := afero.WriteFile(fs, path, data, 0o600); err != nil {
+		return "", contextureerrors.Wrap(err, "write diagnostics bundle")
+	}
+
+	return path, nil
+}
+
+// SanitizeConfig redacts credential values and URL user info from YAML configuration
+func SanitizeConfig(config string) string {
+	lines := strings.Split(config, "\n")
+	for i, line := range lines {
+		if m := yamlKeyValueRegex.FindStringSubmatch(line); m != nil && secretKeyRegex.MatchString(m[2]) {
+			lines[i] = m[1] + m[2] + m[3] + redacted
+			continue
+		}
+		lines[i] = SanitizeURL(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// SanitizeURL redacts user info, such as access tokens, embedded in URLs within s
+func SanitizeURL(s string) string {
+	return urlCredentialsRegex.ReplaceAllString(s, "${1}"+redacted+"@")
+}
+
+// collectEnv returns the CONTEXTURE_* and terminal environment variables, with secrets redacted
+func collectEnv() map[string]string {
+	env := make(map[string]string)
+	for _, entry := range os.Environ() {
+		key, value, ok := strings.Cut(entry, "=")
+		if !ok || (!strings.HasPrefix(key, "CONTEXTURE_") && key != "TERM" && key != "CI") {
+			continue
+		}
+		if secretKeyRegex.MatchString(key) {
+			value = redacted
+		}
+		env[key] = SanitizeURL(value)
+	}
+	return env
+}
+
+// stripANSI removes terminal escape sequences from log lines
+func stripANSI(lines []string) []string {
+	stripped := make([]string, 0, len(lines))
+	for _, line := range lines {
+		stripped = append(stripped, ansiRegex.ReplaceAllString(line, ""))
+	}
+	return stripped
+}
@@ -0,0 +1,61 @@
+package diagnostics
+
+import (
+	"bytes"
+	"sync"
+)
+
+// DefaultLogLines is the number of log lines kept for diagnostics bundles
+const DefaultLogLines = 200
+
+// LogBuffer is an io.Writer that keeps the most recent complete log lines
+type LogBuffer struct {
+	mu      sync.Mutex
+	limit   int
+	lines   []string
+	partial []byte
+}
+
+// NewLogBuffer creates a log buffer that keeps at most limit lines
+func NewLogBuffer(limit int) *LogBuffer {
+	if limit <= 0 {
+		limit = DefaultLogLines
+	}
+	return &LogBuffer{limit: limit}
+}
+
+// Write implements io.Writer, splitting p into lines
+func (b *LogBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	data := append(b.partial, p...)
+	for {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			break
+		}
+		b.lines = append(b.lines, string(data[:i]))
+		data = data[i+1:]
+	}
+	b.partial = append([]byte(nil), data...)
+
+	if len(b.lines) > b.limit {
+		b.lines = append([]string(nil), b.lines[len(b.lines)-b.limit:]...)
+	}
+
+	return len(p), nil
+}
+
+// Lines returns a copy of the buffered lines, oldest first
+func (b *LogBuffer) Lines() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	lines := make([]string, 0, len(b.lines)+1)
+	lines = append(lines, b.lines...)
+	if len(b.partial) > 0 {
+		lines = append(lines, string(b.partial))
+	}
+	return lines
+}
@@ -0,0 +1,75 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLines(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		oldText  string
+		newText  string
+		expected []Line
+	}{
+		{
+			name:     "both empty",
+			expected: []Line{},
+		},
+		{
+			name:    "identical",
+			oldText: "a\nb\n",
+			newText: "a\nb",
+			expected: []Line{
+				{Op: OpEqual, Text: "a"},
+				{Op: OpEqual, Text: "b"},
+			},
+		},
+		{
+			name:    "changed middle line",
+			oldText: "a\nb\nc",
+			newText: "a\nx\nc",
+			expected: []Line{
+				{Op: OpEqual, Text: "a"},
+				{Op: OpDelete, Text: "b"},
+				{Op: OpInsert, Text: "x"},
+				{Op: OpEqual, Text: "c"},
+			},
+		},
+		{
+			name:    "insertions and deletions at the ends",
+			oldText: "a\nb",
+			newText: "b\nc",
+			expected: []Line{
+				{Op: OpDelete, Text: "a"},
+				{Op: OpEqual, Text: "b"},
+				{Op: OpInsert, Text: "c"},
+			},
+		},
+		{
+			name:    "new text empty",
+			oldText: "a",
+			expected: []Line{
+				{Op: OpDelete, Text: "a"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, Lines(tt.oldText, tt.newText))
+		})
+	}
+}
+
+func TestHasChanges(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, HasChanges(Lines("a\nb", "a\nb")))
+	assert.True(t, HasChanges(Lines("a\nb", "a\nc")))
+	assert.False(t, HasChanges(nil))
+}
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+)
+
+// Environment variables that override the execution budget of the generation
+// settings, so CI can tune it without editing the configuration
+const (
+	ParallelFetchesEnv = "CONTEXTURE_PARALLEL_FETCHES"
+	CloneTimeoutEnv    = "CONTEXTURE_CLONE_TIMEOUT"
+	PullTimeoutEnv     = "CONTEXTURE_PULL_TIMEOUT"
+	CommandTimeoutEnv  = "CONTEXTURE_COMMAND_TIMEOUT"
+)
+
+// ExecutionBudget is the concurrency and time commands are allowed
+type ExecutionBudget struct {
+	// ParallelFetches is the number of rules fetched concurrently
+	ParallelFetches int
+	// CloneTimeout and PullTimeout bound each git operation; zero keeps the
+	// git client defaults
+	CloneTimeout time.Duration
+	PullTimeout  time.Duration
+	// CommandTimeout bounds a whole command; zero means no deadline
+	CommandTimeout time.Duration
+}
+
+// Budget returns the execution budget of the generation settings, with the
+// overrides getenv returns for the budget environment variables applied. A
+// nil getenv applies no overrides.
+func (g *GenerationConfig) Budget(getenv func(string) string) (ExecutionBudget, error) {
+	var settings GenerationConfig
+	if g != nil {
+		settings = *g
+	}
+	// value returns the override of env, or setting without one, and where it came from
+	value := func(setting, name, env string) (string, string) {
+		if getenv != nil {
+			if override := strings.TrimSpace(getenv(env)); override != "" {
+				return override, env
+			}
+		}
+		return setting, "generation." + name
+	}
+
+	budget := ExecutionBudget{ParallelFetches: DefaultParallelFetches}
+	parallel := ""
+	if settings.ParallelFetches != 0 {
+		parallel = strconv.Itoa(settings.ParallelFetches)
+	}
+	if raw, source := value(parallel, "parallelFetches", ParallelFetchesEnv); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > MaxParallelFetches {
+			return ExecutionBudget{}, contextureerrors.ValidationErrorf("generation",
+				"invalid parallel fetches %q from %s: expected a number from 1 to %d", raw, source, MaxParallelFetches)
+		}
+		budget.ParallelFetches = n
+	}
+
+	for _, timeout := range []struct {
+		name    string
+		setting string
+		env     string
+		target  *time.Duration
+	}{
+		{name: "cloneTimeout", setting: settings.CloneTimeout, env: CloneTimeoutEnv, target: &budget.CloneTimeout},
+		{name: "pullTimeout", setting: settings.PullTimeout, env: PullTimeoutEnv, target: &budget.PullTimeout},
+		{name: "commandTimeout", setting: settings.CommandTimeout, env: CommandTimeoutEnv, target: &budget.CommandTimeout},
+	} {
+		raw, source := value(timeout.setting, timeout.name, timeout.env)
+		if raw == "" {
+			continue
+		}
+		duration, err := time.ParseDuration(raw)
+		if err != nil || duration <= 0 {
+			return ExecutionBudget{}, contextureerrors.ValidationErrorf("generation",
+				"invalid timeout %q from %s: expected a positive duration such as 30s or 5m", raw, source)
+		}
+		*timeout.target = duration
+	}
+	return budget, nil
+}
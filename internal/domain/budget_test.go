@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerationConfig_Budget(t *testing.T) {
+	t.Parallel()
+	env := func(values map[string]string) func(string) string {
+		return func(key string) string { return values[key] }
+	}
+
+	tests := []struct {
+		name     string
+		config   *GenerationConfig
+		getenv   func(string) string
+		expected ExecutionBudget
+		errMsg   string
+	}{
+		{
+			name:     "nil config uses defaults",
+			expected: ExecutionBudget{ParallelFetches: DefaultParallelFetches},
+		},
+		{
+			name: "settings",
+			config: &GenerationConfig{
+				ParallelFetches: 8,
+				CloneTimeout:    "10m",
+				PullTimeout:     "30s",
+				CommandTimeout:  "15m",
+			},
+			expected: ExecutionBudget{
+				ParallelFetches: 8,
+				CloneTimeout:    10 * time.Minute,
+				PullTimeout:     30 * time.Second,
+				CommandTimeout:  15 * time.Minute,
+			},
+		},
+		{
+			name:   "environment overrides settings",
+			config: &GenerationConfig{ParallelFetches: 8, CloneTimeout: "10m"},
+			getenv: env(map[string]string{
+				ParallelFetchesEnv: "2",
+				CloneTimeoutEnv:    "1m",
+				CommandTimeoutEnv:  "20m",
+			}),
+			expected: ExecutionBudget{
+				ParallelFetches: 2,
+				CloneTimeout:    time.Minute,
+				CommandTimeout:  20 * time.Minute,
+			},
+		},
+		{
+			name:   "too many parallel fetches",
+			config: &GenerationConfig{ParallelFetches: MaxParallelFetches + 1},
+			errMsg: "generation.parallelFetches",
+		},
+		{
+			name:   "invalid environment parallel fetches",
+			getenv: env(map[string]string{ParallelFetchesEnv: "many"}),
+			errMsg: ParallelFetchesEnv,
+		},
+		{
+			name:   "invalid timeout",
+			config: &GenerationConfig{PullTimeout: "soon"},
+			errMsg: "generation.pullTimeout",
+		},
+		{
+			name:   "negative environment timeout",
+			getenv: env(map[string]string{CommandTimeoutEnv: "-1m"}),
+			errMsg: CommandTimeoutEnv,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			budget, err := tt.config.Budget(tt.getenv)
+			if tt.errMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, budget)
+		})
+	}
+}
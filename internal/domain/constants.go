@@ -2,10 +2,13 @@ package domain
 
 // Configuration file names
 const (
-	ConfigFile    = ".contexture.yaml"
-	ContextureDir = ".contexture"
-	LocalRulesDir = "rules"
-	TemplateFile  = "CLAUDE_TEMPLATE.md"
+	ConfigFile      = ".contexture.yaml"
+	LocalConfigFile = ".contexture.local.yaml"
+	ContextureDir   = ".contexture"
+	LocalRulesDir   = "rules"
+	IgnoreFile      = ".contextureignore" // Patterns of files in the rules directory that are not rules
+	RuleTestsDir    = "tests"
+	TemplateFile    = "CLAUDE_TEMPLATE.md"
 )
 
 // Output file defaults
@@ -14,6 +17,8 @@ const (
 	CursorOutputDir    = ".cursor/rules"
 	WindsurfOutputDir  = ".windsurf/rules"
 	WindsurfOutputFile = ".windsurfrules"
+	CopilotOutputFile  = ".github/copilot-instructions.md"
+	GeminiOutputFile   = "GEMINI.md"
 )
 
 // Default repository configuration
@@ -3,6 +3,9 @@ package domain
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"path"
+	"slices"
+	"strings"
 	"time"
 
 	contextureerrors "github.com/contextureai/contexture/internal/errors"
@@ -18,6 +21,10 @@ const (
 	FormatCursor FormatType = "cursor"
 	// FormatWindsurf represents the Windsurf IDE format (.windsurf/rules/)
 	FormatWindsurf FormatType = "windsurf"
+	// FormatCopilot represents the GitHub Copilot format (.github/copilot-instructions.md)
+	FormatCopilot FormatType = "copilot"
+	// FormatGemini represents the Gemini CLI format (GEMINI.md)
+	FormatGemini FormatType = "gemini"
 )
 
 // UserRulesOutputMode defines how user/global rules are handled for a format
@@ -35,6 +42,10 @@ const (
 	// UserRulesDisabled doesn't output user rules at all
 	// (useful for team projects where user rules shouldn't affect project files)
 	UserRulesDisabled UserRulesOutputMode = "disabled"
+
+	// UserRulesFile outputs user rules to a dedicated file outside projects
+	// for IDEs that keep user rules in their settings (e.g., ~/.cursor/user-rules.md)
+	UserRulesFile UserRulesOutputMode = "file"
 )
 
 // String returns the string representation of the format type
@@ -44,12 +55,160 @@ func (ft FormatType) String() string {
 
 // FormatConfig represents the core format configuration
 type FormatConfig struct {
-	Type          FormatType          `yaml:"type"                    json:"type"                    validate:"required,oneof=claude cursor windsurf"`
+	Type          FormatType          `yaml:"type"                    json:"type"                    validate:"required,oneof=claude cursor windsurf copilot gemini"`
 	Enabled       bool                `yaml:"enabled"                 json:"enabled"`
 	Template      string              `yaml:"template,omitempty"      json:"template,omitempty"`      // Optional template file path
 	UserRulesMode UserRulesOutputMode `yaml:"userRulesMode,omitempty" json:"userRulesMode,omitempty"` // How to handle user/global rules
+	Include       []string            `yaml:"include,omitempty"       json:"include,omitempty"`       // Rule path patterns generated for this format; all when empty
+	Exclude       []string            `yaml:"exclude,omitempty"       json:"exclude,omitempty"`       // Rule path patterns left out of this format
+	Settings      *ClaudeSettings     `yaml:"settings,omitempty"      json:"settings,omitempty"`      // Managed .claude/settings.json entries (claude only)
+	GroupBy       RuleGrouping        `yaml:"groupBy,omitempty"       json:"groupBy,omitempty"`       // Groups rules under sections in single-file outputs
+	MergeBelow    int                 `yaml:"mergeBelow,omitempty"    json:"mergeBelow,omitempty"`    // Directory outputs: rules smaller than this many bytes share a file
+	SplitAbove    int                 `yaml:"splitAbove,omitempty"    json:"splitAbove,omitempty"`    // Directory outputs: rules larger than this many bytes are split into parts
+	PlainProse    bool                `yaml:"plainProse,omitempty"    json:"plainProse,omitempty"`    // Strips emoji, smart quotes, and decorative separators from rule content
+	Wrap          int                 `yaml:"wrap,omitempty"          json:"wrap,omitempty"`          // Rewraps paragraphs at this width, or unwraps them when -1
+	Assets        AssetMode           `yaml:"assets,omitempty"        json:"assets,omitempty"`        // Carries files referenced by relative links in rules into the output
 	BaseDir       string              `yaml:"-"                       json:"-"`                       // Runtime option, not serialized
 	IsUserRules   bool                `yaml:"-"                       json:"-"`                       // Runtime flag: true when generating user rules to native location
+	UserRulesFile string              `yaml:"-"                       json:"-"`                       // Runtime option: file user rules are written to, when IsUserRules is set
+	Inherited     bool                `yaml:"-"                       json:"inherited,omitempty"`     // Runtime flag: true when merged from an extended config
+}
+
+// RuleGrouping chooses the sections rules are grouped under in single-file outputs
+type RuleGrouping string
+
+const (
+	// GroupByTag groups rules by their first tag
+	GroupByTag RuleGrouping = "tag"
+	// GroupByFolder groups rules by the folder of their rule path
+	GroupByFolder RuleGrouping = "folder"
+)
+
+// AssetMode chooses how files referenced by relative links in rules, such as
+// images and diagrams, are carried into generated outputs
+type AssetMode string
+
+const (
+	// AssetsCopy copies referenced files to the assets directory of the format
+	AssetsCopy AssetMode = "copy"
+	// AssetsInline embeds small referenced images as data URIs and copies other files
+	AssetsInline AssetMode = "inline"
+)
+
+// ClaudeSettings lists the permission entries contexture manages in
+// .claude/settings.json. Entries added to the file by hand are kept.
+type ClaudeSettings struct {
+	AllowedTools   []string `yaml:"allowedTools,omitempty"   json:"allowedTools,omitempty"`   // Tools Claude may use without asking, e.g. "Bash(go test:*)"
+	DeniedTools    []string `yaml:"deniedTools,omitempty"    json:"deniedTools,omitempty"`    // Tools Claude may not use
+	IgnorePatterns []string `yaml:"ignorePatterns,omitempty" json:"ignorePatterns,omitempty"` // Files Claude may not read, e.g. ".env" or "secrets/**"
+}
+
+// ValidateSettings reports settings on formats other than claude and empty entries
+func (f FormatConfig) ValidateSettings() error {
+	if f.Settings == nil {
+		return nil
+	}
+	if f.Type != FormatClaude {
+		return contextureerrors.ValidationErrorf("formats", "settings are only supported by the claude format, not %s", f.Type)
+	}
+	for _, entry := range slices.Concat(f.Settings.AllowedTools, f.Settings.DeniedTools, f.Settings.IgnorePatterns) {
+		if strings.TrimSpace(entry) == "" {
+			return contextureerrors.ValidationErrorf("formats", "claude settings cannot contain empty entries")
+		}
+	}
+	return nil
+}
+
+// ValidateGroupBy reports an unknown rule grouping
+func (f FormatConfig) ValidateGroupBy() error {
+	switch f.GroupBy {
+	case "", GroupByTag, GroupByFolder:
+		return nil
+	default:
+		return contextureerrors.ValidationErrorf("formats",
+			"invalid groupBy %q for %s: expected %s or %s", f.GroupBy, f.Type, GroupByTag, GroupByFolder)
+	}
+}
+
+// ValidateFileSizes reports negative or overlapping file size thresholds and
+// thresholds on formats that write a single file
+func (f FormatConfig) ValidateFileSizes() error {
+	if f.MergeBelow == 0 && f.SplitAbove == 0 {
+		return nil
+	}
+	if f.Type == FormatClaude {
+		return contextureerrors.ValidationErrorf("formats", "mergeBelow and splitAbove are only supported by directory formats, not %s", f.Type)
+	}
+	if f.MergeBelow < 0 || f.SplitAbove < 0 {
+		return contextureerrors.ValidationErrorf("formats", "mergeBelow and splitAbove for %s cannot be negative", f.Type)
+	}
+	if f.SplitAbove > 0 && f.MergeBelow >= f.SplitAbove {
+		return contextureerrors.ValidationErrorf("formats",
+			"mergeBelow (%d) must be smaller than splitAbove (%d) for %s", f.MergeBelow, f.SplitAbove, f.Type)
+	}
+	return nil
+}
+
+// ValidateWrap reports a wrap width other than a positive width or -1
+func (f FormatConfig) ValidateWrap() error {
+	if f.Wrap < -1 {
+		return contextureerrors.ValidationErrorf("formats", "invalid wrap %d for %s: expected a width, or -1 to unwrap", f.Wrap, f.Type)
+	}
+	return nil
+}
+
+// ValidateAssets reports an unknown asset mode
+func (f FormatConfig) ValidateAssets() error {
+	switch f.Assets {
+	case "", AssetsCopy, AssetsInline:
+		return nil
+	default:
+		return contextureerrors.ValidationErrorf("formats",
+			"invalid assets %q for %s: expected %s or %s", f.Assets, f.Type, AssetsCopy, AssetsInline)
+	}
+}
+
+// IncludesRule reports whether the format generates the rule with ruleID.
+// A rule is generated when it matches an include pattern, or there are none,
+// and matches no exclude pattern.
+func (f FormatConfig) IncludesRule(ruleID string) bool {
+	if len(f.Include) > 0 && !matchesAnyRulePattern(f.Include, ruleID) {
+		return false
+	}
+	return !matchesAnyRulePattern(f.Exclude, ruleID)
+}
+
+// ValidateRulePatterns reports malformed include and exclude patterns
+func (f FormatConfig) ValidateRulePatterns() error {
+	for _, pattern := range append(append([]string(nil), f.Include...), f.Exclude...) {
+		if _, err := path.Match(strings.TrimSuffix(ExtractRulePath(pattern), "/**"), ""); err != nil {
+			return contextureerrors.ValidationErrorf("formats", "invalid rule pattern %q for %s: %v", pattern, f.Type, err)
+		}
+	}
+	return nil
+}
+
+// MatchRulePattern reports whether the path of ruleID matches pattern. Patterns
+// use path.Match syntax against rule paths such as "languages/go/testing";
+// a trailing "/**" matches everything below a directory.
+func MatchRulePattern(pattern, ruleID string) bool {
+	pattern = ExtractRulePath(pattern)
+	rulePath := ExtractRulePath(ruleID)
+	if dir, ok := strings.CutSuffix(pattern, "/**"); ok {
+		return strings.HasPrefix(rulePath, dir+"/")
+	}
+	matched, _ := path.Match(pattern, rulePath)
+	return matched
+}
+
+// matchesAnyRulePattern reports whether ruleID matches one of patterns
+func matchesAnyRulePattern(patterns []string, ruleID string) bool {
+	for _, pattern := range patterns {
+		if MatchRulePattern(pattern, ruleID) {
+			return true
+		}
+	}
+	return false
 }
 
 // FormatSpecificRule represents a rule with format-specific configuration
@@ -200,10 +359,13 @@ func (vr *ValidationResult) AddWarning(field, message, code string) {
 
 // GetContentHash calculates a hash of the transformed content
 func (tr *TransformedRule) GetContentHash() string {
-	// Use SHA256 for proper content hashing
-	h := sha256.New()
-	h.Write([]byte(tr.Content))
-	return hex.EncodeToString(h.Sum(nil))
+	return SHA256Hex([]byte(tr.Content))
+}
+
+// SHA256Hex returns the hex SHA-256 checksum of data
+func SHA256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
 }
 
 // GetAbsolutePath returns the absolute path for the transformed rule
@@ -223,6 +385,10 @@ type FormatCapabilities struct {
 	// Empty if SupportsUserRules is false
 	UserRulesPath string
 
+	// UserRulesFilePath is the file user rules are written to in file mode, for
+	// IDEs without a native user rules file. Empty if the format has none.
+	UserRulesFilePath string
+
 	// DefaultUserRulesMode is the default mode for handling user rules
 	DefaultUserRulesMode UserRulesOutputMode
 
@@ -244,8 +410,12 @@ func (fc *FormatConfig) GetEffectiveUserRulesMode() UserRulesOutputMode {
 		return UserRulesNative // Windsurf supports ~/.windsurf/global_rules.md
 	case FormatClaude:
 		return UserRulesNative // Claude supports ~/.claude/CLAUDE.md
+	case FormatGemini:
+		return UserRulesNative // Gemini CLI supports ~/.gemini/GEMINI.md
 	case FormatCursor:
 		return UserRulesProject // Cursor doesn't support user rules, default to including them
+	case FormatCopilot:
+		return UserRulesDisabled // Copilot instructions are committed and shared with the team
 	default:
 		return UserRulesProject // Unknown formats default to project injection
 	}
@@ -264,10 +434,12 @@ func (fc *FormatConfig) ShouldOmitUserRulesMode() bool {
 	switch fc.Type {
 	case FormatWindsurf:
 		defaultMode = UserRulesNative
-	case FormatClaude:
+	case FormatClaude, FormatGemini:
 		defaultMode = UserRulesNative
 	case FormatCursor:
 		defaultMode = UserRulesProject
+	case FormatCopilot:
+		defaultMode = UserRulesDisabled
 	default:
 		defaultMode = UserRulesProject
 	}
@@ -284,10 +456,28 @@ func (fc FormatConfig) MarshalYAML() (interface{}, error) {
 		Enabled       bool                 `yaml:"enabled"`
 		Template      string               `yaml:"template,omitempty"`
 		UserRulesMode *UserRulesOutputMode `yaml:"userRulesMode,omitempty"`
+		Include       []string             `yaml:"include,omitempty"`
+		Exclude       []string             `yaml:"exclude,omitempty"`
+		Settings      *ClaudeSettings      `yaml:"settings,omitempty"`
+		GroupBy       RuleGrouping         `yaml:"groupBy,omitempty"`
+		MergeBelow    int                  `yaml:"mergeBelow,omitempty"`
+		SplitAbove    int                  `yaml:"splitAbove,omitempty"`
+		PlainProse    bool                 `yaml:"plainProse,omitempty"`
+		Wrap          int                  `yaml:"wrap,omitempty"`
+		Assets        AssetMode            `yaml:"assets,omitempty"`
 	}{
-		Type:     fc.Type,
-		Enabled:  fc.Enabled,
-		Template: fc.Template,
+		Type:       fc.Type,
+		Enabled:    fc.Enabled,
+		Template:   fc.Template,
+		Include:    fc.Include,
+		Exclude:    fc.Exclude,
+		Settings:   fc.Settings,
+		GroupBy:    fc.GroupBy,
+		MergeBelow: fc.MergeBelow,
+		SplitAbove: fc.SplitAbove,
+		PlainProse: fc.PlainProse,
+		Wrap:       fc.Wrap,
+		Assets:     fc.Assets,
 	}
 
 	// Only include UserRulesMode if it's not the default
@@ -2,10 +2,12 @@ package domain
 
 // FormatMetadata contains metadata about a format implementation
 type FormatMetadata struct {
-	Type        FormatType
-	DisplayName string
-	Description string
-	IsDirectory bool // true if format outputs to directories, false for single files
+	Type             FormatType
+	DisplayName      string
+	Description      string
+	IsDirectory      bool   // true if format outputs to directories, false for single files
+	SupportsCommands bool   // true if format generates slash commands from command rules
+	AssetsDir        string // directory, relative to the project root, referenced assets are copied to
 }
 
 // Format defines the interface for output format implementations
@@ -410,6 +410,28 @@ func TestFormatConfig_MarshalYAML(t *testing.T) {
 			},
 			expected: "type: claude\nenabled: true\ntemplate: CLAUDE.template.md\n",
 		},
+		{
+			name: "claude with settings",
+			config: FormatConfig{
+				Type:     FormatClaude,
+				Enabled:  true,
+				Settings: &ClaudeSettings{AllowedTools: []string{"Read"}},
+			},
+			expected: "type: claude\nenabled: true\nsettings:\n    allowedTools:\n        - Read\n",
+		},
+		{
+			name: "cursor with output options",
+			config: FormatConfig{
+				Type:       FormatCursor,
+				Enabled:    true,
+				GroupBy:    GroupByTag,
+				MergeBelow: 200,
+				PlainProse: true,
+				Wrap:       -1,
+				Assets:     AssetsCopy,
+			},
+			expected: "type: cursor\nenabled: true\ngroupBy: tag\nmergeBelow: 200\nplainProse: true\nwrap: -1\nassets: copy\n",
+		},
 	}
 
 	for _, tt := range tests {
@@ -420,3 +442,89 @@ func TestFormatConfig_MarshalYAML(t *testing.T) {
 		})
 	}
 }
+
+func TestMatchRulePattern(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		pattern string
+		ruleID  string
+		want    bool
+	}{
+		{"languages/go/testing", "[contexture:languages/go/testing]", true},
+		{"languages/go/*", "[contexture:languages/go/testing]", true},
+		{"languages/*", "[contexture:languages/go/testing]", false},
+		{"languages/**", "[contexture:languages/go/testing]", true},
+		{"languages/**", "[contexture:languagesx/go]", false},
+		{"[contexture:architecture/*]", "[contexture(local):architecture/layers]", true},
+		{"architecture/*", "architecture/layers", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.ruleID, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, MatchRulePattern(tt.pattern, tt.ruleID))
+		})
+	}
+}
+
+func TestFormatConfig_IncludesRule(t *testing.T) {
+	t.Parallel()
+	cursor := FormatConfig{Type: FormatCursor, Exclude: []string{"architecture/**"}}
+	assert.False(t, cursor.IncludesRule("[contexture:architecture/layers]"))
+	assert.True(t, cursor.IncludesRule("[contexture:languages/go/testing]"))
+
+	claude := FormatConfig{Type: FormatClaude, Include: []string{"languages/**"}, Exclude: []string{"languages/go/legacy"}}
+	assert.True(t, claude.IncludesRule("[contexture:languages/go/testing]"))
+	assert.False(t, claude.IncludesRule("[contexture:languages/go/legacy]"))
+	assert.False(t, claude.IncludesRule("[contexture:security/secrets]"))
+
+	assert.True(t, FormatConfig{Type: FormatWindsurf}.IncludesRule("[contexture:security/secrets]"))
+}
+
+func TestFormatConfig_ValidateRulePatterns(t *testing.T) {
+	t.Parallel()
+	require.NoError(t, FormatConfig{Include: []string{"languages/**"}, Exclude: []string{"a/*"}}.ValidateRulePatterns())
+	require.Error(t, FormatConfig{Exclude: []string{"a/[b"}}.ValidateRulePatterns())
+}
+
+func TestFormatConfig_ValidateGroupBy(t *testing.T) {
+	t.Parallel()
+	require.NoError(t, FormatConfig{Type: FormatClaude}.ValidateGroupBy())
+	require.NoError(t, FormatConfig{Type: FormatClaude, GroupBy: GroupByFolder}.ValidateGroupBy())
+	require.Error(t, FormatConfig{Type: FormatClaude, GroupBy: "language"}.ValidateGroupBy())
+}
+
+func TestFormatConfig_ValidateFileSizes(t *testing.T) {
+	t.Parallel()
+	require.NoError(t, FormatConfig{Type: FormatCursor}.ValidateFileSizes())
+	require.NoError(t, FormatConfig{Type: FormatCursor, MergeBelow: 500, SplitAbove: 8000}.ValidateFileSizes())
+	require.NoError(t, FormatConfig{Type: FormatWindsurf, SplitAbove: 8000}.ValidateFileSizes())
+	require.Error(t, FormatConfig{Type: FormatClaude, SplitAbove: 8000}.ValidateFileSizes())
+	require.Error(t, FormatConfig{Type: FormatCursor, MergeBelow: -1}.ValidateFileSizes())
+	require.Error(t, FormatConfig{Type: FormatCursor, MergeBelow: 8000, SplitAbove: 500}.ValidateFileSizes())
+}
+
+func TestFormatConfig_ValidateWrap(t *testing.T) {
+	t.Parallel()
+	require.NoError(t, FormatConfig{Type: FormatClaude}.ValidateWrap())
+	require.NoError(t, FormatConfig{Type: FormatClaude, Wrap: 80}.ValidateWrap())
+	require.NoError(t, FormatConfig{Type: FormatClaude, Wrap: -1}.ValidateWrap())
+	require.Error(t, FormatConfig{Type: FormatClaude, Wrap: -2}.ValidateWrap())
+}
+
+func TestFormatConfig_ValidateAssets(t *testing.T) {
+	t.Parallel()
+	require.NoError(t, FormatConfig{Type: FormatCursor}.ValidateAssets())
+	require.NoError(t, FormatConfig{Type: FormatCursor, Assets: AssetsCopy}.ValidateAssets())
+	require.NoError(t, FormatConfig{Type: FormatClaude, Assets: AssetsInline}.ValidateAssets())
+	require.Error(t, FormatConfig{Type: FormatClaude, Assets: "embed"}.ValidateAssets())
+}
+
+func TestFormatConfig_ValidateSettings(t *testing.T) {
+	t.Parallel()
+	settings := &ClaudeSettings{AllowedTools: []string{"Bash(go test:*)"}, IgnorePatterns: []string{".env"}}
+	require.NoError(t, FormatConfig{Type: FormatClaude}.ValidateSettings())
+	require.NoError(t, FormatConfig{Type: FormatClaude, Settings: settings}.ValidateSettings())
+	require.Error(t, FormatConfig{Type: FormatCursor, Settings: settings}.ValidateSettings())
+	require.Error(t, FormatConfig{Type: FormatClaude, Settings: &ClaudeSettings{DeniedTools: []string{" "}}}.ValidateSettings())
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+)
+
+// MCPTransport is how an assistant connects to an MCP server
+type MCPTransport string
+
+const (
+	// MCPTransportStdio starts the server as a local process (default when command is set)
+	MCPTransportStdio MCPTransport = "stdio"
+	// MCPTransportHTTP connects to a streamable HTTP server
+	MCPTransportHTTP MCPTransport = "http"
+	// MCPTransportSSE connects to a server-sent events server
+	MCPTransportSSE MCPTransport = "sse"
+)
+
+// MCPServer describes an MCP server the project recommends. Local servers set
+// Command; remote servers set URL.
+type MCPServer struct {
+	Type    MCPTransport      `yaml:"type,omitempty"    json:"type,omitempty"`
+	Command string            `yaml:"command,omitempty" json:"command,omitempty"`
+	Args    []string          `yaml:"args,omitempty"    json:"args,omitempty"`
+	Env     map[string]string `yaml:"env,omitempty"     json:"env,omitempty"`
+	URL     string            `yaml:"url,omitempty"     json:"url,omitempty"`
+	Headers map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`
+}
+
+// Transport returns the configured transport, defaulting to stdio for
+// servers with a command and http for servers with a URL
+func (s MCPServer) Transport() MCPTransport {
+	switch {
+	case s.Type != "":
+		return s.Type
+	case s.URL != "":
+		return MCPTransportHTTP
+	default:
+		return MCPTransportStdio
+	}
+}
+
+// ValidateMCPServers reports servers without a usable command or URL
+func ValidateMCPServers(servers map[string]MCPServer) error {
+	names := make([]string, 0, len(servers))
+	for name := range servers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		server := servers[name]
+		if strings.TrimSpace(name) == "" {
+			return contextureerrors.ValidationErrorf("mcpServers", "server names cannot be empty")
+		}
+		transport := server.Transport()
+		if !slices.Contains([]MCPTransport{MCPTransportStdio, MCPTransportHTTP, MCPTransportSSE}, transport) {
+			return contextureerrors.ValidationErrorf("mcpServers", "server %s has unknown type %q (valid: stdio, http, sse)", name, server.Type)
+		}
+		if transport == MCPTransportStdio {
+			if strings.TrimSpace(server.Command) == "" {
+				return contextureerrors.ValidationErrorf("mcpServers", "server %s requires a command", name)
+			}
+			if server.URL != "" {
+				return contextureerrors.ValidationErrorf("mcpServers", "server %s cannot set both command and url", name)
+			}
+			continue
+		}
+		if strings.TrimSpace(server.URL) == "" {
+			return contextureerrors.ValidationErrorf("mcpServers", "server %s requires a url", name)
+		}
+		if server.Command != "" {
+			return contextureerrors.ValidationErrorf("mcpServers", "server %s cannot set both command and url", name)
+		}
+	}
+	return nil
+}
+
+// MCPConfigWriter is implemented by formats whose assistant reads MCP servers
+// from a project file
+type MCPConfigWriter interface {
+	// WriteMCPServers updates the servers contexture manages in the project's
+	// MCP configuration, removing those no longer configured
+	WriteMCPServers(servers map[string]MCPServer, config *FormatConfig) error
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMCPServer_Transport(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, MCPTransportStdio, MCPServer{Command: "npx"}.Transport())
+	assert.Equal(t, MCPTransportHTTP, MCPServer{URL: "https://mcp.example.com"}.Transport())
+	assert.Equal(t, MCPTransportSSE, MCPServer{Type: MCPTransportSSE, URL: "https://mcp.example.com/sse"}.Transport())
+}
+
+func TestValidateMCPServers(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		servers map[string]MCPServer
+		wantErr string
+	}{
+		{name: "none"},
+		{
+			name: "local and remote",
+			servers: map[string]MCPServer{
+				"github": {Command: "npx", Args: []string{"-y", "@modelcontextprotocol/server-github"}},
+				"docs":   {Type: MCPTransportSSE, URL: "https://mcp.example.com/sse"},
+			},
+		},
+		{name: "missing command", servers: map[string]MCPServer{"github": {}}, wantErr: "requires a command"},
+		{name: "missing url", servers: map[string]MCPServer{"docs": {Type: MCPTransportHTTP}}, wantErr: "requires a url"},
+		{
+			name:    "command and url",
+			servers: map[string]MCPServer{"docs": {Command: "npx", URL: "https://mcp.example.com"}},
+			wantErr: "cannot set both",
+		},
+		{name: "unknown type", servers: map[string]MCPServer{"docs": {Type: "ws", URL: "wss://x"}}, wantErr: "unknown type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := ValidateMCPServers(tt.servers)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
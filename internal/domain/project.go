@@ -3,6 +3,7 @@ package domain
 import (
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 // Project represents the main project configuration
@@ -10,6 +11,14 @@ type Project struct {
 	// Version for configuration compatibility
 	Version int `yaml:"version,omitempty" json:"version,omitempty"`
 
+	// Extends names a base configuration merged beneath this one (optional):
+	// a path relative to this file, or @provider/path/to/config.yaml
+	Extends string `yaml:"extends,omitempty" json:"extends,omitempty"`
+
+	// Variable presets published by providers, as @provider/preset, applied
+	// in order beneath provider defaults and rule variables (optional)
+	Presets []string `yaml:"presets,omitempty" json:"presets,omitempty"`
+
 	// Providers for external rule repositories (optional)
 	Providers []Provider `yaml:"providers,omitempty" json:"providers,omitempty"`
 
@@ -22,18 +31,72 @@ type Project struct {
 	// Generation settings (optional)
 	Generation *GenerationConfig `yaml:"generation,omitempty" json:"generation,omitempty"`
 
+	// Saved rules list filters (optional)
+	Filters []SavedFilter `yaml:"filters,omitempty" json:"filters,omitempty" validate:"dive"`
+
+	// Organization baseline compared by `contexture sync` (optional)
+	Sync *SyncConfig `yaml:"sync,omitempty" json:"sync,omitempty"`
+
+	// MCP servers written to the configuration of assistants that support them (optional)
+	MCPServers map[string]MCPServer `yaml:"mcpServers,omitempty" json:"mcpServers,omitempty"`
+
+	// Command aliases mapping a name to a command path such as "rules update";
+	// read from the global configuration only (optional)
+	Aliases map[string]string `yaml:"aliases,omitempty" json:"aliases,omitempty"`
+
+	// User rules file per format type, replacing the assistant's native
+	// location such as ~/.claude/CLAUDE.md; read from the global configuration
+	// only (optional)
+	UserRulesPaths map[string]string `yaml:"userRulesPaths,omitempty" json:"userRulesPaths,omitempty"`
+
+	// Rules starred with `contexture star`, added to a project with
+	// `contexture rules add --favorites`; read from the global configuration
+	// only (optional)
+	Favorites []string `yaml:"favorites,omitempty" json:"favorites,omitempty"`
+
+	// Experiments offering alternative rule variants, of which one is built
+	// at a time (optional)
+	Experiments map[string]Experiment `yaml:"experiments,omitempty" json:"experiments,omitempty"`
+
+	// Team of the project and whether updating rules other teams own needs
+	// their coordination (optional)
+	Ownership *OwnershipConfig `yaml:"ownership,omitempty" json:"ownership,omitempty"`
+
+	// Keeps outputs with sensitive rules out of version control and
+	// distributes them as an encrypted bundle (optional)
+	Sensitive *SensitiveConfig `yaml:"sensitive,omitempty" json:"sensitive,omitempty"`
+
+	// Approval gate holding rules from untrusted providers until approved
+	// with `contexture approve` (optional)
+	Approval *ApprovalConfig `yaml:"approval,omitempty" json:"approval,omitempty"`
+
+	// Makes unknown keys in this file errors instead of warnings, like the
+	// --strict-config flag (optional)
+	StrictConfig bool `yaml:"strictConfig,omitempty" json:"strictConfig,omitempty"`
+
 	// Embedded format config functionality
 	formatContainer formatConfigContainer `yaml:"-" json:"-"`
 	// Embedded generation config functionality
 	genProvider generationConfigProvider `yaml:"-" json:"-"`
 }
 
+// Experiment is a set of alternative rule variants. Rules join a variant
+// with experiment: <variant>, and a build includes the rules of one variant.
+type Experiment struct {
+	Variants []string `yaml:"variants" json:"variants"`
+	// Default is the variant built when none is selected; without it, the
+	// rules of every variant are left out
+	Default string `yaml:"default,omitempty" json:"default,omitempty"`
+}
+
 // Provider represents a named rule repository
 type Provider struct {
 	Name          string        `yaml:"name"                     json:"name"                     validate:"required"`
 	URL           string        `yaml:"url"                      json:"url"                      validate:"required,url"`
 	DefaultBranch string        `yaml:"defaultBranch,omitempty"  json:"defaultBranch,omitempty"`
+	Mirrors       []string      `yaml:"mirrors,omitempty"        json:"mirrors,omitempty"        validate:"omitempty,dive,url"` // Tried in order when the URL is unavailable
 	Auth          *ProviderAuth `yaml:"auth,omitempty"           json:"auth,omitempty"`
+	Inherited     bool          `yaml:"-"                        json:"inherited,omitempty"` // Runtime flag: true when merged from an extended config
 }
 
 // ProviderAuth represents authentication configuration for a provider
@@ -42,12 +105,166 @@ type ProviderAuth struct {
 	Token string `yaml:"token,omitempty" json:"token,omitempty" validate:"required_if=Type token"`
 }
 
+// OwnershipConfig relates a project to the teams that own its rules
+type OwnershipConfig struct {
+	// Team maintains the project, such as @acme/web; without it, the team is
+	// the owners CODEOWNERS assigns to the configuration file
+	Team string `yaml:"team,omitempty" json:"team,omitempty"`
+	// RequireCoordination makes rules update warn about rules other teams own
+	RequireCoordination bool `yaml:"requireCoordination,omitempty" json:"requireCoordination,omitempty"`
+}
+
+// ApprovalConfig gates rules from providers the project does not trust
+type ApprovalConfig struct {
+	// Required makes rules added from providers outside TrustedProviders
+	// pending until they are approved
+	Required bool `yaml:"required,omitempty" json:"required,omitempty"`
+	// TrustedProviders need no approval: provider names such as @mycompany,
+	// or repository URLs
+	TrustedProviders []string `yaml:"trustedProviders,omitempty" json:"trustedProviders,omitempty"`
+}
+
+// SensitiveConfig protects outputs generated from rules with sensitive
+// internal details
+type SensitiveConfig struct {
+	// RequireIgnored fails builds that would write outputs git tracks
+	RequireIgnored bool `yaml:"requireIgnored,omitempty" json:"requireIgnored,omitempty"`
+	// EncryptCommand reads the bundle of outputs on stdin and writes it
+	// encrypted to stdout, such as "age -r age1..."; builds write the bundle
+	// when it is set
+	EncryptCommand string `yaml:"encryptCommand,omitempty" json:"encryptCommand,omitempty"`
+	// DecryptCommand reverses EncryptCommand for contexture decrypt, such as
+	// "age -d -i key.txt"
+	DecryptCommand string `yaml:"decryptCommand,omitempty" json:"decryptCommand,omitempty"`
+	// Bundle is the path of the encrypted bundle relative to the project,
+	// .contexture/outputs.enc by default
+	Bundle string `yaml:"bundle,omitempty" json:"bundle,omitempty"`
+}
+
+// SyncConfig names the organization baseline a project is aligned with and
+// the deviations from it the team has chosen to keep
+type SyncConfig struct {
+	// Baseline is a path relative to the configuration or @provider/path/to/config.yaml
+	Baseline   string          `yaml:"baseline"             json:"baseline"`
+	Deviations []SyncDeviation `yaml:"deviations,omitempty" json:"deviations,omitempty"`
+}
+
+// SyncDeviationType is the kind of difference from the baseline that was kept
+type SyncDeviationType string
+
+const (
+	// SyncDeviationOmitted means a baseline rule is intentionally not used
+	SyncDeviationOmitted SyncDeviationType = "omitted"
+	// SyncDeviationExtra means a rule not in the baseline is intentionally kept
+	SyncDeviationExtra SyncDeviationType = "extra"
+	// SyncDeviationModified means a rule intentionally differs from its baseline ref or variables
+	SyncDeviationModified SyncDeviationType = "modified"
+)
+
+// SyncDeviation records an intentional difference from the baseline
+type SyncDeviation struct {
+	Rule   string            `yaml:"rule"             json:"rule"`
+	Type   SyncDeviationType `yaml:"type"             json:"type"`
+	Reason string            `yaml:"reason,omitempty" json:"reason,omitempty"`
+}
+
+// SavedFilter is a named rules list filter recalled with `rules list --filter-name`
+type SavedFilter struct {
+	Name       string   `yaml:"name"                 json:"name"                 validate:"required"`
+	Pattern    string   `yaml:"pattern,omitempty"    json:"pattern,omitempty"`
+	Tags       []string `yaml:"tags,omitempty"       json:"tags,omitempty"`
+	Languages  []string `yaml:"languages,omitempty"  json:"languages,omitempty"`
+	Frameworks []string `yaml:"frameworks,omitempty" json:"frameworks,omitempty"`
+	// Source limits the filter to rules from user-level or project configuration
+	Source RuleSource `yaml:"source,omitempty" json:"source,omitempty" validate:"omitempty,oneof=user project"`
+}
+
+// FindFilter returns the saved filter with the given name
+func (p *Project) FindFilter(name string) (SavedFilter, bool) {
+	for _, filter := range p.Filters {
+		if filter.Name == name {
+			return filter, true
+		}
+	}
+	return SavedFilter{}, false
+}
+
 // GenerationConfig represents settings for rule generation
 type GenerationConfig struct {
-	ParallelFetches int    `yaml:"parallelFetches,omitempty" json:"parallelFetches,omitempty"`
-	DefaultBranch   string `yaml:"defaultBranch,omitempty"   json:"defaultBranch,omitempty"`
-	CacheEnabled    bool   `yaml:"cacheEnabled,omitempty"    json:"cacheEnabled,omitempty"`
-	CacheTTL        string `yaml:"cacheTTL,omitempty"        json:"cacheTTL,omitempty"` // Duration string like "5m"
+	ParallelFetches int              `yaml:"parallelFetches,omitempty" json:"parallelFetches,omitempty"`
+	DefaultBranch   string           `yaml:"defaultBranch,omitempty"   json:"defaultBranch,omitempty"`
+	CacheEnabled    bool             `yaml:"cacheEnabled,omitempty"    json:"cacheEnabled,omitempty"`
+	CacheTTL        string           `yaml:"cacheTTL,omitempty"        json:"cacheTTL,omitempty"` // Duration string like "5m"
+	Model           string           `yaml:"model,omitempty"           json:"model,omitempty"`    // Model profile for context size reports
+	Summarize       *SummarizeConfig `yaml:"summarize,omitempty" json:"summarize,omitempty"`
+	Redact          *RedactConfig    `yaml:"redact,omitempty"    json:"redact,omitempty"`
+	Safety          *SafetyConfig    `yaml:"safety,omitempty"    json:"safety,omitempty"`
+	CloneTimeout    string           `yaml:"cloneTimeout,omitempty"    json:"cloneTimeout,omitempty"`   // Duration string bounding each git clone
+	PullTimeout     string           `yaml:"pullTimeout,omitempty"     json:"pullTimeout,omitempty"`    // Duration string bounding each git pull
+	CommandTimeout  string           `yaml:"commandTimeout,omitempty"  json:"commandTimeout,omitempty"` // Duration string bounding a whole command
+	RefreshEnvRule  bool             `yaml:"refreshEnvRule,omitempty"  json:"refreshEnvRule,omitempty"` // Rewrite the project facts rule on build
+	RefreshGitRule  bool             `yaml:"refreshGitRule,omitempty"  json:"refreshGitRule,omitempty"` // Rewrite the git conventions rule on build
+}
+
+// SummarizeStrategy is how oversized rules are condensed
+type SummarizeStrategy string
+
+const (
+	// SummarizeOutline keeps headings and list items
+	SummarizeOutline SummarizeStrategy = "outline"
+	// SummarizeStripExamples removes code blocks and example sections
+	SummarizeStripExamples SummarizeStrategy = "strip-examples"
+	// SummarizeCommand pipes the rule through a user-provided command
+	SummarizeCommand SummarizeStrategy = "command"
+)
+
+// SummarizeConfig condenses rules whose rendered content is longer than Threshold characters
+type SummarizeConfig struct {
+	Threshold int               `yaml:"threshold,omitempty" json:"threshold,omitempty"`
+	Strategy  SummarizeStrategy `yaml:"strategy,omitempty"  json:"strategy,omitempty"`
+	Command   string            `yaml:"command,omitempty"   json:"command,omitempty"`
+}
+
+// RedactAction is what a build does with rule content matching a redaction pattern
+type RedactAction string
+
+const (
+	// RedactFail fails the build, naming the rule and the patterns matched
+	RedactFail RedactAction = "fail"
+	// RedactMask replaces each match with a placeholder naming its pattern
+	RedactMask RedactAction = "mask"
+)
+
+// RedactConfig scans rendered rule content for secrets at build time
+type RedactConfig struct {
+	Action    RedactAction    `yaml:"action,omitempty"    json:"action,omitempty"`
+	Detectors []string        `yaml:"detectors,omitempty" json:"detectors,omitempty"` // Built-in detectors to run; all of them when empty
+	Patterns  []RedactPattern `yaml:"patterns,omitempty"  json:"patterns,omitempty"`
+}
+
+// RedactPattern is a named regular expression matching content to redact
+type RedactPattern struct {
+	Name  string `yaml:"name"  json:"name"`
+	Regex string `yaml:"regex" json:"regex"`
+}
+
+// SafetySeverity is how a build reports content safety findings in third-party rules
+type SafetySeverity string
+
+const (
+	// SafetyOff disables a check
+	SafetyOff SafetySeverity = "off"
+	// SafetyWarning logs findings and builds the rule
+	SafetyWarning SafetySeverity = "warning"
+	// SafetyError fails the build
+	SafetyError SafetySeverity = "error"
+)
+
+// SafetyConfig sets the severity of the checks for injected instructions
+// that builds run on third-party rules
+type SafetyConfig struct {
+	Checks   map[string]SafetySeverity `yaml:"checks,omitempty"   json:"checks,omitempty"`   // Severity by check name; warning when unset
+	Reviewed []string                  `yaml:"reviewed,omitempty" json:"reviewed,omitempty"` // Rule path globs reviewed and trusted despite findings
 }
 
 // GetEnabledFormats returns only the enabled format configurations for Project
@@ -89,6 +306,31 @@ func (p *Project) GetGeneration() *GenerationConfig {
 	return p.genProvider.GetGeneration()
 }
 
+// CommandSettings returns the settings that make contexture run a program,
+// by their path in the configuration file
+func (p *Project) CommandSettings() []string {
+	var settings []string
+	if p.Generation != nil && p.Generation.Summarize != nil && p.Generation.Summarize.Command != "" {
+		settings = append(settings, "generation.summarize.command")
+	}
+	if p.Sensitive != nil {
+		if p.Sensitive.EncryptCommand != "" {
+			settings = append(settings, "sensitive.encryptCommand")
+		}
+		if p.Sensitive.DecryptCommand != "" {
+			settings = append(settings, "sensitive.decryptCommand")
+		}
+	}
+	return settings
+}
+
+// LocalOverrides are personal changes in .contexture.local.yaml, which is not committed.
+// Rules matching a configured rule override its variables; other rules are added.
+type LocalOverrides struct {
+	Rules   []RuleRef `yaml:"rules,omitempty"   json:"rules,omitempty"`
+	Disable []string  `yaml:"disable,omitempty" json:"disable,omitempty"`
+}
+
 // ConfigLocation represents where configuration is stored
 type ConfigLocation string
 
@@ -97,7 +339,7 @@ const (
 	ConfigLocationRoot ConfigLocation = "root"
 	// ConfigLocationContexture indicates config is stored in .contexture/ directory
 	ConfigLocationContexture ConfigLocation = "contexture"
-	// ConfigLocationGlobal indicates config is stored in global ~/.contexture/ directory
+	// ConfigLocationGlobal indicates config is stored in the global ~/.config/contexture/ directory
 	ConfigLocationGlobal ConfigLocation = "global"
 )
 
@@ -108,6 +350,32 @@ type ConfigResult struct {
 	Path     string         `json:"path"`
 }
 
+// ResolveUserRulesPath returns the file user rules of formatType are written
+// to: the UserRulesPaths override with environment variables and a leading ~
+// expanded, or defaultPath when there is none. Relative overrides are
+// resolved against the home directory.
+func (p *Project) ResolveUserRulesPath(formatType FormatType, defaultPath string) string {
+	if p == nil {
+		return defaultPath
+	}
+	override := os.ExpandEnv(p.UserRulesPaths[string(formatType)])
+	if override == "" {
+		return defaultPath
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Clean(override)
+	}
+	if override == "~" || strings.HasPrefix(override, "~/") {
+		return filepath.Join(homeDir, override[1:])
+	}
+	if !filepath.IsAbs(override) {
+		return filepath.Join(homeDir, override)
+	}
+	return filepath.Clean(override)
+}
+
 // GetConfigFileName returns the config file name
 func GetConfigFileName() string {
 	return ".contexture.yaml"
@@ -156,21 +424,3 @@ type MergedConfig struct {
 	GlobalConfig *Project
 	MergedRules  []RuleWithSource
 }
-
-// GetGlobalConfigDir returns the global contexture directory
-func GetGlobalConfigDir() (string, error) {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return "", err
-	}
-	return filepath.Join(homeDir, ".contexture"), nil
-}
-
-// GetGlobalConfigPath returns the global configuration file path
-func GetGlobalConfigPath() (string, error) {
-	dir, err := GetGlobalConfigDir()
-	if err != nil {
-		return "", err
-	}
-	return filepath.Join(dir, GetConfigFileName()), nil
-}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -143,6 +144,25 @@ func TestProject_GetGeneration(t *testing.T) {
 	})
 }
 
+func TestProject_CommandSettings(t *testing.T) {
+	t.Parallel()
+	assert.Empty(t, (&Project{}).CommandSettings())
+	assert.Empty(t, (&Project{
+		Generation: &GenerationConfig{Summarize: &SummarizeConfig{Strategy: SummarizeOutline}},
+		Sensitive:  &SensitiveConfig{RequireIgnored: true},
+	}).CommandSettings())
+
+	project := &Project{
+		Generation: &GenerationConfig{Summarize: &SummarizeConfig{Strategy: SummarizeCommand, Command: "llm summarize"}},
+		Sensitive:  &SensitiveConfig{EncryptCommand: "age -r age1", DecryptCommand: "age -d"},
+	}
+	assert.Equal(t, []string{
+		"generation.summarize.command",
+		"sensitive.encryptCommand",
+		"sensitive.decryptCommand",
+	}, project.CommandSettings())
+}
+
 func TestGetConfigFileName(t *testing.T) {
 	t.Parallel()
 	assert.Equal(t, ".contexture.yaml", GetConfigFileName())
@@ -188,3 +208,23 @@ func TestGetConfigPath(t *testing.T) {
 		})
 	}
 }
+
+func TestProject_ResolveUserRulesPath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("DOTFILES", "/srv/dotfiles")
+
+	project := &Project{
+		UserRulesPaths: map[string]string{
+			"claude":   "$DOTFILES/claude/CLAUDE.md",
+			"windsurf": "~/sync/windsurf/global_rules.md",
+			"cursor":   "relative/rules.md",
+		},
+	}
+
+	assert.Equal(t, "/srv/dotfiles/claude/CLAUDE.md", project.ResolveUserRulesPath(FormatClaude, "/default"))
+	assert.Equal(t, filepath.Join(home, "sync", "windsurf", "global_rules.md"), project.ResolveUserRulesPath(FormatWindsurf, "/default"))
+	assert.Equal(t, filepath.Join(home, "relative", "rules.md"), project.ResolveUserRulesPath(FormatCursor, "/default"))
+	assert.Equal(t, "/default", (&Project{}).ResolveUserRulesPath(FormatClaude, "/default"))
+	assert.Equal(t, "/default", (*Project)(nil).ResolveUserRulesPath(FormatClaude, "/default"))
+}
@@ -0,0 +1,48 @@
+package domain
+
+// RepositoryIndexFile is the manifest at the root of a rule repository
+const RepositoryIndexFile = "index.yaml"
+
+// RepositoryIndex describes the rules published by a rule repository
+type RepositoryIndex struct {
+	// Name of the repository, used as the suggested provider name
+	Name string `yaml:"name" json:"name" validate:"required"`
+
+	// Description of the repository
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+
+	// Rules published by the repository
+	Rules []RepositoryIndexEntry `yaml:"rules" json:"rules" validate:"dive"`
+
+	// Variables are defaults for the variables every rule of the repository
+	// declares, taking precedence over the rules' frontmatter
+	Variables map[string]any `yaml:"variables,omitempty" json:"variables,omitempty"`
+
+	// Presets are named sets of variable values projects select with presets
+	Presets map[string]RepositoryPreset `yaml:"presets,omitempty" json:"presets,omitempty"`
+}
+
+// RepositoryPreset is a named set of variable values published by a repository
+type RepositoryPreset struct {
+	// Description of the preset
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+
+	// Variables set by the preset on the rules that declare them
+	Variables map[string]any `yaml:"variables" json:"variables"`
+}
+
+// RepositoryIndexEntry lists a single rule in a repository index
+type RepositoryIndexEntry struct {
+	// ID is the rule path relative to the repository root, without the .md extension
+	ID string `yaml:"id" json:"id" validate:"required"`
+
+	// Title of the rule, matching its frontmatter
+	Title string `yaml:"title,omitempty" json:"title,omitempty"`
+
+	// Tags of the rule, matching its frontmatter
+	Tags []string `yaml:"tags,omitempty" json:"tags,omitempty"`
+
+	// Variables are defaults for the rule's variables, taking precedence over
+	// the repository's variables and the rule's frontmatter
+	Variables map[string]any `yaml:"variables,omitempty" json:"variables,omitempty"`
+}
@@ -22,6 +22,16 @@ const (
 	TriggerGlob TriggerType = "glob"
 )
 
+// RuleKind distinguishes context rules from other content generated from rules
+type RuleKind string
+
+const (
+	// RuleKindContext is an instruction included in agent context (the default)
+	RuleKindContext RuleKind = "context"
+	// RuleKindCommand is a reusable prompt generated as an agent slash command
+	RuleKindCommand RuleKind = "command"
+)
+
 // RuleTrigger represents the trigger configuration for a rule
 type RuleTrigger struct {
 	Type  TriggerType `yaml:"type"            json:"type"            validate:"required,oneof=always manual model glob"`
@@ -61,6 +71,23 @@ func (rt *RuleTrigger) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	return nil
 }
 
+// VariableOrigin names where the value of a rule variable comes from
+type VariableOrigin string
+
+// Variable origins, from the lowest precedence
+const (
+	// VariableOriginRule is a default in the rule's frontmatter
+	VariableOriginRule VariableOrigin = "rule"
+	// VariableOriginPreset is a variable preset selected by the configuration
+	VariableOriginPreset VariableOrigin = "preset"
+	// VariableOriginProvider is a default in the index of the rule's repository
+	VariableOriginProvider VariableOrigin = "provider"
+	// VariableOriginProject is the rule's configuration or its ID
+	VariableOriginProject VariableOrigin = "project"
+	// VariableOriginFlag is a --var flag
+	VariableOriginFlag VariableOrigin = "flag"
+)
+
 // Rule represents a contexture rule with all its metadata and content
 type Rule struct {
 	// Core identification
@@ -69,9 +96,23 @@ type Rule struct {
 	Description string   `yaml:"description" json:"description" validate:"required,max=200"`
 	Tags        []string `yaml:"tags"        json:"tags"        validate:"required,min=1,max=10"`
 
+	// Kind of content generated from the rule; empty means RuleKindContext
+	Kind RuleKind `yaml:"kind,omitempty" json:"kind,omitempty" validate:"omitempty,oneof=context command"`
+
 	// Trigger configuration
 	Trigger *RuleTrigger `yaml:"trigger,omitempty" json:"trigger,omitempty"`
 
+	// Priority orders rules in generated outputs: higher priorities come first,
+	// and rules of equal priority are ordered by ID
+	Priority int `yaml:"priority,omitempty" json:"priority,omitempty"`
+
+	// Owner is the team that maintains the rule, such as @acme/security
+	Owner string `yaml:"owner,omitempty" json:"owner,omitempty"`
+
+	// Section is the heading the rule is grouped under in single-file outputs,
+	// set from the rule's configuration
+	Section string `yaml:"-" json:"section,omitempty"`
+
 	// Context information
 	Languages  []string `yaml:"languages,omitempty"  json:"languages,omitempty"`
 	Frameworks []string `yaml:"frameworks,omitempty" json:"frameworks,omitempty"`
@@ -80,11 +121,17 @@ type Rule struct {
 	Content          string         `yaml:"-"                   json:"content"             validate:"required"`
 	Variables        map[string]any `yaml:"variables,omitempty" json:"variables,omitempty"`
 	DefaultVariables map[string]any `yaml:"-"                   json:"defaultVariables,omitempty"`
+	Tests            []RuleTest     `yaml:"tests,omitempty"     json:"tests,omitempty"`
 	FilePath         string         `yaml:"-"                   json:"filePath"`
 	Source           string         `yaml:"-"                   json:"source"`
 	Ref              string         `yaml:"-"                   json:"ref,omitempty"`
+	RepoDir          string         `yaml:"-"                   json:"-"` // Repository directory on disk that relative links resolve against
 	CreatedAt        time.Time      `yaml:"-"                   json:"createdAt,omitempty"`
 	UpdatedAt        time.Time      `yaml:"-"                   json:"updatedAt,omitempty"`
+
+	// VariableOrigins records where variables not set by the rule's
+	// frontmatter come from
+	VariableOrigins map[string]VariableOrigin `yaml:"-" json:"variableOrigins,omitempty"`
 }
 
 // GetDefaultTrigger returns a default trigger for the rule if none is set
@@ -98,6 +145,22 @@ func (r *Rule) GetDefaultTrigger() *RuleTrigger {
 	}
 }
 
+// VariableOrigin returns where the value of the variable name comes from
+func (r *Rule) VariableOrigin(name string) VariableOrigin {
+	if origin, ok := r.VariableOrigins[name]; ok {
+		return origin
+	}
+	if _, declared := r.DefaultVariables[name]; declared {
+		return VariableOriginRule
+	}
+	return VariableOriginProject
+}
+
+// IsCommand reports whether the rule is generated as a slash command
+func (r *Rule) IsCommand() bool {
+	return r.Kind == RuleKindCommand
+}
+
 // HasLanguage checks if the rule applies to a specific language
 func (r *Rule) HasLanguage(language string) bool {
 	for _, lang := range r.Languages {
@@ -152,6 +215,19 @@ type RuleRef struct {
 	Variables  map[string]any `yaml:"variables,omitempty" json:"variables,omitempty"`
 	CommitHash string         `yaml:"commitHash"          json:"commitHash"`
 	Pinned     bool           `yaml:"pinned,omitempty"    json:"pinned,omitempty"`
+	When       string         `yaml:"when,omitempty"      json:"when,omitempty"`        // Expression deciding whether the rule is built
+	Priority   *int           `yaml:"priority,omitempty"  json:"priority,omitempty"`    // Overrides the priority in the rule's frontmatter
+	Section    string         `yaml:"section,omitempty"   json:"section,omitempty"`     // Heading the rule is grouped under in single-file outputs
+	Experiment string         `yaml:"experiment,omitempty" json:"experiment,omitempty"` // Experiment variant the rule belongs to
+	Pending    bool           `yaml:"pending,omitempty"   json:"pending,omitempty"`     // Awaiting approval; builds skip the rule
+	Approval   *RuleApproval  `yaml:"approval,omitempty"  json:"approval,omitempty"`    // Who approved the rule and when
+	Inherited  bool           `yaml:"-"                   json:"inherited,omitempty"`   // Runtime flag: true when merged from an extended config
+}
+
+// RuleApproval records the approval of a rule that needed one
+type RuleApproval struct {
+	By string    `yaml:"by" json:"by"`
+	At time.Time `yaml:"at" json:"at"`
 }
 
 // UnmarshalYAML implements custom YAML unmarshaling for RuleRef.
@@ -210,6 +286,27 @@ type ParsedRuleID struct {
 	Variables map[string]any `json:"variables,omitempty"`
 }
 
+// RuleChange describes the last commit that changed a rule
+type RuleChange struct {
+	Hash   string `json:"hash"`
+	Date   string `json:"date"`
+	Author string `json:"author,omitempty"`
+}
+
+// RuleTest declares assertions about a rule's rendered content for a set of variables
+type RuleTest struct {
+	Name        string         `yaml:"name"                   json:"name"`
+	Variables   map[string]any `yaml:"variables,omitempty"    json:"variables,omitempty"`
+	Contains    []string       `yaml:"contains,omitempty"     json:"contains,omitempty"`
+	NotContains []string       `yaml:"not_contains,omitempty" json:"notContains,omitempty"`
+}
+
+// RuleTestFile is a file in .contexture/tests/ declaring tests for a rule
+type RuleTestFile struct {
+	Rule  string     `yaml:"rule"`
+	Tests []RuleTest `yaml:"tests"`
+}
+
 // RuleContext represents the context for rule processing
 type RuleContext struct {
 	Variables map[string]any `json:"variables"`
@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+)
+
+// FormatSectionDirective opens a section of rule content that is only
+// generated for the formats listed after it, for example ":::only cursor windsurf".
+// A line containing only ":::" closes the section.
+const FormatSectionDirective = ":::only"
+
+// formatSectionEnd closes a format section
+const formatSectionEnd = ":::"
+
+// knownFormatTypes are the formats a section can be limited to
+var knownFormatTypes = []FormatType{FormatClaude, FormatCursor, FormatWindsurf, FormatCopilot, FormatGemini}
+
+// FilterFormatSections returns content with the sections limited to other
+// formats removed and the section directives stripped. Directives inside
+// fenced code blocks are left as written.
+func FilterFormatSections(content string, formatType FormatType) (string, error) {
+	if !strings.Contains(content, FormatSectionDirective) {
+		return content, nil
+	}
+
+	lines := strings.Split(content, "\n")
+	kept := make([]string, 0, len(lines))
+	inFence := false
+	// section holds the formats of the open section, or nil outside a section
+	var section []FormatType
+	sectionStart := 0
+	afterDirective := false
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+		}
+
+		if !inFence {
+			if formats, ok := strings.CutPrefix(trimmed, FormatSectionDirective); ok &&
+				(formats == "" || formats[0] == ' ' || formats[0] == '\t') {
+				if section != nil {
+					return "", sectionError(i, "%s inside the section opened on line %d", FormatSectionDirective, sectionStart+1)
+				}
+				parsed, err := parseSectionFormats(formats)
+				if err != nil {
+					return "", sectionError(i, "%v", err)
+				}
+				section = parsed
+				sectionStart = i
+				afterDirective = true
+				continue
+			}
+			if trimmed == formatSectionEnd && section != nil {
+				section = nil
+				afterDirective = true
+				continue
+			}
+		}
+
+		if section != nil && !slices.Contains(section, formatType) {
+			continue
+		}
+
+		// Avoid doubled blank lines where a directive or dropped section was
+		if afterDirective && trimmed == "" && (len(kept) == 0 || strings.TrimSpace(kept[len(kept)-1]) == "") {
+			continue
+		}
+		afterDirective = false
+		kept = append(kept, line)
+	}
+
+	if section != nil {
+		return "", sectionError(sectionStart, "%s section is not closed with %s", FormatSectionDirective, formatSectionEnd)
+	}
+	return strings.Join(kept, "\n"), nil
+}
+
+// ValidateFormatSections reports malformed format sections in content
+func ValidateFormatSections(content string) error {
+	_, err := FilterFormatSections(content, "")
+	return err
+}
+
+// parseSectionFormats parses the format list of a section directive
+func parseSectionFormats(list string) ([]FormatType, error) {
+	fields := strings.FieldsFunc(list, func(r rune) bool {
+		return r == ' ' || r == '\t' || r == ','
+	})
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("%s requires at least one format", FormatSectionDirective)
+	}
+
+	formats := make([]FormatType, 0, len(fields))
+	for _, field := range fields {
+		formatType := FormatType(strings.ToLower(field))
+		if !slices.Contains(knownFormatTypes, formatType) {
+			return nil, fmt.Errorf("unknown format %q in %s", field, FormatSectionDirective)
+		}
+		formats = append(formats, formatType)
+	}
+	return formats, nil
+}
+
+// sectionError reports a format section problem at the zero-based line index
+func sectionError(index int, format string, args ...any) error {
+	return contextureerrors.ValidationErrorf("content", "line %d: %s", index+1, fmt.Sprintf(format, args...))
+}
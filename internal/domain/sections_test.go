@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sectionedContent = "Use small functions.\n\n" +
+	":::only cursor windsurf\n" +
+	"Apply this rule when editing files in the IDE.\n" +
+	":::\n\n" +
+	":::only claude\n" +
+	"Run `go test ./...` before finishing.\n" +
+	":::\n\n" +
+	"```markdown\n:::only cursor\n```\n"
+
+func TestFilterFormatSections(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		formatType FormatType
+		expected   string
+	}{
+		{
+			formatType: FormatClaude,
+			expected:   "Use small functions.\n\nRun `go test ./...` before finishing.\n\n```markdown\n:::only cursor\n```\n",
+		},
+		{
+			formatType: FormatCursor,
+			expected:   "Use small functions.\n\nApply this rule when editing files in the IDE.\n\n```markdown\n:::only cursor\n```\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.formatType), func(t *testing.T) {
+			t.Parallel()
+			filtered, err := FilterFormatSections(sectionedContent, tt.formatType)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, filtered)
+		})
+	}
+}
+
+func TestFilterFormatSections_NoSections(t *testing.T) {
+	t.Parallel()
+	content := "Plain content\n:::\n"
+	filtered, err := FilterFormatSections(content, FormatClaude)
+	require.NoError(t, err)
+	assert.Equal(t, content, filtered)
+}
+
+func TestValidateFormatSections(t *testing.T) {
+	t.Parallel()
+	require.NoError(t, ValidateFormatSections(sectionedContent))
+
+	tests := []struct {
+		name    string
+		content string
+		errMsg  string
+	}{
+		{"unclosed", "a\n:::only claude\nb\n", "line 2: :::only section is not closed"},
+		{"nested", ":::only claude\n:::only cursor\n:::\n", "line 2"},
+		{"unknown format", ":::only vscode\nb\n:::\n", `unknown format "vscode"`},
+		{"no formats", ":::only\nb\n:::\n", "at least one format"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := ValidateFormatSections(tt.content)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
@@ -31,8 +31,16 @@ const (
 	ExitValidation
 	// ExitFormat indicates format error
 	ExitFormat
+	// ExitAuthError indicates an authentication or authorization failure
+	ExitAuthError
+	// ExitPartialSuccess indicates the operation completed for some items but failed for others
+	ExitPartialSuccess
 )
 
+// ExitInterrupted indicates the command was stopped by SIGINT or SIGTERM,
+// following the shell convention of 128 plus the signal number of SIGINT
+const ExitInterrupted ErrorCode = 130
+
 // Error represents a unified error with user-friendly messaging
 type Error struct {
 	// Core error information
@@ -42,9 +50,9 @@ type Error struct {
 	Err  error     // Underlying error
 
 	// User-friendly information
-	Message     string   // User-friendly message
-	Suggestions []string // Helpful suggestions
-	Field       string   // Field name for validation errors
+	Message     string       // User-friendly message
+	Suggestions []Suggestion // Actionable suggestions
+	Field       string       // Field name for validation errors
 }
 
 // ErrorKind represents the category of error
@@ -71,6 +79,12 @@ const (
 	KindTimeout
 	// KindCanceled represents canceled operation errors
 	KindCanceled
+	// KindAuth represents authentication errors
+	KindAuth
+	// KindUsage represents incorrect command usage
+	KindUsage
+	// KindPartial represents operations that only partially succeeded
+	KindPartial
 )
 
 // Error implements the error interface
@@ -123,6 +137,12 @@ func (e *Error) ExitCode() int {
 		return int(ExitConfigError)
 	case KindFormat:
 		return int(ExitFormat)
+	case KindAuth:
+		return int(ExitAuthError)
+	case KindUsage:
+		return int(ExitUsageError)
+	case KindPartial:
+		return int(ExitPartialSuccess)
 	case KindOther, KindRepository, KindCanceled:
 		return int(ExitError)
 	default:
@@ -136,7 +156,7 @@ func (e *Error) IsRetryable() bool {
 }
 
 // WithSuggestions adds suggestions to the error
-func (e *Error) WithSuggestions(suggestions ...string) *Error {
+func (e *Error) WithSuggestions(suggestions ...Suggestion) *Error {
 	e.Suggestions = append(e.Suggestions, suggestions...)
 	return e
 }
@@ -162,6 +182,12 @@ func (e *Error) kindString() string {
 		return "timeout"
 	case KindCanceled:
 		return "canceled"
+	case KindAuth:
+		return "authentication failed"
+	case KindUsage:
+		return "usage error"
+	case KindPartial:
+		return "partial failure"
 	case KindOther:
 		return "other"
 	default:
@@ -203,15 +229,69 @@ func Validation(field, message string) *Error {
 	}
 }
 
-// detectKind attempts to detect the error kind from the error message
+// Partial creates an error for an operation that succeeded for some items but failed for others
+func Partial(op string, err error) *Error {
+	return &Error{
+		Op:   op,
+		Kind: KindPartial,
+		Code: ExitPartialSuccess,
+		Err:  err,
+	}
+}
+
+// ExitCodeFor returns the process exit code for err. An explicit code set anywhere
+// in the error chain takes precedence; otherwise the outermost error kind decides.
+func ExitCodeFor(err error) int {
+	if err == nil {
+		return int(ExitSuccess)
+	}
+
+	for current := err; current != nil; current = errors.Unwrap(current) {
+		if e, ok := current.(*Error); ok && e.Code != 0 {
+			return int(e.Code)
+		}
+	}
+
+	var e *Error
+	if !errors.As(err, &e) {
+		e = Wrap(err, "")
+	}
+	return e.ExitCode()
+}
+
+// detectKind attempts to detect the error kind from sentinel errors and the error message
 func detectKind(err error) ErrorKind {
 	if err == nil {
 		return KindOther
 	}
 
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, ErrCanceled):
+		return KindCanceled
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, ErrTimeout):
+		return KindTimeout
+	case errors.Is(err, ErrAuthenticationFailed):
+		return KindAuth
+	case errors.Is(err, os.ErrPermission), errors.Is(err, ErrPermissionDenied):
+		return KindPermission
+	case errors.Is(err, ErrConfigNotFound):
+		return KindConfig
+	case errors.Is(err, os.ErrNotExist), errors.Is(err, ErrNotFound):
+		return KindNotFound
+	}
+
 	msg := strings.ToLower(err.Error())
 
 	switch {
+	// Hosts reject rate limited requests as forbidden, so check before authorization
+	case strings.Contains(msg, "rate limit"):
+		return KindNetwork
+	case strings.Contains(msg, "authentication") || strings.Contains(msg, "unauthorized") ||
+		strings.Contains(msg, "authorization failed"):
+		return KindAuth
+	case strings.Contains(msg, "flag provided but not defined") ||
+		strings.Contains(msg, "required flag") || strings.Contains(msg, "no help topic"):
+		return KindUsage
 	case strings.Contains(msg, "not found"):
 		return KindNotFound
 	case strings.Contains(msg, "validation") || strings.Contains(msg, "invalid"):
@@ -260,10 +340,10 @@ func Display(err error) {
 	// Display main error message
 	fmt.Fprintf(os.Stderr, "%sError:%s %s\n", errorColor, resetColor, e.Error())
 
-	// Display suggestions if available
-	if len(e.Suggestions) > 0 {
+	// Display suggestions from anywhere in the error chain
+	if suggestions := SuggestionsFor(err); len(suggestions) > 0 {
 		fmt.Fprintf(os.Stderr, "\n%sSuggestions:%s\n", suggestionColor, resetColor)
-		for _, suggestion := range e.Suggestions {
+		for _, suggestion := range suggestions {
 			fmt.Fprintf(os.Stderr, "  • %s\n", suggestion)
 		}
 	}
@@ -351,6 +431,7 @@ func IsRetryable(err error) bool {
 		"network is unreachable",
 		"no route to host",
 		"deadline exceeded",
+		"rate limit",
 	}
 
 	for _, condition := range retryableConditions {
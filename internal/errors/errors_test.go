@@ -470,6 +470,27 @@ func TestError_ExitCode(t *testing.T) {
 			},
 			expected: int(ExitError),
 		},
+		{
+			name: "KindAuth",
+			err: &Error{
+				Kind: KindAuth,
+			},
+			expected: int(ExitAuthError),
+		},
+		{
+			name: "KindUsage",
+			err: &Error{
+				Kind: KindUsage,
+			},
+			expected: int(ExitUsageError),
+		},
+		{
+			name: "KindPartial",
+			err: &Error{
+				Kind: KindPartial,
+			},
+			expected: int(ExitPartialSuccess),
+		},
 		{
 			name: "unknown kind",
 			err: &Error{
@@ -495,13 +516,17 @@ func TestError_WithSuggestions(t *testing.T) {
 	}
 
 	// Test adding single suggestion
-	result := err.WithSuggestions("try again")
-	assert.Equal(t, []string{"try again"}, result.Suggestions)
+	result := err.WithSuggestions(Hint("try again"))
+	assert.Equal(t, []Suggestion{Hint("try again")}, result.Suggestions)
 	assert.Same(t, err, result) // Should return same instance
 
 	// Test adding multiple suggestions
-	result = err.WithSuggestions("check input", "verify config")
-	assert.Equal(t, []string{"try again", "check input", "verify config"}, result.Suggestions)
+	result = err.WithSuggestions(RunCommand("contexture validate", "check input"), CheckConfig("formats", ""))
+	assert.Equal(t, []Suggestion{
+		Hint("try again"),
+		RunCommand("contexture validate", "check input"),
+		CheckConfig("formats", ""),
+	}, result.Suggestions)
 }
 
 func TestError_kindString(t *testing.T) {
@@ -556,6 +581,21 @@ func TestError_kindString(t *testing.T) {
 			kind:     KindCanceled,
 			expected: "canceled",
 		},
+		{
+			name:     "KindAuth",
+			kind:     KindAuth,
+			expected: "authentication failed",
+		},
+		{
+			name:     "KindUsage",
+			kind:     KindUsage,
+			expected: "usage error",
+		},
+		{
+			name:     "KindPartial",
+			kind:     KindPartial,
+			expected: "partial failure",
+		},
 		{
 			name:     "KindOther",
 			kind:     KindOther,
@@ -634,6 +674,88 @@ func TestWrap(t *testing.T) {
 	}
 }
 
+func TestExitCodeFor(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{
+			name:     "nil error",
+			err:      nil,
+			expected: int(ExitSuccess),
+		},
+		{
+			name:     "plain error",
+			err:      fmt.Errorf("something broke"),
+			expected: int(ExitError),
+		},
+		{
+			name:     "explicit code deep in chain",
+			err:      Wrap(Wrap(Validation("name", "required"), "load"), "init"),
+			expected: int(ExitValidation),
+		},
+		{
+			name:     "outer explicit code wins",
+			err:      &Error{Code: ExitConfigError, Err: Validation("name", "required")},
+			expected: int(ExitConfigError),
+		},
+		{
+			name:     "wrapped sentinel authentication error",
+			err:      fmt.Errorf("clone: %w", ErrAuthenticationFailed),
+			expected: int(ExitAuthError),
+		},
+		{
+			name:     "wrapped permission error",
+			err:      Wrap(fmt.Errorf("write: %w", os.ErrPermission), "save"),
+			expected: int(ExitPermError),
+		},
+		{
+			name:     "deadline exceeded",
+			err:      Wrap(context.DeadlineExceeded, "fetch"),
+			expected: int(ExitNetworkError),
+		},
+		{
+			name:     "config not found",
+			err:      fmt.Errorf("load: %w", ErrConfigNotFound),
+			expected: int(ExitConfigError),
+		},
+		{
+			name:     "unknown flag",
+			err:      fmt.Errorf("flag provided but not defined: -bogus"),
+			expected: int(ExitUsageError),
+		},
+		{
+			name:     "partial success",
+			err:      Partial("update rules", fmt.Errorf("1 of 3 rule update(s) failed")),
+			expected: int(ExitPartialSuccess),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ExitCodeFor(tt.err))
+		})
+	}
+}
+
+func TestDetectKind_Auth(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, KindAuth, detectKind(fmt.Errorf("authentication required")))
+	assert.Equal(t, KindAuth, detectKind(fmt.Errorf("remote: 401 Unauthorized")))
+	// Authentication failures take precedence over "not found" wording
+	assert.Equal(t, KindAuth, detectKind(fmt.Errorf("authentication required: repository not found")))
+}
+
+func TestDetectKind_RateLimit(t *testing.T) {
+	t.Parallel()
+	// Rate limits take precedence over the authorization failure hosts report them as
+	assert.Equal(t, KindNetwork, detectKind(fmt.Errorf("authorization failed: API rate limit exceeded")))
+	assert.Equal(t, KindNetwork, detectKind(fmt.Errorf("rate limit exceeded for github.com")))
+	assert.True(t, IsRetryable(fmt.Errorf("rate limit exceeded for github.com")))
+}
+
 func TestValidation(t *testing.T) {
 	t.Parallel()
 	result := Validation("email", "invalid format")
@@ -775,7 +897,7 @@ func TestDisplay(t *testing.T) {
 			err: &Error{
 				Message:     "validation failed",
 				Kind:        KindValidation,
-				Suggestions: []string{"Check your input", "Try again"},
+				Suggestions: []Suggestion{Hint("Check your input"), Hint("Try again")},
 			},
 			termEnv:            "",
 			expectError:        true,
@@ -789,7 +911,7 @@ func TestDisplay(t *testing.T) {
 			err: &Error{
 				Message:     "validation failed",
 				Kind:        KindValidation,
-				Suggestions: []string{"Check your input"},
+				Suggestions: []Suggestion{Hint("Check your input")},
 			},
 			termEnv:           "xterm-256color",
 			expectError:       true,
@@ -903,7 +1025,7 @@ func TestDisplay_WithComplexError(t *testing.T) {
 		Kind:        KindNetwork,
 		Message:     "failed to fetch rule",
 		Err:         baseErr,
-		Suggestions: []string{"Check your network connection", "Verify the repository URL"},
+		Suggestions: []Suggestion{Hint("Check your network connection"), Hint("Verify the repository URL")},
 	}
 
 	Display(wrappedErr)
@@ -0,0 +1,132 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// SuggestionKind identifies what a suggestion asks the user to do
+type SuggestionKind string
+
+const (
+	// SuggestionCommand asks the user to run a command
+	SuggestionCommand SuggestionKind = "command"
+	// SuggestionConfig asks the user to check a configuration key
+	SuggestionConfig SuggestionKind = "config"
+	// SuggestionHint is free-form advice
+	SuggestionHint SuggestionKind = "hint"
+)
+
+// Suggestion is a structured, actionable hint attached to an error
+type Suggestion struct {
+	Kind        SuggestionKind `json:"kind"`
+	Description string         `json:"description,omitempty"`
+	Command     string         `json:"command,omitempty"`
+	ConfigKey   string         `json:"configKey,omitempty"`
+}
+
+// RunCommand suggests running command to achieve description
+func RunCommand(command, description string) Suggestion {
+	return Suggestion{Kind: SuggestionCommand, Command: command, Description: description}
+}
+
+// CheckConfig suggests checking the configuration key for the reason in description
+func CheckConfig(key, description string) Suggestion {
+	return Suggestion{Kind: SuggestionConfig, ConfigKey: key, Description: description}
+}
+
+// Hint creates a free-form suggestion
+func Hint(description string) Suggestion {
+	return Suggestion{Kind: SuggestionHint, Description: description}
+}
+
+// String renders the suggestion as a single line of text
+func (s Suggestion) String() string {
+	switch s.Kind {
+	case SuggestionCommand:
+		if s.Description == "" {
+			return fmt.Sprintf("Run '%s'", s.Command)
+		}
+		return fmt.Sprintf("Run '%s' to %s", s.Command, s.Description)
+	case SuggestionConfig:
+		if s.Description == "" {
+			return fmt.Sprintf("Check '%s' in your configuration", s.ConfigKey)
+		}
+		return fmt.Sprintf("Check '%s' in your configuration: %s", s.ConfigKey, s.Description)
+	case SuggestionHint:
+		return s.Description
+	default:
+		return s.Description
+	}
+}
+
+// SuggestionsFor collects the suggestions attached anywhere in the error chain,
+// outermost first, skipping duplicates
+func SuggestionsFor(err error) []Suggestion {
+	var suggestions []Suggestion
+	seen := make(map[Suggestion]bool)
+
+	for current := err; current != nil; current = errors.Unwrap(current) {
+		e, ok := current.(*Error)
+		if !ok {
+			continue
+		}
+		for _, s := range e.Suggestions {
+			if !seen[s] {
+				seen[s] = true
+				suggestions = append(suggestions, s)
+			}
+		}
+	}
+
+	return suggestions
+}
+
+// Report is the machine-readable representation of an error
+type Report struct {
+	Message     string       `json:"message"`
+	Kind        string       `json:"kind"`
+	ExitCode    int          `json:"exitCode"`
+	Field       string       `json:"field,omitempty"`
+	Suggestions []Suggestion `json:"suggestions"`
+}
+
+// NewReport builds a report for err, or returns nil if err is nil
+func NewReport(err error) *Report {
+	if err == nil {
+		return nil
+	}
+
+	var e *Error
+	if !errors.As(err, &e) {
+		e = Wrap(err, "")
+	}
+
+	suggestions := SuggestionsFor(err)
+	if suggestions == nil {
+		suggestions = []Suggestion{}
+	}
+
+	return &Report{
+		Message:     err.Error(),
+		Kind:        e.kindString(),
+		ExitCode:    ExitCodeFor(err),
+		Field:       e.Field,
+		Suggestions: suggestions,
+	}
+}
+
+// DisplayJSON writes the error report for err to w as JSON
+func DisplayJSON(w io.Writer, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(struct {
+		Error *Report `json:"error"`
+	}{Error: NewReport(err)})
+}
@@ -0,0 +1,124 @@
+package errors
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuggestion_String(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		suggestion Suggestion
+		expected   string
+	}{
+		{
+			name:       "command with description",
+			suggestion: RunCommand("contexture init", "create a project configuration"),
+			expected:   "Run 'contexture init' to create a project configuration",
+		},
+		{
+			name:       "command without description",
+			suggestion: RunCommand("contexture build", ""),
+			expected:   "Run 'contexture build'",
+		},
+		{
+			name:       "config key with description",
+			suggestion: CheckConfig("providers", "the provider must be defined"),
+			expected:   "Check 'providers' in your configuration: the provider must be defined",
+		},
+		{
+			name:       "config key without description",
+			suggestion: CheckConfig("formats", ""),
+			expected:   "Check 'formats' in your configuration",
+		},
+		{
+			name:       "hint",
+			suggestion: Hint("Check your network connection"),
+			expected:   "Check your network connection",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, tt.suggestion.String())
+		})
+	}
+}
+
+func TestSuggestionsFor(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, SuggestionsFor(nil))
+	assert.Nil(t, SuggestionsFor(fmt.Errorf("plain error")))
+
+	inner := Wrap(fmt.Errorf("no such file"), "load config").
+		WithSuggestions(RunCommand("contexture init", "create a project configuration"))
+	outer := Wrap(inner, "build").
+		WithSuggestions(Hint("Check the current directory"), RunCommand("contexture init", "create a project configuration"))
+	wrapped := fmt.Errorf("command failed: %w", outer)
+
+	assert.Equal(t, []Suggestion{
+		Hint("Check the current directory"),
+		RunCommand("contexture init", "create a project configuration"),
+	}, SuggestionsFor(wrapped))
+}
+
+func TestNewReport(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, NewReport(nil))
+
+	err := Wrap(Validation("formats", "unsupported format"), "validate").
+		WithSuggestions(CheckConfig("formats", ""))
+	report := NewReport(err)
+	require.NotNil(t, report)
+	assert.Equal(t, err.Error(), report.Message)
+	assert.Equal(t, "validation error", report.Kind)
+	assert.Equal(t, int(ExitValidation), report.ExitCode)
+	assert.Equal(t, []Suggestion{CheckConfig("formats", "")}, report.Suggestions)
+
+	plain := NewReport(fmt.Errorf("something broke"))
+	require.NotNil(t, plain)
+	assert.Equal(t, int(ExitError), plain.ExitCode)
+	assert.NotNil(t, plain.Suggestions)
+}
+
+func TestDisplayJSON(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, DisplayJSON(&buf, nil))
+	assert.Empty(t, buf.String())
+
+	err := Wrap(ErrConfigNotFound, "load project configuration").
+		WithSuggestions(RunCommand("contexture init", "create a project configuration"))
+	require.NoError(t, DisplayJSON(&buf, err))
+
+	var decoded struct {
+		Error struct {
+			Message     string `json:"message"`
+			Kind        string `json:"kind"`
+			ExitCode    int    `json:"exitCode"`
+			Suggestions []struct {
+				Kind        string `json:"kind"`
+				Command     string `json:"command"`
+				Description string `json:"description"`
+			} `json:"suggestions"`
+		} `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+
+	assert.Equal(t, "load project configuration: configuration not found", decoded.Error.Message)
+	assert.Equal(t, "configuration error", decoded.Error.Kind)
+	assert.Equal(t, int(ExitConfigError), decoded.Error.ExitCode)
+	require.Len(t, decoded.Error.Suggestions, 1)
+	assert.Equal(t, "command", decoded.Error.Suggestions[0].Kind)
+	assert.Equal(t, "contexture init", decoded.Error.Suggestions[0].Command)
+}
@@ -0,0 +1,127 @@
+// Package experiment selects the active variants of rule experiments and
+// records them in the build manifest, so teams can compare how alternative
+// instructions perform
+package experiment
+
+import (
+	"encoding/json"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/staging"
+	"github.com/spf13/afero"
+)
+
+// Env selects experiment variants, comma-separated. Variants passed with
+// --experiment take precedence.
+const Env = "CONTEXTURE_EXPERIMENT"
+
+// ManifestFile is the build manifest in the .contexture directory of a project
+const ManifestFile = "build-manifest.json"
+
+// ParseEnv splits the value of Env into variant names
+func ParseEnv(value string) []string {
+	var variants []string
+	for variant := range strings.SplitSeq(value, ",") {
+		if variant = strings.TrimSpace(variant); variant != "" {
+			variants = append(variants, variant)
+		}
+	}
+	return variants
+}
+
+// Resolve returns the active variant of each experiment: the last selected
+// variant of the experiment, else its default. Experiments without either
+// are left out, so none of their variants are built.
+func Resolve(experiments map[string]domain.Experiment, selected []string) (map[string]string, error) {
+	owners := make(map[string]string)
+	active := make(map[string]string)
+	for name, experiment := range experiments {
+		for _, variant := range experiment.Variants {
+			owners[variant] = name
+		}
+		if experiment.Default != "" {
+			active[name] = experiment.Default
+		}
+	}
+
+	for _, variant := range selected {
+		name, ok := owners[variant]
+		if !ok {
+			err := contextureerrors.Validation("experiment", "unknown experiment variant: "+variant)
+			if len(owners) == 0 {
+				return nil, err.WithSuggestions(contextureerrors.Hint("declare experiments in the project configuration"))
+			}
+			return nil, err.WithSuggestions(contextureerrors.Hint(
+				"available variants: " + strings.Join(slices.Sorted(maps.Keys(owners)), ", ")))
+		}
+		active[name] = variant
+	}
+	return active, nil
+}
+
+// Filter splits rules into those outside experiments or in an active
+// variant, and those in an inactive variant
+func Filter(rules []domain.RuleRef, active map[string]string) ([]domain.RuleRef, []domain.RuleRef) {
+	variants := slices.Collect(maps.Values(active))
+	var kept, skipped []domain.RuleRef
+	for _, ref := range rules {
+		if ref.Experiment == "" || slices.Contains(variants, ref.Experiment) {
+			kept = append(kept, ref)
+		} else {
+			skipped = append(skipped, ref)
+		}
+	}
+	return kept, skipped
+}
+
+// Manifest records what a build generated, so the outputs an assistant saw
+// can be matched to the experiment variants that produced them
+type Manifest struct {
+	BuiltAt time.Time `json:"builtAt"`
+	// Experiments maps each experiment to its active variant, or to an empty
+	// string when none was built
+	Experiments map[string]string `json:"experiments"`
+	// Rules are the IDs of the rules built
+	Rules []string `json:"rules"`
+}
+
+// NewManifest returns the manifest of a build of rules with the active
+// variants of experiments
+func NewManifest(experiments map[string]domain.Experiment, active map[string]string, rules []domain.RuleRef) *Manifest {
+	manifest := &Manifest{
+		BuiltAt:     time.Now().UTC(),
+		Experiments: make(map[string]string, len(experiments)),
+		Rules:       make([]string, 0, len(rules)),
+	}
+	for name := range experiments {
+		manifest.Experiments[name] = active[name]
+	}
+	for _, ref := range rules {
+		manifest.Rules = append(manifest.Rules, ref.ID)
+	}
+	return manifest
+}
+
+// WriteManifest writes manifest to ManifestFile in the .contexture directory of
+// projectDir, replacing the previous manifest atomically
+func WriteManifest(fs afero.Fs, projectDir string, manifest *Manifest) (string, error) {
+	dir := filepath.Join(projectDir, domain.ContextureDir)
+	path := filepath.Join(dir, ManifestFile)
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return path, contextureerrors.Wrap(err, "encode build manifest")
+	}
+	if err := fs.MkdirAll(dir, 0o755); err != nil {
+		return path, contextureerrors.Wrap(err, "create .contexture directory")
+	}
+	if err := staging.WriteFile(fs, path, append(data, '\n'), 0o644); err != nil {
+		return path, contextureerrors.Wrap(err, "write build manifest")
+	}
+	return path, nil
+}
@@ -0,0 +1,100 @@
+package experiment
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testExperiments = map[string]domain.Experiment{
+	"retrieval-style": {Variants: []string{"retrieval-style-a", "retrieval-style-b"}, Default: "retrieval-style-a"},
+	"tone":            {Variants: []string{"tone-terse", "tone-friendly"}},
+}
+
+func TestParseEnv(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, []string{"retrieval-style-b", "tone-terse"}, ParseEnv(" retrieval-style-b, ,tone-terse "))
+	assert.Nil(t, ParseEnv(""))
+}
+
+func TestResolve(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		selected []string
+		want     map[string]string
+		wantErr  bool
+	}{
+		{
+			name: "defaults",
+			want: map[string]string{"retrieval-style": "retrieval-style-a"},
+		},
+		{
+			name:     "selection overrides the default",
+			selected: []string{"retrieval-style-b", "tone-friendly"},
+			want:     map[string]string{"retrieval-style": "retrieval-style-b", "tone": "tone-friendly"},
+		},
+		{
+			name:     "last selection wins",
+			selected: []string{"tone-terse", "tone-friendly"},
+			want:     map[string]string{"retrieval-style": "retrieval-style-a", "tone": "tone-friendly"},
+		},
+		{
+			name:     "unknown variant",
+			selected: []string{"retrieval-style-c"},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			active, err := Resolve(testExperiments, tt.selected)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "unknown experiment variant")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, active)
+		})
+	}
+}
+
+func TestFilter(t *testing.T) {
+	t.Parallel()
+	rules := []domain.RuleRef{
+		{ID: "[contexture:go/style]"},
+		{ID: "[contexture:retrieval/a]", Experiment: "retrieval-style-a"},
+		{ID: "[contexture:retrieval/b]", Experiment: "retrieval-style-b"},
+		{ID: "[contexture:tone/terse]", Experiment: "tone-terse"},
+	}
+
+	kept, skipped := Filter(rules, map[string]string{"retrieval-style": "retrieval-style-b"})
+	assert.Equal(t, []domain.RuleRef{rules[0], rules[2]}, kept)
+	assert.Equal(t, []domain.RuleRef{rules[1], rules[3]}, skipped)
+}
+
+func TestWriteManifest(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	active := map[string]string{"retrieval-style": "retrieval-style-b"}
+	rules := []domain.RuleRef{{ID: "[contexture:go/style]"}, {ID: "[contexture:retrieval/b]", Experiment: "retrieval-style-b"}}
+
+	path, err := WriteManifest(fs, "/work/app", NewManifest(testExperiments, active, rules))
+	require.NoError(t, err)
+	assert.Equal(t, "/work/app/.contexture/build-manifest.json", path)
+
+	data, err := afero.ReadFile(fs, path)
+	require.NoError(t, err)
+	var manifest Manifest
+	require.NoError(t, json.Unmarshal(data, &manifest))
+	assert.Equal(t, map[string]string{"retrieval-style": "retrieval-style-b", "tone": ""}, manifest.Experiments)
+	assert.Equal(t, []string{"[contexture:go/style]", "[contexture:retrieval/b]"}, manifest.Rules)
+	assert.False(t, manifest.BuiltAt.IsZero())
+}
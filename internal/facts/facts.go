@@ -0,0 +1,322 @@
+// Package facts detects the build and test commands, directory layout, and
+// tooling of a repository and renders them as a local rule
+package facts
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/contextureai/contexture/internal/condition"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/generated"
+	"github.com/spf13/afero"
+)
+
+// RuleFile is the file name of the rule in the local rules directory
+const RuleFile = "project-facts.md"
+
+// rule is the rule written by contexture generate env-rule
+var rule = generated.Rule{Command: "env-rule", File: RuleFile}
+
+// GeneratedMarker identifies a rule written by Render, so it is only
+// refreshed while it is machine-maintained
+var GeneratedMarker = generated.Marker(rule.Command)
+
+// Command is a command that builds, tests, or otherwise works on the project
+type Command struct {
+	// Run is the command line, such as make test or npm run lint
+	Run string
+	// Description says what the command does, when the source documents it
+	Description string
+}
+
+// Facts describes a repository
+type Facts struct {
+	Name      string
+	Languages []string
+	// GoModule and GoVersion are read from go.mod
+	GoModule  string
+	GoVersion string
+	// PackageName and PackageManager describe package.json
+	PackageName    string
+	PackageManager string
+	Commands       []Command
+	// Dirs are the top-level directories, without hidden and dependency directories
+	Dirs []string
+	// Tools are the tools whose configuration files the repository contains
+	Tools []Tool
+}
+
+// Tool is a tool the repository is configured for
+type Tool struct {
+	Name string
+	File string
+}
+
+// toolMarkers maps configuration files to the tool they configure
+var toolMarkers = []Tool{
+	{"golangci-lint", ".golangci.yml"},
+	{"golangci-lint", ".golangci.yaml"},
+	{"GoReleaser", ".goreleaser.yml"},
+	{"GoReleaser", ".goreleaser.yaml"},
+	{"GitHub Actions", ".github/workflows"},
+	{"GitLab CI", ".gitlab-ci.yml"},
+	{"Docker", "Dockerfile"},
+	{"Docker Compose", "docker-compose.yml"},
+	{"Docker Compose", "compose.yaml"},
+	{"pre-commit", ".pre-commit-config.yaml"},
+	{"ESLint", "eslint.config.js"},
+	{"ESLint", ".eslintrc.json"},
+	{"Prettier", ".prettierrc"},
+	{"TypeScript", "tsconfig.json"},
+	{"Ruff", "ruff.toml"},
+	{"Tox", "tox.ini"},
+	{"Just", "justfile"},
+	{"Task", "Taskfile.yml"},
+}
+
+// skippedDirs are top-level directories left out of the layout
+var skippedDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+	"dist":         true,
+	"build":        true,
+	"target":       true,
+	"bin":          true,
+	"__pycache__":  true,
+}
+
+// makeTargetPattern matches a make target with an optional ## description
+var makeTargetPattern = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9_./-]*)\s*:([^=].*)?$`)
+
+// Detect reads the facts of the repository in dir
+func Detect(fs afero.Fs, dir string) (*Facts, error) {
+	facts := &Facts{
+		Name:      filepath.Base(dir),
+		Languages: condition.Detect(fs, dir, nil).Project.Languages,
+	}
+
+	if err := facts.readGoMod(fs, dir); err != nil {
+		return nil, err
+	}
+	if err := facts.readMakefile(fs, dir); err != nil {
+		return nil, err
+	}
+	if err := facts.readPackageJSON(fs, dir); err != nil {
+		return nil, err
+	}
+	if facts.GoModule != "" && !facts.hasCommand("test") {
+		facts.Commands = append(facts.Commands,
+			Command{Run: "go build ./...", Description: "Build all packages"},
+			Command{Run: "go test ./...", Description: "Run the tests"})
+	}
+
+	entries, err := afero.ReadDir(fs, dir)
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "read project directory")
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() && !strings.HasPrefix(name, ".") && !skippedDirs[name] {
+			facts.Dirs = append(facts.Dirs, name)
+		}
+	}
+
+	seen := map[string]bool{}
+	for _, tool := range toolMarkers {
+		if seen[tool.Name] {
+			continue
+		}
+		if exists, _ := afero.Exists(fs, filepath.Join(dir, tool.File)); exists {
+			seen[tool.Name] = true
+			facts.Tools = append(facts.Tools, tool)
+		}
+	}
+	return facts, nil
+}
+
+// hasCommand reports whether a detected command runs the given target or script
+func (f *Facts) hasCommand(name string) bool {
+	return slices.ContainsFunc(f.Commands, func(c Command) bool {
+		return strings.HasSuffix(c.Run, " "+name)
+	})
+}
+
+// readGoMod reads the module path and Go version from go.mod
+func (f *Facts) readGoMod(fs afero.Fs, dir string) error {
+	data, err := generated.ReadOptional(fs, filepath.Join(dir, "go.mod"))
+	if data == nil || err != nil {
+		return err
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		switch fields[0] {
+		case "module":
+			f.GoModule = strings.Trim(fields[1], `"`)
+		case "go":
+			f.GoVersion = fields[1]
+		}
+	}
+	return nil
+}
+
+// readMakefile reads the targets of the Makefile, with the descriptions
+// written after ## on the target line
+func (f *Facts) readMakefile(fs afero.Fs, dir string) error {
+	data, err := generated.ReadOptional(fs, filepath.Join(dir, "Makefile"))
+	if data == nil || err != nil {
+		return err
+	}
+	seen := map[string]bool{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		match := makeTargetPattern.FindStringSubmatch(scanner.Text())
+		if match == nil || seen[match[1]] || strings.HasPrefix(match[1], ".") {
+			continue
+		}
+		seen[match[1]] = true
+		var description string
+		if _, comment, ok := strings.Cut(match[2], "##"); ok {
+			description = strings.TrimSpace(comment)
+		}
+		f.Commands = append(f.Commands, Command{Run: "make " + match[1], Description: description})
+	}
+	return nil
+}
+
+// readPackageJSON reads the package name and scripts from package.json, and
+// the package manager from the lock file present
+func (f *Facts) readPackageJSON(fs afero.Fs, dir string) error {
+	data, err := generated.ReadOptional(fs, filepath.Join(dir, "package.json"))
+	if data == nil || err != nil {
+		return err
+	}
+	var pkg struct {
+		Name    string            `json:"name"`
+		Scripts map[string]string `json:"scripts"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return contextureerrors.Wrap(err, "parse package.json")
+	}
+	f.PackageName = pkg.Name
+
+	f.PackageManager = "npm"
+	for _, lock := range []struct{ file, manager string }{
+		{"pnpm-lock.yaml", "pnpm"},
+		{"yarn.lock", "yarn"},
+		{"bun.lockb", "bun"},
+	} {
+		if exists, _ := afero.Exists(fs, filepath.Join(dir, lock.file)); exists {
+			f.PackageManager = lock.manager
+			break
+		}
+	}
+
+	names := make([]string, 0, len(pkg.Scripts))
+	for name := range pkg.Scripts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		f.Commands = append(f.Commands, Command{
+			Run:         f.PackageManager + " run " + name,
+			Description: "`" + pkg.Scripts[name] + "`",
+		})
+	}
+	return nil
+}
+
+// Render returns the local rule describing facts. The content only changes
+// when the facts do, so refreshing an unchanged project rewrites nothing.
+func Render(facts *Facts) string {
+	var b strings.Builder
+	generated.WriteHeader(&b, rule.Command, "Project Facts",
+		"Build and test commands, layout, and tooling of "+facts.Name, "project")
+
+	var languages []string
+	for _, language := range facts.Languages {
+		switch {
+		case language == "javascript" && slices.Contains(facts.Languages, "typescript"):
+			continue
+		case language == "go" && facts.GoModule != "":
+			line := "Go"
+			if facts.GoVersion != "" {
+				line += " " + facts.GoVersion
+			}
+			languages = append(languages, line+", module `"+facts.GoModule+"`")
+		case (language == "javascript" || language == "typescript") && facts.PackageManager != "":
+			line := languageName(language) + ", " + facts.PackageManager
+			if facts.PackageName != "" {
+				line += ", package `" + facts.PackageName + "`"
+			}
+			languages = append(languages, line)
+		default:
+			languages = append(languages, languageName(language))
+		}
+	}
+	generated.WriteSection(&b, "Languages", languages)
+
+	commands := make([]string, len(facts.Commands))
+	for i, command := range facts.Commands {
+		commands[i] = "`" + command.Run + "`"
+		if command.Description != "" {
+			commands[i] += ": " + command.Description
+		}
+	}
+	generated.WriteSection(&b, "Commands", commands)
+
+	dirs := make([]string, len(facts.Dirs))
+	for i, dir := range facts.Dirs {
+		dirs[i] = "`" + dir + "/`"
+	}
+	generated.WriteSection(&b, "Layout", dirs)
+
+	tools := make([]string, len(facts.Tools))
+	for i, tool := range facts.Tools {
+		tools[i] = tool.Name + " (`" + tool.File + "`)"
+	}
+	generated.WriteSection(&b, "Tooling", tools)
+	return b.String()
+}
+
+// languageName returns the display name of a detected language
+func languageName(language string) string {
+	switch language {
+	case "javascript":
+		return "JavaScript"
+	case "typescript":
+		return "TypeScript"
+	case "php":
+		return "PHP"
+	default:
+		return strings.ToUpper(language[:1]) + language[1:]
+	}
+}
+
+// Write renders the facts of the repository in projectDir into the rule in
+// rulesDir. A rule without the generated marker was taken over by hand and
+// is only replaced with force. It reports whether the file changed.
+func Write(fs afero.Fs, projectDir, rulesDir string, force bool) (string, bool, error) {
+	return generated.Write(fs, rulesDir, rule, force, func() (string, error) {
+		facts, err := Detect(fs, projectDir)
+		if err != nil {
+			return "", err
+		}
+		// The rules directory only exists once the rule is written, so it is
+		// left out of the layout to keep the rule the same on refresh
+		if filepath.Clean(filepath.Dir(rulesDir)) == filepath.Clean(projectDir) {
+			facts.Dirs = slices.DeleteFunc(facts.Dirs, func(dir string) bool { return dir == filepath.Base(rulesDir) })
+		}
+		return Render(facts), nil
+	})
+}
@@ -0,0 +1,114 @@
+package facts
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMakefile = `GO ?= go
+VERSION := 1.0
+
+.PHONY: build test
+
+build: ## Build the binary
+	$(GO) build ./cmd/app
+
+test: build ## Run the tests
+	$(GO) test ./...
+
+%.o: %.c
+	cc -c $<
+`
+
+func writeFiles(t *testing.T, fs afero.Fs, files map[string]string) {
+	t.Helper()
+	for path, content := range files {
+		require.NoError(t, fs.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, afero.WriteFile(fs, path, []byte(content), 0o644))
+	}
+}
+
+func TestDetect(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	writeFiles(t, fs, map[string]string{
+		"/work/app/go.mod":                    "module github.com/acme/app\n\ngo 1.25\n",
+		"/work/app/Makefile":                  testMakefile,
+		"/work/app/package.json":              `{"name": "app-ui", "scripts": {"lint": "eslint .", "build": "vite build"}}`,
+		"/work/app/pnpm-lock.yaml":            "",
+		"/work/app/.golangci.yml":             "",
+		"/work/app/.github/workflows/ci.yml":  "",
+		"/work/app/cmd/app/main.go":           "package main",
+		"/work/app/internal/core/core.go":     "package core",
+		"/work/app/node_modules/x/index.js":   "",
+		"/work/app/.contexture/rules/rule.md": "",
+	})
+
+	facts, err := Detect(fs, "/work/app")
+	require.NoError(t, err)
+	assert.Equal(t, "app", facts.Name)
+	assert.Equal(t, []string{"go", "javascript"}, facts.Languages)
+	assert.Equal(t, "github.com/acme/app", facts.GoModule)
+	assert.Equal(t, "1.25", facts.GoVersion)
+	assert.Equal(t, "pnpm", facts.PackageManager)
+	assert.Equal(t, []Command{
+		{Run: "make build", Description: "Build the binary"},
+		{Run: "make test", Description: "Run the tests"},
+		{Run: "pnpm run build", Description: "`vite build`"},
+		{Run: "pnpm run lint", Description: "`eslint .`"},
+	}, facts.Commands, "go commands are left out when make has a test target")
+	assert.Equal(t, []string{"cmd", "internal"}, facts.Dirs)
+	assert.Equal(t, []Tool{
+		{Name: "golangci-lint", File: ".golangci.yml"},
+		{Name: "GitHub Actions", File: ".github/workflows"},
+	}, facts.Tools)
+}
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+	content := Render(&Facts{
+		Name:      "app",
+		Languages: []string{"go"},
+		GoModule:  "github.com/acme/app",
+		GoVersion: "1.25",
+		Commands:  []Command{{Run: "go test ./...", Description: "Run the tests"}},
+		Dirs:      []string{"cmd"},
+	})
+
+	assert.Contains(t, content, "title: Project Facts\n")
+	assert.Contains(t, content, "trigger: always\n")
+	assert.Contains(t, content, GeneratedMarker)
+	assert.Contains(t, content, "- Go 1.25, module `github.com/acme/app`\n")
+	assert.Contains(t, content, "- `go test ./...`: Run the tests\n")
+	assert.Contains(t, content, "## Layout\n\n- `cmd/`\n")
+	assert.NotContains(t, content, "## Tooling", "empty sections are left out")
+}
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	writeFiles(t, fs, map[string]string{"/work/app/go.mod": "module github.com/acme/app\n"})
+	rulesDir := "/work/app/rules"
+
+	path, changed, err := Write(fs, "/work/app", rulesDir, false)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, filepath.Join(rulesDir, RuleFile), path)
+
+	_, changed, err = Write(fs, "/work/app", rulesDir, false)
+	require.NoError(t, err)
+	assert.False(t, changed, "unchanged facts rewrite nothing")
+
+	require.NoError(t, afero.WriteFile(fs, path, []byte("# Written by hand\n"), 0o644))
+	_, _, err = Write(fs, "/work/app", rulesDir, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "was not generated by contexture")
+
+	_, changed, err = Write(fs, "/work/app", rulesDir, true)
+	require.NoError(t, err)
+	assert.True(t, changed)
+}
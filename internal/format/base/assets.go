@@ -0,0 +1,148 @@
+package base
+
+import (
+	"encoding/base64"
+	"mime"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/links"
+)
+
+// maxInlineAssetSize is the largest image embedded as a data URI in inline mode
+const maxInlineAssetSize = 16 * 1024
+
+// embedAssets returns copies of rules whose relative links to files in their
+// rule repository, such as images and diagrams, point to copies of the files
+// in the assets directory of the format, or to data URIs for small images in
+// inline mode. Links to other rules are left as written. Assets no longer
+// referenced are removed, so the assets directory only holds what the last
+// write needed.
+func (cf *CommonFormat) embedAssets(rules []*domain.TransformedRule, config *domain.FormatConfig) ([]*domain.TransformedRule, error) {
+	metadata := cf.strategy.GetMetadata()
+	if metadata.AssetsDir == "" || config.IsUserRules {
+		return rules, nil
+	}
+
+	baseDir := config.BaseDir
+	if baseDir == "" {
+		baseDir = "."
+	}
+	assetsDir := filepath.Join(baseDir, filepath.FromSlash(metadata.AssetsDir))
+	outputDir := cf.strategy.GetOutputPath(config)
+	if !metadata.IsDirectory {
+		outputDir = filepath.Dir(outputDir)
+	}
+	linkDir, err := filepath.Rel(outputDir, assetsDir)
+	if err != nil {
+		return nil, contextureerrors.WithOpf("embed assets", "failed to resolve %s: %w", assetsDir, err)
+	}
+
+	written := map[string]bool{}
+	embedded := make([]*domain.TransformedRule, len(rules))
+	for i, rule := range rules {
+		embedded[i] = rule
+		if config.Assets == "" || rule.Rule.RepoDir == "" {
+			continue
+		}
+		copied := *rule
+		for _, link := range links.Extract(rule.Content) {
+			if !link.IsRelative() {
+				continue
+			}
+			replacement, err := cf.embedAsset(rule.Rule, link.Target, config.Assets, assetsDir, written)
+			if err != nil {
+				return nil, err
+			}
+			if replacement == "" {
+				continue
+			}
+			if !strings.HasPrefix(replacement, "data:") {
+				replacement = path.Join(filepath.ToSlash(linkDir), replacement)
+			}
+			copied.Content = replaceLinkTarget(copied.Content, link.Target, replacement)
+		}
+		embedded[i] = &copied
+	}
+
+	if err := cf.removeStaleAssets(assetsDir, written); err != nil {
+		return nil, err
+	}
+	return embedded, nil
+}
+
+// embedAsset carries the file a relative link of rule points to into the
+// output. It returns the data URI of a small image in inline mode, otherwise
+// the name of its copy in assetsDir, or an empty string for links to rules and
+// to files that do not exist.
+func (cf *CommonFormat) embedAsset(
+	rule *domain.Rule,
+	target string,
+	mode domain.AssetMode,
+	assetsDir string,
+	written map[string]bool,
+) (string, error) {
+	resolved, ok := links.ResolveRelative(filepath.ToSlash(rule.FilePath), target)
+	if !ok || resolved == "." || strings.HasSuffix(resolved, ".md") {
+		return "", nil
+	}
+	source := filepath.Join(rule.RepoDir, filepath.FromSlash(resolved))
+	info, err := cf.GetFileInfo(source)
+	if err != nil || info.IsDir() {
+		cf.LogWarn("Referenced asset not found", "rule", rule.ID, "link", target)
+		return "", nil
+	}
+	data, err := cf.ReadFile(source)
+	if err != nil {
+		return "", contextureerrors.WithOpf("embed assets", "failed to read %s: %w", source, err)
+	}
+
+	mediaType := mime.TypeByExtension(path.Ext(resolved))
+	if mode == domain.AssetsInline && len(data) <= maxInlineAssetSize && strings.HasPrefix(mediaType, "image/") {
+		mediaType, _, _ = strings.Cut(mediaType, ";")
+		return "data:" + mediaType + ";base64," + base64.StdEncoding.EncodeToString(data), nil
+	}
+
+	// Copies are named by content, so the same file referenced by several
+	// rules is copied once and files of the same name do not collide
+	name := cf.CalculateContentHash(data)[:8] + "-" + path.Base(resolved)
+	if !written[name] {
+		if err := cf.WriteFile(filepath.Join(assetsDir, name), data); err != nil {
+			return "", contextureerrors.WithOpf("embed assets", "failed to copy %s: %w", source, err)
+		}
+		written[name] = true
+	}
+	return name, nil
+}
+
+// replaceLinkTarget points the inline links to target in content to replacement
+func replaceLinkTarget(content, target, replacement string) string {
+	content = strings.ReplaceAll(content, "]("+target, "]("+replacement)
+	return strings.ReplaceAll(content, "](<"+target+">", "](<"+replacement+">")
+}
+
+// removeStaleAssets removes the files in assetsDir that are not in written,
+// and the directory itself once it is empty
+func (cf *CommonFormat) removeStaleAssets(assetsDir string, written map[string]bool) error {
+	exists, err := cf.DirExists(assetsDir)
+	if err != nil || !exists {
+		return nil //nolint:nilerr // Nothing to clean up when the directory cannot be read
+	}
+	files, err := cf.ListDirectory(assetsDir)
+	if err != nil {
+		return contextureerrors.WithOpf("remove stale assets", "failed to list %s: %w", assetsDir, err)
+	}
+	for _, file := range files {
+		if file.IsDir() || written[file.Name()] {
+			continue
+		}
+		if err := cf.RemoveFile(filepath.Join(assetsDir, file.Name())); err != nil {
+			return contextureerrors.WithOpf("remove stale assets", "failed to delete %s: %w", file.Name(), err)
+		}
+	}
+	cf.CleanupEmptyDirectory(assetsDir)
+	return nil
+}
@@ -0,0 +1,52 @@
+package base
+
+import (
+	"testing"
+
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommonFormat_Write_Assets(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/cache/repo/go/diagrams/flow.svg", []byte("<svg/>"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "/cache/repo/shared/spec.pdf", []byte("%PDF"), 0o644))
+
+	strategy := &recordingStrategy{outputDir: "/project/.cursor/rules", assetsDir: ".cursor/rules/assets"}
+	cf := NewCommonFormat(NewBaseFormat(fs, domain.FormatCursor), strategy)
+	rule := &domain.TransformedRule{
+		Rule: &domain.Rule{ID: "[contexture:go/errors]", FilePath: "go/errors", RepoDir: "/cache/repo"},
+		Content: "![Flow](diagrams/flow.svg \"Flow\")\n" +
+			"See [the spec](../shared/spec.pdf), [testing](testing.md), and [missing](missing.png).\n",
+	}
+
+	require.NoError(t, cf.Write([]*domain.TransformedRule{rule}, &domain.FormatConfig{BaseDir: "/project", Assets: domain.AssetsCopy}))
+	files, err := afero.ReadDir(fs, "/project/.cursor/rules/assets")
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	svg, pdf := files[1].Name(), files[0].Name()
+	assert.Regexp(t, `^[0-9a-f]{8}-flow\.svg$`, svg)
+	assert.Regexp(t, `^[0-9a-f]{8}-spec\.pdf$`, pdf)
+	assert.Equal(t, "![Flow](assets/"+svg+" \"Flow\")\n"+
+		"See [the spec](assets/"+pdf+"), [testing](testing.md), and [missing](missing.png).\n",
+		strategy.written[0].Content)
+	assert.Contains(t, rule.Content, "diagrams/flow.svg", "transformed rules are not changed in place")
+
+	// Inline mode embeds small images and copies other files; the stale copy is removed
+	require.NoError(t, cf.Write([]*domain.TransformedRule{rule}, &domain.FormatConfig{BaseDir: "/project", Assets: domain.AssetsInline}))
+	assert.Contains(t, strategy.written[0].Content, "![Flow](data:image/svg+xml;base64,PHN2Zy8+ \"Flow\")")
+	files, err = afero.ReadDir(fs, "/project/.cursor/rules/assets")
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, pdf, files[0].Name())
+
+	// Without an asset mode links are left as written and copies are removed
+	require.NoError(t, cf.Write([]*domain.TransformedRule{rule}, &domain.FormatConfig{BaseDir: "/project"}))
+	assert.Equal(t, rule.Content, strategy.written[0].Content)
+	exists, err := afero.DirExists(fs, "/project/.cursor/rules/assets")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
@@ -11,10 +11,14 @@ import (
 	"time"
 
 	"github.com/charmbracelet/log"
+	"github.com/contextureai/contexture/internal/cache"
+	"github.com/contextureai/contexture/internal/dependencies"
 	"github.com/contextureai/contexture/internal/domain"
 	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/render"
 	"github.com/contextureai/contexture/internal/rule"
 	"github.com/contextureai/contexture/internal/template"
+	"github.com/contextureai/contexture/internal/version"
 	"github.com/spf13/afero"
 )
 
@@ -23,14 +27,19 @@ type Base struct {
 	fs             afero.Fs
 	templateEngine template.Engine
 	formatType     domain.FormatType
+	renderCache    *cache.RenderCache
 }
 
 // NewBaseFormat creates a new base format
 func NewBaseFormat(fs afero.Fs, formatType domain.FormatType) *Base {
+	build := version.Get()
 	return &Base{
 		fs:             fs,
 		templateEngine: template.NewEngine(),
 		formatType:     formatType,
+		// Formats are built without dependencies, so rendered content is cached
+		// in the cache directory of the process environment
+		renderCache: cache.NewRenderCache(fs, dependencies.OSEnvironment{}, build.Version+"-"+build.Commit),
 	}
 }
 
@@ -88,61 +97,66 @@ func (bf *Base) ProcessTemplate(
 	templateContent string,
 	additionalVars ...map[string]any,
 ) (string, error) {
-	// Create base template variables
-	variables := map[string]any{
-		"rule":        rule,
-		"id":          rule.ID,
-		"title":       rule.Title,
-		"description": rule.Description,
-		"tags":        rule.Tags,
-		"content":     rule.Content,
-		"source":      rule.Source,
-		"ref":         rule.Ref,
-		"languages":   rule.Languages,
-		"frameworks":  rule.Frameworks,
-	}
-
-	// Add trigger if it exists (convert to basic types for template compatibility)
-	if rule.Trigger != nil {
-		variables["trigger"] = map[string]any{
-			"type":  string(rule.Trigger.Type),
-			"globs": rule.Trigger.Globs,
-		}
+	var additional map[string]any
+	if len(additionalVars) > 0 {
+		additional = additionalVars[0]
+	}
+
+	// Process the template (all rendering is text-based, no HTML escaping)
+	content, err := render.Content(bf.templateEngine, rule, templateContent, additional)
+	if err != nil {
+		return "", contextureerrors.Wrap(err, "base.ProcessTemplate")
+	}
+
+	return content, nil
+}
+
+// RenderRuleContent renders a rule's content template with its variables,
+// reading through the rendered content cache. Content that references the
+// current date or time is always rendered.
+func (bf *Base) RenderRuleContent(rule *domain.Rule, variables map[string]any) (string, error) {
+	render := func() (string, error) {
+		return bf.ProcessTemplate(rule, rule.Content, variables)
 	}
 
-	// Add variables from the parsed rule ID if they exist
-	if rule.Variables != nil {
-		for key, value := range rule.Variables {
-			// Convert string booleans to actual booleans for proper template logic
-			if strVal, ok := value.(string); ok {
-				switch strings.ToLower(strVal) {
-				case "true", "1", "yes":
-					variables[key] = true
-				case "false", "0", "no":
-					variables[key] = false
-				default:
-					variables[key] = value
-				}
-			} else {
-				variables[key] = value
+	key, ok := renderCacheKey(rule, variables)
+	if !ok || bf.renderCache == nil {
+		return render()
+	}
+	return bf.renderCache.GetOrRender(key, render)
+}
+
+// renderCacheKey hashes everything that affects how a rule's content renders:
+// the template engine version, the rule with its content, and the variables.
+// It reports false when the content depends on the current time or the inputs
+// cannot be hashed.
+func renderCacheKey(r *domain.Rule, variables map[string]any) (string, bool) {
+	if strings.Contains(r.Content, "{{") {
+		for _, name := range render.TimeVariables {
+			if strings.Contains(r.Content, "."+name) {
+				return "", false
 			}
 		}
 	}
 
-	// Add additional variables if provided (they can override base ones if needed)
-	if len(additionalVars) > 0 && additionalVars[0] != nil {
-		for key, value := range additionalVars[0] {
-			variables[key] = value
-		}
+	// Time variables are always present, but only matter when referenced
+	stable := make(map[string]any, len(variables))
+	for key, value := range variables {
+		stable[key] = value
+	}
+	for _, name := range render.TimeVariables {
+		delete(stable, name)
 	}
 
-	// Process the template (all rendering is text-based, no HTML escaping)
-	content, err := bf.templateEngine.Render(templateContent, variables)
+	data, err := json.Marshal(struct {
+		Version   int            `json:"version"`
+		Rule      *domain.Rule   `json:"rule"`
+		Variables map[string]any `json:"variables"`
+	}{template.Version, r, stable})
 	if err != nil {
-		return "", contextureerrors.Wrap(err, "base.ProcessTemplate")
+		return "", false
 	}
-
-	return content, nil
+	return fmt.Sprintf("%x", sha256.Sum256(data)), true
 }
 
 // CreateTransformedRule creates a transformed rule with common metadata
@@ -1,6 +1,7 @@
 package base
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/contextureai/contexture/internal/domain"
@@ -129,6 +130,38 @@ func TestBaseFormat_ProcessTemplateWithVars(t *testing.T) {
 	assert.Contains(t, result, "Rule content")
 }
 
+func TestBaseFormat_RenderRuleContent(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	base := NewBaseFormat(fs, domain.FormatClaude)
+
+	cached := &domain.Rule{ID: "[contexture:go/style]", Title: "Style", Content: "Indent with {{.indent}}."}
+	variables := map[string]any{"indent": "tabs", "date": "2025-01-01"}
+
+	content, err := base.RenderRuleContent(cached, variables)
+	require.NoError(t, err)
+	assert.Equal(t, "Indent with tabs.", content)
+
+	// Time variables only change the key of content that references them
+	variables["date"] = "2025-01-02"
+	key, ok := renderCacheKey(cached, variables)
+	require.True(t, ok)
+	exists, err := afero.Exists(fs, filepath.Join(base.renderCache.Dir(), key))
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	content, err = base.RenderRuleContent(cached, map[string]any{"indent": "spaces"})
+	require.NoError(t, err)
+	assert.Equal(t, "Indent with spaces.", content)
+
+	dated := &domain.Rule{ID: "[contexture:go/dated]", Title: "Dated", Content: "Updated {{.date}}."}
+	_, ok = renderCacheKey(dated, map[string]any{"date": "2025-01-01"})
+	assert.False(t, ok)
+	content, err = base.RenderRuleContent(dated, map[string]any{"date": "2025-01-01"})
+	require.NoError(t, err)
+	assert.Equal(t, "Updated 2025-01-01.", content)
+}
+
 func TestBaseFormat_CreateTransformedRule(t *testing.T) {
 	t.Parallel()
 	base := NewBaseFormat(afero.NewMemMapFs(), domain.FormatClaude)
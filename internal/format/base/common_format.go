@@ -56,6 +56,21 @@ func NewCommonFormat(base *Base, strategy FormatStrategy) *CommonFormat {
 	}
 }
 
+// RenderContent renders the content template of a processed rule with its
+// variables, keeping only the :::only sections meant for this format
+func (cf *CommonFormat) RenderContent(processedRule *domain.ProcessedRule) (string, error) {
+	renderedContent, err := cf.RenderRuleContent(processedRule.Rule, processedRule.Variables)
+	if err != nil {
+		return "", contextureerrors.Wrap(err, "render_rule_content")
+	}
+
+	renderedContent, err = domain.FilterFormatSections(renderedContent, cf.formatType)
+	if err != nil {
+		return "", contextureerrors.Wrap(err, "filter_format_sections")
+	}
+	return renderedContent, nil
+}
+
 // Transform converts a processed rule to format representation (shared logic)
 // This method implements the common 2-stage template processing used by all formats:
 // Stage 1: Render the rule content template with variables
@@ -65,9 +80,9 @@ func (cf *CommonFormat) Transform(processedRule *domain.ProcessedRule) (*domain.
 	cf.LogDebug("Transforming processed rule using CommonFormat", "id", rule.ID)
 
 	// Stage 1: Render the rule content template first
-	renderedContent, err := cf.ProcessTemplate(rule, rule.Content, processedRule.Variables)
+	renderedContent, err := cf.RenderContent(processedRule)
 	if err != nil {
-		return nil, contextureerrors.Wrap(err, "render_rule_content")
+		return nil, err
 	}
 
 	// Stage 2: Use format-specific template wrapper and include rendered content
@@ -121,11 +136,37 @@ func (cf *CommonFormat) Validate(rule *domain.Rule) (*domain.ValidationResult, e
 func (cf *CommonFormat) Write(rules []*domain.TransformedRule, config *domain.FormatConfig) error {
 	cf.LogDebug("Writing rules", "count", len(rules))
 
+	if config != nil {
+		embedded, err := cf.embedAssets(rules, config)
+		if err != nil {
+			return err
+		}
+		rules = embedded
+	}
+	if config != nil && (config.PlainProse || config.Wrap != 0) {
+		rules = normalizeRules(rules, config)
+	}
+
 	// Delegate to format-specific write implementation
 	// Format handlers handle 0 rules by deleting output files
 	return cf.strategy.WriteFiles(rules, config)
 }
 
+// normalizeRules returns copies of rules with their content made plain prose
+// and rewrapped as configured
+func normalizeRules(rules []*domain.TransformedRule, config *domain.FormatConfig) []*domain.TransformedRule {
+	normalized := make([]*domain.TransformedRule, len(rules))
+	for i, rule := range rules {
+		copied := *rule
+		if config.PlainProse {
+			copied.Content = PlainProse(copied.Content)
+		}
+		copied.Content = WrapProse(copied.Content, config.Wrap)
+		normalized[i] = &copied
+	}
+	return normalized
+}
+
 // Remove deletes a specific rule from the format
 // For single-file formats: rebuilds file without the rule
 // For multi-file formats: deletes the individual file
@@ -0,0 +1,126 @@
+package base
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/contextureai/contexture/internal/domain"
+)
+
+// ungroupedSection is the heading of rules with no section
+const ungroupedSection = "Other"
+
+// RuleGroup is a section of a single-file output and the rules under it
+type RuleGroup struct {
+	Title string
+	Rules []*domain.TransformedRule
+}
+
+// GroupRules groups rules into the sections of a single-file output. A rule's
+// configured section wins over its tag or folder. Sections are ordered by
+// their highest rule priority, then by title, with ungrouped rules last;
+// rules keep their order within a section. Without a grouping and without
+// configured sections it returns nil, and rules are written ungrouped.
+func GroupRules(rules []*domain.TransformedRule, groupBy domain.RuleGrouping) []RuleGroup {
+	grouped := groupBy != ""
+	for _, rule := range rules {
+		if rule.Rule.Section != "" {
+			grouped = true
+		}
+	}
+	if !grouped {
+		return nil
+	}
+
+	var groups []RuleGroup
+	index := map[string]int{}
+	priority := map[string]int{}
+	for _, rule := range rules {
+		title := sectionTitle(rule.Rule, groupBy)
+		i, exists := index[title]
+		if !exists {
+			i = len(groups)
+			index[title] = i
+			groups = append(groups, RuleGroup{Title: title})
+			priority[title] = rule.Rule.Priority
+		}
+		groups[i].Rules = append(groups[i].Rules, rule)
+		priority[title] = max(priority[title], rule.Rule.Priority)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		titleI, titleJ := groups[i].Title, groups[j].Title
+		if (titleI == ungroupedSection) != (titleJ == ungroupedSection) {
+			return titleJ == ungroupedSection
+		}
+		if priority[titleI] != priority[titleJ] {
+			return priority[titleI] > priority[titleJ]
+		}
+		return strings.ToLower(titleI) < strings.ToLower(titleJ)
+	})
+	return groups
+}
+
+// sectionTitle returns the heading rule is grouped under
+func sectionTitle(rule *domain.Rule, groupBy domain.RuleGrouping) string {
+	if rule.Section != "" {
+		return rule.Section
+	}
+	switch groupBy {
+	case domain.GroupByTag:
+		if len(rule.Tags) > 0 {
+			return rule.Tags[0]
+		}
+	case domain.GroupByFolder:
+		if dir := path.Dir(domain.ExtractRulePath(rule.ID)); dir != "." && dir != "/" {
+			return dir
+		}
+	}
+	return ungroupedSection
+}
+
+// GroupedContent writes groups as H2 sections preceded by a table of
+// contents. joinRules writes the rules of one section.
+func GroupedContent(groups []RuleGroup, joinRules func([]*domain.TransformedRule) string) string {
+	var content strings.Builder
+
+	content.WriteString("## Contents\n\n")
+	anchors := map[string]int{}
+	for _, group := range groups {
+		anchor := headingAnchor(group.Title)
+		// Repeated headings get numbered anchors, as on GitHub
+		if n := anchors[anchor]; n > 0 {
+			anchors[anchor]++
+			anchor = fmt.Sprintf("%s-%d", anchor, n)
+		} else {
+			anchors[anchor] = 1
+		}
+		fmt.Fprintf(&content, "- [%s](#%s) (%d)\n", group.Title, anchor, len(group.Rules))
+	}
+
+	for _, group := range groups {
+		fmt.Fprintf(&content, "\n## %s\n\n", group.Title)
+		content.WriteString(joinRules(group.Rules))
+		content.WriteString("\n")
+	}
+
+	return strings.TrimSuffix(content.String(), "\n")
+}
+
+// headingAnchor returns the link anchor Markdown renderers such as GitHub
+// generate for a heading: lowercase, with punctuation removed and spaces
+// replaced by hyphens
+func headingAnchor(heading string) string {
+	var anchor strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(heading)) {
+		switch {
+		case r == ' ':
+			anchor.WriteRune('-')
+		case r == '-' || r == '_' || r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r > 127:
+			anchor.WriteRune(r)
+		}
+	}
+	return anchor.String()
+}
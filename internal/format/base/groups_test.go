@@ -0,0 +1,73 @@
+package base
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func groupTitles(groups []RuleGroup) []string {
+	titles := make([]string, len(groups))
+	for i, group := range groups {
+		titles[i] = group.Title
+	}
+	return titles
+}
+
+func TestGroupRules(t *testing.T) {
+	t.Parallel()
+
+	goTesting := &domain.TransformedRule{Rule: &domain.Rule{ID: "[contexture:languages/go/testing]", Tags: []string{"testing", "go"}}}
+	goErrors := &domain.TransformedRule{Rule: &domain.Rule{ID: "[contexture:languages/go/errors]", Tags: []string{"go"}}}
+	secrets := &domain.TransformedRule{Rule: &domain.Rule{ID: "[contexture:security/secrets]", Tags: []string{"security"}, Priority: 10}}
+	local := &domain.TransformedRule{Rule: &domain.Rule{ID: "[contexture(local):notes]"}}
+	rules := []*domain.TransformedRule{secrets, goErrors, goTesting, local}
+
+	assert.Nil(t, GroupRules(rules, ""), "rules are ungrouped by default")
+
+	groups := GroupRules(rules, domain.GroupByTag)
+	assert.Equal(t, []string{"security", "go", "testing", "Other"}, groupTitles(groups))
+
+	groups = GroupRules(rules, domain.GroupByFolder)
+	assert.Equal(t, []string{"security", "languages/go", "Other"}, groupTitles(groups))
+	assert.Equal(t, []*domain.TransformedRule{goErrors, goTesting}, groups[1].Rules)
+
+	// A configured section groups rules without a grouping and wins over one
+	onboarding := &domain.TransformedRule{Rule: &domain.Rule{ID: "[contexture:team/onboarding]", Section: "Getting Started"}}
+	groups = GroupRules([]*domain.TransformedRule{goErrors, onboarding}, "")
+	assert.Equal(t, []string{"Getting Started", "Other"}, groupTitles(groups))
+	groups = GroupRules([]*domain.TransformedRule{goErrors, onboarding}, domain.GroupByFolder)
+	assert.Equal(t, []string{"Getting Started", "languages/go"}, groupTitles(groups))
+}
+
+func TestGroupedContent(t *testing.T) {
+	t.Parallel()
+
+	groups := []RuleGroup{
+		{Title: "Getting Started", Rules: []*domain.TransformedRule{{Content: "Welcome"}}},
+		{Title: "languages/go", Rules: []*domain.TransformedRule{{Content: "Errors"}, {Content: "Testing"}}},
+	}
+	joinRules := func(rules []*domain.TransformedRule) string {
+		contents := make([]string, len(rules))
+		for i, rule := range rules {
+			contents[i] = rule.Content
+		}
+		return strings.Join(contents, "\n\n---\n\n")
+	}
+
+	content := GroupedContent(groups, joinRules)
+	require.True(t, strings.HasPrefix(content, "## Contents\n\n"))
+	assert.Contains(t, content, "- [Getting Started](#getting-started) (1)\n- [languages/go](#languagesgo) (2)\n")
+	assert.Contains(t, content, "\n## Getting Started\n\nWelcome\n")
+	assert.True(t, strings.HasSuffix(content, "## languages/go\n\nErrors\n\n---\n\nTesting"))
+}
+
+func TestHeadingAnchor(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "getting-started", headingAnchor("Getting Started"))
+	assert.Equal(t, "cc-style", headingAnchor("C/C++ Style"))
+	assert.Equal(t, "api_v2", headingAnchor(" API_v2 "))
+}
@@ -0,0 +1,53 @@
+package base
+
+import (
+	"strings"
+
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+)
+
+const (
+	// KeepBlockStart opens a block of hand-written content that builds preserve
+	KeepBlockStart = "<!-- contexture:keep -->"
+	// KeepBlockEnd closes a keep block
+	KeepBlockEnd = "<!-- /contexture:keep -->"
+)
+
+// WrapKeepBlock wraps content in keep block markers
+func WrapKeepBlock(content string) string {
+	return KeepBlockStart + "\n" + strings.TrimSpace(content) + "\n" + KeepBlockEnd
+}
+
+// ExtractKeepBlocks returns the keep blocks in content, markers included. An
+// unterminated block runs to the end of the content.
+func ExtractKeepBlocks(content string) []string {
+	var blocks []string
+	for {
+		start := strings.Index(content, KeepBlockStart)
+		if start < 0 {
+			return blocks
+		}
+		content = content[start:]
+		end := strings.Index(content, KeepBlockEnd)
+		if end < 0 {
+			return append(blocks, strings.TrimSpace(content)+"\n"+KeepBlockEnd)
+		}
+		end += len(KeepBlockEnd)
+		blocks = append(blocks, content[:end])
+		content = content[end:]
+	}
+}
+
+// ReadKeepBlocks returns the keep blocks of the file at path, or nothing when
+// the file does not exist
+func (bf *Base) ReadKeepBlocks(path string) ([]string, error) {
+	exists, err := bf.FileExists(path)
+	if err != nil || !exists {
+		return nil, err
+	}
+	data, err := bf.ReadFile(path)
+	if err != nil {
+		return nil, contextureerrors.WithOpf("read keep blocks", "%s: %w", path, err)
+	}
+	return ExtractKeepBlocks(string(data)), nil
+}
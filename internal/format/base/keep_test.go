@@ -0,0 +1,38 @@
+package base
+
+import (
+	"testing"
+
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractKeepBlocks(t *testing.T) {
+	t.Parallel()
+
+	content := "# claude.md\n\n" + WrapKeepBlock("Team notes\n") + "\n\nrule\n\n" +
+		KeepBlockStart + "\nTrailing notes"
+	blocks := ExtractKeepBlocks(content)
+	require.Len(t, blocks, 2)
+	assert.Equal(t, KeepBlockStart+"\nTeam notes\n"+KeepBlockEnd, blocks[0])
+	assert.Equal(t, KeepBlockStart+"\nTrailing notes\n"+KeepBlockEnd, blocks[1])
+
+	assert.Empty(t, ExtractKeepBlocks("no blocks"))
+}
+
+func TestBase_ReadKeepBlocks(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	bf := NewBaseFormat(fs, domain.FormatClaude)
+
+	blocks, err := bf.ReadKeepBlocks("/project/CLAUDE.md")
+	require.NoError(t, err)
+	assert.Empty(t, blocks)
+
+	require.NoError(t, afero.WriteFile(fs, "/project/CLAUDE.md", []byte(WrapKeepBlock("Kept")), 0o644))
+	blocks, err = bf.ReadKeepBlocks("/project/CLAUDE.md")
+	require.NoError(t, err)
+	assert.Equal(t, []string{WrapKeepBlock("Kept")}, blocks)
+}
@@ -0,0 +1,205 @@
+package base
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+)
+
+// combinedFilePrefix starts the names of files holding several small rules
+const combinedFilePrefix = "combined-"
+
+// RuleFile is a file written by a directory format: a whole rule, several
+// small rules combined, or one part of a large rule
+type RuleFile struct {
+	Filename string
+	// Content is the file content, tracking comments included
+	Content string
+	// Size is the length of the rule content, tracking comments excluded
+	Size  int
+	Rules []*domain.TransformedRule
+}
+
+// LayoutRuleFiles lays out the files of a directory format. Rules are written
+// to their own file unless config sets size thresholds: rules smaller than
+// MergeBelow bytes are combined with the other small rules sharing their
+// frontmatter, and rules larger than SplitAbove bytes are split into parts at
+// headings or paragraphs. Every file carries the frontmatter and tracking
+// comments of its rules.
+func (bf *Base) LayoutRuleFiles(rules []*domain.TransformedRule, config *domain.FormatConfig, ext string) []RuleFile {
+	var mergeBelow, splitAbove int
+	if config != nil {
+		mergeBelow, splitAbove = config.MergeBelow, config.SplitAbove
+	}
+
+	var files []RuleFile
+	// Small rules are combined by frontmatter, in order of first appearance
+	var frontmatters []string
+	small := map[string][]*domain.TransformedRule{}
+
+	for _, rule := range rules {
+		frontmatter, body := splitRawFrontmatter(rule.Content)
+		switch {
+		case mergeBelow > 0 && len(rule.Content) < mergeBelow:
+			if _, seen := small[frontmatter]; !seen {
+				frontmatters = append(frontmatters, frontmatter)
+			}
+			small[frontmatter] = append(small[frontmatter], rule)
+		case splitAbove > 0 && len(rule.Content) > splitAbove:
+			parts := splitRuleBody(body, splitAbove-len(frontmatter))
+			stem := strings.TrimSuffix(rule.Filename, ext)
+			for i, part := range parts {
+				if i > 0 && rule.Rule.Title != "" {
+					part = fmt.Sprintf("# %s (part %d of %d)\n\n%s", rule.Rule.Title, i+1, len(parts), part)
+				}
+				content := frontmatter + part
+				files = append(files, RuleFile{
+					Filename: fmt.Sprintf("%s-part-%d%s", stem, i+1, ext),
+					Content:  bf.trackedContent(content, rule),
+					Size:     len(content),
+					Rules:    []*domain.TransformedRule{rule},
+				})
+			}
+		default:
+			files = append(files, bf.ruleFile(rule))
+		}
+	}
+
+	for _, frontmatter := range frontmatters {
+		group := small[frontmatter]
+		if len(group) == 1 {
+			files = append(files, bf.ruleFile(group[0]))
+			continue
+		}
+		bodies := make([]string, len(group))
+		size := len(frontmatter)
+		for i, rule := range group {
+			_, body := splitRawFrontmatter(rule.Content)
+			bodies[i] = bf.trackedContent(strings.TrimSpace(body), rule)
+			size += len(body)
+		}
+		hash := bf.CalculateContentHash([]byte(frontmatter))
+		files = append(files, RuleFile{
+			Filename: combinedFilePrefix + hash[:8] + ext,
+			Content:  frontmatter + strings.Join(bodies, "\n\n"),
+			Size:     size,
+			Rules:    group,
+		})
+	}
+
+	return files
+}
+
+// ruleFile returns the file of a rule written on its own
+func (bf *Base) ruleFile(rule *domain.TransformedRule) RuleFile {
+	return RuleFile{
+		Filename: rule.Filename,
+		Content:  bf.trackedContent(rule.Content, rule),
+		Size:     len(rule.Content),
+		Rules:    []*domain.TransformedRule{rule},
+	}
+}
+
+// trackedContent appends the tracking comment of rule to content, only
+// including non-default variables
+func (bf *Base) trackedContent(content string, rule *domain.TransformedRule) string {
+	return bf.AppendTrackingCommentWithDefaults(content, rule.Rule.ID, rule.Rule.Variables, rule.Rule.DefaultVariables)
+}
+
+// RemoveStaleFiles removes the generated files in dir, those carrying a
+// tracking comment, that are not in written. Hand-written files are kept.
+func (bf *Base) RemoveStaleFiles(dir string, written map[string]bool) error {
+	files, err := bf.ListDirectory(dir)
+	if err != nil {
+		return contextureerrors.WithOpf("remove stale files", "failed to list %s: %w", dir, err)
+	}
+	for _, file := range files {
+		if file.IsDir() || written[file.Name()] {
+			continue
+		}
+		path := filepath.Join(dir, file.Name())
+		content, err := bf.ReadFile(path)
+		if err != nil {
+			return contextureerrors.WithOpf("remove stale files", "failed to read %s: %w", path, err)
+		}
+		if !strings.Contains(string(content), domain.RuleIDCommentPrefix) {
+			continue
+		}
+		if err := bf.RemoveFile(path); err != nil {
+			return contextureerrors.WithOpf("remove stale files", "failed to delete %s: %w", path, err)
+		}
+		bf.LogDebug("Removed stale rule file", "path", path)
+	}
+	return nil
+}
+
+// splitRawFrontmatter splits content into its frontmatter, delimiters and
+// trailing blank lines included, and the body after it
+func splitRawFrontmatter(content string) (string, string) {
+	if !strings.HasPrefix(content, "---\n") {
+		return "", content
+	}
+	end := strings.Index(content[4:], "\n---\n")
+	if end == -1 {
+		return "", content
+	}
+	end += 4 + len("\n---\n")
+	for end < len(content) && content[end] == '\n' {
+		end++
+	}
+	return content[:end], content[end:]
+}
+
+// splitRuleBody splits body into parts of at most limit bytes. Parts end
+// before a heading where possible, otherwise before a paragraph, and never
+// inside a fenced code block unless a single block exceeds the limit.
+func splitRuleBody(body string, limit int) []string {
+	if limit <= 0 || len(body) <= limit {
+		return []string{body}
+	}
+
+	lines := strings.SplitAfter(body, "\n")
+	var parts []string
+	start, size := 0, 0
+	// Last places within the current part where it can end, -1 when none
+	heading, paragraph := -1, -1
+	inFence := false
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		isHeading := !inFence && i > 0 && strings.HasPrefix(trimmed, "#")
+		isParagraph := !inFence && i > 0 && trimmed != "" && strings.TrimSpace(lines[i-1]) == ""
+
+		if size+len(line) > limit && i > start {
+			cut := i
+			switch {
+			case isHeading:
+			case heading > start:
+				cut = heading
+			case isParagraph:
+			case paragraph > start:
+				cut = paragraph
+			}
+			parts = append(parts, strings.TrimRight(strings.Join(lines[start:cut], ""), "\n")+"\n")
+			start, size = cut, len(strings.Join(lines[cut:i], ""))
+			heading, paragraph = -1, -1
+		}
+
+		if i > start {
+			if isHeading {
+				heading = i
+			} else if isParagraph {
+				paragraph = i
+			}
+		}
+		size += len(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+		}
+	}
+
+	return append(parts, strings.Join(lines[start:], ""))
+}
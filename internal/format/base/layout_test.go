@@ -0,0 +1,91 @@
+package base
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func layoutRule(id, filename, content string) *domain.TransformedRule {
+	return &domain.TransformedRule{
+		Rule:     &domain.Rule{ID: id, Title: strings.TrimSuffix(filename, ".mdc")},
+		Content:  content,
+		Filename: filename,
+	}
+}
+
+func TestBase_LayoutRuleFiles(t *testing.T) {
+	t.Parallel()
+	bf := NewBaseFormat(afero.NewMemMapFs(), domain.FormatCursor)
+
+	always := "---\nalwaysApply: true\n---\n\n"
+	large := always + "# Large\n\nIntro.\n\n## First\n\n" + strings.Repeat("a", 60) + "\n\n## Second\n\n" + strings.Repeat("b", 60) + "\n"
+	rules := []*domain.TransformedRule{
+		layoutRule("[contexture:a]", "a.mdc", always+"Tiny A"),
+		layoutRule("[contexture:b]", "b.mdc", "---\nalwaysApply: false\n---\n\nTiny B"),
+		layoutRule("[contexture:c]", "c.mdc", always+"Tiny C"),
+		layoutRule("[contexture:large]", "large.mdc", large),
+		layoutRule("[contexture:medium]", "medium.mdc", always+strings.Repeat("m", 50)),
+	}
+
+	files := bf.LayoutRuleFiles(rules, nil, ".mdc")
+	require.Len(t, files, len(rules), "rules get their own file by default")
+
+	files = bf.LayoutRuleFiles(rules, &domain.FormatConfig{MergeBelow: 40, SplitAbove: 120}, ".mdc")
+	names := make([]string, len(files))
+	for i, file := range files {
+		names[i] = file.Filename
+	}
+	require.Len(t, files, 5)
+	assert.Equal(t, []string{"large-part-1.mdc", "large-part-2.mdc", "medium.mdc"}, names[:3])
+
+	// Parts keep the frontmatter and end before headings
+	assert.True(t, strings.HasPrefix(files[0].Content, always+"# Large"))
+	assert.Contains(t, files[0].Content, "## First")
+	assert.NotContains(t, files[0].Content, "## Second")
+	assert.True(t, strings.HasPrefix(files[1].Content, always+"# large (part 2 of 2)\n\n## Second"))
+	assert.Contains(t, files[1].Content, "<!-- id: [contexture:large] -->")
+
+	// Small rules sharing frontmatter are combined, a lone one is left alone
+	assert.True(t, strings.HasPrefix(names[3], "combined-"))
+	assert.Len(t, files[3].Rules, 2)
+	assert.True(t, strings.HasPrefix(files[3].Content, always+"Tiny A"))
+	assert.Contains(t, files[3].Content, "<!-- id: [contexture:a] -->\n\nTiny C")
+	assert.Equal(t, "b.mdc", names[4])
+}
+
+func TestSplitRuleBody(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"short"}, splitRuleBody("short", 100))
+
+	// Fenced code is not split at its blank lines
+	body := "Intro paragraph.\n\n```go\nfunc a() {\n\n\treturn\n}\n```\n\nOutro paragraph.\n"
+	parts := splitRuleBody(body, 40)
+	require.Len(t, parts, 3)
+	assert.Equal(t, "Intro paragraph.\n", parts[0])
+	assert.Equal(t, "```go\nfunc a() {\n\n\treturn\n}\n```\n", parts[1])
+	assert.Equal(t, "Outro paragraph.\n", parts[2])
+}
+
+func TestBase_RemoveStaleFiles(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	bf := NewBaseFormat(fs, domain.FormatCursor)
+
+	require.NoError(t, afero.WriteFile(fs, "/rules/kept.mdc", []byte("rule\n\n<!-- id: [contexture:kept] -->"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "/rules/stale.mdc", []byte("rule\n\n<!-- id: [contexture:stale] -->"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "/rules/mine.mdc", []byte("hand-written"), 0o644))
+
+	require.NoError(t, bf.RemoveStaleFiles("/rules", map[string]bool{"kept.mdc": true}))
+
+	for path, want := range map[string]bool{"/rules/kept.mdc": true, "/rules/stale.mdc": false, "/rules/mine.mdc": true} {
+		exists, err := afero.Exists(fs, path)
+		require.NoError(t, err)
+		assert.Equal(t, want, exists, path)
+	}
+}
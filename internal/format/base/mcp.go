@@ -0,0 +1,130 @@
+package base
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"sort"
+
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+)
+
+const (
+	// mcpServersKey holds the servers in Claude and Cursor MCP config files
+	mcpServersKey = "mcpServers"
+	// mcpMarkerKey records the servers contexture manages, so servers added by
+	// hand are left alone
+	mcpMarkerKey = "_contexture"
+)
+
+// mcpMarker is the record of managed servers kept in an MCP config file
+type mcpMarker struct {
+	Servers []string `json:"servers"`
+}
+
+// WriteMCPServers updates the servers contexture manages in the MCP config
+// file at path. Servers it wrote before that are no longer configured are
+// removed; other servers and settings are preserved. The file is removed
+// when nothing is left in it.
+func (bf *Base) WriteMCPServers(path string, servers map[string]domain.MCPServer) error {
+	exists, err := bf.FileExists(path)
+	if err != nil {
+		return contextureerrors.Wrap(err, "check mcp config")
+	}
+	if !exists && len(servers) == 0 {
+		return nil
+	}
+
+	config := make(map[string]any)
+	if exists {
+		data, err := bf.ReadFile(path)
+		if err != nil {
+			return contextureerrors.Wrap(err, "read mcp config")
+		}
+		if err := json.Unmarshal(data, &config); err != nil {
+			return contextureerrors.WithOpf("parse mcp config", "%s: %w", path, err)
+		}
+		if config == nil {
+			config = make(map[string]any)
+		}
+	}
+
+	var previous mcpMarker
+	if raw, ok := config[mcpMarkerKey]; ok {
+		data, err := json.Marshal(raw)
+		if err == nil {
+			err = json.Unmarshal(data, &previous)
+		}
+		if err != nil {
+			return contextureerrors.WithOpf("parse mcp config", "%s: %w", path, err)
+		}
+	}
+	if len(previous.Servers) == 0 && len(servers) == 0 {
+		// Nothing managed before or now, so leave the file as written
+		return nil
+	}
+
+	entries := make(map[string]any)
+	if raw, ok := config[mcpServersKey]; ok {
+		existing, ok := raw.(map[string]any)
+		if !ok {
+			return contextureerrors.ValidationErrorf(mcpServersKey, "%s: %s must be an object", path, mcpServersKey)
+		}
+		entries = existing
+	}
+	for _, name := range previous.Servers {
+		if _, ok := servers[name]; !ok {
+			delete(entries, name)
+		}
+	}
+
+	names := make([]string, 0, len(servers))
+	for name, server := range servers {
+		entries[name] = mcpServerEntry(server)
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if len(entries) == 0 {
+		delete(config, mcpServersKey)
+	} else {
+		config[mcpServersKey] = entries
+	}
+	if len(names) == 0 {
+		delete(config, mcpMarkerKey)
+	} else {
+		config[mcpMarkerKey] = mcpMarker{Servers: names}
+	}
+
+	if len(config) == 0 {
+		if err := bf.RemoveFile(path); err != nil {
+			return contextureerrors.WithOpf("remove mcp config", "%s: %w", path, err)
+		}
+		bf.LogDebug("Removed MCP config file", "path", path)
+		return nil
+	}
+
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return contextureerrors.Wrap(err, "encode mcp config")
+	}
+	if err := bf.EnsureDirectory(filepath.Dir(path)); err != nil {
+		return contextureerrors.Wrap(err, "failed to create mcp config directory")
+	}
+	if err := bf.WriteFile(path, append(data, '\n')); err != nil {
+		return contextureerrors.WithOpf("write mcp config", "%s: %w", path, err)
+	}
+	bf.LogDebug("Wrote MCP config file", "path", path, "servers", len(names))
+	return nil
+}
+
+// mcpServerEntry returns the config file entry for a server. Local servers
+// omit the type, which assistants default to stdio.
+func mcpServerEntry(server domain.MCPServer) domain.MCPServer {
+	entry := server
+	entry.Type = server.Transport()
+	if entry.Type == domain.MCPTransportStdio {
+		entry.Type = ""
+	}
+	return entry
+}
@@ -0,0 +1,98 @@
+package base
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMCPPath = "/project/.mcp.json"
+
+func readTestMCPConfig(t *testing.T, fs afero.Fs) map[string]any {
+	t.Helper()
+	data, err := afero.ReadFile(fs, testMCPPath)
+	require.NoError(t, err)
+	var config map[string]any
+	require.NoError(t, json.Unmarshal(data, &config))
+	return config
+}
+
+func TestBase_WriteMCPServers(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	bf := NewBaseFormat(fs, domain.FormatClaude)
+
+	// A server added by hand
+	require.NoError(t, afero.WriteFile(fs, testMCPPath,
+		[]byte(`{"mcpServers": {"local": {"command": "./bin/mcp"}}}`), 0o644))
+
+	servers := map[string]domain.MCPServer{
+		"github": {Command: "npx", Args: []string{"-y", "@modelcontextprotocol/server-github"}},
+		"docs":   {URL: "https://mcp.example.com", Headers: map[string]string{"Authorization": "Bearer ${DOCS_TOKEN}"}},
+	}
+	require.NoError(t, bf.WriteMCPServers(testMCPPath, servers))
+
+	config := readTestMCPConfig(t, fs)
+	entries := config["mcpServers"].(map[string]any)
+	assert.Equal(t, map[string]any{"command": "./bin/mcp"}, entries["local"])
+	assert.Equal(t, map[string]any{
+		"command": "npx",
+		"args":    []any{"-y", "@modelcontextprotocol/server-github"},
+	}, entries["github"])
+	assert.Equal(t, map[string]any{
+		"type":    "http",
+		"url":     "https://mcp.example.com",
+		"headers": map[string]any{"Authorization": "Bearer ${DOCS_TOKEN}"},
+	}, entries["docs"])
+	assert.Equal(t, map[string]any{"servers": []any{"docs", "github"}}, config[mcpMarkerKey])
+
+	// Removing a server from the config removes only that managed server
+	delete(servers, "docs")
+	require.NoError(t, bf.WriteMCPServers(testMCPPath, servers))
+	entries = readTestMCPConfig(t, fs)["mcpServers"].(map[string]any)
+	assert.Contains(t, entries, "local")
+	assert.Contains(t, entries, "github")
+	assert.NotContains(t, entries, "docs")
+
+	// Without servers the file is left as it was before contexture
+	require.NoError(t, bf.WriteMCPServers(testMCPPath, nil))
+	config = readTestMCPConfig(t, fs)
+	assert.NotContains(t, config, mcpMarkerKey)
+	assert.Equal(t, map[string]any{"local": map[string]any{"command": "./bin/mcp"}}, config["mcpServers"])
+}
+
+func TestBase_WriteMCPServers_CreatesAndRemovesFile(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	bf := NewBaseFormat(fs, domain.FormatClaude)
+
+	require.NoError(t, bf.WriteMCPServers(testMCPPath, nil))
+	exists, err := afero.Exists(fs, testMCPPath)
+	require.NoError(t, err)
+	assert.False(t, exists, "no file should be created without servers")
+
+	require.NoError(t, bf.WriteMCPServers(testMCPPath, map[string]domain.MCPServer{"github": {Command: "npx"}}))
+	exists, err = afero.Exists(fs, testMCPPath)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	require.NoError(t, bf.WriteMCPServers(testMCPPath, nil))
+	exists, err = afero.Exists(fs, testMCPPath)
+	require.NoError(t, err)
+	assert.False(t, exists, "file only holding managed servers should be removed")
+}
+
+func TestBase_WriteMCPServers_InvalidFile(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	bf := NewBaseFormat(fs, domain.FormatClaude)
+	require.NoError(t, afero.WriteFile(fs, testMCPPath, []byte(`{"mcpServers": []}`), 0o644))
+
+	err := bf.WriteMCPServers(testMCPPath, map[string]domain.MCPServer{"github": {Command: "npx"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be an object")
+}
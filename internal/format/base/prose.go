@@ -0,0 +1,114 @@
+package base
+
+import (
+	"strings"
+	"unicode"
+)
+
+// smartQuotes maps typographic quotes to their plain equivalents
+var smartQuotes = strings.NewReplacer(
+	"‘", "'", "’", "'", "‚", "'", "‛", "'",
+	"“", `"`, "”", `"`, "„", `"`, "‟", `"`,
+)
+
+// PlainProse sanitizes generated content for organizations that require plain
+// prose: emoji are removed, smart quotes are made plain, and decorative
+// separator lines are dropped. Frontmatter and fenced code blocks are left as
+// written.
+func PlainProse(content string) string {
+	frontmatter, body := splitRawFrontmatter(content)
+
+	lines := strings.Split(body, "\n")
+	kept := make([]string, 0, len(lines))
+	inFence := false
+	// afterSeparator is set while the blank lines after a dropped separator are skipped
+	afterSeparator := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if afterSeparator && trimmed == "" && len(kept) > 0 && kept[len(kept)-1] == "" {
+			continue
+		}
+		afterSeparator = false
+
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			kept = append(kept, line)
+			continue
+		}
+		if inFence {
+			kept = append(kept, line)
+			continue
+		}
+
+		// A line of dashes or equals under text underlines a heading
+		setextHeading := i > 0 && strings.TrimSpace(lines[i-1]) != "" && strings.Trim(trimmed, "-=") == ""
+		if isDecorativeSeparator(trimmed) && !setextHeading {
+			// Separators between paragraphs leave a single blank line
+			afterSeparator = true
+			if len(kept) == 0 || strings.TrimSpace(kept[len(kept)-1]) != "" {
+				kept = append(kept, "")
+			}
+			continue
+		}
+
+		plain := smartQuotes.Replace(stripEmoji(line))
+		if plain != line {
+			plain = strings.TrimRight(plain, " \t")
+		}
+		kept = append(kept, plain)
+	}
+
+	return frontmatter + strings.Join(kept, "\n")
+}
+
+// isDecorativeSeparator reports whether line only repeats separator characters,
+// such as "***", "=====", or "━━━━"
+func isDecorativeSeparator(line string) bool {
+	if len([]rune(line)) < 3 {
+		return false
+	}
+	for _, r := range line {
+		if r == ' ' {
+			continue
+		}
+		if !strings.ContainsRune("-=*_~#•·─━═▬■□◆◇★☆", r) && !isEmoji(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// stripEmoji removes emoji from line, along with the space that followed an
+// emoji starting a line or word
+func stripEmoji(line string) string {
+	var plain strings.Builder
+	runes := []rune(line)
+	for i := 0; i < len(runes); i++ {
+		if !isEmoji(runes[i]) {
+			plain.WriteRune(runes[i])
+			continue
+		}
+		atWordStart := i == 0 || unicode.IsSpace(runes[i-1]) || isEmoji(runes[i-1])
+		if atWordStart && i+1 < len(runes) && runes[i+1] == ' ' {
+			i++
+		}
+	}
+	return plain.String()
+}
+
+// isEmoji reports whether r is an emoji or a character that combines emoji
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF: // pictographs, emoticons, flags
+		return true
+	case r >= 0x2600 && r <= 0x27BF: // miscellaneous symbols and dingbats
+		return true
+	case r >= 0x2B50 && r <= 0x2B55, r >= 0x231A && r <= 0x231B, r >= 0x23E9 && r <= 0x23FA:
+		return true
+	case r == 0x200D || r == 0x20E3 || r == 0xFE0E || r == 0xFE0F: // joiners, keycaps, variation selectors
+		return true
+	case r >= 0xE0020 && r <= 0xE007F: // tag sequences
+		return true
+	}
+	return false
+}
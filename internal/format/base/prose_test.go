@@ -0,0 +1,85 @@
+package base
+
+import (
+	"testing"
+
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlainProse(t *testing.T) {
+	t.Parallel()
+
+	content := "---\n" +
+		"description: \"Rules ✨\"\n" +
+		"---\n\n" +
+		"# 🚀 Getting Started\n\n" +
+		"✅ Use “descriptive” names, don’t abbreviate. 👍\n\n" +
+		"━━━━━━━━━━\n\n" +
+		"Setext Heading\n" +
+		"--------------\n\n" +
+		"* * *\n\n" +
+		"```go\n" +
+		"s := \"✨ “quoted” ✨\"\n" +
+		"\n" +
+		"---\n" +
+		"```\n"
+
+	assert.Equal(t, "---\n"+
+		"description: \"Rules ✨\"\n"+
+		"---\n\n"+
+		"# Getting Started\n\n"+
+		"Use \"descriptive\" names, don't abbreviate.\n\n"+
+		"Setext Heading\n"+
+		"--------------\n\n"+
+		"```go\n"+
+		"s := \"✨ “quoted” ✨\"\n"+
+		"\n"+
+		"---\n"+
+		"```\n", PlainProse(content))
+
+	assert.Equal(t, "Plain text stays as written.", PlainProse("Plain text stays as written."))
+}
+
+func TestCommonFormat_Write_PlainProse(t *testing.T) {
+	t.Parallel()
+	strategy := &recordingStrategy{}
+	cf := NewCommonFormat(NewBaseFormat(afero.NewMemMapFs(), domain.FormatCursor), strategy)
+
+	rule := &domain.TransformedRule{Rule: &domain.Rule{ID: "[contexture:a]"}, Content: "🎉 Done"}
+	require.NoError(t, cf.Write([]*domain.TransformedRule{rule}, &domain.FormatConfig{PlainProse: true}))
+	assert.Equal(t, "Done", strategy.written[0].Content)
+	assert.Equal(t, "🎉 Done", rule.Content, "transformed rules are not changed in place")
+
+	require.NoError(t, cf.Write([]*domain.TransformedRule{rule}, &domain.FormatConfig{}))
+	assert.Equal(t, "🎉 Done", strategy.written[0].Content)
+
+	wrapped := &domain.TransformedRule{Rule: &domain.Rule{ID: "[contexture:b]"}, Content: "One\nparagraph"}
+	require.NoError(t, cf.Write([]*domain.TransformedRule{wrapped}, &domain.FormatConfig{Wrap: -1}))
+	assert.Equal(t, "One paragraph", strategy.written[0].Content)
+}
+
+// recordingStrategy records the rules written through it, as a directory
+// format writing to outputDir
+type recordingStrategy struct {
+	FormatStrategy
+
+	outputDir string
+	assetsDir string
+	written   []*domain.TransformedRule
+}
+
+func (s *recordingStrategy) GetMetadata() *domain.FormatMetadata {
+	return &domain.FormatMetadata{IsDirectory: true, AssetsDir: s.assetsDir}
+}
+
+func (s *recordingStrategy) GetOutputPath(_ *domain.FormatConfig) string {
+	return s.outputDir
+}
+
+func (s *recordingStrategy) WriteFiles(rules []*domain.TransformedRule, _ *domain.FormatConfig) error {
+	s.written = rules
+	return nil
+}
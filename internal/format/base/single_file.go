@@ -0,0 +1,171 @@
+package base
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+)
+
+// SingleFile writes the rules of a format that appends every rule to one
+// file, such as CLAUDE.md. Hand-written keep blocks come before the rules,
+// and a custom template can replace the default header and footer.
+type SingleFile struct {
+	bf *Base
+	// name names the format in log messages
+	name string
+	// header is the first line of files written without a template
+	header string
+}
+
+// NewSingleFile creates a single-file writer for the format named name,
+// whose default layout starts with header
+func NewSingleFile(bf *Base, name, header string) *SingleFile {
+	return &SingleFile{
+		bf:     bf,
+		name:   name,
+		header: header,
+	}
+}
+
+// Write writes rules to outputPath, removing it when there are no rules and
+// no keep blocks
+func (s *SingleFile) Write(rules []*domain.TransformedRule, config *domain.FormatConfig, outputPath string) error {
+	// Hand-written keep blocks survive every rebuild
+	kept, err := s.bf.ReadKeepBlocks(outputPath)
+	if err != nil {
+		return err
+	}
+
+	if len(rules) == 0 && len(kept) == 0 {
+		s.bf.LogDebug("No rules to write for " + s.name + " format, deleting output file")
+		exists, err := s.bf.FileExists(outputPath)
+		if err != nil {
+			s.bf.LogDebug("Failed to check if file exists", "path", outputPath, "error", err)
+			return nil
+		}
+		if exists {
+			if err := s.bf.RemoveFile(outputPath); err != nil {
+				return contextureerrors.WithOpf("delete output file", "failed to delete %s: %w", outputPath, err)
+			}
+			s.bf.LogInfo("Deleted "+s.name+" format file", "path", outputPath)
+		}
+		return nil
+	}
+
+	var groupBy domain.RuleGrouping
+	if config != nil {
+		groupBy = config.GroupBy
+	}
+	rulesContent := keptContent(kept) + s.RulesContent(rules, groupBy)
+
+	var content string
+	if config != nil && config.Template != "" {
+		content, err = s.renderTemplate(rulesContent, config)
+		if err != nil {
+			return err
+		}
+	} else {
+		content = s.defaultContent(rulesContent)
+	}
+
+	if err := s.bf.WriteFile(outputPath, []byte(content)); err != nil {
+		return contextureerrors.Wrap(err, "failed to write "+s.name+" format file")
+	}
+
+	s.bf.LogInfo("Successfully wrote "+s.name+" format file", "path", outputPath, "rules", len(rules))
+	return nil
+}
+
+// RulesContent creates the formatted rules content without header/footer,
+// grouped under sections when configured
+func (s *SingleFile) RulesContent(rules []*domain.TransformedRule, groupBy domain.RuleGrouping) string {
+	if groups := GroupRules(rules, groupBy); groups != nil {
+		return GroupedContent(groups, s.joinRules)
+	}
+	return s.joinRules(rules)
+}
+
+// Header returns the header of files written without a template
+func (s *SingleFile) Header() string {
+	return s.header
+}
+
+// Footer returns the footer of files written without a template
+func (s *SingleFile) Footer() string {
+	timestamp := time.Now().Format("2006-01-02 15:04:05")
+	return fmt.Sprintf("---\n\n<!-- Generated by Contexture CLI at %s -->", timestamp)
+}
+
+// renderTemplate renders the custom template of config, relative to the
+// project directory, with the rules content as .Rules. A missing template
+// falls back to the default layout.
+func (s *SingleFile) renderTemplate(rulesContent string, config *domain.FormatConfig) (string, error) {
+	templatePath := config.Template
+	if config.BaseDir != "" {
+		templatePath = filepath.Join(config.BaseDir, config.Template)
+
+		// Validate path is within base directory to prevent path traversal
+		cleanPath, err := filepath.Abs(templatePath)
+		if err != nil {
+			return "", contextureerrors.Wrap(err, "invalid template path")
+		}
+		cleanBase, err := filepath.Abs(config.BaseDir)
+		if err != nil {
+			return "", contextureerrors.Wrap(err, "invalid base directory")
+		}
+		if !strings.HasPrefix(cleanPath, cleanBase+string(filepath.Separator)) && cleanPath != cleanBase {
+			return "", contextureerrors.WithOpf("validate template path", "template path %q is outside base directory %q", config.Template, config.BaseDir)
+		}
+	}
+
+	exists, err := s.bf.FileExists(templatePath)
+	if err != nil {
+		return "", contextureerrors.Wrap(err, "failed to check template file")
+	}
+	if !exists {
+		s.bf.LogWarn("Template file not found, falling back to default format", "template", templatePath)
+		return s.defaultContent(rulesContent), nil
+	}
+
+	templateBytes, err := s.bf.ReadFile(templatePath)
+	if err != nil {
+		return "", contextureerrors.WithOpf("read template file", "failed to read template file %s: %w", templatePath, err)
+	}
+
+	// Create a dummy rule for template processing (we only need the template engine functionality)
+	dummyRule := &domain.Rule{ID: "template", Title: "Template Processing"}
+	content, err := s.bf.ProcessTemplate(dummyRule, string(templateBytes), map[string]any{"Rules": rulesContent})
+	if err != nil {
+		return "", contextureerrors.Wrap(err, "failed to process template")
+	}
+	return content, nil
+}
+
+// defaultContent wraps the rules content in the default header and footer
+func (s *SingleFile) defaultContent(rulesContent string) string {
+	return s.header + "\n\n" + rulesContent + "\n\n" + s.Footer()
+}
+
+// joinRules joins rules with separators and tracking comments
+func (s *SingleFile) joinRules(rules []*domain.TransformedRule) string {
+	var content strings.Builder
+	for i, rule := range rules {
+		if i > 0 {
+			content.WriteString("\n\n---\n\n")
+		}
+		content.WriteString(s.bf.AppendTrackingCommentWithDefaults(rule.Content, rule.Rule.ID, rule.Rule.Variables, rule.Rule.DefaultVariables))
+	}
+	return content.String()
+}
+
+// keptContent joins keep blocks so they come before the generated rules
+func keptContent(kept []string) string {
+	if len(kept) == 0 {
+		return ""
+	}
+	return strings.Join(kept, "\n\n") + "\n\n"
+}
@@ -0,0 +1,52 @@
+package base
+
+import (
+	"testing"
+
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSingleFile_Write(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	file := NewSingleFile(NewBaseFormat(fs, domain.FormatClaude), "Claude", "# claude.md")
+	config := &domain.FormatConfig{Type: domain.FormatClaude, BaseDir: "/project"}
+	const outputPath = "/project/CLAUDE.md"
+
+	kept := WrapKeepBlock("Team notes")
+	require.NoError(t, afero.WriteFile(fs, outputPath, []byte("old\n\n"+kept+"\n\nold rule"), 0o644))
+	rules := []*domain.TransformedRule{
+		{Rule: &domain.Rule{ID: "[contexture:test/one]", Title: "One"}, Content: "Rule one"},
+		{Rule: &domain.Rule{ID: "[contexture:test/two]", Title: "Two"}, Content: "Rule two"},
+	}
+	require.NoError(t, file.Write(rules, config, outputPath))
+
+	content, err := afero.ReadFile(fs, outputPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "# claude.md\n\n"+kept+"\n\nRule one")
+	assert.Contains(t, string(content), "\n\n---\n\nRule two")
+	assert.Contains(t, string(content), "<!-- Generated by Contexture CLI at ")
+	assert.NotContains(t, string(content), "old rule")
+
+	// A template replaces the header and footer, and must stay in the project
+	require.NoError(t, afero.WriteFile(fs, "/project/CLAUDE.template.md", []byte("# Acme\n\n{{.Rules}}\n"), 0o644))
+	config.Template = "CLAUDE.template.md"
+	require.NoError(t, file.Write(rules, config, outputPath))
+	content, err = afero.ReadFile(fs, outputPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "# Acme\n\n"+kept+"\n\nRule one")
+	assert.NotContains(t, string(content), "# claude.md")
+	config.Template = "../outside.md"
+	require.Error(t, file.Write(rules, config, outputPath))
+
+	// Without rules or keep blocks the file is removed
+	config.Template = ""
+	require.NoError(t, afero.WriteFile(fs, outputPath, []byte("old"), 0o644))
+	require.NoError(t, file.Write(nil, config, outputPath))
+	exists, err := afero.Exists(fs, outputPath)
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
@@ -0,0 +1,138 @@
+package base
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// listItemPattern matches the marker of a bullet or numbered list item
+var listItemPattern = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])\s+`)
+
+// paragraph is a block of prose being rewrapped
+type paragraph struct {
+	// first starts the first line, such as a list marker
+	first string
+	// indent starts the following lines
+	indent string
+	lines  []string
+	// verbatim is set for blocks that cannot be rewrapped safely, such as
+	// tables, setext headings, and lines with hard breaks
+	verbatim bool
+}
+
+// WrapProse rewraps the paragraphs and list items of markdown content at
+// width, or unwraps each onto a single line when width is negative. Headings,
+// tables, block quotes, HTML, code, and frontmatter are left as written.
+func WrapProse(content string, width int) string {
+	if width == 0 {
+		return content
+	}
+	frontmatter, body := splitRawFrontmatter(content)
+
+	var out []string
+	var current *paragraph
+	flush := func() {
+		if current != nil {
+			out = append(out, current.wrap(width)...)
+			current = nil
+		}
+	}
+
+	inFence := false
+	for _, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			flush()
+			inFence = !inFence
+			out = append(out, line)
+			continue
+		}
+		if inFence {
+			out = append(out, line)
+			continue
+		}
+
+		switch {
+		case current != nil && trimmed != "" && strings.Trim(trimmed, "-=") == "":
+			// An underline turns the paragraph into a setext heading
+			current.verbatim = true
+			current.lines = append(current.lines, line)
+			flush()
+		case trimmed == "" || isBlockLine(trimmed) || current == nil && isIndentedCode(line):
+			flush()
+			out = append(out, line)
+		case listItemPattern.MatchString(line):
+			flush()
+			marker := listItemPattern.FindString(line)
+			current = &paragraph{
+				first:  marker,
+				indent: strings.Repeat(" ", utf8.RuneCountInString(marker)),
+				lines:  []string{line},
+			}
+		default:
+			if current == nil {
+				indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+				current = &paragraph{first: indent, indent: indent}
+			}
+			current.lines = append(current.lines, line)
+		}
+
+		if current != nil && (strings.HasSuffix(line, "  ") || strings.HasSuffix(line, "\\") || strings.Contains(line, "|")) {
+			current.verbatim = true
+		}
+	}
+	flush()
+
+	return frontmatter + strings.Join(out, "\n")
+}
+
+// isBlockLine reports whether a trimmed line starts a block that is never
+// rewrapped: a heading, block quote, HTML, or thematic break
+func isBlockLine(trimmed string) bool {
+	if strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, ">") || strings.HasPrefix(trimmed, "<") {
+		return true
+	}
+	return isDecorativeSeparator(trimmed)
+}
+
+// isIndentedCode reports whether line is indented enough to be a code block
+func isIndentedCode(line string) bool {
+	return strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t")
+}
+
+// wrap returns the lines of the paragraph rewrapped at width
+func (p *paragraph) wrap(width int) []string {
+	if p.verbatim {
+		return p.lines
+	}
+
+	// The list marker is not a word of the paragraph
+	text := strings.Join(p.lines, " ")
+	text = strings.TrimPrefix(text, p.first)
+	words := strings.Fields(text)
+	if width < 0 {
+		return []string{p.first + strings.Join(words, " ")}
+	}
+
+	var lines []string
+	line := p.first
+	lineWidth := utf8.RuneCountInString(line)
+	empty := true
+	for _, word := range words {
+		wordWidth := utf8.RuneCountInString(word)
+		// Words longer than the width get a line of their own
+		if !empty && lineWidth+1+wordWidth > width {
+			lines = append(lines, line)
+			line, lineWidth, empty = p.indent, utf8.RuneCountInString(p.indent), true
+		}
+		if !empty {
+			line += " "
+			lineWidth++
+		}
+		line += word
+		lineWidth += wordWidth
+		empty = false
+	}
+	return append(lines, line)
+}
@@ -0,0 +1,48 @@
+package base
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrapProse(t *testing.T) {
+	t.Parallel()
+
+	content := "---\n" +
+		"description: \"A long description that is never wrapped because it is frontmatter\"\n" +
+		"---\n\n" +
+		"# A heading that is longer than the width\n\n" +
+		"Prefer small functions that\ndo one thing and name them after what they do.\n\n" +
+		"- Return errors instead of panicking in library code.\n" +
+		"  Wrap them with context.\n" +
+		"1. Numbered items wrap too.\n\n" +
+		"| Table | Row that is long enough to wrap |\n\n" +
+		"Setext heading\n" +
+		"==============\n\n" +
+		"```\n" +
+		"code that is long enough to wrap but stays as written\n" +
+		"```\n"
+
+	assert.Equal(t, "---\n"+
+		"description: \"A long description that is never wrapped because it is frontmatter\"\n"+
+		"---\n\n"+
+		"# A heading that is longer than the width\n\n"+
+		"Prefer small functions that do\n"+
+		"one thing and name them after\n"+
+		"what they do.\n\n"+
+		"- Return errors instead of\n"+
+		"  panicking in library code.\n"+
+		"  Wrap them with context.\n"+
+		"1. Numbered items wrap too.\n\n"+
+		"| Table | Row that is long enough to wrap |\n\n"+
+		"Setext heading\n"+
+		"==============\n\n"+
+		"```\n"+
+		"code that is long enough to wrap but stays as written\n"+
+		"```\n", WrapProse(content, 30))
+
+	assert.Equal(t, "Prefer small functions that do one thing.\n\n- An item continued.",
+		WrapProse("Prefer small functions\nthat do one thing.\n\n- An item\n  continued.", -1))
+	assert.Equal(t, "Left\nas written", WrapProse("Left\nas written", 0))
+}
@@ -4,7 +4,9 @@ import (
 	"github.com/contextureai/contexture/internal/domain"
 	contextureerrors "github.com/contextureai/contexture/internal/errors"
 	"github.com/contextureai/contexture/internal/format/claude"
+	"github.com/contextureai/contexture/internal/format/copilot"
 	"github.com/contextureai/contexture/internal/format/cursor"
+	"github.com/contextureai/contexture/internal/format/gemini"
 	"github.com/contextureai/contexture/internal/format/windsurf"
 	"github.com/spf13/afero"
 )
@@ -27,6 +29,8 @@ func NewBuilder() *Builder {
 	builder.Register(domain.FormatClaude, claude.NewFormatFromOptions)
 	builder.Register(domain.FormatCursor, cursor.NewFormatFromOptions)
 	builder.Register(domain.FormatWindsurf, windsurf.NewFormatFromOptions)
+	builder.Register(domain.FormatCopilot, copilot.NewFormatFromOptions)
+	builder.Register(domain.FormatGemini, gemini.NewFormatFromOptions)
 
 	return builder
 }
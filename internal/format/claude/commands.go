@@ -0,0 +1,110 @@
+package claude
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+)
+
+// commandsDirName is the directory Claude reads custom slash commands from
+const commandsDirName = "commands"
+
+// GetCommandsDir returns the directory slash commands are written to: .claude/commands
+// in the project, or commands inside ~/.claude for user rules
+func (s *Strategy) GetCommandsDir(config *domain.FormatConfig) string {
+	baseDir := "."
+	if config != nil && config.BaseDir != "" {
+		baseDir = config.BaseDir
+	}
+	if config != nil && config.IsUserRules {
+		return filepath.Join(baseDir, commandsDirName)
+	}
+	return filepath.Join(baseDir, ".claude", commandsDirName)
+}
+
+// commandFilename returns the slash command file for a rule: the last segment
+// of its path, so [contexture:workflows/review-pr] becomes /review-pr
+func commandFilename(ruleID string) string {
+	return path.Base(domain.ExtractRulePath(ruleID)) + ".md"
+}
+
+// transformCommand converts a command rule to a slash command file
+func (f *Format) transformCommand(processedRule *domain.ProcessedRule) (*domain.TransformedRule, error) {
+	rule := processedRule.Rule
+	content, err := f.RenderContent(processedRule)
+	if err != nil {
+		return nil, err
+	}
+
+	// Claude shows the description in the slash command menu
+	if rule.Description != "" {
+		content = fmt.Sprintf("---\ndescription: %q\n---\n\n%s", rule.Description, strings.TrimLeft(content, "\n"))
+	}
+
+	filename := commandFilename(rule.ID)
+	return f.strategy.bf.CreateTransformedRule(rule, content, filename, filepath.Join(".claude", commandsDirName, filename),
+		map[string]any{"kind": string(domain.RuleKindCommand)}), nil
+}
+
+// writeCommands writes command rules to the commands directory and removes
+// command files generated earlier for rules that are no longer configured.
+// Command files without a tracking comment were not generated and are left alone.
+func (s *Strategy) writeCommands(commands []*domain.TransformedRule, config *domain.FormatConfig) error {
+	commandsDir := s.GetCommandsDir(config)
+
+	// written maps the command files written to the rules they came from
+	written := make(map[string]string, len(commands))
+	if len(commands) > 0 {
+		if err := s.bf.EnsureDirectory(commandsDir); err != nil {
+			return contextureerrors.Wrap(err, "failed to create commands directory")
+		}
+	}
+	for _, command := range commands {
+		filePath := filepath.Join(commandsDir, command.Filename)
+		if other, ok := written[command.Filename]; ok {
+			return contextureerrors.ValidationErrorf("kind",
+				"command rules %s and %s both generate %s", other, command.Rule.ID, filePath)
+		}
+		content := s.bf.AppendTrackingCommentWithDefaults(command.Content, command.Rule.ID, command.Rule.Variables, command.Rule.DefaultVariables)
+		if err := s.bf.WriteFile(filePath, []byte(content)); err != nil {
+			return contextureerrors.WithOpf("write command", "%s: %w", command.Rule.ID, err)
+		}
+		written[command.Filename] = command.Rule.ID
+		s.bf.LogDebug("Wrote Claude command file", "ruleID", command.Rule.ID, "path", filePath)
+	}
+
+	exists, err := s.bf.DirExists(commandsDir)
+	if err != nil || !exists {
+		return nil
+	}
+	files, err := s.bf.ListDirectory(commandsDir)
+	if err != nil {
+		return contextureerrors.Wrap(err, "list commands directory")
+	}
+	for _, file := range files {
+		if _, ok := written[file.Name()]; ok || file.IsDir() || filepath.Ext(file.Name()) != ".md" {
+			continue
+		}
+		filePath := filepath.Join(commandsDir, file.Name())
+		data, err := s.bf.ReadFile(filePath)
+		if err != nil || len(s.bf.ExtractTrackingComments(string(data))) == 0 {
+			continue
+		}
+		if err := s.bf.RemoveFile(filePath); err != nil {
+			return contextureerrors.WithOpf("remove command", "%s: %w", filePath, err)
+		}
+		s.bf.LogDebug("Removed stale Claude command file", "path", filePath)
+	}
+
+	if len(commands) == 0 {
+		s.bf.CleanupEmptyDirectory(commandsDir)
+		if config == nil || !config.IsUserRules {
+			s.bf.CleanupEmptyDirectory(filepath.Dir(commandsDir))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package claude
+
+import (
+	"testing"
+
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormat_Transform_Command(t *testing.T) {
+	t.Parallel()
+	f := NewFormat(afero.NewMemMapFs())
+
+	rule := &domain.Rule{
+		ID:          "[contexture:workflows/review-pr]",
+		Title:       "Review PR",
+		Description: "Review the current pull request",
+		Kind:        domain.RuleKindCommand,
+		Content:     "Review the changes on this branch.",
+	}
+	transformed, err := f.Transform(&domain.ProcessedRule{Rule: rule, Content: rule.Content})
+	require.NoError(t, err)
+
+	assert.Equal(t, "review-pr.md", transformed.Filename)
+	assert.Equal(t, ".claude/commands/review-pr.md", transformed.RelativePath)
+	assert.Equal(t, "---\ndescription: \"Review the current pull request\"\n---\n\nReview the changes on this branch.", transformed.Content)
+	assert.Equal(t, "command", transformed.Metadata["kind"])
+}
+
+func TestFormat_Write_Commands(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	f := NewFormat(fs)
+	config := &domain.FormatConfig{BaseDir: "/project"}
+
+	// A stale command generated earlier and one written by hand
+	require.NoError(t, afero.WriteFile(fs, "/project/.claude/commands/old.md",
+		[]byte("Old\n\n<!-- id: [contexture:workflows/old] -->\n"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "/project/.claude/commands/mine.md", []byte("Mine\n"), 0o644))
+
+	rules := []*domain.TransformedRule{
+		{
+			Rule:     &domain.Rule{ID: "[contexture:test/context]", Title: "Context"},
+			Content:  "Context content",
+			Filename: "CLAUDE.md",
+		},
+		{
+			Rule:     &domain.Rule{ID: "[contexture:workflows/review-pr]", Kind: domain.RuleKindCommand},
+			Content:  "Review the changes",
+			Filename: "review-pr.md",
+		},
+	}
+	require.NoError(t, f.Write(rules, config))
+
+	claudeMD, err := afero.ReadFile(fs, "/project/CLAUDE.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(claudeMD), "Context content")
+	assert.NotContains(t, string(claudeMD), "Review the changes")
+
+	command, err := afero.ReadFile(fs, "/project/.claude/commands/review-pr.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(command), "Review the changes")
+	assert.Contains(t, string(command), "[contexture:workflows/review-pr]")
+
+	exists, err := afero.Exists(fs, "/project/.claude/commands/old.md")
+	require.NoError(t, err)
+	assert.False(t, exists, "stale generated command should be removed")
+
+	exists, err = afero.Exists(fs, "/project/.claude/commands/mine.md")
+	require.NoError(t, err)
+	assert.True(t, exists, "hand-written command should be kept")
+}
+
+func TestFormat_Write_DuplicateCommands(t *testing.T) {
+	t.Parallel()
+	f := NewFormat(afero.NewMemMapFs())
+
+	rules := []*domain.TransformedRule{
+		{Rule: &domain.Rule{ID: "[contexture:a/review]", Kind: domain.RuleKindCommand}, Content: "A", Filename: "review.md"},
+		{Rule: &domain.Rule{ID: "[contexture:b/review]", Kind: domain.RuleKindCommand}, Content: "B", Filename: "review.md"},
+	}
+	err := f.Write(rules, &domain.FormatConfig{BaseDir: "/project"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "both generate")
+}
+
+func TestStrategy_GetCommandsDir(t *testing.T) {
+	t.Parallel()
+	s := NewStrategy(afero.NewMemMapFs(), nil)
+
+	assert.Equal(t, ".claude/commands", s.GetCommandsDir(nil))
+	assert.Equal(t, "/project/.claude/commands", s.GetCommandsDir(&domain.FormatConfig{BaseDir: "/project"}))
+	assert.Equal(t, "/home/user/.claude/commands",
+		s.GetCommandsDir(&domain.FormatConfig{BaseDir: "/home/user/.claude", IsUserRules: true}))
+}
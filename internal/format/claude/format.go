@@ -1,13 +1,9 @@
 package claude
 
 import (
-	"fmt"
 	"path/filepath"
-	"strings"
-	"time"
 
 	"github.com/contextureai/contexture/internal/domain"
-	contextureerrors "github.com/contextureai/contexture/internal/errors"
 	"github.com/contextureai/contexture/internal/format/base"
 	"github.com/spf13/afero"
 )
@@ -16,15 +12,17 @@ const defaultClaudeFilename = "CLAUDE.md"
 
 // Strategy implements the FormatStrategy interface for Claude format
 type Strategy struct {
-	fs afero.Fs
-	bf *base.Base
+	fs   afero.Fs
+	bf   *base.Base
+	file *base.SingleFile
 }
 
 // NewStrategy creates a new Claude strategy
 func NewStrategy(fs afero.Fs, bf *base.Base) *Strategy {
 	return &Strategy{
-		fs: fs,
-		bf: bf,
+		fs:   fs,
+		bf:   bf,
+		file: base.NewSingleFile(bf, "Claude", "# claude.md"),
 	}
 }
 
@@ -52,6 +50,9 @@ func (s *Strategy) GetOutputPath(config *domain.FormatConfig) string {
 	if config == nil {
 		return filename
 	}
+	if config.IsUserRules && config.UserRulesFile != "" {
+		return config.UserRulesFile
+	}
 
 	baseDir := config.BaseDir
 	if baseDir == "" {
@@ -81,43 +82,34 @@ func (s *Strategy) GenerateFilename(_ string) string {
 // GetMetadata returns metadata about Claude format
 func (s *Strategy) GetMetadata() *domain.FormatMetadata {
 	return &domain.FormatMetadata{
-		Type:        domain.FormatClaude,
-		DisplayName: "Claude AI Assistant",
-		Description: "Single-file format for Claude AI assistant (CLAUDE.md)",
-		IsDirectory: false,
+		Type:             domain.FormatClaude,
+		DisplayName:      "Claude AI Assistant",
+		Description:      "Single-file format for Claude AI assistant (CLAUDE.md)",
+		IsDirectory:      false,
+		SupportsCommands: true,
+		AssetsDir:        ".claude/assets",
 	}
 }
 
-// WriteFiles handles writing rules for Claude format (single file or custom template)
+// WriteFiles handles writing rules for Claude format (single file or custom template).
+// Command rules are written as slash commands instead, and configured settings
+// are written to .claude/settings.json.
 func (s *Strategy) WriteFiles(rules []*domain.TransformedRule, config *domain.FormatConfig) error {
-	outputPath := s.GetOutputPath(config)
-
-	// When no rules, delete the output file if it exists
-	if len(rules) == 0 {
-		s.bf.LogDebug("No rules to write for Claude format, deleting output file")
-		exists, err := s.bf.FileExists(outputPath)
-		if err != nil {
-			s.bf.LogDebug("Failed to check if file exists", "path", outputPath, "error", err)
-			return nil
-		}
-		if exists {
-			if err := s.bf.RemoveFile(outputPath); err != nil {
-				return contextureerrors.WithOpf("delete output file", "failed to delete %s: %w", outputPath, err)
-			}
-			s.bf.LogInfo("Deleted Claude format file", "path", outputPath)
+	var commands, contextRules []*domain.TransformedRule
+	for _, rule := range rules {
+		if rule.Rule.IsCommand() {
+			commands = append(commands, rule)
+		} else {
+			contextRules = append(contextRules, rule)
 		}
-		return nil
 	}
-
-	s.bf.LogDebug("Writing Claude format file", "rules", len(rules))
-
-	// Check if a custom template is specified
-	if config != nil && config.Template != "" {
-		return s.writeWithTemplate(rules, config, outputPath)
+	if err := s.writeCommands(commands, config); err != nil {
+		return err
 	}
-
-	// Default behavior: write without custom template
-	return s.writeWithoutTemplate(rules, outputPath)
+	if err := s.writeSettings(config); err != nil {
+		return err
+	}
+	return s.file.Write(contextRules, config, s.GetOutputPath(config))
 }
 
 // CleanupEmptyDirectories handles cleanup for Claude format (no-op since it's file-based)
@@ -132,146 +124,6 @@ func (s *Strategy) CreateDirectories(_ *domain.FormatConfig) error {
 	return nil
 }
 
-// writeWithTemplate processes rules using a custom template file
-func (s *Strategy) writeWithTemplate(rules []*domain.TransformedRule, config *domain.FormatConfig, outputPath string) error {
-	s.bf.LogDebug("Using custom template for Claude format", "template", config.Template)
-
-	// Get template path - relative to project directory with validation
-	var templatePath string
-	if config.BaseDir != "" {
-		templatePath = filepath.Join(config.BaseDir, config.Template)
-
-		// Validate path is within base directory to prevent path traversal
-		cleanPath, err := filepath.Abs(templatePath)
-		if err != nil {
-			return contextureerrors.Wrap(err, "invalid template path")
-		}
-
-		cleanBase, err := filepath.Abs(config.BaseDir)
-		if err != nil {
-			return contextureerrors.Wrap(err, "invalid base directory")
-		}
-
-		// Ensure template path is within base directory
-		if !strings.HasPrefix(cleanPath, cleanBase+string(filepath.Separator)) && cleanPath != cleanBase {
-			return contextureerrors.WithOpf("validate template path", "template path %q is outside base directory %q", config.Template, config.BaseDir)
-		}
-	} else {
-		templatePath = config.Template
-	}
-
-	// Check if template file exists
-	exists, err := s.bf.FileExists(templatePath)
-	if err != nil {
-		return contextureerrors.Wrap(err, "failed to check template file")
-	}
-	if !exists {
-		s.bf.LogWarn("Template file not found, falling back to default format", "template", templatePath)
-		return s.writeWithoutTemplate(rules, outputPath)
-	}
-
-	// Read template content
-	templateBytes, err := s.bf.ReadFile(templatePath)
-	if err != nil {
-		return contextureerrors.WithOpf("read template file", "failed to read template file %s: %w", templatePath, err)
-	}
-	templateContent := string(templateBytes)
-
-	// Generate rules content (same as default format but without header/footer)
-	rulesContent := s.generateRulesContent(rules)
-
-	// Process template with rules content
-	variables := map[string]any{
-		"Rules": rulesContent,
-	}
-
-	// Create a dummy rule for template processing (we only need the template engine functionality)
-	dummyRule := &domain.Rule{ID: "template", Title: "Template Processing"}
-	processedContent, err := s.bf.ProcessTemplate(dummyRule, templateContent, variables)
-	if err != nil {
-		return contextureerrors.Wrap(err, "failed to process template")
-	}
-
-	// Write to file
-	if err := s.bf.WriteFile(outputPath, []byte(processedContent)); err != nil {
-		return contextureerrors.Wrap(err, "failed to write Claude format file with template")
-	}
-
-	s.bf.LogInfo("Successfully wrote Claude format file using template", "path", outputPath, "template", config.Template, "rules", len(rules))
-	return nil
-}
-
-// writeWithoutTemplate is the default write behavior
-func (s *Strategy) writeWithoutTemplate(rules []*domain.TransformedRule, outputPath string) error {
-	// Combine all rules into a single document
-	var content strings.Builder
-	content.Grow(s.estimateContentSize(rules))
-
-	// Write header
-	content.WriteString(s.getFileHeader(len(rules)))
-	content.WriteString("\n\n")
-
-	// Write rules content
-	content.WriteString(s.generateRulesContent(rules))
-
-	// Write footer
-	content.WriteString("\n\n")
-	content.WriteString(s.getFileFooter())
-
-	// Write to file
-	if err := s.bf.WriteFile(outputPath, []byte(content.String())); err != nil {
-		return contextureerrors.Wrap(err, "failed to write Claude format file")
-	}
-
-	s.bf.LogInfo("Successfully wrote Claude format file", "path", outputPath, "rules", len(rules))
-	return nil
-}
-
-// generateRulesContent creates the formatted rules content without header/footer
-func (s *Strategy) generateRulesContent(rules []*domain.TransformedRule) string {
-	var content strings.Builder
-
-	for i, rule := range rules {
-		if i > 0 {
-			content.WriteString("\n\n---\n\n")
-		}
-
-		// Write rule content
-		ruleContent := rule.Content
-
-		// Append tracking comment using the new system, only including non-default variables
-		ruleContent = s.bf.AppendTrackingCommentWithDefaults(ruleContent, rule.Rule.ID, rule.Rule.Variables, rule.Rule.DefaultVariables)
-
-		content.WriteString(ruleContent)
-	}
-
-	return content.String()
-}
-
-// getFileHeader returns the header for the Claude format file
-func (s *Strategy) getFileHeader(_ int) string {
-	return "# claude.md"
-}
-
-// getFileFooter returns the footer for the Claude format file
-func (s *Strategy) getFileFooter() string {
-	timestamp := time.Now().Format("2006-01-02 15:04:05")
-	return fmt.Sprintf("---\n\n<!-- Generated by Contexture CLI at %s -->", timestamp)
-}
-
-// estimateContentSize estimates the total size needed for the Claude format file
-func (s *Strategy) estimateContentSize(rules []*domain.TransformedRule) int {
-	// Start with header + footer overhead
-	size := 1024
-
-	// Add space for each rule's content plus separators
-	for _, rule := range rules {
-		size += len(rule.Content) + 200 // Content + tracking comment + separator overhead
-	}
-
-	return size
-}
-
 // Format implements the Claude single-file format using CommonFormat
 type Format struct {
 	*base.CommonFormat
@@ -291,6 +143,15 @@ func NewFormat(fs afero.Fs) *Format {
 	}
 }
 
+// Transform converts a processed rule to its Claude representation, using
+// slash command files for command rules
+func (f *Format) Transform(processedRule *domain.ProcessedRule) (*domain.TransformedRule, error) {
+	if processedRule.Rule.IsCommand() {
+		return f.transformCommand(processedRule)
+	}
+	return f.CommonFormat.Transform(processedRule)
+}
+
 // NewFormatFromOptions creates a new Claude format with options
 func NewFormatFromOptions(fs afero.Fs, _ map[string]any) (domain.Format, error) {
 	return NewFormat(fs), nil
@@ -307,12 +168,12 @@ func (f *Format) getDefaultTemplate() string {
 	return f.strategy.GetDefaultTemplate()
 }
 
-func (f *Format) getFileHeader(ruleCount int) string {
-	return f.strategy.getFileHeader(ruleCount)
+func (f *Format) getFileHeader(_ int) string {
+	return f.strategy.file.Header()
 }
 
 func (f *Format) getFileFooter() string {
-	return f.strategy.getFileFooter()
+	return f.strategy.file.Footer()
 }
 
 func (f *Format) getOutputFilename() string {
@@ -320,5 +181,5 @@ func (f *Format) getOutputFilename() string {
 }
 
 func (f *Format) generateRulesContent(rules []*domain.TransformedRule) string {
-	return f.strategy.generateRulesContent(rules)
+	return f.strategy.file.RulesContent(rules, "")
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/contextureai/contexture/internal/domain"
+	"github.com/contextureai/contexture/internal/format/base"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -65,6 +66,23 @@ func TestFormat_Transform(t *testing.T) {
 	assert.Equal(t, "CLAUDE.md", transformed.Metadata["filename"])
 }
 
+func TestFormat_Transform_FormatSections(t *testing.T) {
+	t.Parallel()
+	f := NewFormat(afero.NewMemMapFs())
+
+	rule := &domain.Rule{
+		ID:      "[contexture:test/sections]",
+		Title:   "Sections",
+		Content: "Shared\n\n:::only claude\nFor Claude\n:::\n\n:::only cursor\nFor Cursor\n:::\n",
+	}
+	transformed, err := f.Transform(&domain.ProcessedRule{Rule: rule, Content: rule.Content})
+	require.NoError(t, err)
+
+	assert.Contains(t, transformed.Content, "For Claude")
+	assert.NotContains(t, transformed.Content, "For Cursor")
+	assert.NotContains(t, transformed.Content, ":::")
+}
+
 func TestFormat_Transform_MinimalRule(t *testing.T) {
 	t.Parallel()
 	fs := afero.NewMemMapFs()
@@ -652,3 +670,54 @@ func TestFormat_generateRulesContent(t *testing.T) {
 		assert.Empty(t, content)
 	})
 }
+
+func TestStrategy_WriteFiles_KeepBlocks(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	f := NewFormat(fs)
+	config := &domain.FormatConfig{Type: domain.FormatClaude, BaseDir: "/project"}
+
+	kept := base.WrapKeepBlock("Hand-written notes")
+	require.NoError(t, afero.WriteFile(fs, "/project/CLAUDE.md", []byte("old output\n\n"+kept+"\n\nold rule"), 0o644))
+
+	rules := []*domain.TransformedRule{{
+		Rule:    &domain.Rule{ID: "[contexture:test/rule]", Title: "Rule"},
+		Content: "Rule content",
+	}}
+	require.NoError(t, f.strategy.WriteFiles(rules, config))
+
+	content, err := afero.ReadFile(fs, "/project/CLAUDE.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(content), kept+"\n\nRule content")
+	assert.NotContains(t, string(content), "old rule")
+
+	// Without rules the file is kept for its keep blocks
+	require.NoError(t, f.strategy.WriteFiles(nil, config))
+	content, err = afero.ReadFile(fs, "/project/CLAUDE.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(content), kept)
+	assert.NotContains(t, string(content), "Rule content")
+}
+
+func TestStrategy_WriteFiles_GroupBy(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	f := NewFormat(fs)
+	config := &domain.FormatConfig{Type: domain.FormatClaude, BaseDir: "/project", GroupBy: domain.GroupByFolder}
+
+	rules := []*domain.TransformedRule{
+		{Rule: &domain.Rule{ID: "[contexture:languages/go/errors]"}, Content: "Go errors"},
+		{Rule: &domain.Rule{ID: "[contexture:languages/go/testing]"}, Content: "Go testing"},
+		{Rule: &domain.Rule{ID: "[contexture:security/secrets]"}, Content: "Secrets"},
+	}
+	require.NoError(t, f.strategy.WriteFiles(rules, config))
+
+	content, err := afero.ReadFile(fs, "/project/CLAUDE.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "## Contents\n\n"+
+		"- [languages/go](#languagesgo) (2)\n"+
+		"- [security](#security) (1)\n")
+	assert.Contains(t, string(content), "## languages/go\n\nGo errors")
+	assert.Contains(t, string(content), "Go testing")
+	assert.Contains(t, string(content), "## security\n\nSecrets")
+}
@@ -0,0 +1,28 @@
+package claude
+
+import (
+	"path/filepath"
+
+	"github.com/contextureai/contexture/internal/domain"
+)
+
+// mcpConfigFilename is the project MCP config Claude reads from the project root
+const mcpConfigFilename = ".mcp.json"
+
+// GetMCPConfigPath returns the path of the project's .mcp.json
+func (s *Strategy) GetMCPConfigPath(config *domain.FormatConfig) string {
+	baseDir := "."
+	if config != nil && config.BaseDir != "" {
+		baseDir = config.BaseDir
+	}
+	return filepath.Join(baseDir, mcpConfigFilename)
+}
+
+// WriteMCPServers writes the project's MCP servers to .mcp.json. User rules
+// are skipped, since the servers belong to the project.
+func (f *Format) WriteMCPServers(servers map[string]domain.MCPServer, config *domain.FormatConfig) error {
+	if config != nil && config.IsUserRules {
+		return nil
+	}
+	return f.strategy.bf.WriteMCPServers(f.strategy.GetMCPConfigPath(config), servers)
+}
@@ -0,0 +1,27 @@
+package claude
+
+import (
+	"testing"
+
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormat_WriteMCPServers(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	f := NewFormat(fs)
+	servers := map[string]domain.MCPServer{"github": {Command: "npx"}}
+
+	require.NoError(t, f.WriteMCPServers(servers, &domain.FormatConfig{BaseDir: "/project"}))
+	exists, err := afero.Exists(fs, "/project/.mcp.json")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	require.NoError(t, f.WriteMCPServers(servers, &domain.FormatConfig{BaseDir: "/home/user/.claude", IsUserRules: true}))
+	exists, err = afero.Exists(fs, "/home/user/.claude/.mcp.json")
+	require.NoError(t, err)
+	assert.False(t, exists, "user rules should not write project MCP servers")
+}
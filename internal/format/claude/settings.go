@@ -0,0 +1,196 @@
+package claude
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+)
+
+const (
+	// settingsFilename is the project settings file Claude reads from .claude/
+	settingsFilename = "settings.json"
+	// settingsMarkerKey records the permission entries contexture manages, so
+	// they can be updated without touching entries added by hand
+	settingsMarkerKey = "_contexture"
+)
+
+// managedSettings are the permission entries contexture owns in settings.json
+type managedSettings struct {
+	Allow []string `json:"allow,omitempty"`
+	Deny  []string `json:"deny,omitempty"`
+}
+
+func (m managedSettings) empty() bool {
+	return len(m.Allow) == 0 && len(m.Deny) == 0
+}
+
+// GetSettingsPath returns the path of the project's .claude/settings.json
+func (s *Strategy) GetSettingsPath(config *domain.FormatConfig) string {
+	baseDir := "."
+	if config != nil && config.BaseDir != "" {
+		baseDir = config.BaseDir
+	}
+	return filepath.Join(baseDir, ".claude", settingsFilename)
+}
+
+// settingsEntries converts the configured settings to permission entries.
+// Ignore patterns become Read denials relative to the project root.
+func settingsEntries(settings *domain.ClaudeSettings) managedSettings {
+	if settings == nil {
+		return managedSettings{}
+	}
+	entries := managedSettings{
+		Allow: slices.Clone(settings.AllowedTools),
+		Deny:  slices.Clone(settings.DeniedTools),
+	}
+	for _, pattern := range settings.IgnorePatterns {
+		if !strings.HasPrefix(pattern, "/") && !strings.HasPrefix(pattern, "./") && !strings.HasPrefix(pattern, "~/") {
+			pattern = "./" + pattern
+		}
+		entries.Deny = append(entries.Deny, "Read("+pattern+")")
+	}
+	return entries
+}
+
+// writeSettings updates the managed permission entries in .claude/settings.json.
+// Other settings and entries added by hand are preserved. User rules never
+// change the user's own settings.
+func (s *Strategy) writeSettings(config *domain.FormatConfig) error {
+	if config != nil && config.IsUserRules {
+		return nil
+	}
+
+	var wanted managedSettings
+	if config != nil {
+		wanted = settingsEntries(config.Settings)
+	}
+
+	settingsPath := s.GetSettingsPath(config)
+	exists, err := s.bf.FileExists(settingsPath)
+	if err != nil {
+		return contextureerrors.Wrap(err, "check claude settings")
+	}
+	if !exists && wanted.empty() {
+		return nil
+	}
+
+	settings := make(map[string]any)
+	if exists {
+		data, err := s.bf.ReadFile(settingsPath)
+		if err != nil {
+			return contextureerrors.Wrap(err, "read claude settings")
+		}
+		if err := json.Unmarshal(data, &settings); err != nil {
+			return contextureerrors.WithOpf("parse claude settings", "%s: %w", settingsPath, err)
+		}
+		if settings == nil {
+			settings = make(map[string]any)
+		}
+	}
+
+	previous, err := decodeManagedSettings(settings[settingsMarkerKey])
+	if err != nil {
+		return contextureerrors.WithOpf("parse claude settings", "%s: %w", settingsPath, err)
+	}
+	if previous.empty() && wanted.empty() {
+		// Nothing managed before or now, so leave the file as written
+		return nil
+	}
+
+	permissions := make(map[string]any)
+	if raw, ok := settings["permissions"]; ok {
+		existing, ok := raw.(map[string]any)
+		if !ok {
+			return contextureerrors.ValidationErrorf("permissions", "%s: permissions must be an object", settingsPath)
+		}
+		permissions = existing
+	}
+	for key, entries := range map[string][2][]string{
+		"allow": {previous.Allow, wanted.Allow},
+		"deny":  {previous.Deny, wanted.Deny},
+	} {
+		if err := setPermissionEntries(permissions, key, entries[0], entries[1]); err != nil {
+			return contextureerrors.ValidationErrorf("permissions", "%s: %v", settingsPath, err)
+		}
+	}
+
+	if len(permissions) == 0 {
+		delete(settings, "permissions")
+	} else {
+		settings["permissions"] = permissions
+	}
+	if wanted.empty() {
+		delete(settings, settingsMarkerKey)
+	} else {
+		settings[settingsMarkerKey] = wanted
+	}
+
+	if len(settings) == 0 {
+		if err := s.bf.RemoveFile(settingsPath); err != nil {
+			return contextureerrors.WithOpf("remove claude settings", "%s: %w", settingsPath, err)
+		}
+		s.bf.CleanupEmptyDirectory(filepath.Dir(settingsPath))
+		s.bf.LogDebug("Removed Claude settings file", "path", settingsPath)
+		return nil
+	}
+
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return contextureerrors.Wrap(err, "encode claude settings")
+	}
+	if err := s.bf.EnsureDirectory(filepath.Dir(settingsPath)); err != nil {
+		return contextureerrors.Wrap(err, "failed to create .claude directory")
+	}
+	if err := s.bf.WriteFile(settingsPath, append(data, '\n')); err != nil {
+		return contextureerrors.WithOpf("write claude settings", "%s: %w", settingsPath, err)
+	}
+	s.bf.LogDebug("Wrote Claude settings file", "path", settingsPath)
+	return nil
+}
+
+// decodeManagedSettings reads the marker recorded by a previous build
+func decodeManagedSettings(raw any) (managedSettings, error) {
+	var managed managedSettings
+	if raw == nil {
+		return managed, nil
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return managed, err
+	}
+	err = json.Unmarshal(data, &managed)
+	return managed, err
+}
+
+// setPermissionEntries replaces the previously managed entries of a permission
+// list with the wanted ones, keeping entries added by hand first
+func setPermissionEntries(permissions map[string]any, key string, previous, wanted []string) error {
+	var entries []any
+	if raw, ok := permissions[key]; ok {
+		existing, ok := raw.([]any)
+		if !ok {
+			return fmt.Errorf("permissions.%s must be a list", key)
+		}
+		for _, entry := range existing {
+			if value, ok := entry.(string); ok && (slices.Contains(previous, value) || slices.Contains(wanted, value)) {
+				continue
+			}
+			entries = append(entries, entry)
+		}
+	}
+	for _, value := range wanted {
+		entries = append(entries, value)
+	}
+
+	if len(entries) == 0 {
+		delete(permissions, key)
+		return nil
+	}
+	permissions[key] = entries
+	return nil
+}
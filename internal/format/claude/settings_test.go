@@ -0,0 +1,126 @@
+package claude
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSettingsPath = "/project/.claude/settings.json"
+
+func readTestSettings(t *testing.T, fs afero.Fs) map[string]any {
+	t.Helper()
+	data, err := afero.ReadFile(fs, testSettingsPath)
+	require.NoError(t, err)
+	var settings map[string]any
+	require.NoError(t, json.Unmarshal(data, &settings))
+	return settings
+}
+
+func TestStrategy_WriteSettings(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	s := NewFormat(fs).strategy
+
+	// Existing settings with an entry added by hand
+	require.NoError(t, afero.WriteFile(fs, testSettingsPath,
+		[]byte(`{"model": "opus", "permissions": {"allow": ["Bash(make:*)"]}}`), 0o644))
+
+	config := &domain.FormatConfig{
+		Type:    domain.FormatClaude,
+		BaseDir: "/project",
+		Settings: &domain.ClaudeSettings{
+			AllowedTools:   []string{"Bash(go test:*)"},
+			IgnorePatterns: []string{".env", "./secrets/**"},
+		},
+	}
+	require.NoError(t, s.writeSettings(config))
+
+	settings := readTestSettings(t, fs)
+	assert.Equal(t, "opus", settings["model"])
+	permissions := settings["permissions"].(map[string]any)
+	assert.Equal(t, []any{"Bash(make:*)", "Bash(go test:*)"}, permissions["allow"])
+	assert.Equal(t, []any{"Read(./.env)", "Read(./secrets/**)"}, permissions["deny"])
+
+	// Changing the config replaces only the managed entries
+	config.Settings = &domain.ClaudeSettings{AllowedTools: []string{"Bash(go build:*)"}}
+	require.NoError(t, s.writeSettings(config))
+
+	settings = readTestSettings(t, fs)
+	permissions = settings["permissions"].(map[string]any)
+	assert.Equal(t, []any{"Bash(make:*)", "Bash(go build:*)"}, permissions["allow"])
+	assert.NotContains(t, permissions, "deny")
+
+	// Removing the settings leaves the file as it was before contexture
+	config.Settings = nil
+	require.NoError(t, s.writeSettings(config))
+
+	settings = readTestSettings(t, fs)
+	assert.NotContains(t, settings, settingsMarkerKey)
+	assert.Equal(t, map[string]any{"allow": []any{"Bash(make:*)"}}, settings["permissions"])
+}
+
+func TestStrategy_WriteSettings_CreatesAndRemovesFile(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	s := NewFormat(fs).strategy
+	config := &domain.FormatConfig{
+		Type:     domain.FormatClaude,
+		BaseDir:  "/project",
+		Settings: &domain.ClaudeSettings{DeniedTools: []string{"WebFetch"}},
+	}
+
+	require.NoError(t, s.writeSettings(config))
+	settings := readTestSettings(t, fs)
+	assert.Equal(t, map[string]any{"deny": []any{"WebFetch"}}, settings["permissions"])
+
+	config.Settings = nil
+	require.NoError(t, s.writeSettings(config))
+	exists, err := afero.Exists(fs, testSettingsPath)
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestStrategy_WriteSettings_Skipped(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no settings configured", func(t *testing.T) {
+		t.Parallel()
+		fs := afero.NewMemMapFs()
+		require.NoError(t, NewFormat(fs).strategy.writeSettings(&domain.FormatConfig{BaseDir: "/project"}))
+		exists, err := afero.Exists(fs, testSettingsPath)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("user rules", func(t *testing.T) {
+		t.Parallel()
+		fs := afero.NewMemMapFs()
+		config := &domain.FormatConfig{
+			BaseDir:     "/home/user/.claude",
+			IsUserRules: true,
+			Settings:    &domain.ClaudeSettings{AllowedTools: []string{"Read"}},
+		}
+		require.NoError(t, NewFormat(fs).strategy.writeSettings(config))
+		exists, err := afero.Exists(fs, "/home/user/.claude/.claude/settings.json")
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("invalid permissions", func(t *testing.T) {
+		t.Parallel()
+		fs := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fs, testSettingsPath, []byte(`{"permissions": []}`), 0o644))
+		config := &domain.FormatConfig{
+			BaseDir:  "/project",
+			Settings: &domain.ClaudeSettings{AllowedTools: []string{"Read"}},
+		}
+		err := NewFormat(fs).strategy.writeSettings(config)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "permissions must be an object")
+	})
+}
@@ -0,0 +1,123 @@
+package copilot
+
+import (
+	"path/filepath"
+
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/contextureai/contexture/internal/format/base"
+	"github.com/spf13/afero"
+)
+
+// Strategy implements the FormatStrategy interface for Copilot format
+type Strategy struct {
+	fs   afero.Fs
+	bf   *base.Base
+	file *base.SingleFile
+}
+
+// NewStrategy creates a new Copilot strategy
+func NewStrategy(fs afero.Fs, bf *base.Base) *Strategy {
+	return &Strategy{
+		fs:   fs,
+		bf:   bf,
+		file: base.NewSingleFile(bf, "Copilot", "# Copilot Instructions"),
+	}
+}
+
+// GetDefaultTemplate returns the default Copilot template. Copilot applies
+// the whole file to every request, so rules with a glob trigger name the
+// files they are about.
+func (s *Strategy) GetDefaultTemplate() string {
+	return `# {{.title}}
+
+{{if .description}}{{.description}}
+
+{{end}}{{if .trigger}}{{if eq .trigger.type "glob"}}**Applies:** When working with {{join_and .trigger.globs}} files
+
+{{end}}{{end}}{{.content}}`
+}
+
+// GetOutputPath returns the full output path for the Copilot instructions file
+func (s *Strategy) GetOutputPath(config *domain.FormatConfig) string {
+	filename := filepath.FromSlash(domain.CopilotOutputFile)
+
+	if config == nil {
+		return filename
+	}
+	if config.IsUserRules && config.UserRulesFile != "" {
+		return config.UserRulesFile
+	}
+
+	baseDir := config.BaseDir
+	if baseDir == "" {
+		baseDir = "."
+	}
+	return filepath.Join(baseDir, filename)
+}
+
+// GetFileExtension returns the file extension for Copilot format
+func (s *Strategy) GetFileExtension() string {
+	return ".md"
+}
+
+// IsSingleFile returns true since Copilot format outputs to a single file
+func (s *Strategy) IsSingleFile() bool {
+	return true
+}
+
+// GenerateFilename generates a filename from a rule ID (not used for single-file format)
+func (s *Strategy) GenerateFilename(_ string) string {
+	return filepath.Base(domain.CopilotOutputFile)
+}
+
+// GetMetadata returns metadata about Copilot format
+func (s *Strategy) GetMetadata() *domain.FormatMetadata {
+	return &domain.FormatMetadata{
+		Type:        domain.FormatCopilot,
+		DisplayName: "GitHub Copilot",
+		Description: "Single-file format for GitHub Copilot (.github/copilot-instructions.md)",
+		IsDirectory: false,
+		AssetsDir:   ".github/copilot-assets",
+	}
+}
+
+// WriteFiles writes the rules to the Copilot instructions file, removing it
+// when there are no rules and no keep blocks
+func (s *Strategy) WriteFiles(rules []*domain.TransformedRule, config *domain.FormatConfig) error {
+	return s.file.Write(rules, config, s.GetOutputPath(config))
+}
+
+// CleanupEmptyDirectories handles cleanup for Copilot format (no-op since it's file-based)
+func (s *Strategy) CleanupEmptyDirectories(_ *domain.FormatConfig) error {
+	s.bf.LogDebug("Copilot format doesn't need directory cleanup (file-based)")
+	return nil
+}
+
+// CreateDirectories creates the .github directory the instructions file is written to
+func (s *Strategy) CreateDirectories(config *domain.FormatConfig) error {
+	return s.bf.EnsureDirectory(filepath.Dir(s.GetOutputPath(config)))
+}
+
+// Format implements the Copilot single-file format using CommonFormat
+type Format struct {
+	*base.CommonFormat
+
+	strategy *Strategy
+}
+
+// NewFormat creates a new Copilot format implementation
+func NewFormat(fs afero.Fs) *Format {
+	bf := base.NewBaseFormat(fs, domain.FormatCopilot)
+	strategy := NewStrategy(fs, bf)
+	commonFormat := base.NewCommonFormat(bf, strategy)
+
+	return &Format{
+		CommonFormat: commonFormat,
+		strategy:     strategy,
+	}
+}
+
+// NewFormatFromOptions creates a new Copilot format with options
+func NewFormatFromOptions(fs afero.Fs, _ map[string]any) (domain.Format, error) {
+	return NewFormat(fs), nil
+}
@@ -0,0 +1,133 @@
+package copilot
+
+import (
+	"testing"
+
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/contextureai/contexture/internal/format/base"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testOutputPath = "/project/.github/copilot-instructions.md"
+
+func TestFormat_Transform(t *testing.T) {
+	t.Parallel()
+	f := NewFormat(afero.NewMemMapFs())
+
+	rule := &domain.Rule{
+		ID:          "[contexture:languages/go/testing]",
+		Title:       "Go Testing",
+		Description: "Write table-driven tests",
+		Content:     "Use testify.\n\n:::only claude\nFor Claude\n:::\n\n:::only copilot\nFor Copilot\n:::\n",
+		Trigger:     &domain.RuleTrigger{Type: domain.TriggerGlob, Globs: []string{"*_test.go"}},
+	}
+	transformed, err := f.Transform(&domain.ProcessedRule{Rule: rule, Content: rule.Content})
+	require.NoError(t, err)
+	assert.Equal(t, "copilot-instructions.md", transformed.Filename)
+	assert.Contains(t, transformed.Content, "# Go Testing")
+	assert.Contains(t, transformed.Content, "Write table-driven tests")
+	assert.Contains(t, transformed.Content, "**Applies:** When working with *_test.go files")
+	assert.Contains(t, transformed.Content, "For Copilot")
+	assert.NotContains(t, transformed.Content, "For Claude")
+	assert.Equal(t, "copilot", transformed.Metadata["format"])
+}
+
+func TestFormat_Write(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	f := NewFormat(fs)
+	config := &domain.FormatConfig{Type: domain.FormatCopilot, BaseDir: "/project"}
+
+	rules := []*domain.TransformedRule{
+		{Rule: &domain.Rule{ID: "[contexture:test/rule1]", Title: "Rule 1"}, Content: "Content of rule 1"},
+		{Rule: &domain.Rule{ID: "[contexture:test/rule2]", Title: "Rule 2"}, Content: "Content of rule 2"},
+	}
+	require.NoError(t, f.Write(rules, config))
+
+	content, err := afero.ReadFile(fs, testOutputPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "# Copilot Instructions\n\nContent of rule 1")
+	assert.Contains(t, string(content), "\n\n---\n\nContent of rule 2")
+	assert.Contains(t, string(content), "<!-- id: [contexture:test/rule2] -->")
+	assert.Contains(t, string(content), "<!-- Generated by Contexture CLI at ")
+
+	// Without rules the file is removed
+	require.NoError(t, f.Write(nil, config))
+	exists, err := afero.Exists(fs, testOutputPath)
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestFormat_GetOutputPath(t *testing.T) {
+	t.Parallel()
+	f := NewFormat(afero.NewMemMapFs())
+
+	assert.Equal(t, ".github/copilot-instructions.md", f.GetOutputPath(nil))
+	assert.Equal(t, ".github/copilot-instructions.md", f.GetOutputPath(&domain.FormatConfig{}))
+	assert.Equal(t, testOutputPath, f.GetOutputPath(&domain.FormatConfig{BaseDir: "/project"}))
+	assert.Equal(t, "/home/user/instructions.md", f.GetOutputPath(&domain.FormatConfig{
+		BaseDir: "/home/user", IsUserRules: true, UserRulesFile: "/home/user/instructions.md",
+	}))
+}
+
+func TestStrategy_WriteFiles_KeepBlocks(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	f := NewFormat(fs)
+	config := &domain.FormatConfig{Type: domain.FormatCopilot, BaseDir: "/project"}
+
+	kept := base.WrapKeepBlock("Hand-written notes")
+	require.NoError(t, afero.WriteFile(fs, testOutputPath, []byte("old output\n\n"+kept+"\n\nold rule"), 0o644))
+
+	rules := []*domain.TransformedRule{{
+		Rule:    &domain.Rule{ID: "[contexture:test/rule]", Title: "Rule"},
+		Content: "Rule content",
+	}}
+	require.NoError(t, f.strategy.WriteFiles(rules, config))
+
+	content, err := afero.ReadFile(fs, testOutputPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), kept+"\n\nRule content")
+	assert.NotContains(t, string(content), "old rule")
+
+	// Without rules the file is kept for its keep blocks
+	require.NoError(t, f.strategy.WriteFiles(nil, config))
+	content, err = afero.ReadFile(fs, testOutputPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), kept)
+	assert.NotContains(t, string(content), "Rule content")
+}
+
+func TestStrategy_WriteFiles_Template(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	f := NewFormat(fs)
+	config := &domain.FormatConfig{Type: domain.FormatCopilot, BaseDir: "/project", Template: "copilot.tmpl.md"}
+	require.NoError(t, afero.WriteFile(fs, "/project/copilot.tmpl.md", []byte("# Acme\n\n{{.Rules}}\n"), 0o644))
+
+	rules := []*domain.TransformedRule{{
+		Rule:    &domain.Rule{ID: "[contexture:test/rule]", Title: "Rule"},
+		Content: "Rule content",
+	}}
+	require.NoError(t, f.strategy.WriteFiles(rules, config))
+
+	content, err := afero.ReadFile(fs, testOutputPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "# Acme\n\nRule content")
+	assert.NotContains(t, string(content), "# Copilot Instructions")
+
+	config.Template = "../outside.md"
+	require.Error(t, f.strategy.WriteFiles(rules, config))
+}
+
+func TestHandler_GetCapabilities(t *testing.T) {
+	t.Parallel()
+	caps := (&Handler{}).GetCapabilities()
+	assert.False(t, caps.SupportsUserRules)
+	assert.Equal(t, domain.UserRulesDisabled, caps.DefaultUserRulesMode)
+
+	config := domain.FormatConfig{Type: domain.FormatCopilot}
+	assert.Equal(t, domain.UserRulesDisabled, config.GetEffectiveUserRulesMode())
+}
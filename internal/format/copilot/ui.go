@@ -0,0 +1,35 @@
+// Package copilot provides GitHub Copilot-specific UI components and format construction
+package copilot
+
+import (
+	"github.com/charmbracelet/huh"
+	"github.com/contextureai/contexture/internal/domain"
+)
+
+// Handler implements the format.Handler interface for Copilot format
+type Handler struct{}
+
+// GetUIOption returns the UI option for Copilot format selection
+func (h *Handler) GetUIOption(selected bool) huh.Option[string] {
+	return huh.NewOption(h.GetDisplayName(), "copilot").Selected(selected)
+}
+
+// GetDisplayName returns the display name for Copilot format
+func (h *Handler) GetDisplayName() string {
+	return "GitHub Copilot (.github/copilot-instructions.md)"
+}
+
+// GetDescription returns the description for Copilot format
+func (h *Handler) GetDescription() string {
+	return "Repository custom instructions for GitHub Copilot"
+}
+
+// GetCapabilities returns the capabilities for Copilot format
+func (h *Handler) GetCapabilities() domain.FormatCapabilities {
+	return domain.FormatCapabilities{
+		SupportsUserRules:    false,                    // Personal instructions live in Copilot's settings
+		UserRulesPath:        "",                       // No user rules path
+		DefaultUserRulesMode: domain.UserRulesDisabled, // The instructions file is shared with the team
+		MaxRuleSize:          0,                        // No specific limit
+	}
+}
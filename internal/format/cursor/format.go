@@ -8,6 +8,7 @@ import (
 	"github.com/contextureai/contexture/internal/domain"
 	contextureerrors "github.com/contextureai/contexture/internal/errors"
 	"github.com/contextureai/contexture/internal/format/base"
+	"github.com/contextureai/contexture/internal/render"
 	"github.com/spf13/afero"
 )
 
@@ -46,6 +47,10 @@ func (s *Strategy) GetOutputPath(config *domain.FormatConfig) string {
 	if config == nil || config.BaseDir == "" {
 		return domain.CursorOutputDir
 	}
+	// User rules are written to a single file outside projects
+	if config.IsUserRules && config.UserRulesFile != "" {
+		return filepath.Dir(config.UserRulesFile)
+	}
 	return filepath.Join(config.BaseDir, domain.CursorOutputDir)
 }
 
@@ -72,27 +77,32 @@ func (s *Strategy) GetMetadata() *domain.FormatMetadata {
 		DisplayName: "Cursor IDE",
 		Description: "Multi-file format for Cursor IDE (.cursor/rules/)",
 		IsDirectory: true,
+		AssetsDir:   domain.CursorOutputDir + "/assets",
 	}
 }
 
 // WriteFiles handles writing rules for Cursor format (multi-file)
 func (s *Strategy) WriteFiles(rules []*domain.TransformedRule, config *domain.FormatConfig) error {
+	if config != nil && config.IsUserRules {
+		return s.writeUserRulesFile(rules, config.UserRulesFile)
+	}
 	outputDir := s.GetOutputPath(config)
 
-	// When no rules, delete all files in the output directory
+	// When no rules, delete all generated files in the output directory.
+	// Hand-written rule files have no tracking comment and are kept.
 	if len(rules) == 0 {
-		s.bf.LogDebug("No rules to write for Cursor format, deleting output directory")
+		s.bf.LogDebug("No rules to write for Cursor format, deleting generated files")
 		exists, err := s.bf.DirExists(outputDir)
 		if err != nil {
 			s.bf.LogDebug("Failed to check if directory exists", "path", outputDir, "error", err)
 			return nil
 		}
 		if exists {
-			// Remove the entire rules directory
-			if err := s.bf.RemoveDirectory(outputDir); err != nil {
-				return contextureerrors.WithOpf("delete output directory", "failed to delete %s: %w", outputDir, err)
+			if err := s.removeGeneratedFiles(outputDir); err != nil {
+				return err
 			}
-			s.bf.LogInfo("Deleted Cursor format directory", "path", outputDir)
+			s.bf.CleanupEmptyDirectory(outputDir)
+			s.bf.LogInfo("Deleted Cursor format files", "path", outputDir)
 
 			// Also clean up parent .cursor directory if it's now empty
 			if config != nil {
@@ -114,32 +124,91 @@ func (s *Strategy) WriteFiles(rules []*domain.TransformedRule, config *domain.Fo
 		return contextureerrors.Wrap(err, "failed to create output directory")
 	}
 
-	// Write each rule to its own file
+	// Write each rule to its own file, or combined or split by size when configured.
+	// Tracking comments are appended at the end instead of a header at the beginning.
 	var errors []error
-	for _, rule := range rules {
-		filePath := filepath.Join(outputDir, rule.Filename)
-
-		// Append tracking comment at the end instead of header at beginning, only including non-default variables
-		content := s.bf.AppendTrackingCommentWithDefaults(rule.Content, rule.Rule.ID, rule.Rule.Variables, rule.Rule.DefaultVariables)
+	written := map[string]bool{}
+	for _, file := range s.bf.LayoutRuleFiles(rules, config, s.GetFileExtension()) {
+		filePath := filepath.Join(outputDir, file.Filename)
+		written[file.Filename] = true
 
-		if err := s.bf.WriteFile(filePath, []byte(content)); err != nil {
-			errors = append(errors, contextureerrors.WithOpf("failed to write rule", "%s: %w", rule.Rule.ID, err))
+		if err := s.bf.WriteFile(filePath, []byte(file.Content)); err != nil {
+			errors = append(errors, contextureerrors.WithOpf("failed to write rule", "%s: %w", file.Filename, err))
 			continue
 		}
 
-		s.bf.LogDebug("Wrote Cursor rule file", "ruleID", rule.Rule.ID, "path", filePath)
+		s.bf.LogDebug("Wrote Cursor rule file", "path", filePath, "rules", len(file.Rules))
 	}
 
 	if len(errors) > 0 {
 		return contextureerrors.WithOpf("WriteFiles", "failed to write %d rules: %v", len(errors), errors)
 	}
 
+	// Files left from an earlier layout would duplicate rules
+	if err := s.bf.RemoveStaleFiles(outputDir, written); err != nil {
+		return err
+	}
+
 	s.bf.LogInfo("Successfully wrote Cursor format files", "count", len(rules), "directory", outputDir)
 	return nil
 }
 
+// writeUserRulesFile writes user rules to a single markdown file. Cursor
+// keeps user rules in its settings rather than in a file, so the file holds
+// the text to paste into Settings → Rules → User Rules, without the .mdc
+// frontmatter of project rules. With no rules a generated file is removed.
+func (s *Strategy) writeUserRulesFile(rules []*domain.TransformedRule, path string) error {
+	if len(rules) == 0 {
+		data, err := s.bf.ReadFile(path)
+		if err != nil || len(s.bf.ExtractTrackingComments(string(data))) == 0 {
+			return nil
+		}
+		if err := s.bf.RemoveFile(path); err != nil {
+			return contextureerrors.Wrap(err, "remove Cursor user rules file")
+		}
+		s.bf.LogInfo("Deleted Cursor user rules file", "path", path)
+		return nil
+	}
+
+	if err := s.bf.EnsureDirectory(filepath.Dir(path)); err != nil {
+		return contextureerrors.Wrap(err, "failed to create user rules directory")
+	}
+
+	var content strings.Builder
+	content.WriteString("# User Rules\n\n")
+	content.WriteString("<!-- Generated by contexture. Paste into Cursor Settings → Rules → User Rules. -->\n")
+	for _, rule := range rules {
+		body := rule.Content
+		if _, stripped, err := render.SplitFrontmatter(body); err == nil {
+			body = stripped
+		}
+		content.WriteString("\n")
+		content.WriteString(s.bf.AppendTrackingCommentWithDefaults(
+			strings.TrimSpace(body), rule.Rule.ID, rule.Rule.Variables, rule.Rule.DefaultVariables))
+		content.WriteString("\n")
+	}
+
+	if err := s.bf.WriteFile(path, []byte(content.String())); err != nil {
+		return contextureerrors.Wrap(err, "write Cursor user rules file")
+	}
+	s.bf.LogInfo("Wrote Cursor user rules file", "path", path, "rules", len(rules))
+	return nil
+}
+
+// removeGeneratedFiles removes the files in dir that carry a tracking comment
+func (s *Strategy) removeGeneratedFiles(dir string) error {
+	if err := s.bf.RemoveStaleFiles(dir, nil); err != nil {
+		return contextureerrors.Wrap(err, "delete output directory")
+	}
+	return nil
+}
+
 // CleanupEmptyDirectories handles cleanup of empty directories for Cursor format
 func (s *Strategy) CleanupEmptyDirectories(config *domain.FormatConfig) error {
+	// The user rules file lives in ~/.cursor, which Cursor owns
+	if config.IsUserRules {
+		return nil
+	}
 	outputDir := s.GetOutputPath(config)
 
 	baseDir := config.BaseDir
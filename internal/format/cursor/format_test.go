@@ -2,6 +2,7 @@ package cursor
 
 import (
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/contextureai/contexture/internal/domain"
@@ -245,6 +246,53 @@ func TestFormat_Write(t *testing.T) {
 	// Note: Index file functionality has been removed as it was unused
 }
 
+func TestFormat_Write_UserRulesFile(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	f := NewFormat(fs)
+
+	transformed, err := f.Transform(&domain.ProcessedRule{
+		Rule: &domain.Rule{
+			ID:      "[contexture:style/naming]",
+			Title:   "Naming",
+			Trigger: &domain.RuleTrigger{Type: domain.TriggerAlways},
+			Content: "Name things clearly.",
+		},
+		Variables: map[string]any{},
+	})
+	require.NoError(t, err)
+
+	config := &domain.FormatConfig{
+		Type:          domain.FormatCursor,
+		BaseDir:       "/home/user/.cursor",
+		IsUserRules:   true,
+		UserRulesFile: "/home/user/.cursor/user-rules.md",
+	}
+	require.NoError(t, f.Write([]*domain.TransformedRule{transformed}, config))
+
+	content, err := afero.ReadFile(fs, "/home/user/.cursor/user-rules.md")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(content), "# User Rules\n"))
+	assert.Contains(t, string(content), "Name things clearly.")
+	assert.Contains(t, string(content), "<!-- id: [contexture:style/naming] -->")
+	assert.NotContains(t, string(content), "alwaysApply")
+	exists, err := afero.DirExists(fs, "/home/user/.cursor/rules")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	// Without rules the generated file is removed, and a hand-written one kept
+	require.NoError(t, f.Write(nil, config))
+	exists, err = afero.Exists(fs, "/home/user/.cursor/user-rules.md")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	require.NoError(t, afero.WriteFile(fs, "/home/user/.cursor/user-rules.md", []byte("My notes"), 0o644))
+	require.NoError(t, f.Write(nil, config))
+	content, err = afero.ReadFile(fs, "/home/user/.cursor/user-rules.md")
+	require.NoError(t, err)
+	assert.Equal(t, "My notes", string(content))
+}
+
 func TestFormat_Write_EmptyRules(t *testing.T) {
 	t.Parallel()
 	fs := afero.NewMemMapFs()
@@ -528,3 +576,51 @@ func findRuleByFilename(rules []*domain.InstalledRule, filename string) *domain.
 	}
 	return nil
 }
+
+func TestFormat_Write_EmptyRulesKeepsHandWrittenFiles(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	f := NewFormat(fs)
+	config := &domain.FormatConfig{BaseDir: "/output"}
+
+	generated := filepath.Join(testCursorOutputDir, "test-rule.mdc")
+	handWritten := filepath.Join(testCursorOutputDir, "team.mdc")
+	require.NoError(t, afero.WriteFile(fs, generated, []byte("Rule\n\n<!-- id: [contexture:test/rule] -->\n"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, handWritten, []byte("Use tabs.\n"), 0o644))
+
+	require.NoError(t, f.Write([]*domain.TransformedRule{}, config))
+
+	exists, err := afero.Exists(fs, generated)
+	require.NoError(t, err)
+	assert.False(t, exists)
+	exists, err = afero.Exists(fs, handWritten)
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func TestFormat_Write_MergeBelow(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	f := NewFormat(fs)
+	config := &domain.FormatConfig{BaseDir: "/output", MergeBelow: 200}
+
+	rules := []*domain.TransformedRule{
+		{Rule: &domain.Rule{ID: "[contexture:test/a]"}, Content: "---\nalwaysApply: true\n---\n\nUse tabs.", Filename: "test-a.mdc"},
+		{Rule: &domain.Rule{ID: "[contexture:test/b]"}, Content: "---\nalwaysApply: true\n---\n\nWrap at 100.", Filename: "test-b.mdc"},
+	}
+	require.NoError(t, f.Write(rules, config))
+
+	files, err := afero.ReadDir(fs, testCursorOutputDir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.True(t, strings.HasPrefix(files[0].Name(), "combined-"))
+
+	// Without the threshold the combined file is replaced by one file per rule
+	config.MergeBelow = 0
+	require.NoError(t, f.Write(rules, config))
+	files, err = afero.ReadDir(fs, testCursorOutputDir)
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	assert.Equal(t, "test-a.mdc", files[0].Name())
+	assert.Equal(t, "test-b.mdc", files[1].Name())
+}
@@ -2,6 +2,9 @@
 package cursor
 
 import (
+	"os"
+	"path/filepath"
+
 	"github.com/charmbracelet/huh"
 	"github.com/contextureai/contexture/internal/domain"
 )
@@ -26,10 +29,13 @@ func (h *Handler) GetDescription() string {
 
 // GetCapabilities returns the capabilities for Cursor format
 func (h *Handler) GetCapabilities() domain.FormatCapabilities {
+	homeDir, _ := os.UserHomeDir()
+
 	return domain.FormatCapabilities{
-		SupportsUserRules:    false,                   // Cursor doesn't support native user rules
-		UserRulesPath:        "",                      // No user rules path
-		DefaultUserRulesMode: domain.UserRulesProject, // Default to including user rules in project
-		MaxRuleSize:          0,                       // No specific limit
+		SupportsUserRules:    false,                                              // Cursor doesn't support native user rules
+		UserRulesPath:        "",                                                 // No user rules path
+		UserRulesFilePath:    filepath.Join(homeDir, ".cursor", "user-rules.md"), // Written in file mode
+		DefaultUserRulesMode: domain.UserRulesProject,                            // Default to including user rules in project
+		MaxRuleSize:          0,                                                  // No specific limit
 	}
 }
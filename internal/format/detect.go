@@ -0,0 +1,173 @@
+package format
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/spf13/afero"
+)
+
+// Detection reports an AI assistant found on this machine or in a project,
+// with the evidence that it is in use
+type Detection struct {
+	Format  domain.FormatType
+	Signals []string
+}
+
+// assistantTraces lists where an assistant leaves traces when it is installed or used
+type assistantTraces struct {
+	format domain.FormatType
+	// binaries are executables looked up on PATH
+	binaries []string
+	// homePaths are configuration directories relative to the home directory
+	homePaths []string
+	// projectPaths are files or directories relative to the project root
+	projectPaths []string
+	// extensions are VS Code extension ID prefixes
+	extensions []string
+}
+
+// knownAssistants lists the traces of each built-in format, in display order
+var knownAssistants = []assistantTraces{
+	{
+		format:       domain.FormatClaude,
+		binaries:     []string{"claude"},
+		homePaths:    []string{".claude"},
+		projectPaths: []string{"CLAUDE.md", ".claude"},
+		extensions:   []string{"anthropic.claude-code"},
+	},
+	{
+		format:       domain.FormatCursor,
+		binaries:     []string{"cursor"},
+		homePaths:    []string{".cursor"},
+		projectPaths: []string{".cursor", ".cursorrules"},
+	},
+	{
+		format:       domain.FormatWindsurf,
+		binaries:     []string{"windsurf"},
+		homePaths:    []string{".windsurf", filepath.Join(".codeium", "windsurf")},
+		projectPaths: []string{".windsurf", ".windsurfrules"},
+		extensions:   []string{"codeium.codeium", "codeium.windsurf"},
+	},
+	{
+		format:       domain.FormatCopilot,
+		homePaths:    []string{filepath.Join(".config", "github-copilot")},
+		projectPaths: []string{filepath.FromSlash(domain.CopilotOutputFile)},
+		extensions:   []string{"github.copilot"},
+	},
+	{
+		format:       domain.FormatGemini,
+		binaries:     []string{"gemini"},
+		homePaths:    []string{".gemini"},
+		projectPaths: []string{domain.GeminiOutputFile, ".gemini"},
+	},
+}
+
+// vscodeExtensionDirs are the extension directories of VS Code installations,
+// relative to the home directory
+var vscodeExtensionDirs = []string{
+	filepath.Join(".vscode", "extensions"),
+	filepath.Join(".vscode-insiders", "extensions"),
+	filepath.Join(".vscode-server", "extensions"),
+}
+
+// Detector finds the AI assistants a user works with, so the matching formats
+// can be suggested when configuring a project
+type Detector struct {
+	fs       afero.Fs
+	homeDir  string
+	lookPath func(string) (string, error)
+}
+
+// NewDetector creates a detector that inspects the user's home directory, as
+// found in env, and PATH
+func NewDetector(fs afero.Fs, env dependencies.Environment) *Detector {
+	homeDir, _ := dependencies.HomeDir(env)
+	return &Detector{
+		fs:       fs,
+		homeDir:  homeDir,
+		lookPath: exec.LookPath,
+	}
+}
+
+// Detect returns the assistants with traces on this machine or in projectDir.
+// An empty projectDir skips the project checks.
+func (d *Detector) Detect(projectDir string) []Detection {
+	extensions := d.vscodeExtensions()
+
+	var detections []Detection
+	for _, assistant := range knownAssistants {
+		var signals []string
+		for _, binary := range assistant.binaries {
+			if _, err := d.lookPath(binary); err == nil {
+				signals = append(signals, binary+" on PATH")
+			}
+		}
+		if d.homeDir != "" {
+			for _, path := range assistant.homePaths {
+				if d.exists(filepath.Join(d.homeDir, path)) {
+					signals = append(signals, filepath.Join("~", path))
+				}
+			}
+		}
+		if projectDir != "" {
+			for _, path := range assistant.projectPaths {
+				if d.exists(filepath.Join(projectDir, path)) {
+					signals = append(signals, path+" in project")
+				}
+			}
+		}
+		for _, prefix := range assistant.extensions {
+			for _, extension := range extensions {
+				if strings.HasPrefix(extension, prefix) {
+					signals = append(signals, "VS Code extension "+prefix)
+					break
+				}
+			}
+		}
+
+		if len(signals) > 0 {
+			detections = append(detections, Detection{Format: assistant.format, Signals: signals})
+		}
+	}
+	return detections
+}
+
+// DetectedFormats returns the format types of the detected assistants
+func DetectedFormats(detections []Detection) []string {
+	formats := make([]string, 0, len(detections))
+	for _, detection := range detections {
+		formats = append(formats, string(detection.Format))
+	}
+	return formats
+}
+
+// vscodeExtensions lists the lowercased directory names of installed VS Code
+// extensions, which start with the extension ID
+func (d *Detector) vscodeExtensions() []string {
+	if d.homeDir == "" {
+		return nil
+	}
+
+	var extensions []string
+	for _, dir := range vscodeExtensionDirs {
+		entries, err := afero.ReadDir(d.fs, filepath.Join(d.homeDir, dir))
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				extensions = append(extensions, strings.ToLower(entry.Name()))
+			}
+		}
+	}
+	return extensions
+}
+
+func (d *Detector) exists(path string) bool {
+	_, err := d.fs.Stat(path)
+	return err == nil
+}
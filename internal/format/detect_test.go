@@ -0,0 +1,70 @@
+package format
+
+import (
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDetector(fs afero.Fs, binaries ...string) *Detector {
+	return &Detector{
+		fs:      fs,
+		homeDir: "/home/user",
+		lookPath: func(name string) (string, error) {
+			if slices.Contains(binaries, name) {
+				return "/usr/local/bin/" + name, nil
+			}
+			return "", errors.New("not found")
+		},
+	}
+}
+
+func TestNewDetector_HomeFromEnvironment(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "/home/user", NewDetector(afero.NewMemMapFs(), dependencies.MapEnvironment{"HOME": "/home/user"}).homeDir)
+	assert.Equal(t, `C:\Users\user`, NewDetector(afero.NewMemMapFs(), dependencies.MapEnvironment{"USERPROFILE": `C:\Users\user`}).homeDir)
+	assert.Empty(t, NewDetector(afero.NewMemMapFs(), dependencies.MapEnvironment{}).homeDir)
+}
+
+func TestDetector_Detect(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nothing installed", func(t *testing.T) {
+		t.Parallel()
+		detector := newTestDetector(afero.NewMemMapFs())
+		assert.Empty(t, detector.Detect("/project"))
+	})
+
+	t.Run("binaries, home directories, project files and extensions", func(t *testing.T) {
+		t.Parallel()
+		fs := afero.NewMemMapFs()
+		require.NoError(t, fs.MkdirAll("/home/user/.claude", 0o755))
+		require.NoError(t, fs.MkdirAll("/home/user/.vscode/extensions/codeium.codeium-1.20.0", 0o755))
+		require.NoError(t, afero.WriteFile(fs, "/project/.cursorrules", []byte("rules"), 0o644))
+
+		detections := newTestDetector(fs, "claude").Detect("/project")
+
+		require.Len(t, detections, 3)
+		assert.Equal(t, domain.FormatClaude, detections[0].Format)
+		assert.Equal(t, []string{"claude on PATH", filepath.Join("~", ".claude")}, detections[0].Signals)
+		assert.Equal(t, domain.FormatCursor, detections[1].Format)
+		assert.Equal(t, []string{".cursorrules in project"}, detections[1].Signals)
+		assert.Equal(t, domain.FormatWindsurf, detections[2].Format)
+		assert.Equal(t, []string{"VS Code extension codeium.codeium"}, detections[2].Signals)
+		assert.Equal(t, []string{"claude", "cursor", "windsurf"}, DetectedFormats(detections))
+	})
+
+	t.Run("empty project directory skips project checks", func(t *testing.T) {
+		t.Parallel()
+		fs := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fs, "CLAUDE.md", []byte("# rules"), 0o644))
+		assert.Empty(t, newTestDetector(fs).Detect(""))
+	})
+}
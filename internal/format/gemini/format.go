@@ -0,0 +1,126 @@
+package gemini
+
+import (
+	"path/filepath"
+
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/contextureai/contexture/internal/format/base"
+	"github.com/spf13/afero"
+)
+
+// Strategy implements the FormatStrategy interface for Gemini format
+type Strategy struct {
+	fs   afero.Fs
+	bf   *base.Base
+	file *base.SingleFile
+}
+
+// NewStrategy creates a new Gemini strategy
+func NewStrategy(fs afero.Fs, bf *base.Base) *Strategy {
+	return &Strategy{
+		fs:   fs,
+		bf:   bf,
+		file: base.NewSingleFile(bf, "Gemini", "# gemini.md"),
+	}
+}
+
+// GetDefaultTemplate returns the default Gemini template
+func (s *Strategy) GetDefaultTemplate() string {
+	return `# {{.title}}
+
+{{if .description}}{{.description}}
+
+{{end}}{{if .trigger}}{{if eq .trigger.type "always"}}**Applies:** Always active
+{{else if eq .trigger.type "glob"}}**Applies:** When working with {{join_and .trigger.globs}} files
+{{else if eq .trigger.type "model_decision"}}**Applies:** When {{.description}}
+{{else}}**Applies:** When explicitly requested
+{{end}}
+
+{{end}}{{if .tags}}**Tags:** {{join_and .tags}}
+{{end}}{{if .frameworks}}**Frameworks:** {{join_and .frameworks}}
+{{end}}{{.content}}`
+}
+
+// GetOutputPath returns the full output path for the Gemini format file
+func (s *Strategy) GetOutputPath(config *domain.FormatConfig) string {
+	if config == nil {
+		return domain.GeminiOutputFile
+	}
+	if config.IsUserRules && config.UserRulesFile != "" {
+		return config.UserRulesFile
+	}
+
+	baseDir := config.BaseDir
+	if baseDir == "" {
+		baseDir = "."
+	}
+	return filepath.Join(baseDir, domain.GeminiOutputFile)
+}
+
+// GetFileExtension returns the file extension for Gemini format
+func (s *Strategy) GetFileExtension() string {
+	return ".md"
+}
+
+// IsSingleFile returns true since Gemini format outputs to a single file
+func (s *Strategy) IsSingleFile() bool {
+	return true
+}
+
+// GenerateFilename generates a filename from a rule ID (not used for single-file format)
+func (s *Strategy) GenerateFilename(_ string) string {
+	return domain.GeminiOutputFile
+}
+
+// GetMetadata returns metadata about Gemini format
+func (s *Strategy) GetMetadata() *domain.FormatMetadata {
+	return &domain.FormatMetadata{
+		Type:        domain.FormatGemini,
+		DisplayName: "Gemini CLI",
+		Description: "Single-file format for Gemini CLI (GEMINI.md)",
+		IsDirectory: false,
+		AssetsDir:   ".gemini/assets",
+	}
+}
+
+// WriteFiles writes the rules to GEMINI.md, removing it when there are no
+// rules and no keep blocks
+func (s *Strategy) WriteFiles(rules []*domain.TransformedRule, config *domain.FormatConfig) error {
+	return s.file.Write(rules, config, s.GetOutputPath(config))
+}
+
+// CleanupEmptyDirectories handles cleanup for Gemini format (no-op since it's file-based)
+func (s *Strategy) CleanupEmptyDirectories(_ *domain.FormatConfig) error {
+	s.bf.LogDebug("Gemini format doesn't need directory cleanup (file-based)")
+	return nil
+}
+
+// CreateDirectories creates necessary directories for Gemini format (no-op since it's file-based)
+func (s *Strategy) CreateDirectories(_ *domain.FormatConfig) error {
+	s.bf.LogDebug("Gemini format doesn't need directory creation (file-based)")
+	return nil
+}
+
+// Format implements the Gemini single-file format using CommonFormat
+type Format struct {
+	*base.CommonFormat
+
+	strategy *Strategy
+}
+
+// NewFormat creates a new Gemini format implementation
+func NewFormat(fs afero.Fs) *Format {
+	bf := base.NewBaseFormat(fs, domain.FormatGemini)
+	strategy := NewStrategy(fs, bf)
+	commonFormat := base.NewCommonFormat(bf, strategy)
+
+	return &Format{
+		CommonFormat: commonFormat,
+		strategy:     strategy,
+	}
+}
+
+// NewFormatFromOptions creates a new Gemini format with options
+func NewFormatFromOptions(fs afero.Fs, _ map[string]any) (domain.Format, error) {
+	return NewFormat(fs), nil
+}
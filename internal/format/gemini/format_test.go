@@ -0,0 +1,152 @@
+package gemini
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/contextureai/contexture/internal/format/base"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testOutputPath = "/project/GEMINI.md"
+
+func TestFormat_Transform(t *testing.T) {
+	t.Parallel()
+	f := NewFormat(afero.NewMemMapFs())
+
+	rule := &domain.Rule{
+		ID:          "[contexture:languages/go/testing]",
+		Title:       "Go Testing",
+		Description: "Write table-driven tests",
+		Content:     "Use testify.\n\n:::only claude\nFor Claude\n:::\n\n:::only gemini\nFor Gemini\n:::\n",
+		Trigger:     &domain.RuleTrigger{Type: domain.TriggerGlob, Globs: []string{"*_test.go"}},
+	}
+	transformed, err := f.Transform(&domain.ProcessedRule{Rule: rule, Content: rule.Content})
+	require.NoError(t, err)
+	assert.Equal(t, "GEMINI.md", transformed.Filename)
+	assert.Contains(t, transformed.Content, "# Go Testing")
+	assert.Contains(t, transformed.Content, "**Applies:** When working with *_test.go files")
+	assert.Contains(t, transformed.Content, "For Gemini")
+	assert.NotContains(t, transformed.Content, "For Claude")
+	assert.Equal(t, "gemini", transformed.Metadata["format"])
+}
+
+func TestFormat_Write(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	f := NewFormat(fs)
+	config := &domain.FormatConfig{Type: domain.FormatGemini, BaseDir: "/project"}
+
+	rules := []*domain.TransformedRule{
+		{Rule: &domain.Rule{ID: "[contexture:test/rule1]", Title: "Rule 1"}, Content: "Content of rule 1"},
+		{Rule: &domain.Rule{ID: "[contexture:test/rule2]", Title: "Rule 2"}, Content: "Content of rule 2"},
+	}
+	require.NoError(t, f.Write(rules, config))
+
+	content, err := afero.ReadFile(fs, testOutputPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "# gemini.md\n\nContent of rule 1")
+	assert.Contains(t, string(content), "\n\n---\n\nContent of rule 2")
+	assert.Contains(t, string(content), "<!-- id: [contexture:test/rule2] -->")
+	assert.Contains(t, string(content), "<!-- Generated by Contexture CLI at ")
+
+	// Without rules the file is removed
+	require.NoError(t, f.Write(nil, config))
+	exists, err := afero.Exists(fs, testOutputPath)
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestFormat_Write_UserRules(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	f := NewFormat(fs)
+	config := &domain.FormatConfig{
+		Type:          domain.FormatGemini,
+		BaseDir:       "/home/user/.gemini",
+		IsUserRules:   true,
+		UserRulesFile: "/home/user/.gemini/GEMINI.md",
+	}
+
+	rules := []*domain.TransformedRule{{
+		Rule:    &domain.Rule{ID: "[contexture:personal/style]", Title: "Style"},
+		Content: "Be concise.",
+	}}
+	require.NoError(t, f.Write(rules, config))
+
+	content, err := afero.ReadFile(fs, "/home/user/.gemini/GEMINI.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "Be concise.")
+}
+
+func TestFormat_GetOutputPath(t *testing.T) {
+	t.Parallel()
+	f := NewFormat(afero.NewMemMapFs())
+
+	assert.Equal(t, "GEMINI.md", f.GetOutputPath(nil))
+	assert.Equal(t, "GEMINI.md", f.GetOutputPath(&domain.FormatConfig{}))
+	assert.Equal(t, testOutputPath, f.GetOutputPath(&domain.FormatConfig{BaseDir: "/project"}))
+	assert.Equal(t, "/home/user/dotfiles/GEMINI.md", f.GetOutputPath(&domain.FormatConfig{
+		BaseDir: "/home/user/.gemini", IsUserRules: true, UserRulesFile: "/home/user/dotfiles/GEMINI.md",
+	}))
+}
+
+func TestStrategy_WriteFiles_KeepBlocks(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	f := NewFormat(fs)
+	config := &domain.FormatConfig{Type: domain.FormatGemini, BaseDir: "/project"}
+
+	kept := base.WrapKeepBlock("Hand-written notes")
+	require.NoError(t, afero.WriteFile(fs, testOutputPath, []byte("old output\n\n"+kept+"\n\nold rule"), 0o644))
+
+	rules := []*domain.TransformedRule{{
+		Rule:    &domain.Rule{ID: "[contexture:test/rule]", Title: "Rule"},
+		Content: "Rule content",
+	}}
+	require.NoError(t, f.strategy.WriteFiles(rules, config))
+
+	content, err := afero.ReadFile(fs, testOutputPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), kept+"\n\nRule content")
+	assert.NotContains(t, string(content), "old rule")
+}
+
+func TestStrategy_WriteFiles_Template(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	f := NewFormat(fs)
+	config := &domain.FormatConfig{Type: domain.FormatGemini, BaseDir: "/project", Template: "GEMINI.template.md"}
+	require.NoError(t, afero.WriteFile(fs, "/project/GEMINI.template.md", []byte("# Acme\n\n{{.Rules}}\n"), 0o644))
+
+	rules := []*domain.TransformedRule{{
+		Rule:    &domain.Rule{ID: "[contexture:test/rule]", Title: "Rule"},
+		Content: "Rule content",
+	}}
+	require.NoError(t, f.strategy.WriteFiles(rules, config))
+
+	content, err := afero.ReadFile(fs, testOutputPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "# Acme\n\nRule content")
+	assert.NotContains(t, string(content), "# gemini.md")
+
+	config.Template = "../outside.md"
+	require.Error(t, f.strategy.WriteFiles(rules, config))
+}
+
+func TestHandler_GetCapabilities(t *testing.T) {
+	t.Parallel()
+	caps := (&Handler{}).GetCapabilities()
+	assert.True(t, caps.SupportsUserRules)
+	assert.Equal(t, domain.UserRulesNative, caps.DefaultUserRulesMode)
+	assert.True(t, strings.HasSuffix(caps.UserRulesPath, filepath.Join(".gemini", "GEMINI.md")))
+
+	config := domain.FormatConfig{Type: domain.FormatGemini}
+	assert.Equal(t, domain.UserRulesNative, config.GetEffectiveUserRulesMode())
+	config.UserRulesMode = domain.UserRulesNative
+	assert.True(t, config.ShouldOmitUserRulesMode())
+}
@@ -0,0 +1,41 @@
+// Package gemini provides Gemini CLI-specific UI components and format construction
+package gemini
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/charmbracelet/huh"
+	"github.com/contextureai/contexture/internal/domain"
+)
+
+// Handler implements the format.Handler interface for Gemini format
+type Handler struct{}
+
+// GetUIOption returns the UI option for Gemini format selection
+func (h *Handler) GetUIOption(selected bool) huh.Option[string] {
+	return huh.NewOption(h.GetDisplayName(), "gemini").Selected(selected)
+}
+
+// GetDisplayName returns the display name for Gemini format
+func (h *Handler) GetDisplayName() string {
+	return "Gemini CLI (GEMINI.md)"
+}
+
+// GetDescription returns the description for Gemini format
+func (h *Handler) GetDescription() string {
+	return "Single file output for Google's Gemini CLI"
+}
+
+// GetCapabilities returns the capabilities for Gemini format
+func (h *Handler) GetCapabilities() domain.FormatCapabilities {
+	homeDir, _ := os.UserHomeDir()
+	userRulesPath := filepath.Join(homeDir, ".gemini", domain.GeminiOutputFile)
+
+	return domain.FormatCapabilities{
+		SupportsUserRules:    true,
+		UserRulesPath:        userRulesPath,
+		DefaultUserRulesMode: domain.UserRulesNative,
+		MaxRuleSize:          0, // No specific limit for Gemini
+	}
+}
@@ -8,7 +8,9 @@ import (
 	"github.com/contextureai/contexture/internal/domain"
 	contextureerrors "github.com/contextureai/contexture/internal/errors"
 	"github.com/contextureai/contexture/internal/format/claude"
+	"github.com/contextureai/contexture/internal/format/copilot"
 	"github.com/contextureai/contexture/internal/format/cursor"
+	"github.com/contextureai/contexture/internal/format/gemini"
 	"github.com/contextureai/contexture/internal/format/windsurf"
 	"github.com/spf13/afero"
 )
@@ -54,6 +56,8 @@ func GetDefaultRegistry(fs afero.Fs) *Registry {
 	registry.Register(domain.FormatClaude, &claude.Handler{})
 	registry.Register(domain.FormatCursor, &cursor.Handler{})
 	registry.Register(domain.FormatWindsurf, &windsurf.Handler{})
+	registry.Register(domain.FormatCopilot, &copilot.Handler{})
+	registry.Register(domain.FormatGemini, &gemini.Handler{})
 
 	return registry
 }
@@ -72,6 +76,8 @@ func (r *Registry) GetUIOptions(selectedFormats []string) []huh.Option[string] {
 		domain.FormatClaude,
 		domain.FormatCursor,
 		domain.FormatWindsurf,
+		domain.FormatCopilot,
+		domain.FormatGemini,
 	}
 
 	for _, formatType := range orderedTypes {
@@ -84,12 +90,13 @@ func (r *Registry) GetUIOptions(selectedFormats []string) []huh.Option[string] {
 	return options
 }
 
-// GetAvailableFormats returns a list of all available format types
+// GetAvailableFormats returns a list of all available format types, sorted
 func (r *Registry) GetAvailableFormats() []domain.FormatType {
 	var formats []domain.FormatType
 	for formatType := range r.handlers {
 		formats = append(formats, formatType)
 	}
+	slices.Sort(formats)
 	return formats
 }
 
@@ -40,12 +40,14 @@ func TestGetDefaultRegistry(t *testing.T) {
 
 	// Check that all built-in formats are registered
 	formats := registry.GetAvailableFormats()
-	assert.Len(t, formats, 3)
+	assert.Len(t, formats, 5)
 
 	expectedFormats := []domain.FormatType{
 		domain.FormatClaude,
 		domain.FormatCursor,
 		domain.FormatWindsurf,
+		domain.FormatCopilot,
+		domain.FormatGemini,
 	}
 
 	for _, expected := range expectedFormats {
@@ -73,12 +75,14 @@ func TestRegistry_GetUIOptions(t *testing.T) {
 	registry := GetDefaultRegistry(fs)
 
 	options := registry.GetUIOptions([]string{"claude"})
-	assert.Len(t, options, 3) // claude, cursor, windsurf
+	assert.Len(t, options, 5) // claude, cursor, windsurf, copilot, gemini
 
 	// Check that options are in the expected order
 	assert.Equal(t, "claude", options[0].Value)
 	assert.Equal(t, "cursor", options[1].Value)
 	assert.Equal(t, "windsurf", options[2].Value)
+	assert.Equal(t, "copilot", options[3].Value)
+	assert.Equal(t, "gemini", options[4].Value)
 }
 
 func TestRegistry_GetAvailableFormats(t *testing.T) {
@@ -275,12 +279,14 @@ func TestDefaultBuilder_GetSupportedFormats(t *testing.T) {
 	builder := NewBuilder()
 
 	formats := builder.GetSupportedFormats()
-	assert.Len(t, formats, 3)
+	assert.Len(t, formats, 5)
 
 	expectedFormats := []domain.FormatType{
 		domain.FormatClaude,
 		domain.FormatCursor,
 		domain.FormatWindsurf,
+		domain.FormatCopilot,
+		domain.FormatGemini,
 	}
 
 	for _, expected := range expectedFormats {
@@ -24,6 +24,8 @@ const (
 	ModeMultiFile OutputMode = "multi"
 
 	singleFileFilename = "rules.md"
+	// globalRulesFilename is the single file Windsurf reads user rules from
+	globalRulesFilename = "global_rules.md"
 )
 
 // Strategy implements the FormatStrategy interface for Windsurf format
@@ -88,8 +90,12 @@ func (s *Strategy) GetOutputPath(config *domain.FormatConfig) string {
 	if config == nil || config.BaseDir == "" {
 		return domain.WindsurfOutputDir
 	}
-	// For user rules, output directly to BaseDir (e.g., ~/.windsurf)
+	// For user rules, output directly to BaseDir (e.g., ~/.windsurf), or to
+	// the directory of an overridden user rules file
 	if config.IsUserRules {
+		if config.UserRulesFile != "" {
+			return filepath.Dir(config.UserRulesFile)
+		}
 		return config.BaseDir
 	}
 	// For project rules, output to .windsurf/rules/ subdirectory
@@ -121,6 +127,7 @@ func (s *Strategy) GetMetadata() *domain.FormatMetadata {
 		DisplayName: "Windsurf IDE",
 		Description: "Multi-file format for Windsurf IDE (.windsurf/rules/)",
 		IsDirectory: true,
+		AssetsDir:   domain.WindsurfOutputDir + "/assets",
 	}
 }
 
@@ -158,9 +165,10 @@ func (s *Strategy) WriteFiles(rules []*domain.TransformedRule, config *domain.Fo
 
 	s.bf.LogDebug("Writing Windsurf format files", "rules", len(rules), "mode", s.mode)
 
-	// Check character limits for each rule individually
+	// Check character limits for each rule individually, unless it is split into parts
 	for _, rule := range rules {
-		if len(rule.Content) > domain.WindsurfMaxSingleRuleChars {
+		split := config != nil && config.SplitAbove > 0 && len(rule.Content) > config.SplitAbove
+		if len(rule.Content) > domain.WindsurfMaxSingleRuleChars && !split {
 			return contextureerrors.ValidationErrorf(
 				rule.Rule.ID,
 				"rule '%s' exceeds Windsurf per-file limit of %d characters (current: %d)",
@@ -181,7 +189,7 @@ func (s *Strategy) WriteFiles(rules []*domain.TransformedRule, config *domain.Fo
 	if useSingleFile {
 		return s.writeSingleFile(rules, outputDir, config)
 	}
-	return s.writeMultiFile(rules, outputDir)
+	return s.writeMultiFile(rules, outputDir, config)
 }
 
 // CleanupEmptyDirectories handles cleanup of empty directories for Windsurf format
@@ -210,12 +218,7 @@ func (s *Strategy) CreateDirectories(config *domain.FormatConfig) error {
 
 // writeSingleFile writes all rules to a single file
 func (s *Strategy) writeSingleFile(rules []*domain.TransformedRule, outputDir string, config *domain.FormatConfig) error {
-	filename := singleFileFilename
-	// For user rules, use global_rules.md instead of rules.md
-	if config != nil && config.IsUserRules {
-		filename = "global_rules.md"
-	}
-	filePath := filepath.Join(outputDir, filename)
+	filePath := filepath.Join(outputDir, singleFileName(config))
 
 	var content strings.Builder
 	content.Grow(s.estimateContentSize(rules))
@@ -224,15 +227,15 @@ func (s *Strategy) writeSingleFile(rules []*domain.TransformedRule, outputDir st
 	content.WriteString(s.getSingleFileHeader(len(rules)))
 	content.WriteString("\n\n")
 
-	// Write each rule
-	for i, rule := range rules {
-		if i > 0 {
-			content.WriteString("\n\n---\n\n")
-		}
-
-		// Write rule content with tracking comment appended, only including non-default variables
-		ruleContent := s.bf.AppendTrackingCommentWithDefaults(rule.Content, rule.Rule.ID, rule.Rule.Variables, rule.Rule.DefaultVariables)
-		content.WriteString(ruleContent)
+	// Write each rule, grouped under sections when configured
+	var groupBy domain.RuleGrouping
+	if config != nil {
+		groupBy = config.GroupBy
+	}
+	if groups := base.GroupRules(rules, groupBy); groups != nil {
+		content.WriteString(base.GroupedContent(groups, s.joinRules))
+	} else {
+		content.WriteString(s.joinRules(rules))
 	}
 
 	// Write footer
@@ -248,29 +251,66 @@ func (s *Strategy) writeSingleFile(rules []*domain.TransformedRule, outputDir st
 	return nil
 }
 
-// writeMultiFile writes each rule to its own file
-func (s *Strategy) writeMultiFile(rules []*domain.TransformedRule, outputDir string) error {
-	var errors []error
+// joinRules joins rules with separators and tracking comments
+func (s *Strategy) joinRules(rules []*domain.TransformedRule) string {
+	var content strings.Builder
+	for i, rule := range rules {
+		if i > 0 {
+			content.WriteString("\n\n---\n\n")
+		}
 
-	// Write each rule to its own file
-	for _, rule := range rules {
-		filePath := filepath.Join(outputDir, rule.Filename)
+		// Write rule content with tracking comment appended, only including non-default variables
+		ruleContent := s.bf.AppendTrackingCommentWithDefaults(rule.Content, rule.Rule.ID, rule.Rule.Variables, rule.Rule.DefaultVariables)
+		content.WriteString(ruleContent)
+	}
+	return content.String()
+}
 
-		// Append tracking comment at the end instead of header at beginning, only including non-default variables
-		content := s.bf.AppendTrackingCommentWithDefaults(rule.Content, rule.Rule.ID, rule.Rule.Variables, rule.Rule.DefaultVariables)
+// writeMultiFile writes each rule to its own file, or combined or split by
+// size when configured
+func (s *Strategy) writeMultiFile(rules []*domain.TransformedRule, outputDir string, config *domain.FormatConfig) error {
+	files := s.bf.LayoutRuleFiles(rules, config, s.GetFileExtension())
 
-		if err := s.bf.WriteFile(filePath, []byte(content)); err != nil {
-			errors = append(errors, contextureerrors.Wrap(err, "windsurf.writeMultiFile: write rule "+rule.Rule.ID))
+	// Combined files and parts of split rules are held to the per-file limit too
+	for _, file := range files {
+		if len(file.Rules) == 1 && file.Filename == file.Rules[0].Filename {
 			continue
 		}
+		if file.Size > domain.WindsurfMaxSingleRuleChars {
+			return contextureerrors.ValidationErrorf(
+				file.Filename,
+				"file '%s' exceeds Windsurf per-file limit of %d characters (current: %d)",
+				file.Filename,
+				domain.WindsurfMaxSingleRuleChars,
+				file.Size,
+			)
+		}
+	}
 
-		s.bf.LogDebug("Wrote Windsurf rule file", "ruleID", rule.Rule.ID, "path", filePath)
+	var errors []error
+	written := map[string]bool{}
+	for _, file := range files {
+		filePath := filepath.Join(outputDir, file.Filename)
+		written[file.Filename] = true
+
+		// Tracking comments are appended at the end instead of a header at the beginning
+		if err := s.bf.WriteFile(filePath, []byte(file.Content)); err != nil {
+			errors = append(errors, contextureerrors.Wrap(err, "windsurf.writeMultiFile: write "+file.Filename))
+			continue
+		}
+
+		s.bf.LogDebug("Wrote Windsurf rule file", "path", filePath, "rules", len(file.Rules))
 	}
 
 	if len(errors) > 0 {
 		return contextureerrors.WithOpf("windsurf.writeMultiFile", "failed to write %d rules: %v", len(errors), errors)
 	}
 
+	// Files left from an earlier layout would duplicate rules
+	if err := s.bf.RemoveStaleFiles(outputDir, written); err != nil {
+		return err
+	}
+
 	s.bf.LogInfo("Successfully wrote Windsurf multi-file format", "count", len(rules), "directory", outputDir)
 	return nil
 }
@@ -448,8 +488,7 @@ func (f *Format) GetDefaultTemplate() string {
 // removeSingleFile removes a rule from the single file by parsing and rebuilding content
 func (f *Format) removeSingleFile(ruleID string, config *domain.FormatConfig) error {
 	outputDir := f.strategy.GetOutputPath(config)
-	filename := singleFileFilename
-	filePath := filepath.Join(outputDir, filename)
+	filePath := filepath.Join(outputDir, singleFileName(config))
 
 	// Read current content (EAFP - will fail if file doesn't exist)
 	content, err := f.strategy.bf.ReadFile(filePath)
@@ -474,6 +513,18 @@ func (f *Format) removeSingleFile(ruleID string, config *domain.FormatConfig) er
 	return nil
 }
 
+// singleFileName returns the name of the single output file: rules.md in
+// projects, and global_rules.md or the overridden file name for user rules
+func singleFileName(config *domain.FormatConfig) string {
+	if config == nil || !config.IsUserRules {
+		return singleFileFilename
+	}
+	if config.UserRulesFile != "" {
+		return filepath.Base(config.UserRulesFile)
+	}
+	return globalRulesFilename
+}
+
 // removeRuleFromContent removes a specific rule from Windsurf format content
 func (f *Format) removeRuleFromContent(content, ruleID string) string {
 	// Split content by rule separators
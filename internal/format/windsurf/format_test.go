@@ -797,6 +797,33 @@ func TestFormat_Write_UserRulesGeneration(t *testing.T) {
 		assert.Contains(t, contentStr, "single-file mode")
 	})
 
+	t.Run("user rules output to overridden file", func(t *testing.T) {
+		rules := []*domain.TransformedRule{
+			{
+				Rule: &domain.Rule{
+					ID:      "[contexture:testing/unit]",
+					Title:   "Unit Testing",
+					Content: "Write unit tests for all functions",
+				},
+				Content:  "# Unit Testing\n\nWrite unit tests for all functions",
+				Filename: "testing-unit.md",
+			},
+		}
+
+		config := &domain.FormatConfig{
+			Type:          domain.FormatWindsurf,
+			BaseDir:       "/home/user/.windsurf",
+			IsUserRules:   true,
+			UserRulesFile: "/home/user/dotfiles/windsurf-rules.md",
+		}
+
+		require.NoError(t, f.Write(rules, config))
+
+		content, err := afero.ReadFile(fs, "/home/user/dotfiles/windsurf-rules.md")
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "Unit Testing")
+	})
+
 	t.Run("project rules output to .windsurf/rules/ directory", func(t *testing.T) {
 		rules := []*domain.TransformedRule{
 			{
@@ -0,0 +1,284 @@
+// Package gc removes what interrupted runs leave behind: temporary clone and
+// staging directories, temporary files of configuration saves and cache
+// writes, and git lock files in cached repositories
+package gc
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/contextureai/contexture/internal/cache"
+	"github.com/contextureai/contexture/internal/dependencies"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/staging"
+	"github.com/spf13/afero"
+)
+
+const (
+	// DefaultMinAge is the age below which leftovers are kept, as they may
+	// belong to a command still running
+	DefaultMinAge = time.Hour
+
+	// Interval is the time between two runs of the housekeeping at startup
+	Interval = 24 * time.Hour
+
+	// StampFile is the file in the state directory recording the last run
+	StampFile = "gc-last-run"
+)
+
+// tempDirPrefixes name the directories commands create in the system temp directory
+var tempDirPrefixes = []string{"contexture-add-", "contexture-stage-"}
+
+// Kind describes a leftover
+type Kind string
+
+const (
+	// TempDir is a temporary clone or staging directory
+	TempDir Kind = "temp-dir"
+	// PartialClone is a cached repository whose clone did not complete
+	PartialClone Kind = "partial-clone"
+	// TempFile is a temporary file of an interrupted write
+	TempFile Kind = "temp-file"
+	// Lock is a git lock file in a cached repository
+	Lock Kind = "lock"
+)
+
+// Item is a leftover found by the collector
+type Item struct {
+	Path string
+	Kind Kind
+	// Size is the number of bytes the item takes
+	Size int64
+}
+
+// Report lists the leftovers a run found
+type Report struct {
+	Items []Item
+	// Reclaimed is the number of bytes of the items removed, or of the
+	// items found in a dry run
+	Reclaimed int64
+}
+
+// Collector finds and removes leftovers
+type Collector struct {
+	fs         afero.Fs
+	tempDir    string
+	cacheDir   string
+	stateDir   string
+	configDirs []string
+	minAge     time.Duration
+	now        func() time.Time
+}
+
+// New creates a collector for the system temp directory, the cache
+// directory of env, recording its runs in the state directory of env, and
+// configDirs, the directories configuration files are
+// saved in, such as the project and global configuration directories
+func New(fs afero.Fs, env dependencies.Environment, configDirs ...string) *Collector {
+	return &Collector{
+		fs:         fs,
+		tempDir:    os.TempDir(),
+		cacheDir:   cache.BaseDir(env),
+		stateDir:   cache.StateDir(env),
+		configDirs: configDirs,
+		minAge:     DefaultMinAge,
+		now:        time.Now,
+	}
+}
+
+// Collect removes the leftovers older than DefaultMinAge, or only reports
+// them with dryRun. Items that cannot be removed are skipped and their
+// errors returned together.
+func (c *Collector) Collect(dryRun bool) (*Report, error) {
+	report := &Report{}
+	var errs []error
+	for _, item := range c.find() {
+		if !dryRun {
+			if err := c.fs.RemoveAll(item.Path); err != nil {
+				errs = append(errs, contextureerrors.Wrap(err, "remove "+item.Path))
+				continue
+			}
+		}
+		report.Items = append(report.Items, item)
+		report.Reclaimed += item.Size
+	}
+	if len(errs) > 0 {
+		return report, contextureerrors.Partial("collect garbage", errors.Join(errs...))
+	}
+	return report, nil
+}
+
+// CollectIfDue runs Collect when the last run is older than Interval, and
+// records the run. It returns nil when no run was due.
+func (c *Collector) CollectIfDue() (*Report, error) {
+	stamp := filepath.Join(c.stateDir, StampFile)
+	if info, err := c.fs.Stat(stamp); err == nil && c.now().Sub(info.ModTime()) < Interval {
+		return nil, nil
+	}
+	if err := c.fs.MkdirAll(c.stateDir, 0o755); err != nil {
+		return nil, contextureerrors.Wrap(err, "create state directory")
+	}
+	// The stamp is written first so concurrent commands do not run it twice
+	now := c.now()
+	if err := afero.WriteFile(c.fs, stamp, []byte(now.UTC().Format(time.RFC3339)+"\n"), 0o644); err != nil {
+		return nil, contextureerrors.Wrap(err, "record garbage collection")
+	}
+	_ = c.fs.Chtimes(stamp, now, now)
+	// Earlier versions kept the stamp in the cache directory
+	_ = c.fs.Remove(filepath.Join(c.cacheDir, StampFile))
+	return c.Collect(false)
+}
+
+// find returns the leftovers older than the minimum age
+func (c *Collector) find() []Item {
+	var items []Item
+	for _, entry := range c.readDir(c.tempDir) {
+		if entry.IsDir() && hasAnyPrefix(entry.Name(), tempDirPrefixes) && c.expired(entry) {
+			items = append(items, c.item(filepath.Join(c.tempDir, entry.Name()), TempDir))
+		}
+	}
+
+	for _, entry := range c.readDir(c.cacheDir) {
+		path := filepath.Join(c.cacheDir, entry.Name())
+		switch {
+		case !entry.IsDir():
+			// The marker of a partial clone is removed with it, or alone
+			// once the clone is gone
+			if (staging.IsLeftover(entry.Name()) || c.staleMarker(path)) && c.expired(entry) {
+				items = append(items, c.item(path, TempFile))
+			}
+		case slices.Contains(cache.ReservedDirs, entry.Name()):
+			items = append(items, c.tempFiles(path)...)
+		case c.exists(cache.CloneMarker(path)):
+			if c.expired(entry) {
+				items = append(items, c.item(path, PartialClone))
+				items = append(items, c.item(cache.CloneMarker(path), TempFile))
+			}
+		case c.isDir(filepath.Join(path, ".git")):
+			items = append(items, c.locks(filepath.Join(path, ".git"))...)
+		}
+	}
+
+	for _, dir := range c.configDirs {
+		for _, entry := range c.readDir(dir) {
+			name := entry.Name()
+			leftover := staging.IsLeftover(name) || (!entry.IsDir() && strings.HasSuffix(name, ".yaml.tmp"))
+			if leftover && c.expired(entry) {
+				items = append(items, c.item(filepath.Join(dir, name), TempFile))
+			}
+		}
+	}
+	return items
+}
+
+// staleMarker reports whether path is the marker of a clone that no longer exists
+func (c *Collector) staleMarker(path string) bool {
+	name := filepath.Base(path)
+	if !cache.IsCloneMarker(name) {
+		return false
+	}
+	return !c.exists(filepath.Join(filepath.Dir(path), strings.TrimSuffix(name[1:], filepath.Ext(name))))
+}
+
+// tempFiles returns the temporary files of interrupted cache writes under dir
+func (c *Collector) tempFiles(dir string) []Item {
+	var items []Item
+	_ = afero.Walk(c.fs, dir, func(path string, info fs.FileInfo, err error) error {
+		if err == nil && !info.IsDir() && (strings.HasPrefix(info.Name(), ".tmp-") || staging.IsLeftover(info.Name())) && c.expired(info) {
+			items = append(items, Item{Path: path, Kind: TempFile, Size: info.Size()})
+		}
+		return nil
+	})
+	return items
+}
+
+// locks returns the git lock files of an interrupted clone or pull in gitDir
+func (c *Collector) locks(gitDir string) []Item {
+	var items []Item
+	_ = afero.Walk(c.fs, gitDir, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info.IsDir() && info.Name() == "objects" {
+			return filepath.SkipDir
+		}
+		if !info.IsDir() && strings.HasSuffix(info.Name(), ".lock") && c.expired(info) {
+			items = append(items, Item{Path: path, Kind: Lock, Size: info.Size()})
+		}
+		return nil
+	})
+	return items
+}
+
+// item returns the item at path, sized with everything below it
+func (c *Collector) item(path string, kind Kind) Item {
+	item := Item{Path: path, Kind: kind}
+	_ = afero.Walk(c.fs, path, func(_ string, info fs.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			item.Size += info.Size()
+		}
+		return nil
+	})
+	return item
+}
+
+// readDir returns the entries of dir, or none when it cannot be read
+func (c *Collector) readDir(dir string) []fs.FileInfo {
+	if dir == "" {
+		return nil
+	}
+	entries, err := afero.ReadDir(c.fs, dir)
+	if err != nil {
+		log.Debug("Skipping directory for garbage collection", "path", dir, "error", err)
+		return nil
+	}
+	return entries
+}
+
+// exists reports whether path exists
+func (c *Collector) exists(path string) bool {
+	exists, _ := afero.Exists(c.fs, path)
+	return exists
+}
+
+// isDir reports whether path is a directory
+func (c *Collector) isDir(path string) bool {
+	exists, _ := afero.DirExists(c.fs, path)
+	return exists
+}
+
+// expired reports whether info was last modified before the minimum age
+func (c *Collector) expired(info fs.FileInfo) bool {
+	return c.now().Sub(info.ModTime()) >= c.minAge
+}
+
+// hasAnyPrefix reports whether name starts with one of prefixes
+func hasAnyPrefix(name string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// FormatBytes formats a number of bytes for display, such as 1.5 MB
+func FormatBytes(n int64) string {
+	const unit = 1000
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "kMGTPE"[exp])
+}
@@ -0,0 +1,155 @@
+package gc
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestCollector returns a collector over directories of an in-memory
+// filesystem, and a function writing a file last modified age ago
+func newTestCollector(t *testing.T) (*Collector, func(path, content string, age time.Duration)) {
+	t.Helper()
+	fs := afero.NewMemMapFs()
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	c := &Collector{
+		fs:         fs,
+		tempDir:    "/tmp",
+		cacheDir:   "/cache/contexture",
+		stateDir:   "/state/contexture",
+		configDirs: []string{"/project", "/home/user/.config/contexture"},
+		minAge:     DefaultMinAge,
+		now:        func() time.Time { return now },
+	}
+	write := func(path, content string, age time.Duration) {
+		require.NoError(t, fs.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, afero.WriteFile(fs, path, []byte(content), 0o644))
+		modified := now.Add(-age)
+		for p := path; p != "/"; p = filepath.Dir(p) {
+			require.NoError(t, fs.Chtimes(p, modified, modified))
+		}
+	}
+	return c, write
+}
+
+func TestCollector_Collect(t *testing.T) {
+	t.Parallel()
+	c, write := newTestCollector(t)
+	write("/tmp/contexture-add-123/go/testing.md", "# Testing", 2*time.Hour)
+	write("/tmp/contexture-stage-456/CLAUDE.md", "# Rules", 3*time.Hour)
+	write("/tmp/other-tool-789/data", "keep", 3*time.Hour)
+	write("/cache/contexture/github.com_acme_rules-main/README.md", "partial", 2*time.Hour)
+	write("/cache/contexture/.github.com_acme_rules-main.cloning", "", 2*time.Hour)
+	write("/cache/contexture/.github.com_acme_gone-main.cloning", "", 2*time.Hour)
+	write("/cache/contexture/github.com_acme_tools-main/.git/index.lock", "", 2*time.Hour)
+	write("/cache/contexture/github.com_acme_tools-main/.git/HEAD", "ref: refs/heads/main", 2*time.Hour)
+	write("/cache/contexture/rendered/v1/.tmp-123", "half", 2*time.Hour)
+	write("/cache/contexture/blobs/abc", "cached", 2*time.Hour)
+	write("/cache/contexture/configs/.abc.yaml.tmp-789", "half", 2*time.Hour)
+	write("/project/.CLAUDE.md.tmp-123", "rules", 2*time.Hour)
+	write("/project/.cursor.bak-456/rules/go.mdc", "go", 2*time.Hour)
+	write("/project/.contexture.yaml.tmp", "version: 1", 2*time.Hour)
+	write("/home/user/.config/contexture/.contexture.yaml.tmp", "version: 1", 2*time.Hour)
+
+	// Directories the cache does not know, such as clones of a branch with
+	// a slash in its name, are kept
+	write("/cache/contexture/github.com_acme_rules-feature/login/.git/HEAD", "ref: refs/heads/feature/login", 2*time.Hour)
+	write("/cache/contexture/notes/todo.md", "keep", 2*time.Hour)
+	write("/project/.env.bak-final", "keep", 2*time.Hour)
+
+	// Leftovers of commands that may still be running are kept
+	write("/tmp/contexture-add-999/go/errors.md", "# Errors", time.Minute)
+	write("/cache/contexture/github.com_acme_new-main/README.md", "cloning", time.Minute)
+	write("/cache/contexture/.github.com_acme_new-main.cloning", "", time.Minute)
+	write("/cache/contexture/github.com_acme_web-main/.git/index.lock", "", time.Minute)
+
+	report, err := c.Collect(true)
+	require.NoError(t, err)
+	assert.Equal(t, []Item{
+		{Path: "/tmp/contexture-add-123", Kind: TempDir, Size: 9},
+		{Path: "/tmp/contexture-stage-456", Kind: TempDir, Size: 7},
+		{Path: "/cache/contexture/.github.com_acme_gone-main.cloning", Kind: TempFile},
+		{Path: "/cache/contexture/configs/.abc.yaml.tmp-789", Kind: TempFile, Size: 4},
+		{Path: "/cache/contexture/github.com_acme_rules-main", Kind: PartialClone, Size: 7},
+		{Path: "/cache/contexture/.github.com_acme_rules-main.cloning", Kind: TempFile},
+		{Path: "/cache/contexture/github.com_acme_tools-main/.git/index.lock", Kind: Lock},
+		{Path: "/cache/contexture/rendered/v1/.tmp-123", Kind: TempFile, Size: 4},
+		{Path: "/project/.CLAUDE.md.tmp-123", Kind: TempFile, Size: 5},
+		{Path: "/project/.contexture.yaml.tmp", Kind: TempFile, Size: 10},
+		{Path: "/project/.cursor.bak-456", Kind: TempFile, Size: 2},
+		{Path: "/home/user/.config/contexture/.contexture.yaml.tmp", Kind: TempFile, Size: 10},
+	}, report.Items)
+	assert.Equal(t, int64(58), report.Reclaimed)
+
+	// A dry run removes nothing
+	exists, err := afero.Exists(c.fs, "/tmp/contexture-add-123")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	report, err = c.Collect(false)
+	require.NoError(t, err)
+	assert.Len(t, report.Items, 12)
+	for _, item := range report.Items {
+		exists, err := afero.Exists(c.fs, item.Path)
+		require.NoError(t, err)
+		assert.False(t, exists, item.Path)
+	}
+	for _, kept := range []string{
+		"/tmp/other-tool-789/data",
+		"/tmp/contexture-add-999/go/errors.md",
+		"/cache/contexture/github.com_acme_tools-main/.git/HEAD",
+		"/cache/contexture/blobs/abc",
+		"/cache/contexture/github.com_acme_web-main/.git/index.lock",
+		"/cache/contexture/github.com_acme_new-main/README.md",
+		"/cache/contexture/github.com_acme_rules-feature/login/.git/HEAD",
+		"/cache/contexture/notes/todo.md",
+		"/project/.env.bak-final",
+	} {
+		exists, err := afero.Exists(c.fs, kept)
+		require.NoError(t, err)
+		assert.True(t, exists, kept)
+	}
+}
+
+func TestCollector_CollectIfDue(t *testing.T) {
+	t.Parallel()
+	c, write := newTestCollector(t)
+	write("/tmp/contexture-add-123/go/testing.md", "# Testing", 2*time.Hour)
+	write("/cache/contexture/"+StampFile, "2026-03-02T11:00:00Z", time.Hour)
+
+	report, err := c.CollectIfDue()
+	require.NoError(t, err)
+	require.NotNil(t, report)
+	assert.Len(t, report.Items, 1)
+	exists, err := afero.Exists(c.fs, "/state/contexture/"+StampFile)
+	require.NoError(t, err)
+	assert.True(t, exists, "runs are recorded in the state directory")
+	exists, err = afero.Exists(c.fs, "/cache/contexture/"+StampFile)
+	require.NoError(t, err)
+	assert.False(t, exists, "the stamp of earlier versions is removed")
+
+	// The next run is due a day later
+	write("/tmp/contexture-add-456/go/testing.md", "# Testing", 2*time.Hour)
+	report, err = c.CollectIfDue()
+	require.NoError(t, err)
+	assert.Nil(t, report)
+
+	later := c.now().Add(Interval)
+	c.now = func() time.Time { return later }
+	report, err = c.CollectIfDue()
+	require.NoError(t, err)
+	require.NotNil(t, report)
+	assert.Len(t, report.Items, 1)
+}
+
+func TestFormatBytes(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "0 B", FormatBytes(0))
+	assert.Equal(t, "999 B", FormatBytes(999))
+	assert.Equal(t, "1.5 kB", FormatBytes(1500))
+	assert.Equal(t, "12.3 MB", FormatBytes(12_300_000))
+}
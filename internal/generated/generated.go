@@ -0,0 +1,99 @@
+// Package generated writes the local rules contexture generates from a
+// repository, such as the project facts and the git conventions. Each
+// generator detects and renders its own rule; this package keeps the rules
+// recognizable, protects rules taken over by hand, and writes them only when
+// they change.
+package generated
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/spf13/afero"
+)
+
+// Rule describes a generated rule
+type Rule struct {
+	// Command is the generate subcommand writing the rule, such as env-rule
+	Command string
+	// File is the file name of the rule in the local rules directory
+	File string
+}
+
+// Marker returns the comment that identifies a rule written by command, so
+// it is only refreshed while it is machine-maintained
+func Marker(command string) string {
+	return "<!-- Generated by contexture generate " + command
+}
+
+// WriteHeader writes the frontmatter of a rule applying always, the
+// generated marker of command, and the title as heading
+func WriteHeader(b *strings.Builder, command, title, description, tag string) {
+	b.WriteString("---\n")
+	fmt.Fprintf(b, "title: %s\n", title)
+	fmt.Fprintf(b, "description: %s\n", description)
+	fmt.Fprintf(b, "tags: [%s]\n", tag)
+	b.WriteString("trigger: always\n")
+	b.WriteString("---\n\n")
+	b.WriteString(Marker(command) + "; changes are overwritten when it is refreshed -->\n\n")
+	fmt.Fprintf(b, "# %s\n", title)
+}
+
+// WriteSection writes a heading and a list, or nothing for an empty list
+func WriteSection(b *strings.Builder, heading string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n## %s\n\n", heading)
+	for _, item := range items {
+		fmt.Fprintf(b, "- %s\n", item)
+	}
+}
+
+// ReadOptional returns the content of path, or nil when it does not exist
+func ReadOptional(fs afero.Fs, path string) ([]byte, error) {
+	data, err := afero.ReadFile(fs, path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "read "+filepath.Base(path))
+	}
+	return data, nil
+}
+
+// Write writes the content render returns to the rule in rulesDir. A rule
+// without the generated marker was taken over by hand and is only replaced
+// with force; render is not called for it. It reports whether the file
+// changed.
+func Write(fs afero.Fs, rulesDir string, rule Rule, force bool, render func() (string, error)) (string, bool, error) {
+	path := filepath.Join(rulesDir, rule.File)
+	existing, err := ReadOptional(fs, path)
+	if err != nil {
+		return path, false, err
+	}
+	if existing != nil && !force && !bytes.Contains(existing, []byte(Marker(rule.Command))) {
+		return path, false, contextureerrors.Validation(rule.Command, path+" was not generated by contexture").
+			WithSuggestions(contextureerrors.RunCommand("contexture generate "+rule.Command+" --force", "replace it"))
+	}
+
+	content, err := render()
+	if err != nil {
+		return path, false, err
+	}
+	if string(existing) == content {
+		return path, false, nil
+	}
+	if err := fs.MkdirAll(rulesDir, 0o755); err != nil {
+		return path, false, contextureerrors.Wrap(err, "create rules directory")
+	}
+	if err := afero.WriteFile(fs, path, []byte(content), 0o644); err != nil {
+		return path, false, contextureerrors.Wrap(err, "write "+rule.File)
+	}
+	return path, true, nil
+}
@@ -0,0 +1,80 @@
+package generated
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testRule = Rule{Command: "test-rule", File: "test.md"}
+
+func renderTest() (string, error) {
+	var b strings.Builder
+	WriteHeader(&b, testRule.Command, "Test", "A generated rule", "test")
+	WriteSection(&b, "Items", []string{"one", "two"})
+	WriteSection(&b, "Empty", nil)
+	return b.String(), nil
+}
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+
+	path, changed, err := Write(fs, "/project/rules", testRule, false, renderTest)
+	require.NoError(t, err)
+	assert.Equal(t, "/project/rules/test.md", path)
+	assert.True(t, changed)
+	content, err := afero.ReadFile(fs, path)
+	require.NoError(t, err)
+	assert.Equal(t, `---
+title: Test
+description: A generated rule
+tags: [test]
+trigger: always
+---
+
+<!-- Generated by contexture generate test-rule; changes are overwritten when it is refreshed -->
+
+# Test
+
+## Items
+
+- one
+- two
+`, string(content))
+
+	_, changed, err = Write(fs, "/project/rules", testRule, false, renderTest)
+	require.NoError(t, err)
+	assert.False(t, changed, "an unchanged rule is not rewritten")
+}
+
+func TestWrite_HandEdited(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/rules/test.md", []byte("# Written by hand\n"), 0o644))
+
+	rendered := false
+	render := func() (string, error) {
+		rendered = true
+		return renderTest()
+	}
+	_, _, err := Write(fs, "/rules", testRule, false, render)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "was not generated by contexture")
+	assert.False(t, rendered)
+
+	_, changed, err := Write(fs, "/rules", testRule, true, render)
+	require.NoError(t, err)
+	assert.True(t, changed)
+}
+
+func TestReadOptional(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	data, err := ReadOptional(fs, "/missing")
+	require.NoError(t, err)
+	assert.Nil(t, data)
+}
@@ -0,0 +1,33 @@
+package git
+
+import (
+	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+)
+
+// UserIdentity returns the git user of dir, as "Name <email>" or whichever
+// of the two is configured, from the configuration of the repository
+// containing dir and the user's global configuration. It returns an empty
+// string when neither is configured.
+func UserIdentity(dir string) string {
+	var config *gitconfig.Config
+	if repo, err := git.PlainOpenWithOptions(dir, &git.PlainOpenOptions{DetectDotGit: true}); err == nil {
+		config, _ = repo.ConfigScoped(gitconfig.GlobalScope)
+	}
+	if config == nil {
+		config, _ = gitconfig.LoadConfig(gitconfig.GlobalScope)
+	}
+	if config == nil {
+		return ""
+	}
+
+	name, email := config.User.Name, config.User.Email
+	switch {
+	case name != "" && email != "":
+		return name + " <" + email + ">"
+	case email != "":
+		return email
+	default:
+		return name
+	}
+}
@@ -38,6 +38,63 @@ func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
 	return &MockRepository_Expecter{mock: &_m.Mock}
 }
 
+// CheckRemote provides a mock function for the type MockRepository
+func (_mock *MockRepository) CheckRemote(ctx context.Context, repoURL string) error {
+	ret := _mock.Called(ctx, repoURL)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckRemote")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, repoURL)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepository_CheckRemote_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckRemote'
+type MockRepository_CheckRemote_Call struct {
+	*mock.Call
+}
+
+// CheckRemote is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repoURL string
+func (_e *MockRepository_Expecter) CheckRemote(ctx interface{}, repoURL interface{}) *MockRepository_CheckRemote_Call {
+	return &MockRepository_CheckRemote_Call{Call: _e.mock.On("CheckRemote", ctx, repoURL)}
+}
+
+func (_c *MockRepository_CheckRemote_Call) Run(run func(ctx context.Context, repoURL string)) *MockRepository_CheckRemote_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_CheckRemote_Call) Return(err error) *MockRepository_CheckRemote_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepository_CheckRemote_Call) RunAndReturn(run func(ctx context.Context, repoURL string) error) *MockRepository_CheckRemote_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Clone provides a mock function for the type MockRepository
 func (_mock *MockRepository) Clone(ctx context.Context, repoURL string, localPath string, opts ...CloneOption) error {
 	var tmpRet mock.Arguments
@@ -116,6 +173,146 @@ func (_c *MockRepository_Clone_Call) RunAndReturn(run func(ctx context.Context,
 	return _c
 }
 
+// CountFileCommitsSince provides a mock function for the type MockRepository
+func (_mock *MockRepository) CountFileCommitsSince(localPath string, filePath string, commitHash string, branch string) (int, error) {
+	ret := _mock.Called(localPath, filePath, commitHash, branch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountFileCommitsSince")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, string, string) (int, error)); ok {
+		return returnFunc(localPath, filePath, commitHash, branch)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string, string, string) int); ok {
+		r0 = returnFunc(localPath, filePath, commitHash, branch)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string, string, string) error); ok {
+		r1 = returnFunc(localPath, filePath, commitHash, branch)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_CountFileCommitsSince_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountFileCommitsSince'
+type MockRepository_CountFileCommitsSince_Call struct {
+	*mock.Call
+}
+
+// CountFileCommitsSince is a helper method to define mock.On call
+//   - localPath string
+//   - filePath string
+//   - commitHash string
+//   - branch string
+func (_e *MockRepository_Expecter) CountFileCommitsSince(localPath interface{}, filePath interface{}, commitHash interface{}, branch interface{}) *MockRepository_CountFileCommitsSince_Call {
+	return &MockRepository_CountFileCommitsSince_Call{Call: _e.mock.On("CountFileCommitsSince", localPath, filePath, commitHash, branch)}
+}
+
+func (_c *MockRepository_CountFileCommitsSince_Call) Run(run func(localPath string, filePath string, commitHash string, branch string)) *MockRepository_CountFileCommitsSince_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_CountFileCommitsSince_Call) Return(n int, err error) *MockRepository_CountFileCommitsSince_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockRepository_CountFileCommitsSince_Call) RunAndReturn(run func(localPath string, filePath string, commitHash string, branch string) (int, error)) *MockRepository_CountFileCommitsSince_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBranchNames provides a mock function for the type MockRepository
+func (_mock *MockRepository) GetBranchNames(localPath string) ([]string, error) {
+	ret := _mock.Called(localPath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBranchNames")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) ([]string, error)); ok {
+		return returnFunc(localPath)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = returnFunc(localPath)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(localPath)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_GetBranchNames_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBranchNames'
+type MockRepository_GetBranchNames_Call struct {
+	*mock.Call
+}
+
+// GetBranchNames is a helper method to define mock.On call
+//   - localPath string
+func (_e *MockRepository_Expecter) GetBranchNames(localPath interface{}) *MockRepository_GetBranchNames_Call {
+	return &MockRepository_GetBranchNames_Call{Call: _e.mock.On("GetBranchNames", localPath)}
+}
+
+func (_c *MockRepository_GetBranchNames_Call) Run(run func(localPath string)) *MockRepository_GetBranchNames_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetBranchNames_Call) Return(strings []string, err error) *MockRepository_GetBranchNames_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *MockRepository_GetBranchNames_Call) RunAndReturn(run func(localPath string) ([]string, error)) *MockRepository_GetBranchNames_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetCommitInfoByHash provides a mock function for the type MockRepository
 func (_mock *MockRepository) GetCommitInfoByHash(localPath string, commitHash string) (*CommitInfo, error) {
 	ret := _mock.Called(localPath, commitHash)
@@ -184,6 +381,74 @@ func (_c *MockRepository_GetCommitInfoByHash_Call) RunAndReturn(run func(localPa
 	return _c
 }
 
+// GetCommitSubjects provides a mock function for the type MockRepository
+func (_mock *MockRepository) GetCommitSubjects(localPath string, limit int) ([]string, error) {
+	ret := _mock.Called(localPath, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCommitSubjects")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, int) ([]string, error)); ok {
+		return returnFunc(localPath, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, int) []string); ok {
+		r0 = returnFunc(localPath, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = returnFunc(localPath, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_GetCommitSubjects_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCommitSubjects'
+type MockRepository_GetCommitSubjects_Call struct {
+	*mock.Call
+}
+
+// GetCommitSubjects is a helper method to define mock.On call
+//   - localPath string
+//   - limit int
+func (_e *MockRepository_Expecter) GetCommitSubjects(localPath interface{}, limit interface{}) *MockRepository_GetCommitSubjects_Call {
+	return &MockRepository_GetCommitSubjects_Call{Call: _e.mock.On("GetCommitSubjects", localPath, limit)}
+}
+
+func (_c *MockRepository_GetCommitSubjects_Call) Run(run func(localPath string, limit int)) *MockRepository_GetCommitSubjects_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetCommitSubjects_Call) Return(strings []string, err error) *MockRepository_GetCommitSubjects_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *MockRepository_GetCommitSubjects_Call) RunAndReturn(run func(localPath string, limit int) ([]string, error)) *MockRepository_GetCommitSubjects_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetFileAtCommit provides a mock function for the type MockRepository
 func (_mock *MockRepository) GetFileAtCommit(localPath string, filePath string, commitHash string) ([]byte, error) {
 	ret := _mock.Called(localPath, filePath, commitHash)
@@ -0,0 +1,228 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/go-git/go-git/v5/plumbing/transport/client"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+)
+
+// Rate limiting constants for HTTP(S) requests to Git hosts
+const (
+	// DefaultRequestInterval is the minimum time between requests to the same host
+	DefaultRequestInterval = 100 * time.Millisecond
+	// DefaultMaxRateLimitWait is the longest a request waits for a host's rate limit to reset
+	DefaultMaxRateLimitWait = 2 * time.Minute
+	// defaultRateLimitBackoff is the first backoff when a host does not say when its limit resets
+	defaultRateLimitBackoff = 2 * time.Second
+)
+
+// RateLimitError reports that a Git host rate limited requests longer than
+// Contexture is willing to wait
+type RateLimitError struct {
+	Host  string
+	Reset time.Time // zero when the host did not report a reset time
+}
+
+func (e *RateLimitError) Error() string {
+	if e.Reset.IsZero() {
+		return fmt.Sprintf("rate limit exceeded for %s", e.Host)
+	}
+	return fmt.Sprintf("rate limit exceeded for %s until %s", e.Host, e.Reset.Local().Format(time.Kitchen))
+}
+
+// RateLimiter spaces requests to each host and holds them back while the
+// host's rate limit is exhausted. Requests reserve their slot in arrival
+// order, so bulk operations queue instead of bursting.
+type RateLimiter struct {
+	interval   time.Duration
+	maxWait    time.Duration
+	maxRetries int
+
+	mu    sync.Mutex
+	hosts map[string]time.Time // earliest time the next request to a host may start
+
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// NewRateLimiter creates a rate limiter that starts requests to the same host
+// at least interval apart, waits at most maxWait for a rate limit to reset,
+// and retries a rate limited request up to maxRetries times
+func NewRateLimiter(interval, maxWait time.Duration, maxRetries int) *RateLimiter {
+	return &RateLimiter{
+		interval:   interval,
+		maxWait:    maxWait,
+		maxRetries: maxRetries,
+		hosts:      make(map[string]time.Time),
+		now:        time.Now,
+		sleep:      sleepContext,
+	}
+}
+
+// Wait blocks until a request to host may start. It returns a RateLimitError
+// without waiting when the host is blocked for longer than the maximum wait.
+func (l *RateLimiter) Wait(ctx context.Context, host string) error {
+	l.mu.Lock()
+	now := l.now()
+	start := l.hosts[host]
+	if start.Before(now) {
+		start = now
+	}
+	if delay := start.Sub(now); delay > l.maxWait {
+		l.mu.Unlock()
+		return &RateLimitError{Host: host, Reset: start}
+	}
+	l.hosts[host] = start.Add(l.interval)
+	l.mu.Unlock()
+
+	if delay := start.Sub(now); delay > 0 {
+		log.Debug("Waiting for Git host rate limit", "host", host, "delay", delay)
+		return l.sleep(ctx, delay)
+	}
+	return nil
+}
+
+// Block holds back requests to host until the given time
+func (l *RateLimiter) Block(host string, until time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if until.After(l.hosts[host]) {
+		l.hosts[host] = until
+	}
+}
+
+// Transport wraps base so every request goes through the rate limiter
+func (l *RateLimiter) Transport(base http.RoundTripper) http.RoundTripper {
+	return &rateLimitedTransport{base: base, limiter: l}
+}
+
+// rateLimitedTransport is an http.RoundTripper that waits for the rate
+// limiter before each request and retries requests rejected by a rate limit
+type rateLimitedTransport struct {
+	base    http.RoundTripper
+	limiter *RateLimiter
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+	backoff := defaultRateLimitBackoff
+
+	for attempt := 0; ; attempt++ {
+		if err := t.limiter.Wait(req.Context(), host); err != nil {
+			return nil, err
+		}
+
+		resp, err := t.base.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+
+		now := t.limiter.now()
+		reset, limited := rateLimitReset(resp, now)
+		if !limited {
+			// Hold back further requests once the host reports the limit is used up
+			if !reset.IsZero() {
+				t.limiter.Block(host, reset)
+			}
+			return resp, nil
+		}
+
+		drainBody(resp)
+		if reset.IsZero() {
+			reset = now.Add(backoff)
+			backoff *= 2
+		}
+		t.limiter.Block(host, reset)
+		log.Debug("Git host rate limited request", "host", host, "status", resp.StatusCode, "reset", reset)
+
+		if attempt >= t.limiter.maxRetries || reset.Sub(now) > t.limiter.maxWait || (req.Body != nil && req.GetBody == nil) {
+			return nil, &RateLimitError{Host: host, Reset: reset}
+		}
+
+		retry := req.Clone(req.Context())
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			retry.Body = body
+		}
+		req = retry
+	}
+}
+
+// rateLimitReset reports whether resp was rejected by a rate limit, and when
+// the limit resets. For responses that were not rejected, the reset time is
+// set only when the host reports that no requests remain.
+func rateLimitReset(resp *http.Response, now time.Time) (time.Time, bool) {
+	header := resp.Header
+	exhausted := header.Get("X-RateLimit-Remaining") == "0"
+	retryAfter := header.Get("Retry-After")
+
+	limited := resp.StatusCode == http.StatusTooManyRequests ||
+		(resp.StatusCode == http.StatusForbidden && (exhausted || retryAfter != ""))
+	if !limited && !exhausted {
+		return time.Time{}, false
+	}
+
+	if retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil {
+			return now.Add(time.Duration(seconds) * time.Second), limited
+		}
+		if date, err := http.ParseTime(retryAfter); err == nil {
+			return date, limited
+		}
+	}
+	if unix, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		return time.Unix(unix, 0), limited
+	}
+	return time.Time{}, limited
+}
+
+// drainBody discards and closes a response body so the connection can be reused
+func drainBody(resp *http.Response) {
+	if resp.Body == nil {
+		return
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+	_ = resp.Body.Close()
+}
+
+// sleepContext sleeps for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+var (
+	defaultRateLimiter         = NewRateLimiter(DefaultRequestInterval, DefaultMaxRateLimitWait, DefaultMaxRetries)
+	installRateLimitedHTTPOnce sync.Once
+)
+
+// installRateLimitedHTTP routes go-git's HTTP(S) transports through the
+// process-wide rate limiter. go-git keeps one transport per scheme, so the
+// limiter is shared by every client in the process.
+func installRateLimitedHTTP() {
+	installRateLimitedHTTPOnce.Do(func() {
+		transport := githttp.NewClient(&http.Client{
+			Transport: defaultRateLimiter.Transport(http.DefaultTransport),
+		})
+		client.InstallProtocol("https", transport)
+		client.InstallProtocol("http", transport)
+	})
+}
@@ -0,0 +1,154 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRateLimiter returns a rate limiter on a fake clock that advances when it sleeps
+func newTestRateLimiter(interval, maxWait time.Duration, maxRetries int) (*RateLimiter, *[]time.Duration) {
+	limiter := NewRateLimiter(interval, maxWait, maxRetries)
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	var sleeps []time.Duration
+	limiter.now = func() time.Time { return now }
+	limiter.sleep = func(_ context.Context, d time.Duration) error {
+		sleeps = append(sleeps, d)
+		now = now.Add(d)
+		return nil
+	}
+	return limiter, &sleeps
+}
+
+func TestRateLimiter_Wait(t *testing.T) {
+	t.Parallel()
+	limiter, sleeps := newTestRateLimiter(time.Second, time.Minute, 0)
+	ctx := context.Background()
+
+	require.NoError(t, limiter.Wait(ctx, "github.com"))
+	require.NoError(t, limiter.Wait(ctx, "github.com"))
+	// Other hosts are not held back
+	require.NoError(t, limiter.Wait(ctx, "gitlab.com"))
+	assert.Equal(t, []time.Duration{time.Second}, *sleeps)
+
+	reset := limiter.now().Add(2 * time.Minute)
+	limiter.Block("github.com", reset)
+	err := limiter.Wait(ctx, "github.com")
+	var rateLimitErr *RateLimitError
+	require.ErrorAs(t, err, &rateLimitErr)
+	assert.Equal(t, "github.com", rateLimitErr.Host)
+	assert.Equal(t, reset, rateLimitErr.Reset)
+}
+
+func TestRateLimitReset(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		status      int
+		headers     map[string]string
+		wantReset   time.Time
+		wantLimited bool
+	}{
+		{name: "ok", status: http.StatusOK},
+		{name: "forbidden without rate limit headers", status: http.StatusForbidden},
+		{
+			name:        "too many requests with retry-after seconds",
+			status:      http.StatusTooManyRequests,
+			headers:     map[string]string{"Retry-After": "30"},
+			wantReset:   now.Add(30 * time.Second),
+			wantLimited: true,
+		},
+		{
+			name:        "too many requests with retry-after date",
+			status:      http.StatusTooManyRequests,
+			headers:     map[string]string{"Retry-After": "Wed, 01 Jan 2025 12:05:00 GMT"},
+			wantReset:   now.Add(5 * time.Minute),
+			wantLimited: true,
+		},
+		{
+			name:        "forbidden with exhausted limit",
+			status:      http.StatusForbidden,
+			headers:     map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "1735733100"},
+			wantReset:   time.Unix(1735733100, 0),
+			wantLimited: true,
+		},
+		{
+			name:      "ok with exhausted limit",
+			status:    http.StatusOK,
+			headers:   map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "1735733100"},
+			wantReset: time.Unix(1735733100, 0),
+		},
+		{name: "too many requests without reset", status: http.StatusTooManyRequests, wantLimited: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			resp := &http.Response{StatusCode: tt.status, Header: http.Header{}}
+			for key, value := range tt.headers {
+				resp.Header.Set(key, value)
+			}
+			reset, limited := rateLimitReset(resp, now)
+			assert.Equal(t, tt.wantLimited, limited)
+			assert.True(t, tt.wantReset.Equal(reset), "reset %v, want %v", reset, tt.wantReset)
+		})
+	}
+}
+
+func TestRateLimitedTransport_RetriesAfterReset(t *testing.T) {
+	t.Parallel()
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Header().Set("Retry-After", "5")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	limiter, sleeps := newTestRateLimiter(0, time.Minute, 3)
+	client := &http.Client{Transport: limiter.Transport(http.DefaultTransport)}
+
+	req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader("want"))
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(2), requests.Load())
+	assert.Equal(t, []time.Duration{5 * time.Second}, *sleeps)
+}
+
+func TestRateLimitedTransport_GivesUpBeyondMaxWait(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	limiter, sleeps := newTestRateLimiter(0, time.Minute, 3)
+	client := &http.Client{Transport: limiter.Transport(http.DefaultTransport)}
+
+	resp, err := client.Get(server.URL)
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	var rateLimitErr *RateLimitError
+	require.True(t, errors.As(err, &rateLimitErr))
+	assert.Contains(t, err.Error(), "rate limit exceeded")
+	assert.Empty(t, *sleeps)
+}
@@ -17,20 +17,27 @@ package git
 import (
 	"context"
 	"errors"
+	"io"
+	"net"
 	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/charmbracelet/log"
 	contextureerrors "github.com/contextureai/contexture/internal/errors"
 	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
 	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/go-git/go-git/v5/plumbing/transport/http"
 	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/go-git/go-git/v5/storage/memory"
 	"github.com/kevinburke/ssh_config"
 	"github.com/spf13/afero"
 )
@@ -54,8 +61,37 @@ var (
 	ErrRepositoryExists  = errors.New("repository already exists")
 	ErrNotARepository    = errors.New("not a git repository")
 	ErrNoAuthMethod      = errors.New("no authentication method available")
+	ErrFileNotFound      = errors.New("file not found at commit")
 )
 
+// IsUnavailable reports whether err means the remote could not be reached,
+// timed out, refused the credentials, or failed on the server, so another copy
+// of the repository may succeed where the remote did not
+func IsUnavailable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	for _, target := range []error{
+		ErrAuthFailed,
+		ErrNoAuthMethod,
+		transport.ErrAuthenticationRequired,
+		transport.ErrAuthorizationFailed,
+		contextureerrors.ErrTimeout,
+		context.DeadlineExceeded,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	var netErr net.Error
+	var rateLimitErr *RateLimitError
+	if errors.As(err, &netErr) || errors.As(err, &rateLimitErr) {
+		return true
+	}
+	var httpErr *http.Err
+	return errors.As(err, &httpErr) && httpErr.StatusCode() >= 500
+}
+
 // Repository defines the interface for Git repository operations
 // Following Go conventions, the interface is named after what it represents
 type Repository interface {
@@ -65,15 +101,20 @@ type Repository interface {
 	GetFileCommitInfo(localPath, filePath, branch string) (*CommitInfo, error)
 	GetCommitInfoByHash(localPath, commitHash string) (*CommitInfo, error)
 	GetFileAtCommit(localPath, filePath, commitHash string) ([]byte, error)
+	CountFileCommitsSince(localPath, filePath, commitHash, branch string) (int, error)
+	GetCommitSubjects(localPath string, limit int) ([]string, error)
+	GetBranchNames(localPath string) ([]string, error)
 	ValidateURL(repoURL string) error
 	IsValidRepository(localPath string) bool
 	GetRemoteURL(localPath string) (string, error)
+	CheckRemote(ctx context.Context, repoURL string) error
 }
 
 // CommitInfo represents git commit information
 type CommitInfo struct {
-	Hash string
-	Date string
+	Hash   string
+	Date   string
+	Author string
 }
 
 // Config holds configuration for Git operations
@@ -108,11 +149,35 @@ type Client struct {
 	httpURLRegex *regexp.Regexp
 }
 
+// configuredTimeouts are the clone and pull timeouts set with SetDefaultTimeouts
+var configuredTimeouts atomic.Pointer[[2]time.Duration]
+
+// SetDefaultTimeouts sets the clone and pull timeouts of configurations
+// created by DefaultConfig afterwards. A zero timeout restores its default.
+func SetDefaultTimeouts(clone, pull time.Duration) {
+	configuredTimeouts.Store(&[2]time.Duration{clone, pull})
+}
+
+// defaultTimeouts returns the clone and pull timeouts of new configurations
+func defaultTimeouts() (time.Duration, time.Duration) {
+	clone, pull := DefaultCloneTimeout, DefaultPullTimeout
+	if configured := configuredTimeouts.Load(); configured != nil {
+		if configured[0] > 0 {
+			clone = configured[0]
+		}
+		if configured[1] > 0 {
+			pull = configured[1]
+		}
+	}
+	return clone, pull
+}
+
 // DefaultConfig returns a configuration with secure defaults
 func DefaultConfig(fs afero.Fs) Config {
+	cloneTimeout, pullTimeout := defaultTimeouts()
 	return Config{
-		CloneTimeout:   DefaultCloneTimeout,
-		PullTimeout:    DefaultPullTimeout,
+		CloneTimeout:   cloneTimeout,
+		PullTimeout:    pullTimeout,
 		MaxRetries:     DefaultMaxRetries,
 		AllowedSchemes: []string{"https", "ssh"},
 		AllowedHosts: []string{
@@ -124,8 +189,10 @@ func DefaultConfig(fs afero.Fs) Config {
 	}
 }
 
-// NewClient creates a new Client with the provided configuration
+// NewClient creates a new Client with the provided configuration.
+// HTTP(S) requests of all clients share a per-host rate limiter.
 func NewClient(fs afero.Fs, config Config) Repository {
+	installRateLimitedHTTP()
 	return &Client{
 		fs:     fs,
 		config: config,
@@ -541,8 +608,9 @@ func (c *Client) GetFileCommitInfo(localPath, filePath, branch string) (*CommitI
 	}
 
 	return &CommitInfo{
-		Hash: fileCommit.Hash.String(), // Full hash (stored in config)
-		Date: fileCommit.Author.When.Format("2 Jan 2006"),
+		Hash:   fileCommit.Hash.String(), // Full hash (stored in config)
+		Date:   fileCommit.Author.When.Format("2 Jan 2006"),
+		Author: fileCommit.Author.Name,
 	}, nil
 }
 
@@ -565,8 +633,9 @@ func (c *Client) GetCommitInfoByHash(localPath, commitHash string) (*CommitInfo,
 	}
 
 	return &CommitInfo{
-		Hash: commit.Hash.String(), // Full hash (stored in config)
-		Date: commit.Author.When.Format("2 Jan 2006"),
+		Hash:   commit.Hash.String(), // Full hash (stored in config)
+		Date:   commit.Author.When.Format("2 Jan 2006"),
+		Author: commit.Author.Name,
 	}, nil
 }
 
@@ -597,6 +666,9 @@ func (c *Client) GetFileAtCommit(localPath, filePath, commitHash string) ([]byte
 
 	// Get the file from the tree
 	file, err := tree.File(filePath)
+	if errors.Is(err, object.ErrFileNotFound) {
+		return nil, contextureerrors.Wrap(ErrFileNotFound, "get_file")
+	}
 	if err != nil {
 		return nil, contextureerrors.Wrap(err, "get_file")
 	}
@@ -620,6 +692,134 @@ func (c *Client) GetFileAtCommit(localPath, filePath, commitHash string) ([]byte
 	return content, nil
 }
 
+// CountFileCommitsSince returns the number of commits on the specified branch
+// that touched a file after the given commit. Commits that the given commit
+// already contains are not counted.
+func (c *Client) CountFileCommitsSince(localPath, filePath, commitHash, branch string) (int, error) {
+	repo, err := git.PlainOpen(localPath)
+	if err != nil {
+		return 0, contextureerrors.Wrap(err, "open_repository")
+	}
+
+	ref, err := c.resolveReference(repo, branch)
+	if err != nil {
+		return 0, contextureerrors.Wrap(err, "resolve_reference")
+	}
+
+	// Resolve commit hash (handles both full and short hashes efficiently)
+	hash, err := repo.ResolveRevision(plumbing.Revision(commitHash))
+	if err != nil {
+		return 0, contextureerrors.Wrap(err, "resolve_commit")
+	}
+
+	since, err := repo.CommitObject(*hash)
+	if err != nil {
+		return 0, contextureerrors.Wrap(err, "get_commit")
+	}
+
+	iter, err := repo.Log(&git.LogOptions{
+		From:     ref.Hash(),
+		FileName: &filePath,
+	})
+	if err != nil {
+		return 0, contextureerrors.Wrap(err, "get_file_history")
+	}
+	defer iter.Close()
+
+	count := 0
+	for {
+		commit, err := iter.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return 0, contextureerrors.Wrap(err, "walk_file_history")
+		}
+		if commit.Hash == since.Hash {
+			break
+		}
+		if contained, err := commit.IsAncestor(since); err == nil && contained {
+			break
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+// GetCommitSubjects returns the subject lines of up to limit commits reachable
+// from HEAD, newest first. A repository without commits has none.
+func (c *Client) GetCommitSubjects(localPath string, limit int) ([]string, error) {
+	repo, err := git.PlainOpen(localPath)
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "open_repository")
+	}
+
+	head, err := repo.Head()
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "resolve_head")
+	}
+
+	iter, err := repo.Log(&git.LogOptions{From: head.Hash()})
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "get_history")
+	}
+	defer iter.Close()
+
+	var subjects []string
+	err = iter.ForEach(func(commit *object.Commit) error {
+		if len(subjects) >= limit {
+			return storer.ErrStop
+		}
+		subject, _, _ := strings.Cut(strings.TrimSpace(commit.Message), "\n")
+		subjects = append(subjects, strings.TrimSpace(subject))
+		return nil
+	})
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "read_history")
+	}
+	return subjects, nil
+}
+
+// GetBranchNames returns the names of the local and remote-tracking branches,
+// without the remote name, such as feature/login for origin/feature/login
+func (c *Client) GetBranchNames(localPath string) ([]string, error) {
+	repo, err := git.PlainOpen(localPath)
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "open_repository")
+	}
+
+	refs, err := repo.References()
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "list_references")
+	}
+	defer refs.Close()
+
+	seen := map[string]bool{}
+	var names []string
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		var name string
+		switch {
+		case ref.Name().IsBranch():
+			name = ref.Name().Short()
+		case ref.Name().IsRemote():
+			_, name, _ = strings.Cut(ref.Name().Short(), "/")
+		}
+		if name != "" && name != "HEAD" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "read_references")
+	}
+	return names, nil
+}
+
 // ValidateURL validates a git repository URL with comprehensive security checks
 func (c *Client) ValidateURL(repoURL string) error {
 	if repoURL == "" {
@@ -682,6 +882,32 @@ func (c *Client) GetRemoteURL(localPath string) (string, error) {
 	return config.URLs[0], nil
 }
 
+// CheckRemote verifies that a remote repository is reachable by listing its references
+// without cloning. It applies the same URL validation and authentication as Clone.
+func (c *Client) CheckRemote(ctx context.Context, repoURL string) error {
+	if err := c.ValidateURL(repoURL); err != nil {
+		return contextureerrors.Wrap(err, "check_remote")
+	}
+
+	ctx, cancel := c.setupTimeout(ctx, c.config.PullTimeout)
+	defer cancel()
+
+	auth, err := c.config.AuthProvider.GetAuth(repoURL)
+	if err != nil {
+		return contextureerrors.Wrap(err, "check_remote")
+	}
+
+	remote := git.NewRemote(memory.NewStorage(), &gitconfig.RemoteConfig{
+		Name: "origin",
+		URLs: []string{repoURL},
+	})
+	if _, err := remote.ListContext(ctx, &git.ListOptions{Auth: auth}); err != nil {
+		return contextureerrors.Wrap(err, "check_remote")
+	}
+
+	return nil
+}
+
 // Helper methods for GitClient
 
 // setupTimeout sets up a timeout context if one isn't already set
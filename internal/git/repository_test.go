@@ -1,11 +1,18 @@
 package git
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net"
+	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
@@ -569,6 +576,20 @@ func TestDefaultConfig(t *testing.T) {
 	assert.NotEmpty(t, config.AllowedHosts) // Has default allowed hosts
 }
 
+// TestSetDefaultTimeouts changes package state, so it does not run in parallel
+func TestSetDefaultTimeouts(t *testing.T) {
+	t.Cleanup(func() { SetDefaultTimeouts(0, 0) })
+
+	SetDefaultTimeouts(30*time.Second, 0)
+	config := DefaultConfig(afero.NewMemMapFs())
+	assert.Equal(t, 30*time.Second, config.CloneTimeout)
+	assert.Equal(t, DefaultPullTimeout, config.PullTimeout)
+
+	SetDefaultTimeouts(0, 0)
+	config = DefaultConfig(afero.NewMemMapFs())
+	assert.Equal(t, DefaultCloneTimeout, config.CloneTimeout)
+}
+
 func TestNewClient(t *testing.T) {
 	t.Parallel()
 	fs := afero.NewMemMapFs()
@@ -903,3 +924,104 @@ func TestDefaultAuthProvider_TildeExpansion(t *testing.T) {
 	hostname := provider.extractHostnameFromSSHURL("git@github.com:user/repo.git")
 	assert.Equal(t, "github.com", hostname)
 }
+
+func TestClient_CountFileCommitsSince(t *testing.T) {
+	t.Parallel()
+	repoDir := t.TempDir()
+	repo, err := git.PlainInit(repoDir, false)
+	require.NoError(t, err)
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+
+	commit := func(file, content string) plumbing.Hash {
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, file), []byte(content), 0o644))
+		_, err := worktree.Add(file)
+		require.NoError(t, err)
+		hash, err := worktree.Commit("update "+file, &git.CommitOptions{
+			Author: &object.Signature{Name: "Test", Email: "test@example.com", When: time.Now()},
+		})
+		require.NoError(t, err)
+		return hash
+	}
+
+	first := commit("rule.md", "v1")
+	commit("other.md", "v1")
+	commit("rule.md", "v2")
+	commit("rule.md", "v3")
+
+	client := NewClient(afero.NewOsFs(), DefaultConfig(afero.NewOsFs()))
+
+	count, err := client.CountFileCommitsSince(repoDir, "rule.md", first.String(), "")
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	count, err = client.CountFileCommitsSince(repoDir, "other.md", first.String(), "")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	_, err = client.CountFileCommitsSince(repoDir, "rule.md", "0000000000000000000000000000000000000000", "")
+	assert.Error(t, err)
+}
+
+func TestClient_GetCommitSubjectsAndBranchNames(t *testing.T) {
+	t.Parallel()
+	repoDir := t.TempDir()
+	repo, err := git.PlainInit(repoDir, false)
+	require.NoError(t, err)
+	client := NewClient(afero.NewOsFs(), DefaultConfig(afero.NewOsFs()))
+
+	subjects, err := client.GetCommitSubjects(repoDir, 10)
+	require.NoError(t, err)
+	assert.Empty(t, subjects, "a repository without commits has no history")
+
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+	var head plumbing.Hash
+	for i, message := range []string{"feat: add login\n\nWith a form.", "fix: reject empty passwords", "docs: describe login"} {
+		file := fmt.Sprintf("file%d.md", i)
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, file), []byte(message), 0o644))
+		_, err := worktree.Add(file)
+		require.NoError(t, err)
+		head, err = worktree.Commit(message, &git.CommitOptions{
+			Author: &object.Signature{Name: "Test", Email: "test@example.com", When: time.Now()},
+		})
+		require.NoError(t, err)
+	}
+
+	subjects, err = client.GetCommitSubjects(repoDir, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs: describe login", "fix: reject empty passwords"}, subjects)
+
+	require.NoError(t, repo.Storer.SetReference(plumbing.NewHashReference("refs/heads/feature/login", head)))
+	require.NoError(t, repo.Storer.SetReference(plumbing.NewHashReference("refs/remotes/origin/fix/footer", head)))
+	require.NoError(t, repo.Storer.SetReference(plumbing.NewHashReference("refs/remotes/origin/feature/login", head)))
+
+	branches, err := client.GetBranchNames(repoDir)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"master", "feature/login", "fix/footer"}, branches)
+
+	_, err = client.GetCommitSubjects(t.TempDir(), 10)
+	assert.Error(t, err)
+}
+
+func TestIsUnavailable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "authentication failed", err: fmt.Errorf("clone: %w", ErrAuthFailed), want: true},
+		{name: "authorization failed", err: transport.ErrAuthorizationFailed, want: true},
+		{name: "timeout", err: fmt.Errorf("clone: %w", context.DeadlineExceeded), want: true},
+		{name: "network", err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}, want: true},
+		{name: "rate limited", err: &RateLimitError{Host: "github.com"}, want: true},
+		{name: "canceled", err: context.Canceled, want: false},
+		{name: "invalid URL", err: ErrInvalidURL, want: false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, IsUnavailable(tt.err), tt.name)
+	}
+}
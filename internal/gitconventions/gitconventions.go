@@ -0,0 +1,565 @@
+// Package gitconventions detects the commit message and branch naming
+// conventions of a repository, from its commitlint configuration and its
+// history, and renders them as a local rule
+package gitconventions
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/generated"
+	"github.com/contextureai/contexture/internal/git"
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+)
+
+// RuleFile is the file name of the rule in the local rules directory
+const RuleFile = "git-conventions.md"
+
+// rule is the rule written by contexture generate git-rule
+var rule = generated.Rule{Command: "git-rule", File: RuleFile}
+
+// GeneratedMarker identifies a rule written by Render, so it is only
+// refreshed while it is machine-maintained
+var GeneratedMarker = generated.Marker(rule.Command)
+
+// Commit subject styles
+const (
+	// StyleConventional is Conventional Commits, type(scope): description
+	StyleConventional = "conventional"
+	// StyleBracketed starts subjects with a bracketed reference, [ABC-123] description
+	StyleBracketed = "bracketed"
+	// StylePlain is a summary without a prefix
+	StylePlain = "plain"
+)
+
+const (
+	// historyLimit is the number of recent commits analysed
+	historyLimit = 100
+	// styleShare is the share of commits that must follow a style for it to
+	// be the project's convention
+	styleShare = 0.6
+	// ticketShare is the share of commits that must reference a ticket for
+	// references to be expected
+	ticketShare = 0.3
+	// minUses is how often a type, scope, or branch prefix is used before it
+	// is listed, so one-off mistakes are left out
+	minUses = 2
+	// maxScopes is the number of most used scopes listed
+	maxScopes = 12
+)
+
+// Case is the letter case descriptions start with
+type Case string
+
+// Description cases
+const (
+	CaseLower Case = "lower"
+	CaseUpper Case = "upper"
+)
+
+// Conventions describes how a repository names commits and branches
+type Conventions struct {
+	Name string
+	// Commitlint is the commitlint configuration file, when there is one
+	Commitlint string
+	// Commits is the number of commits analysed
+	Commits int
+	Style   string
+	// Types and Scopes are the Conventional Commits types and scopes in use,
+	// or allowed by commitlint
+	Types  []string
+	Scopes []string
+	// Reference is an example of the bracketed reference of bracketed
+	// subjects, with its digits replaced
+	Reference string
+	// TicketKey is the key of the tickets most subjects reference, such as ABC
+	TicketKey string
+	// Case is the case descriptions start with, when most agree
+	Case Case
+	// NoPeriod is set when subjects do not end with a period
+	NoPeriod bool
+	// HeaderMaxLength is the longest subject commitlint allows
+	HeaderMaxLength int
+	// BranchPrefixes are the prefixes of topic branches, such as feature
+	BranchPrefixes []string
+	// BranchTickets is set when topic branches name a ticket
+	BranchTickets bool
+	// MainBranches are the long-lived branches, such as main and develop
+	MainBranches []string
+}
+
+// empty reports whether nothing was detected
+func (c *Conventions) empty() bool {
+	return c.Style == "" && len(c.BranchPrefixes) == 0 && len(c.MainBranches) == 0
+}
+
+var (
+	conventionalPattern = regexp.MustCompile(`^([a-z]+)(?:\(([^)]+)\))?!?: (\S.*)$`)
+	bracketedPattern    = regexp.MustCompile(`^\[([^\]]+)\] (\S.*)$`)
+	ticketPattern       = regexp.MustCompile(`\b([A-Z][A-Z0-9]+)-\d+\b`)
+	digitsPattern       = regexp.MustCompile(`\d+`)
+)
+
+// commitlintFiles are the commitlint configuration files, in the order
+// commitlint looks for them
+var commitlintFiles = []string{
+	".commitlintrc",
+	".commitlintrc.json",
+	".commitlintrc.yaml",
+	".commitlintrc.yml",
+	".commitlintrc.js",
+	".commitlintrc.cjs",
+	".commitlintrc.mjs",
+	".commitlintrc.ts",
+	"commitlint.config.js",
+	"commitlint.config.cjs",
+	"commitlint.config.mjs",
+	"commitlint.config.ts",
+}
+
+// conventionalTypes are the types of @commitlint/config-conventional
+var conventionalTypes = []string{
+	"build", "chore", "ci", "docs", "feat", "fix", "perf", "refactor", "revert", "style", "test",
+}
+
+// mainBranches are the long-lived branch names, which are not topic branches
+var mainBranches = []string{"main", "master", "develop", "development", "trunk"}
+
+// Detect reads the conventions of the repository in dir. The history is
+// read through repo from the repository containing dir; a directory outside
+// a repository only has its commitlint configuration.
+func Detect(fs afero.Fs, repo git.Repository, dir string) (*Conventions, error) {
+	conventions := &Conventions{Name: filepath.Base(dir)}
+	if err := conventions.readCommitlint(fs, dir); err != nil {
+		return nil, err
+	}
+
+	root := findRepositoryRoot(fs, dir)
+	if root == "" {
+		return conventions, nil
+	}
+	subjects, err := repo.GetCommitSubjects(root, historyLimit)
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "read commit history")
+	}
+	branches, err := repo.GetBranchNames(root)
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "read branches")
+	}
+	conventions.analyzeSubjects(subjects)
+	conventions.analyzeBranches(branches)
+	return conventions, nil
+}
+
+// findRepositoryRoot returns the closest directory from dir up that contains
+// .git, or an empty string outside a repository
+func findRepositoryRoot(fs afero.Fs, dir string) string {
+	for {
+		if exists, _ := afero.Exists(fs, filepath.Join(dir, ".git")); exists {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// commitlintConfig is the part of a commitlint configuration the rule uses
+type commitlintConfig struct {
+	Extends any              `yaml:"extends" json:"extends"`
+	Rules   map[string][]any `yaml:"rules"   json:"rules"`
+}
+
+// readCommitlint reads the commitlint configuration file or the commitlint
+// key of package.json. Configurations written in JavaScript are scanned for
+// the rules rather than evaluated.
+func (c *Conventions) readCommitlint(fs afero.Fs, dir string) error {
+	for _, name := range commitlintFiles {
+		data, err := generated.ReadOptional(fs, filepath.Join(dir, name))
+		if err != nil {
+			return err
+		}
+		if data == nil {
+			continue
+		}
+		c.Commitlint = name
+		switch filepath.Ext(name) {
+		case ".js", ".cjs", ".mjs", ".ts":
+			c.applyCommitlintScript(string(data))
+		default:
+			var config commitlintConfig
+			if err := yaml.Unmarshal(data, &config); err != nil {
+				return contextureerrors.Wrap(err, "parse "+name)
+			}
+			c.applyCommitlint(&config)
+		}
+		return nil
+	}
+
+	data, err := generated.ReadOptional(fs, filepath.Join(dir, "package.json"))
+	if data == nil || err != nil {
+		return err
+	}
+	var pkg struct {
+		Commitlint *commitlintConfig `json:"commitlint"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return contextureerrors.Wrap(err, "parse package.json")
+	}
+	if pkg.Commitlint != nil {
+		c.Commitlint = "package.json"
+		c.applyCommitlint(pkg.Commitlint)
+	}
+	return nil
+}
+
+// applyCommitlint takes the style, types, scopes, and header length from a
+// commitlint configuration
+func (c *Conventions) applyCommitlint(config *commitlintConfig) {
+	var presets []string
+	switch extends := config.Extends.(type) {
+	case string:
+		presets = []string{extends}
+	case []any:
+		for _, preset := range extends {
+			if name, ok := preset.(string); ok {
+				presets = append(presets, name)
+			}
+		}
+	}
+	if slices.ContainsFunc(presets, isConventionalPreset) {
+		c.Style = StyleConventional
+		c.Types = conventionalTypes
+		c.HeaderMaxLength = 100
+	}
+
+	if types := ruleValues(config.Rules["type-enum"]); len(types) > 0 {
+		c.Style = StyleConventional
+		c.Types = types
+	}
+	c.Scopes = ruleValues(config.Rules["scope-enum"])
+	if length := ruleValue(config.Rules["header-max-length"]); length > 0 {
+		c.HeaderMaxLength = length
+	}
+}
+
+// isConventionalPreset reports whether a shared configuration enforces
+// Conventional Commits
+func isConventionalPreset(preset string) bool {
+	return strings.Contains(preset, "config-conventional") || strings.Contains(preset, "config-angular")
+}
+
+// ruleValues returns the values of an enabled enum rule, [level, "always", [values]]
+func ruleValues(rule []any) []string {
+	if len(rule) < 3 || !ruleEnabled(rule) {
+		return nil
+	}
+	values, _ := rule[2].([]any)
+	var names []string
+	for _, value := range values {
+		if name, ok := value.(string); ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// ruleValue returns the number of an enabled rule, [level, "always", number]
+func ruleValue(rule []any) int {
+	if len(rule) < 3 || !ruleEnabled(rule) {
+		return 0
+	}
+	switch value := rule[2].(type) {
+	case int:
+		return value
+	case float64:
+		return int(value)
+	}
+	return 0
+}
+
+// ruleEnabled reports whether a rule reports violations and applies always
+func ruleEnabled(rule []any) bool {
+	level := fmt.Sprint(rule[0])
+	return level != "0" && fmt.Sprint(rule[1]) == "always"
+}
+
+var (
+	scriptTypeEnumPattern  = regexp.MustCompile(`['"]?type-enum['"]?\s*:\s*\[\s*\d\s*,\s*['"]always['"]\s*,\s*\[([^\]]*)\]`)
+	scriptScopeEnumPattern = regexp.MustCompile(`['"]?scope-enum['"]?\s*:\s*\[\s*\d\s*,\s*['"]always['"]\s*,\s*\[([^\]]*)\]`)
+	scriptHeaderPattern    = regexp.MustCompile(`['"]?header-max-length['"]?\s*:\s*\[\s*\d\s*,\s*['"]always['"]\s*,\s*(\d+)`)
+	scriptStringPattern    = regexp.MustCompile(`['"]([^'"]+)['"]`)
+)
+
+// applyCommitlintScript scans a JavaScript or TypeScript configuration for
+// the shared configuration it extends and the literal values of its rules
+func (c *Conventions) applyCommitlintScript(script string) {
+	if isConventionalPreset(script) {
+		c.Style = StyleConventional
+		c.Types = conventionalTypes
+		c.HeaderMaxLength = 100
+	}
+	scriptStrings := func(list string) []string {
+		var values []string
+		for _, match := range scriptStringPattern.FindAllStringSubmatch(list, -1) {
+			values = append(values, match[1])
+		}
+		return values
+	}
+	if match := scriptTypeEnumPattern.FindStringSubmatch(script); match != nil {
+		c.Style = StyleConventional
+		c.Types = scriptStrings(match[1])
+	}
+	if match := scriptScopeEnumPattern.FindStringSubmatch(script); match != nil {
+		c.Scopes = scriptStrings(match[1])
+	}
+	if match := scriptHeaderPattern.FindStringSubmatch(script); match != nil {
+		c.HeaderMaxLength, _ = strconv.Atoi(match[1])
+	}
+}
+
+// analyzeSubjects takes the style, and the types and scopes it uses, from
+// the commit subjects when commitlint does not set them, and the ticket
+// references and description case from the subjects in any case
+func (c *Conventions) analyzeSubjects(subjects []string) {
+	types := map[string]int{}
+	scopes := map[string]int{}
+	references := map[string]int{}
+	tickets := map[string]int{}
+	var conventional, bracketed, referencing, lower, upper, periods int
+	var descriptions []string
+
+	for _, subject := range subjects {
+		if strings.HasPrefix(subject, "Merge ") || strings.HasPrefix(subject, "Revert \"") {
+			continue
+		}
+		c.Commits++
+		description := subject
+		if match := conventionalPattern.FindStringSubmatch(subject); match != nil {
+			conventional++
+			types[match[1]]++
+			if match[2] != "" {
+				scopes[match[2]]++
+			}
+			description = match[3]
+		} else if match := bracketedPattern.FindStringSubmatch(subject); match != nil {
+			bracketed++
+			references[digitsPattern.ReplaceAllString(match[1], "123")]++
+			description = match[2]
+		}
+		descriptions = append(descriptions, description)
+
+		if keys := ticketPattern.FindAllStringSubmatch(subject, -1); keys != nil {
+			referencing++
+			tickets[keys[0][1]]++
+		}
+		if strings.HasSuffix(subject, ".") {
+			periods++
+		}
+	}
+	if c.Commits == 0 {
+		return
+	}
+
+	share := func(count int) float64 { return float64(count) / float64(c.Commits) }
+	if c.Style == "" {
+		switch {
+		case share(conventional) >= styleShare:
+			c.Style = StyleConventional
+			c.Types = used(types, 0)
+			c.Scopes = used(scopes, maxScopes)
+		case share(bracketed) >= styleShare:
+			c.Style = StyleBracketed
+			c.Reference = mostUsed(references)
+		default:
+			c.Style = StylePlain
+		}
+	} else if c.Style == StyleConventional && len(c.Scopes) == 0 {
+		c.Scopes = used(scopes, maxScopes)
+	}
+
+	if share(referencing) >= ticketShare && c.Style != StyleBracketed {
+		c.TicketKey = mostUsed(tickets)
+	}
+	for _, description := range descriptions {
+		switch {
+		case description == "":
+		case description[0] >= 'a' && description[0] <= 'z':
+			lower++
+		case description[0] >= 'A' && description[0] <= 'Z':
+			upper++
+		}
+	}
+	switch {
+	case share(lower) >= 0.8:
+		c.Case = CaseLower
+	case share(upper) >= 0.8:
+		c.Case = CaseUpper
+	}
+	c.NoPeriod = share(periods) < 0.1
+}
+
+// analyzeBranches takes the long-lived branches and the prefixes of topic
+// branches, such as feature in feature/login
+func (c *Conventions) analyzeBranches(branches []string) {
+	prefixes := map[string]int{}
+	var topics, ticketed int
+	for _, branch := range branches {
+		if slices.Contains(mainBranches, branch) {
+			if !slices.Contains(c.MainBranches, branch) {
+				c.MainBranches = append(c.MainBranches, branch)
+			}
+			continue
+		}
+		prefix, rest, found := strings.Cut(branch, "/")
+		if !found {
+			continue
+		}
+		topics++
+		prefixes[prefix]++
+		if ticketPattern.MatchString(rest) {
+			ticketed++
+		}
+	}
+	sort.Slice(c.MainBranches, func(i, j int) bool {
+		return slices.Index(mainBranches, c.MainBranches[i]) < slices.Index(mainBranches, c.MainBranches[j])
+	})
+	c.BranchPrefixes = used(prefixes, 0)
+	c.BranchTickets = topics > 0 && float64(ticketed)/float64(topics) >= styleShare
+}
+
+// used returns the names used at least minUses times, or the limit most
+// used of them when limit is set, sorted by name
+func used(counts map[string]int, limit int) []string {
+	var names []string
+	for name, count := range counts {
+		if count >= minUses {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if counts[names[i]] != counts[names[j]] {
+			return counts[names[i]] > counts[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	if limit > 0 && len(names) > limit {
+		names = names[:limit]
+	}
+	sort.Strings(names)
+	return names
+}
+
+// mostUsed returns the most used name, the first by name on a tie
+func mostUsed(counts map[string]int) string {
+	var best string
+	for name, count := range counts {
+		if best == "" || count > counts[best] || (count == counts[best] && name < best) {
+			best = name
+		}
+	}
+	return best
+}
+
+// Render returns the local rule describing conventions. It leaves out the
+// commits themselves, so refreshing rewrites the rule only when the
+// conventions change.
+func Render(conventions *Conventions) string {
+	var b strings.Builder
+	generated.WriteHeader(&b, rule.Command, "Git Conventions",
+		"Commit message and branch naming conventions of "+conventions.Name, "git")
+
+	var commits []string
+	enforced := ""
+	if conventions.Commitlint != "" {
+		enforced = ", enforced by commitlint (`" + conventions.Commitlint + "`)"
+	}
+	switch conventions.Style {
+	case StyleConventional:
+		commits = append(commits, "Follow Conventional Commits: `type(scope): description`"+enforced)
+		if len(conventions.Types) > 0 {
+			commits = append(commits, "Types: "+codeList(conventions.Types))
+		}
+		if len(conventions.Scopes) > 0 {
+			commits = append(commits, "Scopes: "+codeList(conventions.Scopes))
+		}
+	case StyleBracketed:
+		commits = append(commits, "Start the subject with a bracketed reference, such as `["+conventions.Reference+"] Add the feature`"+enforced)
+	case StylePlain:
+		commits = append(commits, "Write a one-line summary subject without a type prefix"+enforced)
+	}
+	if conventions.TicketKey != "" {
+		commits = append(commits, "Reference the ticket in the subject, such as `"+conventions.TicketKey+"-123`")
+	}
+	switch conventions.Case {
+	case CaseLower:
+		commits = append(commits, "Start the description with a lowercase letter")
+	case CaseUpper:
+		commits = append(commits, "Start the description with a capital letter")
+	}
+	if conventions.NoPeriod && conventions.Style != "" {
+		commits = append(commits, "Leave off the trailing period")
+	}
+	if conventions.HeaderMaxLength > 0 {
+		commits = append(commits, fmt.Sprintf("Keep the subject within %d characters", conventions.HeaderMaxLength))
+	}
+	generated.WriteSection(&b, "Commit Messages", commits)
+
+	var branches []string
+	if len(conventions.BranchPrefixes) > 0 {
+		prefixes := make([]string, len(conventions.BranchPrefixes))
+		for i, prefix := range conventions.BranchPrefixes {
+			prefixes[i] = prefix + "/"
+		}
+		example := "`" + conventions.BranchPrefixes[0] + "/short-description`"
+		if conventions.BranchTickets {
+			key := conventions.TicketKey
+			if key == "" {
+				key = "ABC"
+			}
+			example = "`" + conventions.BranchPrefixes[0] + "/" + key + "-123-short-description`"
+		}
+		branches = append(branches, "Name topic branches with a prefix: "+codeList(prefixes)+", such as "+example)
+	}
+	if len(conventions.MainBranches) > 0 {
+		branches = append(branches, "Long-lived branches: "+codeList(conventions.MainBranches))
+	}
+	generated.WriteSection(&b, "Branches", branches)
+	return b.String()
+}
+
+// codeList formats names as a comma-separated list of code spans
+func codeList(names []string) string {
+	spans := make([]string, len(names))
+	for i, name := range names {
+		spans[i] = "`" + name + "`"
+	}
+	return strings.Join(spans, ", ")
+}
+
+// Write renders the conventions of the repository in projectDir into the
+// rule in rulesDir. A rule without the generated marker was taken over by
+// hand and is only replaced with force. It reports whether the file changed.
+func Write(fs afero.Fs, repo git.Repository, projectDir, rulesDir string, force bool) (string, bool, error) {
+	return generated.Write(fs, rulesDir, rule, force, func() (string, error) {
+		conventions, err := Detect(fs, repo, projectDir)
+		if err != nil {
+			return "", err
+		}
+		if conventions.empty() {
+			return "", contextureerrors.Validation(rule.Command, "no commit history or commitlint configuration found").
+				WithSuggestions(contextureerrors.Hint("run it in a git repository with commits, or add a commitlint configuration"))
+		}
+		return Render(conventions), nil
+	})
+}
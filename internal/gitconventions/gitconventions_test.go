@@ -0,0 +1,263 @@
+package gitconventions
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/git"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, fs afero.Fs, files map[string]string) {
+	t.Helper()
+	for path, content := range files {
+		require.NoError(t, fs.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, afero.WriteFile(fs, path, []byte(content), 0o644))
+	}
+}
+
+// newHistory returns a repository serving subjects and branches for the
+// repository at /work/app
+func newHistory(t *testing.T, subjects, branches []string) *git.MockRepository {
+	t.Helper()
+	repo := git.NewMockRepository(t)
+	repo.EXPECT().GetCommitSubjects("/work/app", historyLimit).Return(subjects, nil).Maybe()
+	repo.EXPECT().GetBranchNames("/work/app").Return(branches, nil).Maybe()
+	return repo
+}
+
+func TestDetect_History(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		subjects []string
+		branches []string
+		want     Conventions
+	}{
+		{
+			name: "conventional commits",
+			subjects: []string{
+				"feat(api): add pagination",
+				"fix(api): handle empty pages",
+				"feat(ui): show the page count",
+				"docs: describe pagination",
+				"Merge pull request #12 from acme/feature/pages",
+				"fix(ui): align the footer",
+				"update readme",
+			},
+			branches: []string{"main", "feature/pages", "feature/search", "fix/footer", "spike"},
+			want: Conventions{
+				Name:           "app",
+				Commits:        6,
+				Style:          StyleConventional,
+				Types:          []string{"feat", "fix"},
+				Scopes:         []string{"api", "ui"},
+				Case:           CaseLower,
+				NoPeriod:       true,
+				BranchPrefixes: []string{"feature"},
+				MainBranches:   []string{"main"},
+			},
+		},
+		{
+			name: "bracketed references",
+			subjects: []string{
+				"[PAY-101] Add refunds",
+				"[PAY-102] Validate refund amounts",
+				"[PAY-87] Fix rounding",
+			},
+			branches: []string{"develop", "main", "feature/PAY-101-refunds", "feature/PAY-102-amounts"},
+			want: Conventions{
+				Name:           "app",
+				Commits:        3,
+				Style:          StyleBracketed,
+				Reference:      "PAY-123",
+				Case:           CaseUpper,
+				NoPeriod:       true,
+				BranchPrefixes: []string{"feature"},
+				BranchTickets:  true,
+				MainBranches:   []string{"main", "develop"},
+			},
+		},
+		{
+			name:     "plain subjects with tickets",
+			subjects: []string{"Add refunds for OPS-4.", "Fix rounding in OPS-9.", "Tidy up."},
+			want: Conventions{
+				Name:      "app",
+				Commits:   3,
+				Style:     StylePlain,
+				TicketKey: "OPS",
+				Case:      CaseUpper,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fs := afero.NewMemMapFs()
+			require.NoError(t, fs.MkdirAll("/work/app/.git", 0o755))
+
+			conventions, err := Detect(fs, newHistory(t, tt.subjects, tt.branches), "/work/app")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, *conventions)
+		})
+	}
+}
+
+func TestDetect_Commitlint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		files      map[string]string
+		wantFile   string
+		wantTypes  []string
+		wantScopes []string
+		wantLength int
+	}{
+		{
+			name:       "shared configuration",
+			files:      map[string]string{"/work/app/.commitlintrc.yml": "extends:\n  - '@commitlint/config-conventional'\n"},
+			wantFile:   ".commitlintrc.yml",
+			wantTypes:  conventionalTypes,
+			wantLength: 100,
+		},
+		{
+			name: "json rules",
+			files: map[string]string{"/work/app/.commitlintrc.json": `{
+				"extends": "@commitlint/config-conventional",
+				"rules": {
+					"type-enum": [2, "always", ["feat", "fix", "chore"]],
+					"scope-enum": [2, "always", ["api", "cli"]],
+					"header-max-length": [2, "always", 72]
+				}
+			}`},
+			wantFile:   ".commitlintrc.json",
+			wantTypes:  []string{"feat", "fix", "chore"},
+			wantScopes: []string{"api", "cli"},
+			wantLength: 72,
+		},
+		{
+			name: "javascript configuration",
+			files: map[string]string{"/work/app/commitlint.config.js": `module.exports = {
+  extends: ['@commitlint/config-conventional'],
+  rules: {
+    'type-enum': [2, 'always', ['feat', 'fix']],
+    'header-max-length': [2, 'always', 80],
+  },
+};
+`},
+			wantFile:   "commitlint.config.js",
+			wantTypes:  []string{"feat", "fix"},
+			wantLength: 80,
+		},
+		{
+			name:       "package.json key",
+			files:      map[string]string{"/work/app/package.json": `{"commitlint": {"extends": ["@commitlint/config-angular"]}}`},
+			wantFile:   "package.json",
+			wantTypes:  conventionalTypes,
+			wantLength: 100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fs := afero.NewMemMapFs()
+			writeFiles(t, fs, tt.files)
+
+			// Outside a repository only the configuration is read
+			conventions, err := Detect(fs, git.NewMockRepository(t), "/work/app")
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantFile, conventions.Commitlint)
+			assert.Equal(t, StyleConventional, conventions.Style)
+			assert.Equal(t, tt.wantTypes, conventions.Types)
+			assert.Equal(t, tt.wantScopes, conventions.Scopes)
+			assert.Equal(t, tt.wantLength, conventions.HeaderMaxLength)
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+	content := Render(&Conventions{
+		Name:            "app",
+		Commitlint:      ".commitlintrc.json",
+		Style:           StyleConventional,
+		Types:           []string{"feat", "fix"},
+		Scopes:          []string{"api"},
+		TicketKey:       "PAY",
+		Case:            CaseLower,
+		NoPeriod:        true,
+		HeaderMaxLength: 72,
+		BranchPrefixes:  []string{"feature", "fix"},
+		BranchTickets:   true,
+		MainBranches:    []string{"main"},
+	})
+
+	assert.Contains(t, content, "title: Git Conventions\n")
+	assert.Contains(t, content, "trigger: always\n")
+	assert.Contains(t, content, GeneratedMarker)
+	assert.Contains(t, content, "- Follow Conventional Commits: `type(scope): description`, enforced by commitlint (`.commitlintrc.json`)\n")
+	assert.Contains(t, content, "- Types: `feat`, `fix`\n")
+	assert.Contains(t, content, "- Scopes: `api`\n")
+	assert.Contains(t, content, "- Reference the ticket in the subject, such as `PAY-123`\n")
+	assert.Contains(t, content, "- Start the description with a lowercase letter\n")
+	assert.Contains(t, content, "- Leave off the trailing period\n")
+	assert.Contains(t, content, "- Keep the subject within 72 characters\n")
+	assert.Contains(t, content, "- Name topic branches with a prefix: `feature/`, `fix/`, such as `feature/PAY-123-short-description`\n")
+	assert.Contains(t, content, "- Long-lived branches: `main`\n")
+}
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+
+	t.Run("writes and refreshes the rule", func(t *testing.T) {
+		t.Parallel()
+		fs := afero.NewMemMapFs()
+		require.NoError(t, fs.MkdirAll("/work/app/.git", 0o755))
+		repo := newHistory(t, []string{"feat: add login", "fix: reject empty passwords"}, []string{"main"})
+
+		path, changed, err := Write(fs, repo, "/work/app", "/work/app/.contexture/rules", false)
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, "/work/app/.contexture/rules/"+RuleFile, path)
+
+		// The same conventions leave the rule untouched
+		_, changed, err = Write(fs, repo, "/work/app", "/work/app/.contexture/rules", false)
+		require.NoError(t, err)
+		assert.False(t, changed)
+	})
+
+	t.Run("keeps a rule taken over by hand", func(t *testing.T) {
+		t.Parallel()
+		fs := afero.NewMemMapFs()
+		writeFiles(t, fs, map[string]string{
+			"/work/app/.commitlintrc.json":            `{"extends": "@commitlint/config-conventional"}`,
+			"/work/app/.contexture/rules/" + RuleFile: "# Our git rules\n",
+		})
+		repo := git.NewMockRepository(t)
+
+		_, _, err := Write(fs, repo, "/work/app", "/work/app/.contexture/rules", false)
+		require.Error(t, err)
+
+		_, changed, err := Write(fs, repo, "/work/app", "/work/app/.contexture/rules", true)
+		require.NoError(t, err)
+		assert.True(t, changed)
+	})
+
+	t.Run("fails without history or commitlint", func(t *testing.T) {
+		t.Parallel()
+		fs := afero.NewMemMapFs()
+		require.NoError(t, fs.MkdirAll("/work/app", 0o755))
+
+		_, _, err := Write(fs, git.NewMockRepository(t), "/work/app", "/work/app/.contexture/rules", false)
+		require.Error(t, err)
+		exists, err := afero.Exists(fs, "/work/app/.contexture/rules/"+RuleFile)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
@@ -0,0 +1,143 @@
+// Package globs matches the file globs of rule triggers against project files
+package globs
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/spf13/afero"
+)
+
+// skippedDirs are not searched for project files: version control data and
+// installed dependencies, which rules are not written for
+var skippedDirs = map[string]bool{
+	".git":         true,
+	"node_modules": true,
+}
+
+// Match reports whether the slash-separated relative filePath matches
+// pattern. Patterns without a slash match the file name in any directory;
+// others match from the project root, where "**" matches any number of
+// directories and a trailing slash matches everything below a directory.
+// Braces list alternatives, as in "*.{ts,tsx}".
+func Match(pattern, filePath string) bool {
+	filePath = strings.TrimPrefix(filepath.ToSlash(filePath), "./")
+	for _, expanded := range expandBraces(pattern) {
+		expanded = strings.TrimPrefix(strings.TrimPrefix(expanded, "./"), "/")
+		if strings.HasSuffix(expanded, "/") {
+			expanded += "**"
+		}
+		if !strings.Contains(expanded, "/") {
+			if matched, _ := path.Match(expanded, path.Base(filePath)); matched {
+				return true
+			}
+			continue
+		}
+		if matchSegments(strings.Split(expanded, "/"), strings.Split(filePath, "/")) {
+			return true
+		}
+	}
+	return false
+}
+
+// Count returns how many of files match pattern
+func Count(pattern string, files []string) int {
+	count := 0
+	for _, file := range files {
+		if Match(pattern, file) {
+			count++
+		}
+	}
+	return count
+}
+
+// ProjectFiles lists the files below root as sorted, slash-separated paths
+// relative to root, skipping version control data and node_modules
+func ProjectFiles(fs afero.Fs, root string) ([]string, error) {
+	var files []string
+	err := afero.Walk(fs, root, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if filePath != root && skippedDirs[info.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(root, filePath)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, contextureerrors.WithOpf("list project files", "%s: %w", root, err)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// matchSegments matches path segments against pattern segments, where a
+// "**" segment matches zero or more path segments
+func matchSegments(pattern, parts []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(parts); i++ {
+				if matchSegments(pattern[1:], parts[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(parts) == 0 {
+			return false
+		}
+		if matched, _ := path.Match(pattern[0], parts[0]); !matched {
+			return false
+		}
+		pattern, parts = pattern[1:], parts[1:]
+	}
+	return len(parts) == 0
+}
+
+// expandBraces returns the patterns listed by the brace alternatives in
+// pattern. An unclosed brace is kept as written.
+func expandBraces(pattern string) []string {
+	start := strings.IndexByte(pattern, '{')
+	if start < 0 {
+		return []string{pattern}
+	}
+
+	depth := 0
+	alternativeStart := start + 1
+	var alternatives []string
+	for end := start; end < len(pattern); end++ {
+		switch pattern[end] {
+		case '{':
+			depth++
+		case ',':
+			if depth == 1 {
+				alternatives = append(alternatives, pattern[alternativeStart:end])
+				alternativeStart = end + 1
+			}
+		case '}':
+			depth--
+			if depth > 0 {
+				continue
+			}
+			alternatives = append(alternatives, pattern[alternativeStart:end])
+			var expanded []string
+			for _, alternative := range alternatives {
+				expanded = append(expanded, expandBraces(pattern[:start]+alternative+pattern[end+1:])...)
+			}
+			return expanded
+		}
+	}
+	return []string{pattern}
+}
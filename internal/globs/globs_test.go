@@ -0,0 +1,63 @@
+package globs
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatch(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"*.go", "main.go", true},
+		{"*.go", "internal/app/app.go", true},
+		{"*.go", "main.ts", false},
+		{"**/*.go", "main.go", true},
+		{"**/*.go", "internal/app/app.go", true},
+		{"internal/**/*.go", "internal/app/app.go", true},
+		{"internal/**/*.go", "cmd/main.go", false},
+		{"internal/*.go", "internal/app/app.go", false},
+		{"src/**", "src/a/b.ts", true},
+		{"src/", "src/a/b.ts", true},
+		{"./src/*.ts", "src/index.ts", true},
+		{"*.{ts,tsx}", "web/App.tsx", true},
+		{"*.{ts,tsx}", "web/App.jsx", false},
+		{"{src,lib}/**/*.{ts,tsx}", "lib/x/y.ts", true},
+		{"docs/{a,{b,c}}.md", "docs/c.md", true},
+		{"*.{ts", "a.{ts", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.path, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, Match(tt.pattern, tt.path))
+		})
+	}
+}
+
+func TestProjectFiles(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	for _, file := range []string{
+		"/project/main.go",
+		"/project/internal/app/app.go",
+		"/project/.git/HEAD",
+		"/project/web/node_modules/pkg/index.js",
+		"/project/web/index.ts",
+	} {
+		require.NoError(t, afero.WriteFile(fs, file, []byte("x"), 0o644))
+	}
+
+	files, err := ProjectFiles(fs, "/project")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"internal/app/app.go", "main.go", "web/index.ts"}, files)
+
+	assert.Equal(t, 2, Count("**/*.go", files))
+	assert.Equal(t, 0, Count("*.rs", files))
+}
@@ -0,0 +1,139 @@
+package globs
+
+import (
+	"bufio"
+	"path"
+	"strings"
+
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/spf13/afero"
+)
+
+// DefaultIgnorePatterns are ignored in every rules directory: drafts and
+// installed dependencies are never meant to be built
+var DefaultIgnorePatterns = []string{"drafts/", "*.draft.md", "node_modules/"}
+
+// Ignore decides which files in a directory are left out, using
+// gitignore-style patterns: a trailing slash matches only directories, a
+// leading "!" re-includes a path, and the last matching pattern wins.
+type Ignore struct {
+	patterns []ignorePattern
+}
+
+// ignorePattern is a single line of an ignore file
+type ignorePattern struct {
+	glob     string
+	negate   bool
+	dirOnly  bool
+	anchored bool // a leading slash matches from the directory root only
+}
+
+// NewIgnore returns an Ignore for patterns, after the default patterns
+func NewIgnore(patterns []string) *Ignore {
+	ignore := &Ignore{}
+	for _, pattern := range append(append([]string(nil), DefaultIgnorePatterns...), patterns...) {
+		ignore.add(pattern)
+	}
+	return ignore
+}
+
+// LoadIgnore reads the ignore file at filePath. A missing file leaves only
+// the default patterns.
+func LoadIgnore(fs afero.Fs, filePath string) (*Ignore, error) {
+	patterns, err := readPatterns(fs, filePath)
+	if err != nil {
+		return nil, err
+	}
+	return NewIgnore(patterns), nil
+}
+
+// LoadGitignore reads the .gitignore file at filePath, without the default
+// patterns. A missing file ignores nothing.
+func LoadGitignore(fs afero.Fs, filePath string) (*Ignore, error) {
+	patterns, err := readPatterns(fs, filePath)
+	if err != nil {
+		return nil, err
+	}
+	ignore := &Ignore{}
+	for _, pattern := range patterns {
+		ignore.add(pattern)
+	}
+	return ignore, nil
+}
+
+// readPatterns returns the lines of the ignore file at filePath, or none
+// when it does not exist
+func readPatterns(fs afero.Fs, filePath string) ([]string, error) {
+	exists, err := afero.Exists(fs, filePath)
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	data, err := afero.ReadFile(fs, filePath)
+	if err != nil {
+		return nil, contextureerrors.WithOpf("read ignore file", "%s: %w", filePath, err)
+	}
+	var patterns []string
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		patterns = append(patterns, scanner.Text())
+	}
+	return patterns, nil
+}
+
+// add parses one ignore line, skipping blanks and comments
+func (i *Ignore) add(line string) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return
+	}
+	pattern := ignorePattern{}
+	if rest, ok := strings.CutPrefix(line, "!"); ok {
+		pattern.negate = true
+		line = rest
+	}
+	if rest, ok := strings.CutSuffix(line, "/"); ok {
+		pattern.dirOnly = true
+		line = rest
+	}
+	pattern.anchored = strings.HasPrefix(line, "/")
+	pattern.glob = strings.TrimPrefix(line, "/")
+	if pattern.glob != "" {
+		i.patterns = append(i.patterns, pattern)
+	}
+}
+
+// Ignored reports whether the slash-separated relPath is ignored. Paths below
+// an ignored directory are ignored too.
+func (i *Ignore) Ignored(relPath string, isDir bool) bool {
+	relPath = strings.TrimPrefix(relPath, "./")
+	ignored := false
+	for _, pattern := range i.patterns {
+		if pattern.matches(relPath, isDir) {
+			ignored = !pattern.negate
+		}
+	}
+	return ignored
+}
+
+// matches reports whether the pattern matches relPath or one of its directories
+func (p ignorePattern) matches(relPath string, isDir bool) bool {
+	if (isDir || !p.dirOnly) && p.match(relPath) {
+		return true
+	}
+	for dir := path.Dir(relPath); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		if p.match(dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// match matches a single path against the pattern
+func (p ignorePattern) match(candidate string) bool {
+	if p.anchored && !strings.Contains(p.glob, "/") {
+		matched, _ := path.Match(p.glob, candidate)
+		return matched
+	}
+	return Match(p.glob, candidate)
+}
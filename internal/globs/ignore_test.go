@@ -0,0 +1,82 @@
+package globs
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIgnore_Ignored(t *testing.T) {
+	t.Parallel()
+	ignore := NewIgnore([]string{
+		"# scratch work",
+		"",
+		"scratch/",
+		"wip-*.md",
+		"!wip-keep.md",
+		"/notes.md",
+		"team/old",
+	})
+
+	tests := []struct {
+		path  string
+		isDir bool
+		want  bool
+	}{
+		{"go/style.md", false, false},
+		{"drafts", true, true},
+		{"drafts/idea.md", false, true},
+		{"go/drafts/idea.md", false, true},
+		{"go/idea.draft.md", false, true},
+		{"node_modules/pkg/README.md", false, true},
+		{"scratch/a.md", false, true},
+		{"scratch.md", false, false},
+		{"go/wip-errors.md", false, true},
+		{"go/wip-keep.md", false, false},
+		{"notes.md", false, true},
+		{"go/notes.md", false, false},
+		{"team/old/rule.md", false, true},
+		{"team/old", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, ignore.Ignored(tt.path, tt.isDir))
+		})
+	}
+}
+
+func TestLoadIgnore(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+
+	ignore, err := LoadIgnore(fs, "/rules/.contextureignore")
+	require.NoError(t, err)
+	assert.True(t, ignore.Ignored("drafts/a.md", false), "defaults apply without a file")
+	assert.False(t, ignore.Ignored("scratch/a.md", false))
+
+	require.NoError(t, afero.WriteFile(fs, "/rules/.contextureignore", []byte("scratch/\n!drafts/\n"), 0o644))
+	ignore, err = LoadIgnore(fs, "/rules/.contextureignore")
+	require.NoError(t, err)
+	assert.True(t, ignore.Ignored("scratch/a.md", false))
+	assert.False(t, ignore.Ignored("drafts/a.md", false), "defaults can be re-included")
+}
+
+func TestLoadGitignore(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+
+	ignore, err := LoadGitignore(fs, "/project/.gitignore")
+	require.NoError(t, err)
+	assert.False(t, ignore.Ignored("drafts/a.md", false), "no defaults")
+
+	require.NoError(t, afero.WriteFile(fs, "/project/.gitignore", []byte("CLAUDE.md\n.cursor/\n"), 0o644))
+	ignore, err = LoadGitignore(fs, "/project/.gitignore")
+	require.NoError(t, err)
+	assert.True(t, ignore.Ignored("CLAUDE.md", false))
+	assert.True(t, ignore.Ignored(".cursor/rules", true))
+	assert.False(t, ignore.Ignored("AGENTS.md", false))
+}
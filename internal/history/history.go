@@ -0,0 +1,113 @@
+// Package history remembers the rules a user added or viewed recently, across
+// projects, so they can be suggested again
+package history
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"time"
+
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/spf13/afero"
+)
+
+const (
+	// File is the name of the history file in the state directory
+	File = "history.json"
+	// MaxEntries is the number of rules the history remembers
+	MaxEntries = 50
+)
+
+// Entry is a rule used recently
+type Entry struct {
+	RuleID string    `json:"ruleId"`
+	UsedAt time.Time `json:"usedAt"`
+}
+
+// Store keeps the rules used recently in a file, most recent first
+type Store struct {
+	fs   afero.Fs
+	path string
+	now  func() time.Time
+}
+
+// NewStore creates a store keeping its history in the file at path
+func NewStore(fs afero.Fs, path string) *Store {
+	return &Store{fs: fs, path: path, now: time.Now}
+}
+
+// Recent returns up to limit rules used recently, most recent first. A limit
+// of zero or less returns all of them. A missing history is empty.
+func (s *Store) Recent(limit int) ([]Entry, error) {
+	entries, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries, nil
+}
+
+// Record moves the rules to the top of the history, in the order given, and
+// forgets the oldest rules beyond MaxEntries
+func (s *Store) Record(ruleIDs ...string) error {
+	if len(ruleIDs) == 0 {
+		return nil
+	}
+	entries, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	now := s.now()
+	used := make(map[string]bool, len(ruleIDs))
+	recorded := make([]Entry, 0, len(ruleIDs)+len(entries))
+	for _, id := range ruleIDs {
+		if id == "" || used[id] {
+			continue
+		}
+		used[id] = true
+		recorded = append(recorded, Entry{RuleID: id, UsedAt: now})
+	}
+	for _, entry := range entries {
+		if !used[entry.RuleID] {
+			recorded = append(recorded, entry)
+		}
+	}
+	if len(recorded) > MaxEntries {
+		recorded = recorded[:MaxEntries]
+	}
+	return s.save(recorded)
+}
+
+// load reads the history file
+func (s *Store) load() ([]Entry, error) {
+	data, err := afero.ReadFile(s.fs, s.path)
+	if err != nil {
+		if exists, _ := afero.Exists(s.fs, s.path); !exists {
+			return nil, nil
+		}
+		return nil, contextureerrors.WithOpf("load history", "failed to read %s: %w", s.path, err)
+	}
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, contextureerrors.WithOpf("load history", "failed to parse %s: %w", s.path, err)
+	}
+	return entries, nil
+}
+
+// save writes the history file
+func (s *Store) save(entries []Entry) error {
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return contextureerrors.Wrap(err, "marshal history")
+	}
+	if err := s.fs.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return contextureerrors.Wrap(err, "create history directory")
+	}
+	if err := afero.WriteFile(s.fs, s.path, append(data, '\n'), 0o644); err != nil {
+		return contextureerrors.WithOpf("save history", "failed to write %s: %w", s.path, err)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package history
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ruleIDs(entries []Entry) []string {
+	ids := make([]string, len(entries))
+	for i, entry := range entries {
+		ids[i] = entry.RuleID
+	}
+	return ids
+}
+
+func TestStore_Record(t *testing.T) {
+	t.Parallel()
+	store := NewStore(afero.NewMemMapFs(), "/home/user/.config/contexture/"+File)
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	store.now = func() time.Time { return now }
+
+	recent, err := store.Recent(0)
+	require.NoError(t, err)
+	assert.Empty(t, recent)
+
+	require.NoError(t, store.Record("languages/go/testing", "security/auth"))
+	require.NoError(t, store.Record("@mycompany/style", "languages/go/testing", "@mycompany/style"))
+
+	recent, err = store.Recent(0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"@mycompany/style", "languages/go/testing", "security/auth"}, ruleIDs(recent))
+	assert.Equal(t, now, recent[0].UsedAt)
+
+	recent, err = store.Recent(2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"@mycompany/style", "languages/go/testing"}, ruleIDs(recent))
+}
+
+func TestStore_RecordForgetsOldest(t *testing.T) {
+	t.Parallel()
+	store := NewStore(afero.NewMemMapFs(), "/state/"+File)
+
+	for i := range MaxEntries + 5 {
+		require.NoError(t, store.Record(fmt.Sprintf("rule-%d", i)))
+	}
+
+	recent, err := store.Recent(0)
+	require.NoError(t, err)
+	require.Len(t, recent, MaxEntries)
+	assert.Equal(t, fmt.Sprintf("rule-%d", MaxEntries+4), recent[0].RuleID)
+	assert.Equal(t, "rule-5", recent[MaxEntries-1].RuleID)
+}
+
+func TestStore_CorruptHistory(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/state/"+File, []byte("not json"), 0o644))
+
+	_, err := NewStore(fs, "/state/"+File).Recent(0)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse")
+}
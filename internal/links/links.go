@@ -0,0 +1,261 @@
+// Package links finds the links in rule content and checks that they resolve
+package links
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/spf13/afero"
+)
+
+const (
+	// CacheFile is the name of the link cache file in the contexture cache directory
+	CacheFile = "links.json"
+	// DefaultTimeout bounds each HTTP link check
+	DefaultTimeout = 10 * time.Second
+	// DefaultCacheTTL is how long a link that responded is trusted without checking it again
+	DefaultCacheTTL = 24 * time.Hour
+	// maxConcurrentChecks limits the HTTP link checks running at once
+	maxConcurrentChecks = 8
+)
+
+var (
+	// inlinePattern matches the target of inline links and images: [text](target "title")
+	inlinePattern = regexp.MustCompile(`!?\[[^\]]*\]\(\s*<?([^)\s>]+)>?(?:\s+["'(][^)]*)?\)`)
+	// autolinkPattern matches autolinks: <https://example.com>
+	autolinkPattern = regexp.MustCompile(`<(https?://[^>\s]+)>`)
+	// bareURLPattern matches URLs written without link syntax
+	bareURLPattern = regexp.MustCompile(`https?://[^\s<>()\[\]"'` + "`" + `]+`)
+	// inlineCodePattern matches inline code spans, whose links are not followed
+	inlineCodePattern = regexp.MustCompile("`[^`]*`")
+)
+
+// Link is a link found in content
+type Link struct {
+	Target string
+	Line   int
+}
+
+// IsHTTP reports whether the link points to an http or https URL
+func (l Link) IsHTTP() bool {
+	return strings.HasPrefix(l.Target, "http://") || strings.HasPrefix(l.Target, "https://")
+}
+
+// IsRelative reports whether the link points to a file relative to the content
+func (l Link) IsRelative() bool {
+	if l.Target == "" || strings.HasPrefix(l.Target, "#") || strings.HasPrefix(l.Target, "/") {
+		return false
+	}
+	parsed, err := url.Parse(l.Target)
+	return err == nil && parsed.Scheme == "" && parsed.Host == ""
+}
+
+// Extract returns the links in markdown content in order of appearance, each
+// target once. Links in code and links containing template actions are skipped.
+func Extract(content string) []Link {
+	var found []Link
+	seen := map[string]bool{}
+	add := func(target string, line int) {
+		target = strings.TrimRight(target, ".,;:!?")
+		if target == "" || seen[target] || strings.Contains(target, "{{") {
+			return
+		}
+		seen[target] = true
+		found = append(found, Link{Target: target, Line: line})
+	}
+
+	inFence := false
+	for i, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		line = inlineCodePattern.ReplaceAllString(line, "")
+		for _, match := range inlinePattern.FindAllStringSubmatch(line, -1) {
+			add(match[1], i+1)
+		}
+		for _, match := range autolinkPattern.FindAllStringSubmatch(line, -1) {
+			add(match[1], i+1)
+		}
+		// Bare URLs outside link syntax
+		rest := autolinkPattern.ReplaceAllString(inlinePattern.ReplaceAllString(line, ""), "")
+		for _, match := range bareURLPattern.FindAllString(rest, -1) {
+			add(match, i+1)
+		}
+	}
+	return found
+}
+
+// ResolveRelative returns the slash-separated path a relative link in the file
+// at filePath points to, both relative to the repository root, without its
+// fragment or query. It reports false when the link leaves the repository.
+func ResolveRelative(filePath, target string) (string, bool) {
+	if i := strings.IndexAny(target, "#?"); i >= 0 {
+		target = target[:i]
+	}
+	if unescaped, err := url.PathUnescape(target); err == nil {
+		target = unescaped
+	}
+	resolved := path.Join(path.Dir(filePath), target)
+	if resolved == ".." || strings.HasPrefix(resolved, "../") {
+		return "", false
+	}
+	return resolved, true
+}
+
+// cacheEntry records when a URL last responded
+type cacheEntry struct {
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
+// Checker checks that HTTP links respond, remembering links that did in a
+// cache file so repeated runs only check new or failing links
+type Checker struct {
+	fs        afero.Fs
+	client    *http.Client
+	cachePath string
+	ttl       time.Duration
+	now       func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]cacheEntry
+}
+
+// NewChecker creates a checker that keeps its cache in the file at cachePath.
+// An empty cachePath disables caching.
+func NewChecker(fs afero.Fs, cachePath string) *Checker {
+	return &Checker{
+		fs:        fs,
+		client:    &http.Client{Timeout: DefaultTimeout},
+		cachePath: cachePath,
+		ttl:       DefaultCacheTTL,
+		now:       time.Now,
+	}
+}
+
+// CheckAll checks the URLs concurrently and returns the errors of those that
+// did not respond successfully, by URL. The cache is saved afterwards.
+func (c *Checker) CheckAll(ctx context.Context, urls []string) (map[string]error, error) {
+	c.loadCache()
+
+	failures := map[string]error{}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, maxConcurrentChecks)
+	for _, target := range urls {
+		wg.Add(1)
+		go func(target string) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+			if err := c.Check(ctx, target); err != nil {
+				mu.Lock()
+				failures[target] = err
+				mu.Unlock()
+			}
+		}(target)
+	}
+	wg.Wait()
+
+	return failures, c.saveCache()
+}
+
+// Check reports whether target responds with a successful status. Servers
+// that refuse HEAD requests are asked with GET.
+func (c *Checker) Check(ctx context.Context, target string) error {
+	c.mu.Lock()
+	entry, cached := c.cache[target]
+	c.mu.Unlock()
+	if cached && c.now().Sub(entry.CheckedAt) < c.ttl {
+		return nil
+	}
+
+	status, err := c.request(ctx, http.MethodHead, target)
+	if err == nil && (status == http.StatusMethodNotAllowed || status == http.StatusForbidden || status == http.StatusNotImplemented) {
+		status, err = c.request(ctx, http.MethodGet, target)
+	}
+	if err != nil {
+		return err
+	}
+	if status < 200 || status > 399 {
+		return fmt.Errorf("responded with %d %s", status, http.StatusText(status))
+	}
+
+	c.mu.Lock()
+	if c.cache == nil {
+		c.cache = map[string]cacheEntry{}
+	}
+	c.cache[target] = cacheEntry{CheckedAt: c.now()}
+	c.mu.Unlock()
+	return nil
+}
+
+// request sends a request to target and returns the response status
+func (c *Checker) request(ctx context.Context, method, target string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, target, nil)
+	if err != nil {
+		return 0, contextureerrors.Wrap(err, "create request")
+	}
+	req.Header.Set("User-Agent", "contexture-link-checker")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	_ = resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+// loadCache reads the cache file, starting empty when it is missing or unreadable
+func (c *Checker) loadCache() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache = map[string]cacheEntry{}
+	if c.cachePath == "" {
+		return
+	}
+	data, err := afero.ReadFile(c.fs, c.cachePath)
+	if err != nil {
+		return
+	}
+	_ = json.Unmarshal(data, &c.cache)
+}
+
+// saveCache writes the links that responded within the TTL to the cache file
+func (c *Checker) saveCache() error {
+	if c.cachePath == "" {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for target, entry := range c.cache {
+		if c.now().Sub(entry.CheckedAt) >= c.ttl {
+			delete(c.cache, target)
+		}
+	}
+	data, err := json.MarshalIndent(c.cache, "", "  ")
+	if err != nil {
+		return contextureerrors.Wrap(err, "marshal link cache")
+	}
+	if err := c.fs.MkdirAll(filepath.Dir(c.cachePath), 0o755); err != nil {
+		return contextureerrors.Wrap(err, "create link cache directory")
+	}
+	if err := afero.WriteFile(c.fs, c.cachePath, data, 0o644); err != nil {
+		return contextureerrors.Wrap(err, "write link cache")
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package links
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtract(t *testing.T) {
+	t.Parallel()
+
+	content := "# Errors\n\n" +
+		"See [wrapping](./wrapping.md#context) and ![diagram](img/flow.png \"Flow\").\n" +
+		"Read <https://go.dev/blog/errors> or https://pkg.go.dev/errors.\n" +
+		"Inline `https://example.com/code` is skipped, as is [template]({{.url}}).\n" +
+		"Again [wrapping](./wrapping.md#context).\n\n" +
+		"```go\n// https://example.com/fenced\n```\n"
+
+	assert.Equal(t, []Link{
+		{Target: "./wrapping.md#context", Line: 3},
+		{Target: "img/flow.png", Line: 3},
+		{Target: "https://go.dev/blog/errors", Line: 4},
+		{Target: "https://pkg.go.dev/errors", Line: 4},
+	}, Extract(content))
+}
+
+func TestLink_Kinds(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		target   string
+		http     bool
+		relative bool
+	}{
+		{target: "https://go.dev", http: true},
+		{target: "http://example.com", http: true},
+		{target: "../shared/errors.md", relative: true},
+		{target: "errors.md", relative: true},
+		{target: "#usage"},
+		{target: "/absolute.md"},
+		{target: "mailto:team@example.com"},
+	}
+	for _, tt := range tests {
+		link := Link{Target: tt.target}
+		assert.Equal(t, tt.http, link.IsHTTP(), tt.target)
+		assert.Equal(t, tt.relative, link.IsRelative(), tt.target)
+	}
+}
+
+func TestResolveRelative(t *testing.T) {
+	t.Parallel()
+
+	resolved, ok := ResolveRelative("go/errors.md", "../shared/style%20guide.md#naming")
+	assert.True(t, ok)
+	assert.Equal(t, "shared/style guide.md", resolved)
+
+	resolved, ok = ResolveRelative("errors.md", "./go/testing.md?raw=1")
+	assert.True(t, ok)
+	assert.Equal(t, "go/testing.md", resolved)
+
+	_, ok = ResolveRelative("go/errors.md", "../../outside.md")
+	assert.False(t, ok)
+}
+
+func TestChecker_CheckAll(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		switch r.URL.Path {
+		case "/ok":
+			w.WriteHeader(http.StatusOK)
+		case "/no-head":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	fs := afero.NewMemMapFs()
+	checker := NewChecker(fs, "/cache/links.json")
+	urls := []string{server.URL + "/ok", server.URL + "/no-head", server.URL + "/missing"}
+
+	failures, err := checker.CheckAll(context.Background(), urls)
+	require.NoError(t, err)
+	require.Len(t, failures, 1)
+	assert.EqualError(t, failures[server.URL+"/missing"], "responded with 404 Not Found")
+	assert.Equal(t, int32(4), requests.Load())
+
+	// Links that responded are cached; failing links are checked again
+	requests.Store(0)
+	cached := NewChecker(fs, "/cache/links.json")
+	failures, err = cached.CheckAll(context.Background(), urls)
+	require.NoError(t, err)
+	assert.Len(t, failures, 1)
+	assert.Equal(t, int32(1), requests.Load())
+
+	// Expired entries are checked again
+	requests.Store(0)
+	expired := NewChecker(fs, "/cache/links.json")
+	expired.now = func() time.Time { return time.Now().Add(DefaultCacheTTL) }
+	_, err = expired.CheckAll(context.Background(), urls)
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), requests.Load())
+}
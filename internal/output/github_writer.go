@@ -0,0 +1,183 @@
+package output
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/diff"
+)
+
+// Annotation levels of GitHub Actions workflow commands
+const (
+	annotationError   = "error"
+	annotationWarning = "warning"
+)
+
+// GitHubWriter implements Writer interface for GitHub Actions. Validation,
+// lint, test and snapshot results are emitted as workflow command annotations
+// so they appear inline on pull requests, followed by the terminal output for
+// the job log. Other results are written as in the terminal.
+type GitHubWriter struct {
+	*TerminalWriter
+	// baseDir is the directory annotation file paths are made relative to
+	baseDir string
+}
+
+// NewGitHubWriter creates a new GitHub Actions writer. File paths are made
+// relative to $GITHUB_WORKSPACE in env, or to workingDir outside Actions.
+func NewGitHubWriter(workingDir dependencies.WorkingDir, env dependencies.Environment) *GitHubWriter {
+	baseDir := env.Getenv("GITHUB_WORKSPACE")
+	if baseDir == "" {
+		baseDir, _ = workingDir.Getwd()
+	}
+	return &GitHubWriter{TerminalWriter: NewTerminalWriter(), baseDir: baseDir}
+}
+
+// Annotation is a GitHub Actions workflow command that marks a file, or the
+// whole run when File is empty
+type Annotation struct {
+	Level   string // "error" or "warning"
+	File    string
+	Line    int // 1-based; 0 when unknown
+	Title   string
+	Message string
+}
+
+// String formats the annotation as a workflow command, such as
+// "::error file=rules/go.md,title=rule::invalid frontmatter"
+func (a Annotation) String() string {
+	var properties []string
+	if a.File != "" {
+		properties = append(properties, "file="+escapeProperty(a.File))
+		if a.Line > 0 {
+			properties = append(properties, fmt.Sprintf("line=%d", a.Line))
+		}
+	}
+	if a.Title != "" {
+		properties = append(properties, "title="+escapeProperty(a.Title))
+	}
+
+	command := "::" + a.Level
+	if len(properties) > 0 {
+		command += " " + strings.Join(properties, ",")
+	}
+	return command + "::" + escapeData(a.Message)
+}
+
+// escapeData escapes a workflow command message
+func escapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeProperty escapes a workflow command property value
+func escapeProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
+// WriteValidationResults writes validate results as annotations
+func (w *GitHubWriter) WriteValidationResults(issues []ValidationIssue, metadata ValidateMetadata) error {
+	w.writeIssues("contexture validate", issues)
+	return w.TerminalWriter.WriteValidationResults(issues, metadata)
+}
+
+// WriteLintResults writes repo lint results as annotations
+func (w *GitHubWriter) WriteLintResults(issues []ValidationIssue, metadata LintMetadata) error {
+	w.writeIssues("contexture repo lint", issues)
+	return w.TerminalWriter.WriteLintResults(issues, metadata)
+}
+
+// WriteTestResults writes failed rule tests as annotations
+func (w *GitHubWriter) WriteTestResults(results []TestResult, metadata TestMetadata) error {
+	for _, result := range results {
+		if result.Passed {
+			continue
+		}
+		fmt.Println(Annotation{
+			Level:   annotationError,
+			File:    w.relativePath(result.Path),
+			Title:   "contexture test: " + result.Rule + " › " + result.Test,
+			Message: strings.Join(result.Failures, "\n"),
+		})
+	}
+	return w.TerminalWriter.WriteTestResults(results, metadata)
+}
+
+// WriteSnapshotResults writes files that differ from their snapshots as
+// annotations on the first changed line
+func (w *GitHubWriter) WriteSnapshotResults(mismatches []SnapshotMismatch, metadata SnapshotMetadata) error {
+	for _, mismatch := range mismatches {
+		line := firstChangedLine(mismatch.Diff)
+		if mismatch.Status == "removed" {
+			// The file is no longer generated, so no line of it can be marked
+			line = 0
+		}
+		fmt.Println(Annotation{
+			Level: annotationError,
+			File:  w.relativePath(filepath.Join(metadata.BaseDir, filepath.FromSlash(mismatch.Path))),
+			Line:  line,
+			Title: "contexture snapshot",
+			Message: fmt.Sprintf("%s differs from its snapshot (%s). Run `contexture snapshot --update` to accept it.",
+				mismatch.Path, mismatch.Status),
+		})
+	}
+	return w.TerminalWriter.WriteSnapshotResults(mismatches, metadata)
+}
+
+// writeIssues prints an annotation for each issue. Issues about rule IDs or
+// URLs are reported for the whole run with the location in the message.
+func (w *GitHubWriter) writeIssues(title string, issues []ValidationIssue) {
+	for _, issue := range issues {
+		annotation := Annotation{
+			Level:   annotationError,
+			Title:   title + " (" + issue.Check + ")",
+			Message: issue.Message,
+		}
+		if issue.Warning {
+			annotation.Level = annotationWarning
+		}
+		if isFilePath(issue.Path) {
+			annotation.File = w.relativePath(issue.Path)
+		} else if issue.Path != "" {
+			annotation.Message = issue.Path + ": " + issue.Message
+		}
+		fmt.Println(annotation)
+	}
+}
+
+// relativePath returns path relative to the writer's base directory, when it is inside it
+func (w *GitHubWriter) relativePath(path string) string {
+	if path == "" || !filepath.IsAbs(path) || w.baseDir == "" {
+		return filepath.ToSlash(path)
+	}
+	rel, err := filepath.Rel(w.baseDir, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// isFilePath reports whether an issue path names a file rather than a rule ID or URL
+func isFilePath(path string) bool {
+	if path == "" || strings.HasPrefix(path, "[") || strings.HasPrefix(path, "@") ||
+		strings.HasPrefix(path, "git@") || strings.Contains(path, "://") {
+		return false
+	}
+	return filepath.IsAbs(path) || filepath.Ext(path) != ""
+}
+
+// firstChangedLine returns the line of the new text at which a diff first
+// differs, or 0 when it does not
+func firstChangedLine(lines []diff.Line) int {
+	line := 1
+	for _, l := range lines {
+		switch l.Op {
+		case diff.OpEqual:
+			line++
+		case diff.OpInsert, diff.OpDelete:
+			return line
+		}
+	}
+	return 0
+}
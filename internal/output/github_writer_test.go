@@ -0,0 +1,129 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/diff"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGitHubWriter(t *testing.T) {
+	t.Parallel()
+	workingDir := dependencies.StaticWorkingDir("/project")
+	writer := NewGitHubWriter(workingDir, dependencies.MapEnvironment{"GITHUB_WORKSPACE": "/work"})
+	assert.Implements(t, (*Writer)(nil), writer)
+	assert.Equal(t, "/work", writer.baseDir)
+
+	// Outside Actions, paths are relative to the working directory
+	writer = NewGitHubWriter(workingDir, dependencies.MapEnvironment{})
+	assert.Equal(t, "/project", writer.baseDir)
+}
+
+func TestAnnotation_String(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		annotation Annotation
+		expected   string
+	}{
+		{
+			name:       "message only",
+			annotation: Annotation{Level: "error", Message: "failed"},
+			expected:   "::error::failed",
+		},
+		{
+			name:       "file, line and title",
+			annotation: Annotation{Level: "warning", File: "rules/go.md", Line: 3, Title: "lint (rule)", Message: "no files"},
+			expected:   "::warning file=rules/go.md,line=3,title=lint (rule)::no files",
+		},
+		{
+			name:       "line without file is dropped",
+			annotation: Annotation{Level: "error", Line: 3, Message: "failed"},
+			expected:   "::error::failed",
+		},
+		{
+			name:       "escaping",
+			annotation: Annotation{Level: "error", File: "a,b.md", Title: "x: y", Message: "100%\nsure"},
+			expected:   "::error file=a%2Cb.md,title=x%3A y::100%25%0Asure",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, tt.annotation.String())
+		})
+	}
+}
+
+func TestGitHubWriter_WriteValidationResults(t *testing.T) {
+	writer := &GitHubWriter{TerminalWriter: NewTerminalWriter(), baseDir: "/project"}
+	issues := []ValidationIssue{
+		{Check: "config", Path: "/project/.contexture.yaml", Message: "unsupported format type \"vim\""},
+		{Check: "rule", Path: "/project/rules/go.md", Message: "trigger glob \"*.go\" matches no files", Warning: true},
+		{Check: "provider", Path: "https://example.com/rules.git", Message: "unreachable"},
+		{Check: "lock", Path: "[contexture:go/style]", Message: "rule is pinned but has no commit hash"},
+	}
+
+	output := captureStdout(t, func() {
+		require.NoError(t, writer.WriteValidationResults(issues, ValidateMetadata{RulesChecked: 1}))
+	})
+	lines := strings.Split(output, "\n")
+
+	assert.Equal(t, `::error file=.contexture.yaml,title=contexture validate (config)::unsupported format type "vim"`, lines[0])
+	assert.Equal(t, `::warning file=rules/go.md,title=contexture validate (rule)::trigger glob "*.go" matches no files`, lines[1])
+	assert.Equal(t, "::error title=contexture validate (provider)::https://example.com/rules.git: unreachable", lines[2])
+	assert.Equal(t, "::error title=contexture validate (lock)::[contexture:go/style]: rule is pinned but has no commit hash", lines[3])
+	// The terminal report follows for the job log
+	assert.Contains(t, output, "Found 3 issue(s)")
+}
+
+func TestGitHubWriter_WriteTestResults(t *testing.T) {
+	writer := &GitHubWriter{TerminalWriter: NewTerminalWriter(), baseDir: "/project"}
+	results := []TestResult{
+		{Rule: "go", Test: "passes", Path: "/project/rules/go.md", Passed: true},
+		{Rule: "go", Test: "mentions gofmt", Path: "/project/rules/go.md", Failures: []string{"missing \"gofmt\"", "too long"}},
+	}
+
+	output := captureStdout(t, func() {
+		require.NoError(t, writer.WriteTestResults(results, TestMetadata{TestsRun: 2, TestsFailed: 1}))
+	})
+
+	assert.Contains(t, output, `::error file=rules/go.md,title=contexture test%3A go › mentions gofmt::missing "gofmt"%0Atoo long`)
+	assert.NotContains(t, output, "passes::")
+}
+
+func TestGitHubWriter_WriteSnapshotResults(t *testing.T) {
+	writer := &GitHubWriter{TerminalWriter: NewTerminalWriter(), baseDir: "/project"}
+	mismatches := []SnapshotMismatch{
+		{
+			Path:   "CLAUDE.md",
+			Status: "changed",
+			Diff: []diff.Line{
+				{Op: diff.OpEqual, Text: "# claude.md"},
+				{Op: diff.OpEqual, Text: ""},
+				{Op: diff.OpDelete, Text: "Use tabs."},
+				{Op: diff.OpInsert, Text: "Use spaces."},
+			},
+		},
+		{Path: ".cursor/rules/old.mdc", Status: "removed", Diff: []diff.Line{{Op: diff.OpDelete, Text: "old"}}},
+	}
+
+	output := captureStdout(t, func() {
+		require.NoError(t, writer.WriteSnapshotResults(mismatches, SnapshotMetadata{BaseDir: "/project", FilesChecked: 1}))
+	})
+
+	assert.Contains(t, output, "::error file=CLAUDE.md,line=3,title=contexture snapshot::CLAUDE.md differs from its snapshot (changed).")
+	assert.Contains(t, output, "::error file=.cursor/rules/old.mdc,title=contexture snapshot::")
+}
+
+func TestFirstChangedLine(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, 0, firstChangedLine([]diff.Line{{Op: diff.OpEqual, Text: "a"}}))
+	assert.Equal(t, 1, firstChangedLine([]diff.Line{{Op: diff.OpInsert, Text: "a"}}))
+	assert.Equal(t, 2, firstChangedLine([]diff.Line{{Op: diff.OpEqual, Text: "a"}, {Op: diff.OpDelete, Text: "b"}}))
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/contextureai/contexture/internal/diff"
 	"github.com/contextureai/contexture/internal/domain"
 	contextureerrors "github.com/contextureai/contexture/internal/errors"
 )
@@ -60,6 +61,43 @@ type JSONQueryOutput struct {
 	Rules    []*JSONRule   `json:"rules"`
 }
 
+// JSONValidateOutput represents the JSON structure for validate output
+type JSONValidateOutput struct {
+	Metadata ValidateMetadata  `json:"metadata"`
+	Issues   []ValidationIssue `json:"issues"`
+}
+
+// JSONLintOutput represents the JSON structure for repo lint output
+type JSONLintOutput struct {
+	Metadata LintMetadata      `json:"metadata"`
+	Issues   []ValidationIssue `json:"issues"`
+}
+
+// JSONTestOutput represents the JSON structure for test output
+type JSONTestOutput struct {
+	Metadata TestMetadata `json:"metadata"`
+	Results  []TestResult `json:"results"`
+}
+
+// JSONSnapshotOutput represents the JSON structure for snapshot output
+type JSONSnapshotOutput struct {
+	Metadata   SnapshotMetadata   `json:"metadata"`
+	Mismatches []SnapshotMismatch `json:"mismatches"`
+}
+
+// JSONSelectionOutput represents the JSON structure for dry-run selections
+type JSONSelectionOutput struct {
+	Metadata SelectionMetadata `json:"metadata"`
+}
+
+// JSONCompareOutput represents the JSON structure for rules compare output
+type JSONCompareOutput struct {
+	Left      *JSONRule   `json:"left"`
+	Right     *JSONRule   `json:"right"`
+	Identical bool        `json:"identical"`
+	Diff      []diff.Line `json:"diff"`
+}
+
 // convertToJSONRules converts domain rules to JSON rules
 func convertToJSONRules(rules []*domain.Rule) []*JSONRule {
 	jsonRules := make([]*JSONRule, len(rules))
@@ -167,3 +205,179 @@ func (w *JSONWriter) WriteQueryResults(rules []*domain.Rule, metadata QueryMetad
 	fmt.Println(string(jsonData))
 	return nil
 }
+
+// WriteValidationResults writes validate results in JSON format to stdout
+func (w *JSONWriter) WriteValidationResults(issues []ValidationIssue, metadata ValidateMetadata) error {
+	if issues == nil {
+		issues = []ValidationIssue{}
+	}
+
+	output := JSONValidateOutput{
+		Metadata: metadata,
+		Issues:   issues,
+	}
+
+	jsonData, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return contextureerrors.Wrap(err, "marshal validation results to JSON")
+	}
+
+	fmt.Println(string(jsonData))
+	return nil
+}
+
+// WriteRuleComparison writes rules compare result in JSON format to stdout
+func (w *JSONWriter) WriteRuleComparison(left, right *domain.Rule, lines []diff.Line) error {
+	if lines == nil {
+		lines = []diff.Line{}
+	}
+
+	jsonRules := convertToJSONRules([]*domain.Rule{left, right})
+	output := JSONCompareOutput{
+		Left:      jsonRules[0],
+		Right:     jsonRules[1],
+		Identical: !diff.HasChanges(lines),
+		Diff:      lines,
+	}
+
+	jsonData, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return contextureerrors.Wrap(err, "marshal comparison to JSON")
+	}
+
+	fmt.Println(string(jsonData))
+	return nil
+}
+
+// WriteTestResults writes test command results in JSON format to stdout
+func (w *JSONWriter) WriteTestResults(results []TestResult, metadata TestMetadata) error {
+	if results == nil {
+		results = []TestResult{}
+	}
+
+	output := JSONTestOutput{
+		Metadata: metadata,
+		Results:  results,
+	}
+
+	jsonData, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return contextureerrors.Wrap(err, "marshal test results to JSON")
+	}
+
+	fmt.Println(string(jsonData))
+	return nil
+}
+
+// WriteLintResults writes repo lint results in JSON format to stdout
+func (w *JSONWriter) WriteLintResults(issues []ValidationIssue, metadata LintMetadata) error {
+	if issues == nil {
+		issues = []ValidationIssue{}
+	}
+
+	output := JSONLintOutput{
+		Metadata: metadata,
+		Issues:   issues,
+	}
+
+	jsonData, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return contextureerrors.Wrap(err, "marshal lint results to JSON")
+	}
+
+	fmt.Println(string(jsonData))
+	return nil
+}
+
+// WriteRepoStats writes repo stats in JSON format to stdout
+func (w *JSONWriter) WriteRepoStats(stats RepoStats) error {
+	if stats.MissingDescription == nil {
+		stats.MissingDescription = []string{}
+	}
+	if stats.MissingTrigger == nil {
+		stats.MissingTrigger = []string{}
+	}
+
+	jsonData, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return contextureerrors.Wrap(err, "marshal repo stats to JSON")
+	}
+
+	fmt.Println(string(jsonData))
+	return nil
+}
+
+// WriteOutdatedReport writes the outdated report in JSON format to stdout
+func (w *JSONWriter) WriteOutdatedReport(report OutdatedReport) error {
+	if report.Rules == nil {
+		report.Rules = []OutdatedRule{}
+	}
+
+	jsonData, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return contextureerrors.Wrap(err, "marshal outdated report to JSON")
+	}
+
+	fmt.Println(string(jsonData))
+	return nil
+}
+
+// WriteAuditReport writes the audit report in JSON format to stdout
+func (w *JSONWriter) WriteAuditReport(report AuditReport) error {
+	if report.Repositories == nil {
+		report.Repositories = []AuditRepository{}
+	}
+	if report.Adoption == nil {
+		report.Adoption = []RuleAdoption{}
+	}
+	for i := range report.Repositories {
+		if report.Repositories[i].Violations == nil {
+			report.Repositories[i].Violations = []AuditViolation{}
+		}
+	}
+
+	jsonData, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return contextureerrors.Wrap(err, "marshal audit report to JSON")
+	}
+
+	fmt.Println(string(jsonData))
+	return nil
+}
+
+// WriteSnapshotResults writes snapshot comparison results in JSON format to stdout
+func (w *JSONWriter) WriteSnapshotResults(mismatches []SnapshotMismatch, metadata SnapshotMetadata) error {
+	if mismatches == nil {
+		mismatches = []SnapshotMismatch{}
+	}
+
+	output := JSONSnapshotOutput{
+		Metadata:   metadata,
+		Mismatches: mismatches,
+	}
+
+	jsonData, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return contextureerrors.Wrap(err, "marshal snapshot results to JSON")
+	}
+
+	fmt.Println(string(jsonData))
+	return nil
+}
+
+// WriteSelection writes the would-be selection of a dry run in JSON format to
+// stdout. Dry runs report their selection as JSON whatever the output format,
+// so scripts can consume it.
+func (w *JSONWriter) WriteSelection(metadata SelectionMetadata) error {
+	if metadata.Selected == nil {
+		metadata.Selected = []string{}
+	}
+
+	jsonData, err := json.MarshalIndent(JSONSelectionOutput{Metadata: metadata}, "", "  ")
+	if err != nil {
+		return contextureerrors.Wrap(err, "marshal selection to JSON")
+	}
+
+	fmt.Println(string(jsonData))
+	return nil
+}
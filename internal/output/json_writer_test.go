@@ -8,6 +8,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/contextureai/contexture/internal/diff"
 	"github.com/contextureai/contexture/internal/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -196,8 +197,202 @@ func TestJSONWriter_WriteRulesList_ValidJSONFormat(t *testing.T) {
 	assert.Contains(t, output, "  \"rules\":")
 }
 
+func TestJSONWriter_WriteValidationResults(t *testing.T) {
+	writer := NewJSONWriter()
+
+	issues := []ValidationIssue{
+		{Check: "rule", Path: "rules/broken.md", Message: "title is required"},
+	}
+	metadata := ValidateMetadata{
+		ConfigPath:   ".contexture.yaml",
+		Offline:      true,
+		RulesChecked: 1,
+	}
+
+	output := captureStdout(t, func() {
+		err := writer.WriteValidationResults(issues, metadata)
+		require.NoError(t, err)
+	})
+
+	var result JSONValidateOutput
+	err := json.Unmarshal([]byte(output), &result)
+	require.NoError(t, err)
+
+	assert.Equal(t, metadata, result.Metadata)
+	require.Len(t, result.Issues, 1)
+	assert.Equal(t, "rule", result.Issues[0].Check)
+	assert.Equal(t, "rules/broken.md", result.Issues[0].Path)
+}
+
+func TestJSONWriter_WriteValidationResults_NoIssues(t *testing.T) {
+	writer := NewJSONWriter()
+
+	output := captureStdout(t, func() {
+		err := writer.WriteValidationResults(nil, ValidateMetadata{Valid: true})
+		require.NoError(t, err)
+	})
+
+	// Issues should serialize as an empty array, not null
+	assert.Contains(t, output, "\"issues\": []")
+}
+
 func TestNewJSONWriter(t *testing.T) {
 	writer := NewJSONWriter()
 	assert.NotNil(t, writer)
 	assert.Implements(t, (*Writer)(nil), writer)
 }
+
+func TestJSONWriter_WriteRuleComparison(t *testing.T) {
+	writer := NewJSONWriter()
+
+	left := &domain.Rule{ID: "@one/go/testing", Title: "Go Testing", Content: "a\nb"}
+	right := &domain.Rule{ID: "@two/go/testing", Title: "Testing in Go", Content: "a\nc"}
+
+	output := captureStdout(t, func() {
+		err := writer.WriteRuleComparison(left, right, diff.Lines(left.Content, right.Content))
+		require.NoError(t, err)
+	})
+
+	var result JSONCompareOutput
+	require.NoError(t, json.Unmarshal([]byte(output), &result))
+
+	assert.Equal(t, "@one/go/testing", result.Left.ID)
+	assert.Equal(t, "@two/go/testing", result.Right.ID)
+	assert.False(t, result.Identical)
+	assert.Equal(t, []diff.Line{
+		{Op: diff.OpEqual, Text: "a"},
+		{Op: diff.OpDelete, Text: "b"},
+		{Op: diff.OpInsert, Text: "c"},
+	}, result.Diff)
+}
+
+func TestJSONWriter_WriteTestResults(t *testing.T) {
+	writer := NewJSONWriter()
+
+	results := []TestResult{
+		{Rule: "team/style", Test: "defaults", Passed: true},
+		{Rule: "team/style", Test: "strict", Failures: []string{`expected output to contain "panic"`}},
+	}
+	metadata := TestMetadata{RulesTested: 1, TestsRun: 2, TestsFailed: 1}
+
+	output := captureStdout(t, func() {
+		err := writer.WriteTestResults(results, metadata)
+		require.NoError(t, err)
+	})
+
+	var result JSONTestOutput
+	require.NoError(t, json.Unmarshal([]byte(output), &result))
+	assert.Equal(t, metadata, result.Metadata)
+	assert.Equal(t, results, result.Results)
+
+	output = captureStdout(t, func() {
+		require.NoError(t, writer.WriteTestResults(nil, TestMetadata{Passed: true}))
+	})
+	assert.Contains(t, output, "\"results\": []")
+}
+
+func TestJSONWriter_WriteLintResults(t *testing.T) {
+	writer := NewJSONWriter()
+
+	issues := []ValidationIssue{
+		{Check: "index", Path: "/repo/go/style.md", Message: "rule is not listed in index.yaml"},
+	}
+	metadata := LintMetadata{Path: "/repo", RulesChecked: 3, Indexed: true}
+
+	output := captureStdout(t, func() {
+		require.NoError(t, writer.WriteLintResults(issues, metadata))
+	})
+
+	var result JSONLintOutput
+	require.NoError(t, json.Unmarshal([]byte(output), &result))
+	assert.Equal(t, metadata, result.Metadata)
+	assert.Equal(t, issues, result.Issues)
+
+	output = captureStdout(t, func() {
+		require.NoError(t, writer.WriteLintResults(nil, LintMetadata{Valid: true}))
+	})
+	assert.Contains(t, output, "\"issues\": []")
+}
+
+func TestJSONWriter_WriteOutdatedReport(t *testing.T) {
+	writer := NewJSONWriter()
+
+	report := OutdatedReport{
+		ConfigPath:   "/project/.contexture.yaml",
+		RulesChecked: 2,
+		Outdated:     1,
+		Pinned:       1,
+		Rules: []OutdatedRule{
+			{ID: "[contexture:go/testing]", Pinned: true, CurrentCommit: "abc", LatestCommit: "def", CommitsBehind: 2, DaysBehind: 9},
+		},
+	}
+
+	output := captureStdout(t, func() {
+		require.NoError(t, writer.WriteOutdatedReport(report))
+	})
+
+	var result OutdatedReport
+	require.NoError(t, json.Unmarshal([]byte(output), &result))
+	assert.Equal(t, report, result)
+
+	output = captureStdout(t, func() {
+		require.NoError(t, writer.WriteOutdatedReport(OutdatedReport{}))
+	})
+	assert.Contains(t, output, "\"rules\": []")
+}
+
+func TestJSONWriter_WriteAuditReport(t *testing.T) {
+	writer := NewJSONWriter()
+
+	report := AuditReport{
+		Baseline: "@acme/configs/baseline.yaml",
+		Repositories: []AuditRepository{
+			{
+				Repository: "services/api",
+				ConfigPath: ".contexture.yaml",
+				Rules:      3,
+				LocalRules: 1,
+				Outdated:   1,
+				Violations: []AuditViolation{{Rule: "[contexture:security/secrets]", Type: "omitted"}},
+			},
+			{Repository: "https://github.com/acme/web.git", Error: "fetch repository: not found"},
+		},
+		Adoption:   []RuleAdoption{{ID: "[contexture:go/errors]", Repositories: 1}},
+		Violations: 1,
+		Failed:     1,
+	}
+
+	output := captureStdout(t, func() {
+		require.NoError(t, writer.WriteAuditReport(report))
+	})
+
+	var result AuditReport
+	require.NoError(t, json.Unmarshal([]byte(output), &result))
+	assert.Equal(t, report.Repositories[0], result.Repositories[0])
+	assert.Empty(t, result.Repositories[1].Violations)
+	assert.Equal(t, report.Adoption, result.Adoption)
+
+	output = captureStdout(t, func() {
+		require.NoError(t, writer.WriteAuditReport(AuditReport{}))
+	})
+	assert.Contains(t, output, "\"repositories\": []")
+	assert.Contains(t, output, "\"adoption\": []")
+}
+
+func TestJSONWriter_WriteSelection(t *testing.T) {
+	writer := NewJSONWriter()
+
+	metadata := SelectionMetadata{Command: "config formats add", Selected: []string{"cursor", "windsurf"}}
+	output := captureStdout(t, func() {
+		require.NoError(t, writer.WriteSelection(metadata))
+	})
+
+	var result JSONSelectionOutput
+	require.NoError(t, json.Unmarshal([]byte(output), &result))
+	assert.Equal(t, metadata, result.Metadata)
+
+	output = captureStdout(t, func() {
+		require.NoError(t, writer.WriteSelection(SelectionMetadata{Command: "init"}))
+	})
+	assert.Contains(t, output, "\"selected\": []")
+}
@@ -1,7 +1,13 @@
 package output
 
 import (
+	"fmt"
+	"sort"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/contextureai/contexture/internal/diff"
 	"github.com/contextureai/contexture/internal/domain"
+	"github.com/contextureai/contexture/internal/ui"
 	"github.com/contextureai/contexture/internal/ui/rules"
 )
 
@@ -22,6 +28,8 @@ func (w *TerminalWriter) WriteRulesList(rulesSlice []*domain.Rule, metadata List
 	if metadata.Pattern != "" {
 		options.Pattern = metadata.Pattern
 	}
+	options.LastChanges = metadata.LastChanges
+	options.ShowOwners = metadata.Verbose
 
 	// Delegate to existing display logic
 	return rules.DisplayRuleList(rulesSlice, options)
@@ -53,3 +61,280 @@ func (w *TerminalWriter) WriteQueryResults(rulesSlice []*domain.Rule, metadata Q
 	// Delegate to existing display logic with custom header
 	return rules.DisplayQueryResults(rulesSlice, metadata.Query, metadata.QueryType, options)
 }
+
+// WriteRuleComparison writes rules compare result in terminal format
+func (w *TerminalWriter) WriteRuleComparison(left, right *domain.Rule, lines []diff.Line) error {
+	return rules.DisplayRuleComparison(left, right, lines)
+}
+
+// WriteValidationResults writes validate results in terminal format
+func (w *TerminalWriter) WriteValidationResults(issues []ValidationIssue, metadata ValidateMetadata) error {
+	theme := ui.DefaultTheme()
+	successStyle := lipgloss.NewStyle().Foreground(theme.Success)
+	errorStyle := lipgloss.NewStyle().Foreground(theme.Error)
+	mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+
+	summary := fmt.Sprintf("%d rule(s), %d provider(s) checked", metadata.RulesChecked, metadata.ProvidersChecked)
+	if metadata.Offline {
+		summary += ", offline"
+	}
+
+	var errors, warnings []ValidationIssue
+	for _, issue := range issues {
+		if issue.Warning {
+			warnings = append(warnings, issue)
+		} else {
+			errors = append(errors, issue)
+		}
+	}
+
+	if len(errors) == 0 {
+		fmt.Printf("%s %s\n", successStyle.Render("✓ Configuration is valid"), mutedStyle.Render("["+summary+"]"))
+	} else {
+		fmt.Printf("%s %s\n\n", errorStyle.Render(fmt.Sprintf("✗ Found %d issue(s)", len(errors))), mutedStyle.Render("["+summary+"]"))
+		writeValidationIssues(errors)
+	}
+
+	if len(warnings) > 0 {
+		warningStyle := lipgloss.NewStyle().Foreground(theme.Warning)
+		fmt.Printf("\n%s\n\n", warningStyle.Render(fmt.Sprintf("⚠ %d warning(s)", len(warnings))))
+		writeValidationIssues(warnings)
+	}
+
+	return nil
+}
+
+// writeValidationIssues prints issues with their check and path
+func writeValidationIssues(issues []ValidationIssue) {
+	mutedStyle := lipgloss.NewStyle().Foreground(ui.DefaultTheme().Muted)
+	for _, issue := range issues {
+		location := issue.Check
+		if issue.Path != "" {
+			location += " " + issue.Path
+		}
+		fmt.Printf("  %s %s\n", mutedStyle.Render(location+":"), issue.Message)
+	}
+}
+
+// WriteTestResults writes test command results in terminal format
+func (w *TerminalWriter) WriteTestResults(results []TestResult, metadata TestMetadata) error {
+	theme := ui.DefaultTheme()
+	successStyle := lipgloss.NewStyle().Foreground(theme.Success)
+	errorStyle := lipgloss.NewStyle().Foreground(theme.Error)
+	mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+
+	if len(results) == 0 {
+		fmt.Println(mutedStyle.Render("No rule tests found"))
+		return nil
+	}
+
+	for _, result := range results {
+		name := result.Rule + " › " + result.Test
+		if result.Passed {
+			fmt.Printf("%s %s\n", successStyle.Render("✓"), name)
+			continue
+		}
+		fmt.Printf("%s %s\n", errorStyle.Render("✗"), name)
+		if result.Path != "" {
+			fmt.Printf("    %s\n", mutedStyle.Render(result.Path))
+		}
+		for _, failure := range result.Failures {
+			fmt.Printf("    %s\n", failure)
+		}
+	}
+
+	summary := fmt.Sprintf("%d test(s) across %d rule(s)", metadata.TestsRun, metadata.RulesTested)
+	fmt.Println()
+	if metadata.Passed {
+		fmt.Printf("%s %s\n", successStyle.Render("✓ All tests passed"), mutedStyle.Render("["+summary+"]"))
+	} else {
+		fmt.Printf("%s %s\n", errorStyle.Render(fmt.Sprintf("✗ %d test(s) failed", metadata.TestsFailed)), mutedStyle.Render("["+summary+"]"))
+	}
+
+	return nil
+}
+
+// WriteLintResults writes repo lint results in terminal format
+func (w *TerminalWriter) WriteLintResults(issues []ValidationIssue, metadata LintMetadata) error {
+	theme := ui.DefaultTheme()
+	successStyle := lipgloss.NewStyle().Foreground(theme.Success)
+	errorStyle := lipgloss.NewStyle().Foreground(theme.Error)
+	mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+
+	summary := fmt.Sprintf("%d rule(s) checked", metadata.RulesChecked)
+	if !metadata.Indexed {
+		summary += ", no index"
+	}
+
+	if len(issues) == 0 {
+		fmt.Printf("%s %s\n", successStyle.Render("✓ Repository is valid"), mutedStyle.Render("["+summary+"]"))
+		return nil
+	}
+
+	fmt.Printf("%s %s\n\n", errorStyle.Render(fmt.Sprintf("✗ Found %d issue(s)", len(issues))), mutedStyle.Render("["+summary+"]"))
+	for _, issue := range issues {
+		location := issue.Check
+		if issue.Path != "" {
+			location += " " + issue.Path
+		}
+		fmt.Printf("  %s %s\n", mutedStyle.Render(location+":"), issue.Message)
+	}
+
+	return nil
+}
+
+// WriteRepoStats writes repo stats in terminal format
+func (w *TerminalWriter) WriteRepoStats(stats RepoStats) error {
+	theme := ui.DefaultTheme()
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(theme.Primary)
+	mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+	warningStyle := lipgloss.NewStyle().Foreground(theme.Warning)
+
+	fmt.Printf("%s %s\n", headerStyle.Render(fmt.Sprintf("%d rule(s)", stats.TotalRules)), mutedStyle.Render(stats.Source))
+	fmt.Printf("Average body length: %d characters\n", stats.AverageBodyLength)
+
+	writeCounts := func(title string, counts map[string]int) {
+		fmt.Printf("\n%s\n", headerStyle.Render(title))
+		if len(counts) == 0 {
+			fmt.Printf("  %s\n", mutedStyle.Render("none"))
+			return
+		}
+		names := make([]string, 0, len(counts))
+		for name := range counts {
+			names = append(names, name)
+		}
+		sort.Slice(names, func(i, j int) bool {
+			if counts[names[i]] != counts[names[j]] {
+				return counts[names[i]] > counts[names[j]]
+			}
+			return names[i] < names[j]
+		})
+		for _, name := range names {
+			fmt.Printf("  %5d  %s\n", counts[name], name)
+		}
+	}
+	writeCounts("By folder", stats.ByFolder)
+	writeCounts("By tag", stats.ByTag)
+	writeCounts("By language", stats.ByLanguage)
+
+	fmt.Printf("\n%s\n", headerStyle.Render("Last modified"))
+	for _, bucket := range stats.LastModified {
+		fmt.Printf("  %5d  %s\n", bucket.Rules, bucket.Label)
+	}
+
+	writeRules := func(title string, rules []string) {
+		if len(rules) == 0 {
+			return
+		}
+		fmt.Printf("\n%s\n", warningStyle.Render(fmt.Sprintf("%s (%d)", title, len(rules))))
+		for _, rule := range rules {
+			fmt.Printf("  %s\n", rule)
+		}
+	}
+	writeRules("Missing description", stats.MissingDescription)
+	writeRules("Missing trigger", stats.MissingTrigger)
+	writeRules("Unreadable frontmatter", stats.Unreadable)
+
+	return nil
+}
+
+// WriteOutdatedReport writes the outdated report in terminal format
+func (w *TerminalWriter) WriteOutdatedReport(report OutdatedReport) error {
+	theme := ui.DefaultTheme()
+	successStyle := lipgloss.NewStyle().Bold(true).Foreground(theme.Success)
+	updateStyle := lipgloss.NewStyle().Bold(true).Foreground(theme.Update)
+	errorStyle := lipgloss.NewStyle().Foreground(theme.Error)
+	mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+
+	if report.Outdated == 0 {
+		fmt.Println(successStyle.Render(fmt.Sprintf("All %d rule(s) are up to date", report.RulesChecked-report.Failed)))
+	} else {
+		fmt.Println(updateStyle.Render(fmt.Sprintf("↑ %d of %d rule(s) outdated", report.Outdated, report.RulesChecked)))
+	}
+
+	for _, rule := range report.Rules {
+		if rule.Error != "" {
+			fmt.Printf("  %s %s %s\n", errorStyle.Render("✗"), rule.ID, errorStyle.Render(rule.Error))
+			continue
+		}
+		details := fmt.Sprintf("%d commit(s), %d day(s) behind", rule.CommitsBehind, rule.DaysBehind)
+		if rule.Pinned {
+			details += ", pinned"
+		}
+		fmt.Printf("  %s %s %s\n", updateStyle.Render("↑"), rule.ID, mutedStyle.Render(details))
+	}
+
+	return nil
+}
+
+// WriteAuditReport writes the audit report in terminal format
+func (w *TerminalWriter) WriteAuditReport(report AuditReport) error {
+	theme := ui.DefaultTheme()
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(theme.Primary)
+	successStyle := lipgloss.NewStyle().Foreground(theme.Success)
+	updateStyle := lipgloss.NewStyle().Foreground(theme.Update)
+	warningStyle := lipgloss.NewStyle().Foreground(theme.Warning)
+	errorStyle := lipgloss.NewStyle().Foreground(theme.Error)
+	mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+
+	fmt.Printf("%s %s\n", headerStyle.Render(fmt.Sprintf("%d repositories audited", len(report.Repositories))),
+		mutedStyle.Render(fmt.Sprintf("%d violation(s), %d failed", report.Violations, report.Failed)))
+
+	for _, repo := range report.Repositories {
+		fmt.Println()
+		if repo.Error != "" {
+			fmt.Printf("%s %s %s\n", errorStyle.Render("✗"), repo.Repository, errorStyle.Render(repo.Error))
+			continue
+		}
+
+		details := fmt.Sprintf("%d rule(s), %d local", repo.Rules, repo.LocalRules)
+		if repo.Baseline != "" {
+			details += ", baseline " + repo.Baseline
+		}
+		fmt.Printf("%s %s\n", headerStyle.Render(repo.Repository), mutedStyle.Render(details))
+		if repo.Outdated > 0 {
+			fmt.Printf("  %s\n", updateStyle.Render(fmt.Sprintf("↑ %d rule(s) outdated", repo.Outdated)))
+		}
+		for _, violation := range repo.Violations {
+			line := fmt.Sprintf("%s %s", violation.Type, violation.Rule)
+			if violation.Detail != "" {
+				line += " " + mutedStyle.Render("("+violation.Detail+")")
+			}
+			fmt.Printf("  %s %s\n", warningStyle.Render("!"), line)
+		}
+		if repo.Outdated == 0 && len(repo.Violations) == 0 {
+			fmt.Printf("  %s\n", successStyle.Render("✓ up to date and aligned"))
+		}
+	}
+
+	if len(report.Adoption) > 0 {
+		fmt.Printf("\n%s\n", headerStyle.Render("Rule adoption"))
+		for _, adoption := range report.Adoption {
+			fmt.Printf("  %5d  %s\n", adoption.Repositories, adoption.ID)
+		}
+	}
+
+	return nil
+}
+
+// WriteSnapshotResults writes snapshot comparison results in terminal format,
+// showing each mismatched file with its diff
+func (w *TerminalWriter) WriteSnapshotResults(mismatches []SnapshotMismatch, metadata SnapshotMetadata) error {
+	theme := ui.DefaultTheme()
+	successStyle := lipgloss.NewStyle().Foreground(theme.Success)
+	errorStyle := lipgloss.NewStyle().Foreground(theme.Error)
+	pathStyle := lipgloss.NewStyle().Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+
+	if metadata.Matched {
+		fmt.Println(successStyle.Render(fmt.Sprintf("✓ %d generated file(s) match their snapshots", metadata.FilesChecked)))
+		return nil
+	}
+
+	for _, mismatch := range mismatches {
+		fmt.Printf("%s %s %s\n", errorStyle.Render("✗"), pathStyle.Render(mismatch.Path), mutedStyle.Render("("+mismatch.Status+")"))
+		fmt.Println(ui.RenderDiff(mismatch.Diff))
+		fmt.Println()
+	}
+	return nil
+}
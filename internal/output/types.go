@@ -2,7 +2,10 @@
 package output
 
 import (
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/diff"
 	"github.com/contextureai/contexture/internal/domain"
+	"github.com/contextureai/contexture/internal/query"
 )
 
 // Format represents the output format type
@@ -13,6 +16,8 @@ const (
 	FormatDefault Format = "default"
 	// FormatJSON represents JSON output format
 	FormatJSON Format = "json"
+	// FormatGitHub represents GitHub Actions workflow command annotations
+	FormatGitHub Format = "github"
 )
 
 // Writer interface for different output formats
@@ -22,13 +27,28 @@ type Writer interface {
 	WriteRulesRemove(metadata RemoveMetadata) error
 	WriteRulesUpdate(metadata UpdateMetadata) error
 	WriteQueryResults(rules []*domain.Rule, metadata QueryMetadata) error
+	WriteValidationResults(issues []ValidationIssue, metadata ValidateMetadata) error
+	WriteRuleComparison(left, right *domain.Rule, lines []diff.Line) error
+	WriteTestResults(results []TestResult, metadata TestMetadata) error
+	WriteLintResults(issues []ValidationIssue, metadata LintMetadata) error
+	WriteRepoStats(stats RepoStats) error
+	WriteOutdatedReport(report OutdatedReport) error
+	WriteSnapshotResults(mismatches []SnapshotMismatch, metadata SnapshotMetadata) error
+	WriteAuditReport(report AuditReport) error
 }
 
 // ListMetadata contains contextual information for rules list commands
 type ListMetadata struct {
-	Pattern       string `json:"pattern,omitempty"`
-	TotalRules    int    `json:"totalRules"`
-	FilteredRules int    `json:"filteredRules"`
+	Pattern       string        `json:"pattern,omitempty"`
+	FilterName    string        `json:"filterName,omitempty"`
+	Facets        *query.Facets `json:"facets,omitempty"`
+	TotalRules    int           `json:"totalRules"`
+	FilteredRules int           `json:"filteredRules"`
+	// LastChanges holds the last commit of each rule by ID, when requested
+	LastChanges map[string]domain.RuleChange `json:"lastChanges,omitempty"`
+	// Verbose shows the owners of rules in terminal output; JSON output
+	// always includes them
+	Verbose bool `json:"-"`
 }
 
 // AddMetadata contains contextual information for rules add commands
@@ -55,14 +75,159 @@ type QueryMetadata struct {
 	TotalResults int    `json:"totalResults"`
 }
 
+// ValidationIssue describes a single problem reported by the validate or repo lint command
+type ValidationIssue struct {
+	Check   string `json:"check"` // e.g. "config", "rule" or "index"
+	Path    string `json:"path,omitempty"`
+	Message string `json:"message"`
+	Warning bool   `json:"warning,omitempty"` // reported without failing validation
+}
+
+// ValidateMetadata contains contextual information for validate commands
+type ValidateMetadata struct {
+	ConfigPath       string `json:"configPath,omitempty"`
+	Offline          bool   `json:"offline"`
+	RulesChecked     int    `json:"rulesChecked"`
+	ProvidersChecked int    `json:"providersChecked"`
+	Valid            bool   `json:"valid"`
+}
+
+// TestResult is the outcome of a single rule test run by the test command
+type TestResult struct {
+	Rule     string   `json:"rule"`
+	Test     string   `json:"test"`
+	Path     string   `json:"path,omitempty"` // file declaring the test
+	Passed   bool     `json:"passed"`
+	Failures []string `json:"failures,omitempty"`
+}
+
+// TestMetadata contains contextual information for test commands
+type TestMetadata struct {
+	RulesTested int  `json:"rulesTested"`
+	TestsRun    int  `json:"testsRun"`
+	TestsFailed int  `json:"testsFailed"`
+	Passed      bool `json:"passed"`
+}
+
+// LintMetadata contains contextual information for repo lint commands
+type LintMetadata struct {
+	Path         string `json:"path"`
+	RulesChecked int    `json:"rulesChecked"`
+	Indexed      bool   `json:"indexed"` // whether the repository has an index manifest
+	Valid        bool   `json:"valid"`
+}
+
+// RepoStats summarizes a rule repository for repo stats commands
+type RepoStats struct {
+	Source             string         `json:"source"`
+	TotalRules         int            `json:"totalRules"`
+	ByFolder           map[string]int `json:"byFolder"`
+	ByTag              map[string]int `json:"byTag"`
+	ByLanguage         map[string]int `json:"byLanguage"`
+	AverageBodyLength  int            `json:"averageBodyLength"` // in characters
+	LastModified       []AgeBucket    `json:"lastModified"`
+	MissingDescription []string       `json:"missingDescription"`
+	MissingTrigger     []string       `json:"missingTrigger"`
+	Unreadable         []string       `json:"unreadable,omitempty"` // rules whose frontmatter could not be parsed
+}
+
+// AgeBucket counts the rules last modified within an age range
+type AgeBucket struct {
+	Label string `json:"label"`
+	Rules int    `json:"rules"`
+}
+
+// OutdatedReport lists the remote rules of a project that have updates available
+type OutdatedReport struct {
+	ConfigPath   string         `json:"configPath,omitempty"`
+	RulesChecked int            `json:"rulesChecked"`
+	Outdated     int            `json:"outdated"`
+	Pinned       int            `json:"pinned"` // pinned rules among the outdated ones
+	Failed       int            `json:"failed"`
+	Rules        []OutdatedRule `json:"rules"` // outdated rules and rules that could not be checked
+}
+
+// OutdatedRule describes how far a rule is behind the latest version of its source
+type OutdatedRule struct {
+	ID            string `json:"id"`
+	Source        string `json:"source,omitempty"`
+	Ref           string `json:"ref,omitempty"`
+	Pinned        bool   `json:"pinned"`
+	CurrentCommit string `json:"currentCommit,omitempty"`
+	CurrentDate   string `json:"currentDate,omitempty"` // YYYY-MM-DD
+	LatestCommit  string `json:"latestCommit,omitempty"`
+	LatestDate    string `json:"latestDate,omitempty"` // YYYY-MM-DD
+	CommitsBehind int    `json:"commitsBehind"`        // commits touching the rule since the current commit
+	DaysBehind    int    `json:"daysBehind"`           // days between the current and latest commit
+	Error         string `json:"error,omitempty"`
+}
+
+// SnapshotMismatch describes a generated file that differs from its recorded snapshot
+type SnapshotMismatch struct {
+	Path   string      `json:"path"`   // slash-separated, relative to the project directory
+	Status string      `json:"status"` // "changed", "added" or "removed"
+	Diff   []diff.Line `json:"diff"`
+}
+
+// SnapshotMetadata contains contextual information for snapshot commands
+type SnapshotMetadata struct {
+	BaseDir      string `json:"baseDir"`
+	FilesChecked int    `json:"filesChecked"`
+	Matched      bool   `json:"matched"`
+}
+
+// SelectionMetadata describes what an interactive flow run with --dry-run
+// would have selected
+type SelectionMetadata struct {
+	Command  string   `json:"command"`
+	Selected []string `json:"selected"`
+}
+
+// AuditReport summarizes the contexture configurations of many repositories
+type AuditReport struct {
+	Baseline     string            `json:"baseline,omitempty"` // baseline given for every repository
+	Repositories []AuditRepository `json:"repositories"`
+	Adoption     []RuleAdoption    `json:"adoption"` // remote rules by the number of repositories using them
+	Violations   int               `json:"violations"`
+	Failed       int               `json:"failed"` // repositories that could not be audited
+}
+
+// AuditRepository summarizes the configuration of one repository
+type AuditRepository struct {
+	Repository string           `json:"repository"` // path or URL as listed
+	ConfigPath string           `json:"configPath,omitempty"`
+	Baseline   string           `json:"baseline,omitempty"` // baseline the repository was compared with
+	Rules      int              `json:"rules"`
+	LocalRules int              `json:"localRules"`
+	Outdated   int              `json:"outdated"`
+	Violations []AuditViolation `json:"violations"`
+	Error      string           `json:"error,omitempty"`
+}
+
+// AuditViolation is a baseline rule a repository omits or changes without a
+// recorded deviation
+type AuditViolation struct {
+	Rule   string `json:"rule"`
+	Type   string `json:"type"` // "omitted" or "modified"
+	Detail string `json:"detail,omitempty"`
+}
+
+// RuleAdoption counts the repositories using a rule
+type RuleAdoption struct {
+	ID           string `json:"id"`
+	Repositories int    `json:"repositories"`
+}
+
 // Manager handles output format selection and writing
 type Manager struct {
 	format Format
 	writer Writer
 }
 
-// NewManager creates a new output manager for the specified format
-func NewManager(format Format) (*Manager, error) {
+// NewManager creates a new output manager for the specified format. The
+// GitHub format reads the workspace its file paths are relative to from env,
+// or uses workingDir.
+func NewManager(format Format, workingDir dependencies.WorkingDir, env dependencies.Environment) (*Manager, error) {
 	var writer Writer
 	var err error
 
@@ -71,6 +236,8 @@ func NewManager(format Format) (*Manager, error) {
 		writer = NewTerminalWriter()
 	case FormatJSON:
 		writer = NewJSONWriter()
+	case FormatGitHub:
+		writer = NewGitHubWriter(workingDir, env)
 	default:
 		return nil, &UnsupportedFormatError{Format: string(format)}
 	}
@@ -106,11 +273,51 @@ func (m *Manager) WriteQueryResults(rules []*domain.Rule, metadata QueryMetadata
 	return m.writer.WriteQueryResults(rules, metadata)
 }
 
+// WriteValidationResults writes the validate result using the configured format
+func (m *Manager) WriteValidationResults(issues []ValidationIssue, metadata ValidateMetadata) error {
+	return m.writer.WriteValidationResults(issues, metadata)
+}
+
+// WriteRuleComparison writes the rules compare result using the configured format
+func (m *Manager) WriteRuleComparison(left, right *domain.Rule, lines []diff.Line) error {
+	return m.writer.WriteRuleComparison(left, right, lines)
+}
+
+// WriteTestResults writes the test command results using the configured format
+func (m *Manager) WriteTestResults(results []TestResult, metadata TestMetadata) error {
+	return m.writer.WriteTestResults(results, metadata)
+}
+
+// WriteLintResults writes the repo lint result using the configured format
+func (m *Manager) WriteLintResults(issues []ValidationIssue, metadata LintMetadata) error {
+	return m.writer.WriteLintResults(issues, metadata)
+}
+
+// WriteRepoStats writes the repo stats result using the configured format
+func (m *Manager) WriteRepoStats(stats RepoStats) error {
+	return m.writer.WriteRepoStats(stats)
+}
+
+// WriteOutdatedReport writes the outdated report using the configured format
+func (m *Manager) WriteOutdatedReport(report OutdatedReport) error {
+	return m.writer.WriteOutdatedReport(report)
+}
+
+// WriteSnapshotResults writes snapshot comparison results using the configured format
+func (m *Manager) WriteSnapshotResults(mismatches []SnapshotMismatch, metadata SnapshotMetadata) error {
+	return m.writer.WriteSnapshotResults(mismatches, metadata)
+}
+
+// WriteAuditReport writes the audit report using the configured format
+func (m *Manager) WriteAuditReport(report AuditReport) error {
+	return m.writer.WriteAuditReport(report)
+}
+
 // UnsupportedFormatError represents an error for unsupported output formats
 type UnsupportedFormatError struct {
 	Format string
 }
 
 func (e *UnsupportedFormatError) Error() string {
-	return "unsupported output format: " + e.Format + " (supported formats: default, json)"
+	return "unsupported output format: " + e.Format + " (supported formats: default, json, github)"
 }
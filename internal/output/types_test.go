@@ -3,6 +3,7 @@ package output
 import (
 	"testing"
 
+	"github.com/contextureai/contexture/internal/dependencies"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -14,12 +15,13 @@ func TestNewManager_ValidFormats(t *testing.T) {
 	}{
 		{"default format", FormatDefault},
 		{"json format", FormatJSON},
+		{"github format", FormatGitHub},
 		{"empty format", ""},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			manager, err := NewManager(tt.format)
+			manager, err := NewManager(tt.format, dependencies.StaticWorkingDir("/project"), dependencies.MapEnvironment{})
 			require.NoError(t, err)
 			assert.NotNil(t, manager)
 			assert.NotNil(t, manager.writer)
@@ -28,7 +30,7 @@ func TestNewManager_ValidFormats(t *testing.T) {
 }
 
 func TestNewManager_InvalidFormat(t *testing.T) {
-	manager, err := NewManager("invalid")
+	manager, err := NewManager("invalid", dependencies.StaticWorkingDir("/project"), dependencies.MapEnvironment{})
 	require.Error(t, err)
 	assert.Nil(t, manager)
 
@@ -39,7 +41,7 @@ func TestNewManager_InvalidFormat(t *testing.T) {
 
 func TestUnsupportedFormatError(t *testing.T) {
 	err := &UnsupportedFormatError{Format: "yaml"}
-	expected := "unsupported output format: yaml (supported formats: default, json)"
+	expected := "unsupported output format: yaml (supported formats: default, json, github)"
 	assert.Equal(t, expected, err.Error())
 }
 
@@ -0,0 +1,124 @@
+// Package ownership decides which team a project belongs to, from its
+// configuration or CODEOWNERS, and which rules other teams own
+package ownership
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/globs"
+	"github.com/spf13/afero"
+)
+
+// CodeownersPaths are where CODEOWNERS files are looked up, relative to the
+// repository root, in the order GitHub reads them
+var CodeownersPaths = []string{
+	filepath.Join(".github", "CODEOWNERS"),
+	"CODEOWNERS",
+	filepath.Join("docs", "CODEOWNERS"),
+}
+
+// Codeowners assigns owners to paths. The last pattern matching a path
+// wins, and a pattern without owners leaves the path unowned.
+type Codeowners struct {
+	entries []codeownersEntry
+}
+
+// codeownersEntry is a single line of a CODEOWNERS file
+type codeownersEntry struct {
+	pattern string
+	owners  []string
+}
+
+// ParseCodeowners parses the content of a CODEOWNERS file, skipping blank
+// lines and comments
+func ParseCodeowners(content string) *Codeowners {
+	codeowners := &Codeowners{}
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		codeowners.entries = append(codeowners.entries, codeownersEntry{pattern: fields[0], owners: fields[1:]})
+	}
+	return codeowners
+}
+
+// LoadCodeowners reads the first CODEOWNERS file of the repository at root,
+// or returns nil when it has none
+func LoadCodeowners(fs afero.Fs, root string) (*Codeowners, error) {
+	for _, relPath := range CodeownersPaths {
+		data, err := afero.ReadFile(fs, filepath.Join(root, relPath))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, contextureerrors.WithOpf("read CODEOWNERS", "%s: %w", relPath, err)
+		}
+		return ParseCodeowners(string(data)), nil
+	}
+	return nil, nil
+}
+
+// Owners returns the owners of the slash-separated relPath
+func (c *Codeowners) Owners(relPath string) []string {
+	relPath = strings.TrimPrefix(filepath.ToSlash(relPath), "./")
+	var owners []string
+	for _, entry := range c.entries {
+		if matchCodeowners(entry.pattern, relPath) {
+			owners = entry.owners
+		}
+	}
+	return owners
+}
+
+// matchCodeowners reports whether pattern matches relPath or one of its
+// directories, as patterns such as /apps/web own everything below them
+func matchCodeowners(pattern, relPath string) bool {
+	for candidate := relPath; candidate != "." && candidate != "/"; candidate = path.Dir(candidate) {
+		if globs.Match(pattern, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+// Team returns the owners of the project at projectDir: the team its
+// configuration names, or the owners CODEOWNERS assigns to its
+// configuration file at configPath. It is empty when neither names one.
+func Team(fs afero.Fs, config *domain.Project, projectDir, configPath string) ([]string, error) {
+	if config.Ownership != nil && config.Ownership.Team != "" {
+		return []string{config.Ownership.Team}, nil
+	}
+	codeowners, err := LoadCodeowners(fs, projectDir)
+	if err != nil || codeowners == nil {
+		return nil, err
+	}
+	relPath, err := filepath.Rel(projectDir, configPath)
+	if err != nil {
+		return nil, nil
+	}
+	return codeowners.Owners(relPath), nil
+}
+
+// OwnedByOthers reports whether a rule owned by owner belongs to a team
+// other than team. Rules without an owner belong to everyone.
+func OwnedByOthers(owner string, team []string) bool {
+	if owner == "" {
+		return false
+	}
+	for _, member := range team {
+		if strings.EqualFold(member, owner) {
+			return false
+		}
+	}
+	return true
+}
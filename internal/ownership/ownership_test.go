@@ -0,0 +1,85 @@
+package ownership
+
+import (
+	"testing"
+
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCodeowners = `# Default owners
+*                 @acme/platform
+/apps/web/        @acme/web @alice
+*.md              @acme/docs
+/apps/web/legacy/
+`
+
+func TestCodeowners_Owners(t *testing.T) {
+	t.Parallel()
+	codeowners := ParseCodeowners(testCodeowners)
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{path: "go.mod", want: []string{"@acme/platform"}},
+		{path: "apps/web/.contexture.yaml", want: []string{"@acme/web", "@alice"}},
+		{path: "apps/web/README.md", want: []string{"@acme/docs"}},
+		{path: "./apps/web/src/index.ts", want: []string{"@acme/web", "@alice"}},
+		{path: "apps/web/legacy/app.js", want: []string{}},
+		{path: "apps/api/web/main.go", want: []string{"@acme/platform"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, codeowners.Owners(tt.path))
+		})
+	}
+}
+
+func TestLoadCodeowners(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+
+	codeowners, err := LoadCodeowners(fs, "/repo")
+	require.NoError(t, err)
+	assert.Nil(t, codeowners)
+
+	require.NoError(t, afero.WriteFile(fs, "/repo/docs/CODEOWNERS", []byte("* @acme/docs\n"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "/repo/.github/CODEOWNERS", []byte("* @acme/platform\n"), 0o644))
+	codeowners, err = LoadCodeowners(fs, "/repo")
+	require.NoError(t, err)
+	require.NotNil(t, codeowners)
+	assert.Equal(t, []string{"@acme/platform"}, codeowners.Owners("README.md"))
+}
+
+func TestTeam(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/repo/CODEOWNERS", []byte("/.contexture.yaml @acme/web\n"), 0o644))
+
+	team, err := Team(fs, &domain.Project{}, "/repo", "/repo/.contexture.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"@acme/web"}, team)
+
+	// The configured team takes precedence over CODEOWNERS
+	config := &domain.Project{Ownership: &domain.OwnershipConfig{Team: "@acme/api"}}
+	team, err = Team(fs, config, "/repo", "/repo/.contexture.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"@acme/api"}, team)
+
+	team, err = Team(afero.NewMemMapFs(), &domain.Project{}, "/repo", "/repo/.contexture.yaml")
+	require.NoError(t, err)
+	assert.Empty(t, team)
+}
+
+func TestOwnedByOthers(t *testing.T) {
+	t.Parallel()
+	team := []string{"@acme/web", "@alice"}
+	assert.False(t, OwnedByOthers("", team))
+	assert.False(t, OwnedByOthers("@Acme/Web", team))
+	assert.True(t, OwnedByOthers("@acme/security", team))
+	assert.True(t, OwnedByOthers("@acme/security", nil))
+}
@@ -0,0 +1,98 @@
+// Package permissions checks that the files a command writes are writable
+// before it starts, and provides the read-only mode that turns writes into
+// reported no-ops
+package permissions
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/spf13/afero"
+)
+
+// Target is a file or directory a command writes
+type Target struct {
+	Path string
+	// Description says what the path holds, such as "Claude output"
+	Description string
+}
+
+// Check verifies that every target can be written, so a long operation does
+// not fail after writing part of its files. It returns a permission error
+// listing the targets that cannot be written. In read-only mode nothing is
+// written, so every target passes.
+func Check(fs afero.Fs, targets []Target) error {
+	if _, ok := fs.(*ReadOnlyFs); ok {
+		return nil
+	}
+	var problems []error
+	seen := make(map[string]bool)
+	for _, target := range targets {
+		if target.Path == "" || seen[target.Path] {
+			continue
+		}
+		seen[target.Path] = true
+		if err := Writable(fs, target.Path); err != nil {
+			problems = append(problems, fmt.Errorf("%s (%s): %w", target.Path, target.Description, err))
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return contextureerrors.Wrap(errors.Join(problems...), "check write permissions").
+		WithSuggestions(
+			contextureerrors.Hint("make these paths writable, or check the ownership of the checkout"),
+			contextureerrors.RunCommand("contexture --read-only <command>", "see what the command would write without writing"),
+		)
+}
+
+// Writable reports why path cannot be written, or nil when it can. Existing
+// files are opened for writing without changing them, and a directory is
+// checked by creating and removing a file in it; paths that do not exist
+// yet are checked at the nearest existing directory above them.
+func Writable(fs afero.Fs, path string) error {
+	info, err := fs.Stat(path)
+	missing := false
+	for errors.Is(err, os.ErrNotExist) {
+		parent := filepath.Dir(path)
+		if parent == path {
+			return err
+		}
+		path, missing = parent, true
+		info, err = fs.Stat(path)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !info.IsDir() {
+		if missing {
+			return fmt.Errorf("%s is not a directory", path)
+		}
+		file, err := fs.OpenFile(path, os.O_WRONLY, 0)
+		if err != nil {
+			return unwrapPathError(err)
+		}
+		return file.Close()
+	}
+	probe, err := afero.TempFile(fs, path, ".contexture-write-check-*")
+	if err != nil {
+		return unwrapPathError(err)
+	}
+	name := probe.Name()
+	_ = probe.Close()
+	return fs.Remove(name)
+}
+
+// unwrapPathError drops the operation and path of err, which the caller
+// reports with the path it checked
+func unwrapPathError(err error) error {
+	var pathErr *os.PathError
+	if errors.As(err, &pathErr) {
+		return pathErr.Err
+	}
+	return err
+}
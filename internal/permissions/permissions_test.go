@@ -0,0 +1,55 @@
+package permissions
+
+import (
+	"os"
+	"testing"
+
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWritable(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/project/CLAUDE.md", []byte("rules"), 0o644))
+
+	require.NoError(t, Writable(fs, "/project/CLAUDE.md"))
+	require.NoError(t, Writable(fs, "/project/.cursor/rules"))
+	require.ErrorContains(t, Writable(fs, "/project/CLAUDE.md/rules"), "not a directory")
+
+	// The check leaves no files behind
+	entries, err := afero.ReadDir(fs, "/project")
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+	data, err := afero.ReadFile(fs, "/project/CLAUDE.md")
+	require.NoError(t, err)
+	assert.Equal(t, "rules", string(data))
+
+	readOnly := afero.NewReadOnlyFs(fs)
+	require.Error(t, Writable(readOnly, "/project/CLAUDE.md"))
+	require.Error(t, Writable(readOnly, "/project/.cursor/rules"))
+}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/project/CLAUDE.md", []byte("rules"), 0o644))
+	targets := []Target{
+		{Path: "/project/CLAUDE.md", Description: "Claude output"},
+		{Path: "/project/.cursor/rules", Description: "Cursor output"},
+	}
+
+	require.NoError(t, Check(fs, targets))
+
+	err := Check(afero.NewReadOnlyFs(fs), targets)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, os.ErrPermission)
+	assert.Equal(t, int(contextureerrors.ExitPermError), contextureerrors.ExitCodeFor(err))
+	assert.ErrorContains(t, err, "/project/CLAUDE.md (Claude output)")
+	assert.ErrorContains(t, err, "/project/.cursor/rules (Cursor output)")
+
+	// Read-only mode writes nothing, so there is nothing to check
+	require.NoError(t, Check(NewReadOnlyFs(afero.NewReadOnlyFs(fs), nil, nil), targets))
+}
@@ -0,0 +1,194 @@
+package permissions
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/contextureai/contexture/internal/staging"
+	"github.com/spf13/afero"
+)
+
+// Write is a change read-only mode skipped
+type Write struct {
+	// Op is "write", "remove", or "rename"
+	Op   string
+	Path string
+}
+
+// ReadOnlyFs turns the writes of the filesystem it wraps into no-ops that
+// are reported. Written files are kept in memory, so the rest of the command
+// reads them back as if they had been written. Paths below the writable
+// directories, such as the rule cache, are written as usual.
+type ReadOnlyFs struct {
+	base     afero.Fs
+	overlay  afero.Fs
+	layer    afero.Fs
+	writable []string
+	report   func(Write)
+
+	mu       sync.Mutex
+	reported map[Write]bool
+}
+
+// NewReadOnlyFs wraps base to skip writes outside the writable directories,
+// calling report once for each skipped change
+func NewReadOnlyFs(base afero.Fs, writable []string, report func(Write)) *ReadOnlyFs {
+	layer := afero.NewMemMapFs()
+	dirs := make([]string, 0, len(writable))
+	for _, dir := range writable {
+		if dir != "" {
+			dirs = append(dirs, staging.AbsPath(dir))
+		}
+	}
+	return &ReadOnlyFs{
+		base:     base,
+		overlay:  afero.NewCopyOnWriteFs(afero.NewReadOnlyFs(base), layer),
+		layer:    layer,
+		writable: dirs,
+		report:   report,
+		reported: make(map[Write]bool),
+	}
+}
+
+// fsFor returns the filesystem that handles name
+func (f *ReadOnlyFs) fsFor(name string) afero.Fs {
+	path := staging.AbsPath(name)
+	for _, dir := range f.writable {
+		if staging.Within(path, dir) {
+			return f.base
+		}
+	}
+	return f.overlay
+}
+
+// skip reports a skipped change once
+func (f *ReadOnlyFs) skip(op, name string) {
+	write := Write{Op: op, Path: name}
+	f.mu.Lock()
+	first := !f.reported[write]
+	f.reported[write] = true
+	f.mu.Unlock()
+	if first && f.report != nil {
+		f.report(write)
+	}
+}
+
+// Create creates a file in memory
+func (f *ReadOnlyFs) Create(name string) (afero.File, error) {
+	fs := f.fsFor(name)
+	if fs == f.overlay {
+		f.skip("write", name)
+	}
+	return fs.Create(name)
+}
+
+// Mkdir creates a directory in memory
+func (f *ReadOnlyFs) Mkdir(name string, perm os.FileMode) error {
+	return f.fsFor(name).Mkdir(name, perm)
+}
+
+// MkdirAll creates a directory and its parents in memory
+func (f *ReadOnlyFs) MkdirAll(path string, perm os.FileMode) error {
+	return f.fsFor(path).MkdirAll(path, perm)
+}
+
+// Open opens a file for reading, as written in memory if it was
+func (f *ReadOnlyFs) Open(name string) (afero.File, error) {
+	return f.fsFor(name).Open(name)
+}
+
+// OpenFile opens a file, keeping what is written to it in memory
+func (f *ReadOnlyFs) OpenFile(name string, flag int, perm os.FileMode) (afero.File, error) {
+	fs := f.fsFor(name)
+	if fs == f.overlay && flag&(os.O_WRONLY|os.O_RDWR|os.O_APPEND|os.O_CREATE|os.O_TRUNC) != 0 {
+		f.skip("write", name)
+	}
+	return fs.OpenFile(name, flag, perm)
+}
+
+// Remove reports the removal of a file, removing it only when it was
+// written in memory
+func (f *ReadOnlyFs) Remove(name string) error {
+	fs := f.fsFor(name)
+	if fs != f.overlay {
+		return fs.Remove(name)
+	}
+	if _, err := f.overlay.Stat(name); err != nil {
+		return err
+	}
+	f.skip("remove", name)
+	f.removeFromLayer(name, f.layer.Remove)
+	return nil
+}
+
+// RemoveAll reports the removal of a path, removing it only when it was
+// written in memory
+func (f *ReadOnlyFs) RemoveAll(path string) error {
+	fs := f.fsFor(path)
+	if fs != f.overlay {
+		return fs.RemoveAll(path)
+	}
+	if _, err := f.overlay.Stat(path); err != nil {
+		return nil
+	}
+	f.skip("remove", path)
+	f.removeFromLayer(path, f.layer.RemoveAll)
+	return nil
+}
+
+// removeFromLayer removes a path that only exists in memory, so files
+// written and removed again by the command disappear
+func (f *ReadOnlyFs) removeFromLayer(name string, remove func(string) error) {
+	if _, err := f.base.Stat(name); errors.Is(err, os.ErrNotExist) {
+		_ = remove(name)
+	}
+}
+
+// Rename moves a file in memory
+func (f *ReadOnlyFs) Rename(oldname, newname string) error {
+	fs := f.fsFor(newname)
+	if fs != f.overlay && f.fsFor(oldname) == fs {
+		return fs.Rename(oldname, newname)
+	}
+	f.skip("rename", oldname+" to "+newname)
+	if err := f.overlay.Rename(oldname, newname); err == nil {
+		return nil
+	}
+	// Files that exist on disk stay there, and their content is copied
+	data, err := afero.ReadFile(f.overlay, oldname)
+	if err != nil {
+		return err
+	}
+	info, err := f.overlay.Stat(oldname)
+	if err != nil {
+		return err
+	}
+	return afero.WriteFile(f.overlay, newname, data, info.Mode())
+}
+
+// Stat returns the file info of a file, as written in memory if it was
+func (f *ReadOnlyFs) Stat(name string) (os.FileInfo, error) {
+	return f.fsFor(name).Stat(name)
+}
+
+// Name returns the name of the filesystem
+func (f *ReadOnlyFs) Name() string {
+	return "ReadOnlyFs"
+}
+
+// Chmod changes the mode of a file in memory
+func (f *ReadOnlyFs) Chmod(name string, mode os.FileMode) error {
+	return f.fsFor(name).Chmod(name, mode)
+}
+
+// Chown changes the owner of a file in memory
+func (f *ReadOnlyFs) Chown(name string, uid, gid int) error {
+	return f.fsFor(name).Chown(name, uid, gid)
+}
+
+// Chtimes changes the times of a file in memory
+func (f *ReadOnlyFs) Chtimes(name string, atime, mtime time.Time) error {
+	return f.fsFor(name).Chtimes(name, atime, mtime)
+}
@@ -0,0 +1,72 @@
+package permissions
+
+import (
+	"os"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadOnlyFs(t *testing.T) {
+	t.Parallel()
+	base := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(base, "/project/CLAUDE.md", []byte("old"), 0o644))
+	require.NoError(t, afero.WriteFile(base, "/project/.cursor/rules/go.mdc", []byte("go"), 0o644))
+
+	var skipped []Write
+	fs := NewReadOnlyFs(base, []string{"/cache"}, func(write Write) { skipped = append(skipped, write) })
+
+	// Writes are read back from memory and leave the disk unchanged
+	require.NoError(t, afero.WriteFile(fs, "/project/CLAUDE.md", []byte("new"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "/project/CLAUDE.md", []byte("newer"), 0o644))
+	data, err := afero.ReadFile(fs, "/project/CLAUDE.md")
+	require.NoError(t, err)
+	assert.Equal(t, "newer", string(data))
+	data, err = afero.ReadFile(base, "/project/CLAUDE.md")
+	require.NoError(t, err)
+	assert.Equal(t, "old", string(data))
+
+	require.NoError(t, fs.MkdirAll("/project/.windsurf/rules", 0o755))
+	require.NoError(t, afero.WriteFile(fs, "/project/.windsurf/rules/go.md", []byte("go"), 0o644))
+	exists, err := afero.Exists(fs, "/project/.windsurf/rules/go.md")
+	require.NoError(t, err)
+	assert.True(t, exists)
+	exists, err = afero.DirExists(base, "/project/.windsurf")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	// Removals are reported and skipped
+	require.NoError(t, fs.RemoveAll("/project/.cursor"))
+	exists, err = afero.Exists(base, "/project/.cursor/rules/go.mdc")
+	require.NoError(t, err)
+	assert.True(t, exists)
+	require.ErrorIs(t, fs.Remove("/project/missing.md"), os.ErrNotExist)
+
+	// Files written in memory can be moved and removed again
+	require.NoError(t, afero.WriteFile(fs, "/project/.tmp-rules", []byte("tmp"), 0o644))
+	require.NoError(t, fs.Rename("/project/.tmp-rules", "/project/AGENTS.md"))
+	data, err = afero.ReadFile(fs, "/project/AGENTS.md")
+	require.NoError(t, err)
+	assert.Equal(t, "tmp", string(data))
+	require.NoError(t, fs.Remove("/project/AGENTS.md"))
+	exists, err = afero.Exists(fs, "/project/AGENTS.md")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	// Writable directories are written as usual
+	require.NoError(t, afero.WriteFile(fs, "/cache/repo/HEAD", []byte("main"), 0o644))
+	exists, err = afero.Exists(base, "/cache/repo/HEAD")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	assert.Equal(t, []Write{
+		{Op: "write", Path: "/project/CLAUDE.md"},
+		{Op: "write", Path: "/project/.windsurf/rules/go.md"},
+		{Op: "remove", Path: "/project/.cursor"},
+		{Op: "write", Path: "/project/.tmp-rules"},
+		{Op: "rename", Path: "/project/.tmp-rules to /project/AGENTS.md"},
+		{Op: "remove", Path: "/project/AGENTS.md"},
+	}, skipped)
+}
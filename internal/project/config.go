@@ -27,8 +27,10 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/log"
+	"github.com/contextureai/contexture/internal/dependencies"
 	"github.com/contextureai/contexture/internal/domain"
 	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/globs"
 	"github.com/contextureai/contexture/internal/validation"
 	"github.com/spf13/afero"
 	"gopkg.in/yaml.v3"
@@ -100,6 +102,12 @@ type Manager struct {
 	validator    ConfigValidator
 	homeProvider HomeDirectoryProvider
 	cleaner      *ConfigCleaner
+	remote       RemoteConfigFetcher
+	env          dependencies.Environment
+	// configFile is read and saved instead of searching the project directory
+	configFile string
+	// configData is the content of configFile, parsed instead of reading it
+	configData []byte
 }
 
 // ConfigCleaner handles the removal of default values from configurations before saving.
@@ -128,7 +136,8 @@ type FailsafeConfigValidator struct {
 
 // DefaultHomeDirectoryProvider provides home directory resolution.
 type DefaultHomeDirectoryProvider struct {
-	fs afero.Fs
+	fs  afero.Fs
+	env dependencies.Environment
 }
 
 // ConfigError represents a configuration operation error with operation context and file path.
@@ -159,11 +168,24 @@ func NewManager(fs afero.Fs) *Manager {
 		repo:         &DefaultConfigRepository{fs: fs},
 		matcher:      matcher,
 		validator:    newDefaultConfigValidator(),
-		homeProvider: &DefaultHomeDirectoryProvider{fs: fs},
+		homeProvider: &DefaultHomeDirectoryProvider{fs: fs, env: dependencies.OSEnvironment{}},
 		cleaner:      &ConfigCleaner{},
+		remote:       NewRemoteConfigFetcher(fs, dependencies.OSEnvironment{}),
+		env:          dependencies.OSEnvironment{},
 	}
 }
 
+// NewManagerFromDependencies creates a manager that reads the environment of
+// deps and the configuration file selected with --config
+func NewManagerFromDependencies(deps *dependencies.Dependencies) *Manager {
+	m := NewManager(deps.FS)
+	m.env = deps.GetEnv()
+	m.homeProvider = &DefaultHomeDirectoryProvider{fs: deps.FS, env: m.env}
+	m.remote = NewRemoteConfigFetcher(deps.FS, m.env)
+	m.configFile = deps.ConfigFile
+	return m
+}
+
 // NewManagerForTesting creates a manager with injectable dependencies for testing.
 func NewManagerForTesting(
 	repo ConfigRepository,
@@ -177,80 +199,43 @@ func NewManagerForTesting(
 		validator:    validator,
 		homeProvider: homeProvider,
 		cleaner:      &ConfigCleaner{},
+		remote:       NewRemoteConfigFetcher(repo.GetFilesystem(), dependencies.OSEnvironment{}),
+		env:          dependencies.OSEnvironment{},
 	}
 }
 
 // LoadConfig loads project configuration from the filesystem with proper error handling.
 // It tries multiple locations in order of preference and returns detailed error information.
+// A configuration file set with SetConfigFile is loaded instead of searching basePath.
 func (m *Manager) LoadConfig(basePath string) (*domain.ConfigResult, error) {
-	if strings.TrimSpace(basePath) == "" {
-		return nil, contextureerrors.ValidationErrorf("basePath", "cannot be empty")
-	}
-
-	// Try .contexture/ directory first (preferred location)
-	contexturePath := domain.GetConfigPath(basePath, domain.ConfigLocationContexture)
-	if exists, err := m.repo.Exists(contexturePath); err != nil {
-		return nil, &ConfigError{
-			Operation: "check existence",
-			Path:      contexturePath,
-			Err:       err,
-		}
-	} else if exists {
-		config, err := m.repo.Load(contexturePath)
-		if err != nil {
+	if path := m.configFile; path != "" {
+		result, found, err := m.loadConfigAt(path, ConfigFileLocation(path))
+		if err == nil && !found {
 			return nil, &ConfigError{
-				Operation: "load",
-				Path:      contexturePath,
-				Err:       err,
+				Operation: "locate",
+				Path:      path,
+				Err:       errors.New("configuration file does not exist"),
 			}
 		}
-
-		if err := m.validator.ValidateProject(config); err != nil {
-			return nil, &ConfigError{
-				Operation: "validate",
-				Path:      contexturePath,
-				Err:       err,
-			}
+		if err == nil {
+			setFormatBaseDir(result.Config, basePath)
 		}
+		return result, err
+	}
 
-		return &domain.ConfigResult{
-			Config:   config,
-			Location: domain.ConfigLocationContexture,
-			Path:     contexturePath,
-		}, nil
+	if strings.TrimSpace(basePath) == "" {
+		return nil, contextureerrors.ValidationErrorf("basePath", "cannot be empty")
 	}
 
-	// Try project root as fallback
-	rootPath := domain.GetConfigPath(basePath, domain.ConfigLocationRoot)
-	if exists, err := m.repo.Exists(rootPath); err != nil {
-		return nil, &ConfigError{
-			Operation: "check existence",
-			Path:      rootPath,
-			Err:       err,
+	// Try .contexture/ directory first (preferred location), then the project root
+	for _, location := range []domain.ConfigLocation{domain.ConfigLocationContexture, domain.ConfigLocationRoot} {
+		result, found, err := m.loadConfigAt(domain.GetConfigPath(basePath, location), location)
+		if err == nil && found {
+			setFormatBaseDir(result.Config, basePath)
 		}
-	} else if exists {
-		config, err := m.repo.Load(rootPath)
-		if err != nil {
-			return nil, &ConfigError{
-				Operation: "load",
-				Path:      rootPath,
-				Err:       err,
-			}
+		if err != nil || found {
+			return result, err
 		}
-
-		if err := m.validator.ValidateProject(config); err != nil {
-			return nil, &ConfigError{
-				Operation: "validate",
-				Path:      rootPath,
-				Err:       err,
-			}
-		}
-
-		return &domain.ConfigResult{
-			Config:   config,
-			Location: domain.ConfigLocationRoot,
-			Path:     rootPath,
-		}, nil
 	}
 
 	return nil, &ConfigError{
@@ -260,6 +245,61 @@ func (m *Manager) LoadConfig(basePath string) (*domain.ConfigResult, error) {
 	}
 }
 
+// setFormatBaseDir makes the formats of config write their outputs to the
+// project directory rather than the process working directory
+func setFormatBaseDir(config *domain.Project, basePath string) {
+	for i := range config.Formats {
+		if config.Formats[i].BaseDir == "" {
+			config.Formats[i].BaseDir = basePath
+		}
+	}
+}
+
+// loadConfigAt loads and validates the configuration file at path, reporting
+// whether the file exists
+func (m *Manager) loadConfigAt(path string, location domain.ConfigLocation) (*domain.ConfigResult, bool, error) {
+	var config *domain.Project
+	var err error
+	if m.configData != nil && path == m.configFile {
+		config, err = parseConfig(m.configData, path)
+	} else {
+		exists, existsErr := m.repo.Exists(path)
+		if existsErr != nil {
+			return nil, false, &ConfigError{
+				Operation: "check existence",
+				Path:      path,
+				Err:       existsErr,
+			}
+		}
+		if !exists {
+			return nil, false, nil
+		}
+		config, err = m.repo.Load(path)
+	}
+	if err != nil {
+		return nil, true, &ConfigError{
+			Operation: "load",
+			Path:      path,
+			Err:       err,
+		}
+	}
+
+	if err := m.validator.ValidateProject(config); err != nil {
+		return nil, true, &ConfigError{
+			Operation: "validate",
+			Path:      path,
+			Err:       err,
+		}
+	}
+	warnDuplicateRules(config, path)
+
+	return &domain.ConfigResult{
+		Config:   config,
+		Location: location,
+		Path:     path,
+	}, true, nil
+}
+
 // SaveConfig saves project configuration with atomic writes and validation.
 func (m *Manager) SaveConfig(
 	config *domain.Project,
@@ -284,6 +324,9 @@ func (m *Manager) SaveConfig(
 	}
 
 	configPath := domain.GetConfigPath(basePath, location)
+	if path := m.configFile; path != "" {
+		configPath = path
+	}
 
 	// Clean configuration to remove defaults
 	cleanConfig := m.cleaner.CleanProject(config)
@@ -329,6 +372,11 @@ func (m *Manager) InitConfig(
 		return nil, contextureerrors.Wrap(err, "save initial config")
 	}
 
+	// Keep personal overrides out of version control
+	if err := m.ensureGitignored(basePath, domain.LocalConfigFile); err != nil {
+		log.Warn("Failed to add local overrides to .gitignore", "error", err)
+	}
+
 	return config, nil
 }
 
@@ -397,6 +445,21 @@ func (m *Manager) HasRule(config *domain.Project, ruleID string) bool {
 	return false
 }
 
+// FindRule returns the configured rule reference matching ruleID, or nil if there is none.
+// The returned pointer refers to the entry in config.Rules, so changes are kept on save.
+func (m *Manager) FindRule(config *domain.Project, ruleID string) *domain.RuleRef {
+	if config == nil || strings.TrimSpace(ruleID) == "" {
+		return nil
+	}
+
+	for i := range config.Rules {
+		if m.matcher.MatchRule(ruleID, config.Rules[i].ID) {
+			return &config.Rules[i]
+		}
+	}
+	return nil
+}
+
 // GetConfigLocation determines the best location for configuration with smart defaults.
 func (m *Manager) GetConfigLocation(basePath string, preferContexture bool) domain.ConfigLocation {
 	if preferContexture {
@@ -413,15 +476,13 @@ func (m *Manager) GetConfigLocation(basePath string, preferContexture bool) doma
 	return domain.ConfigLocationRoot
 }
 
-// DiscoverLocalRules discovers all local rules in the project's rules directory
-func (m *Manager) DiscoverLocalRules(configResult *domain.ConfigResult) ([]domain.RuleRef, error) {
+// LocalRulesDir returns the directory holding local rules for a configuration
+func (m *Manager) LocalRulesDir(configResult *domain.ConfigResult) (string, error) {
 	if configResult == nil {
-		return nil, contextureerrors.ValidationErrorf("configResult", "cannot be nil")
+		return "", contextureerrors.ValidationErrorf("configResult", "cannot be nil")
 	}
 
-	// Determine rules directory based on config location
 	var rulesDir string
-
 	switch configResult.Location {
 	case domain.ConfigLocationRoot:
 		// If config is in project root, rules directory is "rules/"
@@ -435,11 +496,25 @@ func (m *Manager) DiscoverLocalRules(configResult *domain.ConfigResult) ([]domai
 		contextureDir := filepath.Dir(configResult.Path)
 		rulesDir = filepath.Join(contextureDir, domain.LocalRulesDir)
 	case domain.ConfigLocationGlobal:
-		// If config is global (~/.contexture/), rules directory is "~/.contexture/rules/"
+		// If config is global (~/.config/contexture/), rules directory is "~/.config/contexture/rules/"
+		// Named profiles in profiles/ share the rules directory of the global directory
 		globalDir := filepath.Dir(configResult.Path)
+		if filepath.Base(globalDir) == profilesDirName {
+			globalDir = filepath.Dir(globalDir)
+		}
 		rulesDir = filepath.Join(globalDir, domain.LocalRulesDir)
 	default:
-		return nil, contextureerrors.ValidationErrorf("configResult.Location", "unknown location: %s", configResult.Location)
+		return "", contextureerrors.ValidationErrorf("configResult.Location", "unknown location: %s", configResult.Location)
+	}
+
+	return rulesDir, nil
+}
+
+// DiscoverLocalRules discovers all local rules in the project's rules directory
+func (m *Manager) DiscoverLocalRules(configResult *domain.ConfigResult) ([]domain.RuleRef, error) {
+	rulesDir, err := m.LocalRulesDir(configResult)
+	if err != nil {
+		return nil, err
 	}
 
 	// Check if rules directory exists
@@ -453,24 +528,41 @@ func (m *Manager) DiscoverLocalRules(configResult *domain.ConfigResult) ([]domai
 		return nil, nil // No local rules directory, return empty slice
 	}
 
-	// Discover all .md files in the rules directory
+	ignore, err := globs.LoadIgnore(m.repo.GetFilesystem(), filepath.Join(rulesDir, domain.IgnoreFile))
+	if err != nil {
+		return nil, err
+	}
+
+	// Discover all .md files in the rules directory that are not ignored
 	var localRules []domain.RuleRef
 	err = afero.Walk(m.repo.GetFilesystem(), rulesDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
 
-		// Skip directories and non-markdown files
-		if info.IsDir() || !strings.HasSuffix(info.Name(), domain.MarkdownExt) {
-			return nil
-		}
-
 		// Get relative path from rules directory
 		relPath, err := filepath.Rel(rulesDir, path)
 		if err != nil {
 			return contextureerrors.Wrap(err, "get relative path")
 		}
 
+		if info.IsDir() {
+			if path != rulesDir && ignore.Ignored(filepath.ToSlash(relPath), true) {
+				log.Debug("Skipping ignored directory", "path", path)
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		// Skip non-markdown and ignored files
+		if !strings.HasSuffix(info.Name(), domain.MarkdownExt) {
+			return nil
+		}
+		if ignore.Ignored(filepath.ToSlash(relPath), false) {
+			log.Debug("Skipping ignored rule file", "path", path)
+			return nil
+		}
+
 		// Remove .md extension to get rule ID
 		ruleID := strings.TrimSuffix(relPath, domain.MarkdownExt)
 
@@ -498,7 +590,8 @@ func (m *Manager) DiscoverLocalRules(configResult *domain.ConfigResult) ([]domai
 	return localRules, nil
 }
 
-// LoadConfigWithLocalRules loads project configuration and automatically includes local rules
+// LoadConfigWithLocalRules loads project configuration, merges any extended
+// base configuration beneath it, and automatically includes local rules
 func (m *Manager) LoadConfigWithLocalRules(basePath string) (*domain.ConfigResult, error) {
 	// Load the base configuration
 	configResult, err := m.LoadConfig(basePath)
@@ -506,6 +599,10 @@ func (m *Manager) LoadConfigWithLocalRules(basePath string) (*domain.ConfigResul
 		return nil, err
 	}
 
+	if err := m.applyExtends(configResult); err != nil {
+		return nil, err
+	}
+
 	// Discover local rules
 	localRules, err := m.DiscoverLocalRules(configResult)
 	if err != nil {
@@ -540,11 +637,18 @@ func (r *DefaultConfigRepository) Load(path string) (*domain.Project, error) {
 	if err != nil {
 		return nil, contextureerrors.Wrap(err, "read config file")
 	}
+	return parseConfig(data, path)
+}
 
+// parseConfig parses the project configuration data read from path
+func parseConfig(data []byte, path string) (*domain.Project, error) {
 	var config domain.Project
 	if err := yaml.Unmarshal(data, &config); err != nil {
 		return nil, contextureerrors.Wrap(err, "parse config file")
 	}
+	if err := checkUnknownKeys(data, &config, path, StrictConfig() || config.StrictConfig); err != nil {
+		return nil, err
+	}
 
 	// Apply default values
 	if config.Version == 0 {
@@ -669,20 +773,15 @@ func (v *DefaultConfigValidator) ValidateRuleRef(ref domain.RuleRef) error {
 
 // Implementation of DefaultHomeDirectoryProvider
 
-// GetHomeDir returns the user's home directory
+// GetHomeDir returns the user's home directory, as found in the environment
 func (p *DefaultHomeDirectoryProvider) GetHomeDir() (string, error) {
-	// For now, we'll need to use os.UserHomeDir but this is abstracted
-	// so it can be easily mocked or replaced in tests
-	homeDir, err := getUserHomeDir()
+	homeDir, err := dependencies.HomeDir(p.env)
 	if err != nil {
 		return "", contextureerrors.Wrap(err, "get user home directory")
 	}
 	return homeDir, nil
 }
 
-// getUserHomeDir is a wrapper around os.UserHomeDir for easier testing
-var getUserHomeDir = os.UserHomeDir
-
 // Implementation of ConfigCleaner
 
 // CleanProject removes default values from project configuration before saving.
@@ -694,14 +793,16 @@ func (c *ConfigCleaner) CleanProject(config *domain.Project) *domain.Project {
 	// Create a copy to avoid modifying the original
 	cleanConfig := &domain.Project{
 		Version: config.Version,
-		Rules:   make([]domain.RuleRef, 0, len(config.Rules)), // Use 0 length, capacity for filtering
-		Formats: make([]domain.FormatConfig, len(config.Formats)),
+		Extends: config.Extends,
+		Rules:   make([]domain.RuleRef, 0, len(config.Rules)),        // Use 0 length, capacity for filtering
+		Formats: make([]domain.FormatConfig, 0, len(config.Formats)), // Inherited formats are filtered
 	}
 
-	// Clean rules - exclude local rules (they should not be saved to config)
+	// Clean rules - exclude local and inherited rules (they should not be saved to config)
 	for _, rule := range config.Rules {
 		// Skip local rules - they are auto-discovered and should not be persisted
-		if rule.Source == "local" {
+		// Skip inherited rules - they belong to the extended base configuration
+		if rule.Source == "local" || rule.Inherited {
 			continue
 		}
 		cleanRule := domain.RuleRef{
@@ -728,11 +829,23 @@ func (c *ConfigCleaner) CleanProject(config *domain.Project) *domain.Project {
 			cleanRule.CommitHash = rule.CommitHash
 		}
 
+		// Keep the build condition so the rule stays conditional
+		cleanRule.When = rule.When
+		cleanRule.Priority = rule.Priority
+		cleanRule.Section = rule.Section
+		cleanRule.Experiment = rule.Experiment
+		cleanRule.Pinned = rule.Pinned
+		cleanRule.Pending = rule.Pending
+		cleanRule.Approval = rule.Approval
+
 		cleanConfig.Rules = append(cleanConfig.Rules, cleanRule)
 	}
 
 	// Clean formats
-	for i, format := range config.Formats {
+	for _, format := range config.Formats {
+		if format.Inherited {
+			continue
+		}
 		cleanFormat := domain.FormatConfig{
 			Type:    format.Type,
 			Enabled: format.Enabled, // Always include enabled for clarity
@@ -748,13 +861,36 @@ func (c *ConfigCleaner) CleanProject(config *domain.Project) *domain.Project {
 		if format.UserRulesMode != "" {
 			cleanFormat.UserRulesMode = format.UserRulesMode
 		}
+		cleanFormat.Include = format.Include
+		cleanFormat.Exclude = format.Exclude
+		cleanFormat.Settings = format.Settings
+		cleanFormat.GroupBy = format.GroupBy
+		cleanFormat.MergeBelow = format.MergeBelow
+		cleanFormat.SplitAbove = format.SplitAbove
+		cleanFormat.PlainProse = format.PlainProse
+		cleanFormat.Wrap = format.Wrap
+		cleanFormat.Assets = format.Assets
 
-		cleanConfig.Formats[i] = cleanFormat
+		cleanConfig.Formats = append(cleanConfig.Formats, cleanFormat)
 	}
 
 	// Clean optional fields
 	cleanConfig.Providers = c.cleanProviders(config.Providers)
 	cleanConfig.Generation = c.cleanGenerationConfig(config.Generation)
+	cleanConfig.Sync = config.Sync
+	cleanConfig.MCPServers = config.MCPServers
+	cleanConfig.Aliases = config.Aliases
+	cleanConfig.UserRulesPaths = config.UserRulesPaths
+	cleanConfig.Favorites = config.Favorites
+	cleanConfig.StrictConfig = config.StrictConfig
+	cleanConfig.Presets = config.Presets
+	cleanConfig.Experiments = config.Experiments
+	cleanConfig.Ownership = config.Ownership
+	cleanConfig.Sensitive = config.Sensitive
+	cleanConfig.Approval = config.Approval
+	if len(config.Filters) > 0 {
+		cleanConfig.Filters = config.Filters
+	}
 
 	return cleanConfig
 }
@@ -767,9 +903,13 @@ func (c *ConfigCleaner) cleanProviders(providers []domain.Provider) []domain.Pro
 
 	var cleanProviders []domain.Provider
 	for _, provider := range providers {
+		if provider.Inherited {
+			continue
+		}
 		cleanProvider := domain.Provider{
-			Name: provider.Name,
-			URL:  provider.URL,
+			Name:    provider.Name,
+			URL:     provider.URL,
+			Mirrors: provider.Mirrors,
 		}
 
 		// Only include defaultBranch if it's not the default
@@ -822,13 +962,50 @@ func (c *ConfigCleaner) cleanGenerationConfig(
 		hasNonDefaults = true
 	}
 
+	if config.CloneTimeout != "" || config.PullTimeout != "" || config.CommandTimeout != "" {
+		cleanGen.CloneTimeout = config.CloneTimeout
+		cleanGen.PullTimeout = config.PullTimeout
+		cleanGen.CommandTimeout = config.CommandTimeout
+		hasNonDefaults = true
+	}
+
+	if config.Model != "" {
+		cleanGen.Model = config.Model
+		hasNonDefaults = true
+	}
+
+	if config.Summarize != nil {
+		cleanGen.Summarize = config.Summarize
+		hasNonDefaults = true
+	}
+
+	if config.Redact != nil {
+		cleanGen.Redact = config.Redact
+		hasNonDefaults = true
+	}
+
+	if config.Safety != nil {
+		cleanGen.Safety = config.Safety
+		hasNonDefaults = true
+	}
+
+	if config.RefreshEnvRule {
+		cleanGen.RefreshEnvRule = true
+		hasNonDefaults = true
+	}
+
+	if config.RefreshGitRule {
+		cleanGen.RefreshGitRule = true
+		hasNonDefaults = true
+	}
+
 	if hasNonDefaults {
 		return cleanGen
 	}
 	return nil
 }
 
-// LoadGlobalConfig loads the global configuration from ~/.contexture
+// LoadGlobalConfig loads the global configuration from the global directory
 func (m *Manager) LoadGlobalConfig() (*domain.ConfigResult, error) {
 	globalPath, err := m.getGlobalConfigPath()
 	if err != nil {
@@ -871,6 +1048,7 @@ func (m *Manager) LoadGlobalConfig() (*domain.ConfigResult, error) {
 			Err:       err,
 		}
 	}
+	warnDuplicateRules(config, globalPath)
 
 	return &domain.ConfigResult{
 		Config:   config,
@@ -892,7 +1070,7 @@ func (m *Manager) LoadGlobalConfigWithLocalRules() (*domain.ConfigResult, error)
 		return configResult, nil
 	}
 
-	// Discover local rules in ~/.contexture/rules/
+	// Discover local rules in the global rules directory
 	localRules, err := m.DiscoverLocalRules(configResult)
 	if err != nil {
 		return nil, contextureerrors.Wrap(err, "discover global local rules")
@@ -934,7 +1112,7 @@ func (m *Manager) SaveGlobalConfig(config *domain.Project) error {
 	}
 
 	// Ensure global directory exists
-	globalDir, err := m.getGlobalConfigDir()
+	globalDir, err := m.GlobalConfigDir()
 	if err != nil {
 		return contextureerrors.Wrap(err, "get global config dir")
 	}
@@ -977,8 +1155,13 @@ func (m *Manager) InitializeGlobalConfig() error {
 		return nil
 	}
 
-	// Create default global config
-	defaultConfig := &domain.Project{
+	return m.SaveGlobalConfig(DefaultGlobalConfig())
+}
+
+// DefaultGlobalConfig returns the global configuration created when none
+// exists: every format enabled and no rules
+func DefaultGlobalConfig() *domain.Project {
+	return &domain.Project{
 		Version: 1,
 		Formats: []domain.FormatConfig{
 			{Type: domain.FormatClaude, Enabled: true},
@@ -987,8 +1170,6 @@ func (m *Manager) InitializeGlobalConfig() error {
 		},
 		Rules: []domain.RuleRef{},
 	}
-
-	return m.SaveGlobalConfig(defaultConfig)
 }
 
 // LoadConfigMerged loads both global and project configs and merges them
@@ -1011,7 +1192,8 @@ func (m *Manager) LoadConfigMerged(basePath string) (*domain.MergedConfig, error
 	return merged, nil
 }
 
-// LoadConfigMergedWithLocalRules loads both global and project configs, merges them, and includes local rules
+// LoadConfigMergedWithLocalRules loads both global and project configs, merges them, and includes
+// local rules and the personal overrides in .contexture.local.yaml
 func (m *Manager) LoadConfigMergedWithLocalRules(basePath string) (*domain.MergedConfig, error) {
 	// Load global config with local rules (optional)
 	globalResult, err := m.LoadGlobalConfigWithLocalRules()
@@ -1025,6 +1207,11 @@ func (m *Manager) LoadConfigMergedWithLocalRules(basePath string) (*domain.Merge
 		return nil, contextureerrors.Wrap(err, "load project config")
 	}
 
+	// Apply personal overrides from .contexture.local.yaml
+	if err := m.applyLocalOverrides(projectResult); err != nil {
+		return nil, contextureerrors.Wrap(err, "load local overrides")
+	}
+
 	// Merge configurations
 	merged := m.MergeConfigs(globalResult, projectResult)
 
@@ -1139,22 +1326,23 @@ func (m *Manager) normalizeRuleID(ruleID string) string {
 	return strings.ToLower(path)
 }
 
-// getGlobalConfigDir returns the global contexture directory using the homeProvider
-func (m *Manager) getGlobalConfigDir() (string, error) {
+// GlobalConfigDir returns the global contexture directory, migrating a
+// legacy ~/.contexture directory to the XDG location when needed
+func (m *Manager) GlobalConfigDir() (string, error) {
 	homeDir, err := m.homeProvider.GetHomeDir()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(homeDir, ".contexture"), nil
+	return resolveGlobalConfigDir(m.repo.GetFilesystem(), homeDir, m.env), nil
 }
 
-// getGlobalConfigPath returns the global configuration file path using the homeProvider
+// getGlobalConfigPath returns the configuration file of the active global profile
 func (m *Manager) getGlobalConfigPath() (string, error) {
-	dir, err := m.getGlobalConfigDir()
+	profile, err := m.ActiveProfile()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(dir, domain.GetConfigFileName()), nil
+	return m.ProfilePath(profile)
 }
 
 // FailsafeConfigValidator methods - all return errors due to initialization failure
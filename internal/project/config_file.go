@@ -0,0 +1,47 @@
+package project
+
+import (
+	"path/filepath"
+
+	"github.com/contextureai/contexture/internal/domain"
+)
+
+// SetConfigFile makes the manager read the project configuration from and
+// save it to path instead of searching the project directory. An empty path
+// restores the search.
+func (m *Manager) SetConfigFile(path string) {
+	m.configFile = path
+	m.configData = nil
+}
+
+// SetConfigData makes the manager read the project configuration from data,
+// the verified content of path, and save it to path. Reading the file again
+// could pick up a copy changed after it was verified.
+func (m *Manager) SetConfigData(path string, data []byte) {
+	m.configFile = path
+	m.configData = data
+}
+
+// ConfigFile returns the configuration file set with SetConfigFile, or an
+// empty string when none is set
+func (m *Manager) ConfigFile() string {
+	return m.configFile
+}
+
+// ConfigFileLocation returns the location of a configuration file: the
+// .contexture directory when it is inside one, and the project root otherwise
+func ConfigFileLocation(path string) domain.ConfigLocation {
+	if filepath.Base(filepath.Dir(path)) == domain.ContextureDir {
+		return domain.ConfigLocationContexture
+	}
+	return domain.ConfigLocationRoot
+}
+
+// ConfigFileProjectDir returns the project directory of a configuration file
+func ConfigFileProjectDir(path string) string {
+	dir := filepath.Dir(path)
+	if ConfigFileLocation(path) == domain.ConfigLocationContexture {
+		return filepath.Dir(dir)
+	}
+	return dir
+}
@@ -0,0 +1,66 @@
+package project
+
+import (
+	"context"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigFileLocation(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, domain.ConfigLocationContexture, ConfigFileLocation("/project/.contexture/.contexture.yaml"))
+	assert.Equal(t, "/project", ConfigFileProjectDir("/project/.contexture/.contexture.yaml"))
+	assert.Equal(t, domain.ConfigLocationRoot, ConfigFileLocation("/project/ci.yaml"))
+	assert.Equal(t, "/project", ConfigFileProjectDir("/project/ci.yaml"))
+}
+
+func TestManager_ConfigFileOverride(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	manager := NewManager(fs)
+
+	// A configuration in the project directory is ignored once a file is set
+	_, err := manager.InitConfig("/project", []domain.FormatType{domain.FormatClaude}, domain.ConfigLocationRoot)
+	require.NoError(t, err)
+	manager.SetConfigFile("/configs/ci.yaml")
+
+	_, err = manager.LoadConfig("/project")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "/configs/ci.yaml")
+
+	config := &domain.Project{
+		Version: 1,
+		Formats: []domain.FormatConfig{{Type: domain.FormatCursor, Enabled: true}},
+	}
+	require.NoError(t, manager.SaveConfig(config, domain.ConfigLocationRoot, "/project"))
+
+	result, err := manager.LoadConfig("/project")
+	require.NoError(t, err)
+	assert.Equal(t, "/configs/ci.yaml", result.Path)
+	assert.Equal(t, domain.ConfigLocationRoot, result.Location)
+	require.Len(t, result.Config.Formats, 1)
+	assert.Equal(t, domain.FormatCursor, result.Config.Formats[0].Type)
+
+	manager.SetConfigFile("")
+	result, err = manager.LoadConfig("/project")
+	require.NoError(t, err)
+	assert.Equal(t, domain.GetConfigPath("/project", domain.ConfigLocationRoot), result.Path)
+}
+
+func TestNewManagerFromDependencies(t *testing.T) {
+	t.Parallel()
+	deps := dependencies.NewForTesting(context.Background()).
+		WithEnv(dependencies.MapEnvironment{"HOME": "/home/user", HomeEnv: "/opt/contexture"}).
+		WithConfigFile("/configs/ci.yaml")
+	manager := NewManagerFromDependencies(deps)
+
+	assert.Equal(t, "/configs/ci.yaml", manager.ConfigFile())
+	dir, err := manager.GlobalConfigDir()
+	require.NoError(t, err)
+	assert.Equal(t, "/opt/contexture", dir)
+}
@@ -285,6 +285,29 @@ func TestManager_HasRule(t *testing.T) {
 	assert.False(t, manager.HasRule(config, "nonexistent/rule"))
 }
 
+func TestManager_FindRule(t *testing.T) {
+	t.Parallel()
+	manager := NewManager(afero.NewMemMapFs())
+
+	config := &domain.Project{
+		Rules: []domain.RuleRef{
+			{ID: "[contexture:test/rule1]"},
+			{ID: "[contexture:test/rule2]"},
+		},
+	}
+
+	ref := manager.FindRule(config, "test/rule2")
+	require.NotNil(t, ref)
+	assert.Equal(t, "[contexture:test/rule2]", ref.ID)
+
+	// The reference points into the config so edits are saved
+	ref.Variables = map[string]any{"strict": true}
+	assert.Equal(t, map[string]any{"strict": true}, config.Rules[1].Variables)
+
+	assert.Nil(t, manager.FindRule(config, "test/nonexistent"))
+	assert.Nil(t, manager.FindRule(nil, "test/rule1"))
+}
+
 func TestManager_GetConfigLocation(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -368,6 +391,26 @@ func TestManager_SaveConfig(t *testing.T) {
 	assert.True(t, exists)
 }
 
+func TestManager_SaveConfig_FormatRulePatterns(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	manager := NewManager(fs)
+
+	config := &domain.Project{
+		Version: 1,
+		Formats: []domain.FormatConfig{
+			{Type: domain.FormatClaude, Enabled: true},
+			{Type: domain.FormatCursor, Enabled: true, Exclude: []string{"architecture/**"}},
+		},
+	}
+	require.NoError(t, manager.SaveConfig(config, domain.ConfigLocationRoot, "/work"))
+
+	loaded, err := manager.LoadConfig("/work")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"architecture/**"}, loaded.Config.GetFormatByType(domain.FormatCursor).Exclude)
+	assert.Empty(t, loaded.Config.GetFormatByType(domain.FormatClaude).Exclude)
+}
+
 func TestManager_DiscoverLocalRules(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -424,6 +467,20 @@ func TestManager_DiscoverLocalRules(t *testing.T) {
 			},
 			expectedRules: []string{"auth", "cache", "strict"},
 		},
+		{
+			name:           "ignored files and directories",
+			configLocation: domain.ConfigLocationRoot,
+			setupFiles: map[string]string{
+				"rules/.contextureignore":        "# scratch work\nscratch/\n!keep.draft.md\n",
+				"rules/auth.md":                  "# Auth Rule\nTest auth rule",
+				"rules/cache.draft.md":           "# Cache Draft\nNot ready",
+				"rules/keep.draft.md":            "# Kept Draft\nRe-included",
+				"rules/drafts/idea.md":           "# Idea\nNot ready",
+				"rules/scratch/notes.md":         "# Notes\nScratch",
+				"rules/node_modules/pkg/rule.md": "# Vendored\nNot a rule",
+			},
+			expectedRules: []string{"auth", "keep.draft"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,190 @@
+package project
+
+import (
+	"maps"
+	"path"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/contextureai/contexture/internal/domain"
+)
+
+// RuleChange is a change made to the rules of a configuration by NormalizeRules
+type RuleChange struct {
+	// From is the rule ID as it was written
+	From string
+	// To is the ID the rule was renamed to, or the ID of the rule a removed
+	// duplicate repeats
+	To string
+	// Removed is set when the rule repeated an earlier one and was dropped
+	Removed bool
+}
+
+// CanonicalRuleID returns ruleID in the form contexture rules add stores:
+// simple paths in the [contexture:path] form, without surrounding spaces or
+// stray slashes. IDs that cannot be parsed are returned trimmed.
+func CanonicalRuleID(ruleID string) string {
+	ruleID = strings.TrimSpace(ruleID)
+	switch {
+	case strings.HasPrefix(ruleID, "@"):
+		providerName, rulePath, _ := strings.Cut(strings.TrimPrefix(ruleID, "@"), "/")
+		return "@" + providerName + "/" + cleanRulePath(rulePath)
+	case strings.HasPrefix(ruleID, "[contexture"):
+		matches := domain.RuleIDParsePatternRegex.FindStringSubmatch(ruleID)
+		if matches == nil || matches[4] != "" {
+			return ruleID
+		}
+		prefix := "[contexture"
+		if matches[1] != "" {
+			prefix += "(" + matches[1] + ")"
+		}
+		canonical := prefix + ":" + cleanRulePath(matches[2])
+		if ref := strings.TrimSpace(matches[3]); ref != "" {
+			canonical += "," + ref
+		}
+		return canonical + "]"
+	case ruleID == "":
+		return ruleID
+	default:
+		return "[contexture:" + cleanRulePath(ruleID) + "]"
+	}
+}
+
+// cleanRulePath removes spaces, leading and trailing slashes, and repeated
+// slashes from a rule path
+func cleanRulePath(rulePath string) string {
+	rulePath = strings.Trim(strings.TrimSpace(rulePath), "/")
+	if rulePath == "" {
+		return rulePath
+	}
+	return path.Clean(rulePath)
+}
+
+// ruleKey identifies the rule a reference points to, ignoring how its ID is
+// spelled: the form of the ID, case, stray slashes, and defaults written out
+func ruleKey(ref domain.RuleRef) string {
+	id := CanonicalRuleID(ref.ID)
+	source, rulePath, branch := ref.Source, "", ref.Ref
+	if strings.HasPrefix(id, "@") {
+		var providerName string
+		providerName, rulePath, _ = strings.Cut(strings.TrimPrefix(id, "@"), "/")
+		if providerName != domain.DefaultProviderName {
+			source = "@" + providerName
+		}
+	} else if matches := domain.RuleIDParsePatternRegex.FindStringSubmatch(id); matches != nil {
+		rulePath = matches[2]
+		if matches[1] != "" {
+			source = matches[1]
+		}
+		if matches[3] != "" {
+			branch = matches[3]
+		}
+	} else {
+		rulePath = id
+	}
+
+	if source == domain.DefaultSource || source == domain.DefaultRepository {
+		source = ""
+	}
+	if branch == domain.DefaultBranch {
+		branch = ""
+	}
+	return strings.ToLower(source + "|" + rulePath + "|" + branch)
+}
+
+// DuplicateRules returns the groups of rules that point to the same rule
+// under different spellings, such as languages/go/testing and
+// [contexture:languages/go/testing/]. Local and inherited rules are skipped.
+func DuplicateRules(rules []domain.RuleRef) [][]domain.RuleRef {
+	groups := map[string][]domain.RuleRef{}
+	var order []string
+	for _, rule := range rules {
+		if rule.Source == "local" || rule.Inherited {
+			continue
+		}
+		key := ruleKey(rule)
+		if _, seen := groups[key]; !seen {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], rule)
+	}
+
+	var duplicates [][]domain.RuleRef
+	for _, key := range order {
+		if len(groups[key]) > 1 {
+			duplicates = append(duplicates, groups[key])
+		}
+	}
+	return duplicates
+}
+
+// FindEquivalentRule returns the configured rule that points to the same rule
+// as ruleID in another spelling, or nil
+func (m *Manager) FindEquivalentRule(config *domain.Project, ruleID string) *domain.RuleRef {
+	if config == nil || strings.TrimSpace(ruleID) == "" {
+		return nil
+	}
+	key := ruleKey(domain.RuleRef{ID: ruleID})
+	for i := range config.Rules {
+		if config.Rules[i].Source != "local" && ruleKey(config.Rules[i]) == key {
+			return &config.Rules[i]
+		}
+	}
+	return nil
+}
+
+// NormalizeRules rewrites the rule IDs of config in canonical form and drops
+// rules repeating an earlier one under another spelling. Variables set only
+// on a dropped duplicate are kept on the rule that remains. Local and
+// inherited rules are left as they are.
+func NormalizeRules(config *domain.Project) []RuleChange {
+	var changes []RuleChange
+	kept := make(map[string]int, len(config.Rules))
+	rules := make([]domain.RuleRef, 0, len(config.Rules))
+	for _, rule := range config.Rules {
+		if rule.Source == "local" || rule.Inherited {
+			rules = append(rules, rule)
+			continue
+		}
+
+		key := ruleKey(rule)
+		if i, seen := kept[key]; seen {
+			first := &rules[i]
+			for name, value := range rule.Variables {
+				if _, set := first.Variables[name]; !set {
+					if first.Variables == nil {
+						first.Variables = make(map[string]any, len(rule.Variables))
+					}
+					first.Variables[name] = value
+				}
+			}
+			changes = append(changes, RuleChange{From: rule.ID, To: first.ID, Removed: true})
+			continue
+		}
+
+		if canonical := CanonicalRuleID(rule.ID); canonical != rule.ID {
+			changes = append(changes, RuleChange{From: rule.ID, To: canonical})
+			rule.ID = canonical
+		}
+		rule.Variables = maps.Clone(rule.Variables)
+		kept[key] = len(rules)
+		rules = append(rules, rule)
+	}
+	config.Rules = rules
+	return changes
+}
+
+// warnDuplicateRules logs the rules of a configuration file written more than
+// once under different spellings
+func warnDuplicateRules(config *domain.Project, path string) {
+	for _, group := range DuplicateRules(config.Rules) {
+		spellings := make([]string, len(group))
+		for i, rule := range group {
+			spellings[i] = rule.ID
+		}
+		log.Warn("Rule configured more than once; run 'contexture config normalize' to merge the entries",
+			"rule", domain.ExtractRulePath(CanonicalRuleID(group[0].ID)),
+			"spellings", strings.Join(spellings, ", "),
+			"file", path)
+	}
+}
@@ -0,0 +1,79 @@
+package project
+
+import (
+	"testing"
+
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalRuleID(t *testing.T) {
+	t.Parallel()
+	tests := map[string]string{
+		"languages/go/testing":                           "[contexture:languages/go/testing]",
+		" /languages//go/testing/ ":                      "[contexture:languages/go/testing]",
+		"[contexture:languages/go/testing/]":             "[contexture:languages/go/testing]",
+		"[contexture(local):architecture/layers]":        "[contexture(local):architecture/layers]",
+		"[contexture(https://x.dev/r.git):go/errors,v2]": "[contexture(https://x.dev/r.git):go/errors,v2]",
+		"@acme/security/auth/":                           "@acme/security/auth",
+		"":                                               "",
+	}
+	for id, want := range tests {
+		assert.Equal(t, want, CanonicalRuleID(id), id)
+	}
+}
+
+func TestDuplicateRules(t *testing.T) {
+	t.Parallel()
+	rules := []domain.RuleRef{
+		{ID: "[contexture:languages/go/testing]"},
+		{ID: "languages/go/testing/"},
+		{ID: "[contexture:Languages/Go/Testing]"},
+		{ID: "@contexture/languages/go/testing"},
+		{ID: "[contexture:languages/go/testing,v2]"},
+		{ID: "@acme/languages/go/testing"},
+		{ID: "[contexture(local):languages/go/testing]", Source: "local"},
+		{ID: "security/auth"},
+	}
+
+	groups := DuplicateRules(rules)
+	require.Len(t, groups, 1)
+	assert.Equal(t, rules[:4], groups[0], "other refs, providers, and local rules are different rules")
+}
+
+func TestNormalizeRules(t *testing.T) {
+	t.Parallel()
+	config := &domain.Project{Rules: []domain.RuleRef{
+		{ID: "languages/go/testing", Variables: map[string]any{"strict": true}},
+		{ID: "[contexture:security/auth]"},
+		{ID: "[contexture:languages/go/testing/]", Variables: map[string]any{"strict": false, "timeout": "5s"}},
+		{ID: "[contexture:org/base]", Inherited: true},
+	}}
+
+	changes := NormalizeRules(config)
+	assert.Equal(t, []RuleChange{
+		{From: "languages/go/testing", To: "[contexture:languages/go/testing]"},
+		{From: "[contexture:languages/go/testing/]", To: "[contexture:languages/go/testing]", Removed: true},
+	}, changes)
+
+	require.Len(t, config.Rules, 3)
+	assert.Equal(t, "[contexture:languages/go/testing]", config.Rules[0].ID)
+	assert.Equal(t, map[string]any{"strict": true, "timeout": "5s"}, config.Rules[0].Variables,
+		"the first entry wins, and variables only set on the duplicate are kept")
+	assert.Equal(t, "[contexture:security/auth]", config.Rules[1].ID)
+	assert.Equal(t, "[contexture:org/base]", config.Rules[2].ID)
+
+	assert.Empty(t, NormalizeRules(config), "normalizing twice changes nothing")
+}
+
+func TestManager_FindEquivalentRule(t *testing.T) {
+	t.Parallel()
+	manager := NewManager(nil)
+	config := &domain.Project{Rules: []domain.RuleRef{{ID: "languages/go/testing"}}}
+
+	found := manager.FindEquivalentRule(config, "[contexture:Languages/go/testing]")
+	require.NotNil(t, found)
+	assert.Equal(t, "languages/go/testing", found.ID)
+	assert.Nil(t, manager.FindEquivalentRule(config, "[contexture:languages/go/style]"))
+}
@@ -0,0 +1,173 @@
+package project
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+)
+
+// Layer names the configuration an effective value comes from
+type Layer string
+
+const (
+	// LayerGlobal is the global configuration and its local rules
+	LayerGlobal Layer = "global"
+	// LayerExtends is a base configuration named by extends
+	LayerExtends Layer = "extends"
+	// LayerProject is the project configuration
+	LayerProject Layer = "project"
+	// LayerLocalRules is the local rules directory of the project
+	LayerLocalRules Layer = "local rules"
+	// LayerLocalOverrides is the uncommitted .contexture.local.yaml
+	LayerLocalOverrides Layer = "local overrides"
+)
+
+// EffectiveFormat is an output format of the effective configuration
+type EffectiveFormat struct {
+	Format domain.FormatConfig
+	Layer  Layer
+}
+
+// EffectiveProvider is a provider of the effective configuration
+type EffectiveProvider struct {
+	Provider domain.Provider
+	Layer    Layer
+}
+
+// EffectiveRule is a rule of the effective configuration
+type EffectiveRule struct {
+	Rule  domain.RuleRef
+	Layer Layer
+	// Overrides is the layer whose rule of the same path this one replaces
+	Overrides Layer
+	// LocalVariables lists the variables .contexture.local.yaml sets on a rule
+	// declared by another layer
+	LocalVariables []string
+}
+
+// EffectiveConfig is the configuration used for generation, with the layer
+// each format, provider, and rule comes from
+type EffectiveConfig struct {
+	Config    *domain.Project
+	Formats   []EffectiveFormat
+	Providers []EffectiveProvider
+	Rules     []EffectiveRule
+	// Disabled lists the rules .contexture.local.yaml disables
+	Disabled []EffectiveRule
+	// Files maps each layer present to the file it was read from
+	Files map[Layer]string
+}
+
+// LoadEffectiveConfig merges the global configuration, the project
+// configuration with its base configurations and local rules, and the local
+// overrides the way generation does, recording where each value comes from
+func (m *Manager) LoadEffectiveConfig(basePath string) (*EffectiveConfig, error) {
+	globalResult, err := m.LoadGlobalConfigWithLocalRules()
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "load global config with local rules")
+	}
+	projectResult, err := m.LoadConfigWithLocalRules(basePath)
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "load project config")
+	}
+	declared := projectResult.Config.Rules
+
+	overrides, err := m.LoadLocalOverrides(projectResult)
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "load local overrides")
+	}
+	if err := m.applyLocalOverrides(projectResult); err != nil {
+		return nil, contextureerrors.Wrap(err, "load local overrides")
+	}
+	merged := m.MergeConfigs(globalResult, projectResult)
+
+	effective := &EffectiveConfig{
+		Config: projectResult.Config,
+		Files:  map[Layer]string{LayerProject: projectResult.Path},
+	}
+	if globalResult != nil && globalResult.Config != nil {
+		effective.Files[LayerGlobal] = globalResult.Path
+	}
+	if projectResult.Config.Extends != "" {
+		effective.Files[LayerExtends] = projectResult.Config.Extends
+	}
+	if overrides != nil {
+		effective.Files[LayerLocalOverrides] = m.LocalOverridesPath(projectResult)
+	}
+
+	for _, format := range projectResult.Config.Formats {
+		layer := LayerProject
+		if format.Inherited {
+			layer = LayerExtends
+		}
+		effective.Formats = append(effective.Formats, EffectiveFormat{Format: format, Layer: layer})
+	}
+	for _, p := range projectResult.Config.Providers {
+		layer := LayerProject
+		if p.Inherited {
+			layer = LayerExtends
+		}
+		effective.Providers = append(effective.Providers, EffectiveProvider{Provider: p, Layer: layer})
+	}
+
+	declaredRules := make(map[string]domain.RuleRef, len(declared))
+	for _, rule := range declared {
+		declaredRules[m.normalizeRuleID(rule.ID)] = rule
+	}
+	var localVariables map[string][]string
+	if overrides != nil {
+		localVariables = make(map[string][]string, len(overrides.Rules))
+		for _, rule := range overrides.Rules {
+			keys := make([]string, 0, len(rule.Variables))
+			for key := range rule.Variables {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			localVariables[m.normalizeRuleID(rule.ID)] = keys
+		}
+	}
+
+	for _, rws := range merged.MergedRules {
+		key := m.normalizeRuleID(rws.RuleRef.ID)
+		rule := EffectiveRule{Rule: rws.RuleRef, Layer: LayerGlobal}
+		if rws.Source == domain.RuleSourceProject {
+			declaredRule, isDeclared := declaredRules[key]
+			rule.Layer = declaredLayer(declaredRule, isDeclared)
+			if isDeclared {
+				rule.LocalVariables = localVariables[key]
+			}
+		}
+		if rws.OverridesGlobal {
+			rule.Overrides = LayerGlobal
+		}
+		effective.Rules = append(effective.Rules, rule)
+	}
+
+	if overrides != nil {
+		for _, rule := range declared {
+			key := m.normalizeRuleID(rule.ID)
+			if slices.ContainsFunc(overrides.Disable, func(id string) bool { return m.normalizeRuleID(id) == key }) {
+				effective.Disabled = append(effective.Disabled, EffectiveRule{Rule: rule, Layer: declaredLayer(rule, true)})
+			}
+		}
+	}
+
+	return effective, nil
+}
+
+// declaredLayer returns the layer of a project rule, which is only added by
+// the local overrides when the project configuration does not declare it
+func declaredLayer(rule domain.RuleRef, declared bool) Layer {
+	switch {
+	case !declared:
+		return LayerLocalOverrides
+	case rule.Source == "local":
+		return LayerLocalRules
+	case rule.Inherited:
+		return LayerExtends
+	default:
+		return LayerProject
+	}
+}
@@ -0,0 +1,78 @@
+package project
+
+import (
+	"testing"
+
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_LoadEffectiveConfig(t *testing.T) {
+	t.Setenv(XDGConfigHomeEnv, "")
+	t.Setenv(HomeEnv, "")
+	fs := afero.NewMemMapFs()
+	writeConfig(t, fs, testGlobalDir+"/.contexture.yaml", &domain.Project{
+		Version: 1,
+		Rules: []domain.RuleRef{
+			{ID: "[contexture:go/errors]"},
+			{ID: "[contexture:personal/terse]"},
+		},
+	})
+	writeConfig(t, fs, "/org/.contexture.yaml", &domain.Project{
+		Version: 1,
+		Formats: []domain.FormatConfig{{Type: domain.FormatCursor, Enabled: true}},
+		Rules:   []domain.RuleRef{{ID: "[contexture:security/secrets]"}},
+	})
+	writeConfig(t, fs, "/org/app/.contexture.yaml", &domain.Project{
+		Version: 1,
+		Extends: "../.contexture.yaml",
+		Formats: []domain.FormatConfig{{Type: domain.FormatClaude, Enabled: true}},
+		Rules: []domain.RuleRef{
+			{ID: "[contexture:go/errors]", Variables: map[string]any{"wrap": true}},
+			{ID: "[contexture:go/testing]"},
+		},
+	})
+	require.NoError(t, afero.WriteFile(fs, "/org/app/"+domain.LocalConfigFile, []byte(`rules:
+  - id: "[contexture:go/errors]"
+    variables:
+      strict: true
+  - id: "[contexture:personal/verbose]"
+disable:
+  - "[contexture:go/testing]"
+`), 0o644))
+
+	effective, err := newTestManagerWithHome(fs, testHomeDir).LoadEffectiveConfig("/org/app")
+	require.NoError(t, err)
+
+	assert.Equal(t, map[Layer]string{
+		LayerGlobal:         testGlobalDir + "/.contexture.yaml",
+		LayerExtends:        "../.contexture.yaml",
+		LayerProject:        "/org/app/.contexture.yaml",
+		LayerLocalOverrides: "/org/app/" + domain.LocalConfigFile,
+	}, effective.Files)
+
+	require.Len(t, effective.Formats, 2)
+	assert.Equal(t, LayerProject, effective.Formats[0].Layer)
+	assert.Equal(t, LayerExtends, effective.Formats[1].Layer)
+
+	layers := map[string]EffectiveRule{}
+	for _, rule := range effective.Rules {
+		layers[rule.Rule.ID] = rule
+	}
+	require.Len(t, layers, 4)
+	assert.Equal(t, LayerGlobal, layers["[contexture:personal/terse]"].Layer)
+	assert.Equal(t, LayerExtends, layers["[contexture:security/secrets]"].Layer)
+	assert.Equal(t, LayerLocalOverrides, layers["[contexture:personal/verbose]"].Layer)
+
+	errors := layers["[contexture:go/errors]"]
+	assert.Equal(t, LayerProject, errors.Layer)
+	assert.Equal(t, LayerGlobal, errors.Overrides)
+	assert.Equal(t, []string{"strict"}, errors.LocalVariables)
+	assert.Equal(t, map[string]any{"strict": true, "wrap": true}, errors.Rule.Variables)
+
+	require.Len(t, effective.Disabled, 1)
+	assert.Equal(t, "[contexture:go/testing]", effective.Disabled[0].Rule.ID)
+	assert.Equal(t, LayerProject, effective.Disabled[0].Layer)
+}
@@ -0,0 +1,217 @@
+package project
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/contextureai/contexture/internal/cache"
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/git"
+	"github.com/contextureai/contexture/internal/provider"
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+)
+
+// maxExtendsDepth limits how many base configurations can be chained with extends
+const maxExtendsDepth = 5
+
+// RemoteConfigFetcher reads base configurations referenced by extends from provider repositories
+type RemoteConfigFetcher interface {
+	FetchConfig(ctx context.Context, repoURL, ref, path string) ([]byte, error)
+}
+
+// DefaultRemoteConfigFetcher reads base configurations from the repository cache
+type DefaultRemoteConfigFetcher struct {
+	fs    afero.Fs
+	cache *cache.SimpleCache
+}
+
+// NewRemoteConfigFetcher creates a fetcher that clones provider repositories
+// into the cache directory of env
+func NewRemoteConfigFetcher(fs afero.Fs, env dependencies.Environment) *DefaultRemoteConfigFetcher {
+	return &DefaultRemoteConfigFetcher{
+		fs:    fs,
+		cache: cache.NewSimpleCache(fs, env, git.NewRepository(fs)),
+	}
+}
+
+// FetchConfig reads the file at path in the repository at repoURL and ref
+func (f *DefaultRemoteConfigFetcher) FetchConfig(ctx context.Context, repoURL, ref, path string) ([]byte, error) {
+	repoDir, err := f.cache.GetRepository(ctx, repoURL, ref)
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "fetch base config repository")
+	}
+
+	data, err := afero.ReadFile(f.fs, filepath.Join(repoDir, filepath.FromSlash(path)))
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "read base config")
+	}
+	return data, nil
+}
+
+// applyExtends merges the base configurations named by extends beneath the
+// loaded configuration. Inherited formats, providers, and rules are marked so
+// they are never written back to the local configuration file.
+func (m *Manager) applyExtends(configResult *domain.ConfigResult) error {
+	config := configResult.Config
+	if config.Extends == "" {
+		return nil
+	}
+
+	merged := *config
+	merged.Formats = append([]domain.FormatConfig(nil), config.Formats...)
+	merged.Providers = append([]domain.Provider(nil), config.Providers...)
+	merged.Rules = append([]domain.RuleRef(nil), config.Rules...)
+
+	visited := map[string]bool{configResult.Path: true}
+	current, currentPath := config, configResult.Path
+	for depth := 0; current.Extends != ""; depth++ {
+		if depth == maxExtendsDepth {
+			return contextureerrors.ValidationErrorf("extends", "more than %d base configurations in %s", maxExtendsDepth, configResult.Path)
+		}
+
+		base, basePath, err := m.loadReferencedConfig(current.Extends, currentPath, &merged)
+		if err != nil {
+			return &ConfigError{Operation: "extend", Path: currentPath, Err: err}
+		}
+		if visited[basePath] {
+			return &ConfigError{
+				Operation: "extend",
+				Path:      currentPath,
+				Err:       contextureerrors.ValidationErrorf("extends", "cycle through %s", basePath),
+			}
+		}
+		visited[basePath] = true
+
+		m.mergeBase(&merged, base)
+		log.Debug("Merged base configuration", "base", basePath, "rules", len(base.Rules))
+		current, currentPath = base, basePath
+	}
+
+	configResult.Config = &merged
+	return nil
+}
+
+// LoadReferencedConfig loads the configuration that reference points at, as
+// used by extends: a path relative to the configuration or @provider/path.
+func (m *Manager) LoadReferencedConfig(reference string, configResult *domain.ConfigResult) (*domain.Project, error) {
+	config, _, err := m.loadReferencedConfig(reference, configResult.Path, configResult.Config)
+	return config, err
+}
+
+// loadReferencedConfig loads the configuration named by extends. Relative
+// paths are resolved from the directory of configPath; @provider/path
+// references are read from the repository of a provider defined in providers.
+func (m *Manager) loadReferencedConfig(
+	extends string,
+	configPath string,
+	providers *domain.Project,
+) (*domain.Project, string, error) {
+	var base domain.Project
+	var basePath string
+	if strings.HasPrefix(extends, "@") {
+		providerName, path, ok := strings.Cut(strings.TrimPrefix(extends, "@"), "/")
+		if !ok || path == "" {
+			return nil, "", contextureerrors.ValidationErrorf("extends", "%q must be @provider/path/to/config.yaml", extends)
+		}
+
+		repoURL, ref, err := providerRepository(providerName, providers)
+		if err != nil {
+			return nil, "", err
+		}
+
+		data, err := m.remote.FetchConfig(context.Background(), repoURL, ref, path)
+		if err != nil {
+			return nil, "", err
+		}
+		if err := yaml.Unmarshal(data, &base); err != nil {
+			return nil, "", contextureerrors.Wrap(err, "parse base config "+extends)
+		}
+		if err := checkUnknownKeys(data, &base, extends, StrictConfig() || base.StrictConfig); err != nil {
+			return nil, "", err
+		}
+		basePath = extends
+
+		// Relative extends inside a remote base cannot be resolved from disk
+		if base.Extends != "" && !strings.HasPrefix(base.Extends, "@") {
+			return nil, "", contextureerrors.ValidationErrorf("extends",
+				"%s extends %q, but configurations from providers can only extend @provider paths", extends, base.Extends)
+		}
+	} else {
+		basePath = extends
+		if !filepath.IsAbs(basePath) {
+			basePath = filepath.Join(filepath.Dir(configPath), basePath)
+		}
+		if isDir, _ := afero.IsDir(m.repo.GetFilesystem(), basePath); isDir {
+			basePath = filepath.Join(basePath, domain.ConfigFile)
+		}
+
+		loaded, err := m.repo.Load(basePath)
+		if err != nil {
+			return nil, "", err
+		}
+		base = *loaded
+	}
+
+	if err := m.validator.ValidateProject(&base); err != nil {
+		return nil, "", contextureerrors.Wrap(err, "validate base config "+extends)
+	}
+	return &base, basePath, nil
+}
+
+// providerRepository returns the repository URL and branch of the provider
+// name, as defined by the providers of config or built in
+func providerRepository(name string, config *domain.Project) (string, string, error) {
+	registry := provider.NewRegistry()
+	if err := registry.LoadFromProject(config); err != nil {
+		return "", "", err
+	}
+	p, err := registry.Get(name)
+	if err != nil {
+		return "", "", err
+	}
+	ref := p.DefaultBranch
+	if ref == "" {
+		ref = domain.DefaultBranch
+	}
+	return p.URL, ref, nil
+}
+
+// mergeBase adds the formats, providers, and rules of base that merged does
+// not already define. Local entries take precedence over inherited ones.
+func (m *Manager) mergeBase(merged, base *domain.Project) {
+	for _, format := range base.Formats {
+		if merged.HasFormat(format.Type) {
+			continue
+		}
+		format.Inherited = true
+		merged.Formats = append(merged.Formats, format)
+	}
+
+	for _, p := range base.Providers {
+		if merged.GetProviderByName(p.Name) != nil {
+			continue
+		}
+		p.Inherited = true
+		merged.Providers = append(merged.Providers, p)
+	}
+
+	defined := make(map[string]bool, len(merged.Rules))
+	for _, rule := range merged.Rules {
+		defined[m.normalizeRuleID(rule.ID)] = true
+	}
+	var inherited []domain.RuleRef
+	for _, rule := range base.Rules {
+		if defined[m.normalizeRuleID(rule.ID)] {
+			continue
+		}
+		rule.Inherited = true
+		inherited = append(inherited, rule)
+	}
+	// Base rules come first so local rules follow them in generated output
+	merged.Rules = append(inherited, merged.Rules...)
+}
@@ -0,0 +1,131 @@
+package project
+
+import (
+	"context"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// fakeRemoteConfigFetcher serves base configurations keyed by path
+type fakeRemoteConfigFetcher struct {
+	configs map[string]string
+	repoURL string
+}
+
+func (f *fakeRemoteConfigFetcher) FetchConfig(_ context.Context, repoURL, _, path string) ([]byte, error) {
+	f.repoURL = repoURL
+	data, ok := f.configs[path]
+	if !ok {
+		return nil, afero.ErrFileNotFound
+	}
+	return []byte(data), nil
+}
+
+func writeConfig(t *testing.T, fs afero.Fs, path string, config *domain.Project) {
+	t.Helper()
+	data, err := yaml.Marshal(config)
+	require.NoError(t, err)
+	require.NoError(t, afero.WriteFile(fs, path, data, 0o644))
+}
+
+func TestManager_Extends(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	writeConfig(t, fs, "/org/.contexture.yaml", &domain.Project{
+		Version: 1,
+		Formats: []domain.FormatConfig{
+			{Type: domain.FormatClaude, Enabled: true},
+			{Type: domain.FormatCursor, Enabled: true},
+		},
+		Providers: []domain.Provider{{Name: "acme", URL: "https://github.com/acme/rules.git"}},
+		Rules: []domain.RuleRef{
+			{ID: "[contexture:security/secrets]"},
+			{ID: "[contexture:go/errors]"},
+		},
+	})
+	writeConfig(t, fs, "/work/app/.contexture.yaml", &domain.Project{
+		Version: 1,
+		Extends: "../../org",
+		Formats: []domain.FormatConfig{{Type: domain.FormatClaude, Enabled: true}},
+		Rules: []domain.RuleRef{
+			{ID: "[contexture:go/errors]", Variables: map[string]any{"strict": true}},
+			{ID: "[contexture:go/testing]"},
+		},
+	})
+
+	manager := NewManager(fs)
+	result, err := manager.LoadConfigWithLocalRules("/work/app")
+	require.NoError(t, err)
+	config := result.Config
+
+	require.Len(t, config.Formats, 2)
+	assert.Equal(t, domain.FormatClaude, config.Formats[0].Type)
+	assert.False(t, config.Formats[0].Inherited, "local format wins")
+	assert.True(t, config.Formats[1].Inherited)
+
+	require.Len(t, config.Providers, 1)
+	assert.True(t, config.Providers[0].Inherited)
+
+	require.Len(t, config.Rules, 3)
+	assert.Equal(t, "[contexture:security/secrets]", config.Rules[0].ID)
+	assert.True(t, config.Rules[0].Inherited)
+	assert.Equal(t, "[contexture:go/errors]", config.Rules[1].ID)
+	assert.Equal(t, true, config.Rules[1].Variables["strict"], "local rule wins")
+	assert.False(t, config.Rules[1].Inherited)
+
+	// Saving must not copy inherited entries into the local file
+	require.NoError(t, manager.SaveConfig(config, result.Location, "/work/app"))
+	saved, err := manager.LoadConfig("/work/app")
+	require.NoError(t, err)
+	assert.Equal(t, "../../org", saved.Config.Extends)
+	assert.Len(t, saved.Config.Formats, 1)
+	assert.Empty(t, saved.Config.Providers)
+	assert.Len(t, saved.Config.Rules, 2)
+}
+
+func TestManager_ExtendsCycle(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	writeConfig(t, fs, "/work/a/.contexture.yaml", &domain.Project{
+		Version: 1,
+		Extends: "../b",
+		Formats: []domain.FormatConfig{{Type: domain.FormatClaude, Enabled: true}},
+	})
+	writeConfig(t, fs, "/work/b/.contexture.yaml", &domain.Project{
+		Version: 1,
+		Extends: "../a",
+		Formats: []domain.FormatConfig{{Type: domain.FormatClaude, Enabled: true}},
+	})
+
+	_, err := NewManager(fs).LoadConfigWithLocalRules("/work/a")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cycle")
+}
+
+func TestManager_ExtendsProvider(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	writeConfig(t, fs, "/work/app/.contexture.yaml", &domain.Project{
+		Version:   1,
+		Extends:   "@acme/configs/base.yaml",
+		Formats:   []domain.FormatConfig{{Type: domain.FormatClaude, Enabled: true}},
+		Providers: []domain.Provider{{Name: "acme", URL: "https://github.com/acme/rules.git"}},
+	})
+
+	remote := &fakeRemoteConfigFetcher{configs: map[string]string{
+		"configs/base.yaml": "version: 1\nformats:\n  - type: claude\n    enabled: true\nrules:\n  - id: \"[contexture:security/secrets]\"\n",
+	}}
+	manager := NewManager(fs)
+	manager.remote = remote
+
+	result, err := manager.LoadConfigWithLocalRules("/work/app")
+	require.NoError(t, err)
+	assert.Equal(t, "https://github.com/acme/rules.git", remote.repoURL)
+	require.Len(t, result.Config.Rules, 1)
+	assert.True(t, result.Config.Rules[0].Inherited)
+}
@@ -13,6 +13,7 @@ import (
 
 const (
 	testHomeDir     = "/home/testuser"
+	testGlobalDir   = "/home/testuser/.config/contexture"
 	testProjectDir  = "/project"
 	testLocalSource = "local"
 )
@@ -116,6 +117,8 @@ rules:
 
 // TestManager_SaveGlobalConfig tests saving global configuration
 func TestManager_SaveGlobalConfig(t *testing.T) {
+	t.Setenv(XDGConfigHomeEnv, "")
+
 	t.Run("creates directory and saves config", func(t *testing.T) {
 		fs := afero.NewMemMapFs()
 		manager := newTestManagerWithHome(fs, testHomeDir)
@@ -134,12 +137,11 @@ func TestManager_SaveGlobalConfig(t *testing.T) {
 		require.NoError(t, err)
 
 		// Verify directory was created
-		globalDir := filepath.Join(testHomeDir, ".contexture")
-		exists, _ := afero.DirExists(fs, globalDir)
+		exists, _ := afero.DirExists(fs, testGlobalDir)
 		assert.True(t, exists)
 
 		// Verify file was created
-		configPath := filepath.Join(testHomeDir, ".contexture", ".contexture.yaml")
+		configPath := filepath.Join(testGlobalDir, ".contexture.yaml")
 		exists, _ = afero.Exists(fs, configPath)
 		assert.True(t, exists)
 
@@ -199,6 +201,8 @@ func TestManager_SaveGlobalConfig(t *testing.T) {
 
 // TestManager_InitializeGlobalConfig tests initializing global configuration
 func TestManager_InitializeGlobalConfig(t *testing.T) {
+	t.Setenv(XDGConfigHomeEnv, "")
+
 	t.Run("creates directory structure", func(t *testing.T) {
 		fs := afero.NewMemMapFs()
 		manager := newTestManagerWithHome(fs, testHomeDir)
@@ -207,12 +211,11 @@ func TestManager_InitializeGlobalConfig(t *testing.T) {
 		require.NoError(t, err)
 
 		// Verify directory exists
-		globalDir := filepath.Join(testHomeDir, ".contexture")
-		exists, _ := afero.DirExists(fs, globalDir)
+		exists, _ := afero.DirExists(fs, testGlobalDir)
 		assert.True(t, exists)
 
 		// Verify config file exists with minimal content
-		configPath := filepath.Join(globalDir, ".contexture.yaml")
+		configPath := filepath.Join(testGlobalDir, ".contexture.yaml")
 		exists, _ = afero.Exists(fs, configPath)
 		assert.True(t, exists)
 
@@ -0,0 +1,144 @@
+package project
+
+import (
+	"maps"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+)
+
+// LocalOverridesPath returns the path of the personal overrides file for a configuration
+func (m *Manager) LocalOverridesPath(configResult *domain.ConfigResult) string {
+	return filepath.Join(filepath.Dir(configResult.Path), domain.LocalConfigFile)
+}
+
+// LoadLocalOverrides reads the personal overrides next to the configuration.
+// It returns nil when the file does not exist.
+func (m *Manager) LoadLocalOverrides(configResult *domain.ConfigResult) (*domain.LocalOverrides, error) {
+	fs := m.repo.GetFilesystem()
+	path := m.LocalOverridesPath(configResult)
+
+	data, err := afero.ReadFile(fs, path)
+	if err != nil {
+		if exists, _ := afero.Exists(fs, path); !exists {
+			return nil, nil
+		}
+		return nil, &ConfigError{Operation: "read", Path: path, Err: err}
+	}
+
+	var overrides domain.LocalOverrides
+	if err := yaml.Unmarshal(data, &overrides); err != nil {
+		return nil, &ConfigError{Operation: "parse", Path: path, Err: err}
+	}
+	if err := checkUnknownKeys(data, &overrides, path, StrictConfig()); err != nil {
+		return nil, err
+	}
+	for _, ref := range overrides.Rules {
+		if strings.TrimSpace(ref.ID) == "" {
+			return nil, &ConfigError{
+				Operation: "validate",
+				Path:      path,
+				Err:       contextureerrors.ValidationErrorf("rules", "rule id cannot be empty"),
+			}
+		}
+	}
+
+	return &overrides, nil
+}
+
+// applyLocalOverrides merges the personal overrides file on top of the
+// project configuration. The result is only used for generation and is never
+// saved, so personal changes do not leak into the shared configuration.
+func (m *Manager) applyLocalOverrides(configResult *domain.ConfigResult) error {
+	overrides, err := m.LoadLocalOverrides(configResult)
+	if err != nil || overrides == nil {
+		return err
+	}
+
+	disabled := make(map[string]bool, len(overrides.Disable))
+	for _, id := range overrides.Disable {
+		disabled[m.normalizeRuleID(id)] = true
+	}
+
+	config := *configResult.Config
+	config.Rules = make([]domain.RuleRef, 0, len(configResult.Config.Rules)+len(overrides.Rules))
+	index := make(map[string]int, len(configResult.Config.Rules))
+	for _, ref := range configResult.Config.Rules {
+		key := m.normalizeRuleID(ref.ID)
+		if disabled[key] {
+			log.Debug("Rule disabled by local overrides", "rule", ref.ID)
+			continue
+		}
+		index[key] = len(config.Rules)
+		config.Rules = append(config.Rules, ref)
+	}
+
+	for _, ref := range overrides.Rules {
+		key := m.normalizeRuleID(ref.ID)
+		if disabled[key] {
+			continue
+		}
+		i, exists := index[key]
+		if !exists {
+			index[key] = len(config.Rules)
+			config.Rules = append(config.Rules, ref)
+			continue
+		}
+
+		// Personal variables win over the shared ones
+		existing := &config.Rules[i]
+		variables := make(map[string]any, len(existing.Variables)+len(ref.Variables))
+		maps.Copy(variables, existing.Variables)
+		maps.Copy(variables, ref.Variables)
+		existing.Variables = variables
+		if ref.Priority != nil {
+			existing.Priority = ref.Priority
+		}
+		if ref.Section != "" {
+			existing.Section = ref.Section
+		}
+	}
+
+	log.Debug("Applied local overrides",
+		"path", m.LocalOverridesPath(configResult),
+		"rules", len(overrides.Rules),
+		"disabled", len(overrides.Disable))
+	configResult.Config = &config
+	return nil
+}
+
+// ensureGitignored adds pattern to the .gitignore in dir when dir is a git
+// working tree or already has a .gitignore
+func (m *Manager) ensureGitignored(dir, pattern string) error {
+	fs := m.repo.GetFilesystem()
+	path := filepath.Join(dir, ".gitignore")
+
+	data, err := afero.ReadFile(fs, path)
+	if err != nil {
+		if isRepo, _ := afero.Exists(fs, filepath.Join(dir, ".git")); !isRepo {
+			return nil
+		}
+		data = nil
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.TrimSpace(line) == pattern {
+			return nil
+		}
+	}
+
+	content := string(data)
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	content += pattern + "\n"
+	if err := afero.WriteFile(fs, path, []byte(content), 0o644); err != nil {
+		return contextureerrors.Wrap(err, "update .gitignore")
+	}
+	return nil
+}
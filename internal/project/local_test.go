@@ -0,0 +1,80 @@
+package project
+
+import (
+	"testing"
+
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_LocalOverrides(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	writeConfig(t, fs, "/work/app/.contexture.yaml", &domain.Project{
+		Version: 1,
+		Formats: []domain.FormatConfig{{Type: domain.FormatClaude, Enabled: true}},
+		Rules: []domain.RuleRef{
+			{ID: "[contexture:go/errors]", Variables: map[string]any{"strict": false, "wrap": true}},
+			{ID: "[contexture:go/testing]"},
+		},
+	})
+	require.NoError(t, afero.WriteFile(fs, "/work/app/"+domain.LocalConfigFile, []byte(`rules:
+  - id: "[contexture:go/errors]"
+    variables:
+      strict: true
+  - id: "[contexture:personal/verbose]"
+disable:
+  - "[contexture:go/testing]"
+`), 0o644))
+
+	manager := NewManager(fs)
+	merged, err := manager.LoadConfigMergedWithLocalRules("/work/app")
+	require.NoError(t, err)
+
+	rules := merged.Project.Rules
+	require.Len(t, rules, 2)
+	assert.Equal(t, "[contexture:go/errors]", rules[0].ID)
+	assert.Equal(t, map[string]any{"strict": true, "wrap": true}, rules[0].Variables)
+	assert.Equal(t, "[contexture:personal/verbose]", rules[1].ID)
+
+	// The shared configuration is untouched
+	shared, err := manager.LoadConfigWithLocalRules("/work/app")
+	require.NoError(t, err)
+	require.Len(t, shared.Config.Rules, 2)
+	assert.Equal(t, false, shared.Config.Rules[0].Variables["strict"])
+}
+
+func TestManager_LoadLocalOverrides_Missing(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	manager := NewManager(fs)
+
+	overrides, err := manager.LoadLocalOverrides(&domain.ConfigResult{Path: "/work/app/.contexture.yaml"})
+	require.NoError(t, err)
+	assert.Nil(t, overrides)
+}
+
+func TestManager_InitConfig_Gitignore(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/work/app/.gitignore", []byte("node_modules/"), 0o644))
+	manager := NewManager(fs)
+
+	_, err := manager.InitConfig("/work/app", []domain.FormatType{domain.FormatClaude}, domain.ConfigLocationRoot)
+	require.NoError(t, err)
+	_, err = manager.InitConfig("/work/app", []domain.FormatType{domain.FormatClaude}, domain.ConfigLocationRoot)
+	require.NoError(t, err)
+
+	data, err := afero.ReadFile(fs, "/work/app/.gitignore")
+	require.NoError(t, err)
+	assert.Equal(t, "node_modules/\n"+domain.LocalConfigFile+"\n", string(data))
+
+	// Directories that are not git working trees are left alone
+	_, err = manager.InitConfig("/work/other", []domain.FormatType{domain.FormatClaude}, domain.ConfigLocationRoot)
+	require.NoError(t, err)
+	exists, err := afero.Exists(fs, "/work/other/.gitignore")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
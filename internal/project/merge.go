@@ -0,0 +1,291 @@
+package project
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// MergeConflict is a setting the two sides of a three-way merge changed
+// differently
+type MergeConflict struct {
+	// Path locates the setting, such as rules["[contexture:go/style]"].variables.coverage
+	Path string
+	// Ours and Theirs are the values of each side, nil when a side removed it
+	Ours   any
+	Theirs any
+}
+
+// MergeThreeWay merges the changes ours and theirs made to base. Lists of
+// rules, formats, providers, and filters are merged as sets keyed by ID,
+// type, or name, and lists of strings by value, so changes to different
+// entries never conflict; other settings merge field by field. Settings
+// both sides changed differently are returned as conflicts and keep the
+// value of ours.
+func MergeThreeWay(base, ours, theirs *domain.Project) (*domain.Project, []MergeConflict) {
+	if base == nil {
+		base = &domain.Project{}
+	}
+	m := &threeWayMerger{}
+	merged := m.merge("",
+		mergeSide{value: reflect.ValueOf(base).Elem(), set: true},
+		mergeSide{value: reflect.ValueOf(ours).Elem(), set: true},
+		mergeSide{value: reflect.ValueOf(theirs).Elem(), set: true},
+	)
+	project := merged.value.Interface().(domain.Project)
+	return &project, m.conflicts
+}
+
+// LoadConfigFile reads the configuration file at path, such as one of the
+// versions git passes to a merge driver
+func (m *Manager) LoadConfigFile(path string) (*domain.Project, error) {
+	config, err := m.repo.Load(path)
+	if err != nil {
+		return nil, &ConfigError{Operation: "load", Path: path, Err: err}
+	}
+	return config, nil
+}
+
+// MarshalConfig returns config as it is saved, without default values
+func (m *Manager) MarshalConfig(config *domain.Project) ([]byte, error) {
+	data, err := yaml.Marshal(m.cleaner.CleanProject(config))
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "marshal config")
+	}
+	return data, nil
+}
+
+// mergeSide is a side's value of a setting, unset when the side does not
+// have it
+type mergeSide struct {
+	value reflect.Value
+	set   bool
+}
+
+func (s mergeSide) equal(other mergeSide) bool {
+	if s.set != other.set {
+		return false
+	}
+	return !s.set || reflect.DeepEqual(s.value.Interface(), other.value.Interface())
+}
+
+func (s mergeSide) interfaceValue() any {
+	if !s.set {
+		return nil
+	}
+	return s.value.Interface()
+}
+
+// threeWayMerger collects the conflicts of a merge
+type threeWayMerger struct {
+	conflicts []MergeConflict
+}
+
+// merge returns the merge of a setting at path
+func (m *threeWayMerger) merge(path string, base, ours, theirs mergeSide) mergeSide {
+	switch {
+	case ours.equal(theirs), base.equal(theirs):
+		return ours
+	case base.equal(ours):
+		return theirs
+	}
+	if ours.set && theirs.set {
+		if merged, ok := m.mergeNested(path, base, ours, theirs); ok {
+			return mergeSide{value: merged, set: true}
+		}
+	}
+	m.conflicts = append(m.conflicts, MergeConflict{Path: path, Ours: ours.interfaceValue(), Theirs: theirs.interfaceValue()})
+	return ours
+}
+
+// mergeNested merges the parts of a setting both sides changed, reporting
+// false for values without parts, which conflict as a whole
+func (m *threeWayMerger) mergeNested(path string, base, ours, theirs mergeSide) (reflect.Value, bool) {
+	typ := ours.value.Type()
+	switch typ.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if ours.value.IsNil() || theirs.value.IsNil() {
+			return reflect.Value{}, false
+		}
+		if typ.Kind() == reflect.Interface && ours.value.Elem().Type() != theirs.value.Elem().Type() {
+			return reflect.Value{}, false
+		}
+		elemBase := mergeSide{}
+		if base.set && !base.value.IsNil() {
+			elemBase = mergeSide{value: base.value.Elem(), set: true}
+		}
+		merged := m.merge(path, elemBase,
+			mergeSide{value: ours.value.Elem(), set: true},
+			mergeSide{value: theirs.value.Elem(), set: true})
+		if typ.Kind() == reflect.Interface {
+			result := reflect.New(typ).Elem()
+			result.Set(merged.value)
+			return result, true
+		}
+		result := reflect.New(typ.Elem())
+		result.Elem().Set(merged.value)
+		return result, true
+
+	case reflect.Struct:
+		result := reflect.New(typ).Elem()
+		for i := range typ.NumField() {
+			name := yamlName(typ.Field(i))
+			if name == "" {
+				continue
+			}
+			field := func(side mergeSide) mergeSide {
+				if !side.set {
+					return mergeSide{}
+				}
+				return mergeSide{value: side.value.Field(i), set: true}
+			}
+			merged := m.merge(joinPath(path, name), field(base), field(ours), field(theirs))
+			if merged.set {
+				result.Field(i).Set(merged.value)
+			}
+		}
+		return result, true
+
+	case reflect.Map:
+		if typ.Key().Kind() != reflect.String {
+			return reflect.Value{}, false
+		}
+		result := reflect.MakeMap(typ)
+		keys := map[string]reflect.Value{}
+		for _, side := range []mergeSide{base, ours, theirs} {
+			if side.set {
+				for _, key := range side.value.MapKeys() {
+					keys[key.String()] = key
+				}
+			}
+		}
+		for _, name := range slices.Sorted(maps.Keys(keys)) {
+			key := keys[name]
+			entry := func(side mergeSide) mergeSide {
+				if !side.set {
+					return mergeSide{}
+				}
+				value := side.value.MapIndex(key)
+				return mergeSide{value: value, set: value.IsValid()}
+			}
+			merged := m.merge(path+"["+strconv.Quote(name)+"]", entry(base), entry(ours), entry(theirs))
+			if merged.set {
+				result.SetMapIndex(key, merged.value)
+			}
+		}
+		if result.Len() == 0 && ours.value.IsNil() {
+			return reflect.Zero(typ), true
+		}
+		return result, true
+
+	case reflect.Slice:
+		keyOf := sliceKey(typ.Elem())
+		if keyOf == nil {
+			return reflect.Value{}, false
+		}
+		return m.mergeKeyed(path, keyOf, base, ours, theirs), true
+	}
+	return reflect.Value{}, false
+}
+
+// mergeKeyed merges lists whose entries are identified by keyOf, keeping
+// the order of ours and adding the entries only theirs has after them
+func (m *threeWayMerger) mergeKeyed(path string, keyOf func(reflect.Value) string, base, ours, theirs mergeSide) reflect.Value {
+	index := func(side mergeSide) ([]string, map[string]reflect.Value) {
+		var order []string
+		entries := map[string]reflect.Value{}
+		if !side.set {
+			return order, entries
+		}
+		for i := range side.value.Len() {
+			entry := side.value.Index(i)
+			key := keyOf(entry)
+			// Repeated entries are told apart by their position
+			for n := 2; entries[key].IsValid(); n++ {
+				key = keyOf(entry) + "#" + strconv.Itoa(n)
+			}
+			order = append(order, key)
+			entries[key] = entry
+		}
+		return order, entries
+	}
+	_, baseEntries := index(base)
+	oursOrder, oursEntries := index(ours)
+	theirsOrder, theirsEntries := index(theirs)
+
+	// Entries ours removed are listed after ours, so changes theirs made
+	// to them are reported as conflicts
+	keys := oursOrder
+	for _, key := range theirsOrder {
+		if _, ok := oursEntries[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	entry := func(entries map[string]reflect.Value, key string) mergeSide {
+		value, ok := entries[key]
+		return mergeSide{value: value, set: ok}
+	}
+	result := reflect.MakeSlice(ours.value.Type(), 0, len(keys))
+	for _, key := range keys {
+		merged := m.merge(path+"["+strconv.Quote(key)+"]",
+			entry(baseEntries, key), entry(oursEntries, key), entry(theirsEntries, key))
+		if merged.set {
+			result = reflect.Append(result, merged.value)
+		}
+	}
+	if result.Len() == 0 && ours.value.IsNil() {
+		return reflect.Zero(ours.value.Type())
+	}
+	return result
+}
+
+// sliceKey returns the key identifying the entries of lists of elem: the
+// value of strings, and the id, type, or name field of structs. Other lists
+// are merged as a whole.
+func sliceKey(elem reflect.Type) func(reflect.Value) string {
+	if elem.Kind() == reflect.String {
+		return func(v reflect.Value) string { return v.String() }
+	}
+	if elem.Kind() != reflect.Struct {
+		return nil
+	}
+	for _, name := range []string{"id", "type", "name"} {
+		for i := range elem.NumField() {
+			field := elem.Field(i)
+			if yamlName(field) == name && field.Type.Kind() == reflect.String {
+				return func(v reflect.Value) string { return v.Field(i).String() }
+			}
+		}
+	}
+	return nil
+}
+
+// yamlName returns the YAML key of an exported field, or an empty string
+// for fields that are not read from configuration files
+func yamlName(field reflect.StructField) string {
+	if !field.IsExported() {
+		return ""
+	}
+	name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return strings.ToLower(field.Name)
+	}
+	return name
+}
+
+// joinPath appends the field name to path
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,125 @@
+package project
+
+import (
+	"testing"
+
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// parseProject parses a configuration for merge tests
+func parseProject(t *testing.T, content string) *domain.Project {
+	t.Helper()
+	var config domain.Project
+	require.NoError(t, yaml.Unmarshal([]byte(content), &config))
+	return &config
+}
+
+func TestMergeThreeWay(t *testing.T) {
+	t.Parallel()
+	base := parseProject(t, `
+formats:
+  - type: claude
+    enabled: true
+rules:
+  - id: "[contexture:go/style]"
+    variables:
+      lineLength: 100
+  - id: "[contexture:go/testing]"
+  - id: "[contexture:docs/readme]"
+presets: ["@acme/go"]
+`)
+
+	t.Run("merges changes to different entries", func(t *testing.T) {
+		t.Parallel()
+		ours := parseProject(t, `
+formats:
+  - type: claude
+    enabled: true
+  - type: cursor
+    enabled: true
+rules:
+  - id: "[contexture:go/style]"
+    variables:
+      lineLength: 120
+  - id: "[contexture:go/testing]"
+  - id: "[contexture:security/secrets]"
+presets: ["@acme/go"]
+`)
+		theirs := parseProject(t, `
+formats:
+  - type: claude
+    enabled: false
+rules:
+  - id: "[contexture:go/style]"
+    variables:
+      lineLength: 100
+      tabs: true
+  - id: "[contexture:docs/readme]"
+  - id: "[contexture:go/errors]"
+presets: ["@acme/go", "@acme/strict"]
+`)
+
+		merged, conflicts := MergeThreeWay(base, ours, theirs)
+		assert.Empty(t, conflicts)
+
+		var ids []string
+		for _, rule := range merged.Rules {
+			ids = append(ids, rule.ID)
+		}
+		assert.Equal(t, []string{"[contexture:go/style]", "[contexture:security/secrets]", "[contexture:go/errors]"}, ids)
+		assert.Equal(t, map[string]any{"lineLength": 120, "tabs": true}, merged.Rules[0].Variables)
+
+		require.Len(t, merged.Formats, 2)
+		assert.Equal(t, domain.FormatClaude, merged.Formats[0].Type)
+		assert.False(t, merged.Formats[0].Enabled)
+		assert.Equal(t, domain.FormatCursor, merged.Formats[1].Type)
+		assert.Equal(t, []string{"@acme/go", "@acme/strict"}, merged.Presets)
+	})
+
+	t.Run("reports settings both sides changed", func(t *testing.T) {
+		t.Parallel()
+		ours := parseProject(t, `
+formats:
+  - type: claude
+    enabled: true
+rules:
+  - id: "[contexture:go/style]"
+    variables:
+      lineLength: 120
+  - id: "[contexture:docs/readme]"
+`)
+		theirs := parseProject(t, `
+formats:
+  - type: claude
+    enabled: true
+rules:
+  - id: "[contexture:go/style]"
+    variables:
+      lineLength: 80
+  - id: "[contexture:go/testing]"
+    commitHash: abc123
+`)
+
+		merged, conflicts := MergeThreeWay(base, ours, theirs)
+		require.Len(t, conflicts, 2)
+		assert.Equal(t, `rules["[contexture:go/style]"].variables["lineLength"]`, conflicts[0].Path)
+		assert.Equal(t, 120, conflicts[0].Ours)
+		assert.Equal(t, 80, conflicts[0].Theirs)
+		assert.Equal(t, `rules["[contexture:go/testing]"]`, conflicts[1].Path)
+		assert.Nil(t, conflicts[1].Ours, "ours removed the rule theirs changed")
+		assert.Equal(t, 120, merged.Rules[0].Variables["lineLength"], "conflicts keep ours")
+	})
+
+	t.Run("merges files added on both sides", func(t *testing.T) {
+		t.Parallel()
+		ours := parseProject(t, "rules:\n  - id: \"[contexture:go/style]\"\n")
+		theirs := parseProject(t, "rules:\n  - id: \"[contexture:go/testing]\"\n")
+
+		merged, conflicts := MergeThreeWay(nil, ours, theirs)
+		assert.Empty(t, conflicts)
+		assert.Len(t, merged.Rules, 2)
+	})
+}
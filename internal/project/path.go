@@ -0,0 +1,354 @@
+package project
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// pathSegment is a step of a configuration path: a field or map key, a list
+// index, or a list filter selecting the item whose field has a value
+type pathSegment struct {
+	key         string
+	index       int
+	filterKey   string
+	filterValue string
+}
+
+// isIndex reports whether the segment selects a list item by position
+func (s pathSegment) isIndex() bool {
+	return s.index >= 0
+}
+
+// isFilter reports whether the segment selects a list item by field value
+func (s pathSegment) isFilter() bool {
+	return s.filterKey != ""
+}
+
+// parsePath splits a configuration path such as generation.parallelFetches,
+// formats[0].enabled, formats[type=cursor].enabled, or mcpServers["my.server"].url
+// into its segments. Names are the keys used in the configuration file.
+func parsePath(expr string) ([]pathSegment, error) {
+	invalid := func(reason string) error {
+		return contextureerrors.ValidationErrorf("path", "invalid path %q: %s", expr, reason)
+	}
+	if strings.TrimSpace(expr) == "" {
+		return nil, invalid("empty path")
+	}
+
+	var segments []pathSegment
+	rest := expr
+	for rest != "" {
+		switch {
+		case rest[0] == '[':
+			end := closingBracket(rest)
+			if end == -1 {
+				return nil, invalid("missing ]")
+			}
+			inner := strings.TrimSpace(rest[1:end])
+			rest = rest[end+1:]
+			segment, err := parseBracket(inner)
+			if err != nil {
+				return nil, invalid(err.Error())
+			}
+			segments = append(segments, segment)
+		case rest[0] == '.':
+			if len(segments) == 0 || rest == "." {
+				return nil, invalid("empty name")
+			}
+			rest = rest[1:]
+			if rest == "" || rest[0] == '.' || rest[0] == '[' {
+				return nil, invalid("empty name")
+			}
+		default:
+			end := strings.IndexAny(rest, ".[")
+			if end == -1 {
+				end = len(rest)
+			}
+			segments = append(segments, pathSegment{key: rest[:end], index: -1})
+			rest = rest[end:]
+		}
+	}
+	return segments, nil
+}
+
+// closingBracket returns the position of the ] closing the [ that starts s,
+// skipping quoted text, or -1
+func closingBracket(s string) int {
+	inQuotes := false
+	for i := 1; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && inQuotes:
+			i++
+		case s[i] == '"':
+			inQuotes = !inQuotes
+		case s[i] == ']' && !inQuotes:
+			return i
+		}
+	}
+	return -1
+}
+
+// parseBracket parses the inside of brackets: an index, a quoted key, or a
+// key=value filter
+func parseBracket(inner string) (pathSegment, error) {
+	if index, err := strconv.Atoi(inner); err == nil {
+		if index < 0 {
+			return pathSegment{}, fmt.Errorf("negative index %d", index)
+		}
+		return pathSegment{index: index}, nil
+	}
+	if unquoted, err := strconv.Unquote(inner); err == nil {
+		return pathSegment{key: unquoted, index: -1}, nil
+	}
+	if key, value, ok := strings.Cut(inner, "="); ok && strings.TrimSpace(key) != "" {
+		value = strings.TrimSpace(value)
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		}
+		return pathSegment{index: -1, filterKey: strings.TrimSpace(key), filterValue: value}, nil
+	}
+	return pathSegment{}, fmt.Errorf("expected an index, a quoted key, or key=value in [%s]", inner)
+}
+
+// GetPath returns the value at a configuration path, such as
+// generation.parallelFetches or formats[type=cursor].enabled
+func GetPath(config *domain.Project, expr string) (any, error) {
+	segments, err := parsePath(expr)
+	if err != nil {
+		return nil, err
+	}
+	value := reflect.ValueOf(config)
+	for i, segment := range segments {
+		value, err = descend(value, segment)
+		if err != nil {
+			return nil, contextureerrors.ValidationErrorf("path", "%s: %v", formatPath(segments[:i+1]), err)
+		}
+		if i < len(segments)-1 && value.Kind() == reflect.Pointer && value.IsNil() {
+			return nil, contextureerrors.ValidationErrorf("path", "%s: not set", formatPath(segments[:i+1]))
+		}
+	}
+	if !value.IsValid() {
+		return nil, nil
+	}
+	return value.Interface(), nil
+}
+
+// SetPath parses raw as YAML into the type of the value at a configuration
+// path and stores it, creating the sections on the way. Items merged from an
+// extended configuration along the path become local overrides.
+func SetPath(config *domain.Project, expr, raw string) error {
+	segments, err := parsePath(expr)
+	if err != nil {
+		return err
+	}
+	return setValue(reflect.ValueOf(config), segments, 0, raw)
+}
+
+// descend returns the value a segment selects in value, following pointers
+func descend(value reflect.Value, segment pathSegment) (reflect.Value, error) {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return reflect.Value{}, fmt.Errorf("not set")
+		}
+		value = value.Elem()
+	}
+
+	switch {
+	case segment.isIndex() || segment.isFilter():
+		if value.Kind() != reflect.Slice {
+			return reflect.Value{}, fmt.Errorf("not a list")
+		}
+		index, err := listIndex(value, segment)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return value.Index(index), nil
+	case value.Kind() == reflect.Struct:
+		field, ok := fieldByName(value, segment.key)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("unknown key %q", segment.key)
+		}
+		return field, nil
+	case value.Kind() == reflect.Map:
+		item := value.MapIndex(reflect.ValueOf(segment.key).Convert(value.Type().Key()))
+		if !item.IsValid() {
+			return reflect.Value{}, fmt.Errorf("not set")
+		}
+		return item, nil
+	default:
+		return reflect.Value{}, fmt.Errorf("%s has no keys", kindName(value.Type()))
+	}
+}
+
+// setValue stores raw at the segments from position i below value
+func setValue(value reflect.Value, segments []pathSegment, i int, raw string) error {
+	fail := func(err error) error {
+		return contextureerrors.ValidationErrorf("path", "%s: %v", formatPath(segments[:i+1]), err)
+	}
+
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			value.Set(reflect.New(value.Type().Elem()))
+		}
+		return setValue(value.Elem(), segments, i, raw)
+	}
+	if i == len(segments) {
+		return decodeValue(value, segments, raw)
+	}
+	markLocal(value)
+
+	segment := segments[i]
+	switch {
+	case segment.isIndex() || segment.isFilter():
+		if value.Kind() != reflect.Slice {
+			return fail(fmt.Errorf("not a list"))
+		}
+		index, err := listIndex(value, segment)
+		if err != nil {
+			return fail(err)
+		}
+		return setValue(value.Index(index), segments, i+1, raw)
+	case value.Kind() == reflect.Struct:
+		field, ok := fieldByName(value, segment.key)
+		if !ok {
+			return fail(fmt.Errorf("unknown key %q", segment.key))
+		}
+		return setValue(field, segments, i+1, raw)
+	case value.Kind() == reflect.Map:
+		if value.IsNil() {
+			value.Set(reflect.MakeMap(value.Type()))
+		}
+		// Map items are not addressable, so the item is changed in a copy
+		key := reflect.ValueOf(segment.key).Convert(value.Type().Key())
+		item := reflect.New(value.Type().Elem()).Elem()
+		if existing := value.MapIndex(key); existing.IsValid() {
+			item.Set(existing)
+		}
+		if err := setValue(item, segments, i+1, raw); err != nil {
+			return err
+		}
+		value.SetMapIndex(key, item)
+		return nil
+	default:
+		return fail(fmt.Errorf("%s has no keys", kindName(value.Type())))
+	}
+}
+
+// decodeValue parses raw as YAML into value, reporting the expected type when
+// it does not fit
+func decodeValue(value reflect.Value, segments []pathSegment, raw string) error {
+	decoded := reflect.New(value.Type())
+	if err := yaml.Unmarshal([]byte(raw), decoded.Interface()); err != nil {
+		return contextureerrors.ValidationErrorf("path", "%s: expected %s, got %q",
+			formatPath(segments), kindName(value.Type()), raw)
+	}
+	value.Set(decoded.Elem())
+	return nil
+}
+
+// markLocal turns an item merged from an extended configuration into a local
+// override, so the change is saved
+func markLocal(value reflect.Value) {
+	if value.Kind() != reflect.Struct {
+		return
+	}
+	if inherited := value.FieldByName("Inherited"); inherited.IsValid() && inherited.Kind() == reflect.Bool && inherited.CanSet() {
+		inherited.SetBool(false)
+	}
+}
+
+// listIndex returns the position of the list item a segment selects
+func listIndex(list reflect.Value, segment pathSegment) (int, error) {
+	if segment.isIndex() {
+		if segment.index >= list.Len() {
+			return 0, fmt.Errorf("index %d out of range, the list has %d items", segment.index, list.Len())
+		}
+		return segment.index, nil
+	}
+	for i := range list.Len() {
+		item := list.Index(i)
+		for item.Kind() == reflect.Pointer && !item.IsNil() {
+			item = item.Elem()
+		}
+		if item.Kind() != reflect.Struct {
+			return 0, fmt.Errorf("items cannot be selected by %s", segment.filterKey)
+		}
+		field, ok := fieldByName(item, segment.filterKey)
+		if ok && fmt.Sprint(field.Interface()) == segment.filterValue {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("no item with %s=%s", segment.filterKey, segment.filterValue)
+}
+
+// fieldByName returns the exported field of a struct whose YAML key is name
+func fieldByName(value reflect.Value, name string) (reflect.Value, bool) {
+	valueType := value.Type()
+	for i := range valueType.NumField() {
+		field := valueType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		key, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if key == "-" {
+			continue
+		}
+		if key == "" {
+			key = strings.ToLower(field.Name)
+		}
+		if key == name {
+			return value.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// kindName describes a type the way configuration values are written
+func kindName(valueType reflect.Type) string {
+	switch valueType.Kind() {
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.String:
+		return "a string"
+	case reflect.Slice, reflect.Array:
+		return "a list"
+	case reflect.Map, reflect.Struct:
+		return "a mapping"
+	case reflect.Pointer:
+		return kindName(valueType.Elem())
+	default:
+		return "a value"
+	}
+}
+
+// formatPath writes segments back as a path
+func formatPath(segments []pathSegment) string {
+	var b strings.Builder
+	for i, segment := range segments {
+		switch {
+		case segment.isIndex():
+			fmt.Fprintf(&b, "[%d]", segment.index)
+		case segment.isFilter():
+			fmt.Fprintf(&b, "[%s=%s]", segment.filterKey, segment.filterValue)
+		case strings.ContainsAny(segment.key, ".[]"):
+			fmt.Fprintf(&b, "[%q]", segment.key)
+		default:
+			if i > 0 {
+				b.WriteByte('.')
+			}
+			b.WriteString(segment.key)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,96 @@
+package project
+
+import (
+	"testing"
+
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pathTestConfig() *domain.Project {
+	return &domain.Project{
+		Version: 1,
+		Formats: []domain.FormatConfig{
+			{Type: domain.FormatClaude, Enabled: true},
+			{Type: domain.FormatCursor, Enabled: false, Inherited: true},
+		},
+		Rules: []domain.RuleRef{
+			{ID: "[contexture:go/style]", Variables: map[string]any{"max_line_length": 100}},
+		},
+		MCPServers: map[string]domain.MCPServer{
+			"my.server": {URL: "https://mcp.example.com"},
+		},
+	}
+}
+
+func TestGetPath(t *testing.T) {
+	t.Parallel()
+	config := pathTestConfig()
+
+	tests := []struct {
+		path string
+		want any
+	}{
+		{"version", 1},
+		{"formats[0].enabled", true},
+		{"formats[type=cursor].enabled", false},
+		{`rules[id="[contexture:go/style]"].variables.max_line_length`, 100},
+		{`mcpServers["my.server"].url`, "https://mcp.example.com"},
+		{"formats[1].include", []string(nil)},
+	}
+	for _, tt := range tests {
+		got, err := GetPath(config, tt.path)
+		require.NoError(t, err, tt.path)
+		assert.Equal(t, tt.want, got, tt.path)
+	}
+
+	errorTests := map[string]string{
+		"":                           "empty path",
+		"formats[":                   "missing ]",
+		"formats..enabled":           "empty name",
+		"unknown":                    `unknown key "unknown"`,
+		"formats[5]":                 "index 5 out of range",
+		"formats[type=windsurf]":     "no item with type=windsurf",
+		"generation.parallelFetches": "generation: not set",
+		"version.major":              "an integer has no keys",
+	}
+	for path, want := range errorTests {
+		_, err := GetPath(config, path)
+		require.Error(t, err, path)
+		assert.Contains(t, err.Error(), want, path)
+	}
+}
+
+func TestSetPath(t *testing.T) {
+	t.Parallel()
+	config := pathTestConfig()
+
+	require.NoError(t, SetPath(config, "formats[type=cursor].enabled", "true"))
+	assert.True(t, config.Formats[1].Enabled)
+	assert.False(t, config.Formats[1].Inherited, "changed items become local overrides")
+
+	require.NoError(t, SetPath(config, "generation.parallelFetches", "4"))
+	require.NotNil(t, config.Generation)
+	assert.Equal(t, 4, config.Generation.ParallelFetches)
+
+	require.NoError(t, SetPath(config, "formats[0].include", `["languages/go/**", "security/*"]`))
+	assert.Equal(t, []string{"languages/go/**", "security/*"}, config.Formats[0].Include)
+
+	require.NoError(t, SetPath(config, `mcpServers["my.server"].url`, "https://other.example.com"))
+	assert.Equal(t, "https://other.example.com", config.MCPServers["my.server"].URL)
+
+	require.NoError(t, SetPath(config, "aliases.up", "rules update"))
+	assert.Equal(t, map[string]string{"up": "rules update"}, config.Aliases)
+
+	require.NoError(t, SetPath(config, "rules[0].variables.max_line_length", "120"))
+	assert.Equal(t, 120, config.Rules[0].Variables["max_line_length"])
+
+	err := SetPath(config, "generation.parallelFetches", "many")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `generation.parallelFetches: expected an integer, got "many"`)
+
+	err = SetPath(config, "formats[0].enabled", "[1, 2]")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expected a boolean")
+}
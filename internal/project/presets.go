@@ -0,0 +1,59 @@
+package project
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// ResolvePresets reads the variable presets config selects from the indexes
+// of their providers' repositories and merges them in order, later presets
+// taking precedence. It returns nil when config selects no presets.
+func (m *Manager) ResolvePresets(config *domain.Project) (map[string]any, error) {
+	if config == nil || len(config.Presets) == 0 {
+		return nil, nil
+	}
+
+	variables := map[string]any{}
+	indexes := map[string]*domain.RepositoryIndex{}
+	for _, preset := range config.Presets {
+		providerName, name, ok := strings.Cut(strings.TrimPrefix(preset, "@"), "/")
+		if !strings.HasPrefix(preset, "@") || !ok || name == "" {
+			return nil, contextureerrors.ValidationErrorf("presets", "%q must be @provider/preset", preset)
+		}
+
+		index, cached := indexes[providerName]
+		if !cached {
+			repoURL, ref, err := providerRepository(providerName, config)
+			if err != nil {
+				return nil, contextureerrors.Wrap(err, "resolve preset "+preset)
+			}
+			data, err := m.remote.FetchConfig(context.Background(), repoURL, ref, domain.RepositoryIndexFile)
+			if err != nil {
+				return nil, contextureerrors.Wrap(err, "read index of @"+providerName)
+			}
+			index = &domain.RepositoryIndex{}
+			if err := yaml.Unmarshal(data, index); err != nil {
+				return nil, contextureerrors.Wrap(err, "parse index of @"+providerName)
+			}
+			indexes[providerName] = index
+		}
+
+		found, ok := index.Presets[name]
+		if !ok {
+			available := slices.Sorted(maps.Keys(index.Presets))
+			err := contextureerrors.Validation("presets", "@"+providerName+" publishes no preset "+name)
+			if len(available) > 0 {
+				err = err.WithSuggestions(contextureerrors.Hint("available presets: " + strings.Join(available, ", ")))
+			}
+			return nil, err
+		}
+		maps.Copy(variables, found.Variables)
+	}
+	return variables, nil
+}
@@ -0,0 +1,67 @@
+package project
+
+import (
+	"testing"
+
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const presetsIndex = `name: acme
+rules: []
+presets:
+  strict:
+    variables:
+      coverage: 90
+      style: strict
+  relaxed:
+    variables:
+      coverage: 60
+`
+
+func TestManager_ResolvePresets(t *testing.T) {
+	t.Parallel()
+	remote := &fakeRemoteConfigFetcher{configs: map[string]string{domain.RepositoryIndexFile: presetsIndex}}
+	manager := NewManager(afero.NewMemMapFs())
+	manager.remote = remote
+
+	config := &domain.Project{
+		Presets:   []string{"@acme/strict", "@acme/relaxed"},
+		Providers: []domain.Provider{{Name: "acme", URL: "https://github.com/acme/rules.git"}},
+	}
+	variables, err := manager.ResolvePresets(config)
+	require.NoError(t, err)
+	assert.Equal(t, "https://github.com/acme/rules.git", remote.repoURL)
+	// Later presets take precedence
+	assert.Equal(t, map[string]any{"coverage": 60, "style": "strict"}, variables)
+
+	variables, err = manager.ResolvePresets(&domain.Project{})
+	require.NoError(t, err)
+	assert.Nil(t, variables)
+}
+
+func TestManager_ResolvePresets_Errors(t *testing.T) {
+	t.Parallel()
+	manager := NewManager(afero.NewMemMapFs())
+	manager.remote = &fakeRemoteConfigFetcher{configs: map[string]string{domain.RepositoryIndexFile: presetsIndex}}
+	providers := []domain.Provider{{Name: "acme", URL: "https://github.com/acme/rules.git"}}
+
+	tests := []struct {
+		preset  string
+		message string
+	}{
+		{"strict", "must be @provider/preset"},
+		{"@acme", "must be @provider/preset"},
+		{"@acme/missing", "publishes no preset missing"},
+		{"@unknown/strict", "unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.preset, func(t *testing.T) {
+			_, err := manager.ResolvePresets(&domain.Project{Presets: []string{tt.preset}, Providers: providers})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.message)
+		})
+	}
+}
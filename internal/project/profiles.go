@@ -0,0 +1,168 @@
+package project
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/spf13/afero"
+)
+
+const (
+	// ProfileEnv names the global profile to use, taking precedence over the
+	// profile chosen with contexture profile use
+	ProfileEnv = "CONTEXTURE_PROFILE"
+	// DefaultProfile is the profile stored in the global configuration file
+	DefaultProfile = "default"
+
+	// profilesDirName is the directory of the named profiles inside the
+	// global directory
+	profilesDirName = "profiles"
+	// activeProfileFile records the profile chosen on this machine
+	activeProfileFile = "profile"
+)
+
+// profileNamePattern matches the names a profile file can be created with
+var profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// ValidateProfileName returns a validation error for names that cannot be
+// used as a profile file name
+func ValidateProfileName(name string) error {
+	if !profileNamePattern.MatchString(name) {
+		return contextureerrors.Validation("profile", "invalid profile name: "+name).
+			WithSuggestions(contextureerrors.Hint("use letters, digits, dots, dashes, and underscores"))
+	}
+	return nil
+}
+
+// ActiveProfile returns the global profile in use: the one named by
+// CONTEXTURE_PROFILE, else the one chosen with contexture profile use, else
+// the default profile
+func (m *Manager) ActiveProfile() (string, error) {
+	if name := strings.TrimSpace(m.env.Getenv(ProfileEnv)); name != "" {
+		return name, ValidateProfileName(name)
+	}
+	dir, err := m.GlobalConfigDir()
+	if err != nil {
+		return "", err
+	}
+	data, err := afero.ReadFile(m.repo.GetFilesystem(), filepath.Join(dir, activeProfileFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return DefaultProfile, nil
+	}
+	if err != nil {
+		return "", contextureerrors.Wrap(err, "read active profile")
+	}
+	if name := strings.TrimSpace(string(data)); name != "" {
+		return name, ValidateProfileName(name)
+	}
+	return DefaultProfile, nil
+}
+
+// ProfilePath returns the configuration file of a global profile. The
+// default profile is the global configuration file; the others are
+// profiles/<name>.yaml in the global directory.
+func (m *Manager) ProfilePath(name string) (string, error) {
+	if err := ValidateProfileName(name); err != nil {
+		return "", err
+	}
+	dir, err := m.GlobalConfigDir()
+	if err != nil {
+		return "", err
+	}
+	if name == DefaultProfile {
+		return filepath.Join(dir, domain.GetConfigFileName()), nil
+	}
+	return filepath.Join(dir, profilesDirName, name+".yaml"), nil
+}
+
+// ListProfiles returns the default profile followed by the named profiles
+// in the global directory, sorted by name
+func (m *Manager) ListProfiles() ([]string, error) {
+	dir, err := m.GlobalConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := afero.ReadDir(m.repo.GetFilesystem(), filepath.Join(dir, profilesDirName))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, contextureerrors.Wrap(err, "read profiles directory")
+	}
+
+	var names []string
+	for _, entry := range entries {
+		name, isYAML := strings.CutSuffix(entry.Name(), ".yaml")
+		if entry.IsDir() || !isYAML || name == DefaultProfile || ValidateProfileName(name) != nil {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return append([]string{DefaultProfile}, names...), nil
+}
+
+// UseProfile makes name the global profile of this machine. Named profiles
+// must exist; the default profile always can be used.
+func (m *Manager) UseProfile(name string) error {
+	path, err := m.ProfilePath(name)
+	if err != nil {
+		return err
+	}
+	if name != DefaultProfile {
+		exists, err := m.repo.Exists(path)
+		if err != nil {
+			return contextureerrors.Wrap(err, "check profile")
+		}
+		if !exists {
+			return contextureerrors.Validation("profile", "profile not found: "+name).
+				WithSuggestions(contextureerrors.RunCommand("contexture profile create "+name, "create the profile"))
+		}
+	}
+
+	dir, err := m.GlobalConfigDir()
+	if err != nil {
+		return err
+	}
+	fs := m.repo.GetFilesystem()
+	statePath := filepath.Join(dir, activeProfileFile)
+	if name == DefaultProfile {
+		if err := fs.Remove(statePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return contextureerrors.Wrap(err, "reset active profile")
+		}
+		return nil
+	}
+	if err := fs.MkdirAll(dir, configDirPermissions); err != nil {
+		return contextureerrors.Wrap(err, "create global config directory")
+	}
+	if err := afero.WriteFile(fs, statePath, []byte(name+"\n"), configFilePermissions); err != nil {
+		return contextureerrors.Wrap(err, "write active profile")
+	}
+	return nil
+}
+
+// CreateProfile writes config as a new named profile
+func (m *Manager) CreateProfile(name string, config *domain.Project) error {
+	path, err := m.ProfilePath(name)
+	if err != nil {
+		return err
+	}
+	exists, err := m.repo.Exists(path)
+	if err != nil {
+		return contextureerrors.Wrap(err, "check profile")
+	}
+	if exists || name == DefaultProfile {
+		return contextureerrors.Validation("profile", "profile already exists: "+name).
+			WithSuggestions(contextureerrors.RunCommand("contexture profile use "+name, "switch to it"))
+	}
+	if err := m.validator.ValidateProject(config); err != nil {
+		return &ConfigError{Operation: "validate", Path: path, Err: err}
+	}
+	if err := m.repo.Save(m.cleaner.CleanProject(config), path); err != nil {
+		return &ConfigError{Operation: "save", Path: path, Err: err}
+	}
+	return nil
+}
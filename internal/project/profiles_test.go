@@ -0,0 +1,82 @@
+package project
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_Profiles(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	manager := newTestManagerWithHome(fs, testHomeDir)
+	env := dependencies.MapEnvironment{}
+	manager.env = env
+
+	active, err := manager.ActiveProfile()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultProfile, active)
+
+	require.NoError(t, manager.SaveGlobalConfig(&domain.Project{
+		Version: 1,
+		Formats: []domain.FormatConfig{{Type: domain.FormatClaude, Enabled: true}},
+		Rules:   []domain.RuleRef{{ID: "[contexture:personal/style]"}},
+	}))
+
+	work := &domain.Project{
+		Version: 1,
+		Formats: []domain.FormatConfig{{Type: domain.FormatClaude, Enabled: true}},
+		Rules:   []domain.RuleRef{{ID: "[contexture:work/security]"}},
+	}
+	require.NoError(t, manager.CreateProfile("work", work))
+	exists, err := afero.Exists(fs, filepath.Join(testGlobalDir, "profiles", "work.yaml"))
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	err = manager.CreateProfile("work", work)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "profile already exists")
+
+	profiles, err := manager.ListProfiles()
+	require.NoError(t, err)
+	assert.Equal(t, []string{DefaultProfile, "work"}, profiles)
+
+	require.NoError(t, manager.UseProfile("work"))
+	result, err := manager.LoadGlobalConfig()
+	require.NoError(t, err)
+	require.NotNil(t, result.Config)
+	assert.Equal(t, "[contexture:work/security]", result.Config.Rules[0].ID)
+
+	// Local rules stay in the shared global rules directory
+	rulesDir, err := manager.LocalRulesDir(result)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(testGlobalDir, domain.LocalRulesDir), rulesDir)
+
+	env[ProfileEnv] = DefaultProfile
+	result, err = manager.LoadGlobalConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "[contexture:personal/style]", result.Config.Rules[0].ID)
+	delete(env, ProfileEnv)
+
+	require.NoError(t, manager.UseProfile(DefaultProfile))
+	active, err = manager.ActiveProfile()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultProfile, active)
+}
+
+func TestManager_UseProfile_Errors(t *testing.T) {
+	manager := newTestManagerWithHome(afero.NewMemMapFs(), testHomeDir)
+
+	err := manager.UseProfile("missing")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "profile not found")
+
+	err = manager.UseProfile("../escape")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid profile name")
+}
@@ -0,0 +1,131 @@
+package project
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"github.com/charmbracelet/log"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// strictConfig is set by the global --strict-config flag
+var strictConfig atomic.Bool
+
+// SetStrictConfig makes unknown keys in configuration files errors instead
+// of warnings
+func SetStrictConfig(strict bool) {
+	strictConfig.Store(strict)
+}
+
+// StrictConfig reports whether unknown keys in configuration files are errors
+func StrictConfig() bool {
+	return strictConfig.Load()
+}
+
+// checkUnknownKeys reports the keys of data no field of the type of target
+// reads, such as a misspelled fromats. Keys are checked against the yaml tags
+// of the fields, so entries of types with their own UnmarshalYAML, such as
+// rules, are checked too. Unknown keys are logged as warnings, or returned as
+// a validation error when strict.
+func checkUnknownKeys(data []byte, target any, path string, strict bool) error {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil || len(document.Content) == 0 {
+		// Other errors were reported by the decoding that produced target
+		return nil
+	}
+	var unknown []string
+	collectUnknownKeys(document.Content[0], reflect.TypeOf(target).Elem(), func(key *yaml.Node) {
+		if !strict {
+			log.Warn("Unknown configuration key", "key", key.Value, "file", path, "line", key.Line)
+		}
+		unknown = append(unknown, fmt.Sprintf("%s (line %d)", key.Value, key.Line))
+	})
+	if !strict || len(unknown) == 0 {
+		return nil
+	}
+	return contextureerrors.Validation("config", fmt.Sprintf("unknown keys in %s: %s", path, strings.Join(unknown, ", "))).
+		WithSuggestions(contextureerrors.Hint("fix or remove the keys; the configuration reference lists the keys each section reads"))
+}
+
+// collectUnknownKeys calls report with each key of the mappings under node
+// that no field of typ reads. Values typ does not describe, such as the
+// variables of a rule, are not checked.
+func collectUnknownKeys(node *yaml.Node, typ reflect.Type, report func(key *yaml.Node)) {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	switch typ.Kind() {
+	case reflect.Struct:
+		fields, open := yamlFields(typ)
+		if node.Kind != yaml.MappingNode || open {
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			field, ok := fields[key.Value]
+			switch {
+			case ok:
+				collectUnknownKeys(value, field, report)
+			case key.Value != "<<": // Merge keys are resolved by the decoder
+				report(key)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if node.Kind != yaml.SequenceNode {
+			return
+		}
+		for _, item := range node.Content {
+			collectUnknownKeys(item, typ.Elem(), report)
+		}
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			return
+		}
+		for i := 1; i < len(node.Content); i += 2 {
+			collectUnknownKeys(node.Content[i], typ.Elem(), report)
+		}
+	}
+}
+
+// yamlFields returns the types of the fields of struct type typ by the key
+// they are read from, following the rules of the yaml decoder. open reports
+// an inlined map, which reads any key.
+func yamlFields(typ reflect.Type) (fields map[string]reflect.Type, open bool) {
+	fields = make(map[string]reflect.Type)
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag := field.Tag.Get("yaml")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if slices.Contains(strings.Split(options, ","), "inline") {
+			inlined := field.Type
+			for inlined.Kind() == reflect.Pointer {
+				inlined = inlined.Elem()
+			}
+			if inlined.Kind() == reflect.Map {
+				return nil, true
+			}
+			inner, innerOpen := yamlFields(inlined)
+			if innerOpen {
+				return nil, true
+			}
+			maps.Copy(fields, inner)
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		fields[name] = field.Type
+	}
+	return fields, false
+}
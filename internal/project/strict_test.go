@@ -0,0 +1,86 @@
+package project
+
+import (
+	"testing"
+
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const misspelledConfig = `version: 1
+fromats:
+  - type: claude
+    enabled: true
+rules:
+  - id: "[contexture:go/errors]"
+    varaibles:
+      strict: true
+`
+
+func TestCheckUnknownKeys(t *testing.T) {
+	t.Parallel()
+	var config domain.Project
+
+	require.NoError(t, checkUnknownKeys([]byte(misspelledConfig), &config, ".contexture.yaml", false),
+		"unknown keys are only warnings by default")
+
+	err := checkUnknownKeys([]byte(misspelledConfig), &config, ".contexture.yaml", true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown keys in .contexture.yaml: fromats (line 2), varaibles (line 7)")
+
+	require.NoError(t, checkUnknownKeys([]byte("version: 1\nformats: []\n"), &config, ".contexture.yaml", true))
+}
+
+func TestCheckUnknownKeys_RuleEntries(t *testing.T) {
+	t.Parallel()
+	var config domain.Project
+
+	// Rule entries have their own UnmarshalYAML; variables take any keys
+	const data = `version: 1
+rules:
+  - id: "[contexture:go/errors]"
+    pinnned: true
+    variables:
+      anything: goes
+  - id: "[contexture:go/style]"
+    approval:
+      by: alice
+      when: yesterday
+`
+	err := checkUnknownKeys([]byte(data), &config, ".contexture.yaml", true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown keys in .contexture.yaml: pinnned (line 4), when (line 10)")
+
+	require.NoError(t, checkUnknownKeys([]byte(`version: 1
+rules:
+  - "[contexture:go/errors]"
+  - id: "[contexture:go/style]"
+    pinned: true
+    variables:
+      anything: goes
+`), &config, ".contexture.yaml", true))
+}
+
+func TestManager_LoadConfig_StrictConfig(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/work/app/.contexture.yaml", []byte(misspelledConfig), 0o644))
+	manager := NewManager(fs)
+
+	_, err := manager.LoadConfig("/work/app")
+	require.NoError(t, err)
+
+	SetStrictConfig(true)
+	t.Cleanup(func() { SetStrictConfig(false) })
+	_, err = manager.LoadConfig("/work/app")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "fromats (line 2)")
+	SetStrictConfig(false)
+
+	// The strictConfig setting makes the file strict without the flag
+	require.NoError(t, afero.WriteFile(fs, "/work/app/.contexture.yaml", []byte("strictConfig: true\n"+misspelledConfig), 0o644))
+	_, err = manager.LoadConfig("/work/app")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "fromats (line 3)")
+}
@@ -0,0 +1,83 @@
+package project
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/staging"
+	"github.com/spf13/afero"
+)
+
+const (
+	// HomeEnv names a directory that replaces the global contexture directory
+	HomeEnv = "CONTEXTURE_HOME"
+	// XDGConfigHomeEnv is the XDG Base Directory variable for user configuration
+	XDGConfigHomeEnv = "XDG_CONFIG_HOME"
+
+	// xdgDirName is contexture's directory inside an XDG base directory
+	xdgDirName = "contexture"
+	// legacyGlobalDirName is the global directory in the home directory used
+	// before XDG support
+	legacyGlobalDirName = ".contexture"
+)
+
+// globalConfigDirs returns the XDG global directory, $XDG_CONFIG_HOME/contexture
+// or ~/.config/contexture, and the legacy ~/.contexture directory
+func globalConfigDirs(homeDir string, env dependencies.Environment) (string, string) {
+	configHome := env.Getenv(XDGConfigHomeEnv)
+	// The specification requires absolute paths and ignores relative ones
+	if !filepath.IsAbs(configHome) {
+		configHome = filepath.Join(homeDir, ".config")
+	}
+	return filepath.Join(configHome, xdgDirName), filepath.Join(homeDir, legacyGlobalDirName)
+}
+
+// resolveGlobalConfigDir returns the global directory to use. CONTEXTURE_HOME
+// takes precedence when set. Otherwise the XDG directory is used when it
+// exists or when neither directory exists. A legacy ~/.contexture directory
+// is copied to the XDG location the first time it is found, and keeps being
+// used if the copy fails.
+func resolveGlobalConfigDir(fs afero.Fs, homeDir string, env dependencies.Environment) string {
+	if home := env.Getenv(HomeEnv); home != "" {
+		return expandHome(home, homeDir)
+	}
+
+	xdg, legacy := globalConfigDirs(homeDir, env)
+	if isDir(fs, xdg) || !isDir(fs, legacy) {
+		return xdg
+	}
+
+	if err := staging.CopyDir(fs, legacy, xdg); err != nil {
+		log.Warn("Failed to move global configuration to XDG directory; using legacy directory",
+			"from", legacy, "to", xdg, "error", err)
+		// Do not leave a partial copy that would be preferred next time
+		_ = fs.RemoveAll(xdg)
+		return legacy
+	}
+	log.Info("Copied global configuration to XDG directory; the old directory is no longer read",
+		"from", legacy, "to", xdg)
+	return xdg
+}
+
+// expandHome resolves a leading ~ against the home directory and makes other
+// paths absolute
+func expandHome(path, homeDir string) string {
+	switch {
+	case path == "~":
+		return homeDir
+	case strings.HasPrefix(path, "~/"):
+		return filepath.Join(homeDir, path[2:])
+	case !filepath.IsAbs(path):
+		if abs, err := filepath.Abs(path); err == nil {
+			return abs
+		}
+	}
+	return filepath.Clean(path)
+}
+
+func isDir(fs afero.Fs, path string) bool {
+	info, err := fs.Stat(path)
+	return err == nil && info.IsDir()
+}
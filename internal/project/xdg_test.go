@@ -0,0 +1,98 @@
+package project
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGlobalConfigDirs(t *testing.T) {
+	t.Parallel()
+	t.Run("defaults to ~/.config", func(t *testing.T) {
+		t.Parallel()
+		xdg, legacy := globalConfigDirs(testHomeDir, dependencies.MapEnvironment{})
+		assert.Equal(t, testGlobalDir, xdg)
+		assert.Equal(t, filepath.Join(testHomeDir, ".contexture"), legacy)
+	})
+
+	t.Run("uses XDG_CONFIG_HOME", func(t *testing.T) {
+		t.Parallel()
+		xdg, _ := globalConfigDirs(testHomeDir, dependencies.MapEnvironment{XDGConfigHomeEnv: "/xdg/config"})
+		assert.Equal(t, "/xdg/config/contexture", xdg)
+	})
+
+	t.Run("ignores relative XDG_CONFIG_HOME", func(t *testing.T) {
+		t.Parallel()
+		xdg, _ := globalConfigDirs(testHomeDir, dependencies.MapEnvironment{XDGConfigHomeEnv: "config"})
+		assert.Equal(t, testGlobalDir, xdg)
+	})
+}
+
+func TestResolveGlobalConfigDir(t *testing.T) {
+	t.Parallel()
+	env := dependencies.MapEnvironment{}
+	legacyDir := filepath.Join(testHomeDir, ".contexture")
+
+	t.Run("uses XDG directory for new installs", func(t *testing.T) {
+		t.Parallel()
+		fs := afero.NewMemMapFs()
+		assert.Equal(t, testGlobalDir, resolveGlobalConfigDir(fs, testHomeDir, env))
+		exists, _ := afero.DirExists(fs, testGlobalDir)
+		assert.False(t, exists)
+	})
+
+	t.Run("copies legacy directory to XDG directory", func(t *testing.T) {
+		t.Parallel()
+		fs := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fs, filepath.Join(legacyDir, ".contexture.yaml"), []byte("version: 1\n"), 0o644))
+		require.NoError(t, afero.WriteFile(fs, filepath.Join(legacyDir, "rules", "team", "style.md"), []byte("Use tabs."), 0o600))
+
+		assert.Equal(t, testGlobalDir, resolveGlobalConfigDir(fs, testHomeDir, env))
+
+		content, err := afero.ReadFile(fs, filepath.Join(testGlobalDir, ".contexture.yaml"))
+		require.NoError(t, err)
+		assert.Equal(t, "version: 1\n", string(content))
+		info, err := fs.Stat(filepath.Join(testGlobalDir, "rules", "team", "style.md"))
+		require.NoError(t, err)
+		assert.Equal(t, 0o600, int(info.Mode().Perm()))
+
+		// The legacy directory is left in place
+		exists, _ := afero.Exists(fs, filepath.Join(legacyDir, ".contexture.yaml"))
+		assert.True(t, exists)
+	})
+
+	t.Run("prefers existing XDG directory", func(t *testing.T) {
+		t.Parallel()
+		fs := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fs, filepath.Join(legacyDir, ".contexture.yaml"), []byte("version: 1\n"), 0o644))
+		require.NoError(t, fs.MkdirAll(testGlobalDir, 0o755))
+
+		assert.Equal(t, testGlobalDir, resolveGlobalConfigDir(fs, testHomeDir, env))
+		exists, _ := afero.Exists(fs, filepath.Join(testGlobalDir, ".contexture.yaml"))
+		assert.False(t, exists)
+	})
+
+	t.Run("uses CONTEXTURE_HOME", func(t *testing.T) {
+		t.Parallel()
+		fs := afero.NewMemMapFs()
+		require.NoError(t, fs.MkdirAll(testGlobalDir, 0o755))
+		env := dependencies.MapEnvironment{HomeEnv: "~/dotfiles/contexture"}
+		assert.Equal(t, filepath.Join(testHomeDir, "dotfiles", "contexture"), resolveGlobalConfigDir(fs, testHomeDir, env))
+
+		env[HomeEnv] = "/opt/contexture"
+		assert.Equal(t, "/opt/contexture", resolveGlobalConfigDir(fs, testHomeDir, env))
+	})
+
+	t.Run("falls back to legacy directory when copy fails", func(t *testing.T) {
+		t.Parallel()
+		base := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(base, filepath.Join(legacyDir, ".contexture.yaml"), []byte("version: 1\n"), 0o644))
+		fs := afero.NewReadOnlyFs(base)
+
+		assert.Equal(t, legacyDir, resolveGlobalConfigDir(fs, testHomeDir, env))
+	})
+}
@@ -72,6 +72,18 @@ func (r *Registry) LoadFromProject(project *domain.Project) error {
 	return nil
 }
 
+// Mirrors returns the mirror URLs of the provider with the given repository
+// URL, or nil when no provider has that URL. It lets repository clones fail
+// over to the mirrors of a provider.
+func (r *Registry) Mirrors(repoURL string) []string {
+	for _, provider := range r.providers {
+		if provider.URL == repoURL {
+			return provider.Mirrors
+		}
+	}
+	return nil
+}
+
 // List returns all registered provider names
 func (r *Registry) List() []string {
 	names := make([]string, 0, len(r.providers))
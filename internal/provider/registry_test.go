@@ -314,3 +314,22 @@ func TestListProviders(t *testing.T) {
 		t.Errorf("expected custom2 branch 'production', got '%s'", providerMap["custom2"].DefaultBranch)
 	}
 }
+
+func TestRegistry_Mirrors(t *testing.T) {
+	registry := NewRegistry()
+	mirrors := []string{"https://gitlab.com/mycompany/rules.git"}
+	if err := registry.Register(&domain.Provider{
+		Name:    "mycompany",
+		URL:     "https://github.com/mycompany/rules.git",
+		Mirrors: mirrors,
+	}); err != nil {
+		t.Fatalf("failed to register provider: %v", err)
+	}
+
+	if got := registry.Mirrors("https://github.com/mycompany/rules.git"); len(got) != 1 || got[0] != mirrors[0] {
+		t.Errorf("Mirrors() = %v, want %v", got, mirrors)
+	}
+	if got := registry.Mirrors(domain.DefaultProviderURL); got != nil {
+		t.Errorf("Mirrors() of a provider without mirrors = %v, want nil", got)
+	}
+}
@@ -13,9 +13,12 @@ import (
 
 // Evaluator provides methods for evaluating queries against rules
 type Evaluator interface {
-	// MatchesText performs simple text search in rule title and ID
+	// MatchesText reports whether a fuzzy text search matches the rule
 	MatchesText(rule *domain.Rule, query string) bool
 
+	// ScoreText fuzzy-matches a text search against the rule and scores the match
+	ScoreText(rule *domain.Rule, query string) Match
+
 	// EvaluateExpr evaluates an expr expression against a rule
 	EvaluateExpr(rule *domain.Rule, exprStr string) (bool, error)
 }
@@ -34,30 +37,15 @@ func NewEvaluator() Evaluator {
 	}
 }
 
-// MatchesText performs simple text search in rule title and ID
-// All terms must match (AND logic) in either the title or ID
+// MatchesText reports whether a fuzzy text search matches the rule.
+// All terms must match (AND logic); see MatchScore for the matching rules.
 func (e *evaluator) MatchesText(rule *domain.Rule, query string) bool {
-	if query == "" {
-		return true
-	}
-
-	// Split query into terms
-	terms := strings.Fields(strings.ToLower(query))
-	if len(terms) == 0 {
-		return true
-	}
-
-	// Create searchable text from title and ID
-	searchText := strings.ToLower(rule.Title + " " + rule.ID)
-
-	// All terms must be present (AND logic)
-	for _, term := range terms {
-		if !strings.Contains(searchText, term) {
-			return false
-		}
-	}
+	return MatchScore(rule, query).Matched
+}
 
-	return true
+// ScoreText fuzzy-matches a text search against the rule and scores the match
+func (e *evaluator) ScoreText(rule *domain.Rule, query string) Match {
+	return MatchScore(rule, query)
 }
 
 // EvaluateExpr evaluates an expr expression against a rule
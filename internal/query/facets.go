@@ -0,0 +1,58 @@
+package query
+
+import (
+	"strings"
+
+	"github.com/contextureai/contexture/internal/domain"
+)
+
+// Facets narrows rules by their tags, languages, and frameworks. A rule matches
+// when it carries at least one selected value for every non-empty facet.
+type Facets struct {
+	Tags       []string `json:"tags,omitempty"`
+	Languages  []string `json:"languages,omitempty"`
+	Frameworks []string `json:"frameworks,omitempty"`
+}
+
+// IsEmpty reports whether no facet values are selected
+func (f Facets) IsEmpty() bool {
+	return len(f.Tags) == 0 && len(f.Languages) == 0 && len(f.Frameworks) == 0
+}
+
+// Matches reports whether the rule satisfies every selected facet
+func (f Facets) Matches(rule *domain.Rule) bool {
+	return anyFold(rule.Tags, f.Tags) &&
+		anyFold(rule.Languages, f.Languages) &&
+		anyFold(rule.Frameworks, f.Frameworks)
+}
+
+// Filter returns the rules that satisfy every selected facet
+func (f Facets) Filter(rules []*domain.Rule) []*domain.Rule {
+	if f.IsEmpty() {
+		return rules
+	}
+
+	filtered := make([]*domain.Rule, 0, len(rules))
+	for _, rule := range rules {
+		if f.Matches(rule) {
+			filtered = append(filtered, rule)
+		}
+	}
+	return filtered
+}
+
+// anyFold reports whether values contains any of selected, ignoring case.
+// An empty selection matches everything.
+func anyFold(values, selected []string) bool {
+	if len(selected) == 0 {
+		return true
+	}
+	for _, value := range values {
+		for _, want := range selected {
+			if strings.EqualFold(value, want) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,37 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFacets_Filter(t *testing.T) {
+	t.Parallel()
+
+	goSecurity := &domain.Rule{ID: "go-security", Tags: []string{"security"}, Languages: []string{"go"}}
+	pySecurity := &domain.Rule{ID: "py-security", Tags: []string{"Security", "auth"}, Languages: []string{"python"}, Frameworks: []string{"django"}}
+	pyTesting := &domain.Rule{ID: "py-testing", Tags: []string{"testing"}, Languages: []string{"python"}, Frameworks: []string{"pytest"}}
+	rules := []*domain.Rule{goSecurity, pySecurity, pyTesting}
+
+	tests := []struct {
+		name     string
+		facets   Facets
+		expected []*domain.Rule
+	}{
+		{name: "no facets", facets: Facets{}, expected: rules},
+		{name: "tag ignores case", facets: Facets{Tags: []string{"SECURITY"}}, expected: []*domain.Rule{goSecurity, pySecurity}},
+		{name: "values within a facet are alternatives", facets: Facets{Tags: []string{"auth", "testing"}}, expected: []*domain.Rule{pySecurity, pyTesting}},
+		{name: "facets combine", facets: Facets{Tags: []string{"security"}, Languages: []string{"python"}}, expected: []*domain.Rule{pySecurity}},
+		{name: "framework", facets: Facets{Frameworks: []string{"pytest"}}, expected: []*domain.Rule{pyTesting}},
+		{name: "no match", facets: Facets{Languages: []string{"rust"}}, expected: []*domain.Rule{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, tt.facets.Filter(rules))
+		})
+	}
+}
@@ -0,0 +1,281 @@
+package query
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/contextureai/contexture/internal/domain"
+)
+
+// Field weights for MatchScore. A term found in the title outranks the same
+// term found in the rule path, which outranks a match in metadata.
+const (
+	titleWeight    = 3
+	pathWeight     = 2
+	metadataWeight = 1
+)
+
+// Per-term scores before field weighting
+const (
+	exactScore       = 100
+	prefixBonus      = 30
+	wordStartBonus   = 15
+	subsequenceScore = 50
+	typoScore        = 30
+)
+
+// Match is the result of fuzzy matching a text query against a rule
+type Match struct {
+	// Matched reports whether every query term matched somewhere in the rule
+	Matched bool
+	// Score ranks matches; higher is better
+	Score int
+	// TitlePositions are the rune indexes of matched characters in the rule title
+	TitlePositions []int
+}
+
+// termMatch is the best match of a single term within a single field
+type termMatch struct {
+	score     int
+	positions []int
+}
+
+// MatchScore fuzzy-matches query against rule. Each whitespace-separated term
+// must match the title, the rule path, or metadata (tags, languages,
+// frameworks, description) as a substring, an in-order subsequence, or a word
+// within a small edit distance. An empty query matches every rule with a zero score.
+func MatchScore(rule *domain.Rule, query string) Match {
+	terms := strings.Fields(strings.ToLower(query))
+	if len(terms) == 0 {
+		return Match{Matched: true}
+	}
+
+	title := lowerRunes(rule.Title)
+	path := lowerRunes(rule.ID)
+	metadata := make([][]rune, 0, len(rule.Tags)+len(rule.Languages)+len(rule.Frameworks)+1)
+	for _, values := range [][]string{rule.Tags, rule.Languages, rule.Frameworks, {rule.Description}} {
+		for _, value := range values {
+			if value != "" {
+				metadata = append(metadata, lowerRunes(value))
+			}
+		}
+	}
+
+	result := Match{Matched: true}
+	seen := make(map[int]bool)
+	for _, term := range terms {
+		termRunes := []rune(term)
+
+		best := 0
+		if m, ok := matchField(title, termRunes, true); ok {
+			best = m.score * titleWeight
+			for _, pos := range m.positions {
+				if !seen[pos] {
+					seen[pos] = true
+					result.TitlePositions = append(result.TitlePositions, pos)
+				}
+			}
+		}
+		if m, ok := matchField(path, termRunes, true); ok {
+			best = max(best, m.score*pathWeight)
+		}
+		for _, field := range metadata {
+			if m, ok := matchField(field, termRunes, false); ok {
+				best = max(best, m.score*metadataWeight)
+			}
+		}
+
+		if best == 0 {
+			return Match{}
+		}
+		result.Score += best
+	}
+
+	slices.Sort(result.TitlePositions)
+	return result
+}
+
+// matchField finds the best match of term within field. Subsequence matching is
+// limited to short fields because scattered letters in long prose match almost anything.
+func matchField(field, term []rune, allowSubsequence bool) (termMatch, bool) {
+	if len(field) == 0 || len(term) == 0 {
+		return termMatch{}, false
+	}
+
+	if idx := indexRunes(field, term); idx >= 0 {
+		score := exactScore
+		if idx == 0 {
+			score += prefixBonus
+		} else if isWordStart(field, idx) {
+			score += wordStartBonus
+		}
+		return termMatch{score: score, positions: span(idx, len(term))}, true
+	}
+
+	if allowSubsequence {
+		if positions, ok := subsequence(field, term); ok {
+			// Penalize matches whose characters are spread far apart
+			gaps := positions[len(positions)-1] - positions[0] + 1 - len(term)
+			return termMatch{score: max(subsequenceScore-gaps*5, 10), positions: positions}, true
+		}
+	}
+
+	if start, length, ok := typoMatch(field, term); ok {
+		return termMatch{score: typoScore, positions: span(start, length)}, true
+	}
+
+	return termMatch{}, false
+}
+
+// subsequence reports the positions of term's runes appearing in order in field.
+// The span of the match may not exceed three times the term length.
+func subsequence(field, term []rune) ([]int, bool) {
+	if len(term) < 2 {
+		return nil, false
+	}
+
+	var best []int
+	for start := range field {
+		if field[start] != term[0] {
+			continue
+		}
+		positions := []int{start}
+		j := 1
+		for i := start + 1; i < len(field) && j < len(term); i++ {
+			if field[i] == term[j] {
+				positions = append(positions, i)
+				j++
+			}
+		}
+		if j < len(term) {
+			break
+		}
+		if positions[len(positions)-1]-start+1 > len(term)*3 {
+			continue
+		}
+		if best == nil || positions[len(positions)-1]-start < best[len(best)-1]-best[0] {
+			best = positions
+		}
+	}
+
+	return best, best != nil
+}
+
+// typoMatch finds a word in field, or the prefix of a word, within the allowed
+// edit distance of term. It returns the start and length of the matched text.
+func typoMatch(field, term []rune) (int, int, bool) {
+	allowed := maxTypos(len(term))
+	if allowed == 0 {
+		return 0, 0, false
+	}
+
+	for start := 0; start < len(field); {
+		if !isWordRune(field[start]) {
+			start++
+			continue
+		}
+		end := start
+		for end < len(field) && isWordRune(field[end]) {
+			end++
+		}
+		word := field[start:end]
+
+		if editDistance(word, term) <= allowed {
+			return start, len(word), true
+		}
+		// Allow partially typed words: compare against the word's prefix
+		if len(word) > len(term) && editDistance(word[:len(term)], term) <= allowed {
+			return start, len(term), true
+		}
+
+		start = end
+	}
+
+	return 0, 0, false
+}
+
+// maxTypos returns how many edits a term of the given length tolerates
+func maxTypos(length int) int {
+	switch {
+	case length >= 8:
+		return 2
+	case length >= 4:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// editDistance returns the optimal string alignment distance between a and b,
+// counting insertions, deletions, substitutions, and adjacent transpositions
+func editDistance(a, b []rune) int {
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+
+	return prev[len(b)]
+}
+
+// lowerRunes lowercases s rune by rune so indexes line up with the original string
+func lowerRunes(s string) []rune {
+	runes := []rune(s)
+	for i, r := range runes {
+		runes[i] = unicode.ToLower(r)
+	}
+	return runes
+}
+
+// indexRunes returns the index of the first occurrence of sub in s, or -1
+func indexRunes(s, sub []rune) int {
+	for i := 0; i+len(sub) <= len(s); i++ {
+		match := true
+		for j := range sub {
+			if s[i+j] != sub[j] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return i
+		}
+	}
+	return -1
+}
+
+// isWordStart reports whether the rune at idx begins a word
+func isWordStart(s []rune, idx int) bool {
+	return idx == 0 || !isWordRune(s[idx-1])
+}
+
+// isWordRune reports whether r is part of a word
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// span returns the indexes start through start+length-1
+func span(start, length int) []int {
+	positions := make([]int, length)
+	for i := range positions {
+		positions[i] = start + i
+	}
+	return positions
+}
@@ -0,0 +1,105 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchScore(t *testing.T) {
+	t.Parallel()
+
+	rule := &domain.Rule{
+		ID:          "@contexture/languages/go/testing",
+		Title:       "Go Testing Best Practices",
+		Description: "Guidelines for writing effective tests",
+		Tags:        []string{"quality"},
+		Languages:   []string{"go"},
+		Frameworks:  []string{"testify"},
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		expected bool
+	}{
+		{name: "empty query", query: "", expected: true},
+		{name: "substring in title", query: "testing", expected: true},
+		{name: "substring in path", query: "languages/go", expected: true},
+		{name: "metadata tag", query: "quality", expected: true},
+		{name: "metadata framework", query: "testify", expected: true},
+		{name: "metadata description", query: "effective", expected: true},
+		{name: "partial word subsequence", query: "bst prac", expected: true},
+		{name: "transposed letters", query: "tetsing", expected: true},
+		{name: "substituted letter", query: "practiced", expected: true},
+		{name: "typo in partial word", query: "pratc", expected: true},
+		{name: "all terms must match", query: "testing python", expected: false},
+		{name: "unrelated word", query: "javascript", expected: false},
+		{name: "short terms require exact characters", query: "zq", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, MatchScore(rule, tt.query).Matched)
+		})
+	}
+}
+
+func TestMatchScore_FieldWeighting(t *testing.T) {
+	t.Parallel()
+
+	inTitle := &domain.Rule{ID: "@contexture/misc/one", Title: "Security Checklist"}
+	inPath := &domain.Rule{ID: "@contexture/security/two", Title: "Checklist"}
+	inMetadata := &domain.Rule{ID: "@contexture/misc/three", Title: "Checklist", Tags: []string{"security"}}
+
+	titleScore := MatchScore(inTitle, "security").Score
+	pathScore := MatchScore(inPath, "security").Score
+	metadataScore := MatchScore(inMetadata, "security").Score
+
+	assert.Greater(t, titleScore, pathScore)
+	assert.Greater(t, pathScore, metadataScore)
+	assert.Positive(t, metadataScore)
+}
+
+func TestMatchScore_ExactBeatsFuzzy(t *testing.T) {
+	t.Parallel()
+
+	exact := &domain.Rule{ID: "@contexture/a", Title: "Testing"}
+	typo := &domain.Rule{ID: "@contexture/b", Title: "Tasting"}
+
+	assert.Greater(t, MatchScore(exact, "testing").Score, MatchScore(typo, "testing").Score)
+}
+
+func TestMatchScore_TitlePositions(t *testing.T) {
+	t.Parallel()
+
+	rule := &domain.Rule{ID: "@contexture/go/testing", Title: "Go Testing"}
+
+	assert.Equal(t, []int{3, 4, 5, 6}, MatchScore(rule, "test").TitlePositions)
+	assert.Equal(t, []int{0, 1, 3, 4, 5, 6}, MatchScore(rule, "test go").TitlePositions)
+	assert.Equal(t, []int{3, 5, 9}, MatchScore(rule, "tsg").TitlePositions)
+	assert.Empty(t, MatchScore(rule, "").TitlePositions)
+}
+
+func TestEditDistance(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"testing", "testing", 0},
+		{"testing", "tetsing", 1},
+		{"testing", "tasting", 1},
+		{"testing", "tests", 3},
+		{"kitten", "sitting", 3},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, editDistance([]rune(tt.a), []rune(tt.b)), "%q vs %q", tt.a, tt.b)
+	}
+}
@@ -0,0 +1,162 @@
+// Package redact finds credentials and other configured patterns in rendered
+// rule content, so secrets that reached a rule repository do not leak into
+// generated output
+package redact
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+)
+
+// detector is a named pattern matching one kind of secret
+type detector struct {
+	name    string
+	pattern *regexp.Regexp
+}
+
+// builtinDetectors match credentials with a recognizable shape
+var builtinDetectors = []detector{
+	{"aws-access-key", regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"github-token", regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{60,})\b`)},
+	{"gitlab-token", regexp.MustCompile(`\bglpat-[A-Za-z0-9_-]{20,}\b`)},
+	{"slack-token", regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}\b`)},
+	{"google-api-key", regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`)},
+	{"stripe-key", regexp.MustCompile(`\b[sr]k_live_[0-9A-Za-z]{24,}\b`)},
+	{"jwt", regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`)},
+	{"private-key", regexp.MustCompile(`-----BEGIN (?:[A-Z]+ )?PRIVATE KEY-----[\s\S]*?-----END (?:[A-Z]+ )?PRIVATE KEY-----`)},
+}
+
+// DetectorNames returns the names of the built-in detectors
+func DetectorNames() []string {
+	names := make([]string, len(builtinDetectors))
+	for i, d := range builtinDetectors {
+		names[i] = d.name
+	}
+	return names
+}
+
+// Match is a secret found in content
+type Match struct {
+	// Pattern names the detector or configured pattern that matched
+	Pattern string
+	// Line is the line of the content the match starts on, from 1
+	Line int
+}
+
+// String describes the match without its content
+func (m Match) String() string {
+	return fmt.Sprintf("%s on line %d", m.Pattern, m.Line)
+}
+
+// Redactor finds the built-in detectors and configured patterns in content
+type Redactor struct {
+	action    domain.RedactAction
+	detectors []detector
+}
+
+// New creates a Redactor from config, running every built-in detector when
+// none are listed and failing on matches unless the action is mask
+func New(config domain.RedactConfig) (*Redactor, error) {
+	if err := Validate(config); err != nil {
+		return nil, err
+	}
+
+	r := &Redactor{action: config.Action}
+	if r.action == "" {
+		r.action = domain.RedactFail
+	}
+	for _, d := range builtinDetectors {
+		if len(config.Detectors) == 0 || slices.Contains(config.Detectors, d.name) {
+			r.detectors = append(r.detectors, d)
+		}
+	}
+	for _, pattern := range config.Patterns {
+		r.detectors = append(r.detectors, detector{name: pattern.Name, pattern: regexp.MustCompile(pattern.Regex)})
+	}
+	return r, nil
+}
+
+// Validate reports configuration errors in config
+func Validate(config domain.RedactConfig) error {
+	switch config.Action {
+	case "", domain.RedactFail, domain.RedactMask:
+	default:
+		return contextureerrors.ValidationErrorf("redact.action",
+			"unknown action %q (available: %s, %s)", config.Action, domain.RedactFail, domain.RedactMask)
+	}
+
+	names := DetectorNames()
+	for _, name := range config.Detectors {
+		if !slices.Contains(names, name) {
+			return contextureerrors.ValidationErrorf("redact.detectors",
+				"unknown detector %q (available: %s)", name, strings.Join(names, ", "))
+		}
+	}
+
+	for i, pattern := range config.Patterns {
+		if strings.TrimSpace(pattern.Name) == "" {
+			return contextureerrors.ValidationErrorf(fmt.Sprintf("redact.patterns[%d].name", i), "pattern name is required")
+		}
+		if pattern.Regex == "" {
+			return contextureerrors.ValidationErrorf(fmt.Sprintf("redact.patterns[%d].regex", i), "pattern %s has no regex", pattern.Name)
+		}
+		if _, err := regexp.Compile(pattern.Regex); err != nil {
+			return contextureerrors.ValidationErrorf(fmt.Sprintf("redact.patterns[%d].regex", i), "pattern %s: %v", pattern.Name, err)
+		}
+	}
+	return nil
+}
+
+// Action returns what to do with content that matches
+func (r *Redactor) Action() domain.RedactAction {
+	return r.action
+}
+
+// Redact returns content with every match replaced by [REDACTED:<pattern>],
+// and the matches in the order they appear
+func (r *Redactor) Redact(content string) (string, []Match) {
+	var matches []Match
+	type span struct {
+		start, end int
+		pattern    string
+	}
+	var spans []span
+	for _, d := range r.detectors {
+		for _, loc := range d.pattern.FindAllStringIndex(content, -1) {
+			if loc[0] == loc[1] {
+				continue
+			}
+			spans = append(spans, span{start: loc[0], end: loc[1], pattern: d.name})
+		}
+	}
+	if len(spans) == 0 {
+		return content, nil
+	}
+
+	// Overlapping matches, such as a token a custom pattern also matches,
+	// are redacted once by the earliest and longest
+	slices.SortFunc(spans, func(a, b span) int {
+		if a.start != b.start {
+			return a.start - b.start
+		}
+		return b.end - a.end
+	})
+	var redacted strings.Builder
+	last := 0
+	for _, s := range spans {
+		if s.start < last {
+			continue
+		}
+		redacted.WriteString(content[last:s.start])
+		redacted.WriteString("[REDACTED:" + s.pattern + "]")
+		matches = append(matches, Match{Pattern: s.pattern, Line: strings.Count(content[:s.start], "\n") + 1})
+		last = s.end
+	}
+	redacted.WriteString(content[last:])
+	return redacted.String(), matches
+}
@@ -0,0 +1,224 @@
+// Package render parses rule files and renders rule content. It depends on
+// neither the filesystem, git, nor the terminal, so it builds for js/wasm and
+// wasip1, and a browser playground renders rules with the same logic as builds.
+package render
+
+import (
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/template"
+	"github.com/contextureai/contexture/internal/version"
+	"gopkg.in/yaml.v3"
+)
+
+// TimeVariables are the built-in variables that hold the current date or time,
+// so content referencing them renders differently on every run
+var TimeVariables = []string{"now", "date", "time", "datetime", "timestamp", "year"}
+
+// Frontmatter is the YAML header of a rule file
+type Frontmatter struct {
+	Title       string              `yaml:"title"`
+	Description string              `yaml:"description"`
+	Tags        []string            `yaml:"tags"`
+	Kind        domain.RuleKind     `yaml:"kind,omitempty"`
+	Trigger     *domain.RuleTrigger `yaml:"trigger,omitempty"`
+	Priority    int                 `yaml:"priority,omitempty"`
+	Owner       string              `yaml:"owner,omitempty"`
+	Languages   []string            `yaml:"languages,omitempty"`
+	Frameworks  []string            `yaml:"frameworks,omitempty"`
+	Variables   map[string]any      `yaml:"variables,omitempty"`
+	Tests       []domain.RuleTest   `yaml:"tests,omitempty"`
+}
+
+// SplitFrontmatter splits rule file content into its frontmatter and body.
+// The frontmatter is nil when the content has none.
+func SplitFrontmatter(content string) (map[string]any, string, error) {
+	content = strings.TrimSpace(content)
+	if !strings.HasPrefix(content, "---") {
+		return nil, content, nil
+	}
+
+	content = strings.TrimPrefix(content, "---\n")
+	parts := strings.SplitN(content, "\n---\n", 2)
+	if len(parts) != 2 {
+		return nil, "", contextureerrors.WithOpf("parse content", "invalid frontmatter format: missing closing ---")
+	}
+
+	var frontmatter map[string]any
+	if err := yaml.Unmarshal([]byte(parts[0]), &frontmatter); err != nil {
+		return nil, "", contextureerrors.Wrap(err, "parse YAML frontmatter")
+	}
+	return frontmatter, parts[1], nil
+}
+
+// ParseRule fills rule from the frontmatter and body of a rule file. The
+// caller sets the rule's identity and the variables of its ID beforehand;
+// frontmatter variables take precedence over them. It reports whether the
+// content had frontmatter.
+func ParseRule(content string, rule *domain.Rule) (bool, error) {
+	data, body, err := SplitFrontmatter(content)
+	if err != nil {
+		return false, contextureerrors.Wrap(err, "parse rule content")
+	}
+	rule.Content = body
+
+	// Round trip through YAML to decode into the typed frontmatter
+	raw, err := yaml.Marshal(data)
+	if err != nil {
+		return false, contextureerrors.Wrap(err, "parse frontmatter")
+	}
+	fm := &Frontmatter{}
+	if err := yaml.Unmarshal(raw, fm); err != nil {
+		return false, contextureerrors.Wrap(err, "parse frontmatter")
+	}
+
+	rule.Title = fm.Title
+	rule.Description = fm.Description
+	rule.Tags = fm.Tags
+	rule.Kind = fm.Kind
+	rule.Trigger = fm.Trigger
+	rule.Priority = fm.Priority
+	rule.Owner = fm.Owner
+	rule.Languages = fm.Languages
+	rule.Frameworks = fm.Frameworks
+	rule.Tests = fm.Tests
+	if fm.Variables != nil {
+		rule.DefaultVariables = maps.Clone(fm.Variables)
+		if rule.Variables == nil {
+			rule.Variables = make(map[string]any)
+		}
+		maps.Copy(rule.Variables, fm.Variables)
+	}
+	return data != nil, nil
+}
+
+// RuleVariables returns the variables a processed rule carries to formats:
+// the context's globals and variables, the rule's variables, which take
+// precedence, its metadata as "rule", and the built-in variables at now
+func RuleVariables(rule *domain.Rule, context *domain.RuleContext, now time.Time) map[string]any {
+	variables := make(map[string]any)
+	if context != nil {
+		maps.Copy(variables, context.Globals)
+		maps.Copy(variables, context.Variables)
+	}
+	maps.Copy(variables, rule.Variables)
+
+	ruleMap := map[string]any{
+		"id":          rule.ID,
+		"title":       rule.Title,
+		"description": rule.Description,
+		"tags":        rule.Tags,
+		"languages":   rule.Languages,
+		"frameworks":  rule.Frameworks,
+		"source":      rule.Source,
+		"ref":         rule.Ref,
+		"filepath":    rule.FilePath,
+	}
+	if rule.Trigger != nil {
+		ruleMap["trigger"] = map[string]any{
+			"type":  string(rule.Trigger.Type),
+			"globs": rule.Trigger.Globs,
+		}
+	}
+	variables["rule"] = ruleMap
+
+	maps.Copy(variables, BuiltinVariables(now))
+	return variables
+}
+
+// BuiltinVariables returns the variables every rule template can reference:
+// contexture build information and the date and time of now
+func BuiltinVariables(now time.Time) map[string]any {
+	buildInfo := version.Get()
+	return map[string]any{
+		"contexture": map[string]any{
+			"version": version.GetShort(),
+			"engine":  "go",
+			"build": map[string]any{
+				"version":   buildInfo.Version,
+				"commit":    buildInfo.Commit,
+				"date":      buildInfo.BuildDate,
+				"by":        buildInfo.BuildBy,
+				"goVersion": buildInfo.GoVersion,
+				"platform":  buildInfo.Platform,
+			},
+		},
+		"now":       now,
+		"date":      now.Format("2006-01-02"),
+		"time":      now.Format("15:04:05"),
+		"datetime":  now.Format("2006-01-02 15:04:05"),
+		"timestamp": now.Unix(),
+	}
+}
+
+// TemplateVariables returns the variables rule content is rendered with: the
+// rule's metadata, the variables of the rule, and additional variables, which
+// take precedence
+func TemplateVariables(rule *domain.Rule, additional map[string]any) map[string]any {
+	variables := map[string]any{
+		"rule":        rule,
+		"id":          rule.ID,
+		"title":       rule.Title,
+		"description": rule.Description,
+		"tags":        rule.Tags,
+		"content":     rule.Content,
+		"source":      rule.Source,
+		"ref":         rule.Ref,
+		"languages":   rule.Languages,
+		"frameworks":  rule.Frameworks,
+	}
+
+	// Convert to basic types for template compatibility
+	if rule.Trigger != nil {
+		variables["trigger"] = map[string]any{
+			"type":  string(rule.Trigger.Type),
+			"globs": rule.Trigger.Globs,
+		}
+	}
+
+	for key, value := range rule.Variables {
+		// Convert string booleans to actual booleans for proper template logic
+		if strVal, ok := value.(string); ok {
+			switch strings.ToLower(strVal) {
+			case "true", "1", "yes":
+				variables[key] = true
+			case "false", "0", "no":
+				variables[key] = false
+			default:
+				variables[key] = value
+			}
+		} else {
+			variables[key] = value
+		}
+	}
+
+	maps.Copy(variables, additional)
+	return variables
+}
+
+// Content renders templateContent for rule with engine
+func Content(engine template.Engine, rule *domain.Rule, templateContent string, additional map[string]any) (string, error) {
+	return engine.Render(templateContent, TemplateVariables(rule, additional))
+}
+
+// Preview parses a rule file and renders its content as a build would, with
+// overrides taking the place of the variables in a rule ID
+func Preview(content string, overrides map[string]any) (*domain.Rule, string, error) {
+	rule := &domain.Rule{Variables: maps.Clone(overrides)}
+	if _, err := ParseRule(content, rule); err != nil {
+		return nil, "", err
+	}
+	// Fetchers apply ID variables over the frontmatter defaults
+	maps.Copy(rule.Variables, overrides)
+
+	variables := RuleVariables(rule, &domain.RuleContext{}, time.Now())
+	rendered, err := Content(template.NewEngine(), rule, rule.Content, variables)
+	if err != nil {
+		return nil, "", err
+	}
+	return rule, rendered, nil
+}
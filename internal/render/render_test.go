@@ -0,0 +1,97 @@
+package render
+
+import (
+	"testing"
+	"time"
+
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testRule = `---
+title: Testing
+description: How to test
+tags: [testing]
+priority: 5
+owner: "@acme/qa"
+variables:
+  framework: testify
+  strict: "yes"
+---
+Use {{.framework}}.{{if .strict}} Always.{{end}}
+`
+
+func TestSplitFrontmatter(t *testing.T) {
+	t.Parallel()
+
+	frontmatter, body, err := SplitFrontmatter(testRule)
+	require.NoError(t, err)
+	assert.Equal(t, "Testing", frontmatter["title"])
+	assert.Equal(t, "Use {{.framework}}.{{if .strict}} Always.{{end}}", body)
+
+	frontmatter, body, err = SplitFrontmatter("# Plain\n\nNo frontmatter.")
+	require.NoError(t, err)
+	assert.Nil(t, frontmatter)
+	assert.Equal(t, "# Plain\n\nNo frontmatter.", body)
+
+	_, _, err = SplitFrontmatter("---\ntitle: Unclosed\n")
+	require.Error(t, err)
+}
+
+func TestParseRule(t *testing.T) {
+	t.Parallel()
+
+	rule := &domain.Rule{ID: "[contexture:testing]", Variables: map[string]any{"framework": "gotest", "extra": 1}}
+	hasFrontmatter, err := ParseRule(testRule, rule)
+	require.NoError(t, err)
+	assert.True(t, hasFrontmatter)
+	assert.Equal(t, "[contexture:testing]", rule.ID)
+	assert.Equal(t, "Testing", rule.Title)
+	assert.Equal(t, []string{"testing"}, rule.Tags)
+	assert.Equal(t, 5, rule.Priority)
+	assert.Equal(t, "@acme/qa", rule.Owner)
+	assert.Equal(t, map[string]any{"framework": "testify", "strict": "yes"}, rule.DefaultVariables)
+	// Frontmatter variables take precedence over ID variables
+	assert.Equal(t, map[string]any{"framework": "testify", "strict": "yes", "extra": 1}, rule.Variables)
+
+	rule = &domain.Rule{}
+	hasFrontmatter, err = ParseRule("Just content.", rule)
+	require.NoError(t, err)
+	assert.False(t, hasFrontmatter)
+	assert.Equal(t, "Just content.", rule.Content)
+}
+
+func TestRuleVariables(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 3, 4, 9, 10, 11, 0, time.UTC)
+	rule := &domain.Rule{ID: "r", Title: "Rule", Variables: map[string]any{"shared": "rule"}}
+	context := &domain.RuleContext{
+		Globals:   map[string]any{"shared": "global", "global": true},
+		Variables: map[string]any{"shared": "context"},
+	}
+
+	variables := RuleVariables(rule, context, now)
+	assert.Equal(t, "rule", variables["shared"])
+	assert.Equal(t, true, variables["global"])
+	assert.Equal(t, "2025-03-04", variables["date"])
+	assert.Equal(t, "Rule", variables["rule"].(map[string]any)["title"])
+	assert.Equal(t, "go", variables["contexture"].(map[string]any)["engine"])
+}
+
+func TestPreview(t *testing.T) {
+	t.Parallel()
+
+	rule, content, err := Preview(testRule, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Testing", rule.Title)
+	assert.Equal(t, "Use testify. Always.", content)
+
+	_, content, err = Preview(testRule, map[string]any{"framework": "gotest", "strict": false})
+	require.NoError(t, err)
+	assert.Equal(t, "Use gotest.", content)
+
+	_, _, err = Preview("---\ntitle: Broken\n---\n{{.missing", nil)
+	require.Error(t, err)
+}
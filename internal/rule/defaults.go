@@ -0,0 +1,116 @@
+package rule
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/contextureai/contexture/internal/validation"
+)
+
+// applyPlainMarkdownDefaults fills in the metadata of a local rule written as
+// plain markdown without frontmatter. The first level-one heading becomes the
+// title and is removed from the content, the first paragraph becomes the
+// description, and the rule is always applied.
+func applyPlainMarkdownDefaults(rule *domain.Rule, filePath string) {
+	lines := strings.Split(rule.Content, "\n")
+
+	// Only a heading on the first line is the title; others belong to the content
+	if len(lines) > 0 && strings.HasPrefix(lines[0], "# ") {
+		rule.Title = strings.TrimSpace(strings.TrimPrefix(lines[0], "# "))
+		rule.Content = strings.TrimSpace(strings.Join(lines[1:], "\n"))
+		lines = lines[1:]
+	}
+	if rule.Title == "" {
+		rule.Title = titleFromPath(filePath)
+	}
+	rule.Title = truncateText(rule.Title, validation.MaxTitleLength)
+	rule.Description = truncateText(firstParagraph(lines), validation.MaxDescriptionLength)
+	rule.Trigger = &domain.RuleTrigger{Type: domain.TriggerAlways}
+}
+
+// titleFromPath derives a title from a rule file name, so "go/error-handling"
+// becomes "Error Handling"
+func titleFromPath(filePath string) string {
+	name := strings.TrimSuffix(path.Base(filepath.ToSlash(filePath)), domain.MarkdownExt)
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return r == '-' || r == '_' || r == ' '
+	})
+	for i, word := range words {
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		words[i] = string(runes)
+	}
+	return strings.Join(words, " ")
+}
+
+// firstParagraph returns the first paragraph of prose in lines, joined into
+// one line. Headings, lists, quotes, tables, and code blocks are skipped.
+func firstParagraph(lines []string) string {
+	var paragraph []string
+	inFence := false
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			if len(paragraph) > 0 {
+				break
+			}
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if trimmed == "" {
+			if len(paragraph) > 0 {
+				break
+			}
+			continue
+		}
+		if len(paragraph) == 0 && !isProse(trimmed) {
+			continue
+		}
+		paragraph = append(paragraph, trimmed)
+	}
+	return strings.Join(paragraph, " ")
+}
+
+// isProse reports whether a line starts a paragraph of text rather than
+// other markdown blocks
+func isProse(line string) bool {
+	for _, prefix := range []string{"#", "- ", "* ", "+ ", ">", "|", ":::", "{{", "<"} {
+		if strings.HasPrefix(line, prefix) {
+			return false
+		}
+	}
+	// Ordered list items such as "1. Step"
+	digits := strings.TrimLeftFunc(line, unicode.IsDigit)
+	return len(digits) == len(line) || !strings.HasPrefix(digits, ". ")
+}
+
+// truncateText shortens text to at most limit characters, cutting at a word
+// boundary and marking the cut with an ellipsis
+func truncateText(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	cut := string(runes[:limit-1])
+	if i := strings.LastIndex(cut, " "); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " ,;:.") + "…"
+}
+
+// DescriptionFromContent derives a rule description from markdown content:
+// its first paragraph of prose, shortened to the description limit
+func DescriptionFromContent(content string) string {
+	return truncateText(firstParagraph(strings.Split(content, "\n")), validation.MaxDescriptionLength)
+}
+
+// ShortenTitle shortens a heading to the rule title limit
+func ShortenTitle(title string) string {
+	return truncateText(strings.TrimSpace(title), validation.MaxTitleLength)
+}
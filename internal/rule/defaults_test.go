@@ -0,0 +1,91 @@
+package rule
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/contextureai/contexture/internal/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestYAMLParser_ParseRule_PlainMarkdown(t *testing.T) {
+	t.Parallel()
+	parser := NewParser()
+	local := Metadata{ID: "[contexture(local):go/error-handling]", FilePath: "go/error-handling", Source: "local"}
+
+	t.Run("heading and paragraph", func(t *testing.T) {
+		t.Parallel()
+		content := "# Error Handling\n\nWrap errors with context\nbefore returning them.\n\n## Examples\n\n- Use %w\n"
+
+		rule, err := parser.ParseRule(content, local)
+		require.NoError(t, err)
+		assert.Equal(t, "Error Handling", rule.Title)
+		assert.Equal(t, "Wrap errors with context before returning them.", rule.Description)
+		assert.Equal(t, domain.TriggerAlways, rule.Trigger.Type)
+		assert.True(t, strings.HasPrefix(rule.Content, "Wrap errors"), "title heading should be removed from content")
+	})
+
+	t.Run("no heading", func(t *testing.T) {
+		t.Parallel()
+		rule, err := parser.ParseRule("- Return early\n- Keep functions short\n", local)
+		require.NoError(t, err)
+		assert.Equal(t, "Error Handling", rule.Title)
+		assert.Empty(t, rule.Description)
+		assert.Equal(t, "- Return early\n- Keep functions short", rule.Content)
+	})
+
+	t.Run("remote rules still need frontmatter", func(t *testing.T) {
+		t.Parallel()
+		_, err := parser.ParseRule("# Error Handling\n\nWrap errors.\n", Metadata{
+			ID:     "[contexture:go/error-handling]",
+			Source: "https://github.com/test/repo.git",
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestFirstParagraph(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"prose", "First line\nsecond line\n\nNext paragraph", "First line second line"},
+		{"skips headings and lists", "## Usage\n\n- item\n1. step\n\nThe text.", "The text."},
+		{"skips code blocks", "```go\nfmt.Println()\n```\n\nAfter code.", "After code."},
+		{"no prose", "- only\n- lists", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, firstParagraph(strings.Split(tt.content, "\n")))
+		})
+	}
+}
+
+func TestTitleFromPath(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "Error Handling", titleFromPath("go/error-handling"))
+	assert.Equal(t, "Team Notes", titleFromPath("/project/rules/team_notes.md"))
+}
+
+func TestTruncateText(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "short", truncateText("short", 10))
+
+	long := strings.Repeat("word ", 60)
+	truncated := truncateText(long, validation.MaxDescriptionLength)
+	assert.LessOrEqual(t, len([]rune(truncated)), validation.MaxDescriptionLength)
+	assert.True(t, strings.HasSuffix(truncated, "word…"))
+}
+
+func TestDescriptionFromContent(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "Run the tests.", DescriptionFromContent("## Testing\n\nRun the tests.\n\nMore."))
+	assert.Empty(t, DescriptionFromContent("- only a list"))
+	assert.Equal(t, "Title", ShortenTitle("  Title "))
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/charmbracelet/log"
 	"github.com/contextureai/contexture/internal/cache"
+	"github.com/contextureai/contexture/internal/dependencies"
 	"github.com/contextureai/contexture/internal/domain"
 	contextureerrors "github.com/contextureai/contexture/internal/errors"
 	"github.com/contextureai/contexture/internal/git"
@@ -36,13 +37,26 @@ func NewFetcher(fs afero.Fs, repository git.Repository, config FetcherConfig, pr
 	if config.MaxWorkers <= 0 {
 		config.MaxWorkers = domain.DefaultMaxWorkers
 	}
+	if config.LocalDir == "" {
+		config.LocalDir = "."
+	}
+	if config.Env == nil {
+		config.Env = dependencies.OSEnvironment{}
+	}
 
 	parser := NewParser()
 	idParser := NewRuleIDParser(config.DefaultURL, providerRegistry)
-	simpleCache := cache.NewSimpleCache(fs, repository)
+	simpleCache := cache.NewSimpleCache(fs, config.Env, repository)
+	if providerRegistry != nil {
+		simpleCache.SetMirrors(providerRegistry)
+	}
 
 	gitFetcher := NewGitRuleFetcher(fs, parser, simpleCache, repository, idParser)
-	localFetcher := NewLocalFetcher(fs, ".")
+	gitFetcher.SetBlobCache(cache.NewBlobCache(fs, config.Env, cache.DefaultBlobCacheEntries))
+	localFetcher := NewLocalFetcher(fs, config.LocalDir)
+	if config.WorkingDir != nil {
+		localFetcher.SetWorkingDir(config.WorkingDir)
+	}
 
 	return &CompositeFetcher{
 		gitFetcher:   gitFetcher,
@@ -240,6 +240,64 @@ func TestGitFetcher_FetchRule(t *testing.T) {
 	assert.Equal(t, "main", rule.Ref)
 }
 
+func TestGitFetcher_FetchRule_IndexDefaults(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	mockRepo := git.NewMockRepository(t)
+
+	fetcher := NewFetcher(fs, mockRepo, FetcherConfig{
+		DefaultURL: "https://github.com/contextureai/rules.git",
+	}, provider.NewRegistry())
+
+	mockRepo.On("Clone", mock.Anything, "https://github.com/contextureai/rules.git", mock.AnythingOfType("string"), mock.AnythingOfType("[]git.CloneOption")).
+		Run(func(args mock.Arguments) {
+			tempPath := args.Get(2).(string)
+			_ = fs.MkdirAll(tempPath+"/go", 0o755)
+			_ = afero.WriteFile(fs, tempPath+"/go/testing.md",
+				[]byte("---\ntitle: Testing\ndescription: Testing rule\ntags: [go]\n"+
+					"variables:\n  coverage: 80\n  framework: testing\n---\n\n# Testing"),
+				0o644)
+			_ = afero.WriteFile(fs, tempPath+"/index.yaml",
+				[]byte("name: rules\nvariables:\n  framework: testify\n  unused: true\n"+
+					"rules:\n  - id: go/testing\n    variables:\n      coverage: 90\n"),
+				0o644)
+		}).
+		Return(nil)
+
+	rule, err := fetcher.FetchRule(context.Background(), "[contexture:go/testing]")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"coverage": 90, "framework": "testify"}, rule.Variables)
+	assert.Equal(t, rule.Variables, rule.DefaultVariables)
+	assert.Equal(t, domain.VariableOriginProvider, rule.VariableOrigin("coverage"))
+}
+
+func TestGitFetcher_FetchRuleAtCommit_CachesFiles(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	mockRepo := git.NewMockRepository(t)
+	commit := "3f2a9c1e8b7d6a5f4e3d2c1b0a9f8e7d6c5b4a39"
+
+	mockRepo.On("Clone", mock.Anything, "https://github.com/contextureai/rules.git", mock.AnythingOfType("string"), mock.AnythingOfType("[]git.CloneOption")).
+		Run(func(args mock.Arguments) {
+			_ = fs.MkdirAll(args.Get(2).(string)+"/.git", 0o755)
+		}).
+		Return(nil).Once()
+	mockRepo.On("GetFileAtCommit", mock.AnythingOfType("string"), "go/testing.md", commit).
+		Return([]byte("---\ntitle: Testing\ndescription: Testing rule\ntags: [go]\n---\n\n# Testing"), nil).Once()
+	mockRepo.On("GetFileAtCommit", mock.AnythingOfType("string"), "index.yaml", commit).
+		Return([]byte("name: rules\n"), nil).Once()
+
+	// Each fetcher stands for a separate invocation of the CLI
+	for range 2 {
+		fetcher := NewFetcher(fs, mockRepo, FetcherConfig{
+			DefaultURL: "https://github.com/contextureai/rules.git",
+		}, provider.NewRegistry())
+		rule, err := fetcher.(CommitFetcher).FetchRuleAtCommit(context.Background(), "[contexture:go/testing]", commit)
+		require.NoError(t, err)
+		assert.Contains(t, rule.Content, "# Testing")
+	}
+}
+
 func TestGitFetcher_FetchRule_NotFound(t *testing.T) {
 	t.Parallel()
 	fs := afero.NewMemMapFs()
@@ -432,6 +490,18 @@ This is a test rule content.`
 		assert.Len(t, rules, 1)
 		assert.Equal(t, "security/test-rule", rules[0])
 	})
+
+	t.Run("list skips ignored rules", func(t *testing.T) {
+		ignoreFS := afero.NewMemMapFs()
+		for _, path := range []string{"security/test-rule.md", "drafts/idea.md", "security/wip.draft.md", "scratch/notes.md"} {
+			require.NoError(t, afero.WriteFile(ignoreFS, baseDir+"/"+path, []byte(testRuleContent), 0o644))
+		}
+		require.NoError(t, afero.WriteFile(ignoreFS, baseDir+"/.contextureignore", []byte("scratch/\n"), 0o644))
+
+		rules, err := NewLocalFetcher(ignoreFS, baseDir).ListAvailableRules(context.Background(), "", "")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"security/test-rule"}, rules)
+	})
 }
 
 func TestExtractRuleIDsFromContent(t *testing.T) {
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/charmbracelet/log"
 	"github.com/contextureai/contexture/internal/cache"
@@ -12,6 +13,7 @@ import (
 	contextureerrors "github.com/contextureai/contexture/internal/errors"
 	"github.com/contextureai/contexture/internal/git"
 	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
 )
 
 // GitRuleFetcher handles fetching rules from Git repositories
@@ -21,6 +23,10 @@ type GitRuleFetcher struct {
 	cache    *cache.SimpleCache
 	repo     git.Repository
 	idParser IDParser
+	// indexes caches the repository indexes read, by directory and commit
+	indexes sync.Map
+	// blobs caches the files read at a commit across invocations, when set
+	blobs *cache.BlobCache
 }
 
 // NewGitRuleFetcher creates a new Git rule fetcher
@@ -40,6 +46,12 @@ func NewGitRuleFetcher(
 	}
 }
 
+// SetBlobCache makes reads of files at a commit go through blobs, so
+// commands inspecting pinned rules do not read the repository again
+func (f *GitRuleFetcher) SetBlobCache(blobs *cache.BlobCache) {
+	f.blobs = blobs
+}
+
 // FetchRule fetches a single rule from Git
 func (f *GitRuleFetcher) FetchRule(ctx context.Context, ruleID string) (*domain.Rule, error) {
 	log.Debug("Fetching rule from Git", "ruleID", ruleID)
@@ -85,15 +97,13 @@ func (f *GitRuleFetcher) FetchRule(ctx context.Context, ruleID string) (*domain.
 	rule.Source = parsed.Source
 	rule.Ref = parsed.Ref
 	rule.FilePath = parsed.RulePath
+	rule.RepoDir = repoDir
 
-	// Merge variables from parsed ID with rule variables
-	if len(parsed.Variables) > 0 {
-		if rule.Variables == nil {
-			rule.Variables = make(map[string]any)
-		}
-		for key, value := range parsed.Variables {
-			rule.Variables[key] = value
-		}
+	// Defaults from the repository index take precedence over the frontmatter,
+	// and variables from the parsed ID over both
+	ApplyDefaults(rule, IndexDefaults(f.repositoryIndex(repoDir, parsed.Source, ""), parsed.RulePath), domain.VariableOriginProvider)
+	for key, value := range parsed.Variables {
+		SetVariable(rule, key, value, domain.VariableOriginProject)
 	}
 
 	log.Debug("Successfully fetched rule from Git", "ruleID", ruleID)
@@ -116,13 +126,7 @@ func (f *GitRuleFetcher) FetchRuleAtCommit(ctx context.Context, ruleID, commitHa
 		return nil, contextureerrors.WithOp("FetchRuleAtCommit.GetRepository", err)
 	}
 
-	// Read the rule file at the specific commit using the injected repository implementation
-	repo := f.repo
-	if repo == nil {
-		repo = git.NewRepository(f.fs)
-	}
-	ruleFilePath := parsed.RulePath + ".md"
-	data, err := repo.GetFileAtCommit(repoDir, ruleFilePath, commitHash)
+	data, err := f.fileAtCommit(repoDir, parsed.Source, parsed.RulePath+".md", commitHash)
 	if err != nil {
 		return nil, contextureerrors.WithOp("FetchRuleAtCommit.GetFileAtCommit", err)
 	}
@@ -144,21 +148,64 @@ func (f *GitRuleFetcher) FetchRuleAtCommit(ctx context.Context, ruleID, commitHa
 	rule.Source = parsed.Source
 	rule.Ref = parsed.Ref
 	rule.FilePath = parsed.RulePath
+	rule.RepoDir = repoDir
 
-	// Merge variables from parsed ID with rule variables
-	if len(parsed.Variables) > 0 {
-		if rule.Variables == nil {
-			rule.Variables = make(map[string]any)
-		}
-		for key, value := range parsed.Variables {
-			rule.Variables[key] = value
-		}
+	// Defaults from the repository index take precedence over the frontmatter,
+	// and variables from the parsed ID over both
+	ApplyDefaults(rule, IndexDefaults(f.repositoryIndex(repoDir, parsed.Source, commitHash), parsed.RulePath), domain.VariableOriginProvider)
+	for key, value := range parsed.Variables {
+		SetVariable(rule, key, value, domain.VariableOriginProject)
 	}
 
 	log.Debug("Successfully fetched rule at commit", "ruleID", ruleID, "commitHash", commitHash)
 	return rule, nil
 }
 
+// fileAtCommit reads path in the repository at repoDir, cloned from source,
+// at commitHash, using the injected repository implementation
+func (f *GitRuleFetcher) fileAtCommit(repoDir, source, path, commitHash string) ([]byte, error) {
+	read := func() ([]byte, error) {
+		repo := f.repo
+		if repo == nil {
+			repo = git.NewRepository(f.fs)
+		}
+		return repo.GetFileAtCommit(repoDir, path, commitHash)
+	}
+	if f.blobs == nil {
+		return read()
+	}
+	return f.blobs.GetOrFetch(source, commitHash, path, read)
+}
+
+// repositoryIndex returns the index of the repository at repoDir, cloned
+// from source, read at commitHash when set, or nil when the repository has
+// no valid index
+func (f *GitRuleFetcher) repositoryIndex(repoDir, source, commitHash string) *domain.RepositoryIndex {
+	key := repoDir + "@" + commitHash
+	if cached, ok := f.indexes.Load(key); ok {
+		return cached.(*domain.RepositoryIndex)
+	}
+
+	var data []byte
+	var err error
+	if commitHash != "" {
+		data, err = f.fileAtCommit(repoDir, source, domain.RepositoryIndexFile, commitHash)
+	} else {
+		data, err = afero.ReadFile(f.fs, filepath.Join(repoDir, domain.RepositoryIndexFile))
+	}
+
+	var index *domain.RepositoryIndex
+	if err == nil {
+		index = &domain.RepositoryIndex{}
+		if err := yaml.Unmarshal(data, index); err != nil {
+			log.Warn("Ignoring invalid repository index", "repository", repoDir, "error", err)
+			index = nil
+		}
+	}
+	f.indexes.Store(key, index)
+	return index
+}
+
 // ListAvailableRules lists all available rules in a Git repository
 func (f *GitRuleFetcher) ListAvailableRules(
 	ctx context.Context,
@@ -4,6 +4,7 @@ package rule
 import (
 	"context"
 
+	"github.com/contextureai/contexture/internal/dependencies"
 	"github.com/contextureai/contexture/internal/domain"
 )
 
@@ -54,6 +55,15 @@ type Processor interface {
 type FetcherConfig struct {
 	DefaultURL string
 	MaxWorkers int
+	// LocalDir is the project directory local rules are resolved from; it
+	// defaults to the working directory
+	LocalDir string
+	// WorkingDir provides the working directory; it defaults to the process
+	// working directory
+	WorkingDir dependencies.WorkingDir
+	// Env provides the environment the cache directory is read from; it
+	// defaults to the process environment
+	Env dependencies.Environment
 }
 
 // Metadata contains metadata about a rule file
@@ -9,8 +9,10 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/log"
+	"github.com/contextureai/contexture/internal/dependencies"
 	"github.com/contextureai/contexture/internal/domain"
 	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/globs"
 	"github.com/spf13/afero"
 	"github.com/titanous/json5"
 )
@@ -20,20 +22,28 @@ var localVariablesPattern = domain.VariablesPatternRegex
 
 // LocalFetcher implements rule fetching from local filesystem
 type LocalFetcher struct {
-	fs      afero.Fs
-	baseDir string
-	parser  Parser
+	fs         afero.Fs
+	baseDir    string
+	workingDir dependencies.WorkingDir
+	parser     Parser
 }
 
 // NewLocalFetcher creates a fetcher that reads rules from local filesystem
 func NewLocalFetcher(fs afero.Fs, baseDir string) *LocalFetcher {
 	return &LocalFetcher{
-		fs:      fs,
-		baseDir: baseDir,
-		parser:  NewParser(),
+		fs:         fs,
+		baseDir:    baseDir,
+		workingDir: dependencies.OSWorkingDir{},
+		parser:     NewParser(),
 	}
 }
 
+// SetWorkingDir sets the directory a base directory of "." resolves to,
+// instead of the process working directory
+func (f *LocalFetcher) SetWorkingDir(workingDir dependencies.WorkingDir) {
+	f.workingDir = workingDir
+}
+
 // ParseRuleID parses a local rule ID (simplified format or full format)
 func (f *LocalFetcher) ParseRuleID(ruleID string) (*domain.ParsedRuleID, error) {
 	// Handle full format [contexture(local):path] or [contexture(local):path,ref]{variables}
@@ -109,7 +119,7 @@ func (f *LocalFetcher) FetchRule(_ context.Context, ruleID string) (*domain.Rule
 			rulePath += ".md"
 		}
 		// Find the rules directory by looking for .contexture/rules or just rules in the path
-		// For global local rules, this will be ~/.contexture/rules/
+		// For global local rules, this will be ~/.config/contexture/rules/
 		switch {
 		case strings.Contains(rulePath, filepath.Join(domain.ContextureDir, domain.LocalRulesDir)):
 			// Path contains .contexture/rules
@@ -184,6 +194,7 @@ func (f *LocalFetcher) FetchRule(_ context.Context, ruleID string) (*domain.Rule
 	if err != nil {
 		return nil, contextureerrors.WithOp("FetchRule", err)
 	}
+	rule.RepoDir = rulesDir
 
 	log.Debug("Successfully fetched local rule", "ruleID", ruleID)
 	return rule, nil
@@ -230,6 +241,11 @@ func (f *LocalFetcher) ListAvailableRules(
 		return []string{}, nil // Return empty slice if directory doesn't exist
 	}
 
+	ignore, err := globs.LoadIgnore(f.fs, filepath.Join(rulesDir, domain.IgnoreFile))
+	if err != nil {
+		return nil, contextureerrors.WithOp("ListAvailableRules", err)
+	}
+
 	// For local fetcher, ignore source and branch
 	var ruleFiles []string
 
@@ -238,12 +254,22 @@ func (f *LocalFetcher) ListAvailableRules(
 			return err
 		}
 
+		if info.IsDir() && path != rulesDir {
+			if relPath, err := filepath.Rel(rulesDir, path); err == nil && ignore.Ignored(filepath.ToSlash(relPath), true) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
 		if !info.IsDir() && strings.HasSuffix(path, ".md") {
 			// Get relative path from rules directory
 			relPath, err := filepath.Rel(rulesDir, path)
 			if err != nil {
 				return err
 			}
+			if ignore.Ignored(filepath.ToSlash(relPath), false) {
+				return nil
+			}
 
 			// Remove .md extension
 			ruleID := strings.TrimSuffix(relPath, ".md")
@@ -280,7 +306,7 @@ func (f *LocalFetcher) findRulesDirectory() (string, error) {
 	currentDir := f.baseDir
 	if currentDir == "." {
 		var err error
-		currentDir, err = os.Getwd()
+		currentDir, err = f.workingDir.Getwd()
 		if err != nil {
 			return "", contextureerrors.WithOp("findRulesDirectory", err)
 		}
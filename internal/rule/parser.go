@@ -1,14 +1,13 @@
 package rule
 
 import (
-	"maps"
 	"strings"
 
 	"github.com/charmbracelet/log"
 	"github.com/contextureai/contexture/internal/domain"
 	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/render"
 	"github.com/contextureai/contexture/internal/validation"
-	"gopkg.in/yaml.v3"
 )
 
 // YAMLParser provides a cleaner implementation of the parser
@@ -36,30 +35,25 @@ func NewParser() Parser {
 
 // ParseRule parses a rule from content with metadata
 func (p *YAMLParser) ParseRule(content string, metadata Metadata) (*domain.Rule, error) {
-	// Parse frontmatter and body
-	frontmatter, body, err := p.ParseContent(content)
-	if err != nil {
-		return nil, contextureerrors.Wrap(err, "parse rule content")
-	}
-
 	// Create rule with metadata
 	rule := &domain.Rule{
 		ID:        metadata.ID,
 		FilePath:  metadata.FilePath,
 		Source:    metadata.Source,
 		Ref:       metadata.Ref,
-		Content:   body,
 		Variables: metadata.Variables,
 	}
 
-	// Use struct-based frontmatter parsing
-	fm := &ruleFrontmatter{}
-	if err := p.unmarshalFrontmatter(frontmatter, fm); err != nil {
-		return nil, contextureerrors.Wrap(err, "parse frontmatter")
+	// Parse frontmatter and body into the rule
+	hasFrontmatter, err := render.ParseRule(content, rule)
+	if err != nil {
+		return nil, err
 	}
 
-	// Map frontmatter to rule
-	p.mapFrontmatterToRule(fm, rule)
+	// Local rules may be plain markdown notes without frontmatter
+	if !hasFrontmatter && metadata.Source == "local" {
+		applyPlainMarkdownDefaults(rule, metadata.FilePath)
+	}
 
 	// Validate rule
 	if err := p.ValidateRule(rule); err != nil {
@@ -69,45 +63,9 @@ func (p *YAMLParser) ParseRule(content string, metadata Metadata) (*domain.Rule,
 	return rule, nil
 }
 
-// ruleFrontmatter represents the expected frontmatter structure
-type ruleFrontmatter struct {
-	Title       string              `yaml:"title"`
-	Description string              `yaml:"description"`
-	Tags        []string            `yaml:"tags"`
-	Trigger     *domain.RuleTrigger `yaml:"trigger,omitempty"`
-	Languages   []string            `yaml:"languages,omitempty"`
-	Frameworks  []string            `yaml:"frameworks,omitempty"`
-	Variables   map[string]any      `yaml:"variables,omitempty"`
-}
-
 // ParseContent parses frontmatter and body from content
 func (p *YAMLParser) ParseContent(content string) (map[string]any, string, error) {
-	// Trim whitespace
-	content = strings.TrimSpace(content)
-
-	// Check for frontmatter
-	if !strings.HasPrefix(content, "---") {
-		return nil, content, nil
-	}
-
-	// Remove leading ---
-	content = strings.TrimPrefix(content, "---\n")
-
-	// Find end of frontmatter
-	parts := strings.SplitN(content, "\n---\n", 2)
-	if len(parts) != 2 {
-		return nil, "", contextureerrors.WithOpf("parse content", "invalid frontmatter format: missing closing ---")
-	}
-
-	// Parse YAML frontmatter
-	var frontmatter map[string]any
-	if err := yaml.Unmarshal([]byte(parts[0]), &frontmatter); err != nil {
-		return nil, "", contextureerrors.Wrap(err, "parse YAML frontmatter")
-	}
-
-	// Return frontmatter and body
-	body := parts[1]
-	return frontmatter, body, nil
+	return render.SplitFrontmatter(content)
 }
 
 // ValidateRule validates a rule
@@ -140,48 +98,6 @@ func (f *FailsafeParser) ValidateRule(_ *domain.Rule) error {
 	return contextureerrors.Wrap(f.err, "parser initialization failed")
 }
 
-// unmarshalFrontmatter unmarshals frontmatter into a struct
-func (p *YAMLParser) unmarshalFrontmatter(
-	data map[string]any,
-	fm *ruleFrontmatter,
-) error {
-	// Convert map to YAML bytes then unmarshal to struct
-	yamlBytes, err := yaml.Marshal(data)
-	if err != nil {
-		return contextureerrors.Wrap(err, "marshal frontmatter")
-	}
-
-	if err := yaml.Unmarshal(yamlBytes, fm); err != nil {
-		return contextureerrors.Wrap(err, "unmarshal frontmatter")
-	}
-
-	return nil
-}
-
-// mapFrontmatterToRule maps frontmatter struct to rule
-func (p *YAMLParser) mapFrontmatterToRule(fm *ruleFrontmatter, rule *domain.Rule) {
-	rule.Title = fm.Title
-	rule.Description = fm.Description
-	rule.Tags = fm.Tags
-	rule.Trigger = fm.Trigger
-	rule.Languages = fm.Languages
-	rule.Frameworks = fm.Frameworks
-
-	// Store default variables from frontmatter
-	if fm.Variables != nil {
-		rule.DefaultVariables = make(map[string]any)
-		maps.Copy(rule.DefaultVariables, fm.Variables)
-	}
-
-	// Merge variables - frontmatter takes precedence
-	if fm.Variables != nil {
-		if rule.Variables == nil {
-			rule.Variables = make(map[string]any)
-		}
-		maps.Copy(rule.Variables, fm.Variables)
-	}
-}
-
 // parseTrigger parses trigger configuration from frontmatter
 func (p *YAMLParser) parseTrigger(trigger any) (*domain.RuleTrigger, error) {
 	if trigger == nil {
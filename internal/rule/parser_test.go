@@ -145,6 +145,63 @@ Always validate user input to prevent security vulnerabilities.
 		assert.Equal(t, "security", rule.Variables["category"])
 	})
 
+	t.Run("tests block", func(t *testing.T) {
+		content := `---
+title: "Test Rule"
+description: "Test description"
+tags: ["test"]
+tests:
+  - name: strict mode
+    variables:
+      strict: true
+    contains: ["Never panic"]
+    not_contains: ["TODO"]
+---
+
+Content here.`
+
+		rule, err := parser.ParseRule(content, metadata)
+		require.NoError(t, err)
+		require.Len(t, rule.Tests, 1)
+		assert.Equal(t, domain.RuleTest{
+			Name:        "strict mode",
+			Variables:   map[string]any{"strict": true},
+			Contains:    []string{"Never panic"},
+			NotContains: []string{"TODO"},
+		}, rule.Tests[0])
+	})
+
+	t.Run("command kind", func(t *testing.T) {
+		content := `---
+title: "Review PR"
+description: "Review the current pull request"
+tags: ["workflow"]
+kind: command
+---
+
+Review the changes on this branch.`
+
+		rule, err := parser.ParseRule(content, metadata)
+		require.NoError(t, err)
+		assert.Equal(t, domain.RuleKindCommand, rule.Kind)
+		assert.True(t, rule.IsCommand())
+	})
+
+	t.Run("invalid kind", func(t *testing.T) {
+		content := `---
+title: "Test Rule"
+description: "Test description"
+tags: ["test"]
+kind: macro
+---
+
+Content here.`
+
+		_, err := parser.ParseRule(content, metadata)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "kind")
+	})
+
 	t.Run("missing required fields", func(t *testing.T) {
 		invalidContent := `---
 title: "Test Rule"
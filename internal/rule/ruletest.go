@@ -0,0 +1,44 @@
+package rule
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+)
+
+// RunTest renders a rule with a test's variables layered over the rule's defaults
+// and returns a message for each assertion that failed
+func RunTest(processor Processor, rule *domain.Rule, test domain.RuleTest) ([]string, error) {
+	variables := make(map[string]any, len(rule.DefaultVariables)+len(test.Variables))
+	maps.Copy(variables, rule.DefaultVariables)
+	maps.Copy(variables, test.Variables)
+
+	testRule := *rule
+	testRule.Variables = variables
+
+	processed, err := processor.ProcessRule(&testRule, &domain.RuleContext{})
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "process rule")
+	}
+	content, err := processor.ProcessTemplate(testRule.Content, processed.Variables)
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "render rule")
+	}
+
+	var failures []string
+	for _, expected := range test.Contains {
+		if !strings.Contains(content, expected) {
+			failures = append(failures, fmt.Sprintf("expected output to contain %q", expected))
+		}
+	}
+	for _, unexpected := range test.NotContains {
+		if strings.Contains(content, unexpected) {
+			failures = append(failures, fmt.Sprintf("expected output not to contain %q", unexpected))
+		}
+	}
+
+	return failures, nil
+}
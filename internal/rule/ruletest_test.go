@@ -0,0 +1,70 @@
+package rule
+
+import (
+	"testing"
+
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunTest(t *testing.T) {
+	t.Parallel()
+	processor := NewProcessor()
+
+	rule := &domain.Rule{
+		ID:               "[contexture(local):team/style]",
+		Title:            "Team Style",
+		Content:          "Indent with {{.indent}}.{{if .strict}} Never use panics.{{end}}",
+		DefaultVariables: map[string]any{"indent": "tabs", "strict": false},
+	}
+
+	tests := []struct {
+		name     string
+		test     domain.RuleTest
+		failures []string
+	}{
+		{
+			name: "defaults",
+			test: domain.RuleTest{
+				Contains:    []string{"Indent with tabs."},
+				NotContains: []string{"panics"},
+			},
+		},
+		{
+			name: "variables override defaults",
+			test: domain.RuleTest{
+				Variables: map[string]any{"indent": "spaces", "strict": true},
+				Contains:  []string{"Indent with spaces.", "Never use panics."},
+			},
+		},
+		{
+			name: "failed assertions",
+			test: domain.RuleTest{
+				Contains:    []string{"spaces"},
+				NotContains: []string{"tabs"},
+			},
+			failures: []string{
+				`expected output to contain "spaces"`,
+				`expected output not to contain "tabs"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			failures, err := RunTest(processor, rule, tt.test)
+			require.NoError(t, err)
+			assert.Equal(t, tt.failures, failures)
+		})
+	}
+
+	t.Run("render error", func(t *testing.T) {
+		t.Parallel()
+		broken := *rule
+		broken.Content = "{{.indent"
+		_, err := RunTest(processor, &broken, domain.RuleTest{})
+		require.Error(t, err)
+	})
+}
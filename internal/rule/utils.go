@@ -18,6 +18,19 @@ func FetchRulesParallel(
 	fetcher Fetcher,
 	ruleRefs []domain.RuleRef,
 	maxWorkers int,
+) ([]*domain.Rule, error) {
+	return FetchRulesWithPreset(ctx, fetcher, ruleRefs, maxWorkers, nil)
+}
+
+// FetchRulesWithPreset fetches rules in parallel like FetchRulesParallel,
+// setting the variables the rules declare from preset unless their
+// provider's index or their configuration sets them
+func FetchRulesWithPreset(
+	ctx context.Context,
+	fetcher Fetcher,
+	ruleRefs []domain.RuleRef,
+	maxWorkers int,
+	preset map[string]any,
 ) ([]*domain.Rule, error) {
 	if maxWorkers <= 0 {
 		maxWorkers = domain.DefaultMaxWorkers
@@ -44,45 +57,24 @@ func FetchRulesParallel(
 			semaphore <- struct{}{}
 			defer func() { <-semaphore }()
 
-			// Fetch rule - use specific commit hash if available
-			var rule *domain.Rule
-			var err error
-
-			if ref.CommitHash != "" {
-				if commitSourceFetcher, ok := fetcher.(CommitAwareFetcher); ok {
-					rule, err = commitSourceFetcher.FetchRuleAtCommitWithSource(ctx, ref.ID, ref.CommitHash, ref.Source)
-				} else if commitFetcher, ok := fetcher.(CommitFetcher); ok {
-					rule, err = commitFetcher.FetchRuleAtCommit(ctx, ref.ID, ref.CommitHash)
-				} else {
-					// Fallback to regular fetch for other fetcher types
-					rule, err = fetcher.FetchRule(ctx, ref.ID)
-				}
-			} else {
-				// Regular fetch without commit hash, use source-aware method if available
-				if sourceFetcher, ok := fetcher.(SourceAwareFetcher); ok {
-					rule, err = sourceFetcher.FetchRuleWithSource(ctx, ref.ID, ref.Source)
-				} else {
-					// Fallback to regular fetch for other fetcher types
-					rule, err = fetcher.FetchRule(ctx, ref.ID)
-				}
-			}
-
+			rule, err := FetchRuleRef(ctx, fetcher, ref)
 			if err != nil {
 				results <- result{rule: nil, err: err, id: ref.ID}
 				return
 			}
 
 			// Merge variables from RuleRef with fetched rule
-			// RuleRef variables take precedence over rule variables
-			if len(ref.Variables) > 0 {
-				if rule.Variables == nil {
-					rule.Variables = make(map[string]any)
-				}
-				for key, value := range ref.Variables {
-					rule.Variables[key] = value
-				}
+			// RuleRef variables take precedence over preset and rule variables
+			ApplyDefaults(rule, preset, domain.VariableOriginPreset)
+			for key, value := range ref.Variables {
+				SetVariable(rule, key, value, domain.VariableOriginProject)
 			}
 
+			if ref.Priority != nil {
+				rule.Priority = *ref.Priority
+			}
+			rule.Section = ref.Section
+
 			results <- result{rule: rule, err: nil, id: ref.ID}
 		}(ruleRef)
 	}
@@ -112,6 +104,27 @@ func FetchRulesParallel(
 	return rules, nil
 }
 
+// FetchRuleRef fetches the rule ref points to, at its commit hash when it has
+// one, honoring its source when the fetcher supports it
+func FetchRuleRef(ctx context.Context, fetcher Fetcher, ref domain.RuleRef) (*domain.Rule, error) {
+	if ref.CommitHash != "" {
+		if commitSourceFetcher, ok := fetcher.(CommitAwareFetcher); ok {
+			return commitSourceFetcher.FetchRuleAtCommitWithSource(ctx, ref.ID, ref.CommitHash, ref.Source)
+		}
+		if commitFetcher, ok := fetcher.(CommitFetcher); ok {
+			return commitFetcher.FetchRuleAtCommit(ctx, ref.ID, ref.CommitHash)
+		}
+		// Fallback to regular fetch for other fetcher types
+		return fetcher.FetchRule(ctx, ref.ID)
+	}
+
+	// Regular fetch without commit hash, use source-aware method if available
+	if sourceFetcher, ok := fetcher.(SourceAwareFetcher); ok {
+		return sourceFetcher.FetchRuleWithSource(ctx, ref.ID, ref.Source)
+	}
+	return fetcher.FetchRule(ctx, ref.ID)
+}
+
 // ExtractRuleIDsFromContent finds all rule IDs in the given content
 func ExtractRuleIDsFromContent(content string) []string {
 	re := domain.RuleIDExtractPatternRegex
@@ -222,7 +235,8 @@ func FilterNonDefaultVariables(variables, defaults map[string]any) map[string]an
 	return filtered
 }
 
-// SortRulesDeterministically sorts rules by their normalized ID for consistent output
+// SortRulesDeterministically sorts rules by priority, highest first, then by
+// their normalized ID for consistent output
 // This ensures that generated files have the same order every time, preventing
 // unnecessary git diffs when rules are added/removed.
 func SortRulesDeterministically(rules []*domain.Rule, parser IDParser) []*domain.Rule {
@@ -234,9 +248,12 @@ func SortRulesDeterministically(rules []*domain.Rule, parser IDParser) []*domain
 	sorted := make([]*domain.Rule, len(rules))
 	copy(sorted, rules)
 
-	// Sort by normalized ID (case-insensitive, alphabetical)
+	// Sort by priority, then by normalized ID (case-insensitive, alphabetical)
 	// Use stable sort to preserve order for rules with same normalized ID
 	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Priority != sorted[j].Priority {
+			return sorted[i].Priority > sorted[j].Priority
+		}
 		idI := normalizeRuleIDForSort(sorted[i].ID, parser)
 		idJ := normalizeRuleIDForSort(sorted[j].ID, parser)
 		return idI < idJ
@@ -82,6 +82,21 @@ func TestSortRulesDeterministically(t *testing.T) {
 				"[contexture:security/AUTH]",
 			},
 		},
+		{
+			name: "higher priority first",
+			rules: []*domain.Rule{
+				{ID: "[contexture:languages/go/testing]"},
+				{ID: "[contexture:security/auth]", Priority: 10},
+				{ID: "[contexture:languages/go/context]", Priority: -5},
+				{ID: "[contexture:code/style]"},
+			},
+			expected: []string{
+				"[contexture:security/auth]",
+				"[contexture:code/style]",
+				"[contexture:languages/go/testing]",
+				"[contexture:languages/go/context]",
+			},
+		},
 		{
 			name: "with custom source",
 			rules: []*domain.Rule{
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/contextureai/contexture/internal/domain"
+	"github.com/contextureai/contexture/internal/render"
 	"github.com/contextureai/contexture/internal/version"
 )
 
@@ -42,36 +43,7 @@ func (vm *DefaultVariableManager) BuildVariableMap(
 	rule *domain.Rule,
 	context *domain.RuleContext,
 ) map[string]any {
-	variables := make(map[string]any)
-
-	// Add globals first (lowest precedence)
-	if context != nil && context.Globals != nil {
-		for k, v := range context.Globals {
-			variables[k] = v
-		}
-	}
-
-	// Add context variables (override globals)
-	if context != nil && context.Variables != nil {
-		for k, v := range context.Variables {
-			variables[k] = v
-		}
-	}
-
-	// Add rule variables last (highest precedence - override everything)
-	if rule.Variables != nil {
-		for k, v := range rule.Variables {
-			variables[k] = v
-		}
-	}
-
-	// Add the rule object as a map for template access
-	variables["rule"] = vm.buildRuleMap(rule)
-
-	// Add built-in variables
-	variables = vm.addBuiltinVariables(variables)
-
-	return variables
+	return render.RuleVariables(rule, context, time.Now())
 }
 
 // EnrichWithBuiltins adds built-in variables to an existing variable map.
@@ -119,63 +91,3 @@ func (vm *DefaultVariableManager) EnrichWithBuiltins(variables map[string]any) m
 
 	return enriched
 }
-
-// buildRuleMap converts a Rule struct to a map for template access
-func (vm *DefaultVariableManager) buildRuleMap(rule *domain.Rule) map[string]any {
-	ruleMap := map[string]any{
-		"id":          rule.ID,
-		"title":       rule.Title,
-		"description": rule.Description,
-		"tags":        rule.Tags,
-		"languages":   rule.Languages,
-		"frameworks":  rule.Frameworks,
-		"source":      rule.Source,
-		"ref":         rule.Ref,
-		"filepath":    rule.FilePath,
-	}
-
-	// Add trigger information if present
-	if rule.Trigger != nil {
-		ruleMap["trigger"] = map[string]any{
-			"type":  string(rule.Trigger.Type),
-			"globs": rule.Trigger.Globs,
-		}
-	}
-
-	return ruleMap
-}
-
-// addBuiltinVariables adds built-in variables to the map
-func (vm *DefaultVariableManager) addBuiltinVariables(variables map[string]any) map[string]any {
-	if variables == nil {
-		variables = make(map[string]any)
-	}
-
-	// Add contexture metadata
-	contextureInfo := make(map[string]any)
-	contextureInfo["version"] = version.GetShort()
-	contextureInfo["engine"] = "go"
-
-	// Add detailed build information
-	buildInfo := version.Get()
-	contextureInfo["build"] = map[string]any{
-		"version":   buildInfo.Version,
-		"commit":    buildInfo.Commit,
-		"date":      buildInfo.BuildDate,
-		"by":        buildInfo.BuildBy,
-		"goVersion": buildInfo.GoVersion,
-		"platform":  buildInfo.Platform,
-	}
-
-	variables["contexture"] = contextureInfo
-
-	// Add date/time helpers
-	now := time.Now()
-	variables["now"] = now
-	variables["date"] = now.Format("2006-01-02")
-	variables["time"] = now.Format("15:04:05")
-	variables["datetime"] = now.Format("2006-01-02 15:04:05")
-	variables["timestamp"] = now.Unix()
-
-	return variables
-}
@@ -0,0 +1,67 @@
+package rule
+
+import (
+	"maps"
+
+	"github.com/contextureai/contexture/internal/domain"
+)
+
+// variablePrecedence ranks the origins of variables, from the lowest precedence
+var variablePrecedence = map[domain.VariableOrigin]int{
+	domain.VariableOriginRule:     0,
+	domain.VariableOriginPreset:   1,
+	domain.VariableOriginProvider: 2,
+	domain.VariableOriginProject:  3,
+	domain.VariableOriginFlag:     4,
+}
+
+// SetVariable sets a variable of rule and records its origin. Values from an
+// origin of lower precedence than the current one are ignored.
+func SetVariable(rule *domain.Rule, name string, value any, origin domain.VariableOrigin) {
+	if _, set := rule.Variables[name]; set && variablePrecedence[rule.VariableOrigin(name)] > variablePrecedence[origin] {
+		return
+	}
+	if rule.Variables == nil {
+		rule.Variables = make(map[string]any)
+	}
+	rule.Variables[name] = value
+	if rule.VariableOrigins == nil {
+		rule.VariableOrigins = make(map[string]domain.VariableOrigin)
+	}
+	rule.VariableOrigins[name] = origin
+}
+
+// ApplyDefaults sets the variables rule declares in its frontmatter to the
+// values of a preset or a provider's index, which become the rule's defaults.
+// Variables the rule does not declare are ignored, and so are variables an
+// origin of higher precedence set.
+func ApplyDefaults(rule *domain.Rule, values map[string]any, origin domain.VariableOrigin) {
+	for name, value := range values {
+		if _, declared := rule.DefaultVariables[name]; !declared {
+			continue
+		}
+		if variablePrecedence[rule.VariableOrigin(name)] > variablePrecedence[origin] {
+			continue
+		}
+		rule.DefaultVariables[name] = value
+		SetVariable(rule, name, value, origin)
+	}
+}
+
+// IndexDefaults returns the variable defaults index publishes for the rule at
+// rulePath: the repository's variables, overridden by those of the rule's entry
+func IndexDefaults(index *domain.RepositoryIndex, rulePath string) map[string]any {
+	if index == nil {
+		return nil
+	}
+	defaults := maps.Clone(index.Variables)
+	for _, entry := range index.Rules {
+		if entry.ID == rulePath {
+			if defaults == nil {
+				defaults = make(map[string]any, len(entry.Variables))
+			}
+			maps.Copy(defaults, entry.Variables)
+		}
+	}
+	return defaults
+}
@@ -0,0 +1,44 @@
+package rule
+
+import (
+	"testing"
+
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyDefaults_Precedence(t *testing.T) {
+	t.Parallel()
+	fetched := &domain.Rule{
+		Variables:        map[string]any{"coverage": 80, "style": "loose", "framework": "testing"},
+		DefaultVariables: map[string]any{"coverage": 80, "style": "loose", "framework": "testing"},
+	}
+
+	ApplyDefaults(fetched, map[string]any{"coverage": 95}, domain.VariableOriginProvider)
+	// Presets apply beneath provider defaults, and only to declared variables
+	ApplyDefaults(fetched, map[string]any{"coverage": 90, "style": "strict", "undeclared": true}, domain.VariableOriginPreset)
+	SetVariable(fetched, "framework", "testify", domain.VariableOriginProject)
+	SetVariable(fetched, "framework", "ginkgo", domain.VariableOriginFlag)
+	SetVariable(fetched, "framework", "gotest", domain.VariableOriginProject)
+
+	assert.Equal(t, map[string]any{"coverage": 95, "style": "strict", "framework": "ginkgo"}, fetched.Variables)
+	assert.Equal(t, map[string]any{"coverage": 95, "style": "strict", "framework": "testing"}, fetched.DefaultVariables)
+	assert.Equal(t, domain.VariableOriginProvider, fetched.VariableOrigin("coverage"))
+	assert.Equal(t, domain.VariableOriginPreset, fetched.VariableOrigin("style"))
+	assert.Equal(t, domain.VariableOriginFlag, fetched.VariableOrigin("framework"))
+}
+
+func TestIndexDefaults(t *testing.T) {
+	t.Parallel()
+	index := &domain.RepositoryIndex{
+		Variables: map[string]any{"coverage": 80, "style": "strict"},
+		Rules: []domain.RepositoryIndexEntry{
+			{ID: "go/testing", Variables: map[string]any{"coverage": 95}},
+			{ID: "go/style"},
+		},
+	}
+
+	assert.Equal(t, map[string]any{"coverage": 95, "style": "strict"}, IndexDefaults(index, "go/testing"))
+	assert.Equal(t, map[string]any{"coverage": 80, "style": "strict"}, IndexDefaults(index, "go/style"))
+	assert.Nil(t, IndexDefaults(nil, "go/style"))
+}
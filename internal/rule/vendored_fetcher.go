@@ -0,0 +1,156 @@
+package rule
+
+import (
+	"context"
+	"path"
+	"path/filepath"
+	"sync"
+
+	"github.com/charmbracelet/log"
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/vendored"
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+)
+
+// VendoredFetcher resolves remote rules from the vendored copies of a
+// project, checked against the vendor lockfile, and fetches rules that are
+// not vendored, and every rule of projects without a vendor directory, with
+// the fetcher it wraps
+type VendoredFetcher struct {
+	Fetcher
+	fs         afero.Fs
+	parser     Parser
+	projectDir string
+
+	loadOnce sync.Once
+	lock     *vendored.Lock
+	lockErr  error
+}
+
+// NewVendoredFetcher wraps fetcher to prefer the rules vendored in projectDir
+func NewVendoredFetcher(fs afero.Fs, fetcher Fetcher, projectDir string) *VendoredFetcher {
+	return &VendoredFetcher{
+		Fetcher:    fetcher,
+		fs:         fs,
+		parser:     NewParser(),
+		projectDir: projectDir,
+	}
+}
+
+// FetchRule fetches a single rule by ID
+func (f *VendoredFetcher) FetchRule(ctx context.Context, ruleID string) (*domain.Rule, error) {
+	return f.fetch(ctx, domain.RuleRef{ID: ruleID})
+}
+
+// FetchRuleWithSource fetches a single rule by ID with explicit source information
+func (f *VendoredFetcher) FetchRuleWithSource(ctx context.Context, ruleID, source string) (*domain.Rule, error) {
+	return f.fetch(ctx, domain.RuleRef{ID: ruleID, Source: source})
+}
+
+// FetchRuleAtCommit fetches a rule at a specific commit hash
+func (f *VendoredFetcher) FetchRuleAtCommit(ctx context.Context, ruleID, commitHash string) (*domain.Rule, error) {
+	return f.fetch(ctx, domain.RuleRef{ID: ruleID, CommitHash: commitHash})
+}
+
+// FetchRuleAtCommitWithSource fetches a rule at a specific commit hash with explicit source information
+func (f *VendoredFetcher) FetchRuleAtCommitWithSource(ctx context.Context, ruleID, commitHash, source string) (*domain.Rule, error) {
+	return f.fetch(ctx, domain.RuleRef{ID: ruleID, CommitHash: commitHash, Source: source})
+}
+
+// FetchRules fetches multiple rules, vendored ones from the vendor directory
+func (f *VendoredFetcher) FetchRules(ctx context.Context, ruleIDs []string) ([]*domain.Rule, error) {
+	rules := make([]*domain.Rule, 0, len(ruleIDs))
+	for _, ruleID := range ruleIDs {
+		rule, err := f.FetchRule(ctx, ruleID)
+		if err != nil {
+			return nil, contextureerrors.Wrap(err, "fetch rule")
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// fetch returns the vendored copy of the rule ref points to, or fetches it
+// with the wrapped fetcher when it is local or not vendored
+func (f *VendoredFetcher) fetch(ctx context.Context, ref domain.RuleRef) (*domain.Rule, error) {
+	if ref.Source == "local" || isLocalPath(ref.ID) {
+		return FetchRuleRef(ctx, f.Fetcher, ref)
+	}
+	f.loadOnce.Do(func() {
+		f.lock, f.lockErr = vendored.ReadLock(f.fs, f.projectDir)
+	})
+	if f.lockErr != nil {
+		return nil, f.lockErr
+	}
+	if f.lock == nil {
+		return FetchRuleRef(ctx, f.Fetcher, ref)
+	}
+
+	parsed, err := f.ParseRuleID(ref.ID)
+	if err != nil {
+		return nil, err
+	}
+	entry, ok := f.lock.FindRule(parsed.Source, parsed.RulePath, parsed.Ref, ref.CommitHash)
+	if !ok {
+		log.Debug("Rule is not vendored, fetching it", "rule", ref.ID)
+		return FetchRuleRef(ctx, f.Fetcher, ref)
+	}
+	data, err := vendored.ReadFile(f.fs, f.projectDir, entry.File)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := Metadata{
+		ID:        ref.ID,
+		FilePath:  parsed.RulePath,
+		Source:    parsed.Source,
+		Ref:       parsed.Ref,
+		Variables: parsed.Variables,
+	}
+	rule, err := f.parser.ParseRule(string(data), metadata)
+	if err != nil {
+		return nil, contextureerrors.WithOp("FetchRule.ParseRule", err)
+	}
+
+	// Relative links resolve against the vendored copy of the repository,
+	// which holds the assets the rules link to
+	repoDir := vendored.RepoDir(parsed.Source, parsed.Ref, ref.CommitHash)
+	rule.ID = ref.ID
+	rule.Source = parsed.Source
+	rule.Ref = parsed.Ref
+	rule.FilePath = parsed.RulePath
+	rule.RepoDir = filepath.Join(vendored.Dir(f.projectDir), filepath.FromSlash(repoDir))
+
+	index, err := f.repositoryIndex(repoDir)
+	if err != nil {
+		return nil, err
+	}
+	ApplyDefaults(rule, IndexDefaults(index, parsed.RulePath), domain.VariableOriginProvider)
+	for key, value := range parsed.Variables {
+		SetVariable(rule, key, value, domain.VariableOriginProject)
+	}
+
+	log.Debug("Using vendored rule", "ruleID", ref.ID, "path", entry.Path)
+	return rule, nil
+}
+
+// repositoryIndex returns the vendored index of the repository directory
+// repoDir, or nil when the repository has none
+func (f *VendoredFetcher) repositoryIndex(repoDir string) (*domain.RepositoryIndex, error) {
+	file, ok := f.lock.FindFile(path.Join(repoDir, domain.RepositoryIndexFile))
+	if !ok {
+		return nil, nil
+	}
+	data, err := vendored.ReadFile(f.fs, f.projectDir, file)
+	if err != nil {
+		return nil, err
+	}
+	index := &domain.RepositoryIndex{}
+	if err := yaml.Unmarshal(data, index); err != nil {
+		log.Warn("Ignoring invalid repository index", "repository", repoDir, "error", err)
+		return nil, nil
+	}
+	return index, nil
+}
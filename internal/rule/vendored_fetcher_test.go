@@ -0,0 +1,77 @@
+package rule
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/contextureai/contexture/internal/git"
+	"github.com/contextureai/contexture/internal/provider"
+	"github.com/contextureai/contexture/internal/vendored"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVendoredFetcher(t *testing.T) {
+	t.Parallel()
+	const source = "https://github.com/contextureai/rules.git"
+
+	setup := func(t *testing.T) (afero.Fs, *git.MockRepository, *VendoredFetcher) {
+		t.Helper()
+		fs := afero.NewMemMapFs()
+		mockRepo := git.NewMockRepository(t)
+		fetcher := NewFetcher(fs, mockRepo, FetcherConfig{DefaultURL: source}, provider.NewRegistry())
+
+		snapshot := vendored.NewSnapshot()
+		snapshot.AddRule(source, "go/testing", "main", "abc123",
+			[]byte("---\ntitle: Testing\ndescription: Testing rule\ntags: [go]\nvariables:\n  coverage: 80\n---\n\n# Testing"))
+		snapshot.AddFile(vendored.RepoDir(source, "main", "abc123"), domain.RepositoryIndexFile,
+			[]byte("name: rules\nrules:\n  - id: go/testing\n    variables:\n      coverage: 90\n"))
+		_, err := snapshot.Write(fs, "/work/app")
+		require.NoError(t, err)
+		return fs, mockRepo, NewVendoredFetcher(fs, fetcher, "/work/app")
+	}
+
+	t.Run("resolves vendored rules without the network", func(t *testing.T) {
+		t.Parallel()
+		_, _, fetcher := setup(t)
+
+		rules, err := FetchRulesWithPreset(context.Background(), fetcher,
+			[]domain.RuleRef{{ID: "[contexture:go/testing]", CommitHash: "abc123"}}, 1, nil)
+		require.NoError(t, err)
+		require.Len(t, rules, 1)
+		assert.Equal(t, "Testing", rules[0].Title)
+		assert.Equal(t, 90, rules[0].Variables["coverage"])
+		assert.Equal(t, filepath.Join("/work/app/.contexture/vendor", "github.com/contextureai/rules@abc123"), rules[0].RepoDir)
+	})
+
+	t.Run("rejects modified vendored rules", func(t *testing.T) {
+		t.Parallel()
+		fs, _, fetcher := setup(t)
+		path := "/work/app/.contexture/vendor/github.com/contextureai/rules@abc123/go/testing.md"
+		require.NoError(t, afero.WriteFile(fs, path, []byte("---\ntitle: Changed\n---\n"), 0o644))
+
+		_, err := fetcher.FetchRuleAtCommit(context.Background(), "[contexture:go/testing]", "abc123")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "does not match the lockfile")
+	})
+
+	t.Run("fetches rules that are not vendored", func(t *testing.T) {
+		t.Parallel()
+		fs, mockRepo, fetcher := setup(t)
+		mockRepo.On("Clone", mock.Anything, source, mock.AnythingOfType("string"), mock.AnythingOfType("[]git.CloneOption")).
+			Run(func(args mock.Arguments) {
+				tempPath := args.Get(2).(string)
+				_ = afero.WriteFile(fs, tempPath+"/go/style.md",
+					[]byte("---\ntitle: Style\ndescription: Style rule\ntags: [go]\n---\n\n# Style"), 0o644)
+			}).
+			Return(nil)
+
+		rule, err := fetcher.FetchRule(context.Background(), "[contexture:go/style]")
+		require.NoError(t, err)
+		assert.Equal(t, "Style", rule.Title)
+	})
+}
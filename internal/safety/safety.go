@@ -0,0 +1,175 @@
+// Package safety flags instructions in third-party rules that try to take
+// over the agent reading them, such as overriding earlier instructions or
+// sending secrets elsewhere
+package safety
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+)
+
+// check is a named kind of suspicious content
+type check struct {
+	name string
+	find func(content string) [][]int
+}
+
+// patternCheck finds the matches of every pattern
+func patternCheck(patterns ...*regexp.Regexp) func(string) [][]int {
+	return func(content string) [][]int {
+		var locs [][]int
+		for _, pattern := range patterns {
+			locs = append(locs, pattern.FindAllStringIndex(content, -1)...)
+		}
+		return locs
+	}
+}
+
+var (
+	// overridePattern matches requests to drop the instructions an agent already has
+	overridePattern = regexp.MustCompile(`(?i)\b(?:ignore|disregard|forget|override)\s+(?:all\s+|any\s+)?(?:of\s+)?(?:the\s+|your\s+)?` +
+		`(?:previous|prior|above|earlier|preceding|system|other|original)\s+(?:instructions|rules|prompts?|directions|guidelines|context)\b`)
+	// exfiltrationPattern matches requests to send secrets somewhere
+	exfiltrationPattern = regexp.MustCompile(`(?i)\b(?:send|upload|post|transmit|exfiltrate|forward|leak|email)\b[^\n]{0,80}?` +
+		`(?:\b(?:secrets?|credentials?|api[ _-]?keys?|access tokens?|passwords?|environment variables|ssh keys?|private keys?)\b|\.env\b)`)
+	// exfiltrationCommandPattern matches commands that pipe secrets to the network
+	exfiltrationCommandPattern = regexp.MustCompile(`(?i)\b(?:curl|wget|nc)\b[^\n]*(?:\$\{?[A-Z_]*(?:TOKEN|KEY|SECRET|PASSWORD)|~/\.ssh|~/\.aws|/etc/passwd|\.env\b|\bprintenv\b)`)
+	// commentPattern matches HTML comments, which rendered markdown hides from reviewers but agents read
+	commentPattern = regexp.MustCompile(`(?s)<!--(.*?)-->`)
+	// directivePattern matches instructions addressed to an agent
+	directivePattern = regexp.MustCompile(`(?i)\b(?:you (?:must|should|are|will)|ignore|disregard|do not (?:tell|mention|reveal|show)|` +
+		`instructions?|assistant|system prompt|execute|run the|always|never)\b`)
+	// invisiblePattern matches zero-width, bidirectional, and tag characters that hide text
+	invisiblePattern = regexp.MustCompile(`[\x{200B}-\x{200F}\x{202A}-\x{202E}\x{2060}-\x{2064}\x{2066}-\x{2069}\x{FEFF}\x{E0000}-\x{E007F}]+`)
+)
+
+// checks are the content safety checks, in the order findings are reported
+var checks = []check{
+	{"ignore-instructions", patternCheck(overridePattern)},
+	{"exfiltration", patternCheck(exfiltrationPattern, exfiltrationCommandPattern)},
+	{"hidden-comment", hiddenDirectives},
+	{"hidden-unicode", patternCheck(invisiblePattern)},
+}
+
+// hiddenDirectives finds HTML comments that address the agent
+func hiddenDirectives(content string) [][]int {
+	var locs [][]int
+	for _, loc := range commentPattern.FindAllStringSubmatchIndex(content, -1) {
+		if directivePattern.MatchString(content[loc[2]:loc[3]]) {
+			locs = append(locs, loc[:2])
+		}
+	}
+	return locs
+}
+
+// CheckNames returns the names of the checks
+func CheckNames() []string {
+	names := make([]string, len(checks))
+	for i, c := range checks {
+		names[i] = c.name
+	}
+	return names
+}
+
+// Finding is suspicious content found by a check
+type Finding struct {
+	// Check names the check that flagged the content
+	Check string
+	// Line is the line of the content the finding starts on, from 1
+	Line int
+	// Severity is the configured severity of the check
+	Severity domain.SafetySeverity
+}
+
+// String describes the finding
+func (f Finding) String() string {
+	return fmt.Sprintf("%s on line %d", f.Check, f.Line)
+}
+
+// HasErrors reports whether one of findings has error severity
+func HasErrors(findings []Finding) bool {
+	return slices.ContainsFunc(findings, func(f Finding) bool {
+		return f.Severity == domain.SafetyError
+	})
+}
+
+// Linter runs the content safety checks at their configured severity
+type Linter struct {
+	severities map[string]domain.SafetySeverity
+	reviewed   []string
+}
+
+// New creates a Linter from config, running every check at warning
+// severity unless configured otherwise. A nil config uses the defaults.
+func New(config *domain.SafetyConfig) (*Linter, error) {
+	if config == nil {
+		config = &domain.SafetyConfig{}
+	}
+	if err := Validate(*config); err != nil {
+		return nil, err
+	}
+
+	l := &Linter{severities: make(map[string]domain.SafetySeverity), reviewed: config.Reviewed}
+	for _, name := range CheckNames() {
+		l.severities[name] = domain.SafetyWarning
+	}
+	maps.Copy(l.severities, config.Checks)
+	return l, nil
+}
+
+// Validate reports configuration errors in config
+func Validate(config domain.SafetyConfig) error {
+	names := CheckNames()
+	for _, name := range slices.Sorted(maps.Keys(config.Checks)) {
+		if !slices.Contains(names, name) {
+			return contextureerrors.ValidationErrorf("safety.checks",
+				"unknown check %q (available: %s)", name, strings.Join(names, ", "))
+		}
+		switch severity := config.Checks[name]; severity {
+		case domain.SafetyOff, domain.SafetyWarning, domain.SafetyError:
+		default:
+			return contextureerrors.ValidationErrorf("safety.checks."+name,
+				"unknown severity %q (available: %s, %s, %s)", severity, domain.SafetyOff, domain.SafetyWarning, domain.SafetyError)
+		}
+	}
+	for i, pattern := range config.Reviewed {
+		if strings.TrimSpace(pattern) == "" {
+			return contextureerrors.ValidationErrorf(fmt.Sprintf("safety.reviewed[%d]", i), "rule pattern is empty")
+		}
+	}
+	return nil
+}
+
+// Reviewed returns the rule path globs reviewed and trusted despite findings
+func (l *Linter) Reviewed() []string {
+	return l.reviewed
+}
+
+// Lint returns the findings of the checks that are not off, in the order
+// they appear in content
+func (l *Linter) Lint(content string) []Finding {
+	var findings []Finding
+	for _, c := range checks {
+		severity := l.severities[c.name]
+		if severity == domain.SafetyOff {
+			continue
+		}
+		for _, loc := range c.find(content) {
+			findings = append(findings, Finding{
+				Check:    c.name,
+				Line:     strings.Count(content[:loc[0]], "\n") + 1,
+				Severity: severity,
+			})
+		}
+	}
+	slices.SortStableFunc(findings, func(a, b Finding) int {
+		return a.Line - b.Line
+	})
+	return slices.Compact(findings)
+}
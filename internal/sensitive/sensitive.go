@@ -0,0 +1,273 @@
+// Package sensitive keeps outputs generated from rules with sensitive
+// internal details out of version control, and distributes them as a bundle
+// encrypted by a user-provided command
+package sensitive
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/globs"
+	"github.com/contextureai/contexture/internal/staging"
+	"github.com/spf13/afero"
+)
+
+// DefaultBundle is the path of the encrypted bundle relative to the project
+// when the configuration does not set one
+var DefaultBundle = filepath.Join(domain.ContextureDir, "outputs.enc")
+
+// maxBundleSize bounds the decrypted bundle, so a corrupt bundle cannot
+// fill the disk
+const maxBundleSize = 64 << 20
+
+// Tracked returns the outputs git would track: those no .gitignore file
+// between projectDir and the root of its repository ignores. Outputs are
+// paths relative to projectDir.
+func Tracked(fs afero.Fs, projectDir string, outputs []string) ([]string, error) {
+	type gitignore struct {
+		dir    string
+		ignore *globs.Ignore
+	}
+	var gitignores []gitignore
+	for dir := projectDir; ; dir = filepath.Dir(dir) {
+		ignore, err := globs.LoadGitignore(fs, filepath.Join(dir, ".gitignore"))
+		if err != nil {
+			return nil, err
+		}
+		gitignores = append(gitignores, gitignore{dir: dir, ignore: ignore})
+		if isRepo, _ := afero.Exists(fs, filepath.Join(dir, ".git")); isRepo || filepath.Dir(dir) == dir {
+			break
+		}
+	}
+
+	var tracked []string
+	for _, output := range outputs {
+		path := filepath.Join(projectDir, output)
+		isDir, _ := afero.DirExists(fs, path)
+		// Formats writing a directory of files, such as .cursor/rules, are
+		// recognized before their first build by their missing extension
+		if !isDir && filepath.Ext(path) == "" {
+			isDir = true
+		}
+		ignored := false
+		for _, gitignore := range gitignores {
+			rel, err := filepath.Rel(gitignore.dir, path)
+			if err == nil && gitignore.ignore.Ignored(filepath.ToSlash(rel), isDir) {
+				ignored = true
+				break
+			}
+		}
+		if !ignored {
+			tracked = append(tracked, output)
+		}
+	}
+	return tracked, nil
+}
+
+// CheckIgnored returns a validation error listing the outputs git would
+// track, or nil when every output is ignored
+func CheckIgnored(fs afero.Fs, projectDir string, outputs []string) error {
+	tracked, err := Tracked(fs, projectDir, outputs)
+	if err != nil || len(tracked) == 0 {
+		return err
+	}
+	lines := make([]string, len(tracked))
+	for i, output := range tracked {
+		lines[i] = "/" + filepath.ToSlash(output)
+	}
+	return contextureerrors.Validation("sensitive.requireIgnored",
+		"outputs git would track: "+strings.Join(tracked, ", ")).
+		WithSuggestions(contextureerrors.Hint("add these lines to .gitignore: " + strings.Join(lines, " ")))
+}
+
+// Bundler writes and restores the encrypted bundle of a project's outputs
+type Bundler struct {
+	fs         afero.Fs
+	projectDir string
+	config     domain.SensitiveConfig
+	run        dependencies.CommandRunner
+}
+
+// NewBundler returns a Bundler for the outputs of the project in projectDir
+func NewBundler(fs afero.Fs, projectDir string, config domain.SensitiveConfig) *Bundler {
+	return &Bundler{
+		fs:         fs,
+		projectDir: projectDir,
+		config:     config,
+		run:        dependencies.RunCommand,
+	}
+}
+
+// Path returns the path of the encrypted bundle
+func (b *Bundler) Path() string {
+	if b.config.Bundle != "" {
+		return filepath.Join(b.projectDir, b.config.Bundle)
+	}
+	return filepath.Join(b.projectDir, DefaultBundle)
+}
+
+// Write archives the files of outputs, relative to the project, encrypts
+// the archive with the encrypt command, and writes it to the bundle. It
+// returns the number of files bundled.
+func (b *Bundler) Write(ctx context.Context, outputs []string) (int, error) {
+	args := strings.Fields(b.config.EncryptCommand)
+	if len(args) == 0 {
+		return 0, contextureerrors.ValidationErrorf("sensitive.encryptCommand", "no encrypt command configured")
+	}
+	archive, files, err := b.archive(outputs)
+	if err != nil {
+		return 0, err
+	}
+	encrypted, err := b.run(ctx, args, archive)
+	if err != nil {
+		return 0, contextureerrors.Wrap(err, "encrypt outputs")
+	}
+	path := b.Path()
+	if err := b.fs.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return 0, contextureerrors.Wrap(err, "create bundle directory")
+	}
+	if err := staging.WriteFile(b.fs, path, encrypted, 0o644); err != nil {
+		return 0, contextureerrors.Wrap(err, "write encrypted bundle")
+	}
+	return files, nil
+}
+
+// archive returns a gzipped tar archive of the files of outputs, skipping
+// outputs that do not exist
+func (b *Bundler) archive(outputs []string) ([]byte, int, error) {
+	var files []string
+	for _, output := range outputs {
+		err := afero.Walk(b.fs, filepath.Join(b.projectDir, output), func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				if errors.Is(err, os.ErrNotExist) {
+					return nil
+				}
+				return err
+			}
+			if info.Mode().IsRegular() {
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, 0, contextureerrors.Wrap(err, "list outputs")
+		}
+	}
+	slices.Sort(files)
+	files = slices.Compact(files)
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, path := range files {
+		info, err := b.fs.Stat(path)
+		if err != nil {
+			return nil, 0, err
+		}
+		data, err := afero.ReadFile(b.fs, path)
+		if err != nil {
+			return nil, 0, contextureerrors.Wrap(err, "read output")
+		}
+		rel, err := filepath.Rel(b.projectDir, path)
+		if err != nil {
+			return nil, 0, err
+		}
+		header := &tar.Header{
+			Name: filepath.ToSlash(rel),
+			Mode: int64(info.Mode().Perm()),
+			Size: int64(len(data)),
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return nil, 0, err
+		}
+		if _, err := tw.Write(data); err != nil {
+			return nil, 0, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, 0, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, 0, err
+	}
+	return buf.Bytes(), len(files), nil
+}
+
+// Restore decrypts the bundle with the decrypt command and writes its files
+// into the project, replacing them together. With RequireIgnored, nothing is
+// restored when git would track one of the files. It returns the paths
+// restored, relative to the project.
+func (b *Bundler) Restore(ctx context.Context) ([]string, error) {
+	args := strings.Fields(b.config.DecryptCommand)
+	if len(args) == 0 {
+		return nil, contextureerrors.ValidationErrorf("sensitive.decryptCommand", "no decrypt command configured")
+	}
+	encrypted, err := afero.ReadFile(b.fs, b.Path())
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "read encrypted bundle")
+	}
+	archive, err := b.run(ctx, args, encrypted)
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "decrypt outputs")
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "read decrypted bundle")
+	}
+	stage := staging.New(b.fs)
+	defer stage.Discard()
+	var restored []string
+	var size int64
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, contextureerrors.Wrap(err, "read decrypted bundle")
+		}
+		name := filepath.FromSlash(header.Name)
+		if header.Typeflag != tar.TypeReg || !filepath.IsLocal(name) {
+			return nil, contextureerrors.ValidationErrorf("bundle", "unexpected entry %s", header.Name)
+		}
+		size += header.Size
+		if size > maxBundleSize {
+			return nil, contextureerrors.ValidationErrorf("bundle", "decrypted outputs are larger than %d MB", maxBundleSize>>20)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, contextureerrors.Wrap(err, "read decrypted bundle")
+		}
+		path := filepath.Join(b.projectDir, name)
+		if err := stage.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return nil, err
+		}
+		if err := afero.WriteFile(stage, path, data, os.FileMode(header.Mode).Perm()); err != nil {
+			return nil, err
+		}
+		restored = append(restored, name)
+	}
+	// Plaintext outputs are only restored where git ignores them
+	if b.config.RequireIgnored {
+		if err := CheckIgnored(b.fs, b.projectDir, restored); err != nil {
+			return nil, err
+		}
+	}
+	if err := stage.Commit(); err != nil {
+		return nil, contextureerrors.Wrap(err, "restore outputs")
+	}
+	return restored, nil
+}
@@ -0,0 +1,99 @@
+package sensitive
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracked(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	require.NoError(t, fs.MkdirAll("/repo/.git", 0o755))
+	require.NoError(t, afero.WriteFile(fs, "/repo/.gitignore", []byte("/apps/web/CLAUDE.md\n"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "/repo/apps/web/.gitignore", []byte(".cursor/\n"), 0o644))
+	// Files above the repository do not apply
+	require.NoError(t, afero.WriteFile(fs, "/.gitignore", []byte("*\n"), 0o644))
+
+	tracked, err := Tracked(fs, "/repo/apps/web", []string{"CLAUDE.md", ".cursor/rules", ".windsurf/rules", "AGENTS.md"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{".windsurf/rules", "AGENTS.md"}, tracked)
+
+	err = CheckIgnored(fs, "/repo/apps/web", []string{"CLAUDE.md", "AGENTS.md"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "AGENTS.md")
+	require.NoError(t, CheckIgnored(fs, "/repo/apps/web", []string{"CLAUDE.md", ".cursor/rules"}))
+}
+
+func TestBundler(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/project/CLAUDE.md", []byte("secret"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "/project/.cursor/rules/go.mdc", []byte("go"), 0o644))
+
+	bundler := NewBundler(fs, "/project", domain.SensitiveConfig{
+		EncryptCommand: "age -r age1example",
+		DecryptCommand: "age -d -i key.txt",
+	})
+	var commands [][]string
+	// The stand-in cipher reverses the bytes
+	bundler.run = func(_ context.Context, args []string, input []byte) ([]byte, error) {
+		commands = append(commands, args)
+		output := make([]byte, len(input))
+		for i, b := range input {
+			output[len(input)-1-i] = b
+		}
+		return output, nil
+	}
+	assert.Equal(t, "/project/.contexture/outputs.enc", bundler.Path())
+
+	files, err := bundler.Write(context.Background(), []string{"CLAUDE.md", ".cursor/rules", ".windsurf/rules"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, files)
+	encrypted, err := afero.ReadFile(fs, bundler.Path())
+	require.NoError(t, err)
+	assert.NotContains(t, string(encrypted), "secret")
+
+	// Restoring replaces the local outputs
+	require.NoError(t, fs.RemoveAll("/project/.cursor"))
+	require.NoError(t, afero.WriteFile(fs, "/project/CLAUDE.md", []byte("stale"), 0o644))
+	restored, err := bundler.Restore(context.Background())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"CLAUDE.md", ".cursor/rules/go.mdc"}, restored)
+	data, err := afero.ReadFile(fs, "/project/CLAUDE.md")
+	require.NoError(t, err)
+	assert.Equal(t, "secret", string(data))
+	data, err = afero.ReadFile(fs, "/project/.cursor/rules/go.mdc")
+	require.NoError(t, err)
+	assert.Equal(t, "go", string(data))
+	assert.Equal(t, [][]string{{"age", "-r", "age1example"}, {"age", "-d", "-i", "key.txt"}}, commands)
+
+	// Outputs git would track are not restored with requireIgnored
+	bundler.config.RequireIgnored = true
+	_, err = bundler.Restore(context.Background())
+	require.ErrorContains(t, err, "outputs git would track")
+
+	// A failing command leaves the outputs alone
+	bundler.run = func(context.Context, []string, []byte) ([]byte, error) {
+		return nil, errors.New("no identity matched")
+	}
+	_, err = bundler.Restore(context.Background())
+	require.ErrorContains(t, err, "no identity matched")
+	_, err = bundler.Write(context.Background(), []string{"CLAUDE.md"})
+	require.ErrorContains(t, err, "no identity matched")
+}
+
+func TestBundler_NoCommand(t *testing.T) {
+	t.Parallel()
+	bundler := NewBundler(afero.NewMemMapFs(), "/project", domain.SensitiveConfig{Bundle: "secrets/outputs.age"})
+	assert.Equal(t, "/project/secrets/outputs.age", bundler.Path())
+	_, err := bundler.Write(context.Background(), []string{"CLAUDE.md"})
+	require.Error(t, err)
+	_, err = bundler.Restore(context.Background())
+	require.Error(t, err)
+}
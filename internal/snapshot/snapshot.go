@@ -0,0 +1,122 @@
+// Package snapshot records generated output files and compares later builds against them
+package snapshot
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/contextureai/contexture/internal/diff"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/spf13/afero"
+)
+
+// timestampPlaceholder replaces generation timestamps so snapshots stay stable between builds
+const timestampPlaceholder = "<timestamp>"
+
+// timestampRegex matches the timestamps that formats write into generated files
+var timestampRegex = regexp.MustCompile(`\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}`)
+
+// Status describes how a generated file differs from its snapshot
+type Status string
+
+const (
+	// StatusChanged means the file content differs from the snapshot
+	StatusChanged Status = "changed"
+	// StatusAdded means the file was generated but has no snapshot
+	StatusAdded Status = "added"
+	// StatusRemoved means a snapshot exists but the file was not generated
+	StatusRemoved Status = "removed"
+)
+
+// Mismatch is a generated file that does not match its snapshot
+type Mismatch struct {
+	Path   string      `json:"path"`
+	Status Status      `json:"status"`
+	Diff   []diff.Line `json:"diff"`
+}
+
+// Normalize prepares generated content for storing or comparing
+func Normalize(content string) string {
+	return timestampRegex.ReplaceAllString(content, timestampPlaceholder)
+}
+
+// Compare returns the files in outputs that differ from snapshots, sorted by
+// path. Both maps are keyed by path relative to the project root.
+func Compare(outputs, snapshots map[string]string) []Mismatch {
+	var mismatches []Mismatch
+	for path, content := range outputs {
+		recorded, ok := snapshots[path]
+		switch {
+		case !ok:
+			mismatches = append(mismatches, Mismatch{Path: path, Status: StatusAdded, Diff: diff.Lines("", content)})
+		case recorded != content:
+			mismatches = append(mismatches, Mismatch{Path: path, Status: StatusChanged, Diff: diff.Lines(recorded, content)})
+		}
+	}
+	for path, recorded := range snapshots {
+		if _, ok := outputs[path]; !ok {
+			mismatches = append(mismatches, Mismatch{Path: path, Status: StatusRemoved, Diff: diff.Lines(recorded, "")})
+		}
+	}
+
+	sort.Slice(mismatches, func(i, j int) bool {
+		return mismatches[i].Path < mismatches[j].Path
+	})
+	return mismatches
+}
+
+// Read loads every snapshot under dir, keyed by slash-separated relative path.
+// A missing directory yields no snapshots.
+func Read(fs afero.Fs, dir string) (map[string]string, error) {
+	snapshots := make(map[string]string)
+
+	exists, err := afero.DirExists(fs, dir)
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "check snapshots directory")
+	}
+	if !exists {
+		return snapshots, nil
+	}
+
+	err = afero.Walk(fs, dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		relPath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		data, err := afero.ReadFile(fs, path)
+		if err != nil {
+			return err
+		}
+		snapshots[filepath.ToSlash(relPath)] = string(data)
+		return nil
+	})
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "read snapshots")
+	}
+
+	return snapshots, nil
+}
+
+// Write replaces the snapshots under dir with files, keyed by slash-separated relative path
+func Write(fs afero.Fs, dir string, files map[string]string) error {
+	if err := fs.RemoveAll(dir); err != nil {
+		return contextureerrors.Wrap(err, "remove old snapshots")
+	}
+
+	for relPath, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(relPath))
+		if err := fs.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return contextureerrors.Wrap(err, "create snapshot directory")
+		}
+		if err := afero.WriteFile(fs, path, []byte(content), 0o644); err != nil {
+			return contextureerrors.Wrap(err, "write snapshot")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package snapshot
+
+import (
+	"testing"
+
+	"github.com/contextureai/contexture/internal/diff"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+
+	content := "<!-- Generated by Contexture CLI at 2025-01-02 15:04:05 -->"
+	assert.Equal(t, "<!-- Generated by Contexture CLI at <timestamp> -->", Normalize(content))
+	assert.Equal(t, "no timestamps", Normalize("no timestamps"))
+}
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	outputs := map[string]string{
+		"CLAUDE.md":             "a\nb",
+		".cursor/rules/new.mdc": "x",
+		"same.md":               "same",
+	}
+	snapshots := map[string]string{
+		"CLAUDE.md":             "a\nc",
+		".cursor/rules/old.mdc": "y",
+		"same.md":               "same",
+	}
+
+	mismatches := Compare(outputs, snapshots)
+
+	require.Len(t, mismatches, 3)
+	assert.Equal(t, ".cursor/rules/new.mdc", mismatches[0].Path)
+	assert.Equal(t, StatusAdded, mismatches[0].Status)
+	assert.Equal(t, ".cursor/rules/old.mdc", mismatches[1].Path)
+	assert.Equal(t, StatusRemoved, mismatches[1].Status)
+	assert.Equal(t, "CLAUDE.md", mismatches[2].Path)
+	assert.Equal(t, StatusChanged, mismatches[2].Status)
+	assert.Equal(t, []diff.Line{
+		{Op: diff.OpEqual, Text: "a"},
+		{Op: diff.OpDelete, Text: "c"},
+		{Op: diff.OpInsert, Text: "b"},
+	}, mismatches[2].Diff)
+
+	assert.Empty(t, Compare(outputs, outputs))
+}
+
+func TestReadWrite(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+
+	snapshots, err := Read(fs, "/project/.contexture/snapshots")
+	require.NoError(t, err)
+	assert.Empty(t, snapshots)
+
+	require.NoError(t, afero.WriteFile(fs, "/project/.contexture/snapshots/stale.md", []byte("old"), 0o644))
+
+	files := map[string]string{
+		"CLAUDE.md":            "claude",
+		".cursor/rules/go.mdc": "cursor",
+	}
+	require.NoError(t, Write(fs, "/project/.contexture/snapshots", files))
+
+	snapshots, err = Read(fs, "/project/.contexture/snapshots")
+	require.NoError(t, err)
+	assert.Equal(t, files, snapshots)
+}
@@ -0,0 +1,900 @@
+// Package staging stages the files a build writes in a temporary directory,
+// so a build that fails leaves the previous outputs in place instead of a mix
+// of old and new files
+package staging
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/contextureai/contexture/internal/cleanup"
+	"github.com/spf13/afero"
+)
+
+const (
+	// stageDirPrefix names the temporary directories writes are staged in
+	stageDirPrefix = "contexture-stage-"
+
+	// LeftoverAge is the age after which the temporary files and backups
+	// beside a destination are taken for the leftovers of a stopped write
+	LeftoverAge = time.Hour
+)
+
+// leftoverSuffixes follow the destination name in the names of the
+// temporary files and backups written beside it
+var leftoverSuffixes = []string{".tmp-", ".bak-"}
+
+// Fs stages the writes and removals made through it in a temporary
+// directory. Reads see the staged changes, and the filesystem it wraps is
+// unchanged until Commit moves them into place.
+type Fs struct {
+	base afero.Fs
+
+	mu      sync.Mutex
+	dir     string
+	release func()
+	// staged holds the absolute paths of the files written
+	staged map[string]bool
+	// created holds the absolute paths of the directories created
+	created map[string]bool
+	// removed holds the absolute paths removed, hiding everything below them
+	// that was not written again
+	removed map[string]bool
+}
+
+// New returns a filesystem that stages the writes to base
+func New(base afero.Fs) *Fs {
+	return &Fs{
+		base:    base,
+		staged:  make(map[string]bool),
+		created: make(map[string]bool),
+		removed: make(map[string]bool),
+	}
+}
+
+// stagePath returns the path a file is staged at, creating the staging
+// directory on first use
+func (f *Fs) stagePath(path string) (string, error) {
+	if f.dir == "" {
+		dir, err := afero.TempDir(f.base, "", stageDirPrefix)
+		if err != nil {
+			return "", err
+		}
+		f.dir = dir
+		f.release = cleanup.Track(f.base, dir)
+	}
+	return filepath.Join(f.dir, path), nil
+}
+
+// stageLocation returns the staged path of an absolute path, or "" when
+// nothing has been staged yet
+func (f *Fs) stageLocation(path string) string {
+	if f.dir == "" {
+		return ""
+	}
+	return filepath.Join(f.dir, path)
+}
+
+// hidden reports whether path, or a directory above it, was removed
+func (f *Fs) hidden(path string) bool {
+	return hiddenBy(f.removed, path)
+}
+
+// hiddenBy reports whether path, or a directory above it, is in removed
+func hiddenBy(removed map[string]bool, path string) bool {
+	for p := path; ; p = filepath.Dir(p) {
+		if removed[p] {
+			return true
+		}
+		if filepath.Dir(p) == p {
+			return false
+		}
+	}
+}
+
+// kept reports whether path, or a path below it, was written or created
+func (f *Fs) kept(path string) bool {
+	for _, paths := range []map[string]bool{f.staged, f.created} {
+		for p := range paths {
+			if Within(p, path) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// inStage reports whether path exists in the staging directory
+func (f *Fs) inStage(path string) bool {
+	location := f.stageLocation(path)
+	if location == "" {
+		return false
+	}
+	_, err := f.base.Stat(location)
+	return err == nil
+}
+
+// stat returns the file info of path as seen through the staged changes
+func (f *Fs) stat(path string) (os.FileInfo, error) {
+	if f.inStage(path) {
+		return f.base.Stat(f.stageLocation(path))
+	}
+	if f.hidden(path) {
+		return nil, &os.PathError{Op: "stat", Path: path, Err: os.ErrNotExist}
+	}
+	return f.base.Stat(path)
+}
+
+// Stat returns the file info of a file, as staged if it was written
+func (f *Fs) Stat(name string) (os.FileInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.stat(AbsPath(name))
+}
+
+// Open opens a file for reading. Directories list their entries on disk
+// merged with the staged ones.
+func (f *Fs) Open(name string) (afero.File, error) {
+	return f.OpenFile(name, os.O_RDONLY, 0)
+}
+
+// Create creates a staged file
+func (f *Fs) Create(name string) (afero.File, error) {
+	return f.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o666)
+}
+
+// OpenFile opens a file. Files opened for writing are staged, starting from
+// their current content unless they are truncated.
+func (f *Fs) OpenFile(name string, flag int, perm os.FileMode) (afero.File, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	path := AbsPath(name)
+	if flag&(os.O_WRONLY|os.O_RDWR|os.O_APPEND|os.O_CREATE|os.O_TRUNC) == 0 {
+		return f.open(path, flag, perm)
+	}
+
+	info, err := f.stat(path)
+	switch {
+	case err == nil && info.IsDir():
+		return nil, &os.PathError{Op: "open", Path: name, Err: syscall.EISDIR}
+	case err == nil && flag&os.O_CREATE != 0 && flag&os.O_EXCL != 0:
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrExist}
+	case err != nil && flag&os.O_CREATE == 0:
+		return nil, err
+	}
+	if err == nil && !f.staged[path] && flag&os.O_TRUNC == 0 {
+		if err := f.copyToStage(path, info.Mode()); err != nil {
+			return nil, err
+		}
+	}
+
+	location, err := f.stagePath(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.base.MkdirAll(filepath.Dir(location), 0o755); err != nil {
+		return nil, err
+	}
+	// The file exists as far as the caller can tell, even when it has not
+	// been staged yet
+	file, err := f.base.OpenFile(location, flag&^os.O_EXCL|os.O_CREATE, perm)
+	if err != nil {
+		return nil, err
+	}
+	f.staged[path] = true
+	return &stagedFile{File: file, name: name}, nil
+}
+
+// open opens path for reading
+func (f *Fs) open(path string, flag int, perm os.FileMode) (afero.File, error) {
+	info, err := f.stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		if f.staged[path] {
+			file, err := f.base.OpenFile(f.stageLocation(path), flag, perm)
+			if err != nil {
+				return nil, err
+			}
+			return &stagedFile{File: file, name: path}, nil
+		}
+		return f.base.OpenFile(path, flag, perm)
+	}
+
+	// Directories merge the entries on disk that were not removed with the
+	// staged ones, which take precedence
+	union := &afero.UnionFile{Merger: mergeEntries(path, maps.Clone(f.removed))}
+	if !f.hidden(path) {
+		if base, err := f.base.Open(path); err == nil {
+			union.Base = base
+		}
+	}
+	if f.inStage(path) {
+		layer, err := f.base.Open(f.stageLocation(path))
+		if err != nil {
+			if union.Base != nil {
+				_ = union.Base.Close()
+			}
+			return nil, err
+		}
+		union.Layer = layer
+	}
+	return union, nil
+}
+
+// mergeEntries returns the merger listing the directory at path, hiding
+// the entries on disk that were removed when it was opened
+func mergeEntries(path string, removed map[string]bool) afero.DirsMerger {
+	return func(layer, base []os.FileInfo) ([]os.FileInfo, error) {
+		merged := make(map[string]os.FileInfo, len(layer)+len(base))
+		for _, info := range base {
+			if !hiddenBy(removed, filepath.Join(path, info.Name())) {
+				merged[info.Name()] = info
+			}
+		}
+		for _, info := range layer {
+			merged[info.Name()] = info
+		}
+		entries := make([]os.FileInfo, 0, len(merged))
+		for _, info := range merged {
+			entries = append(entries, info)
+		}
+		slices.SortFunc(entries, func(a, b os.FileInfo) int { return strings.Compare(a.Name(), b.Name()) })
+		return entries, nil
+	}
+}
+
+// copyToStage stages the current content of path, so it can be changed
+// without changing the file on disk
+func (f *Fs) copyToStage(path string, mode os.FileMode) error {
+	data, err := afero.ReadFile(f.base, path)
+	if err != nil {
+		return err
+	}
+	location, err := f.stagePath(path)
+	if err != nil {
+		return err
+	}
+	if err := f.base.MkdirAll(filepath.Dir(location), 0o755); err != nil {
+		return err
+	}
+	if err := afero.WriteFile(f.base, location, data, mode.Perm()); err != nil {
+		return err
+	}
+	f.staged[path] = true
+	return nil
+}
+
+// Mkdir creates a staged directory
+func (f *Fs) Mkdir(name string, perm os.FileMode) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	path := AbsPath(name)
+	if _, err := f.stat(path); err == nil {
+		return &os.PathError{Op: "mkdir", Path: name, Err: os.ErrExist}
+	}
+	if _, err := f.stat(filepath.Dir(path)); err != nil {
+		return &os.PathError{Op: "mkdir", Path: name, Err: os.ErrNotExist}
+	}
+	return f.mkdirAll(path, perm)
+}
+
+// MkdirAll creates a staged directory and its parents
+func (f *Fs) MkdirAll(path string, perm os.FileMode) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	abs := AbsPath(path)
+	if info, err := f.stat(abs); err == nil {
+		if !info.IsDir() {
+			return &os.PathError{Op: "mkdir", Path: path, Err: syscall.ENOTDIR}
+		}
+		return nil
+	}
+	return f.mkdirAll(abs, perm)
+}
+
+// mkdirAll stages the directory at path
+func (f *Fs) mkdirAll(path string, perm os.FileMode) error {
+	location, err := f.stagePath(path)
+	if err != nil {
+		return err
+	}
+	if err := f.base.MkdirAll(location, perm); err != nil {
+		return err
+	}
+	f.created[path] = true
+	return nil
+}
+
+// Remove stages the removal of a file or an empty directory
+func (f *Fs) Remove(name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	path := AbsPath(name)
+	info, err := f.stat(path)
+	if err != nil {
+		return &os.PathError{Op: "remove", Path: name, Err: os.ErrNotExist}
+	}
+	if info.IsDir() {
+		entries, err := f.readDir(path)
+		if err != nil {
+			return err
+		}
+		if len(entries) > 0 {
+			return &os.PathError{Op: "remove", Path: name, Err: syscall.ENOTEMPTY}
+		}
+	}
+	return f.remove(path)
+}
+
+// RemoveAll stages the removal of a path and everything below it
+func (f *Fs) RemoveAll(path string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	abs := AbsPath(path)
+	if _, err := f.stat(abs); err != nil {
+		return nil
+	}
+	return f.remove(abs)
+}
+
+// remove drops the staged changes at and below path and hides it on disk
+func (f *Fs) remove(path string) error {
+	if location := f.stageLocation(path); location != "" {
+		if err := f.base.RemoveAll(location); err != nil {
+			return err
+		}
+	}
+	for _, paths := range []map[string]bool{f.staged, f.created} {
+		for p := range paths {
+			if Within(p, path) {
+				delete(paths, p)
+			}
+		}
+	}
+	f.removed[path] = true
+	return nil
+}
+
+// readDir lists the directory at path as seen through the staged changes
+func (f *Fs) readDir(path string) ([]os.FileInfo, error) {
+	dir, err := f.open(path, os.O_RDONLY, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = dir.Close() }()
+	return dir.Readdir(-1)
+}
+
+// Rename stages moving a file
+func (f *Fs) Rename(oldname, newname string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	oldPath, newPath := AbsPath(oldname), AbsPath(newname)
+	info, err := f.stat(oldPath)
+	if err != nil {
+		return &os.LinkError{Op: "rename", Old: oldname, New: newname, Err: os.ErrNotExist}
+	}
+	if info.IsDir() {
+		return &os.LinkError{Op: "rename", Old: oldname, New: newname, Err: errors.New("staged directories cannot be renamed")}
+	}
+	if !f.staged[oldPath] {
+		if err := f.copyToStage(oldPath, info.Mode()); err != nil {
+			return err
+		}
+	}
+
+	location, err := f.stagePath(newPath)
+	if err != nil {
+		return err
+	}
+	if err := f.base.MkdirAll(filepath.Dir(location), 0o755); err != nil {
+		return err
+	}
+	if err := f.base.Rename(f.stageLocation(oldPath), location); err != nil {
+		return err
+	}
+	delete(f.staged, oldPath)
+	f.removed[oldPath] = true
+	f.staged[newPath] = true
+	return nil
+}
+
+// Name returns the name of the filesystem
+func (f *Fs) Name() string {
+	return "StagingFs"
+}
+
+// Chmod changes the mode of a file, staging it first
+func (f *Fs) Chmod(name string, mode os.FileMode) error {
+	return f.change(name, func(path string) error { return f.base.Chmod(path, mode) })
+}
+
+// Chown changes the owner of a file, staging it first
+func (f *Fs) Chown(name string, uid, gid int) error {
+	return f.change(name, func(path string) error { return f.base.Chown(path, uid, gid) })
+}
+
+// Chtimes changes the times of a file, staging it first
+func (f *Fs) Chtimes(name string, atime, mtime time.Time) error {
+	return f.change(name, func(path string) error { return f.base.Chtimes(path, atime, mtime) })
+}
+
+// change applies a change to the staged copy of a file. Directories hold no
+// content to stage, so they are changed in place.
+func (f *Fs) change(name string, apply func(string) error) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	path := AbsPath(name)
+	info, err := f.stat(path)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		if f.inStage(path) {
+			return apply(f.stageLocation(path))
+		}
+		return apply(path)
+	}
+	if !f.staged[path] {
+		if err := f.copyToStage(path, info.Mode()); err != nil {
+			return err
+		}
+	}
+	return apply(f.stageLocation(path))
+}
+
+// Commit moves the staged changes into place. Every file is first written
+// and synced next to its destination, and the previous content of each
+// destination is copied to a backup beside it. The paths removed are then
+// renamed aside, and the files renamed over their destinations; when a
+// rename fails, the removed paths are put back and the files already moved
+// are restored from their backups, so a failed commit leaves the previous
+// files. Each rename is atomic, so even when the process stops midway every
+// file holds either its previous or its new content. The temporary files
+// and backups a stopped commit leaves are removed by a later commit of the
+// same paths once they are older than LeftoverAge.
+func (f *Fs) Commit() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	defer f.discard()
+
+	dirs := make([]string, 0, len(f.created))
+	for dir := range f.created {
+		dirs = append(dirs, dir)
+	}
+	slices.Sort(dirs)
+	files := make([]string, 0, len(f.staged))
+	for path := range f.staged {
+		files = append(files, path)
+	}
+	slices.Sort(files)
+	removed := slices.Sorted(maps.Keys(f.removed))
+	f.removeLeftovers(slices.Concat(files, removed))
+
+	// The directories the commit creates are removed again when it fails
+	missing := make(map[string]bool)
+	for _, dir := range dirs {
+		f.missingDirs(dir, missing)
+	}
+	for _, path := range files {
+		f.missingDirs(filepath.Dir(path), missing)
+	}
+	newDirs := slices.Sorted(maps.Keys(missing))
+
+	for _, dir := range dirs {
+		info, err := f.base.Stat(f.stageLocation(dir))
+		if err != nil {
+			f.removeDirs(newDirs)
+			return err
+		}
+		if err := f.base.MkdirAll(dir, info.Mode().Perm()); err != nil {
+			f.removeDirs(newDirs)
+			return err
+		}
+	}
+
+	// Removed paths are renamed aside rather than deleted, so a failed
+	// commit can put them back, and deleted once every file is in place.
+	// This comes before the new files are written beside their destinations,
+	// which are not among the paths to remove.
+	var aside []move
+	for _, path := range removed {
+		if err := f.moveAside(path, &aside); err != nil {
+			f.putBack(aside)
+			f.removeDirs(newDirs)
+			return err
+		}
+	}
+
+	moves := make([]move, 0, len(files))
+	// The temporary files left once the commit ends are removed: the new
+	// content of files not moved, and the backups of the previous content
+	defer func() {
+		for _, m := range moves {
+			for _, name := range []string{m.tmp, m.backup} {
+				if name != "" {
+					_ = f.base.Remove(name)
+				}
+			}
+		}
+	}()
+	for _, path := range files {
+		m, err := f.prepareMove(path)
+		if m.tmp != "" {
+			moves = append(moves, m)
+		}
+		if err != nil {
+			f.putBack(aside)
+			f.removeDirs(newDirs)
+			return err
+		}
+	}
+
+	synced := make(map[string]bool)
+	for i := range moves {
+		if err := f.base.Rename(moves[i].tmp, moves[i].path); err != nil {
+			f.restore(moves[:i])
+			f.putBack(aside)
+			f.removeDirs(newDirs)
+			return err
+		}
+		moves[i].tmp = ""
+		synced[filepath.Dir(moves[i].path)] = true
+	}
+	for _, m := range aside {
+		synced[filepath.Dir(m.path)] = true
+	}
+	for dir := range synced {
+		syncDir(f.base, dir)
+	}
+
+	for _, m := range aside {
+		_ = f.base.RemoveAll(m.backup)
+	}
+	return nil
+}
+
+// move is a staged file ready to be renamed over its destination
+type move struct {
+	path string
+	// tmp holds the new content, synced next to path
+	tmp string
+	// backup holds a copy of the previous content of path, and is empty when
+	// path did not exist
+	backup string
+}
+
+// prepareMove writes a staged file and a backup of its destination next to
+// the destination. The returned move holds the files written even when it
+// fails.
+func (f *Fs) prepareMove(path string) (move, error) {
+	m := move{path: path}
+	location := f.stageLocation(path)
+	info, err := f.base.Stat(location)
+	if err != nil {
+		return m, err
+	}
+	mode := info.Mode().Perm()
+	// Files that already exist keep their mode
+	existing, err := f.base.Stat(path)
+	exists := err == nil && !existing.IsDir()
+	if exists {
+		mode = existing.Mode().Perm()
+	}
+	if err := f.base.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return m, err
+	}
+
+	staged, err := f.base.Open(location)
+	if err != nil {
+		return m, err
+	}
+	defer func() { _ = staged.Close() }()
+	if m.tmp, err = writeTemp(f.base, path, staged, mode, ".tmp-*"); err != nil {
+		return m, err
+	}
+	if !exists {
+		return m, nil
+	}
+
+	previous, err := f.base.Open(path)
+	if err != nil {
+		return m, err
+	}
+	defer func() { _ = previous.Close() }()
+	m.backup, err = writeTemp(f.base, path, previous, mode, ".bak-*")
+	return m, err
+}
+
+// restore puts back the previous content of the files moved into place,
+// removing the files that did not exist
+func (f *Fs) restore(moved []move) {
+	for i := len(moved) - 1; i >= 0; i-- {
+		m := moved[i]
+		if m.backup == "" {
+			_ = f.base.Remove(m.path)
+			continue
+		}
+		_ = f.base.Rename(m.backup, m.path)
+	}
+}
+
+// moveAside renames path, or the paths below it that were not written again,
+// to a hidden name beside it, adding each to aside
+func (f *Fs) moveAside(path string, aside *[]move) error {
+	if f.kept(path) {
+		entries, err := afero.ReadDir(f.base, path)
+		if err != nil {
+			return nil
+		}
+		for _, entry := range entries {
+			child := filepath.Join(path, entry.Name())
+			if f.staged[child] {
+				continue
+			}
+			if err := f.moveAside(child, aside); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	// Paths below a removed directory are gone with it
+	if _, err := f.base.Stat(path); err != nil {
+		return nil
+	}
+	name, err := asideName(f.base, path)
+	if err != nil {
+		return err
+	}
+	if err := f.base.Rename(path, name); err != nil {
+		return err
+	}
+	*aside = append(*aside, move{path: path, backup: name})
+	return nil
+}
+
+// asideName returns an unused hidden name beside path for its backup
+func asideName(fs afero.Fs, path string) (string, error) {
+	tmp, err := afero.TempFile(fs, filepath.Dir(path), "."+filepath.Base(path)+".bak-*")
+	if err != nil {
+		return "", err
+	}
+	name := tmp.Name()
+	_ = tmp.Close()
+	if err := fs.Remove(name); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// putBack renames the paths moved aside back into place
+func (f *Fs) putBack(aside []move) {
+	for i := len(aside) - 1; i >= 0; i-- {
+		_ = f.base.Rename(aside[i].backup, aside[i].path)
+	}
+}
+
+// removeLeftovers removes the temporary files and backups that stopped
+// commits left beside paths, once they are older than LeftoverAge so the
+// files of commits still running are kept
+func (f *Fs) removeLeftovers(paths []string) {
+	names := make(map[string][]string)
+	for _, path := range paths {
+		dir := filepath.Dir(path)
+		names[dir] = append(names[dir], filepath.Base(path))
+	}
+	for dir, bases := range names {
+		entries, err := afero.ReadDir(f.base, dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if time.Since(entry.ModTime()) < LeftoverAge {
+				continue
+			}
+			if slices.ContainsFunc(bases, func(base string) bool { return leftoverOf(entry.Name(), base) }) {
+				_ = f.base.RemoveAll(filepath.Join(dir, entry.Name()))
+			}
+		}
+	}
+}
+
+// IsLeftover reports whether name is a temporary file or backup that a
+// write or commit puts beside its destination, .<name>.tmp-* or
+// .<name>.bak-* followed by the random digits of the temporary name
+func IsLeftover(name string) bool {
+	if !strings.HasPrefix(name, ".") {
+		return false
+	}
+	for _, suffix := range leftoverSuffixes {
+		i := strings.LastIndex(name, suffix)
+		if i <= 1 {
+			continue
+		}
+		random := name[i+len(suffix):]
+		if random != "" && strings.Trim(random, "0123456789") == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// leftoverOf reports whether name is a temporary file or backup that a
+// commit writes beside the file named base
+func leftoverOf(name, base string) bool {
+	for _, suffix := range leftoverSuffixes {
+		if strings.HasPrefix(name, "."+base+suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// missingDirs adds dir and its ancestors that do not exist on the wrapped
+// filesystem to missing
+func (f *Fs) missingDirs(dir string, missing map[string]bool) {
+	for !missing[dir] {
+		if _, err := f.base.Stat(dir); err == nil {
+			return
+		}
+		missing[dir] = true
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return
+		}
+		dir = parent
+	}
+}
+
+// removeDirs removes the sorted directories a failed commit created, deepest
+// first, when they are empty
+func (f *Fs) removeDirs(dirs []string) {
+	for i := len(dirs) - 1; i >= 0; i-- {
+		_ = f.base.Remove(dirs[i])
+	}
+}
+
+// Discard drops the staged changes. It does nothing after Commit.
+func (f *Fs) Discard() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.discard()
+}
+
+// discard removes the staging directory and forgets the staged changes
+func (f *Fs) discard() {
+	if f.dir != "" {
+		_ = f.base.RemoveAll(f.dir)
+		f.release()
+		f.dir = ""
+	}
+	clear(f.staged)
+	clear(f.created)
+	clear(f.removed)
+}
+
+// WriteFile writes data to path through a synced temporary file in the same
+// directory, so readers and crashes see either the previous or the new
+// content
+func WriteFile(fs afero.Fs, path string, data []byte, perm os.FileMode) error {
+	if err := writeAtomic(fs, path, bytes.NewReader(data), perm); err != nil {
+		return err
+	}
+	syncDir(fs, filepath.Dir(path))
+	return nil
+}
+
+// CopyDir copies the tree at src to dst, preserving file modes
+func CopyDir(fs afero.Fs, src, dst string) error {
+	return afero.Walk(fs, src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		if info.IsDir() {
+			return fs.MkdirAll(target, info.Mode().Perm())
+		}
+		file, err := fs.Open(path)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = file.Close() }()
+		return writeAtomic(fs, target, file, info.Mode().Perm())
+	})
+}
+
+// writeAtomic writes the content of r to path through a synced temporary
+// file renamed over it
+func writeAtomic(fs afero.Fs, path string, r io.Reader, perm os.FileMode) error {
+	name, err := writeTemp(fs, path, r, perm, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if err := fs.Rename(name, path); err != nil {
+		_ = fs.Remove(name)
+		return err
+	}
+	return nil
+}
+
+// writeTemp writes the content of r to a synced hidden file next to path,
+// named after it with suffix, and returns its name
+func writeTemp(fs afero.Fs, path string, r io.Reader, perm os.FileMode, suffix string) (string, error) {
+	tmp, err := afero.TempFile(fs, filepath.Dir(path), "."+filepath.Base(path)+suffix)
+	if err != nil {
+		return "", err
+	}
+	name := tmp.Name()
+	if _, err := io.Copy(tmp, r); err != nil {
+		_ = tmp.Close()
+		_ = fs.Remove(name)
+		return "", err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		_ = fs.Remove(name)
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = fs.Remove(name)
+		return "", err
+	}
+	if err := fs.Chmod(name, perm); err != nil {
+		_ = fs.Remove(name)
+		return "", err
+	}
+	return name, nil
+}
+
+// syncDir syncs a directory so the renames in it survive a crash. Not every
+// platform can sync directories, so failures are ignored.
+func syncDir(fs afero.Fs, dir string) {
+	file, err := fs.Open(dir)
+	if err != nil {
+		return
+	}
+	_ = file.Sync()
+	_ = file.Close()
+}
+
+// stagedFile is a staged file that reports the name it was opened with
+type stagedFile struct {
+	afero.File
+	name string
+}
+
+// Name returns the name the file was opened with
+func (f *stagedFile) Name() string {
+	return f.name
+}
+
+// Within reports whether path is dir or below it
+func Within(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// AbsPath returns the cleaned absolute form of name, or name itself when
+// the working directory is unknown
+func AbsPath(name string) string {
+	if abs, err := filepath.Abs(name); err == nil {
+		return abs
+	}
+	return filepath.Clean(name)
+}
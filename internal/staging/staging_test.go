@@ -0,0 +1,223 @@
+package staging
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFs(t *testing.T) {
+	t.Parallel()
+	base := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(base, "/project/CLAUDE.md", []byte("old"), 0o600))
+	require.NoError(t, afero.WriteFile(base, "/project/.cursor/rules/go.mdc", []byte("go"), 0o644))
+	require.NoError(t, afero.WriteFile(base, "/project/.cursor/rules/old.mdc", []byte("old"), 0o644))
+
+	fs := New(base)
+
+	// Writes are read back without changing the disk
+	require.NoError(t, afero.WriteFile(fs, "/project/CLAUDE.md", []byte("new"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "/project/.cursor/rules/python.mdc", []byte("python"), 0o644))
+	require.NoError(t, fs.Remove("/project/.cursor/rules/old.mdc"))
+	require.NoError(t, fs.MkdirAll("/project/.windsurf/rules", 0o755))
+
+	data, err := afero.ReadFile(fs, "/project/CLAUDE.md")
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(data))
+	entries, err := afero.ReadDir(fs, "/project/.cursor/rules")
+	require.NoError(t, err)
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	assert.Equal(t, []string{"go.mdc", "python.mdc"}, names)
+	exists, err := afero.DirExists(fs, "/project/.windsurf/rules")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	data, err = afero.ReadFile(base, "/project/CLAUDE.md")
+	require.NoError(t, err)
+	assert.Equal(t, "old", string(data))
+	exists, err = afero.Exists(base, "/project/.cursor/rules/old.mdc")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	// Commit moves every change into place
+	require.NoError(t, fs.Commit())
+	data, err = afero.ReadFile(base, "/project/CLAUDE.md")
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(data))
+	info, err := base.Stat("/project/CLAUDE.md")
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	data, err = afero.ReadFile(base, "/project/.cursor/rules/python.mdc")
+	require.NoError(t, err)
+	assert.Equal(t, "python", string(data))
+	exists, err = afero.Exists(base, "/project/.cursor/rules/old.mdc")
+	require.NoError(t, err)
+	assert.False(t, exists)
+	exists, err = afero.DirExists(base, "/project/.windsurf/rules")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	// Nothing is left behind
+	entries, err = afero.ReadDir(base, "/project")
+	require.NoError(t, err)
+	assert.Len(t, entries, 3)
+	entries, err = afero.ReadDir(base, os.TempDir())
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestFs_Discard(t *testing.T) {
+	t.Parallel()
+	base := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(base, "/project/CLAUDE.md", []byte("old"), 0o644))
+
+	fs := New(base)
+	require.NoError(t, afero.WriteFile(fs, "/project/CLAUDE.md", []byte("new"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "/project/AGENTS.md", []byte("new"), 0o644))
+	require.NoError(t, fs.RemoveAll("/project"))
+	fs.Discard()
+
+	data, err := afero.ReadFile(base, "/project/CLAUDE.md")
+	require.NoError(t, err)
+	assert.Equal(t, "old", string(data))
+	exists, err := afero.Exists(base, "/project/AGENTS.md")
+	require.NoError(t, err)
+	assert.False(t, exists)
+	entries, err := afero.ReadDir(base, os.TempDir())
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+// failingRenameFs fails every rename over target
+type failingRenameFs struct {
+	afero.Fs
+
+	target string
+}
+
+func (f failingRenameFs) Rename(oldname, newname string) error {
+	if newname == f.target {
+		return os.ErrPermission
+	}
+	return f.Fs.Rename(oldname, newname)
+}
+
+func TestFs_CommitRollback(t *testing.T) {
+	t.Parallel()
+	mem := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(mem, "/project/AGENTS.md", []byte("old agents"), 0o600))
+	require.NoError(t, afero.WriteFile(mem, "/project/GEMINI.md", []byte("old gemini"), 0o644))
+	require.NoError(t, afero.WriteFile(mem, "/project/.cursor/rules/go.mdc", []byte("old go"), 0o644))
+	base := failingRenameFs{Fs: mem, target: "/project/GEMINI.md"}
+
+	fs := New(base)
+	require.NoError(t, fs.RemoveAll("/project/.cursor"))
+	require.NoError(t, afero.WriteFile(fs, "/project/AGENTS.md", []byte("new agents"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "/project/CLAUDE.md", []byte("new claude"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "/project/GEMINI.md", []byte("new gemini"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "/project/.windsurf/rules/go.md", []byte("go"), 0o644))
+	require.ErrorIs(t, fs.Commit(), os.ErrPermission)
+
+	// The files moved before the failure get their previous content back
+	data, err := afero.ReadFile(mem, "/project/AGENTS.md")
+	require.NoError(t, err)
+	assert.Equal(t, "old agents", string(data))
+	info, err := mem.Stat("/project/AGENTS.md")
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	data, err = afero.ReadFile(mem, "/project/GEMINI.md")
+	require.NoError(t, err)
+	assert.Equal(t, "old gemini", string(data))
+
+	// Removed paths are put back
+	data, err = afero.ReadFile(mem, "/project/.cursor/rules/go.mdc")
+	require.NoError(t, err)
+	assert.Equal(t, "old go", string(data))
+
+	// New files and directories are removed, and no backups are left behind
+	entries, err := afero.ReadDir(mem, "/project")
+	require.NoError(t, err)
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	assert.Equal(t, []string{".cursor", "AGENTS.md", "GEMINI.md"}, names)
+	entries, err = afero.ReadDir(mem, os.TempDir())
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestFs_CommitRemovesLeftovers(t *testing.T) {
+	t.Parallel()
+	base := afero.NewMemMapFs()
+	stale := time.Now().Add(-2 * LeftoverAge)
+	for _, name := range []string{".CLAUDE.md.tmp-123", ".CLAUDE.md.bak-456", ".old.md.bak-789", ".AGENTS.md.tmp-000"} {
+		path := filepath.Join("/project", name)
+		require.NoError(t, afero.WriteFile(base, path, []byte("left"), 0o644))
+		require.NoError(t, base.Chtimes(path, stale, stale))
+	}
+	require.NoError(t, afero.WriteFile(base, "/project/.CLAUDE.md.tmp-999", []byte("running"), 0o644))
+	require.NoError(t, afero.WriteFile(base, "/project/old.md", []byte("old"), 0o644))
+
+	fs := New(base)
+	require.NoError(t, afero.WriteFile(fs, "/project/CLAUDE.md", []byte("rules"), 0o644))
+	require.NoError(t, fs.Remove("/project/old.md"))
+	require.NoError(t, fs.Commit())
+
+	// Only the old leftovers of the paths committed are removed
+	entries, err := afero.ReadDir(base, "/project")
+	require.NoError(t, err)
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	assert.Equal(t, []string{".AGENTS.md.tmp-000", ".CLAUDE.md.tmp-999", "CLAUDE.md"}, names)
+}
+
+func TestFs_RemoveAndRewrite(t *testing.T) {
+	t.Parallel()
+	base := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(base, "/project/.cursor/rules/go.mdc", []byte("go"), 0o644))
+	require.NoError(t, afero.WriteFile(base, "/project/.cursor/rules/old.mdc", []byte("old"), 0o644))
+
+	fs := New(base)
+	require.NoError(t, fs.RemoveAll("/project/.cursor/rules"))
+	_, err := fs.Stat("/project/.cursor/rules/go.mdc")
+	require.ErrorIs(t, err, os.ErrNotExist)
+	require.ErrorIs(t, fs.Remove("/project/.cursor/rules/go.mdc"), os.ErrNotExist)
+
+	// A file written again below a removed directory is kept
+	require.NoError(t, afero.WriteFile(fs, "/project/.cursor/rules/go.mdc", []byte("new go"), 0o644))
+	require.NoError(t, fs.Rename("/project/.cursor/rules/go.mdc", "/project/.cursor/rules/golang.mdc"))
+	require.NoError(t, fs.Commit())
+
+	entries, err := afero.ReadDir(base, "/project/.cursor/rules")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "golang.mdc", entries[0].Name())
+	data, err := afero.ReadFile(base, "/project/.cursor/rules/golang.mdc")
+	require.NoError(t, err)
+	assert.Equal(t, "new go", string(data))
+}
+
+func TestWriteFile(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/project/manifest.json", []byte("{}"), 0o644))
+
+	require.NoError(t, WriteFile(fs, "/project/manifest.json", []byte(`{"rules":[]}`), 0o644))
+	data, err := afero.ReadFile(fs, "/project/manifest.json")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"rules":[]}`, string(data))
+	entries, err := afero.ReadDir(fs, "/project")
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
@@ -0,0 +1,185 @@
+// Package summarize condenses rules whose rendered content is too long for agent context
+package summarize
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/contextureai/contexture/internal/dependencies"
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+)
+
+// DefaultThreshold is the rendered length, in characters, above which rules
+// are condensed when no threshold is configured
+const DefaultThreshold = 8000
+
+var (
+	headingPattern  = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	listItemPattern = regexp.MustCompile(`^\s*([-*+]|\d+[.)])\s+`)
+	fencePattern    = regexp.MustCompile("^\\s*(```|~~~)")
+	examplePattern  = regexp.MustCompile(`(?i)\bexamples?\b`)
+	// directivePattern matches format section directives, which both strategies keep
+	directivePattern = regexp.MustCompile(`^\s*:::`)
+)
+
+// Summarizer condenses content longer than its threshold
+type Summarizer struct {
+	threshold int
+	strategy  domain.SummarizeStrategy
+	command   []string
+	// run executes the summarizer command with input on stdin and returns its stdout
+	run dependencies.CommandRunner
+}
+
+// New creates a Summarizer from config, applying defaults for unset fields
+func New(config domain.SummarizeConfig) (*Summarizer, error) {
+	if err := Validate(config); err != nil {
+		return nil, err
+	}
+
+	s := &Summarizer{
+		threshold: config.Threshold,
+		strategy:  config.Strategy,
+		command:   strings.Fields(config.Command),
+		run:       dependencies.RunCommand,
+	}
+	if s.threshold == 0 {
+		s.threshold = DefaultThreshold
+	}
+	if s.strategy == "" {
+		s.strategy = domain.SummarizeOutline
+	}
+	return s, nil
+}
+
+// Validate reports configuration errors in config
+func Validate(config domain.SummarizeConfig) error {
+	if config.Threshold < 0 {
+		return contextureerrors.ValidationErrorf("summarize.threshold", "threshold must not be negative")
+	}
+
+	switch config.Strategy {
+	case "", domain.SummarizeOutline, domain.SummarizeStripExamples:
+	case domain.SummarizeCommand:
+		if strings.TrimSpace(config.Command) == "" {
+			return contextureerrors.ValidationErrorf("summarize.command", "the command strategy requires a command")
+		}
+	default:
+		return contextureerrors.ValidationErrorf("summarize.strategy",
+			"unknown strategy %q (available: %s, %s, %s)", config.Strategy,
+			domain.SummarizeOutline, domain.SummarizeStripExamples, domain.SummarizeCommand)
+	}
+	return nil
+}
+
+// Threshold returns the length above which content is condensed
+func (s *Summarizer) Threshold() int {
+	return s.threshold
+}
+
+// Summarize condenses content if it is longer than the threshold. The
+// returned flag reports whether content was condensed.
+func (s *Summarizer) Summarize(ctx context.Context, content string) (string, bool, error) {
+	if len(content) <= s.threshold {
+		return content, false, nil
+	}
+
+	var summary string
+	switch s.strategy {
+	case domain.SummarizeStripExamples:
+		summary = StripExamples(content)
+	case domain.SummarizeCommand:
+		output, err := s.run(ctx, s.command, []byte(content))
+		if err != nil {
+			return "", false, contextureerrors.Wrap(err, "run summarizer "+s.command[0])
+		}
+		summary = strings.TrimSpace(string(output)) + "\n"
+	default:
+		summary = Outline(content)
+	}
+
+	if strings.TrimSpace(summary) == "" {
+		return content, false, nil
+	}
+	return summary, true, nil
+}
+
+// Outline keeps the headings and list items of markdown content and drops
+// prose paragraphs, tables, and code blocks
+func Outline(content string) string {
+	var kept []string
+	inFence := false
+	for _, line := range strings.Split(content, "\n") {
+		if fencePattern.MatchString(line) {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if headingPattern.MatchString(line) {
+			// Set headings apart from the items around them
+			kept = append(kept, "", line, "")
+			continue
+		}
+		if listItemPattern.MatchString(line) || directivePattern.MatchString(line) {
+			kept = append(kept, line)
+		}
+	}
+	return joinLines(kept)
+}
+
+// StripExamples removes fenced code blocks and sections whose heading
+// mentions examples, keeping the rest of the content as written
+func StripExamples(content string) string {
+	var kept []string
+	inFence := false
+	// skipLevel is the heading level of the example section being removed, or 0
+	skipLevel := 0
+	for _, line := range strings.Split(content, "\n") {
+		if !inFence {
+			if match := headingPattern.FindStringSubmatch(line); match != nil {
+				level := len(match[1])
+				if skipLevel > 0 && level > skipLevel {
+					continue
+				}
+				skipLevel = 0
+				if examplePattern.MatchString(match[2]) {
+					skipLevel = level
+					continue
+				}
+			}
+		}
+		if fencePattern.MatchString(line) {
+			inFence = !inFence
+			continue
+		}
+		if inFence || (skipLevel > 0 && !directivePattern.MatchString(line)) {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return joinLines(kept)
+}
+
+// joinLines joins lines, collapsing runs of blank lines and trimming the ends
+func joinLines(lines []string) string {
+	var out []string
+	for _, line := range lines {
+		blank := strings.TrimSpace(line) == ""
+		if blank && (len(out) == 0 || out[len(out)-1] == "") {
+			continue
+		}
+		if blank {
+			line = ""
+		}
+		out = append(out, line)
+	}
+	result := strings.TrimSpace(strings.Join(out, "\n"))
+	if result == "" {
+		return ""
+	}
+	return result + "\n"
+}
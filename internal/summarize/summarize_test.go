@@ -0,0 +1,129 @@
+package summarize
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const longRule = `# Error Handling
+
+Errors are values, and handling them well matters.
+
+- Wrap errors with context
+- Never ignore returned errors
+  - Including Close
+
+## Examples
+
+Good:
+
+` + "```go" + `
+return fmt.Errorf("load config: %w", err)
+` + "```" + `
+
+### Bad
+
+Ignoring errors.
+
+## Testing
+
+1. Assert error types with errors.Is
+`
+
+func TestOutline(t *testing.T) {
+	t.Parallel()
+	expected := `# Error Handling
+
+- Wrap errors with context
+- Never ignore returned errors
+  - Including Close
+
+## Examples
+
+### Bad
+
+## Testing
+
+1. Assert error types with errors.Is
+`
+	assert.Equal(t, expected, Outline(longRule))
+}
+
+func TestStripExamples(t *testing.T) {
+	t.Parallel()
+	expected := `# Error Handling
+
+Errors are values, and handling them well matters.
+
+- Wrap errors with context
+- Never ignore returned errors
+  - Including Close
+
+## Testing
+
+1. Assert error types with errors.Is
+`
+	assert.Equal(t, expected, StripExamples(longRule))
+}
+
+func TestOutline_KeepsFormatSections(t *testing.T) {
+	t.Parallel()
+	content := "# Tools\n\n:::only claude\nProse for Claude.\n\n- Run tests\n:::\n"
+	assert.Equal(t, "# Tools\n\n:::only claude\n- Run tests\n:::\n", Outline(content))
+}
+
+func TestSummarizer_Threshold(t *testing.T) {
+	t.Parallel()
+	summarizer, err := New(domain.SummarizeConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultThreshold, summarizer.Threshold())
+
+	summary, condensed, err := summarizer.Summarize(context.Background(), longRule)
+	require.NoError(t, err)
+	assert.False(t, condensed)
+	assert.Equal(t, longRule, summary)
+}
+
+func TestSummarizer_Command(t *testing.T) {
+	t.Parallel()
+	summarizer, err := New(domain.SummarizeConfig{
+		Threshold: 10,
+		Strategy:  domain.SummarizeCommand,
+		Command:   "summarizer --max 100",
+	})
+	require.NoError(t, err)
+
+	var gotArgs []string
+	summarizer.run = func(_ context.Context, args []string, input []byte) ([]byte, error) {
+		gotArgs = args
+		return []byte(strings.SplitN(string(input), "\n", 2)[0] + "\n\n"), nil
+	}
+
+	summary, condensed, err := summarizer.Summarize(context.Background(), longRule)
+	require.NoError(t, err)
+	assert.True(t, condensed)
+	assert.Equal(t, "# Error Handling\n", summary)
+	assert.Equal(t, []string{"summarizer", "--max", "100"}, gotArgs)
+
+	summarizer.run = func(context.Context, []string, []byte) ([]byte, error) {
+		return nil, errors.New("exit status 1")
+	}
+	_, _, err = summarizer.Summarize(context.Background(), longRule)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "summarizer")
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+	require.NoError(t, Validate(domain.SummarizeConfig{Strategy: domain.SummarizeStripExamples}))
+	require.NoError(t, Validate(domain.SummarizeConfig{Strategy: domain.SummarizeCommand, Command: "llm -s summarize"}))
+	require.Error(t, Validate(domain.SummarizeConfig{Strategy: domain.SummarizeCommand}))
+	require.Error(t, Validate(domain.SummarizeConfig{Strategy: "shorten"}))
+	require.Error(t, Validate(domain.SummarizeConfig{Threshold: -1}))
+}
@@ -3,6 +3,7 @@
 // This package wraps Go's text/template to provide markdown-safe template rendering
 // with custom functions for string manipulation, formatting, and array operations.
 // All template rendering is done using text/template to avoid HTML escaping.
+// The package does no logging or I/O, so it builds for js/wasm and wasip1.
 //
 // Example usage:
 //
@@ -17,11 +18,12 @@ package template
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 	"text/template"
+	"text/template/parse"
 	"unicode"
 
-	"github.com/charmbracelet/log"
 	contextureerrors "github.com/contextureai/contexture/internal/errors"
 )
 
@@ -43,6 +45,10 @@ var (
 	nonAlphaNumRegex = regexp.MustCompile(`[^a-z0-9]+`)
 )
 
+// Version identifies the rendering behavior of the engine and its functions.
+// Bump it whenever a change alters rendered output, so cached renders are discarded.
+const Version = 1
+
 // Engine defines the interface for template processing
 type Engine interface {
 	// Render processes a template with the given variables
@@ -67,8 +73,6 @@ func NewEngine() Engine {
 
 // Render processes a template with the given variables
 func (e *templateEngine) Render(templateStr string, variables map[string]any) (string, error) {
-	log.Debug("Rendering template", "vars_count", len(variables))
-
 	// Create a new template instance for thread safety
 	tmpl := template.New("render").Funcs(e.funcMap)
 	tmpl, err := tmpl.Parse(templateStr)
@@ -92,7 +96,6 @@ func (e *templateEngine) Render(templateStr string, variables map[string]any) (s
 		return "", contextureerrors.WithOpf("execute template", "content %q: %v", preview, err)
 	}
 
-	log.Debug("Successfully rendered template")
 	return result.String(), nil
 }
 
@@ -161,6 +164,11 @@ func (e *templateEngine) ExtractVariables(templateStr string) ([]string, error)
 	return variables, nil
 }
 
+// Escape returns a template that renders to text unchanged
+func Escape(text string) string {
+	return strings.ReplaceAll(text, "{{", `{{"{{"}}`)
+}
+
 // createFuncMap creates all custom functions used by Contexture
 func createFuncMap() template.FuncMap {
 	return template.FuncMap{
@@ -431,3 +439,51 @@ func defaultIfEmpty(input any, defaultValue any) any {
 	}
 	return input
 }
+
+// Partials returns the names of the partials a template defines with
+// {{define}} and the names it includes with {{template}}, each sorted
+func Partials(templateStr string) (defined, included []string, err error) {
+	tmpl, err := template.New("partials").Funcs(createFuncMap()).Parse(templateStr)
+	if err != nil {
+		return nil, nil, contextureerrors.WithOp("parse template", err)
+	}
+
+	for _, t := range tmpl.Templates() {
+		if t.Tree == nil {
+			continue
+		}
+		if t.Name() != "partials" {
+			defined = append(defined, t.Name())
+		}
+		included = appendIncludes(included, t.Tree.Root)
+	}
+
+	sort.Strings(defined)
+	sort.Strings(included)
+	return defined, included, nil
+}
+
+// appendIncludes appends the names of the templates included under node
+func appendIncludes(included []string, node parse.Node) []string {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return included
+		}
+		for _, child := range n.Nodes {
+			included = appendIncludes(included, child)
+		}
+	case *parse.TemplateNode:
+		included = append(included, n.Name)
+	case *parse.IfNode:
+		included = appendIncludes(included, n.List)
+		included = appendIncludes(included, n.ElseList)
+	case *parse.RangeNode:
+		included = appendIncludes(included, n.List)
+		included = appendIncludes(included, n.ElseList)
+	case *parse.WithNode:
+		included = appendIncludes(included, n.List)
+		included = appendIncludes(included, n.ElseList)
+	}
+	return included
+}
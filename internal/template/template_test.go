@@ -296,6 +296,16 @@ func TestTemplateEngine_ExtractVariables(t *testing.T) {
 
 // Test custom template functions
 
+func TestEscape(t *testing.T) {
+	t.Parallel()
+	engine := NewEngine()
+
+	text := "Use {{.name}} placeholders, {{ and }} braces"
+	got, err := engine.Render(Escape(text), map[string]any{"name": "x"})
+	require.NoError(t, err)
+	assert.Equal(t, text, got)
+}
+
 func TestSlugify(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -703,3 +713,19 @@ func TestErrorHandling(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestPartials(t *testing.T) {
+	t.Parallel()
+
+	content := `{{define "footer"}}Thanks{{end}}{{define "unused"}}x{{end}}
+{{if .show}}{{template "footer"}}{{end}}
+{{range .items}}{{template "missing" .}}{{end}}`
+
+	defined, included, err := Partials(content)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"footer", "unused"}, defined)
+	assert.Equal(t, []string{"footer", "missing"}, included)
+
+	_, _, err = Partials("{{if}}")
+	require.Error(t, err)
+}
@@ -0,0 +1,104 @@
+package tui
+
+import (
+	"errors"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/charmbracelet/huh"
+	"github.com/contextureai/contexture/internal/dependencies"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/ui"
+)
+
+// NonInteractiveEnv is the environment variable that enables non-interactive mode
+const NonInteractiveEnv = "CONTEXTURE_NONINTERACTIVE"
+
+// ErrNonInteractive indicates a prompt needed an answer while running non-interactively
+var ErrNonInteractive = errors.New("input required, but prompts are disabled in non-interactive mode")
+
+// nonInteractive is set by the global --non-interactive flag
+var nonInteractive atomic.Bool
+
+// SetNonInteractive enables or disables non-interactive mode
+func SetNonInteractive(enabled bool) {
+	nonInteractive.Store(enabled)
+}
+
+// NonInteractiveInEnv reports whether env enables non-interactive mode with a
+// true CONTEXTURE_NONINTERACTIVE value
+func NonInteractiveInEnv(env dependencies.Environment) bool {
+	enabled, err := strconv.ParseBool(env.Getenv(NonInteractiveEnv))
+	return err == nil && enabled
+}
+
+// IsNonInteractive reports whether prompts are disabled by SetNonInteractive,
+// which the global --non-interactive flag and CONTEXTURE_NONINTERACTIVE
+// enable, unless a session is being replayed
+func IsNonInteractive() bool {
+	// A replayed session provides the input of its prompts
+	if replaying() {
+		return false
+	}
+	return nonInteractive.Load()
+}
+
+// RequireInteractive returns an error when the prompt named by op cannot be
+// shown because non-interactive mode is enabled, and nil otherwise
+func RequireInteractive(op string, suggestions ...contextureerrors.Suggestion) error {
+	if !IsNonInteractive() {
+		return nil
+	}
+	if len(suggestions) == 0 {
+		suggestions = []contextureerrors.Suggestion{
+			contextureerrors.Hint("pass the values as arguments or flags, or run without --non-interactive"),
+		}
+	}
+	return (&contextureerrors.Error{
+		Op:   op,
+		Kind: contextureerrors.KindUsage,
+		Err:  ErrNonInteractive,
+	}).WithSuggestions(suggestions...)
+}
+
+// ConfirmOptions represents options for confirmation prompts
+type ConfirmOptions struct {
+	Title       string
+	Description string
+	Affirmative string
+	Negative    string
+	Default     bool
+}
+
+// Confirm asks a yes/no question. In non-interactive mode it returns the
+// default answer without prompting.
+func Confirm(opts ConfirmOptions) (bool, error) {
+	confirmed := opts.Default
+	if IsNonInteractive() {
+		return confirmed, nil
+	}
+
+	if opts.Affirmative == "" {
+		opts.Affirmative = "Yes"
+	}
+	if opts.Negative == "" {
+		opts.Negative = "No"
+	}
+
+	confirm := huh.NewConfirm().
+		Title(opts.Title).
+		Affirmative(opts.Affirmative).
+		Negative(opts.Negative).
+		Value(&confirmed)
+	if opts.Description != "" {
+		confirm = confirm.Description(opts.Description)
+	}
+
+	form := ui.ConfigureHuhForm(huh.NewForm(huh.NewGroup(confirm)))
+	recordAnswer, err := runSessionForm(form)
+	if err != nil {
+		return false, err
+	}
+	recordAnswer(opts.Title, confirmed)
+	return confirmed, nil
+}
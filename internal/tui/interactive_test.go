@@ -0,0 +1,74 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/contextureai/contexture/internal/dependencies"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setNonInteractive enables non-interactive mode for the rest of the test
+func setNonInteractive(t *testing.T) {
+	t.Helper()
+	SetNonInteractive(true)
+	t.Cleanup(func() { SetNonInteractive(false) })
+}
+
+func TestIsNonInteractive(t *testing.T) {
+	assert.False(t, IsNonInteractive())
+	setNonInteractive(t)
+	assert.True(t, IsNonInteractive())
+}
+
+func TestNonInteractiveInEnv(t *testing.T) {
+	t.Parallel()
+	assert.True(t, NonInteractiveInEnv(dependencies.MapEnvironment{NonInteractiveEnv: "true"}))
+	assert.True(t, NonInteractiveInEnv(dependencies.MapEnvironment{NonInteractiveEnv: "1"}))
+	assert.False(t, NonInteractiveInEnv(dependencies.MapEnvironment{NonInteractiveEnv: "0"}))
+	assert.False(t, NonInteractiveInEnv(dependencies.MapEnvironment{NonInteractiveEnv: "sometimes"}))
+	assert.False(t, NonInteractiveInEnv(dependencies.MapEnvironment{}))
+}
+
+func TestRequireInteractive(t *testing.T) {
+	t.Run("interactive", func(t *testing.T) {
+		assert.NoError(t, RequireInteractive("Select formats"))
+	})
+
+	t.Run("non_interactive", func(t *testing.T) {
+		setNonInteractive(t)
+
+		err := RequireInteractive("Select formats")
+		require.Error(t, err)
+		require.ErrorIs(t, err, ErrNonInteractive)
+		assert.Contains(t, err.Error(), "Select formats")
+		assert.Equal(t, int(contextureerrors.ExitUsageError), contextureerrors.ExitCodeFor(err))
+		assert.NotEmpty(t, contextureerrors.SuggestionsFor(err))
+	})
+
+	t.Run("prompts_fail_fast", func(t *testing.T) {
+		setNonInteractive(t)
+
+		_, err := Select(SelectOptions{Options: []SelectOption{{Label: "A", Value: "a"}}})
+		require.ErrorIs(t, err, ErrNonInteractive)
+
+		_, err = MultiSelect(MultiSelectOptions{Options: []SelectOption{{Label: "A", Value: "a"}}})
+		require.ErrorIs(t, err, ErrNonInteractive)
+
+		_, err = Inputs(InputsOptions{Fields: []InputField{{Key: "a", Title: "A"}}})
+		require.ErrorIs(t, err, ErrNonInteractive)
+	})
+}
+
+func TestConfirm_NonInteractiveTakesDefault(t *testing.T) {
+	setNonInteractive(t)
+
+	confirmed, err := Confirm(ConfirmOptions{Title: "Apply?", Default: true})
+	require.NoError(t, err)
+	assert.True(t, confirmed)
+
+	confirmed, err = Confirm(ConfirmOptions{Title: "Delete?"})
+	require.NoError(t, err)
+	assert.False(t, confirmed)
+}
@@ -33,6 +33,37 @@ func HandleFormError(err error) error {
 	return err
 }
 
+// RunForm runs a huh form with the terminal guarded, so a prompt that panics
+// or fails does not leave it in raw mode, and maps cancellation to
+// ErrUserCancelled. Forms are recorded into or replayed from the active
+// session, if any.
+func RunForm(form *huh.Form) error {
+	_, err := runSessionForm(form)
+	return err
+}
+
+// runSessionForm runs form as RunForm does and returns the function recording
+// its answer in the active session
+func runSessionForm(form *huh.Form) (func(prompt string, answer any), error) {
+	form, recordAnswer, err := sessionForm(form)
+	if err != nil {
+		return nil, err
+	}
+	err = guardTerminal(ui.SaveTerminal(), form.Run)
+	if errors.Is(err, huh.ErrTimeout) {
+		// Only replayed forms have a timeout
+		return nil, contextureerrors.ValidationErrorf("session", "the replayed keys did not complete the form")
+	}
+	return recordAnswer, HandleFormError(err)
+}
+
+// guardTerminal calls run, then restore, also when run panics. The panic
+// continues once the terminal is restored, so the crash handler reports it.
+func guardTerminal(restore func(), run func() error) error {
+	defer restore()
+	return run()
+}
+
 // SelectOptions represents options for selection prompts
 type SelectOptions struct {
 	Title       string
@@ -58,6 +89,10 @@ func Select(opts SelectOptions) (string, error) {
 		return "", contextureerrors.ValidationErrorf("options", "no options provided")
 	}
 
+	if err := RequireInteractive(opts.Title); err != nil {
+		return "", err
+	}
+
 	var selected string
 
 	// Create huh options
@@ -82,9 +117,11 @@ func Select(opts SelectOptions) (string, error) {
 		huh.NewGroup(selectPrompt),
 	))
 
-	if err := HandleFormError(form.Run()); err != nil {
+	recordAnswer, err := runSessionForm(form)
+	if err != nil {
 		return "", err
 	}
+	recordAnswer(opts.Title, selected)
 
 	return selected, nil
 }
@@ -107,6 +144,10 @@ func MultiSelect(opts MultiSelectOptions) ([]string, error) {
 		return nil, contextureerrors.ValidationErrorf("options", "no options provided")
 	}
 
+	if err := RequireInteractive(opts.Title); err != nil {
+		return nil, err
+	}
+
 	var selected []string
 
 	// Create huh options
@@ -135,9 +176,80 @@ func MultiSelect(opts MultiSelectOptions) ([]string, error) {
 		huh.NewGroup(multiSelectPrompt),
 	))
 
-	if err := HandleFormError(form.Run()); err != nil {
+	recordAnswer, err := runSessionForm(form)
+	if err != nil {
 		return nil, err
 	}
+	recordAnswer(opts.Title, selected)
 
 	return selected, nil
 }
+
+// InputsOptions represents options for multi-field input forms
+type InputsOptions struct {
+	Title       string
+	Description string
+	Fields      []InputField
+}
+
+// InputField represents a single text field in an input form
+type InputField struct {
+	Key         string
+	Title       string
+	Description string
+	Value       string
+	Validate    func(string) error
+}
+
+// Inputs prompts the user to edit several text fields in one form and
+// returns the edited values keyed by field key
+func Inputs(opts InputsOptions) (map[string]string, error) {
+	if len(opts.Fields) == 0 {
+		return nil, contextureerrors.ValidationErrorf("fields", "no fields provided")
+	}
+
+	title := opts.Title
+	if title == "" {
+		title = "Enter values"
+	}
+	if err := RequireInteractive(title); err != nil {
+		return nil, err
+	}
+
+	values := make([]string, len(opts.Fields))
+	fields := make([]huh.Field, len(opts.Fields))
+	for i, field := range opts.Fields {
+		values[i] = field.Value
+		input := huh.NewInput().
+			Title(field.Title).
+			Value(&values[i])
+		if field.Description != "" {
+			input = input.Description(field.Description)
+		}
+		if field.Validate != nil {
+			input = input.Validate(field.Validate)
+		}
+		fields[i] = input
+	}
+
+	group := huh.NewGroup(fields...)
+	if opts.Title != "" {
+		group = group.Title(opts.Title)
+	}
+	if opts.Description != "" {
+		group = group.Description(opts.Description)
+	}
+
+	form := ui.ConfigureHuhForm(huh.NewForm(group))
+	recordAnswer, err := runSessionForm(form)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]string, len(opts.Fields))
+	for i, field := range opts.Fields {
+		result[field.Key] = values[i]
+	}
+	recordAnswer(title, result)
+	return result, nil
+}
@@ -451,3 +451,25 @@ func TestMultiSelectOptions_EdgeCases(t *testing.T) {
 		assert.Equal(t, 1, defaultCount["b"], "Should have 1 'b' default")
 	})
 }
+
+func TestInputs_NoFields(t *testing.T) {
+	t.Parallel()
+
+	_, err := Inputs(InputsOptions{Title: "Edit"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no fields provided")
+}
+
+func TestGuardTerminal(t *testing.T) {
+	t.Parallel()
+
+	restored := 0
+	err := guardTerminal(func() { restored++ }, func() error { return huh.ErrUserAborted })
+	require.ErrorIs(t, err, huh.ErrUserAborted)
+	assert.Equal(t, 1, restored)
+
+	assert.PanicsWithValue(t, "render failed", func() {
+		_ = guardTerminal(func() { restored++ }, func() error { panic("render failed") })
+	})
+	assert.Equal(t, 2, restored)
+}
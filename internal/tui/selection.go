@@ -0,0 +1,88 @@
+package tui
+
+import (
+	"path"
+	"strings"
+
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+)
+
+// Selection is a choice made with flags instead of a prompt, so interactive
+// flows also work without a terminal
+type Selection struct {
+	// Values names the options selected, by value or label
+	Values []string
+	// Filter selects every option whose value or label matches it: as a glob
+	// pattern when it contains *, ? or [, otherwise as a substring
+	Filter string
+}
+
+// IsSet reports whether the selection names or filters any options
+func (s Selection) IsSet() bool {
+	return len(s.Values) > 0 || s.Filter != ""
+}
+
+// Resolve returns the values of the options the selection chooses, in option
+// order. Values naming no option and a filter matching none are errors.
+func (s Selection) Resolve(options []SelectOption) ([]string, error) {
+	chosen := make(map[string]bool)
+	for _, name := range s.Values {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		found := false
+		for _, opt := range options {
+			if strings.EqualFold(opt.Value, name) || strings.EqualFold(opt.Label, name) {
+				chosen[opt.Value] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, contextureerrors.ValidationErrorf("select",
+				"no option %q; expected one of: %s", name, strings.Join(optionValues(options), ", "))
+		}
+	}
+
+	if s.Filter != "" {
+		matched := false
+		for _, opt := range options {
+			if matchesFilter(s.Filter, opt.Value) || matchesFilter(s.Filter, opt.Label) {
+				chosen[opt.Value] = true
+				matched = true
+			}
+		}
+		if !matched {
+			return nil, contextureerrors.ValidationErrorf("all-matching",
+				"no option matches %q; expected a match for one of: %s", s.Filter, strings.Join(optionValues(options), ", "))
+		}
+	}
+
+	var selected []string
+	for _, opt := range options {
+		if chosen[opt.Value] {
+			selected = append(selected, opt.Value)
+		}
+	}
+	return selected, nil
+}
+
+// matchesFilter reports whether text matches filter, ignoring case
+func matchesFilter(filter, text string) bool {
+	filter, text = strings.ToLower(filter), strings.ToLower(text)
+	if strings.ContainsAny(filter, "*?[") {
+		matched, err := path.Match(filter, text)
+		return err == nil && matched
+	}
+	return strings.Contains(text, filter)
+}
+
+// optionValues returns the values of options
+func optionValues(options []SelectOption) []string {
+	values := make([]string, len(options))
+	for i, opt := range options {
+		values[i] = opt.Value
+	}
+	return values
+}
@@ -0,0 +1,81 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelection_Resolve(t *testing.T) {
+	t.Parallel()
+	options := []SelectOption{
+		{Label: "Claude (CLAUDE.md)", Value: "claude"},
+		{Label: "Cursor (.cursor/rules/)", Value: "cursor"},
+		{Label: "Windsurf (.windsurf/rules/)", Value: "windsurf"},
+	}
+
+	tests := []struct {
+		name      string
+		selection Selection
+		expected  []string
+		errMsg    string
+	}{
+		{
+			name:      "values in option order",
+			selection: Selection{Values: []string{"windsurf", "Claude"}},
+			expected:  []string{"claude", "windsurf"},
+		},
+		{
+			name:      "value by label",
+			selection: Selection{Values: []string{"cursor (.cursor/rules/)"}},
+			expected:  []string{"cursor"},
+		},
+		{
+			name:      "substring filter",
+			selection: Selection{Filter: "rules/"},
+			expected:  []string{"cursor", "windsurf"},
+		},
+		{
+			name:      "glob filter",
+			selection: Selection{Filter: "c*"},
+			expected:  []string{"claude", "cursor"},
+		},
+		{
+			name:      "values and filter combined",
+			selection: Selection{Values: []string{"windsurf"}, Filter: "claude"},
+			expected:  []string{"claude", "windsurf"},
+		},
+		{
+			name:      "unknown value",
+			selection: Selection{Values: []string{"copilot"}},
+			errMsg:    `no option "copilot"; expected one of: claude, cursor, windsurf`,
+		},
+		{
+			name:      "filter matching nothing",
+			selection: Selection{Filter: "zed*"},
+			errMsg:    `no option matches "zed*"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			selected, err := tt.selection.Resolve(options)
+			if tt.errMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, selected)
+		})
+	}
+}
+
+func TestSelection_IsSet(t *testing.T) {
+	t.Parallel()
+	assert.False(t, Selection{}.IsSet())
+	assert.True(t, Selection{Values: []string{"claude"}}.IsSet())
+	assert.True(t, Selection{Filter: "c*"}.IsSet())
+}
@@ -0,0 +1,221 @@
+package tui
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/spf13/afero"
+)
+
+const (
+	// SessionVersion is the version of the session file format
+	SessionVersion = 1
+	// replayTimeout bounds a replayed form, whose recorded keys may no
+	// longer complete it when the prompt has changed
+	replayTimeout = 10 * time.Second
+)
+
+// Session is a recording of the prompts of a run: the keys pressed in each
+// form, in order, and the answers of the prompts of this package. Replaying
+// it presses the same keys, so interactive flows can be reproduced in bug
+// reports and driven by end-to-end tests.
+type Session struct {
+	Version int           `json:"version"`
+	Forms   []SessionForm `json:"forms"`
+}
+
+// SessionForm is a form of a recorded session
+type SessionForm struct {
+	// Keys are the keys pressed, named as Bubble Tea names them
+	Keys []string `json:"keys"`
+	// Prompt and Answer record the title and answer of Select, MultiSelect,
+	// Inputs, and Confirm prompts; forms built elsewhere only record keys
+	Prompt string `json:"prompt,omitempty"`
+	Answer any    `json:"answer,omitempty"`
+}
+
+// LoadSession reads a session file
+func LoadSession(fs afero.Fs, path string) (*Session, error) {
+	data, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return nil, contextureerrors.WithOpf("load session", "failed to read %s: %w", path, err)
+	}
+	var session Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, contextureerrors.WithOpf("load session", "failed to parse %s: %w", path, err)
+	}
+	if session.Version != SessionVersion {
+		return nil, contextureerrors.ValidationErrorf("session",
+			"unsupported session version %d in %s, expected %d", session.Version, path, SessionVersion)
+	}
+	return &session, nil
+}
+
+// Save writes the session to a file
+func (s *Session) Save(fs afero.Fs, path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return contextureerrors.Wrap(err, "marshal session")
+	}
+	if err := afero.WriteFile(fs, path, append(data, '\n'), 0o644); err != nil {
+		return contextureerrors.WithOpf("save session", "failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// active is the session being recorded or replayed, set with RecordSession
+// and ReplaySession
+var active struct {
+	mu      sync.Mutex
+	session *Session
+	replay  bool
+	// next is the index of the next form of the session
+	next int
+}
+
+// RecordSession starts recording the forms run from now on and returns the
+// session they are recorded into
+func RecordSession() *Session {
+	active.mu.Lock()
+	defer active.mu.Unlock()
+	active.session = &Session{Version: SessionVersion}
+	active.replay = false
+	active.next = 0
+	return active.session
+}
+
+// ReplaySession makes the forms run from now on take their keys from
+// session, in order, instead of the terminal
+func ReplaySession(session *Session) {
+	active.mu.Lock()
+	defer active.mu.Unlock()
+	active.session = session
+	active.replay = true
+	active.next = 0
+}
+
+// StopSession stops recording or replaying
+func StopSession() {
+	active.mu.Lock()
+	defer active.mu.Unlock()
+	active.session = nil
+	active.replay = false
+	active.next = 0
+}
+
+// replaying reports whether a session is being replayed
+func replaying() bool {
+	active.mu.Lock()
+	defer active.mu.Unlock()
+	return active.session != nil && active.replay
+}
+
+// sessionForm prepares form for the active session. When recording, it
+// returns a function recording the answer of the form, whose keys are
+// recorded as they are pressed; when replaying, the form reads the keys of
+// the next recorded form.
+func sessionForm(form *huh.Form) (*huh.Form, func(prompt string, answer any), error) {
+	noAnswer := func(string, any) {}
+	active.mu.Lock()
+	defer active.mu.Unlock()
+	if active.session == nil {
+		return form, noAnswer, nil
+	}
+
+	if active.replay {
+		if active.next >= len(active.session.Forms) {
+			return nil, nil, contextureerrors.ValidationErrorf("session",
+				"replayed session has %d forms, but form %d was shown", len(active.session.Forms), active.next+1)
+		}
+		keys, err := keySequences(active.session.Forms[active.next].Keys)
+		if err != nil {
+			return nil, nil, err
+		}
+		active.next++
+		return form.WithInput(&keyReader{keys: keys}).WithTimeout(replayTimeout), noAnswer, nil
+	}
+
+	// Forms are recorded by index, as the slice grows with later forms
+	session := active.session
+	session.Forms = append(session.Forms, SessionForm{Keys: []string{}})
+	index := len(session.Forms) - 1
+	filter := func(_ tea.Model, msg tea.Msg) tea.Msg {
+		if key, ok := msg.(tea.KeyMsg); ok {
+			active.mu.Lock()
+			session.Forms[index].Keys = append(session.Forms[index].Keys, key.String())
+			active.mu.Unlock()
+		}
+		return msg
+	}
+	recordAnswer := func(prompt string, answer any) {
+		active.mu.Lock()
+		defer active.mu.Unlock()
+		session.Forms[index].Prompt = prompt
+		session.Forms[index].Answer = answer
+	}
+	return form.WithProgramOptions(tea.WithFilter(filter)), recordAnswer, nil
+}
+
+// namedKeys are the input sequences of the keys Bubble Tea names
+var namedKeys = map[string]string{
+	"enter":     "\r",
+	"tab":       "\t",
+	"shift+tab": "\x1b[Z",
+	"esc":       "\x1b",
+	"backspace": "\x7f",
+	"delete":    "\x1b[3~",
+	"up":        "\x1b[A",
+	"down":      "\x1b[B",
+	"right":     "\x1b[C",
+	"left":      "\x1b[D",
+	"home":      "\x1b[H",
+	"end":       "\x1b[F",
+	"pgup":      "\x1b[5~",
+	"pgdown":    "\x1b[6~",
+	" ":         " ",
+}
+
+// keySequences returns the input sequences of recorded key names
+func keySequences(keys []string) ([]string, error) {
+	sequences := make([]string, len(keys))
+	for i, key := range keys {
+		switch {
+		case namedKeys[key] != "":
+			sequences[i] = namedKeys[key]
+		case strings.HasPrefix(key, "ctrl+") && len(key) == len("ctrl+")+1 && key[5] >= 'a' && key[5] <= 'z':
+			sequences[i] = string(rune(key[5] - 'a' + 1))
+		case utf8.RuneCountInString(key) == 1:
+			sequences[i] = key
+		default:
+			return nil, contextureerrors.ValidationErrorf("session", "cannot replay key %q", key)
+		}
+	}
+	return sequences, nil
+}
+
+// keyReader returns one key sequence per read, so keys such as esc are not
+// merged with the keys after them. A form still open once the keys run out
+// waits until it times out.
+type keyReader struct {
+	keys []string
+}
+
+// Read implements io.Reader
+func (r *keyReader) Read(p []byte) (int, error) {
+	if len(r.keys) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, r.keys[0])
+	r.keys[0] = r.keys[0][n:]
+	if r.keys[0] == "" {
+		r.keys = r.keys[1:]
+	}
+	return n, nil
+}
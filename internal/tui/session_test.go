@@ -0,0 +1,83 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/huh"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeySequences(t *testing.T) {
+	t.Parallel()
+
+	sequences, err := keySequences([]string{"down", "enter", "ctrl+c", "x", " ", "é"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"\x1b[B", "\r", "\x03", "x", " ", "é"}, sequences)
+
+	_, err = keySequences([]string{"alt+ctrl+shift+f13"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot replay key")
+}
+
+func TestSession_SaveAndLoad(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+
+	session := &Session{Version: SessionVersion, Forms: []SessionForm{
+		{Keys: []string{"down", "enter"}, Prompt: "Pick a format", Answer: "cursor"},
+	}}
+	require.NoError(t, session.Save(fs, "session.json"))
+
+	loaded, err := LoadSession(fs, "session.json")
+	require.NoError(t, err)
+	assert.Equal(t, session, loaded)
+
+	require.NoError(t, afero.WriteFile(fs, "future.json", []byte(`{"version": 99, "forms": []}`), 0o644))
+	_, err = LoadSession(fs, "future.json")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported session version 99")
+}
+
+func TestReplaySession(t *testing.T) {
+	t.Cleanup(StopSession)
+	SetNonInteractive(true)
+	t.Cleanup(func() { SetNonInteractive(false) })
+
+	ReplaySession(&Session{Version: SessionVersion, Forms: []SessionForm{
+		{Keys: []string{"down", "enter"}},
+	}})
+	assert.False(t, IsNonInteractive(), "replayed sessions answer prompts")
+
+	selected, err := Select(SelectOptions{
+		Title: "Pick one",
+		Options: []SelectOption{
+			{Label: "First", Value: "first"},
+			{Label: "Second", Value: "second"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "second", selected)
+
+	// The session has no more forms to replay
+	_, err = Confirm(ConfirmOptions{Title: "Continue?"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "replayed session has 1 forms")
+}
+
+func TestRecordSession(t *testing.T) {
+	t.Cleanup(StopSession)
+	session := RecordSession()
+
+	var value string
+	form, recordAnswer, err := sessionForm(huh.NewForm(huh.NewGroup(huh.NewInput().Value(&value))))
+	require.NoError(t, err)
+	require.NotNil(t, form)
+	recordAnswer("Name", "contexture")
+
+	require.Len(t, session.Forms, 1)
+	assert.Equal(t, "Name", session.Forms[0].Prompt)
+	assert.Equal(t, "contexture", session.Forms[0].Answer)
+	assert.Empty(t, session.Forms[0].Keys)
+}
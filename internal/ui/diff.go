@@ -0,0 +1,30 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/contextureai/contexture/internal/diff"
+)
+
+// RenderDiff renders line diff output with removed lines in red, added lines
+// in green, and unchanged lines muted
+func RenderDiff(lines []diff.Line) string {
+	theme := DefaultTheme()
+	deleteStyle := lipgloss.NewStyle().Foreground(theme.Error)
+	insertStyle := lipgloss.NewStyle().Foreground(theme.Success)
+	equalStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+
+	rendered := make([]string, len(lines))
+	for i, line := range lines {
+		switch line.Op {
+		case diff.OpDelete:
+			rendered[i] = deleteStyle.Render("- " + line.Text)
+		case diff.OpInsert:
+			rendered[i] = insertStyle.Render("+ " + line.Text)
+		default:
+			rendered[i] = equalStyle.Render("  " + line.Text)
+		}
+	}
+	return strings.Join(rendered, "\n")
+}
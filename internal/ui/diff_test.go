@@ -0,0 +1,23 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/contextureai/contexture/internal/diff"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderDiff(t *testing.T) {
+	t.Parallel()
+
+	rendered := RenderDiff([]diff.Line{
+		{Op: diff.OpEqual, Text: "same"},
+		{Op: diff.OpDelete, Text: "old"},
+		{Op: diff.OpInsert, Text: "new"},
+	})
+
+	assert.Contains(t, rendered, "  same")
+	assert.Contains(t, rendered, "- old")
+	assert.Contains(t, rendered, "+ new")
+	assert.Empty(t, RenderDiff(nil))
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 	"unicode/utf8"
 
@@ -28,6 +29,58 @@ func isTerminal() bool {
 	return term.IsTerminal(int(os.Stdout.Fd()))
 }
 
+// ProgressMode selects how progress is reported
+type ProgressMode int
+
+const (
+	// ProgressInteractive redraws spinners and progress lines in place
+	ProgressInteractive ProgressMode = iota
+	// ProgressPlain prints one timestamped line per event, for logs and CI
+	ProgressPlain
+	// ProgressQuiet prints no progress at all
+	ProgressQuiet
+)
+
+// quietProgress suppresses progress output, set by the global --quiet flag
+var quietProgress atomic.Bool
+
+// SetQuiet enables or disables quiet mode. Quiet mode suppresses progress
+// output; command summaries and errors are still printed.
+func SetQuiet(quiet bool) {
+	quietProgress.Store(quiet)
+}
+
+// CurrentProgressMode returns how progress should be reported: nothing in
+// quiet mode, plain lines when stdout is not a terminal, and animated output otherwise
+func CurrentProgressMode() ProgressMode {
+	switch {
+	case quietProgress.Load():
+		return ProgressQuiet
+	case !isTerminal():
+		return ProgressPlain
+	default:
+		return ProgressInteractive
+	}
+}
+
+// progressTimestamp prefixes plain progress lines
+func progressTimestamp() string {
+	return time.Now().Format(time.TimeOnly)
+}
+
+// ProgressLine prints a completed progress event. Interactive output
+// replaces the current line, plain output prints a timestamped line, and
+// quiet mode prints nothing.
+func ProgressLine(line string) {
+	switch CurrentProgressMode() {
+	case ProgressInteractive:
+		fmt.Printf("\r\033[K%s\n", line)
+	case ProgressPlain:
+		fmt.Printf("[%s] %s\n", progressTimestamp(), strings.TrimLeft(line, " "))
+	case ProgressQuiet:
+	}
+}
+
 // ProgressIndicator provides simple progress feedback for CLI operations.
 type ProgressIndicator struct {
 	spinner  spinner.Model
@@ -56,7 +109,7 @@ func NewProgressIndicator(message string) *ProgressIndicator {
 	}
 }
 
-// Start begins displaying the progress indicator, showing a spinner in TTY mode or simple text in non-TTY environments.
+// Start begins displaying the progress indicator, showing a spinner in TTY mode or a timestamped line in non-TTY environments.
 func (pi *ProgressIndicator) Start() {
 	pi.mu.Lock()
 	defer pi.mu.Unlock()
@@ -65,13 +118,13 @@ func (pi *ProgressIndicator) Start() {
 		return
 	}
 
-	// Only show interactive spinner in TTY
-	if !isTerminal() {
-		fmt.Printf("%s\n", pi.message)
-		return
+	switch CurrentProgressMode() {
+	case ProgressInteractive:
+		fmt.Printf("%s %s", pi.spinner.View(), pi.message)
+	case ProgressPlain:
+		fmt.Printf("[%s] %s\n", progressTimestamp(), pi.message)
+	case ProgressQuiet:
 	}
-
-	fmt.Printf("%s %s", pi.spinner.View(), pi.message)
 }
 
 // Update updates the progress bar with a percentage (0.0 to 1.0) and optional message, clearing the line in TTY mode.
@@ -87,16 +140,16 @@ func (pi *ProgressIndicator) Update(percent float64, message string) {
 		pi.message = message
 	}
 
-	// Only show interactive progress bar in TTY
-	if !isTerminal() {
+	switch CurrentProgressMode() {
+	case ProgressInteractive:
+		// Clear the line and show progress bar
+		fmt.Printf("\r%s", strings.Repeat(" ", DefaultTerminalWidth))
+		fmt.Printf("\r%s %s", pi.progress.ViewAs(percent), pi.message)
+	case ProgressPlain:
 		// Simple log output for non-TTY (CI/CD, files, etc.)
-		fmt.Printf("%s (%.0f%%)\n", pi.message, percent*100)
-		return
+		fmt.Printf("[%s] %s (%.0f%%)\n", progressTimestamp(), pi.message, percent*100)
+	case ProgressQuiet:
 	}
-
-	// Clear the line and show progress bar
-	fmt.Printf("\r%s", strings.Repeat(" ", DefaultTerminalWidth))
-	fmt.Printf("\r%s %s", pi.progress.ViewAs(percent), pi.message)
 }
 
 // UpdateSpinner updates the spinner message for indeterminate progress, showing animated spinner in TTY mode.
@@ -112,16 +165,15 @@ func (pi *ProgressIndicator) UpdateSpinner(message string) {
 		pi.message = message
 	}
 
-	// Only show interactive spinner in TTY
-	if !isTerminal() {
-		// Simple log output for non-TTY
-		fmt.Printf("%s\n", pi.message)
-		return
+	switch CurrentProgressMode() {
+	case ProgressInteractive:
+		// Clear the line and show spinner
+		fmt.Printf("\r%s", strings.Repeat(" ", DefaultTerminalWidth))
+		fmt.Printf("\r%s %s", pi.spinner.View(), pi.message)
+	case ProgressPlain:
+		fmt.Printf("[%s] %s\n", progressTimestamp(), pi.message)
+	case ProgressQuiet:
 	}
-
-	// Clear the line and show spinner
-	fmt.Printf("\r%s", strings.Repeat(" ", DefaultTerminalWidth))
-	fmt.Printf("\r%s %s", pi.spinner.View(), pi.message)
 }
 
 // Finish completes the progress indicator with a success checkmark and final message.
@@ -136,14 +188,7 @@ func (pi *ProgressIndicator) Finish(message string) {
 	pi.done = true
 
 	successStyle := lipgloss.NewStyle().Foreground(pi.theme.Success)
-
-	// Only clear line in TTY
-	if isTerminal() {
-		fmt.Printf("\r%s", strings.Repeat(" ", DefaultTerminalWidth))
-		fmt.Printf("\r%s %s\n", successStyle.Render("✓"), message)
-	} else {
-		fmt.Printf("✓ %s\n", message)
-	}
+	ProgressLine(successStyle.Render("✓") + " " + message)
 }
 
 // FinishWithError completes the progress indicator with an error symbol and error message.
@@ -158,14 +203,7 @@ func (pi *ProgressIndicator) FinishWithError(message string) {
 	pi.done = true
 
 	errorStyle := lipgloss.NewStyle().Foreground(pi.theme.Error)
-
-	// Only clear line in TTY
-	if isTerminal() {
-		fmt.Printf("\r%s", strings.Repeat(" ", DefaultTerminalWidth))
-		fmt.Printf("\r%s %s\n", errorStyle.Render("✗"), message)
-	} else {
-		fmt.Printf("✗ %s\n", message)
-	}
+	ProgressLine(errorStyle.Render("✗") + " " + message)
 }
 
 // BubblesSpinner provides a spinner using bubbles components (no manual goroutines).
@@ -228,14 +266,8 @@ func (s *BubblesSpinner) Stop(finalMessage string) {
 
 	s.done = true
 
-	// Clear line and show final message
-	fmt.Printf("\r%s", strings.Repeat(" ", DefaultTerminalWidth))
-	if finalMessage != "" {
-		successStyle := lipgloss.NewStyle().Foreground(s.theme.Success)
-		fmt.Printf("\r%s %s\n", successStyle.Render("✓"), finalMessage)
-	} else {
-		fmt.Print("\r")
-	}
+	successStyle := lipgloss.NewStyle().Foreground(s.theme.Success)
+	s.finish(successStyle.Render("✓"), finalMessage)
 }
 
 // StopWithError stops the spinner and displays an error symbol with the error message.
@@ -249,13 +281,19 @@ func (s *BubblesSpinner) StopWithError(errorMessage string) {
 
 	s.done = true
 
-	// Clear line and show error message
-	fmt.Printf("\r%s", strings.Repeat(" ", DefaultTerminalWidth))
-	if errorMessage != "" {
-		errorStyle := lipgloss.NewStyle().Foreground(s.theme.Error)
-		fmt.Printf("\r%s %s\n", errorStyle.Render("✗"), errorMessage)
-	} else {
-		fmt.Print("\r")
+	errorStyle := lipgloss.NewStyle().Foreground(s.theme.Error)
+	s.finish(errorStyle.Render("✗"), errorMessage)
+}
+
+// finish replaces the spinner line with the final message, or just clears it
+// when the message is empty
+func (s *BubblesSpinner) finish(icon, message string) {
+	if message != "" {
+		ProgressLine(icon + " " + message)
+		return
+	}
+	if CurrentProgressMode() == ProgressInteractive {
+		fmt.Print("\r\033[K")
 	}
 }
 
@@ -277,10 +315,15 @@ func ProgressBar(current, total int, message string) {
 	bar := strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
 	percentage := int(percent * 100)
 
-	fmt.Printf("\r[%s] %d%% (%d/%d) %s", bar, percentage, current, total, message)
-
-	if current >= total {
-		fmt.Println()
+	switch CurrentProgressMode() {
+	case ProgressInteractive:
+		fmt.Printf("\r[%s] %d%% (%d/%d) %s", bar, percentage, current, total, message)
+		if current >= total {
+			fmt.Println()
+		}
+	case ProgressPlain:
+		fmt.Printf("[%s] [%s] %d%% (%d/%d) %s\n", progressTimestamp(), bar, percentage, current, total, message)
+	case ProgressQuiet:
 	}
 }
 
@@ -298,8 +341,8 @@ func formatDuration(d time.Duration) string {
 	return d.Round(time.Millisecond).String()
 }
 
-// getTerminalWidth returns the terminal width, fallback to 80
-func getTerminalWidth() int {
+// TerminalWidth returns the terminal width, fallback to 80
+func TerminalWidth() int {
 	width, _, err := term.GetSize(int(os.Stdout.Fd()))
 	if err != nil {
 		return 80 // fallback
@@ -315,8 +358,10 @@ func WithProgress(message string, fn func() error) error {
 
 	spinner := NewBubblesSpinner(message)
 
-	// Show initial state
-	fmt.Print(spinner.View())
+	// Show initial state; plain output reports only the outcome
+	if CurrentProgressMode() == ProgressInteractive {
+		fmt.Print(spinner.View())
+	}
 
 	err := fn()
 	if err != nil {
@@ -337,8 +382,10 @@ func WithProgressTiming(message string, fn func() error) error {
 	spinner := NewBubblesSpinner(message)
 	start := time.Now()
 
-	// Show initial state
-	fmt.Print(spinner.View())
+	// Show initial state; plain output reports only the outcome
+	if CurrentProgressMode() == ProgressInteractive {
+		fmt.Print(spinner.View())
+	}
 
 	err := fn()
 	duration := time.Since(start)
@@ -355,8 +402,17 @@ func WithProgressTiming(message string, fn func() error) error {
 
 // showTimedCompletion shows a completion message with right-aligned timing
 func showTimedCompletion(icon, message string, duration time.Duration, indent int) {
-	termWidth := getTerminalWidth()
 	durationText := fmt.Sprintf("[%s]", formatDuration(duration))
+	switch CurrentProgressMode() {
+	case ProgressQuiet:
+		return
+	case ProgressPlain:
+		fmt.Printf("[%s] %s %s %s\n", progressTimestamp(), icon, message, durationText)
+		return
+	case ProgressInteractive:
+	}
+
+	termWidth := TerminalWidth()
 	// Use RuneCountInString to count visual characters, not bytes
 	visualTextLength := utf8.RuneCountInString(durationText)
 
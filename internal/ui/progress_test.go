@@ -357,3 +357,57 @@ func TestWithProgress(t *testing.T) {
 		assert.Contains(t, err.Error(), "progress function cannot be nil")
 	})
 }
+
+func TestProgressMode(t *testing.T) {
+	captureStdout := func(fn func()) string {
+		oldStdout := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		fn()
+
+		_ = w.Close()
+		os.Stdout = oldStdout
+		output, _ := io.ReadAll(r)
+		return string(output)
+	}
+
+	t.Run("plain_when_not_a_terminal", func(t *testing.T) {
+		var mode ProgressMode
+		captureStdout(func() { mode = CurrentProgressMode() })
+		assert.Equal(t, ProgressPlain, mode)
+	})
+
+	t.Run("plain_lines_are_timestamped", func(t *testing.T) {
+		outputStr := captureStdout(func() {
+			ProgressLine("  ✓ rule checked")
+			_ = WithProgressTiming("Generated rules", func() error { return nil })
+		})
+
+		lines := strings.Split(strings.TrimSuffix(outputStr, "\n"), "\n")
+		require.Len(t, lines, 2)
+		assert.Regexp(t, `^\[\d{2}:\d{2}:\d{2}\] ✓ rule checked$`, lines[0])
+		assert.Regexp(t, `^\[\d{2}:\d{2}:\d{2}\] ✓ Generated rules \[.+\]$`, lines[1])
+		assert.NotContains(t, outputStr, "\r")
+	})
+
+	t.Run("quiet_suppresses_progress", func(t *testing.T) {
+		SetQuiet(true)
+		defer SetQuiet(false)
+
+		called := false
+		outputStr := captureStdout(func() {
+			assert.Equal(t, ProgressQuiet, CurrentProgressMode())
+			ProgressLine("rule checked")
+			ProgressBar(1, 2, "Half done")
+			_ = WithProgress("Fetched rules", func() error {
+				called = true
+				return nil
+			})
+			ShowFormatCompletion("Claude", time.Second)
+		})
+
+		assert.True(t, called)
+		assert.Empty(t, outputStr)
+	})
+}
@@ -0,0 +1,98 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/contextureai/contexture/internal/diff"
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/contextureai/contexture/internal/ui"
+)
+
+// compareLabelWidth is the width of the field name column in comparisons
+const compareLabelWidth = 14
+
+// DisplayRuleComparison shows two rules' metadata side by side followed by a
+// diff of their content
+func DisplayRuleComparison(left, right *domain.Rule, lines []diff.Line) error {
+	styles := createDisplayStyles()
+	theme := ui.DefaultTheme()
+
+	fmt.Printf("%s\n\n", styles.header.Render("Compare Rules"))
+
+	// Split the remaining width between the two rules
+	columnWidth := max((ui.TerminalWidth()-compareLabelWidth-2)/2, 20)
+	labelStyle := lipgloss.NewStyle().Width(compareLabelWidth).Foreground(theme.Muted)
+	columnStyle := lipgloss.NewStyle().Width(columnWidth).PaddingRight(1)
+	changedStyle := columnStyle.Foreground(theme.Warning)
+
+	row := func(label, a, b string, style lipgloss.Style) {
+		fmt.Println(lipgloss.JoinHorizontal(lipgloss.Top,
+			labelStyle.Render(label), style.Render(a), style.Render(b)))
+	}
+
+	row("", left.ID, right.ID, columnStyle.Inherit(styles.rulePath))
+	for _, field := range comparisonFields(left, right) {
+		style := columnStyle
+		if field.left != field.right {
+			style = changedStyle
+		}
+		row(field.label, field.left, field.right, style)
+	}
+
+	fmt.Printf("\n%s\n", styles.header.Render("Content"))
+	if !diff.HasChanges(lines) {
+		fmt.Println(styles.muted.Render("Rule content is identical"))
+		return nil
+	}
+
+	fmt.Println(ui.RenderDiff(lines))
+
+	return nil
+}
+
+// comparisonField is one row of the side-by-side metadata table
+type comparisonField struct {
+	label       string
+	left, right string
+}
+
+// comparisonFields returns the metadata rows compared between two rules
+func comparisonFields(left, right *domain.Rule) []comparisonField {
+	return []comparisonField{
+		{"Title", left.Title, right.Title},
+		{"Description", left.Description, right.Description},
+		{"Tags", strings.Join(left.Tags, ", "), strings.Join(right.Tags, ", ")},
+		{"Languages", strings.Join(left.Languages, ", "), strings.Join(right.Languages, ", ")},
+		{"Frameworks", strings.Join(left.Frameworks, ", "), strings.Join(right.Frameworks, ", ")},
+		{"Trigger", formatTrigger(left.Trigger), formatTrigger(right.Trigger)},
+		{"Variables", formatSortedVariables(left.DefaultVariables), formatSortedVariables(right.DefaultVariables)},
+		{"Source", left.Source, right.Source},
+		{"Lines", fmt.Sprint(countLines(left.Content)), fmt.Sprint(countLines(right.Content))},
+	}
+}
+
+// formatSortedVariables formats variables as key=value pairs in key order
+func formatSortedVariables(variables map[string]any) string {
+	keys := make([]string, 0, len(variables))
+	for key := range variables {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = fmt.Sprintf("%s=%v", key, variables[key])
+	}
+	return strings.Join(parts, ", ")
+}
+
+// countLines returns the number of lines in content
+func countLines(content string) int {
+	if content == "" {
+		return 0
+	}
+	return strings.Count(strings.TrimSuffix(content, "\n"), "\n") + 1
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/contextureai/contexture/internal/domain"
 	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/query"
 	"github.com/contextureai/contexture/internal/ui"
 )
 
@@ -19,7 +20,10 @@ type DisplayOptions struct {
 	ShowTriggers  bool
 	ShowVariables bool
 	ShowTags      bool
+	ShowOwners    bool
 	Pattern       string // Regex pattern for filtering rules
+	// LastChanges maps rule IDs to the last commit that changed them
+	LastChanges map[string]domain.RuleChange
 }
 
 // DefaultDisplayOptions returns sensible defaults for rule display
@@ -221,6 +225,10 @@ func DisplayRuleList(rules []*domain.Rule, options DisplayOptions) error {
 				fmt.Sprintf("Tags: %s", strings.Join(rule.Tags, ", ")))
 		}
 
+		if options.ShowOwners && rule.Owner != "" {
+			metadataLines = append(metadataLines, "Owner: "+rule.Owner)
+		}
+
 		if options.ShowTriggers && rule.Trigger != nil {
 			trigger := formatTrigger(rule.Trigger)
 			if trigger != "" {
@@ -235,6 +243,10 @@ func DisplayRuleList(rules []*domain.Rule, options DisplayOptions) error {
 			}
 		}
 
+		if change, ok := options.LastChanges[rule.ID]; ok {
+			metadataLines = append(metadataLines, "Last change: "+formatRuleChange(change))
+		}
+
 		// Display metadata lines
 		for _, line := range metadataLines {
 			fmt.Println(styles.metadata.Render(line))
@@ -244,6 +256,26 @@ func DisplayRuleList(rules []*domain.Rule, options DisplayOptions) error {
 	return nil
 }
 
+// formatRuleChange formats a rule's last commit as "2 Jan 2006 by Author (abc1234)"
+func formatRuleChange(change domain.RuleChange) string {
+	text := change.Date
+	if change.Author != "" {
+		text += " by " + change.Author
+	}
+	if change.Hash != "" {
+		text += fmt.Sprintf(" (%s)", truncateHash(change.Hash))
+	}
+	return text
+}
+
+// truncateHash shortens a commit hash for display
+func truncateHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
+
 // extractRulePath extracts the display path from a rule ID
 func extractRulePath(ruleID string) string {
 	return domain.ExtractRuleDisplayPath(ruleID)
@@ -381,9 +413,9 @@ func truncateString(s string, maxLen int) string {
 }
 
 // DisplayQueryResults displays query results with appropriate header
-func DisplayQueryResults(rules []*domain.Rule, query string, queryType string, _ DisplayOptions) error {
+func DisplayQueryResults(rules []*domain.Rule, queryStr string, queryType string, _ DisplayOptions) error {
 	if len(rules) == 0 {
-		fmt.Printf("No rules found matching query: %s\n", query)
+		fmt.Printf("No rules found matching query: %s\n", queryStr)
 		return nil
 	}
 
@@ -394,18 +426,21 @@ func DisplayQueryResults(rules []*domain.Rule, query string, queryType string, _
 	if queryType == "expr" {
 		headerText += " expression"
 	} else {
-		headerText += fmt.Sprintf(" \"%s\"", query)
+		headerText += fmt.Sprintf(" \"%s\"", queryStr)
 	}
 	fmt.Printf("%s\n\n", styles.header.Render(headerText))
 
-	// Sort rules by path for consistent output
+	// Text results arrive ranked by match score; sort expression results by path
+	// for consistent output
 	sortedRules := make([]*domain.Rule, len(rules))
 	copy(sortedRules, rules)
-	sort.Slice(sortedRules, func(i, j int) bool {
-		pathI := extractRulePath(sortedRules[i].ID)
-		pathJ := extractRulePath(sortedRules[j].ID)
-		return pathI < pathJ
-	})
+	if queryType == "expr" {
+		sort.Slice(sortedRules, func(i, j int) bool {
+			pathI := extractRulePath(sortedRules[i].ID)
+			pathJ := extractRulePath(sortedRules[j].ID)
+			return pathI < pathJ
+		})
+	}
 
 	// Display each rule in compact format
 	for _, rule := range sortedRules {
@@ -416,8 +451,13 @@ func DisplayQueryResults(rules []*domain.Rule, query string, queryType string, _
 		}
 		fmt.Println(styles.rulePath.Render(rulePath))
 
-		// 2. Title on next line with indentation
-		fmt.Println(styles.ruleTitle.Render(rule.Title))
+		// 2. Title on next line with indentation, highlighting matched characters
+		if queryType == "expr" {
+			fmt.Println(styles.ruleTitle.Render(rule.Title))
+		} else {
+			positions := query.MatchScore(rule, queryStr).TitlePositions
+			fmt.Println(highlightMatches(rule.Title, positions, styles))
+		}
 
 		// 3. Description (truncated) on third line with indentation
 		if rule.Description != "" {
@@ -428,3 +468,36 @@ func DisplayQueryResults(rules []*domain.Rule, query string, queryType string, _
 
 	return nil
 }
+
+// highlightMatches renders a rule title with the characters at positions
+// emphasized, indented to line up with the other result lines
+func highlightMatches(title string, positions []int, styles DisplayStyles) string {
+	if len(positions) == 0 {
+		return styles.ruleTitle.Render(title)
+	}
+
+	plain := styles.ruleTitle.MarginLeft(0)
+	matched := plain.Bold(true).Underline(true).Foreground(styles.header.GetForeground())
+
+	matchSet := make(map[int]bool, len(positions))
+	for _, pos := range positions {
+		matchSet[pos] = true
+	}
+
+	var b strings.Builder
+	b.WriteString("  ")
+	runes := []rune(title)
+	for start := 0; start < len(runes); {
+		end := start
+		for end < len(runes) && matchSet[end] == matchSet[start] {
+			end++
+		}
+		if matchSet[start] {
+			b.WriteString(matched.Render(string(runes[start:end])))
+		} else {
+			b.WriteString(plain.Render(string(runes[start:end])))
+		}
+		start = end
+	}
+	return b.String()
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/contextureai/contexture/internal/diff"
 	"github.com/contextureai/contexture/internal/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -232,6 +233,35 @@ func TestDisplayRuleList_WithTags(t *testing.T) {
 	assert.Contains(t, output, "Tags: go, testing, best-practices")
 }
 
+func TestDisplayRuleList_WithOwners(t *testing.T) {
+	// t.Parallel() // Removed due to stdout capture
+
+	rules := []*domain.Rule{
+		{
+			ID:    "[contexture:security/secrets]",
+			Title: "Secrets",
+			Owner: "@acme/security",
+		},
+		{
+			ID:    "[contexture:languages/go/testing]",
+			Title: "Go Testing",
+		},
+	}
+
+	output := captureOutput(t, func() {
+		err := DisplayRuleList(rules, DisplayOptions{ShowOwners: true})
+		assert.NoError(t, err)
+	})
+	assert.Contains(t, output, "Owner: @acme/security")
+	assert.Equal(t, 1, strings.Count(output, "Owner:"))
+
+	output = captureOutput(t, func() {
+		err := DisplayRuleList(rules, DisplayOptions{})
+		assert.NoError(t, err)
+	})
+	assert.NotContains(t, output, "Owner:")
+}
+
 func TestDisplayRuleList_WithTriggers(t *testing.T) {
 	// t.Parallel() // Removed due to stdout capture
 
@@ -961,3 +991,63 @@ func TestDisplayRuleList_WithInvalidPattern(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "validation failed for pattern")
 }
+
+func TestDisplayRuleComparison(t *testing.T) {
+	left := &domain.Rule{
+		ID:      "@one/go/testing",
+		Title:   "Go Testing",
+		Tags:    []string{"go", "testing"},
+		Content: "Use table tests.\nRun with -race.",
+	}
+	right := &domain.Rule{
+		ID:      "@two/go/testing",
+		Title:   "Go Testing",
+		Tags:    []string{"go"},
+		Content: "Use table tests.\nPrefer testify.",
+	}
+
+	output := captureOutput(t, func() {
+		err := DisplayRuleComparison(left, right, diff.Lines(left.Content, right.Content))
+		require.NoError(t, err)
+	})
+
+	assert.Contains(t, output, "@one/go/testing")
+	assert.Contains(t, output, "@two/go/testing")
+	assert.Contains(t, output, "go, testing")
+	assert.Contains(t, output, "- Run with -race.")
+	assert.Contains(t, output, "+ Prefer testify.")
+}
+
+func TestDisplayRuleComparison_IdenticalContent(t *testing.T) {
+	rule := &domain.Rule{ID: "@one/go/testing", Title: "Go Testing", Content: "Same"}
+
+	output := captureOutput(t, func() {
+		err := DisplayRuleComparison(rule, rule, diff.Lines(rule.Content, rule.Content))
+		require.NoError(t, err)
+	})
+
+	assert.Contains(t, output, "Rule content is identical")
+}
+
+func TestDisplayRuleList_WithLastChanges(t *testing.T) {
+	// t.Parallel() // Removed due to stdout capture
+
+	rules := []*domain.Rule{
+		{ID: "[contexture:languages/go/testing]", Title: "Go Testing"},
+		{ID: "[contexture(local):team]", Title: "Team"},
+	}
+
+	options := DisplayOptions{
+		LastChanges: map[string]domain.RuleChange{
+			"[contexture:languages/go/testing]": {Hash: "abc1234def5678", Date: "2 Jan 2025", Author: "Jane Doe"},
+		},
+	}
+
+	output := captureOutput(t, func() {
+		err := DisplayRuleList(rules, options)
+		assert.NoError(t, err)
+	})
+
+	assert.Contains(t, output, "Last change: 2 Jan 2025 by Jane Doe (abc1234)")
+	assert.Equal(t, 1, strings.Count(output, "Last change:"))
+}
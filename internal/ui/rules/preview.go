@@ -0,0 +1,41 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/contextureai/contexture/internal/domain"
+	"github.com/contextureai/contexture/internal/ui"
+)
+
+// DisplayRulePreview shows a rule's metadata followed by its rendered content
+func DisplayRulePreview(rule *domain.Rule, content string) error {
+	styles := createDisplayStyles()
+	theme := ui.DefaultTheme()
+
+	fmt.Printf("%s\n\n", styles.header.Render("Preview"))
+	fmt.Println(styles.rulePath.Render(domain.ExtractRulePath(rule.ID)))
+	if rule.Title != "" {
+		fmt.Println(styles.ruleTitle.Render(rule.Title))
+	}
+	if rule.Description != "" {
+		fmt.Println(styles.metadata.Render(rule.Description))
+	}
+	if len(rule.Tags) > 0 {
+		fmt.Println(styles.metadata.Render("Tags: " + strings.Join(rule.Tags, ", ")))
+	}
+	if rule.Trigger != nil {
+		fmt.Println(styles.metadata.Render("Trigger: " + formatTrigger(rule.Trigger)))
+	}
+	fmt.Println()
+
+	contentStyle := lipgloss.NewStyle().
+		Border(lipgloss.NormalBorder(), false, false, false, true).
+		BorderForeground(theme.Muted).
+		PaddingLeft(1).
+		Width(max(ui.TerminalWidth()-4, 20))
+	fmt.Println(contentStyle.Render(strings.TrimRight(content, "\n")))
+
+	return nil
+}
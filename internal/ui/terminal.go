@@ -0,0 +1,34 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/term"
+)
+
+// SaveTerminal records the state of the terminal on stdin and returns a
+// function that restores it, shows the cursor, and clears any progress line.
+// It is called before the process exits while a spinner or prompt may still
+// be active, which would otherwise leave the terminal in raw mode.
+func SaveTerminal() func() {
+	fd := int(os.Stdin.Fd())
+	var state *term.State
+	if term.IsTerminal(fd) {
+		state, _ = term.GetState(fd)
+	}
+	return func() {
+		if state != nil {
+			_ = term.Restore(fd, state)
+		}
+		if CurrentProgressMode() == ProgressInteractive {
+			fmt.Print("\r\033[K\033[?25h")
+		}
+	}
+}
+
+// StdinIsTerminal reports whether stdin is a terminal, so optional prompts can
+// be skipped when nobody can answer them
+func StdinIsTerminal() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
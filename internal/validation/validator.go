@@ -6,6 +6,7 @@ package validation
 import (
 	"context"
 	"errors"
+	"path/filepath"
 	"reflect"
 	"strings"
 
@@ -153,6 +154,10 @@ func (v *defaultValidator) ValidateRule(rule *domain.Rule) *domain.ValidationRes
 			}
 		}
 
+		if rule.Kind != "" && rule.Kind != domain.RuleKindContext && rule.Kind != domain.RuleKindCommand {
+			result.AddError("kind", "must be one of: context command", "INVALID_KIND")
+		}
+
 		// Content is still required even for local rules
 		if strings.TrimSpace(rule.Content) == "" {
 			result.AddError("content", "rule content cannot be empty", "EMPTY_CONTENT")
@@ -206,6 +211,10 @@ func (v *defaultValidator) ValidateRule(rule *domain.Rule) *domain.ValidationRes
 		}
 	}
 
+	if err := domain.ValidateFormatSections(rule.Content); err != nil {
+		result.AddError("content", err.Error(), "INVALID_FORMAT_SECTION")
+	}
+
 	return result
 }
 
@@ -294,6 +303,68 @@ func (v *defaultValidator) ValidateProject(config *domain.Project) error {
 		ruleIDs[rule.ID] = true
 	}
 
+	if err := validateExperiments(config); err != nil {
+		return err
+	}
+
+	if config.Sensitive != nil && config.Sensitive.Bundle != "" && !filepath.IsLocal(config.Sensitive.Bundle) {
+		return contextureerrors.WithOpf(
+			ValidationOperation+" project",
+			"sensitive.bundle %s must be a path inside the project", config.Sensitive.Bundle,
+		)
+	}
+
+	// Validate unique saved filter names
+	filterNames := make(map[string]bool)
+	for _, filter := range config.Filters {
+		if filterNames[filter.Name] {
+			return contextureerrors.WithOpf(
+				ValidationOperation+" project",
+				"duplicate filter name: %s", filter.Name,
+			)
+		}
+		filterNames[filter.Name] = true
+	}
+
+	return nil
+}
+
+// validateExperiments checks that each variant belongs to one experiment,
+// defaults are variants of their experiment, and rules join declared variants
+func validateExperiments(config *domain.Project) error {
+	variants := make(map[string]string)
+	for name, experiment := range config.Experiments {
+		if len(experiment.Variants) == 0 {
+			return contextureerrors.WithOpf(
+				ValidationOperation+" project",
+				"experiment %s has no variants", name,
+			)
+		}
+		for _, variant := range experiment.Variants {
+			if other, exists := variants[variant]; exists {
+				return contextureerrors.WithOpf(
+					ValidationOperation+" project",
+					"variant %s is declared by experiments %s and %s", variant, other, name,
+				)
+			}
+			variants[variant] = name
+		}
+		if experiment.Default != "" && variants[experiment.Default] != name {
+			return contextureerrors.WithOpf(
+				ValidationOperation+" project",
+				"default %s of experiment %s is not one of its variants", experiment.Default, name,
+			)
+		}
+	}
+
+	for _, rule := range config.Rules {
+		if rule.Experiment != "" && variants[rule.Experiment] == "" {
+			return contextureerrors.WithOpf(
+				ValidationOperation+" project",
+				"rule %s joins undeclared experiment variant %s", rule.ID, rule.Experiment,
+			)
+		}
+	}
 	return nil
 }
 
@@ -458,7 +529,7 @@ func (v *defaultValidator) validateFormatType(fl validator.FieldLevel) bool {
 	}
 	// Valid format types
 	switch ft {
-	case domain.FormatClaude, domain.FormatCursor, domain.FormatWindsurf:
+	case domain.FormatClaude, domain.FormatCursor, domain.FormatWindsurf, domain.FormatCopilot, domain.FormatGemini:
 		return true
 	default:
 		return false
@@ -66,6 +66,18 @@ func TestValidateRule(t *testing.T) {
 			wantValid: false, // Contains invalid characters
 			wantError: "invalid character",
 		},
+		{
+			name: "unclosed format section",
+			rule: &domain.Rule{
+				ID:          "[contexture:test/rule]",
+				Title:       "Test Rule",
+				Description: "A test rule",
+				Tags:        []string{"test"},
+				Content:     "Rule content\n:::only cursor\nCursor only",
+			},
+			wantValid: false,
+			wantError: "not closed",
+		},
 		{
 			name: "rule with invalid trigger",
 			rule: &domain.Rule{
@@ -190,6 +202,72 @@ func TestValidateProject(t *testing.T) {
 			wantErr: true,
 			errMsg:  "duplicate rule ID",
 		},
+		{
+			name: "duplicate filter names",
+			config: &domain.Project{
+				Version: 1,
+				Filters: []domain.SavedFilter{
+					{Name: "security-review"},
+					{Name: "security-review"},
+				},
+			},
+			wantErr: true,
+			errMsg:  "duplicate filter name",
+		},
+		{
+			name: "valid experiment",
+			config: &domain.Project{
+				Version: 1,
+				Rules: []domain.RuleRef{
+					{ID: "[contexture:retrieval/b]", Experiment: "retrieval-style-b"},
+				},
+				Experiments: map[string]domain.Experiment{
+					"retrieval-style": {Variants: []string{"retrieval-style-a", "retrieval-style-b"}, Default: "retrieval-style-a"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "experiment default outside its variants",
+			config: &domain.Project{
+				Version: 1,
+				Experiments: map[string]domain.Experiment{
+					"retrieval-style": {Variants: []string{"retrieval-style-a"}, Default: "retrieval-style-b"},
+				},
+			},
+			wantErr: true,
+			errMsg:  "is not one of its variants",
+		},
+		{
+			name: "rule in undeclared experiment variant",
+			config: &domain.Project{
+				Version: 1,
+				Rules: []domain.RuleRef{
+					{ID: "[contexture:retrieval/b]", Experiment: "retrieval-style-b"},
+				},
+			},
+			wantErr: true,
+			errMsg:  "undeclared experiment variant",
+		},
+		{
+			name: "sensitive bundle outside the project",
+			config: &domain.Project{
+				Version:   1,
+				Sensitive: &domain.SensitiveConfig{Bundle: "../outputs.enc"},
+			},
+			wantErr: true,
+			errMsg:  "must be a path inside the project",
+		},
+		{
+			name: "invalid filter source",
+			config: &domain.Project{
+				Version: 1,
+				Filters: []domain.SavedFilter{
+					{Name: "mine", Source: "elsewhere"},
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,228 @@
+// Package vendored keeps copies of a project's remote rules in the
+// .contexture/vendor directory, with a lockfile of their hashes, so builds
+// resolve them without the network
+package vendored
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/spf13/afero"
+)
+
+const (
+	// DirName is the vendor directory in the .contexture directory of a project
+	DirName = "vendor"
+	// LockFile is the lockfile in the vendor directory
+	LockFile = "lock.json"
+)
+
+// Lock lists the vendored files with their SHA-256 checksums
+type Lock struct {
+	// Rules are the vendored rule files
+	Rules []Rule `json:"rules"`
+	// Files are the repository indexes and assets the rules link to
+	Files []File `json:"files,omitempty"`
+}
+
+// Rule is a vendored rule file, identified by the repository, path, and
+// revision it was resolved from
+type Rule struct {
+	Source   string `json:"source"`
+	RulePath string `json:"rulePath"`
+	Ref      string `json:"ref,omitempty"`
+	Commit   string `json:"commit,omitempty"`
+	File
+}
+
+// File is a vendored file, with its slash-separated path in the vendor
+// directory
+type File struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+}
+
+// Dir returns the vendor directory of projectDir
+func Dir(projectDir string) string {
+	return filepath.Join(projectDir, domain.ContextureDir, DirName)
+}
+
+// RepoDir returns the slash-separated directory in the vendor directory
+// holding the files of the repository at source, at commit when set and
+// otherwise at ref, such as github.com/contextureai/rules@main
+func RepoDir(source, ref, commit string) string {
+	name := source
+	if _, rest, ok := strings.Cut(name, "://"); ok {
+		name = rest
+	}
+	// Drop the user of URLs such as git@github.com:org/rules.git
+	if at := strings.Index(name, "@"); at >= 0 && at < strings.IndexAny(name+"/", ":/") {
+		name = name[at+1:]
+	}
+	name = strings.TrimSuffix(strings.ReplaceAll(name, ":", "/"), ".git")
+
+	var segments []string
+	for segment := range strings.SplitSeq(name, "/") {
+		if segment != "" && segment != "." && segment != ".." {
+			segments = append(segments, sanitize(segment))
+		}
+	}
+	revision := ref
+	if commit != "" {
+		revision = commit
+	}
+	if revision == "" {
+		revision = "HEAD"
+	}
+	return strings.Join(segments, "/") + "@" + sanitize(revision)
+}
+
+// sanitize replaces the characters of segment that are not safe in file
+// names
+func sanitize(segment string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '_', r == '-':
+			return r
+		default:
+			return '-'
+		}
+	}, segment)
+}
+
+// ReadLock returns the lockfile of projectDir, or nil when the project has no
+// vendored rules
+func ReadLock(fs afero.Fs, projectDir string) (*Lock, error) {
+	data, err := afero.ReadFile(fs, filepath.Join(Dir(projectDir), LockFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "read vendor lockfile")
+	}
+	var lock Lock
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, contextureerrors.Validation("vendor", "invalid vendor lockfile: "+err.Error()).
+			WithSuggestions(contextureerrors.RunCommand("contexture vendor", "vendor the rules again"))
+	}
+	return &lock, nil
+}
+
+// FindRule returns the vendored rule at rulePath of the repository at source,
+// resolved at commit when set and otherwise at ref
+func (l *Lock) FindRule(source, rulePath, ref, commit string) (Rule, bool) {
+	for _, rule := range l.Rules {
+		if rule.Source == source && rule.RulePath == rulePath && rule.Commit == commit && (commit != "" || rule.Ref == ref) {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+// FindFile returns the vendored file at the slash-separated path
+func (l *Lock) FindFile(filePath string) (File, bool) {
+	for _, file := range l.Files {
+		if file.Path == filePath {
+			return file, true
+		}
+	}
+	return File{}, false
+}
+
+// ReadFile returns the content of the vendored file, after checking it
+// against the checksum in the lockfile
+func ReadFile(fs afero.Fs, projectDir string, file File) ([]byte, error) {
+	data, err := afero.ReadFile(fs, filepath.Join(Dir(projectDir), filepath.FromSlash(file.Path)))
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "read vendored "+file.Path).
+			WithSuggestions(contextureerrors.RunCommand("contexture vendor", "vendor the rules again"))
+	}
+	if checksum := domain.SHA256Hex(data); checksum != file.SHA256 {
+		return nil, contextureerrors.Validation("vendor",
+			"vendored "+file.Path+" does not match the lockfile: checksum is "+checksum+", expected "+file.SHA256).
+			WithSuggestions(contextureerrors.RunCommand("contexture vendor", "vendor the rules again"))
+	}
+	return data, nil
+}
+
+// Snapshot collects the files to vendor
+type Snapshot struct {
+	lock  Lock
+	files map[string][]byte
+}
+
+// NewSnapshot returns an empty snapshot
+func NewSnapshot() *Snapshot {
+	return &Snapshot{files: make(map[string][]byte)}
+}
+
+// AddRule adds the content of the rule at rulePath of the repository at
+// source, resolved at ref or at commit
+func (s *Snapshot) AddRule(source, rulePath, ref, commit string, data []byte) {
+	if _, ok := s.lock.FindRule(source, rulePath, ref, commit); ok {
+		return
+	}
+	filePath := path.Join(RepoDir(source, ref, commit), rulePath+".md")
+	s.files[filePath] = data
+	s.lock.Rules = append(s.lock.Rules, Rule{
+		Source:   source,
+		RulePath: rulePath,
+		Ref:      ref,
+		Commit:   commit,
+		File:     File{Path: filePath, SHA256: domain.SHA256Hex(data)},
+	})
+}
+
+// AddFile adds a file of the repository directory repoDir, as returned by
+// RepoDir, at the slash-separated relPath
+func (s *Snapshot) AddFile(repoDir, relPath string, data []byte) {
+	filePath := path.Join(repoDir, relPath)
+	if _, ok := s.files[filePath]; ok {
+		return
+	}
+	s.files[filePath] = data
+	s.lock.Files = append(s.lock.Files, File{Path: filePath, SHA256: domain.SHA256Hex(data)})
+}
+
+// Write replaces the vendor directory of projectDir with the snapshot and
+// returns its lockfile
+func (s *Snapshot) Write(fs afero.Fs, projectDir string) (*Lock, error) {
+	dir := Dir(projectDir)
+	if err := fs.RemoveAll(dir); err != nil {
+		return nil, contextureerrors.Wrap(err, "clear vendor directory")
+	}
+	for filePath, data := range s.files {
+		target := filepath.Join(dir, filepath.FromSlash(filePath))
+		if err := fs.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return nil, contextureerrors.Wrap(err, "create vendor directory")
+		}
+		if err := afero.WriteFile(fs, target, data, 0o644); err != nil {
+			return nil, contextureerrors.Wrap(err, "write vendored "+filePath)
+		}
+	}
+
+	lock := s.lock
+	slices.SortFunc(lock.Rules, func(a, b Rule) int { return strings.Compare(a.Path, b.Path) })
+	slices.SortFunc(lock.Files, func(a, b File) int { return strings.Compare(a.Path, b.Path) })
+	if lock.Rules == nil {
+		lock.Rules = []Rule{}
+	}
+	data, err := json.MarshalIndent(lock, "", "  ")
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "encode vendor lockfile")
+	}
+	if err := fs.MkdirAll(dir, 0o755); err != nil {
+		return nil, contextureerrors.Wrap(err, "create vendor directory")
+	}
+	if err := afero.WriteFile(fs, filepath.Join(dir, LockFile), append(data, '\n'), 0o644); err != nil {
+		return nil, contextureerrors.Wrap(err, "write vendor lockfile")
+	}
+	return &lock, nil
+}
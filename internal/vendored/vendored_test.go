@@ -0,0 +1,59 @@
+package vendored
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepoDir(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "github.com/contextureai/rules@main", RepoDir("https://github.com/contextureai/rules.git", "main", ""))
+	assert.Equal(t, "github.com/acme/rules@abc123", RepoDir("git@github.com:acme/rules.git", "main", "abc123"))
+	assert.Equal(t, "example.com/rules@HEAD", RepoDir("https://user@example.com/../rules", "", ""))
+	assert.Equal(t, "example.com/team-rules@release-1.0", RepoDir("https://example.com/team rules", "release/1.0", ""))
+}
+
+func TestSnapshot(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	const source = "https://github.com/contextureai/rules.git"
+	require.NoError(t, afero.WriteFile(fs, "/work/app/.contexture/vendor/stale.md", []byte("stale"), 0o644))
+
+	lock, err := ReadLock(fs, "/work/app")
+	require.NoError(t, err)
+	assert.Nil(t, lock, "projects without a lockfile have no vendored rules")
+
+	snapshot := NewSnapshot()
+	snapshot.AddRule(source, "go/testing", "main", "", []byte("# Testing"))
+	snapshot.AddRule(source, "go/testing", "main", "", []byte("# Testing"))
+	snapshot.AddFile(RepoDir(source, "main", ""), "go/diagram.png", []byte("png"))
+	_, err = snapshot.Write(fs, "/work/app")
+	require.NoError(t, err)
+
+	exists, err := afero.Exists(fs, "/work/app/.contexture/vendor/stale.md")
+	require.NoError(t, err)
+	assert.False(t, exists, "vendoring replaces the vendor directory")
+
+	lock, err = ReadLock(fs, "/work/app")
+	require.NoError(t, err)
+	require.Len(t, lock.Rules, 1)
+	rule, ok := lock.FindRule(source, "go/testing", "main", "")
+	require.True(t, ok)
+	assert.Equal(t, "github.com/contextureai/rules@main/go/testing.md", rule.Path)
+	_, ok = lock.FindRule(source, "go/testing", "main", "abc123")
+	assert.False(t, ok)
+
+	data, err := ReadFile(fs, "/work/app", rule.File)
+	require.NoError(t, err)
+	assert.Equal(t, "# Testing", string(data))
+
+	asset, ok := lock.FindFile("github.com/contextureai/rules@main/go/diagram.png")
+	require.True(t, ok)
+	require.NoError(t, afero.WriteFile(fs, "/work/app/.contexture/vendor/"+asset.Path, []byte("changed"), 0o644))
+	_, err = ReadFile(fs, "/work/app", asset)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not match the lockfile")
+}
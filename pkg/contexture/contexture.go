@@ -0,0 +1,365 @@
+// Package contexture is the Go API for embedding contexture. It loads project
+// configurations, resolves their rules, and renders assistant formats in
+// memory, without a terminal UI, prompts, or exiting the process, so other
+// tools can resolve rules without running the contexture binary.
+//
+//	client := contexture.New()
+//	cfg, err := client.LoadConfig("/path/to/project")
+//	if err != nil {
+//		return err
+//	}
+//	files, err := client.Render(ctx, cfg)
+//
+// Render never writes to the project; callers decide what to do with the files.
+package contexture
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+
+	"github.com/contextureai/contexture/internal/condition"
+	"github.com/contextureai/contexture/internal/domain"
+	contextureerrors "github.com/contextureai/contexture/internal/errors"
+	"github.com/contextureai/contexture/internal/format"
+	"github.com/contextureai/contexture/internal/git"
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/contextureai/contexture/internal/provider"
+	"github.com/contextureai/contexture/internal/rule"
+	"github.com/spf13/afero"
+)
+
+// Types shared with the contexture CLI
+type (
+	// Project is a contexture configuration
+	Project = domain.Project
+	// RuleRef is a rule entry in a configuration
+	RuleRef = domain.RuleRef
+	// Rule is a fetched rule
+	Rule = domain.Rule
+	// ProcessedRule is a rule with its templates rendered
+	ProcessedRule = domain.ProcessedRule
+	// FormatType identifies an assistant output format
+	FormatType = domain.FormatType
+)
+
+// Output formats
+const (
+	FormatClaude   = domain.FormatClaude
+	FormatCursor   = domain.FormatCursor
+	FormatWindsurf = domain.FormatWindsurf
+	FormatCopilot  = domain.FormatCopilot
+	FormatGemini   = domain.FormatGemini
+)
+
+// Config is a project configuration merged with the global configuration
+type Config struct {
+	// Dir is the project directory
+	Dir string
+	// Project is the project configuration, with the personal overrides of
+	// .contexture.local.yaml applied
+	Project *Project
+	// Global is the global configuration, or nil when there is none
+	Global *Project
+	// Rules are the project rules, including local rules
+	Rules []RuleRef
+	// GlobalRules are the global rules the project does not override
+	GlobalRules []RuleRef
+}
+
+// File is a rendered output file
+type File struct {
+	// Format is the format the file belongs to
+	Format FormatType
+	// Path is the file path relative to the project directory
+	Path string
+	// Content is the file content
+	Content []byte
+}
+
+// Client loads configurations and resolves rules
+type Client struct {
+	fs      afero.Fs
+	environ []string
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithFS makes the client read configurations and local rules from fs instead
+// of the operating system filesystem
+func WithFS(fs afero.Fs) Option {
+	return func(c *Client) {
+		c.fs = fs
+	}
+}
+
+// WithEnviron sets the environment, in the form "key=value", that rule when
+// expressions are evaluated against. It defaults to the process environment.
+func WithEnviron(environ []string) Option {
+	return func(c *Client) {
+		c.environ = slices.Clone(environ)
+	}
+}
+
+// New creates a client
+func New(opts ...Option) *Client {
+	c := &Client{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.fs == nil {
+		c.fs = afero.NewOsFs()
+	}
+	if c.environ == nil {
+		c.environ = os.Environ()
+	}
+	return c
+}
+
+// LoadConfig loads the configuration of the project in dir, merged with the
+// global configuration and the project's local rules
+func (c *Client) LoadConfig(dir string) (*Config, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "resolve project directory")
+	}
+
+	merged, err := project.NewManager(c.fs).LoadConfigMergedWithLocalRules(dir)
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "load configuration")
+	}
+
+	cfg := &Config{
+		Dir:     dir,
+		Project: merged.Project,
+		Global:  merged.GlobalConfig,
+	}
+	for _, rws := range merged.MergedRules {
+		if rws.Source == domain.RuleSourceUser {
+			cfg.GlobalRules = append(cfg.GlobalRules, rws.RuleRef)
+		} else {
+			cfg.Rules = append(cfg.Rules, rws.RuleRef)
+		}
+	}
+	return cfg, nil
+}
+
+// ResolveRules fetches, validates, and processes the project and global rules
+// of cfg whose when expressions hold, in deterministic order
+func (c *Client) ResolveRules(ctx context.Context, cfg *Config) ([]*ProcessedRule, error) {
+	return c.resolve(ctx, cfg, append(slices.Clone(cfg.Rules), cfg.GlobalRules...))
+}
+
+// Render renders the outputs of the given formats, or of every enabled format
+// when none are given, as the build command would write them. Global rules are
+// included for formats whose userRulesMode is "project"; user-level outputs,
+// such as ~/.claude/CLAUDE.md, are not rendered.
+func (c *Client) Render(ctx context.Context, cfg *Config, formats ...FormatType) ([]File, error) {
+	var targets []domain.FormatConfig
+	for _, formatConfig := range cfg.Project.GetEnabledFormats() {
+		if len(formats) == 0 || slices.Contains(formats, formatConfig.Type) {
+			targets = append(targets, formatConfig)
+		}
+	}
+	if len(targets) == 0 {
+		return nil, contextureerrors.ValidationErrorf("formats", "no target formats available")
+	}
+
+	projectRules, err := c.resolve(ctx, cfg, cfg.Rules)
+	if err != nil {
+		return nil, err
+	}
+	globalRules, err := c.resolve(ctx, cfg, cfg.GlobalRules)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []File
+	for _, formatConfig := range targets {
+		rules := projectRules
+		if formatConfig.GetEffectiveUserRulesMode() == domain.UserRulesProject {
+			rules = append(slices.Clone(projectRules), globalRules...)
+		}
+		formatConfig.BaseDir = cfg.Dir
+
+		// Formats read existing outputs and templates from the project, so
+		// they write to a layer over a read-only view of it
+		layer := afero.NewMemMapFs()
+		fs := &renderFs{Fs: afero.NewCopyOnWriteFs(afero.NewReadOnlyFs(c.fs), layer)}
+		if err := renderFormat(format.GetDefaultRegistry(fs), fs, rules, formatConfig, cfg.Project.MCPServers); err != nil {
+			return nil, contextureerrors.WithOp("render "+string(formatConfig.Type), err)
+		}
+
+		rendered, err := readFiles(layer)
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range slices.Sorted(maps.Keys(rendered)) {
+			rel, err := filepath.Rel(cfg.Dir, path)
+			if err != nil || strings.HasPrefix(rel, "..") {
+				continue
+			}
+			files = append(files, File{Format: formatConfig.Type, Path: rel, Content: rendered[path]})
+		}
+	}
+	return files, nil
+}
+
+// resolve fetches, validates, and processes refs whose when expressions hold
+func (c *Client) resolve(ctx context.Context, cfg *Config, refs []RuleRef) ([]*ProcessedRule, error) {
+	var conditionCtx *condition.Context
+	var applicable []RuleRef
+	for _, ref := range refs {
+		if ref.When != "" {
+			if conditionCtx == nil {
+				conditionCtx = condition.Detect(c.fs, cfg.Dir, c.environ)
+			}
+			matched, err := condition.Evaluate(ref.When, conditionCtx)
+			if err != nil {
+				return nil, contextureerrors.WithOp("evaluate when of "+ref.ID, err)
+			}
+			if !matched {
+				continue
+			}
+		}
+		applicable = append(applicable, ref)
+	}
+	if len(applicable) == 0 {
+		return nil, nil
+	}
+
+	registry := provider.NewRegistry()
+	for _, config := range []*Project{cfg.Global, cfg.Project} {
+		if config == nil {
+			continue
+		}
+		if err := registry.LoadFromProject(config); err != nil {
+			return nil, contextureerrors.Wrap(err, "load providers")
+		}
+	}
+
+	gitConfig := git.DefaultConfig(c.fs)
+	gitConfig.AllowedHosts = nil
+	fetcher := rule.NewFetcher(c.fs, git.NewClient(c.fs, gitConfig), rule.FetcherConfig{LocalDir: cfg.Dir}, registry)
+
+	rules, err := rule.FetchRulesParallel(ctx, fetcher, applicable, cfg.Project.GetGeneration().ParallelFetches)
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "fetch rules")
+	}
+	rules = rule.SortRulesDeterministically(rules, rule.NewRuleIDParser("", nil))
+
+	validator := rule.NewValidator()
+	processor := rule.NewProcessor()
+	processed := make([]*ProcessedRule, 0, len(rules))
+	var problems []string
+	for _, r := range rules {
+		result := validator.ValidateRule(r)
+		if !result.Valid {
+			messages := make([]string, 0, len(result.Errors))
+			for _, err := range result.Errors {
+				messages = append(messages, err.Error())
+			}
+			problems = append(problems, fmt.Sprintf("rule %s validation failed: %s", r.ID, strings.Join(messages, ", ")))
+			continue
+		}
+		processedRule, err := processor.ProcessRule(r, &domain.RuleContext{})
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("rule %s processing failed: %v", r.ID, err))
+			continue
+		}
+		processed = append(processed, processedRule)
+	}
+	if len(problems) > 0 {
+		return nil, contextureerrors.ValidationErrorf("rules", "processing errors: %v", problems)
+	}
+	return processed, nil
+}
+
+// renderFormat writes the output of one format to fs
+func renderFormat(
+	registry *format.Registry,
+	fs afero.Fs,
+	rules []*ProcessedRule,
+	formatConfig domain.FormatConfig,
+	mcpServers map[string]domain.MCPServer,
+) error {
+	f, err := registry.CreateFormat(formatConfig.Type, fs, nil)
+	if err != nil {
+		return err
+	}
+
+	var transformed []*domain.TransformedRule
+	for _, processedRule := range rules {
+		if !formatConfig.IncludesRule(processedRule.Rule.ID) {
+			continue
+		}
+		if processedRule.Rule.IsCommand() && !f.GetMetadata().SupportsCommands {
+			continue
+		}
+		t, err := f.Transform(processedRule)
+		if err != nil {
+			return contextureerrors.Wrap(err, "transform rule")
+		}
+		transformed = append(transformed, t)
+	}
+
+	if err := f.Write(transformed, &formatConfig); err != nil {
+		return contextureerrors.Wrap(err, "write format output")
+	}
+	if writer, ok := f.(domain.MCPConfigWriter); ok {
+		if err := writer.WriteMCPServers(mcpServers, &formatConfig); err != nil {
+			return contextureerrors.Wrap(err, "write mcp servers")
+		}
+	}
+	return nil
+}
+
+// renderFs is a copy-on-write filesystem whose removals of files in the
+// read-only base succeed without effect, so formats can clean up stale outputs
+type renderFs struct {
+	afero.Fs
+}
+
+// Remove removes name from the layer
+func (fs *renderFs) Remove(name string) error {
+	return ignoreBaseRemoval(fs.Fs.Remove(name))
+}
+
+// RemoveAll removes path from the layer
+func (fs *renderFs) RemoveAll(path string) error {
+	return ignoreBaseRemoval(fs.Fs.RemoveAll(path))
+}
+
+func ignoreBaseRemoval(err error) error {
+	if errors.Is(err, syscall.EPERM) {
+		return nil
+	}
+	return err
+}
+
+// readFiles returns the content of every file in fs by path
+func readFiles(fs afero.Fs) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	err := afero.Walk(fs, string(filepath.Separator), func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		content, err := afero.ReadFile(fs, path)
+		if err != nil {
+			return err
+		}
+		files[path] = content
+		return nil
+	})
+	if err != nil {
+		return nil, contextureerrors.Wrap(err, "read rendered files")
+	}
+	return files, nil
+}
@@ -0,0 +1,146 @@
+package contexture
+
+import (
+	"context"
+	"testing"
+
+	"github.com/contextureai/contexture/internal/project"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testConfig = `version: 1
+formats:
+  - type: claude
+    enabled: true
+  - type: cursor
+    enabled: true
+rules:
+  - id: "[contexture(local):/shared/docker]"
+    when: env.CI == "true"
+`
+
+const testRule = `---
+title: Go Style
+description: Go conventions
+tags: [go]
+---
+Use gofumpt.
+`
+
+func setupProject(t *testing.T) afero.Fs {
+	t.Helper()
+	t.Setenv(project.HomeEnv, "/nonexistent/contexture")
+
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/project/.contexture.yaml", []byte(testConfig), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "/project/rules/go-style.md", []byte(testRule), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "/shared/docker.md",
+		[]byte("---\ntitle: Docker\ndescription: Docker conventions\ntags: [docker]\n---\nPin base images.\n"), 0o644))
+	return fs
+}
+
+func TestLoadConfig(t *testing.T) {
+	client := New(WithFS(setupProject(t)))
+
+	cfg, err := client.LoadConfig("/project")
+	require.NoError(t, err)
+	assert.Equal(t, "/project", cfg.Dir)
+	assert.Len(t, cfg.Project.GetEnabledFormats(), 2)
+	assert.Empty(t, cfg.GlobalRules)
+
+	var ids []string
+	for _, ref := range cfg.Rules {
+		ids = append(ids, ref.ID)
+	}
+	assert.Contains(t, ids, "[contexture(local):/shared/docker]")
+}
+
+func TestLoadConfig_Missing(t *testing.T) {
+	t.Setenv(project.HomeEnv, "/nonexistent/contexture")
+	client := New(WithFS(afero.NewMemMapFs()))
+
+	_, err := client.LoadConfig("/project")
+	require.Error(t, err)
+}
+
+func TestResolveRules(t *testing.T) {
+	fs := setupProject(t)
+	client := New(WithFS(fs), WithEnviron([]string{}))
+	cfg, err := client.LoadConfig("/project")
+	require.NoError(t, err)
+
+	rules, err := client.ResolveRules(context.Background(), cfg)
+	require.NoError(t, err)
+	require.Len(t, rules, 1, "the docker rule's when expression does not hold")
+	assert.Equal(t, "Go Style", rules[0].Rule.Title)
+
+	client = New(WithFS(fs), WithEnviron([]string{"CI=true"}))
+	rules, err = client.ResolveRules(context.Background(), cfg)
+	require.NoError(t, err)
+	assert.Len(t, rules, 2)
+}
+
+func TestRender(t *testing.T) {
+	fs := setupProject(t)
+	client := New(WithFS(fs), WithEnviron([]string{}))
+	cfg, err := client.LoadConfig("/project")
+	require.NoError(t, err)
+
+	files, err := client.Render(context.Background(), cfg)
+	require.NoError(t, err)
+
+	byPath := map[string]File{}
+	for _, file := range files {
+		byPath[file.Path] = file
+	}
+	require.Contains(t, byPath, "CLAUDE.md")
+	assert.Equal(t, FormatClaude, byPath["CLAUDE.md"].Format)
+	assert.Contains(t, string(byPath["CLAUDE.md"].Content), "Use gofumpt.")
+	assert.NotContains(t, string(byPath["CLAUDE.md"].Content), "Pin base images.")
+
+	var cursorFiles int
+	for _, file := range files {
+		if file.Format == FormatCursor {
+			cursorFiles++
+		}
+	}
+	assert.Equal(t, 1, cursorFiles)
+
+	// Nothing is written to the project
+	exists, err := afero.Exists(fs, "/project/CLAUDE.md")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestRender_SelectedFormats(t *testing.T) {
+	client := New(WithFS(setupProject(t)))
+	cfg, err := client.LoadConfig("/project")
+	require.NoError(t, err)
+
+	files, err := client.Render(context.Background(), cfg, FormatCursor)
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+	for _, file := range files {
+		assert.Equal(t, FormatCursor, file.Format)
+	}
+
+	_, err = client.Render(context.Background(), cfg, FormatWindsurf)
+	require.Error(t, err)
+}
+
+func TestRender_KeepsExistingOutputsUntouched(t *testing.T) {
+	fs := setupProject(t)
+	require.NoError(t, afero.WriteFile(fs, "/project/.cursor/rules/stale.mdc", []byte("old"), 0o644))
+	client := New(WithFS(fs))
+	cfg, err := client.LoadConfig("/project")
+	require.NoError(t, err)
+
+	_, err = client.Render(context.Background(), cfg, FormatCursor)
+	require.NoError(t, err)
+
+	content, err := afero.ReadFile(fs, "/project/.cursor/rules/stale.mdc")
+	require.NoError(t, err)
+	assert.Equal(t, "old", string(content))
+}